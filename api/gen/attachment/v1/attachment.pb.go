@@ -0,0 +1,763 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.10
+// 	protoc        v6.33.1
+// source: attachment/v1/attachment.proto
+
+package attachmentv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Attachment is a file attached to a project. Its contents live in blob
+// storage and are read with DownloadAttachment.
+type Attachment struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Id        int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	ProjectId int32                  `protobuf:"varint,2,opt,name=project_id,json=projectId,proto3" json:"project_id,omitempty"`
+	Filename  string                 `protobuf:"bytes,3,opt,name=filename,proto3" json:"filename,omitempty"`
+	// Content type sniffed from the contents when uploaded
+	ContentType string `protobuf:"bytes,4,opt,name=content_type,json=contentType,proto3" json:"content_type,omitempty"`
+	Size        int64  `protobuf:"varint,5,opt,name=size,proto3" json:"size,omitempty"`
+	// Hex encoded SHA-256 of the contents
+	Sha256    string                 `protobuf:"bytes,6,opt,name=sha256,proto3" json:"sha256,omitempty"`
+	CreatedAt *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	// Caller named in the x-actor metadata of the upload
+	UploadedBy    string `protobuf:"bytes,8,opt,name=uploaded_by,json=uploadedBy,proto3" json:"uploaded_by,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Attachment) Reset() {
+	*x = Attachment{}
+	mi := &file_attachment_v1_attachment_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Attachment) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Attachment) ProtoMessage() {}
+
+func (x *Attachment) ProtoReflect() protoreflect.Message {
+	mi := &file_attachment_v1_attachment_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Attachment.ProtoReflect.Descriptor instead.
+func (*Attachment) Descriptor() ([]byte, []int) {
+	return file_attachment_v1_attachment_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Attachment) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Attachment) GetProjectId() int32 {
+	if x != nil {
+		return x.ProjectId
+	}
+	return 0
+}
+
+func (x *Attachment) GetFilename() string {
+	if x != nil {
+		return x.Filename
+	}
+	return ""
+}
+
+func (x *Attachment) GetContentType() string {
+	if x != nil {
+		return x.ContentType
+	}
+	return ""
+}
+
+func (x *Attachment) GetSize() int64 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+func (x *Attachment) GetSha256() string {
+	if x != nil {
+		return x.Sha256
+	}
+	return ""
+}
+
+func (x *Attachment) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Attachment) GetUploadedBy() string {
+	if x != nil {
+		return x.UploadedBy
+	}
+	return ""
+}
+
+// UploadMetadata describes the file that follows it on the upload stream
+type UploadMetadata struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	ProjectId int32                  `protobuf:"varint,1,opt,name=project_id,json=projectId,proto3" json:"project_id,omitempty"`
+	Filename  string                 `protobuf:"bytes,2,opt,name=filename,proto3" json:"filename,omitempty"`
+	// Expected size in bytes; the upload fails if a different amount arrives
+	Size int64 `protobuf:"varint,3,opt,name=size,proto3" json:"size,omitempty"`
+	// Expected hex encoded SHA-256 of the contents; the upload fails on a mismatch
+	Sha256        string `protobuf:"bytes,4,opt,name=sha256,proto3" json:"sha256,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UploadMetadata) Reset() {
+	*x = UploadMetadata{}
+	mi := &file_attachment_v1_attachment_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UploadMetadata) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UploadMetadata) ProtoMessage() {}
+
+func (x *UploadMetadata) ProtoReflect() protoreflect.Message {
+	mi := &file_attachment_v1_attachment_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UploadMetadata.ProtoReflect.Descriptor instead.
+func (*UploadMetadata) Descriptor() ([]byte, []int) {
+	return file_attachment_v1_attachment_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *UploadMetadata) GetProjectId() int32 {
+	if x != nil {
+		return x.ProjectId
+	}
+	return 0
+}
+
+func (x *UploadMetadata) GetFilename() string {
+	if x != nil {
+		return x.Filename
+	}
+	return ""
+}
+
+func (x *UploadMetadata) GetSize() int64 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+func (x *UploadMetadata) GetSha256() string {
+	if x != nil {
+		return x.Sha256
+	}
+	return ""
+}
+
+// UploadAttachmentRequest is one message of the upload stream. The first
+// message carries the metadata, every following one a chunk of the contents.
+type UploadAttachmentRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Payload:
+	//
+	//	*UploadAttachmentRequest_Metadata
+	//	*UploadAttachmentRequest_Chunk
+	Payload       isUploadAttachmentRequest_Payload `protobuf_oneof:"payload"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UploadAttachmentRequest) Reset() {
+	*x = UploadAttachmentRequest{}
+	mi := &file_attachment_v1_attachment_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UploadAttachmentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UploadAttachmentRequest) ProtoMessage() {}
+
+func (x *UploadAttachmentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_attachment_v1_attachment_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UploadAttachmentRequest.ProtoReflect.Descriptor instead.
+func (*UploadAttachmentRequest) Descriptor() ([]byte, []int) {
+	return file_attachment_v1_attachment_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *UploadAttachmentRequest) GetPayload() isUploadAttachmentRequest_Payload {
+	if x != nil {
+		return x.Payload
+	}
+	return nil
+}
+
+func (x *UploadAttachmentRequest) GetMetadata() *UploadMetadata {
+	if x != nil {
+		if x, ok := x.Payload.(*UploadAttachmentRequest_Metadata); ok {
+			return x.Metadata
+		}
+	}
+	return nil
+}
+
+func (x *UploadAttachmentRequest) GetChunk() []byte {
+	if x != nil {
+		if x, ok := x.Payload.(*UploadAttachmentRequest_Chunk); ok {
+			return x.Chunk
+		}
+	}
+	return nil
+}
+
+type isUploadAttachmentRequest_Payload interface {
+	isUploadAttachmentRequest_Payload()
+}
+
+type UploadAttachmentRequest_Metadata struct {
+	Metadata *UploadMetadata `protobuf:"bytes,1,opt,name=metadata,proto3,oneof"`
+}
+
+type UploadAttachmentRequest_Chunk struct {
+	Chunk []byte `protobuf:"bytes,2,opt,name=chunk,proto3,oneof"`
+}
+
+func (*UploadAttachmentRequest_Metadata) isUploadAttachmentRequest_Payload() {}
+
+func (*UploadAttachmentRequest_Chunk) isUploadAttachmentRequest_Payload() {}
+
+// UploadAttachmentResponse is the response message for UploadAttachment RPC
+type UploadAttachmentResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Attachment    *Attachment            `protobuf:"bytes,1,opt,name=attachment,proto3" json:"attachment,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UploadAttachmentResponse) Reset() {
+	*x = UploadAttachmentResponse{}
+	mi := &file_attachment_v1_attachment_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UploadAttachmentResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UploadAttachmentResponse) ProtoMessage() {}
+
+func (x *UploadAttachmentResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_attachment_v1_attachment_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UploadAttachmentResponse.ProtoReflect.Descriptor instead.
+func (*UploadAttachmentResponse) Descriptor() ([]byte, []int) {
+	return file_attachment_v1_attachment_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *UploadAttachmentResponse) GetAttachment() *Attachment {
+	if x != nil {
+		return x.Attachment
+	}
+	return nil
+}
+
+// DownloadAttachmentRequest is the request message for DownloadAttachment RPC
+type DownloadAttachmentRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DownloadAttachmentRequest) Reset() {
+	*x = DownloadAttachmentRequest{}
+	mi := &file_attachment_v1_attachment_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DownloadAttachmentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DownloadAttachmentRequest) ProtoMessage() {}
+
+func (x *DownloadAttachmentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_attachment_v1_attachment_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DownloadAttachmentRequest.ProtoReflect.Descriptor instead.
+func (*DownloadAttachmentRequest) Descriptor() ([]byte, []int) {
+	return file_attachment_v1_attachment_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *DownloadAttachmentRequest) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+// DownloadAttachmentResponse is one message of the download stream. The
+// first message carries the attachment, every following one a chunk of the
+// contents.
+type DownloadAttachmentResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Payload:
+	//
+	//	*DownloadAttachmentResponse_Attachment
+	//	*DownloadAttachmentResponse_Chunk
+	Payload       isDownloadAttachmentResponse_Payload `protobuf_oneof:"payload"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DownloadAttachmentResponse) Reset() {
+	*x = DownloadAttachmentResponse{}
+	mi := &file_attachment_v1_attachment_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DownloadAttachmentResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DownloadAttachmentResponse) ProtoMessage() {}
+
+func (x *DownloadAttachmentResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_attachment_v1_attachment_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DownloadAttachmentResponse.ProtoReflect.Descriptor instead.
+func (*DownloadAttachmentResponse) Descriptor() ([]byte, []int) {
+	return file_attachment_v1_attachment_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *DownloadAttachmentResponse) GetPayload() isDownloadAttachmentResponse_Payload {
+	if x != nil {
+		return x.Payload
+	}
+	return nil
+}
+
+func (x *DownloadAttachmentResponse) GetAttachment() *Attachment {
+	if x != nil {
+		if x, ok := x.Payload.(*DownloadAttachmentResponse_Attachment); ok {
+			return x.Attachment
+		}
+	}
+	return nil
+}
+
+func (x *DownloadAttachmentResponse) GetChunk() []byte {
+	if x != nil {
+		if x, ok := x.Payload.(*DownloadAttachmentResponse_Chunk); ok {
+			return x.Chunk
+		}
+	}
+	return nil
+}
+
+type isDownloadAttachmentResponse_Payload interface {
+	isDownloadAttachmentResponse_Payload()
+}
+
+type DownloadAttachmentResponse_Attachment struct {
+	Attachment *Attachment `protobuf:"bytes,1,opt,name=attachment,proto3,oneof"`
+}
+
+type DownloadAttachmentResponse_Chunk struct {
+	Chunk []byte `protobuf:"bytes,2,opt,name=chunk,proto3,oneof"`
+}
+
+func (*DownloadAttachmentResponse_Attachment) isDownloadAttachmentResponse_Payload() {}
+
+func (*DownloadAttachmentResponse_Chunk) isDownloadAttachmentResponse_Payload() {}
+
+// ListAttachmentsRequest is the request message for ListAttachments RPC
+type ListAttachmentsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ProjectId     int32                  `protobuf:"varint,1,opt,name=project_id,json=projectId,proto3" json:"project_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListAttachmentsRequest) Reset() {
+	*x = ListAttachmentsRequest{}
+	mi := &file_attachment_v1_attachment_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAttachmentsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAttachmentsRequest) ProtoMessage() {}
+
+func (x *ListAttachmentsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_attachment_v1_attachment_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAttachmentsRequest.ProtoReflect.Descriptor instead.
+func (*ListAttachmentsRequest) Descriptor() ([]byte, []int) {
+	return file_attachment_v1_attachment_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ListAttachmentsRequest) GetProjectId() int32 {
+	if x != nil {
+		return x.ProjectId
+	}
+	return 0
+}
+
+// ListAttachmentsResponse lists a project's attachments, newest first
+type ListAttachmentsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Attachments   []*Attachment          `protobuf:"bytes,1,rep,name=attachments,proto3" json:"attachments,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListAttachmentsResponse) Reset() {
+	*x = ListAttachmentsResponse{}
+	mi := &file_attachment_v1_attachment_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAttachmentsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAttachmentsResponse) ProtoMessage() {}
+
+func (x *ListAttachmentsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_attachment_v1_attachment_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAttachmentsResponse.ProtoReflect.Descriptor instead.
+func (*ListAttachmentsResponse) Descriptor() ([]byte, []int) {
+	return file_attachment_v1_attachment_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *ListAttachmentsResponse) GetAttachments() []*Attachment {
+	if x != nil {
+		return x.Attachments
+	}
+	return nil
+}
+
+// DeleteAttachmentRequest is the request message for DeleteAttachment RPC
+type DeleteAttachmentRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteAttachmentRequest) Reset() {
+	*x = DeleteAttachmentRequest{}
+	mi := &file_attachment_v1_attachment_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteAttachmentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteAttachmentRequest) ProtoMessage() {}
+
+func (x *DeleteAttachmentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_attachment_v1_attachment_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteAttachmentRequest.ProtoReflect.Descriptor instead.
+func (*DeleteAttachmentRequest) Descriptor() ([]byte, []int) {
+	return file_attachment_v1_attachment_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *DeleteAttachmentRequest) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+// DeleteAttachmentResponse is the response message for DeleteAttachment RPC
+type DeleteAttachmentResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteAttachmentResponse) Reset() {
+	*x = DeleteAttachmentResponse{}
+	mi := &file_attachment_v1_attachment_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteAttachmentResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteAttachmentResponse) ProtoMessage() {}
+
+func (x *DeleteAttachmentResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_attachment_v1_attachment_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteAttachmentResponse.ProtoReflect.Descriptor instead.
+func (*DeleteAttachmentResponse) Descriptor() ([]byte, []int) {
+	return file_attachment_v1_attachment_proto_rawDescGZIP(), []int{9}
+}
+
+var File_attachment_v1_attachment_proto protoreflect.FileDescriptor
+
+const file_attachment_v1_attachment_proto_rawDesc = "" +
+	"\n" +
+	"\x1eattachment/v1/attachment.proto\x12\rattachment.v1\x1a\x1fgoogle/protobuf/timestamp.proto\"\x82\x02\n" +
+	"\n" +
+	"Attachment\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x05R\x02id\x12\x1d\n" +
+	"\n" +
+	"project_id\x18\x02 \x01(\x05R\tprojectId\x12\x1a\n" +
+	"\bfilename\x18\x03 \x01(\tR\bfilename\x12!\n" +
+	"\fcontent_type\x18\x04 \x01(\tR\vcontentType\x12\x12\n" +
+	"\x04size\x18\x05 \x01(\x03R\x04size\x12\x16\n" +
+	"\x06sha256\x18\x06 \x01(\tR\x06sha256\x129\n" +
+	"\n" +
+	"created_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x12\x1f\n" +
+	"\vuploaded_by\x18\b \x01(\tR\n" +
+	"uploadedBy\"w\n" +
+	"\x0eUploadMetadata\x12\x1d\n" +
+	"\n" +
+	"project_id\x18\x01 \x01(\x05R\tprojectId\x12\x1a\n" +
+	"\bfilename\x18\x02 \x01(\tR\bfilename\x12\x12\n" +
+	"\x04size\x18\x03 \x01(\x03R\x04size\x12\x16\n" +
+	"\x06sha256\x18\x04 \x01(\tR\x06sha256\"y\n" +
+	"\x17UploadAttachmentRequest\x12;\n" +
+	"\bmetadata\x18\x01 \x01(\v2\x1d.attachment.v1.UploadMetadataH\x00R\bmetadata\x12\x16\n" +
+	"\x05chunk\x18\x02 \x01(\fH\x00R\x05chunkB\t\n" +
+	"\apayload\"U\n" +
+	"\x18UploadAttachmentResponse\x129\n" +
+	"\n" +
+	"attachment\x18\x01 \x01(\v2\x19.attachment.v1.AttachmentR\n" +
+	"attachment\"+\n" +
+	"\x19DownloadAttachmentRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x05R\x02id\"|\n" +
+	"\x1aDownloadAttachmentResponse\x12;\n" +
+	"\n" +
+	"attachment\x18\x01 \x01(\v2\x19.attachment.v1.AttachmentH\x00R\n" +
+	"attachment\x12\x16\n" +
+	"\x05chunk\x18\x02 \x01(\fH\x00R\x05chunkB\t\n" +
+	"\apayload\"7\n" +
+	"\x16ListAttachmentsRequest\x12\x1d\n" +
+	"\n" +
+	"project_id\x18\x01 \x01(\x05R\tprojectId\"V\n" +
+	"\x17ListAttachmentsResponse\x12;\n" +
+	"\vattachments\x18\x01 \x03(\v2\x19.attachment.v1.AttachmentR\vattachments\")\n" +
+	"\x17DeleteAttachmentRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x05R\x02id\"\x1a\n" +
+	"\x18DeleteAttachmentResponse2\xae\x03\n" +
+	"\x11AttachmentService\x12e\n" +
+	"\x10UploadAttachment\x12&.attachment.v1.UploadAttachmentRequest\x1a'.attachment.v1.UploadAttachmentResponse(\x01\x12k\n" +
+	"\x12DownloadAttachment\x12(.attachment.v1.DownloadAttachmentRequest\x1a).attachment.v1.DownloadAttachmentResponse0\x01\x12`\n" +
+	"\x0fListAttachments\x12%.attachment.v1.ListAttachmentsRequest\x1a&.attachment.v1.ListAttachmentsResponse\x12c\n" +
+	"\x10DeleteAttachment\x12&.attachment.v1.DeleteAttachmentRequest\x1a'.attachment.v1.DeleteAttachmentResponseB)Z'grud/api/gen/attachment/v1;attachmentv1b\x06proto3"
+
+var (
+	file_attachment_v1_attachment_proto_rawDescOnce sync.Once
+	file_attachment_v1_attachment_proto_rawDescData []byte
+)
+
+func file_attachment_v1_attachment_proto_rawDescGZIP() []byte {
+	file_attachment_v1_attachment_proto_rawDescOnce.Do(func() {
+		file_attachment_v1_attachment_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_attachment_v1_attachment_proto_rawDesc), len(file_attachment_v1_attachment_proto_rawDesc)))
+	})
+	return file_attachment_v1_attachment_proto_rawDescData
+}
+
+var file_attachment_v1_attachment_proto_msgTypes = make([]protoimpl.MessageInfo, 10)
+var file_attachment_v1_attachment_proto_goTypes = []any{
+	(*Attachment)(nil),                 // 0: attachment.v1.Attachment
+	(*UploadMetadata)(nil),             // 1: attachment.v1.UploadMetadata
+	(*UploadAttachmentRequest)(nil),    // 2: attachment.v1.UploadAttachmentRequest
+	(*UploadAttachmentResponse)(nil),   // 3: attachment.v1.UploadAttachmentResponse
+	(*DownloadAttachmentRequest)(nil),  // 4: attachment.v1.DownloadAttachmentRequest
+	(*DownloadAttachmentResponse)(nil), // 5: attachment.v1.DownloadAttachmentResponse
+	(*ListAttachmentsRequest)(nil),     // 6: attachment.v1.ListAttachmentsRequest
+	(*ListAttachmentsResponse)(nil),    // 7: attachment.v1.ListAttachmentsResponse
+	(*DeleteAttachmentRequest)(nil),    // 8: attachment.v1.DeleteAttachmentRequest
+	(*DeleteAttachmentResponse)(nil),   // 9: attachment.v1.DeleteAttachmentResponse
+	(*timestamppb.Timestamp)(nil),      // 10: google.protobuf.Timestamp
+}
+var file_attachment_v1_attachment_proto_depIdxs = []int32{
+	10, // 0: attachment.v1.Attachment.created_at:type_name -> google.protobuf.Timestamp
+	1,  // 1: attachment.v1.UploadAttachmentRequest.metadata:type_name -> attachment.v1.UploadMetadata
+	0,  // 2: attachment.v1.UploadAttachmentResponse.attachment:type_name -> attachment.v1.Attachment
+	0,  // 3: attachment.v1.DownloadAttachmentResponse.attachment:type_name -> attachment.v1.Attachment
+	0,  // 4: attachment.v1.ListAttachmentsResponse.attachments:type_name -> attachment.v1.Attachment
+	2,  // 5: attachment.v1.AttachmentService.UploadAttachment:input_type -> attachment.v1.UploadAttachmentRequest
+	4,  // 6: attachment.v1.AttachmentService.DownloadAttachment:input_type -> attachment.v1.DownloadAttachmentRequest
+	6,  // 7: attachment.v1.AttachmentService.ListAttachments:input_type -> attachment.v1.ListAttachmentsRequest
+	8,  // 8: attachment.v1.AttachmentService.DeleteAttachment:input_type -> attachment.v1.DeleteAttachmentRequest
+	3,  // 9: attachment.v1.AttachmentService.UploadAttachment:output_type -> attachment.v1.UploadAttachmentResponse
+	5,  // 10: attachment.v1.AttachmentService.DownloadAttachment:output_type -> attachment.v1.DownloadAttachmentResponse
+	7,  // 11: attachment.v1.AttachmentService.ListAttachments:output_type -> attachment.v1.ListAttachmentsResponse
+	9,  // 12: attachment.v1.AttachmentService.DeleteAttachment:output_type -> attachment.v1.DeleteAttachmentResponse
+	9,  // [9:13] is the sub-list for method output_type
+	5,  // [5:9] is the sub-list for method input_type
+	5,  // [5:5] is the sub-list for extension type_name
+	5,  // [5:5] is the sub-list for extension extendee
+	0,  // [0:5] is the sub-list for field type_name
+}
+
+func init() { file_attachment_v1_attachment_proto_init() }
+func file_attachment_v1_attachment_proto_init() {
+	if File_attachment_v1_attachment_proto != nil {
+		return
+	}
+	file_attachment_v1_attachment_proto_msgTypes[2].OneofWrappers = []any{
+		(*UploadAttachmentRequest_Metadata)(nil),
+		(*UploadAttachmentRequest_Chunk)(nil),
+	}
+	file_attachment_v1_attachment_proto_msgTypes[5].OneofWrappers = []any{
+		(*DownloadAttachmentResponse_Attachment)(nil),
+		(*DownloadAttachmentResponse_Chunk)(nil),
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_attachment_v1_attachment_proto_rawDesc), len(file_attachment_v1_attachment_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   10,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_attachment_v1_attachment_proto_goTypes,
+		DependencyIndexes: file_attachment_v1_attachment_proto_depIdxs,
+		MessageInfos:      file_attachment_v1_attachment_proto_msgTypes,
+	}.Build()
+	File_attachment_v1_attachment_proto = out.File
+	file_attachment_v1_attachment_proto_goTypes = nil
+	file_attachment_v1_attachment_proto_depIdxs = nil
+}
@@ -24,11 +24,25 @@ const (
 
 // Message represents a message entity
 type Message struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
-	Email         string                 `protobuf:"bytes,2,opt,name=email,proto3" json:"email,omitempty"`
-	Message       string                 `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`
-	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Id        int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Email     string                 `protobuf:"bytes,2,opt,name=email,proto3" json:"email,omitempty"`
+	Message   string                 `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`
+	CreatedAt *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	// Team the message was posted to, 0 if none
+	TeamId int32 `protobuf:"varint,5,opt,name=team_id,json=teamId,proto3" json:"team_id,omitempty"`
+	// Conversation the message belongs to, 0 if none
+	ConversationId int32 `protobuf:"varint,6,opt,name=conversation_id,json=conversationId,proto3" json:"conversation_id,omitempty"`
+	// Message this one replies to, 0 if it starts a thread
+	ParentId int32 `protobuf:"varint,7,opt,name=parent_id,json=parentId,proto3" json:"parent_id,omitempty"`
+	// First message of the thread, 0 if this message is the first
+	ThreadRootId int32 `protobuf:"varint,8,opt,name=thread_root_id,json=threadRootId,proto3" json:"thread_root_id,omitempty"`
+	// Whether the text changed after the message was sent, and when it last did
+	Edited   bool                   `protobuf:"varint,9,opt,name=edited,proto3" json:"edited,omitempty"`
+	EditedAt *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=edited_at,json=editedAt,proto3" json:"edited_at,omitempty"`
+	// A deleted message is a tombstone without text
+	Deleted       bool                   `protobuf:"varint,11,opt,name=deleted,proto3" json:"deleted,omitempty"`
+	DeletedAt     *timestamppb.Timestamp `protobuf:"bytes,12,opt,name=deleted_at,json=deletedAt,proto3" json:"deleted_at,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -91,17 +105,173 @@ func (x *Message) GetCreatedAt() *timestamppb.Timestamp {
 	return nil
 }
 
-// GetMessagesByEmailRequest is the request message for GetMessagesByEmail RPC
+func (x *Message) GetTeamId() int32 {
+	if x != nil {
+		return x.TeamId
+	}
+	return 0
+}
+
+func (x *Message) GetConversationId() int32 {
+	if x != nil {
+		return x.ConversationId
+	}
+	return 0
+}
+
+func (x *Message) GetParentId() int32 {
+	if x != nil {
+		return x.ParentId
+	}
+	return 0
+}
+
+func (x *Message) GetThreadRootId() int32 {
+	if x != nil {
+		return x.ThreadRootId
+	}
+	return 0
+}
+
+func (x *Message) GetEdited() bool {
+	if x != nil {
+		return x.Edited
+	}
+	return false
+}
+
+func (x *Message) GetEditedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.EditedAt
+	}
+	return nil
+}
+
+func (x *Message) GetDeleted() bool {
+	if x != nil {
+		return x.Deleted
+	}
+	return false
+}
+
+func (x *Message) GetDeletedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.DeletedAt
+	}
+	return nil
+}
+
+// Conversation groups messages. It is scoped to a project, or direct
+// between two students when project_id is 0.
+type Conversation struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Id        int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	ProjectId int32                  `protobuf:"varint,2,opt,name=project_id,json=projectId,proto3" json:"project_id,omitempty"`
+	Subject   string                 `protobuf:"bytes,3,opt,name=subject,proto3" json:"subject,omitempty"`
+	// Email of the student who started the conversation
+	CreatedBy string `protobuf:"bytes,4,opt,name=created_by,json=createdBy,proto3" json:"created_by,omitempty"`
+	// Emails of the participants, sorted. For a direct conversation these are
+	// the two students; for a project conversation, everyone who has posted.
+	Participants  []string               `protobuf:"bytes,5,rep,name=participants,proto3" json:"participants,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	LastMessageAt *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=last_message_at,json=lastMessageAt,proto3" json:"last_message_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Conversation) Reset() {
+	*x = Conversation{}
+	mi := &file_message_v1_message_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Conversation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Conversation) ProtoMessage() {}
+
+func (x *Conversation) ProtoReflect() protoreflect.Message {
+	mi := &file_message_v1_message_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Conversation.ProtoReflect.Descriptor instead.
+func (*Conversation) Descriptor() ([]byte, []int) {
+	return file_message_v1_message_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Conversation) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Conversation) GetProjectId() int32 {
+	if x != nil {
+		return x.ProjectId
+	}
+	return 0
+}
+
+func (x *Conversation) GetSubject() string {
+	if x != nil {
+		return x.Subject
+	}
+	return ""
+}
+
+func (x *Conversation) GetCreatedBy() string {
+	if x != nil {
+		return x.CreatedBy
+	}
+	return ""
+}
+
+func (x *Conversation) GetParticipants() []string {
+	if x != nil {
+		return x.Participants
+	}
+	return nil
+}
+
+func (x *Conversation) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Conversation) GetLastMessageAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastMessageAt
+	}
+	return nil
+}
+
+// GetMessagesByEmailRequest is the request message for GetMessagesByEmail RPC.
+// At least one of email and team_id is required.
 type GetMessagesByEmailRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Email         string                 `protobuf:"bytes,1,opt,name=email,proto3" json:"email,omitempty"`
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Email string                 `protobuf:"bytes,1,opt,name=email,proto3" json:"email,omitempty"`
+	// Only messages posted to this team when set
+	TeamId        int32 `protobuf:"varint,2,opt,name=team_id,json=teamId,proto3" json:"team_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *GetMessagesByEmailRequest) Reset() {
 	*x = GetMessagesByEmailRequest{}
-	mi := &file_message_v1_message_proto_msgTypes[1]
+	mi := &file_message_v1_message_proto_msgTypes[2]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -113,7 +283,7 @@ func (x *GetMessagesByEmailRequest) String() string {
 func (*GetMessagesByEmailRequest) ProtoMessage() {}
 
 func (x *GetMessagesByEmailRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_message_v1_message_proto_msgTypes[1]
+	mi := &file_message_v1_message_proto_msgTypes[2]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -126,7 +296,7 @@ func (x *GetMessagesByEmailRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetMessagesByEmailRequest.ProtoReflect.Descriptor instead.
 func (*GetMessagesByEmailRequest) Descriptor() ([]byte, []int) {
-	return file_message_v1_message_proto_rawDescGZIP(), []int{1}
+	return file_message_v1_message_proto_rawDescGZIP(), []int{2}
 }
 
 func (x *GetMessagesByEmailRequest) GetEmail() string {
@@ -136,6 +306,13 @@ func (x *GetMessagesByEmailRequest) GetEmail() string {
 	return ""
 }
 
+func (x *GetMessagesByEmailRequest) GetTeamId() int32 {
+	if x != nil {
+		return x.TeamId
+	}
+	return 0
+}
+
 // GetMessagesByEmailResponse is the response message for GetMessagesByEmail RPC
 type GetMessagesByEmailResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -146,7 +323,7 @@ type GetMessagesByEmailResponse struct {
 
 func (x *GetMessagesByEmailResponse) Reset() {
 	*x = GetMessagesByEmailResponse{}
-	mi := &file_message_v1_message_proto_msgTypes[2]
+	mi := &file_message_v1_message_proto_msgTypes[3]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -158,7 +335,7 @@ func (x *GetMessagesByEmailResponse) String() string {
 func (*GetMessagesByEmailResponse) ProtoMessage() {}
 
 func (x *GetMessagesByEmailResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_message_v1_message_proto_msgTypes[2]
+	mi := &file_message_v1_message_proto_msgTypes[3]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -171,7 +348,7 @@ func (x *GetMessagesByEmailResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetMessagesByEmailResponse.ProtoReflect.Descriptor instead.
 func (*GetMessagesByEmailResponse) Descriptor() ([]byte, []int) {
-	return file_message_v1_message_proto_rawDescGZIP(), []int{2}
+	return file_message_v1_message_proto_rawDescGZIP(), []int{3}
 }
 
 func (x *GetMessagesByEmailResponse) GetMessages() []*Message {
@@ -181,24 +358,1537 @@ func (x *GetMessagesByEmailResponse) GetMessages() []*Message {
 	return nil
 }
 
-var File_message_v1_message_proto protoreflect.FileDescriptor
+// ListMessagesRequest is the request message for ListMessages RPC. Unset
+// filters do not apply.
+type ListMessagesRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Maximum number of messages to return. Defaults to 50; values above 500 are coerced to 500.
+	PageSize int32 `protobuf:"varint,1,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	// next_page_token from a previous response. All other fields must match the
+	// request that produced the token.
+	PageToken string `protobuf:"bytes,2,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	// Only messages sent by this email
+	Sender string `protobuf:"bytes,3,opt,name=sender,proto3" json:"sender,omitempty"`
+	// Only messages sent to this email by others, through a conversation it
+	// participates in
+	Recipient string `protobuf:"bytes,4,opt,name=recipient,proto3" json:"recipient,omitempty"`
+	// Only messages posted to a team or a conversation of this project
+	ProjectId int32 `protobuf:"varint,5,opt,name=project_id,json=projectId,proto3" json:"project_id,omitempty"`
+	// Only messages posted to this team
+	TeamId int32 `protobuf:"varint,6,opt,name=team_id,json=teamId,proto3" json:"team_id,omitempty"`
+	// Case-insensitive substring the message text must contain
+	TextContains string `protobuf:"bytes,7,opt,name=text_contains,json=textContains,proto3" json:"text_contains,omitempty"`
+	// Inclusive lower and exclusive upper bounds on created_at
+	CreatedAfter  *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=created_after,json=createdAfter,proto3" json:"created_after,omitempty"`
+	CreatedBefore *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=created_before,json=createdBefore,proto3" json:"created_before,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
 
-const file_message_v1_message_proto_rawDesc = "" +
-	"\n" +
-	"\x18message/v1/message.proto\x12\n" +
-	"message.v1\x1a\x1fgoogle/protobuf/timestamp.proto\"\x84\x01\n" +
-	"\aMessage\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\x05R\x02id\x12\x14\n" +
-	"\x05email\x18\x02 \x01(\tR\x05email\x12\x18\n" +
-	"\amessage\x18\x03 \x01(\tR\amessage\x129\n" +
-	"\n" +
-	"created_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"1\n" +
-	"\x19GetMessagesByEmailRequest\x12\x14\n" +
-	"\x05email\x18\x01 \x01(\tR\x05email\"M\n" +
-	"\x1aGetMessagesByEmailResponse\x12/\n" +
-	"\bmessages\x18\x01 \x03(\v2\x13.message.v1.MessageR\bmessages2u\n" +
-	"\x0eMessageService\x12c\n" +
-	"\x12GetMessagesByEmail\x12%.message.v1.GetMessagesByEmailRequest\x1a&.message.v1.GetMessagesByEmailResponseB#Z!grud/api/gen/message/v1;messagev1b\x06proto3"
+func (x *ListMessagesRequest) Reset() {
+	*x = ListMessagesRequest{}
+	mi := &file_message_v1_message_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListMessagesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListMessagesRequest) ProtoMessage() {}
+
+func (x *ListMessagesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_message_v1_message_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListMessagesRequest.ProtoReflect.Descriptor instead.
+func (*ListMessagesRequest) Descriptor() ([]byte, []int) {
+	return file_message_v1_message_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ListMessagesRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListMessagesRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+func (x *ListMessagesRequest) GetSender() string {
+	if x != nil {
+		return x.Sender
+	}
+	return ""
+}
+
+func (x *ListMessagesRequest) GetRecipient() string {
+	if x != nil {
+		return x.Recipient
+	}
+	return ""
+}
+
+func (x *ListMessagesRequest) GetProjectId() int32 {
+	if x != nil {
+		return x.ProjectId
+	}
+	return 0
+}
+
+func (x *ListMessagesRequest) GetTeamId() int32 {
+	if x != nil {
+		return x.TeamId
+	}
+	return 0
+}
+
+func (x *ListMessagesRequest) GetTextContains() string {
+	if x != nil {
+		return x.TextContains
+	}
+	return ""
+}
+
+func (x *ListMessagesRequest) GetCreatedAfter() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAfter
+	}
+	return nil
+}
+
+func (x *ListMessagesRequest) GetCreatedBefore() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedBefore
+	}
+	return nil
+}
+
+// ListMessagesResponse is the response message for ListMessages RPC
+type ListMessagesResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Matching messages, newest first
+	Messages []*Message `protobuf:"bytes,1,rep,name=messages,proto3" json:"messages,omitempty"`
+	// Token for the next page, empty when there are no more results
+	NextPageToken string `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListMessagesResponse) Reset() {
+	*x = ListMessagesResponse{}
+	mi := &file_message_v1_message_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListMessagesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListMessagesResponse) ProtoMessage() {}
+
+func (x *ListMessagesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_message_v1_message_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListMessagesResponse.ProtoReflect.Descriptor instead.
+func (*ListMessagesResponse) Descriptor() ([]byte, []int) {
+	return file_message_v1_message_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ListMessagesResponse) GetMessages() []*Message {
+	if x != nil {
+		return x.Messages
+	}
+	return nil
+}
+
+func (x *ListMessagesResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+// ExportMessagesRequest selects the messages to export. Unset fields do not filter.
+type ExportMessagesRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Only messages sent by this email
+	Email string `protobuf:"bytes,1,opt,name=email,proto3" json:"email,omitempty"`
+	// Inclusive lower bound on created_at
+	CreatedAfter *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=created_after,json=createdAfter,proto3" json:"created_after,omitempty"`
+	// Exclusive upper bound on created_at
+	CreatedBefore *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=created_before,json=createdBefore,proto3" json:"created_before,omitempty"`
+	// Student exporting, required. Only messages they sent, messages of
+	// conversations they participate in and messages of their teams and
+	// projects are exported.
+	ViewerEmail   string `protobuf:"bytes,4,opt,name=viewer_email,json=viewerEmail,proto3" json:"viewer_email,omitempty"`
+	ViewerId      int32  `protobuf:"varint,5,opt,name=viewer_id,json=viewerId,proto3" json:"viewer_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExportMessagesRequest) Reset() {
+	*x = ExportMessagesRequest{}
+	mi := &file_message_v1_message_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportMessagesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportMessagesRequest) ProtoMessage() {}
+
+func (x *ExportMessagesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_message_v1_message_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportMessagesRequest.ProtoReflect.Descriptor instead.
+func (*ExportMessagesRequest) Descriptor() ([]byte, []int) {
+	return file_message_v1_message_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ExportMessagesRequest) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+func (x *ExportMessagesRequest) GetCreatedAfter() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAfter
+	}
+	return nil
+}
+
+func (x *ExportMessagesRequest) GetCreatedBefore() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedBefore
+	}
+	return nil
+}
+
+func (x *ExportMessagesRequest) GetViewerEmail() string {
+	if x != nil {
+		return x.ViewerEmail
+	}
+	return ""
+}
+
+func (x *ExportMessagesRequest) GetViewerId() int32 {
+	if x != nil {
+		return x.ViewerId
+	}
+	return 0
+}
+
+// ExportMessagesResponse carries one batch of exported messages, oldest first
+type ExportMessagesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Messages      []*Message             `protobuf:"bytes,1,rep,name=messages,proto3" json:"messages,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExportMessagesResponse) Reset() {
+	*x = ExportMessagesResponse{}
+	mi := &file_message_v1_message_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportMessagesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportMessagesResponse) ProtoMessage() {}
+
+func (x *ExportMessagesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_message_v1_message_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportMessagesResponse.ProtoReflect.Descriptor instead.
+func (*ExportMessagesResponse) Descriptor() ([]byte, []int) {
+	return file_message_v1_message_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *ExportMessagesResponse) GetMessages() []*Message {
+	if x != nil {
+		return x.Messages
+	}
+	return nil
+}
+
+// SearchMessagesRequest is the request message for SearchMessages RPC
+type SearchMessagesRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Free text. Every word must match the start of a word in the message;
+	// punctuation is ignored.
+	Query string `protobuf:"bytes,1,opt,name=query,proto3" json:"query,omitempty"`
+	// Maximum number of results. Defaults to 20; values above 100 are coerced to 100.
+	PageSize int32 `protobuf:"varint,2,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	// Student searching, required. Only messages they sent, messages of
+	// conversations they participate in and messages of their teams and
+	// projects are searched.
+	ViewerEmail   string `protobuf:"bytes,3,opt,name=viewer_email,json=viewerEmail,proto3" json:"viewer_email,omitempty"`
+	ViewerId      int32  `protobuf:"varint,4,opt,name=viewer_id,json=viewerId,proto3" json:"viewer_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchMessagesRequest) Reset() {
+	*x = SearchMessagesRequest{}
+	mi := &file_message_v1_message_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchMessagesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchMessagesRequest) ProtoMessage() {}
+
+func (x *SearchMessagesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_message_v1_message_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchMessagesRequest.ProtoReflect.Descriptor instead.
+func (*SearchMessagesRequest) Descriptor() ([]byte, []int) {
+	return file_message_v1_message_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *SearchMessagesRequest) GetQuery() string {
+	if x != nil {
+		return x.Query
+	}
+	return ""
+}
+
+func (x *SearchMessagesRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *SearchMessagesRequest) GetViewerEmail() string {
+	if x != nil {
+		return x.ViewerEmail
+	}
+	return ""
+}
+
+func (x *SearchMessagesRequest) GetViewerId() int32 {
+	if x != nil {
+		return x.ViewerId
+	}
+	return 0
+}
+
+// MessageSearchResult is a message matching a search
+type MessageSearchResult struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Message *Message               `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+	// Relevance in [0, 1), higher is better
+	Rank float64 `protobuf:"fixed64,2,opt,name=rank,proto3" json:"rank,omitempty"`
+	// Fragments of the message around the matches, as HTML-escaped text with
+	// matches wrapped in <mark>
+	Highlight     string `protobuf:"bytes,3,opt,name=highlight,proto3" json:"highlight,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MessageSearchResult) Reset() {
+	*x = MessageSearchResult{}
+	mi := &file_message_v1_message_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MessageSearchResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MessageSearchResult) ProtoMessage() {}
+
+func (x *MessageSearchResult) ProtoReflect() protoreflect.Message {
+	mi := &file_message_v1_message_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MessageSearchResult.ProtoReflect.Descriptor instead.
+func (*MessageSearchResult) Descriptor() ([]byte, []int) {
+	return file_message_v1_message_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *MessageSearchResult) GetMessage() *Message {
+	if x != nil {
+		return x.Message
+	}
+	return nil
+}
+
+func (x *MessageSearchResult) GetRank() float64 {
+	if x != nil {
+		return x.Rank
+	}
+	return 0
+}
+
+func (x *MessageSearchResult) GetHighlight() string {
+	if x != nil {
+		return x.Highlight
+	}
+	return ""
+}
+
+// SearchMessagesResponse is the response message for SearchMessages RPC
+type SearchMessagesResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Best match first
+	Results       []*MessageSearchResult `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchMessagesResponse) Reset() {
+	*x = SearchMessagesResponse{}
+	mi := &file_message_v1_message_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchMessagesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchMessagesResponse) ProtoMessage() {}
+
+func (x *SearchMessagesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_message_v1_message_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchMessagesResponse.ProtoReflect.Descriptor instead.
+func (*SearchMessagesResponse) Descriptor() ([]byte, []int) {
+	return file_message_v1_message_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *SearchMessagesResponse) GetResults() []*MessageSearchResult {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+// CreateConversationRequest starts a conversation. Exactly one of project_id
+// and recipient is required.
+type CreateConversationRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Email of the student starting the conversation
+	CreatedBy string `protobuf:"bytes,1,opt,name=created_by,json=createdBy,proto3" json:"created_by,omitempty"`
+	// Project the conversation is scoped to
+	ProjectId int32 `protobuf:"varint,2,opt,name=project_id,json=projectId,proto3" json:"project_id,omitempty"`
+	// Email of the other student of a direct conversation
+	Recipient     string `protobuf:"bytes,3,opt,name=recipient,proto3" json:"recipient,omitempty"`
+	Subject       string `protobuf:"bytes,4,opt,name=subject,proto3" json:"subject,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateConversationRequest) Reset() {
+	*x = CreateConversationRequest{}
+	mi := &file_message_v1_message_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateConversationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateConversationRequest) ProtoMessage() {}
+
+func (x *CreateConversationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_message_v1_message_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateConversationRequest.ProtoReflect.Descriptor instead.
+func (*CreateConversationRequest) Descriptor() ([]byte, []int) {
+	return file_message_v1_message_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *CreateConversationRequest) GetCreatedBy() string {
+	if x != nil {
+		return x.CreatedBy
+	}
+	return ""
+}
+
+func (x *CreateConversationRequest) GetProjectId() int32 {
+	if x != nil {
+		return x.ProjectId
+	}
+	return 0
+}
+
+func (x *CreateConversationRequest) GetRecipient() string {
+	if x != nil {
+		return x.Recipient
+	}
+	return ""
+}
+
+func (x *CreateConversationRequest) GetSubject() string {
+	if x != nil {
+		return x.Subject
+	}
+	return ""
+}
+
+// CreateConversationResponse is the response message for CreateConversation RPC
+type CreateConversationResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Conversation  *Conversation          `protobuf:"bytes,1,opt,name=conversation,proto3" json:"conversation,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateConversationResponse) Reset() {
+	*x = CreateConversationResponse{}
+	mi := &file_message_v1_message_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateConversationResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateConversationResponse) ProtoMessage() {}
+
+func (x *CreateConversationResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_message_v1_message_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateConversationResponse.ProtoReflect.Descriptor instead.
+func (*CreateConversationResponse) Descriptor() ([]byte, []int) {
+	return file_message_v1_message_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *CreateConversationResponse) GetConversation() *Conversation {
+	if x != nil {
+		return x.Conversation
+	}
+	return nil
+}
+
+// ListConversationsRequest selects conversations. At least one field is required.
+type ListConversationsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Only conversations this email participates in
+	Email string `protobuf:"bytes,1,opt,name=email,proto3" json:"email,omitempty"`
+	// Only conversations of this project
+	ProjectId     int32 `protobuf:"varint,2,opt,name=project_id,json=projectId,proto3" json:"project_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListConversationsRequest) Reset() {
+	*x = ListConversationsRequest{}
+	mi := &file_message_v1_message_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListConversationsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListConversationsRequest) ProtoMessage() {}
+
+func (x *ListConversationsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_message_v1_message_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListConversationsRequest.ProtoReflect.Descriptor instead.
+func (*ListConversationsRequest) Descriptor() ([]byte, []int) {
+	return file_message_v1_message_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *ListConversationsRequest) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+func (x *ListConversationsRequest) GetProjectId() int32 {
+	if x != nil {
+		return x.ProjectId
+	}
+	return 0
+}
+
+// ListConversationsResponse lists conversations, most recently active first
+type ListConversationsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Conversations []*Conversation        `protobuf:"bytes,1,rep,name=conversations,proto3" json:"conversations,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListConversationsResponse) Reset() {
+	*x = ListConversationsResponse{}
+	mi := &file_message_v1_message_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListConversationsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListConversationsResponse) ProtoMessage() {}
+
+func (x *ListConversationsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_message_v1_message_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListConversationsResponse.ProtoReflect.Descriptor instead.
+func (*ListConversationsResponse) Descriptor() ([]byte, []int) {
+	return file_message_v1_message_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *ListConversationsResponse) GetConversations() []*Conversation {
+	if x != nil {
+		return x.Conversations
+	}
+	return nil
+}
+
+// GetThreadRequest is the request message for GetThread RPC
+type GetThreadRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Any message of the thread
+	MessageId int32 `protobuf:"varint,1,opt,name=message_id,json=messageId,proto3" json:"message_id,omitempty"`
+	// Email and student ID of the student reading the thread
+	ViewerEmail   string `protobuf:"bytes,2,opt,name=viewer_email,json=viewerEmail,proto3" json:"viewer_email,omitempty"`
+	ViewerId      int32  `protobuf:"varint,3,opt,name=viewer_id,json=viewerId,proto3" json:"viewer_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetThreadRequest) Reset() {
+	*x = GetThreadRequest{}
+	mi := &file_message_v1_message_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetThreadRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetThreadRequest) ProtoMessage() {}
+
+func (x *GetThreadRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_message_v1_message_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetThreadRequest.ProtoReflect.Descriptor instead.
+func (*GetThreadRequest) Descriptor() ([]byte, []int) {
+	return file_message_v1_message_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *GetThreadRequest) GetMessageId() int32 {
+	if x != nil {
+		return x.MessageId
+	}
+	return 0
+}
+
+func (x *GetThreadRequest) GetViewerEmail() string {
+	if x != nil {
+		return x.ViewerEmail
+	}
+	return ""
+}
+
+func (x *GetThreadRequest) GetViewerId() int32 {
+	if x != nil {
+		return x.ViewerId
+	}
+	return 0
+}
+
+// GetThreadResponse is a thread: its first message and every reply to it,
+// directly or indirectly, oldest first
+type GetThreadResponse struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Root    *Message               `protobuf:"bytes,1,opt,name=root,proto3" json:"root,omitempty"`
+	Replies []*Message             `protobuf:"bytes,2,rep,name=replies,proto3" json:"replies,omitempty"`
+	// Conversation of the thread, unset if it has none
+	Conversation  *Conversation `protobuf:"bytes,3,opt,name=conversation,proto3" json:"conversation,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetThreadResponse) Reset() {
+	*x = GetThreadResponse{}
+	mi := &file_message_v1_message_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetThreadResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetThreadResponse) ProtoMessage() {}
+
+func (x *GetThreadResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_message_v1_message_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetThreadResponse.ProtoReflect.Descriptor instead.
+func (*GetThreadResponse) Descriptor() ([]byte, []int) {
+	return file_message_v1_message_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *GetThreadResponse) GetRoot() *Message {
+	if x != nil {
+		return x.Root
+	}
+	return nil
+}
+
+func (x *GetThreadResponse) GetReplies() []*Message {
+	if x != nil {
+		return x.Replies
+	}
+	return nil
+}
+
+func (x *GetThreadResponse) GetConversation() *Conversation {
+	if x != nil {
+		return x.Conversation
+	}
+	return nil
+}
+
+// GetEditHistoryRequest is the request message for GetEditHistory RPC
+type GetEditHistoryRequest struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	MessageId int32                  `protobuf:"varint,1,opt,name=message_id,json=messageId,proto3" json:"message_id,omitempty"`
+	// Email and student ID of the student reading the history
+	ViewerEmail   string `protobuf:"bytes,2,opt,name=viewer_email,json=viewerEmail,proto3" json:"viewer_email,omitempty"`
+	ViewerId      int32  `protobuf:"varint,3,opt,name=viewer_id,json=viewerId,proto3" json:"viewer_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetEditHistoryRequest) Reset() {
+	*x = GetEditHistoryRequest{}
+	mi := &file_message_v1_message_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetEditHistoryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetEditHistoryRequest) ProtoMessage() {}
+
+func (x *GetEditHistoryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_message_v1_message_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetEditHistoryRequest.ProtoReflect.Descriptor instead.
+func (*GetEditHistoryRequest) Descriptor() ([]byte, []int) {
+	return file_message_v1_message_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *GetEditHistoryRequest) GetMessageId() int32 {
+	if x != nil {
+		return x.MessageId
+	}
+	return 0
+}
+
+func (x *GetEditHistoryRequest) GetViewerEmail() string {
+	if x != nil {
+		return x.ViewerEmail
+	}
+	return ""
+}
+
+func (x *GetEditHistoryRequest) GetViewerId() int32 {
+	if x != nil {
+		return x.ViewerId
+	}
+	return 0
+}
+
+// MessageEdit is a previous text of a message
+type MessageEdit struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Message string                 `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+	// When this text was replaced
+	EditedAt      *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=edited_at,json=editedAt,proto3" json:"edited_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MessageEdit) Reset() {
+	*x = MessageEdit{}
+	mi := &file_message_v1_message_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MessageEdit) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MessageEdit) ProtoMessage() {}
+
+func (x *MessageEdit) ProtoReflect() protoreflect.Message {
+	mi := &file_message_v1_message_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MessageEdit.ProtoReflect.Descriptor instead.
+func (*MessageEdit) Descriptor() ([]byte, []int) {
+	return file_message_v1_message_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *MessageEdit) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *MessageEdit) GetEditedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.EditedAt
+	}
+	return nil
+}
+
+// GetEditHistoryResponse is a message with its previous texts, oldest first
+type GetEditHistoryResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Message       *Message               `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+	Edits         []*MessageEdit         `protobuf:"bytes,2,rep,name=edits,proto3" json:"edits,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetEditHistoryResponse) Reset() {
+	*x = GetEditHistoryResponse{}
+	mi := &file_message_v1_message_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetEditHistoryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetEditHistoryResponse) ProtoMessage() {}
+
+func (x *GetEditHistoryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_message_v1_message_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetEditHistoryResponse.ProtoReflect.Descriptor instead.
+func (*GetEditHistoryResponse) Descriptor() ([]byte, []int) {
+	return file_message_v1_message_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *GetEditHistoryResponse) GetMessage() *Message {
+	if x != nil {
+		return x.Message
+	}
+	return nil
+}
+
+func (x *GetEditHistoryResponse) GetEdits() []*MessageEdit {
+	if x != nil {
+		return x.Edits
+	}
+	return nil
+}
+
+// CheckPostRequest names a student about to post to a conversation, as a
+// reply, or both
+type CheckPostRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Email          string                 `protobuf:"bytes,1,opt,name=email,proto3" json:"email,omitempty"`
+	StudentId      int32                  `protobuf:"varint,2,opt,name=student_id,json=studentId,proto3" json:"student_id,omitempty"`
+	ConversationId int32                  `protobuf:"varint,3,opt,name=conversation_id,json=conversationId,proto3" json:"conversation_id,omitempty"`
+	ReplyTo        int32                  `protobuf:"varint,4,opt,name=reply_to,json=replyTo,proto3" json:"reply_to,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *CheckPostRequest) Reset() {
+	*x = CheckPostRequest{}
+	mi := &file_message_v1_message_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CheckPostRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CheckPostRequest) ProtoMessage() {}
+
+func (x *CheckPostRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_message_v1_message_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CheckPostRequest.ProtoReflect.Descriptor instead.
+func (*CheckPostRequest) Descriptor() ([]byte, []int) {
+	return file_message_v1_message_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *CheckPostRequest) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+func (x *CheckPostRequest) GetStudentId() int32 {
+	if x != nil {
+		return x.StudentId
+	}
+	return 0
+}
+
+func (x *CheckPostRequest) GetConversationId() int32 {
+	if x != nil {
+		return x.ConversationId
+	}
+	return 0
+}
+
+func (x *CheckPostRequest) GetReplyTo() int32 {
+	if x != nil {
+		return x.ReplyTo
+	}
+	return 0
+}
+
+// CheckPostResponse is the response message for CheckPost RPC
+type CheckPostResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CheckPostResponse) Reset() {
+	*x = CheckPostResponse{}
+	mi := &file_message_v1_message_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CheckPostResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CheckPostResponse) ProtoMessage() {}
+
+func (x *CheckPostResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_message_v1_message_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CheckPostResponse.ProtoReflect.Descriptor instead.
+func (*CheckPostResponse) Descriptor() ([]byte, []int) {
+	return file_message_v1_message_proto_rawDescGZIP(), []int{21}
+}
+
+// MarkReadRequest marks messages read by email. Exactly one of message_id
+// and up_to is required.
+type MarkReadRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Email string                 `protobuf:"bytes,1,opt,name=email,proto3" json:"email,omitempty"`
+	// Marks this message read
+	MessageId int32 `protobuf:"varint,2,opt,name=message_id,json=messageId,proto3" json:"message_id,omitempty"`
+	// Marks read every message created up to this time, inclusive, in the
+	// conversations email participates in
+	UpTo *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=up_to,json=upTo,proto3" json:"up_to,omitempty"`
+	// Limits up_to to this conversation when set
+	ConversationId int32 `protobuf:"varint,4,opt,name=conversation_id,json=conversationId,proto3" json:"conversation_id,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *MarkReadRequest) Reset() {
+	*x = MarkReadRequest{}
+	mi := &file_message_v1_message_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MarkReadRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MarkReadRequest) ProtoMessage() {}
+
+func (x *MarkReadRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_message_v1_message_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MarkReadRequest.ProtoReflect.Descriptor instead.
+func (*MarkReadRequest) Descriptor() ([]byte, []int) {
+	return file_message_v1_message_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *MarkReadRequest) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+func (x *MarkReadRequest) GetMessageId() int32 {
+	if x != nil {
+		return x.MessageId
+	}
+	return 0
+}
+
+func (x *MarkReadRequest) GetUpTo() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpTo
+	}
+	return nil
+}
+
+func (x *MarkReadRequest) GetConversationId() int32 {
+	if x != nil {
+		return x.ConversationId
+	}
+	return 0
+}
+
+// MarkReadResponse is the response message for MarkRead RPC
+type MarkReadResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Number of messages that were unread before the call
+	Marked        int32                  `protobuf:"varint,1,opt,name=marked,proto3" json:"marked,omitempty"`
+	ReadAt        *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=read_at,json=readAt,proto3" json:"read_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MarkReadResponse) Reset() {
+	*x = MarkReadResponse{}
+	mi := &file_message_v1_message_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MarkReadResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MarkReadResponse) ProtoMessage() {}
+
+func (x *MarkReadResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_message_v1_message_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MarkReadResponse.ProtoReflect.Descriptor instead.
+func (*MarkReadResponse) Descriptor() ([]byte, []int) {
+	return file_message_v1_message_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *MarkReadResponse) GetMarked() int32 {
+	if x != nil {
+		return x.Marked
+	}
+	return 0
+}
+
+func (x *MarkReadResponse) GetReadAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ReadAt
+	}
+	return nil
+}
+
+// GetUnreadCountsRequest is the request message for GetUnreadCounts RPC
+type GetUnreadCountsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Email         string                 `protobuf:"bytes,1,opt,name=email,proto3" json:"email,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetUnreadCountsRequest) Reset() {
+	*x = GetUnreadCountsRequest{}
+	mi := &file_message_v1_message_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetUnreadCountsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetUnreadCountsRequest) ProtoMessage() {}
+
+func (x *GetUnreadCountsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_message_v1_message_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetUnreadCountsRequest.ProtoReflect.Descriptor instead.
+func (*GetUnreadCountsRequest) Descriptor() ([]byte, []int) {
+	return file_message_v1_message_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *GetUnreadCountsRequest) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+// UnreadCount is the number of unread messages in a conversation
+type UnreadCount struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	ConversationId int32                  `protobuf:"varint,1,opt,name=conversation_id,json=conversationId,proto3" json:"conversation_id,omitempty"`
+	Unread         int32                  `protobuf:"varint,2,opt,name=unread,proto3" json:"unread,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *UnreadCount) Reset() {
+	*x = UnreadCount{}
+	mi := &file_message_v1_message_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UnreadCount) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UnreadCount) ProtoMessage() {}
+
+func (x *UnreadCount) ProtoReflect() protoreflect.Message {
+	mi := &file_message_v1_message_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UnreadCount.ProtoReflect.Descriptor instead.
+func (*UnreadCount) Descriptor() ([]byte, []int) {
+	return file_message_v1_message_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *UnreadCount) GetConversationId() int32 {
+	if x != nil {
+		return x.ConversationId
+	}
+	return 0
+}
+
+func (x *UnreadCount) GetUnread() int32 {
+	if x != nil {
+		return x.Unread
+	}
+	return 0
+}
+
+// GetUnreadCountsResponse lists the conversations with unread messages
+type GetUnreadCountsResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Total int32                  `protobuf:"varint,1,opt,name=total,proto3" json:"total,omitempty"`
+	// Sorted by conversation ID
+	Conversations []*UnreadCount `protobuf:"bytes,2,rep,name=conversations,proto3" json:"conversations,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetUnreadCountsResponse) Reset() {
+	*x = GetUnreadCountsResponse{}
+	mi := &file_message_v1_message_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetUnreadCountsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetUnreadCountsResponse) ProtoMessage() {}
+
+func (x *GetUnreadCountsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_message_v1_message_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetUnreadCountsResponse.ProtoReflect.Descriptor instead.
+func (*GetUnreadCountsResponse) Descriptor() ([]byte, []int) {
+	return file_message_v1_message_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *GetUnreadCountsResponse) GetTotal() int32 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *GetUnreadCountsResponse) GetConversations() []*UnreadCount {
+	if x != nil {
+		return x.Conversations
+	}
+	return nil
+}
+
+var File_message_v1_message_proto protoreflect.FileDescriptor
+
+const file_message_v1_message_proto_rawDesc = "" +
+	"\n" +
+	"\x18message/v1/message.proto\x12\n" +
+	"message.v1\x1a\x1fgoogle/protobuf/timestamp.proto\"\xaf\x03\n" +
+	"\aMessage\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x05R\x02id\x12\x14\n" +
+	"\x05email\x18\x02 \x01(\tR\x05email\x12\x18\n" +
+	"\amessage\x18\x03 \x01(\tR\amessage\x129\n" +
+	"\n" +
+	"created_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x12\x17\n" +
+	"\ateam_id\x18\x05 \x01(\x05R\x06teamId\x12'\n" +
+	"\x0fconversation_id\x18\x06 \x01(\x05R\x0econversationId\x12\x1b\n" +
+	"\tparent_id\x18\a \x01(\x05R\bparentId\x12$\n" +
+	"\x0ethread_root_id\x18\b \x01(\x05R\fthreadRootId\x12\x16\n" +
+	"\x06edited\x18\t \x01(\bR\x06edited\x127\n" +
+	"\tedited_at\x18\n" +
+	" \x01(\v2\x1a.google.protobuf.TimestampR\beditedAt\x12\x18\n" +
+	"\adeleted\x18\v \x01(\bR\adeleted\x129\n" +
+	"\n" +
+	"deleted_at\x18\f \x01(\v2\x1a.google.protobuf.TimestampR\tdeletedAt\"\x99\x02\n" +
+	"\fConversation\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x05R\x02id\x12\x1d\n" +
+	"\n" +
+	"project_id\x18\x02 \x01(\x05R\tprojectId\x12\x18\n" +
+	"\asubject\x18\x03 \x01(\tR\asubject\x12\x1d\n" +
+	"\n" +
+	"created_by\x18\x04 \x01(\tR\tcreatedBy\x12\"\n" +
+	"\fparticipants\x18\x05 \x03(\tR\fparticipants\x129\n" +
+	"\n" +
+	"created_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x12B\n" +
+	"\x0flast_message_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\rlastMessageAt\"J\n" +
+	"\x19GetMessagesByEmailRequest\x12\x14\n" +
+	"\x05email\x18\x01 \x01(\tR\x05email\x12\x17\n" +
+	"\ateam_id\x18\x02 \x01(\x05R\x06teamId\"M\n" +
+	"\x1aGetMessagesByEmailResponse\x12/\n" +
+	"\bmessages\x18\x01 \x03(\v2\x13.message.v1.MessageR\bmessages\"\xe8\x02\n" +
+	"\x13ListMessagesRequest\x12\x1b\n" +
+	"\tpage_size\x18\x01 \x01(\x05R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x02 \x01(\tR\tpageToken\x12\x16\n" +
+	"\x06sender\x18\x03 \x01(\tR\x06sender\x12\x1c\n" +
+	"\trecipient\x18\x04 \x01(\tR\trecipient\x12\x1d\n" +
+	"\n" +
+	"project_id\x18\x05 \x01(\x05R\tprojectId\x12\x17\n" +
+	"\ateam_id\x18\x06 \x01(\x05R\x06teamId\x12#\n" +
+	"\rtext_contains\x18\a \x01(\tR\ftextContains\x12?\n" +
+	"\rcreated_after\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\fcreatedAfter\x12A\n" +
+	"\x0ecreated_before\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\rcreatedBefore\"o\n" +
+	"\x14ListMessagesResponse\x12/\n" +
+	"\bmessages\x18\x01 \x03(\v2\x13.message.v1.MessageR\bmessages\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\"\xf1\x01\n" +
+	"\x15ExportMessagesRequest\x12\x14\n" +
+	"\x05email\x18\x01 \x01(\tR\x05email\x12?\n" +
+	"\rcreated_after\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\fcreatedAfter\x12A\n" +
+	"\x0ecreated_before\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\rcreatedBefore\x12!\n" +
+	"\fviewer_email\x18\x04 \x01(\tR\vviewerEmail\x12\x1b\n" +
+	"\tviewer_id\x18\x05 \x01(\x05R\bviewerId\"I\n" +
+	"\x16ExportMessagesResponse\x12/\n" +
+	"\bmessages\x18\x01 \x03(\v2\x13.message.v1.MessageR\bmessages\"\x8a\x01\n" +
+	"\x15SearchMessagesRequest\x12\x14\n" +
+	"\x05query\x18\x01 \x01(\tR\x05query\x12\x1b\n" +
+	"\tpage_size\x18\x02 \x01(\x05R\bpageSize\x12!\n" +
+	"\fviewer_email\x18\x03 \x01(\tR\vviewerEmail\x12\x1b\n" +
+	"\tviewer_id\x18\x04 \x01(\x05R\bviewerId\"v\n" +
+	"\x13MessageSearchResult\x12-\n" +
+	"\amessage\x18\x01 \x01(\v2\x13.message.v1.MessageR\amessage\x12\x12\n" +
+	"\x04rank\x18\x02 \x01(\x01R\x04rank\x12\x1c\n" +
+	"\thighlight\x18\x03 \x01(\tR\thighlight\"S\n" +
+	"\x16SearchMessagesResponse\x129\n" +
+	"\aresults\x18\x01 \x03(\v2\x1f.message.v1.MessageSearchResultR\aresults\"\x91\x01\n" +
+	"\x19CreateConversationRequest\x12\x1d\n" +
+	"\n" +
+	"created_by\x18\x01 \x01(\tR\tcreatedBy\x12\x1d\n" +
+	"\n" +
+	"project_id\x18\x02 \x01(\x05R\tprojectId\x12\x1c\n" +
+	"\trecipient\x18\x03 \x01(\tR\trecipient\x12\x18\n" +
+	"\asubject\x18\x04 \x01(\tR\asubject\"Z\n" +
+	"\x1aCreateConversationResponse\x12<\n" +
+	"\fconversation\x18\x01 \x01(\v2\x18.message.v1.ConversationR\fconversation\"O\n" +
+	"\x18ListConversationsRequest\x12\x14\n" +
+	"\x05email\x18\x01 \x01(\tR\x05email\x12\x1d\n" +
+	"\n" +
+	"project_id\x18\x02 \x01(\x05R\tprojectId\"[\n" +
+	"\x19ListConversationsResponse\x12>\n" +
+	"\rconversations\x18\x01 \x03(\v2\x18.message.v1.ConversationR\rconversations\"q\n" +
+	"\x10GetThreadRequest\x12\x1d\n" +
+	"\n" +
+	"message_id\x18\x01 \x01(\x05R\tmessageId\x12!\n" +
+	"\fviewer_email\x18\x02 \x01(\tR\vviewerEmail\x12\x1b\n" +
+	"\tviewer_id\x18\x03 \x01(\x05R\bviewerId\"\xa9\x01\n" +
+	"\x11GetThreadResponse\x12'\n" +
+	"\x04root\x18\x01 \x01(\v2\x13.message.v1.MessageR\x04root\x12-\n" +
+	"\areplies\x18\x02 \x03(\v2\x13.message.v1.MessageR\areplies\x12<\n" +
+	"\fconversation\x18\x03 \x01(\v2\x18.message.v1.ConversationR\fconversation\"v\n" +
+	"\x15GetEditHistoryRequest\x12\x1d\n" +
+	"\n" +
+	"message_id\x18\x01 \x01(\x05R\tmessageId\x12!\n" +
+	"\fviewer_email\x18\x02 \x01(\tR\vviewerEmail\x12\x1b\n" +
+	"\tviewer_id\x18\x03 \x01(\x05R\bviewerId\"`\n" +
+	"\vMessageEdit\x12\x18\n" +
+	"\amessage\x18\x01 \x01(\tR\amessage\x127\n" +
+	"\tedited_at\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\beditedAt\"v\n" +
+	"\x16GetEditHistoryResponse\x12-\n" +
+	"\amessage\x18\x01 \x01(\v2\x13.message.v1.MessageR\amessage\x12-\n" +
+	"\x05edits\x18\x02 \x03(\v2\x17.message.v1.MessageEditR\x05edits\"\x8b\x01\n" +
+	"\x10CheckPostRequest\x12\x14\n" +
+	"\x05email\x18\x01 \x01(\tR\x05email\x12\x1d\n" +
+	"\n" +
+	"student_id\x18\x02 \x01(\x05R\tstudentId\x12'\n" +
+	"\x0fconversation_id\x18\x03 \x01(\x05R\x0econversationId\x12\x19\n" +
+	"\breply_to\x18\x04 \x01(\x05R\areplyTo\"\x13\n" +
+	"\x11CheckPostResponse\"\xa0\x01\n" +
+	"\x0fMarkReadRequest\x12\x14\n" +
+	"\x05email\x18\x01 \x01(\tR\x05email\x12\x1d\n" +
+	"\n" +
+	"message_id\x18\x02 \x01(\x05R\tmessageId\x12/\n" +
+	"\x05up_to\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\x04upTo\x12'\n" +
+	"\x0fconversation_id\x18\x04 \x01(\x05R\x0econversationId\"_\n" +
+	"\x10MarkReadResponse\x12\x16\n" +
+	"\x06marked\x18\x01 \x01(\x05R\x06marked\x123\n" +
+	"\aread_at\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\x06readAt\".\n" +
+	"\x16GetUnreadCountsRequest\x12\x14\n" +
+	"\x05email\x18\x01 \x01(\tR\x05email\"N\n" +
+	"\vUnreadCount\x12'\n" +
+	"\x0fconversation_id\x18\x01 \x01(\x05R\x0econversationId\x12\x16\n" +
+	"\x06unread\x18\x02 \x01(\x05R\x06unread\"n\n" +
+	"\x17GetUnreadCountsResponse\x12\x14\n" +
+	"\x05total\x18\x01 \x01(\x05R\x05total\x12=\n" +
+	"\rconversations\x18\x02 \x03(\v2\x17.message.v1.UnreadCountR\rconversations2\xd8\a\n" +
+	"\x0eMessageService\x12h\n" +
+	"\x12GetMessagesByEmail\x12%.message.v1.GetMessagesByEmailRequest\x1a&.message.v1.GetMessagesByEmailResponse\"\x03\x88\x02\x01\x12Q\n" +
+	"\fListMessages\x12\x1f.message.v1.ListMessagesRequest\x1a .message.v1.ListMessagesResponse\x12Y\n" +
+	"\x0eExportMessages\x12!.message.v1.ExportMessagesRequest\x1a\".message.v1.ExportMessagesResponse0\x01\x12W\n" +
+	"\x0eSearchMessages\x12!.message.v1.SearchMessagesRequest\x1a\".message.v1.SearchMessagesResponse\x12c\n" +
+	"\x12CreateConversation\x12%.message.v1.CreateConversationRequest\x1a&.message.v1.CreateConversationResponse\x12`\n" +
+	"\x11ListConversations\x12$.message.v1.ListConversationsRequest\x1a%.message.v1.ListConversationsResponse\x12H\n" +
+	"\tGetThread\x12\x1c.message.v1.GetThreadRequest\x1a\x1d.message.v1.GetThreadResponse\x12H\n" +
+	"\tCheckPost\x12\x1c.message.v1.CheckPostRequest\x1a\x1d.message.v1.CheckPostResponse\x12W\n" +
+	"\x0eGetEditHistory\x12!.message.v1.GetEditHistoryRequest\x1a\".message.v1.GetEditHistoryResponse\x12E\n" +
+	"\bMarkRead\x12\x1b.message.v1.MarkReadRequest\x1a\x1c.message.v1.MarkReadResponse\x12Z\n" +
+	"\x0fGetUnreadCounts\x12\".message.v1.GetUnreadCountsRequest\x1a#.message.v1.GetUnreadCountsResponseB#Z!grud/api/gen/message/v1;messagev1b\x06proto3"
 
 var (
 	file_message_v1_message_proto_rawDescOnce sync.Once
@@ -212,23 +1902,90 @@ func file_message_v1_message_proto_rawDescGZIP() []byte {
 	return file_message_v1_message_proto_rawDescData
 }
 
-var file_message_v1_message_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
+var file_message_v1_message_proto_msgTypes = make([]protoimpl.MessageInfo, 27)
 var file_message_v1_message_proto_goTypes = []any{
 	(*Message)(nil),                    // 0: message.v1.Message
-	(*GetMessagesByEmailRequest)(nil),  // 1: message.v1.GetMessagesByEmailRequest
-	(*GetMessagesByEmailResponse)(nil), // 2: message.v1.GetMessagesByEmailResponse
-	(*timestamppb.Timestamp)(nil),      // 3: google.protobuf.Timestamp
+	(*Conversation)(nil),               // 1: message.v1.Conversation
+	(*GetMessagesByEmailRequest)(nil),  // 2: message.v1.GetMessagesByEmailRequest
+	(*GetMessagesByEmailResponse)(nil), // 3: message.v1.GetMessagesByEmailResponse
+	(*ListMessagesRequest)(nil),        // 4: message.v1.ListMessagesRequest
+	(*ListMessagesResponse)(nil),       // 5: message.v1.ListMessagesResponse
+	(*ExportMessagesRequest)(nil),      // 6: message.v1.ExportMessagesRequest
+	(*ExportMessagesResponse)(nil),     // 7: message.v1.ExportMessagesResponse
+	(*SearchMessagesRequest)(nil),      // 8: message.v1.SearchMessagesRequest
+	(*MessageSearchResult)(nil),        // 9: message.v1.MessageSearchResult
+	(*SearchMessagesResponse)(nil),     // 10: message.v1.SearchMessagesResponse
+	(*CreateConversationRequest)(nil),  // 11: message.v1.CreateConversationRequest
+	(*CreateConversationResponse)(nil), // 12: message.v1.CreateConversationResponse
+	(*ListConversationsRequest)(nil),   // 13: message.v1.ListConversationsRequest
+	(*ListConversationsResponse)(nil),  // 14: message.v1.ListConversationsResponse
+	(*GetThreadRequest)(nil),           // 15: message.v1.GetThreadRequest
+	(*GetThreadResponse)(nil),          // 16: message.v1.GetThreadResponse
+	(*GetEditHistoryRequest)(nil),      // 17: message.v1.GetEditHistoryRequest
+	(*MessageEdit)(nil),                // 18: message.v1.MessageEdit
+	(*GetEditHistoryResponse)(nil),     // 19: message.v1.GetEditHistoryResponse
+	(*CheckPostRequest)(nil),           // 20: message.v1.CheckPostRequest
+	(*CheckPostResponse)(nil),          // 21: message.v1.CheckPostResponse
+	(*MarkReadRequest)(nil),            // 22: message.v1.MarkReadRequest
+	(*MarkReadResponse)(nil),           // 23: message.v1.MarkReadResponse
+	(*GetUnreadCountsRequest)(nil),     // 24: message.v1.GetUnreadCountsRequest
+	(*UnreadCount)(nil),                // 25: message.v1.UnreadCount
+	(*GetUnreadCountsResponse)(nil),    // 26: message.v1.GetUnreadCountsResponse
+	(*timestamppb.Timestamp)(nil),      // 27: google.protobuf.Timestamp
 }
 var file_message_v1_message_proto_depIdxs = []int32{
-	3, // 0: message.v1.Message.created_at:type_name -> google.protobuf.Timestamp
-	0, // 1: message.v1.GetMessagesByEmailResponse.messages:type_name -> message.v1.Message
-	1, // 2: message.v1.MessageService.GetMessagesByEmail:input_type -> message.v1.GetMessagesByEmailRequest
-	2, // 3: message.v1.MessageService.GetMessagesByEmail:output_type -> message.v1.GetMessagesByEmailResponse
-	3, // [3:4] is the sub-list for method output_type
-	2, // [2:3] is the sub-list for method input_type
-	2, // [2:2] is the sub-list for extension type_name
-	2, // [2:2] is the sub-list for extension extendee
-	0, // [0:2] is the sub-list for field type_name
+	27, // 0: message.v1.Message.created_at:type_name -> google.protobuf.Timestamp
+	27, // 1: message.v1.Message.edited_at:type_name -> google.protobuf.Timestamp
+	27, // 2: message.v1.Message.deleted_at:type_name -> google.protobuf.Timestamp
+	27, // 3: message.v1.Conversation.created_at:type_name -> google.protobuf.Timestamp
+	27, // 4: message.v1.Conversation.last_message_at:type_name -> google.protobuf.Timestamp
+	0,  // 5: message.v1.GetMessagesByEmailResponse.messages:type_name -> message.v1.Message
+	27, // 6: message.v1.ListMessagesRequest.created_after:type_name -> google.protobuf.Timestamp
+	27, // 7: message.v1.ListMessagesRequest.created_before:type_name -> google.protobuf.Timestamp
+	0,  // 8: message.v1.ListMessagesResponse.messages:type_name -> message.v1.Message
+	27, // 9: message.v1.ExportMessagesRequest.created_after:type_name -> google.protobuf.Timestamp
+	27, // 10: message.v1.ExportMessagesRequest.created_before:type_name -> google.protobuf.Timestamp
+	0,  // 11: message.v1.ExportMessagesResponse.messages:type_name -> message.v1.Message
+	0,  // 12: message.v1.MessageSearchResult.message:type_name -> message.v1.Message
+	9,  // 13: message.v1.SearchMessagesResponse.results:type_name -> message.v1.MessageSearchResult
+	1,  // 14: message.v1.CreateConversationResponse.conversation:type_name -> message.v1.Conversation
+	1,  // 15: message.v1.ListConversationsResponse.conversations:type_name -> message.v1.Conversation
+	0,  // 16: message.v1.GetThreadResponse.root:type_name -> message.v1.Message
+	0,  // 17: message.v1.GetThreadResponse.replies:type_name -> message.v1.Message
+	1,  // 18: message.v1.GetThreadResponse.conversation:type_name -> message.v1.Conversation
+	27, // 19: message.v1.MessageEdit.edited_at:type_name -> google.protobuf.Timestamp
+	0,  // 20: message.v1.GetEditHistoryResponse.message:type_name -> message.v1.Message
+	18, // 21: message.v1.GetEditHistoryResponse.edits:type_name -> message.v1.MessageEdit
+	27, // 22: message.v1.MarkReadRequest.up_to:type_name -> google.protobuf.Timestamp
+	27, // 23: message.v1.MarkReadResponse.read_at:type_name -> google.protobuf.Timestamp
+	25, // 24: message.v1.GetUnreadCountsResponse.conversations:type_name -> message.v1.UnreadCount
+	2,  // 25: message.v1.MessageService.GetMessagesByEmail:input_type -> message.v1.GetMessagesByEmailRequest
+	4,  // 26: message.v1.MessageService.ListMessages:input_type -> message.v1.ListMessagesRequest
+	6,  // 27: message.v1.MessageService.ExportMessages:input_type -> message.v1.ExportMessagesRequest
+	8,  // 28: message.v1.MessageService.SearchMessages:input_type -> message.v1.SearchMessagesRequest
+	11, // 29: message.v1.MessageService.CreateConversation:input_type -> message.v1.CreateConversationRequest
+	13, // 30: message.v1.MessageService.ListConversations:input_type -> message.v1.ListConversationsRequest
+	15, // 31: message.v1.MessageService.GetThread:input_type -> message.v1.GetThreadRequest
+	20, // 32: message.v1.MessageService.CheckPost:input_type -> message.v1.CheckPostRequest
+	17, // 33: message.v1.MessageService.GetEditHistory:input_type -> message.v1.GetEditHistoryRequest
+	22, // 34: message.v1.MessageService.MarkRead:input_type -> message.v1.MarkReadRequest
+	24, // 35: message.v1.MessageService.GetUnreadCounts:input_type -> message.v1.GetUnreadCountsRequest
+	3,  // 36: message.v1.MessageService.GetMessagesByEmail:output_type -> message.v1.GetMessagesByEmailResponse
+	5,  // 37: message.v1.MessageService.ListMessages:output_type -> message.v1.ListMessagesResponse
+	7,  // 38: message.v1.MessageService.ExportMessages:output_type -> message.v1.ExportMessagesResponse
+	10, // 39: message.v1.MessageService.SearchMessages:output_type -> message.v1.SearchMessagesResponse
+	12, // 40: message.v1.MessageService.CreateConversation:output_type -> message.v1.CreateConversationResponse
+	14, // 41: message.v1.MessageService.ListConversations:output_type -> message.v1.ListConversationsResponse
+	16, // 42: message.v1.MessageService.GetThread:output_type -> message.v1.GetThreadResponse
+	21, // 43: message.v1.MessageService.CheckPost:output_type -> message.v1.CheckPostResponse
+	19, // 44: message.v1.MessageService.GetEditHistory:output_type -> message.v1.GetEditHistoryResponse
+	23, // 45: message.v1.MessageService.MarkRead:output_type -> message.v1.MarkReadResponse
+	26, // 46: message.v1.MessageService.GetUnreadCounts:output_type -> message.v1.GetUnreadCountsResponse
+	36, // [36:47] is the sub-list for method output_type
+	25, // [25:36] is the sub-list for method input_type
+	25, // [25:25] is the sub-list for extension type_name
+	25, // [25:25] is the sub-list for extension extendee
+	0,  // [0:25] is the sub-list for field type_name
 }
 
 func init() { file_message_v1_message_proto_init() }
@@ -242,7 +1999,7 @@ func file_message_v1_message_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_message_v1_message_proto_rawDesc), len(file_message_v1_message_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   3,
+			NumMessages:   27,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
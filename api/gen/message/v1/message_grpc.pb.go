@@ -20,6 +20,16 @@ const _ = grpc.SupportPackageIsVersion9
 
 const (
 	MessageService_GetMessagesByEmail_FullMethodName = "/message.v1.MessageService/GetMessagesByEmail"
+	MessageService_ListMessages_FullMethodName       = "/message.v1.MessageService/ListMessages"
+	MessageService_ExportMessages_FullMethodName     = "/message.v1.MessageService/ExportMessages"
+	MessageService_SearchMessages_FullMethodName     = "/message.v1.MessageService/SearchMessages"
+	MessageService_CreateConversation_FullMethodName = "/message.v1.MessageService/CreateConversation"
+	MessageService_ListConversations_FullMethodName  = "/message.v1.MessageService/ListConversations"
+	MessageService_GetThread_FullMethodName          = "/message.v1.MessageService/GetThread"
+	MessageService_CheckPost_FullMethodName          = "/message.v1.MessageService/CheckPost"
+	MessageService_GetEditHistory_FullMethodName     = "/message.v1.MessageService/GetEditHistory"
+	MessageService_MarkRead_FullMethodName           = "/message.v1.MessageService/MarkRead"
+	MessageService_GetUnreadCounts_FullMethodName    = "/message.v1.MessageService/GetUnreadCounts"
 )
 
 // MessageServiceClient is the client API for MessageService service.
@@ -28,8 +38,45 @@ const (
 //
 // MessageService provides operations on messages
 type MessageServiceClient interface {
-	// GetMessagesByEmail returns messages filtered by email
+	// Deprecated: Do not use.
+	// GetMessagesByEmail returns every message filtered by email, team or both,
+	// without a limit. Use ListMessages instead.
 	GetMessagesByEmail(ctx context.Context, in *GetMessagesByEmailRequest, opts ...grpc.CallOption) (*GetMessagesByEmailResponse, error)
+	// ListMessages returns a page of the matching messages, newest first
+	ListMessages(ctx context.Context, in *ListMessagesRequest, opts ...grpc.CallOption) (*ListMessagesResponse, error)
+	// ExportMessages streams all matching messages in batches. The response
+	// header metadata carries a content-disposition with a suggested file name.
+	ExportMessages(ctx context.Context, in *ExportMessagesRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ExportMessagesResponse], error)
+	// SearchMessages returns the messages best matching a full-text query
+	SearchMessages(ctx context.Context, in *SearchMessagesRequest, opts ...grpc.CallOption) (*SearchMessagesResponse, error)
+	// CreateConversation starts a conversation. Messages are added to it over
+	// NATS. A project that does not exist is NOT_FOUND.
+	CreateConversation(ctx context.Context, in *CreateConversationRequest, opts ...grpc.CallOption) (*CreateConversationResponse, error)
+	ListConversations(ctx context.Context, in *ListConversationsRequest, opts ...grpc.CallOption) (*ListConversationsResponse, error)
+	// GetThread returns the thread of a message to a student who may read it:
+	// its author, a participant of its direct conversation, or a member of the
+	// project of its conversation or of the team it was posted to. Threads
+	// outside of conversations and teams are open to all. Anyone else gets
+	// PERMISSION_DENIED.
+	GetThread(ctx context.Context, in *GetThreadRequest, opts ...grpc.CallOption) (*GetThreadResponse, error)
+	// CheckPost runs the checks a message posted over NATS to a conversation or
+	// as a reply has to pass, without saving anything, so that the post can be
+	// refused before it is sent. An unknown conversation or message is
+	// NOT_FOUND; a student who is not a participant of the direct conversation
+	// or a member of the conversation's project is PERMISSION_DENIED.
+	CheckPost(ctx context.Context, in *CheckPostRequest, opts ...grpc.CallOption) (*CheckPostResponse, error)
+	// GetEditHistory returns a message with the texts it had before its edits,
+	// to those who may read its thread; other viewers are PERMISSION_DENIED.
+	// Messages are edited and deleted over NATS; deleting one keeps its
+	// history, which only the author can still read.
+	GetEditHistory(ctx context.Context, in *GetEditHistoryRequest, opts ...grpc.CallOption) (*GetEditHistoryResponse, error)
+	// MarkRead records that a student read messages and publishes a
+	// message.read event. A message of a direct conversation email is not part
+	// of is PERMISSION_DENIED. Marking messages again is a no-op.
+	MarkRead(ctx context.Context, in *MarkReadRequest, opts ...grpc.CallOption) (*MarkReadResponse, error)
+	// GetUnreadCounts counts the messages others posted to the conversations
+	// of a student that the student has not read
+	GetUnreadCounts(ctx context.Context, in *GetUnreadCountsRequest, opts ...grpc.CallOption) (*GetUnreadCountsResponse, error)
 }
 
 type messageServiceClient struct {
@@ -40,6 +87,7 @@ func NewMessageServiceClient(cc grpc.ClientConnInterface) MessageServiceClient {
 	return &messageServiceClient{cc}
 }
 
+// Deprecated: Do not use.
 func (c *messageServiceClient) GetMessagesByEmail(ctx context.Context, in *GetMessagesByEmailRequest, opts ...grpc.CallOption) (*GetMessagesByEmailResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(GetMessagesByEmailResponse)
@@ -50,14 +98,160 @@ func (c *messageServiceClient) GetMessagesByEmail(ctx context.Context, in *GetMe
 	return out, nil
 }
 
+func (c *messageServiceClient) ListMessages(ctx context.Context, in *ListMessagesRequest, opts ...grpc.CallOption) (*ListMessagesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListMessagesResponse)
+	err := c.cc.Invoke(ctx, MessageService_ListMessages_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *messageServiceClient) ExportMessages(ctx context.Context, in *ExportMessagesRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ExportMessagesResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &MessageService_ServiceDesc.Streams[0], MessageService_ExportMessages_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ExportMessagesRequest, ExportMessagesResponse]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type MessageService_ExportMessagesClient = grpc.ServerStreamingClient[ExportMessagesResponse]
+
+func (c *messageServiceClient) SearchMessages(ctx context.Context, in *SearchMessagesRequest, opts ...grpc.CallOption) (*SearchMessagesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SearchMessagesResponse)
+	err := c.cc.Invoke(ctx, MessageService_SearchMessages_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *messageServiceClient) CreateConversation(ctx context.Context, in *CreateConversationRequest, opts ...grpc.CallOption) (*CreateConversationResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreateConversationResponse)
+	err := c.cc.Invoke(ctx, MessageService_CreateConversation_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *messageServiceClient) ListConversations(ctx context.Context, in *ListConversationsRequest, opts ...grpc.CallOption) (*ListConversationsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListConversationsResponse)
+	err := c.cc.Invoke(ctx, MessageService_ListConversations_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *messageServiceClient) GetThread(ctx context.Context, in *GetThreadRequest, opts ...grpc.CallOption) (*GetThreadResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetThreadResponse)
+	err := c.cc.Invoke(ctx, MessageService_GetThread_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *messageServiceClient) CheckPost(ctx context.Context, in *CheckPostRequest, opts ...grpc.CallOption) (*CheckPostResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CheckPostResponse)
+	err := c.cc.Invoke(ctx, MessageService_CheckPost_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *messageServiceClient) GetEditHistory(ctx context.Context, in *GetEditHistoryRequest, opts ...grpc.CallOption) (*GetEditHistoryResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetEditHistoryResponse)
+	err := c.cc.Invoke(ctx, MessageService_GetEditHistory_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *messageServiceClient) MarkRead(ctx context.Context, in *MarkReadRequest, opts ...grpc.CallOption) (*MarkReadResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(MarkReadResponse)
+	err := c.cc.Invoke(ctx, MessageService_MarkRead_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *messageServiceClient) GetUnreadCounts(ctx context.Context, in *GetUnreadCountsRequest, opts ...grpc.CallOption) (*GetUnreadCountsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetUnreadCountsResponse)
+	err := c.cc.Invoke(ctx, MessageService_GetUnreadCounts_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // MessageServiceServer is the server API for MessageService service.
 // All implementations must embed UnimplementedMessageServiceServer
 // for forward compatibility.
 //
 // MessageService provides operations on messages
 type MessageServiceServer interface {
-	// GetMessagesByEmail returns messages filtered by email
+	// Deprecated: Do not use.
+	// GetMessagesByEmail returns every message filtered by email, team or both,
+	// without a limit. Use ListMessages instead.
 	GetMessagesByEmail(context.Context, *GetMessagesByEmailRequest) (*GetMessagesByEmailResponse, error)
+	// ListMessages returns a page of the matching messages, newest first
+	ListMessages(context.Context, *ListMessagesRequest) (*ListMessagesResponse, error)
+	// ExportMessages streams all matching messages in batches. The response
+	// header metadata carries a content-disposition with a suggested file name.
+	ExportMessages(*ExportMessagesRequest, grpc.ServerStreamingServer[ExportMessagesResponse]) error
+	// SearchMessages returns the messages best matching a full-text query
+	SearchMessages(context.Context, *SearchMessagesRequest) (*SearchMessagesResponse, error)
+	// CreateConversation starts a conversation. Messages are added to it over
+	// NATS. A project that does not exist is NOT_FOUND.
+	CreateConversation(context.Context, *CreateConversationRequest) (*CreateConversationResponse, error)
+	ListConversations(context.Context, *ListConversationsRequest) (*ListConversationsResponse, error)
+	// GetThread returns the thread of a message to a student who may read it:
+	// its author, a participant of its direct conversation, or a member of the
+	// project of its conversation or of the team it was posted to. Threads
+	// outside of conversations and teams are open to all. Anyone else gets
+	// PERMISSION_DENIED.
+	GetThread(context.Context, *GetThreadRequest) (*GetThreadResponse, error)
+	// CheckPost runs the checks a message posted over NATS to a conversation or
+	// as a reply has to pass, without saving anything, so that the post can be
+	// refused before it is sent. An unknown conversation or message is
+	// NOT_FOUND; a student who is not a participant of the direct conversation
+	// or a member of the conversation's project is PERMISSION_DENIED.
+	CheckPost(context.Context, *CheckPostRequest) (*CheckPostResponse, error)
+	// GetEditHistory returns a message with the texts it had before its edits,
+	// to those who may read its thread; other viewers are PERMISSION_DENIED.
+	// Messages are edited and deleted over NATS; deleting one keeps its
+	// history, which only the author can still read.
+	GetEditHistory(context.Context, *GetEditHistoryRequest) (*GetEditHistoryResponse, error)
+	// MarkRead records that a student read messages and publishes a
+	// message.read event. A message of a direct conversation email is not part
+	// of is PERMISSION_DENIED. Marking messages again is a no-op.
+	MarkRead(context.Context, *MarkReadRequest) (*MarkReadResponse, error)
+	// GetUnreadCounts counts the messages others posted to the conversations
+	// of a student that the student has not read
+	GetUnreadCounts(context.Context, *GetUnreadCountsRequest) (*GetUnreadCountsResponse, error)
 	mustEmbedUnimplementedMessageServiceServer()
 }
 
@@ -71,6 +265,36 @@ type UnimplementedMessageServiceServer struct{}
 func (UnimplementedMessageServiceServer) GetMessagesByEmail(context.Context, *GetMessagesByEmailRequest) (*GetMessagesByEmailResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetMessagesByEmail not implemented")
 }
+func (UnimplementedMessageServiceServer) ListMessages(context.Context, *ListMessagesRequest) (*ListMessagesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListMessages not implemented")
+}
+func (UnimplementedMessageServiceServer) ExportMessages(*ExportMessagesRequest, grpc.ServerStreamingServer[ExportMessagesResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method ExportMessages not implemented")
+}
+func (UnimplementedMessageServiceServer) SearchMessages(context.Context, *SearchMessagesRequest) (*SearchMessagesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SearchMessages not implemented")
+}
+func (UnimplementedMessageServiceServer) CreateConversation(context.Context, *CreateConversationRequest) (*CreateConversationResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateConversation not implemented")
+}
+func (UnimplementedMessageServiceServer) ListConversations(context.Context, *ListConversationsRequest) (*ListConversationsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListConversations not implemented")
+}
+func (UnimplementedMessageServiceServer) GetThread(context.Context, *GetThreadRequest) (*GetThreadResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetThread not implemented")
+}
+func (UnimplementedMessageServiceServer) CheckPost(context.Context, *CheckPostRequest) (*CheckPostResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CheckPost not implemented")
+}
+func (UnimplementedMessageServiceServer) GetEditHistory(context.Context, *GetEditHistoryRequest) (*GetEditHistoryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetEditHistory not implemented")
+}
+func (UnimplementedMessageServiceServer) MarkRead(context.Context, *MarkReadRequest) (*MarkReadResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method MarkRead not implemented")
+}
+func (UnimplementedMessageServiceServer) GetUnreadCounts(context.Context, *GetUnreadCountsRequest) (*GetUnreadCountsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetUnreadCounts not implemented")
+}
 func (UnimplementedMessageServiceServer) mustEmbedUnimplementedMessageServiceServer() {}
 func (UnimplementedMessageServiceServer) testEmbeddedByValue()                        {}
 
@@ -110,6 +334,179 @@ func _MessageService_GetMessagesByEmail_Handler(srv interface{}, ctx context.Con
 	return interceptor(ctx, in, info, handler)
 }
 
+func _MessageService_ListMessages_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListMessagesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MessageServiceServer).ListMessages(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MessageService_ListMessages_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MessageServiceServer).ListMessages(ctx, req.(*ListMessagesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MessageService_ExportMessages_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ExportMessagesRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(MessageServiceServer).ExportMessages(m, &grpc.GenericServerStream[ExportMessagesRequest, ExportMessagesResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type MessageService_ExportMessagesServer = grpc.ServerStreamingServer[ExportMessagesResponse]
+
+func _MessageService_SearchMessages_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SearchMessagesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MessageServiceServer).SearchMessages(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MessageService_SearchMessages_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MessageServiceServer).SearchMessages(ctx, req.(*SearchMessagesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MessageService_CreateConversation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateConversationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MessageServiceServer).CreateConversation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MessageService_CreateConversation_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MessageServiceServer).CreateConversation(ctx, req.(*CreateConversationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MessageService_ListConversations_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListConversationsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MessageServiceServer).ListConversations(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MessageService_ListConversations_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MessageServiceServer).ListConversations(ctx, req.(*ListConversationsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MessageService_GetThread_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetThreadRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MessageServiceServer).GetThread(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MessageService_GetThread_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MessageServiceServer).GetThread(ctx, req.(*GetThreadRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MessageService_CheckPost_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CheckPostRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MessageServiceServer).CheckPost(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MessageService_CheckPost_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MessageServiceServer).CheckPost(ctx, req.(*CheckPostRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MessageService_GetEditHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetEditHistoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MessageServiceServer).GetEditHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MessageService_GetEditHistory_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MessageServiceServer).GetEditHistory(ctx, req.(*GetEditHistoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MessageService_MarkRead_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MarkReadRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MessageServiceServer).MarkRead(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MessageService_MarkRead_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MessageServiceServer).MarkRead(ctx, req.(*MarkReadRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MessageService_GetUnreadCounts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetUnreadCountsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MessageServiceServer).GetUnreadCounts(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MessageService_GetUnreadCounts_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MessageServiceServer).GetUnreadCounts(ctx, req.(*GetUnreadCountsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // MessageService_ServiceDesc is the grpc.ServiceDesc for MessageService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -121,7 +518,49 @@ var MessageService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetMessagesByEmail",
 			Handler:    _MessageService_GetMessagesByEmail_Handler,
 		},
+		{
+			MethodName: "ListMessages",
+			Handler:    _MessageService_ListMessages_Handler,
+		},
+		{
+			MethodName: "SearchMessages",
+			Handler:    _MessageService_SearchMessages_Handler,
+		},
+		{
+			MethodName: "CreateConversation",
+			Handler:    _MessageService_CreateConversation_Handler,
+		},
+		{
+			MethodName: "ListConversations",
+			Handler:    _MessageService_ListConversations_Handler,
+		},
+		{
+			MethodName: "GetThread",
+			Handler:    _MessageService_GetThread_Handler,
+		},
+		{
+			MethodName: "CheckPost",
+			Handler:    _MessageService_CheckPost_Handler,
+		},
+		{
+			MethodName: "GetEditHistory",
+			Handler:    _MessageService_GetEditHistory_Handler,
+		},
+		{
+			MethodName: "MarkRead",
+			Handler:    _MessageService_MarkRead_Handler,
+		},
+		{
+			MethodName: "GetUnreadCounts",
+			Handler:    _MessageService_GetUnreadCounts_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "ExportMessages",
+			Handler:       _MessageService_ExportMessages_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "message/v1/message.proto",
 }
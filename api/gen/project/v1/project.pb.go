@@ -9,6 +9,8 @@ package projectv1
 import (
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	fieldmaskpb "google.golang.org/protobuf/types/known/fieldmaskpb"
+	structpb "google.golang.org/protobuf/types/known/structpb"
 	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
 	reflect "reflect"
 	sync "sync"
@@ -22,13 +24,243 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+// ProjectStatus is the lifecycle state of a project. Allowed transitions are
+// DRAFT -> ACTIVE -> COMPLETED -> ARCHIVED, plus DRAFT/ACTIVE -> ARCHIVED.
+type ProjectStatus int32
+
+const (
+	ProjectStatus_PROJECT_STATUS_UNSPECIFIED ProjectStatus = 0
+	ProjectStatus_PROJECT_STATUS_DRAFT       ProjectStatus = 1
+	ProjectStatus_PROJECT_STATUS_ACTIVE      ProjectStatus = 2
+	ProjectStatus_PROJECT_STATUS_COMPLETED   ProjectStatus = 3
+	ProjectStatus_PROJECT_STATUS_ARCHIVED    ProjectStatus = 4
+)
+
+// Enum value maps for ProjectStatus.
+var (
+	ProjectStatus_name = map[int32]string{
+		0: "PROJECT_STATUS_UNSPECIFIED",
+		1: "PROJECT_STATUS_DRAFT",
+		2: "PROJECT_STATUS_ACTIVE",
+		3: "PROJECT_STATUS_COMPLETED",
+		4: "PROJECT_STATUS_ARCHIVED",
+	}
+	ProjectStatus_value = map[string]int32{
+		"PROJECT_STATUS_UNSPECIFIED": 0,
+		"PROJECT_STATUS_DRAFT":       1,
+		"PROJECT_STATUS_ACTIVE":      2,
+		"PROJECT_STATUS_COMPLETED":   3,
+		"PROJECT_STATUS_ARCHIVED":    4,
+	}
+)
+
+func (x ProjectStatus) Enum() *ProjectStatus {
+	p := new(ProjectStatus)
+	*p = x
+	return p
+}
+
+func (x ProjectStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ProjectStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_project_v1_project_proto_enumTypes[0].Descriptor()
+}
+
+func (ProjectStatus) Type() protoreflect.EnumType {
+	return &file_project_v1_project_proto_enumTypes[0]
+}
+
+func (x ProjectStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ProjectStatus.Descriptor instead.
+func (ProjectStatus) EnumDescriptor() ([]byte, []int) {
+	return file_project_v1_project_proto_rawDescGZIP(), []int{0}
+}
+
+// TagMatch says how the tags of a ListProjectsRequest are combined
+type TagMatch int32
+
+const (
+	// Same as TAG_MATCH_ANY
+	TagMatch_TAG_MATCH_UNSPECIFIED TagMatch = 0
+	// The project has at least one of the tags
+	TagMatch_TAG_MATCH_ANY TagMatch = 1
+	// The project has every one of the tags
+	TagMatch_TAG_MATCH_ALL TagMatch = 2
+)
+
+// Enum value maps for TagMatch.
+var (
+	TagMatch_name = map[int32]string{
+		0: "TAG_MATCH_UNSPECIFIED",
+		1: "TAG_MATCH_ANY",
+		2: "TAG_MATCH_ALL",
+	}
+	TagMatch_value = map[string]int32{
+		"TAG_MATCH_UNSPECIFIED": 0,
+		"TAG_MATCH_ANY":         1,
+		"TAG_MATCH_ALL":         2,
+	}
+)
+
+func (x TagMatch) Enum() *TagMatch {
+	p := new(TagMatch)
+	*p = x
+	return p
+}
+
+func (x TagMatch) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (TagMatch) Descriptor() protoreflect.EnumDescriptor {
+	return file_project_v1_project_proto_enumTypes[1].Descriptor()
+}
+
+func (TagMatch) Type() protoreflect.EnumType {
+	return &file_project_v1_project_proto_enumTypes[1]
+}
+
+func (x TagMatch) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use TagMatch.Descriptor instead.
+func (TagMatch) EnumDescriptor() ([]byte, []int) {
+	return file_project_v1_project_proto_rawDescGZIP(), []int{1}
+}
+
+// MemberRole is the role a student has within a project
+type MemberRole int32
+
+const (
+	MemberRole_MEMBER_ROLE_UNSPECIFIED MemberRole = 0
+	MemberRole_MEMBER_ROLE_OWNER       MemberRole = 1
+	MemberRole_MEMBER_ROLE_CONTRIBUTOR MemberRole = 2
+	MemberRole_MEMBER_ROLE_VIEWER      MemberRole = 3
+	// Grades the project's submissions; instructors do not submit
+	MemberRole_MEMBER_ROLE_INSTRUCTOR MemberRole = 4
+)
+
+// Enum value maps for MemberRole.
+var (
+	MemberRole_name = map[int32]string{
+		0: "MEMBER_ROLE_UNSPECIFIED",
+		1: "MEMBER_ROLE_OWNER",
+		2: "MEMBER_ROLE_CONTRIBUTOR",
+		3: "MEMBER_ROLE_VIEWER",
+		4: "MEMBER_ROLE_INSTRUCTOR",
+	}
+	MemberRole_value = map[string]int32{
+		"MEMBER_ROLE_UNSPECIFIED": 0,
+		"MEMBER_ROLE_OWNER":       1,
+		"MEMBER_ROLE_CONTRIBUTOR": 2,
+		"MEMBER_ROLE_VIEWER":      3,
+		"MEMBER_ROLE_INSTRUCTOR":  4,
+	}
+)
+
+func (x MemberRole) Enum() *MemberRole {
+	p := new(MemberRole)
+	*p = x
+	return p
+}
+
+func (x MemberRole) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (MemberRole) Descriptor() protoreflect.EnumDescriptor {
+	return file_project_v1_project_proto_enumTypes[2].Descriptor()
+}
+
+func (MemberRole) Type() protoreflect.EnumType {
+	return &file_project_v1_project_proto_enumTypes[2]
+}
+
+func (x MemberRole) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use MemberRole.Descriptor instead.
+func (MemberRole) EnumDescriptor() ([]byte, []int) {
+	return file_project_v1_project_proto_rawDescGZIP(), []int{2}
+}
+
+// ProjectEventType is the kind of change a ProjectEvent describes
+type ProjectEventType int32
+
+const (
+	ProjectEventType_PROJECT_EVENT_TYPE_UNSPECIFIED ProjectEventType = 0
+	ProjectEventType_PROJECT_EVENT_TYPE_CREATED     ProjectEventType = 1
+	ProjectEventType_PROJECT_EVENT_TYPE_UPDATED     ProjectEventType = 2
+	ProjectEventType_PROJECT_EVENT_TYPE_DELETED     ProjectEventType = 3
+)
+
+// Enum value maps for ProjectEventType.
+var (
+	ProjectEventType_name = map[int32]string{
+		0: "PROJECT_EVENT_TYPE_UNSPECIFIED",
+		1: "PROJECT_EVENT_TYPE_CREATED",
+		2: "PROJECT_EVENT_TYPE_UPDATED",
+		3: "PROJECT_EVENT_TYPE_DELETED",
+	}
+	ProjectEventType_value = map[string]int32{
+		"PROJECT_EVENT_TYPE_UNSPECIFIED": 0,
+		"PROJECT_EVENT_TYPE_CREATED":     1,
+		"PROJECT_EVENT_TYPE_UPDATED":     2,
+		"PROJECT_EVENT_TYPE_DELETED":     3,
+	}
+)
+
+func (x ProjectEventType) Enum() *ProjectEventType {
+	p := new(ProjectEventType)
+	*p = x
+	return p
+}
+
+func (x ProjectEventType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ProjectEventType) Descriptor() protoreflect.EnumDescriptor {
+	return file_project_v1_project_proto_enumTypes[3].Descriptor()
+}
+
+func (ProjectEventType) Type() protoreflect.EnumType {
+	return &file_project_v1_project_proto_enumTypes[3]
+}
+
+func (x ProjectEventType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ProjectEventType.Descriptor instead.
+func (ProjectEventType) EnumDescriptor() ([]byte, []int) {
+	return file_project_v1_project_proto_rawDescGZIP(), []int{3}
+}
+
 // Project represents a project entity
 type Project struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
-	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
-	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
-	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Id          int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name        string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	CreatedAt   *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt   *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	Description string                 `protobuf:"bytes,5,opt,name=description,proto3" json:"description,omitempty"`
+	Status      ProjectStatus          `protobuf:"varint,6,opt,name=status,proto3,enum=project.v1.ProjectStatus" json:"status,omitempty"`
+	// Optional planned start and due dates
+	StartDate *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=start_date,json=startDate,proto3" json:"start_date,omitempty"`
+	DueDate   *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=due_date,json=dueDate,proto3" json:"due_date,omitempty"`
+	// Incremented on every update; send it back in UpdateProjectRequest.version
+	// to update only if nobody else changed the project in the meantime
+	Version int64 `protobuf:"varint,9,opt,name=version,proto3" json:"version,omitempty"`
+	// Lowercase labels, sorted by name
+	Tags          []string `protobuf:"bytes,10,rep,name=tags,proto3" json:"tags,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -40,14 +272,1956 @@ func (x *Project) Reset() {
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *Project) String() string {
+func (x *Project) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Project) ProtoMessage() {}
+
+func (x *Project) ProtoReflect() protoreflect.Message {
+	mi := &file_project_v1_project_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Project.ProtoReflect.Descriptor instead.
+func (*Project) Descriptor() ([]byte, []int) {
+	return file_project_v1_project_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Project) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Project) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Project) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Project) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+func (x *Project) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *Project) GetStatus() ProjectStatus {
+	if x != nil {
+		return x.Status
+	}
+	return ProjectStatus_PROJECT_STATUS_UNSPECIFIED
+}
+
+func (x *Project) GetStartDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartDate
+	}
+	return nil
+}
+
+func (x *Project) GetDueDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.DueDate
+	}
+	return nil
+}
+
+func (x *Project) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *Project) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+// GetAllProjectsRequest is the request message for GetAllProjects RPC
+type GetAllProjectsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetAllProjectsRequest) Reset() {
+	*x = GetAllProjectsRequest{}
+	mi := &file_project_v1_project_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetAllProjectsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAllProjectsRequest) ProtoMessage() {}
+
+func (x *GetAllProjectsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_project_v1_project_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAllProjectsRequest.ProtoReflect.Descriptor instead.
+func (*GetAllProjectsRequest) Descriptor() ([]byte, []int) {
+	return file_project_v1_project_proto_rawDescGZIP(), []int{1}
+}
+
+// GetAllProjectsResponse is the response message for GetAllProjects RPC
+type GetAllProjectsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Projects      []*Project             `protobuf:"bytes,1,rep,name=projects,proto3" json:"projects,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetAllProjectsResponse) Reset() {
+	*x = GetAllProjectsResponse{}
+	mi := &file_project_v1_project_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetAllProjectsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAllProjectsResponse) ProtoMessage() {}
+
+func (x *GetAllProjectsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_project_v1_project_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAllProjectsResponse.ProtoReflect.Descriptor instead.
+func (*GetAllProjectsResponse) Descriptor() ([]byte, []int) {
+	return file_project_v1_project_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *GetAllProjectsResponse) GetProjects() []*Project {
+	if x != nil {
+		return x.Projects
+	}
+	return nil
+}
+
+// ListProjectsRequest is the request message for ListProjects RPC
+type ListProjectsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Maximum number of projects to return. Defaults to 50; values above 1000 are coerced to 1000.
+	PageSize int32 `protobuf:"varint,1,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	// next_page_token from a previous response. All other fields must match the
+	// request that produced the token.
+	PageToken string `protobuf:"bytes,2,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	// Case-insensitive substring the project name must contain
+	NameContains string `protobuf:"bytes,3,opt,name=name_contains,json=nameContains,proto3" json:"name_contains,omitempty"`
+	// Inclusive lower and exclusive upper bounds on created_at
+	CreatedAfter  *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=created_after,json=createdAfter,proto3" json:"created_after,omitempty"`
+	CreatedBefore *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=created_before,json=createdBefore,proto3" json:"created_before,omitempty"`
+	// Inclusive lower and exclusive upper bounds on updated_at
+	UpdatedAfter  *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=updated_after,json=updatedAfter,proto3" json:"updated_after,omitempty"`
+	UpdatedBefore *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=updated_before,json=updatedBefore,proto3" json:"updated_before,omitempty"`
+	// One of "id", "name", "created_at" or "updated_at", optionally followed by
+	// " desc". Defaults to "id".
+	OrderBy string `protobuf:"bytes,8,opt,name=order_by,json=orderBy,proto3" json:"order_by,omitempty"`
+	// Only return projects in this status
+	Status ProjectStatus `protobuf:"varint,9,opt,name=status,proto3,enum=project.v1.ProjectStatus" json:"status,omitempty"`
+	// Only return projects carrying these tags, combined as tag_match says
+	Tags          []string `protobuf:"bytes,10,rep,name=tags,proto3" json:"tags,omitempty"`
+	TagMatch      TagMatch `protobuf:"varint,11,opt,name=tag_match,json=tagMatch,proto3,enum=project.v1.TagMatch" json:"tag_match,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListProjectsRequest) Reset() {
+	*x = ListProjectsRequest{}
+	mi := &file_project_v1_project_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListProjectsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListProjectsRequest) ProtoMessage() {}
+
+func (x *ListProjectsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_project_v1_project_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListProjectsRequest.ProtoReflect.Descriptor instead.
+func (*ListProjectsRequest) Descriptor() ([]byte, []int) {
+	return file_project_v1_project_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *ListProjectsRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListProjectsRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+func (x *ListProjectsRequest) GetNameContains() string {
+	if x != nil {
+		return x.NameContains
+	}
+	return ""
+}
+
+func (x *ListProjectsRequest) GetCreatedAfter() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAfter
+	}
+	return nil
+}
+
+func (x *ListProjectsRequest) GetCreatedBefore() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedBefore
+	}
+	return nil
+}
+
+func (x *ListProjectsRequest) GetUpdatedAfter() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAfter
+	}
+	return nil
+}
+
+func (x *ListProjectsRequest) GetUpdatedBefore() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedBefore
+	}
+	return nil
+}
+
+func (x *ListProjectsRequest) GetOrderBy() string {
+	if x != nil {
+		return x.OrderBy
+	}
+	return ""
+}
+
+func (x *ListProjectsRequest) GetStatus() ProjectStatus {
+	if x != nil {
+		return x.Status
+	}
+	return ProjectStatus_PROJECT_STATUS_UNSPECIFIED
+}
+
+func (x *ListProjectsRequest) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *ListProjectsRequest) GetTagMatch() TagMatch {
+	if x != nil {
+		return x.TagMatch
+	}
+	return TagMatch_TAG_MATCH_UNSPECIFIED
+}
+
+// ListProjectsResponse is the response message for ListProjects RPC
+type ListProjectsResponse struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Projects []*Project             `protobuf:"bytes,1,rep,name=projects,proto3" json:"projects,omitempty"`
+	// Token for the next page, empty when there are no more results
+	NextPageToken string `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListProjectsResponse) Reset() {
+	*x = ListProjectsResponse{}
+	mi := &file_project_v1_project_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListProjectsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListProjectsResponse) ProtoMessage() {}
+
+func (x *ListProjectsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_project_v1_project_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListProjectsResponse.ProtoReflect.Descriptor instead.
+func (*ListProjectsResponse) Descriptor() ([]byte, []int) {
+	return file_project_v1_project_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ListProjectsResponse) GetProjects() []*Project {
+	if x != nil {
+		return x.Projects
+	}
+	return nil
+}
+
+func (x *ListProjectsResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+// SearchProjectsRequest is the request message for SearchProjects RPC
+type SearchProjectsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Free text. Every word must match the start of a word in the project name
+	// or description; punctuation is ignored.
+	Query string `protobuf:"bytes,1,opt,name=query,proto3" json:"query,omitempty"`
+	// Maximum number of results. Defaults to 20; values above 100 are coerced to 100.
+	PageSize      int32 `protobuf:"varint,2,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchProjectsRequest) Reset() {
+	*x = SearchProjectsRequest{}
+	mi := &file_project_v1_project_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchProjectsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchProjectsRequest) ProtoMessage() {}
+
+func (x *SearchProjectsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_project_v1_project_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchProjectsRequest.ProtoReflect.Descriptor instead.
+func (*SearchProjectsRequest) Descriptor() ([]byte, []int) {
+	return file_project_v1_project_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *SearchProjectsRequest) GetQuery() string {
+	if x != nil {
+		return x.Query
+	}
+	return ""
+}
+
+func (x *SearchProjectsRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+// ProjectSearchResult is a project matching a search
+type ProjectSearchResult struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Project *Project               `protobuf:"bytes,1,opt,name=project,proto3" json:"project,omitempty"`
+	// Relevance in [0, 1), higher is better. Name matches weigh more than
+	// description matches.
+	Rank float64 `protobuf:"fixed64,2,opt,name=rank,proto3" json:"rank,omitempty"`
+	// The name and description as HTML-escaped text with matches wrapped in
+	// <mark>. The description is cut down to the fragments around the matches.
+	NameHighlight        string `protobuf:"bytes,3,opt,name=name_highlight,json=nameHighlight,proto3" json:"name_highlight,omitempty"`
+	DescriptionHighlight string `protobuf:"bytes,4,opt,name=description_highlight,json=descriptionHighlight,proto3" json:"description_highlight,omitempty"`
+	unknownFields        protoimpl.UnknownFields
+	sizeCache            protoimpl.SizeCache
+}
+
+func (x *ProjectSearchResult) Reset() {
+	*x = ProjectSearchResult{}
+	mi := &file_project_v1_project_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ProjectSearchResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProjectSearchResult) ProtoMessage() {}
+
+func (x *ProjectSearchResult) ProtoReflect() protoreflect.Message {
+	mi := &file_project_v1_project_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProjectSearchResult.ProtoReflect.Descriptor instead.
+func (*ProjectSearchResult) Descriptor() ([]byte, []int) {
+	return file_project_v1_project_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ProjectSearchResult) GetProject() *Project {
+	if x != nil {
+		return x.Project
+	}
+	return nil
+}
+
+func (x *ProjectSearchResult) GetRank() float64 {
+	if x != nil {
+		return x.Rank
+	}
+	return 0
+}
+
+func (x *ProjectSearchResult) GetNameHighlight() string {
+	if x != nil {
+		return x.NameHighlight
+	}
+	return ""
+}
+
+func (x *ProjectSearchResult) GetDescriptionHighlight() string {
+	if x != nil {
+		return x.DescriptionHighlight
+	}
+	return ""
+}
+
+// SearchProjectsResponse is the response message for SearchProjects RPC
+type SearchProjectsResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Best match first
+	Results       []*ProjectSearchResult `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchProjectsResponse) Reset() {
+	*x = SearchProjectsResponse{}
+	mi := &file_project_v1_project_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchProjectsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchProjectsResponse) ProtoMessage() {}
+
+func (x *SearchProjectsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_project_v1_project_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchProjectsResponse.ProtoReflect.Descriptor instead.
+func (*SearchProjectsResponse) Descriptor() ([]byte, []int) {
+	return file_project_v1_project_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *SearchProjectsResponse) GetResults() []*ProjectSearchResult {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+// GetProjectRequest is the request message for GetProject RPC
+type GetProjectRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetProjectRequest) Reset() {
+	*x = GetProjectRequest{}
+	mi := &file_project_v1_project_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetProjectRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetProjectRequest) ProtoMessage() {}
+
+func (x *GetProjectRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_project_v1_project_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetProjectRequest.ProtoReflect.Descriptor instead.
+func (*GetProjectRequest) Descriptor() ([]byte, []int) {
+	return file_project_v1_project_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *GetProjectRequest) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+// GetProjectResponse is the response message for GetProject RPC
+type GetProjectResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Project       *Project               `protobuf:"bytes,1,opt,name=project,proto3" json:"project,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetProjectResponse) Reset() {
+	*x = GetProjectResponse{}
+	mi := &file_project_v1_project_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetProjectResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetProjectResponse) ProtoMessage() {}
+
+func (x *GetProjectResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_project_v1_project_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetProjectResponse.ProtoReflect.Descriptor instead.
+func (*GetProjectResponse) Descriptor() ([]byte, []int) {
+	return file_project_v1_project_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *GetProjectResponse) GetProject() *Project {
+	if x != nil {
+		return x.Project
+	}
+	return nil
+}
+
+// CreateProjectRequest is the request message for CreateProject RPC
+type CreateProjectRequest struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Name        string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Description string                 `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
+	StartDate   *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=start_date,json=startDate,proto3" json:"start_date,omitempty"`
+	DueDate     *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=due_date,json=dueDate,proto3" json:"due_date,omitempty"`
+	// Tags are trimmed, lowercased and deduplicated; at most 20 of up to 64
+	// characters each, without commas
+	Tags          []string `protobuf:"bytes,5,rep,name=tags,proto3" json:"tags,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateProjectRequest) Reset() {
+	*x = CreateProjectRequest{}
+	mi := &file_project_v1_project_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateProjectRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateProjectRequest) ProtoMessage() {}
+
+func (x *CreateProjectRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_project_v1_project_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateProjectRequest.ProtoReflect.Descriptor instead.
+func (*CreateProjectRequest) Descriptor() ([]byte, []int) {
+	return file_project_v1_project_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *CreateProjectRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CreateProjectRequest) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *CreateProjectRequest) GetStartDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartDate
+	}
+	return nil
+}
+
+func (x *CreateProjectRequest) GetDueDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.DueDate
+	}
+	return nil
+}
+
+func (x *CreateProjectRequest) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+// CreateProjectResponse is the response message for CreateProject RPC
+type CreateProjectResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Project       *Project               `protobuf:"bytes,1,opt,name=project,proto3" json:"project,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateProjectResponse) Reset() {
+	*x = CreateProjectResponse{}
+	mi := &file_project_v1_project_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateProjectResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateProjectResponse) ProtoMessage() {}
+
+func (x *CreateProjectResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_project_v1_project_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateProjectResponse.ProtoReflect.Descriptor instead.
+func (*CreateProjectResponse) Descriptor() ([]byte, []int) {
+	return file_project_v1_project_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *CreateProjectResponse) GetProject() *Project {
+	if x != nil {
+		return x.Project
+	}
+	return nil
+}
+
+// UpdateProjectRequest is the request message for UpdateProject RPC
+type UpdateProjectRequest struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Id          int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name        string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Description string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	StartDate   *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=start_date,json=startDate,proto3" json:"start_date,omitempty"`
+	DueDate     *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=due_date,json=dueDate,proto3" json:"due_date,omitempty"`
+	// Fields to update: "name", "description", "start_date", "due_date", "tags".
+	// When empty, every field that is set in the request is updated (AIP-134).
+	// Status is changed through TransitionProject only.
+	UpdateMask *fieldmaskpb.FieldMask `protobuf:"bytes,6,opt,name=update_mask,json=updateMask,proto3" json:"update_mask,omitempty"`
+	// When set, the update only applies if the project is still at this version
+	// and fails with ABORTED otherwise. Zero updates unconditionally.
+	Version int64 `protobuf:"varint,7,opt,name=version,proto3" json:"version,omitempty"`
+	// Replaces all tags of the project; use the "tags" mask path to clear them
+	Tags          []string `protobuf:"bytes,8,rep,name=tags,proto3" json:"tags,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateProjectRequest) Reset() {
+	*x = UpdateProjectRequest{}
+	mi := &file_project_v1_project_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateProjectRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateProjectRequest) ProtoMessage() {}
+
+func (x *UpdateProjectRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_project_v1_project_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateProjectRequest.ProtoReflect.Descriptor instead.
+func (*UpdateProjectRequest) Descriptor() ([]byte, []int) {
+	return file_project_v1_project_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *UpdateProjectRequest) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *UpdateProjectRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *UpdateProjectRequest) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *UpdateProjectRequest) GetStartDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartDate
+	}
+	return nil
+}
+
+func (x *UpdateProjectRequest) GetDueDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.DueDate
+	}
+	return nil
+}
+
+func (x *UpdateProjectRequest) GetUpdateMask() *fieldmaskpb.FieldMask {
+	if x != nil {
+		return x.UpdateMask
+	}
+	return nil
+}
+
+func (x *UpdateProjectRequest) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *UpdateProjectRequest) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+// UpdateProjectResponse is the response message for UpdateProject RPC
+type UpdateProjectResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Project       *Project               `protobuf:"bytes,1,opt,name=project,proto3" json:"project,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateProjectResponse) Reset() {
+	*x = UpdateProjectResponse{}
+	mi := &file_project_v1_project_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateProjectResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateProjectResponse) ProtoMessage() {}
+
+func (x *UpdateProjectResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_project_v1_project_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateProjectResponse.ProtoReflect.Descriptor instead.
+func (*UpdateProjectResponse) Descriptor() ([]byte, []int) {
+	return file_project_v1_project_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *UpdateProjectResponse) GetProject() *Project {
+	if x != nil {
+		return x.Project
+	}
+	return nil
+}
+
+// TransitionProjectRequest is the request message for TransitionProject RPC
+type TransitionProjectRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Status        ProjectStatus          `protobuf:"varint,2,opt,name=status,proto3,enum=project.v1.ProjectStatus" json:"status,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TransitionProjectRequest) Reset() {
+	*x = TransitionProjectRequest{}
+	mi := &file_project_v1_project_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TransitionProjectRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TransitionProjectRequest) ProtoMessage() {}
+
+func (x *TransitionProjectRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_project_v1_project_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TransitionProjectRequest.ProtoReflect.Descriptor instead.
+func (*TransitionProjectRequest) Descriptor() ([]byte, []int) {
+	return file_project_v1_project_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *TransitionProjectRequest) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *TransitionProjectRequest) GetStatus() ProjectStatus {
+	if x != nil {
+		return x.Status
+	}
+	return ProjectStatus_PROJECT_STATUS_UNSPECIFIED
+}
+
+// TransitionProjectResponse is the response message for TransitionProject RPC
+type TransitionProjectResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Project       *Project               `protobuf:"bytes,1,opt,name=project,proto3" json:"project,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TransitionProjectResponse) Reset() {
+	*x = TransitionProjectResponse{}
+	mi := &file_project_v1_project_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TransitionProjectResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TransitionProjectResponse) ProtoMessage() {}
+
+func (x *TransitionProjectResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_project_v1_project_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TransitionProjectResponse.ProtoReflect.Descriptor instead.
+func (*TransitionProjectResponse) Descriptor() ([]byte, []int) {
+	return file_project_v1_project_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *TransitionProjectResponse) GetProject() *Project {
+	if x != nil {
+		return x.Project
+	}
+	return nil
+}
+
+// DeleteProjectRequest is the request message for DeleteProject RPC
+type DeleteProjectRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteProjectRequest) Reset() {
+	*x = DeleteProjectRequest{}
+	mi := &file_project_v1_project_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteProjectRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteProjectRequest) ProtoMessage() {}
+
+func (x *DeleteProjectRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_project_v1_project_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteProjectRequest.ProtoReflect.Descriptor instead.
+func (*DeleteProjectRequest) Descriptor() ([]byte, []int) {
+	return file_project_v1_project_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *DeleteProjectRequest) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+// DeleteProjectResponse is the response message for DeleteProject RPC
+type DeleteProjectResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteProjectResponse) Reset() {
+	*x = DeleteProjectResponse{}
+	mi := &file_project_v1_project_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteProjectResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteProjectResponse) ProtoMessage() {}
+
+func (x *DeleteProjectResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_project_v1_project_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteProjectResponse.ProtoReflect.Descriptor instead.
+func (*DeleteProjectResponse) Descriptor() ([]byte, []int) {
+	return file_project_v1_project_proto_rawDescGZIP(), []int{17}
+}
+
+// HistoryEntry is one recorded change to a project or its members
+type HistoryEntry struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	// create, update, delete, restore, member_added or member_removed
+	Action string `protobuf:"bytes,2,opt,name=action,proto3" json:"action,omitempty"`
+	// Who made the change, as sent in the x-actor metadata, or "system"
+	Actor string `protobuf:"bytes,3,opt,name=actor,proto3" json:"actor,omitempty"`
+	// The changed fields before and after the change, keyed by JSON field name
+	Before        *structpb.Struct       `protobuf:"bytes,4,opt,name=before,proto3" json:"before,omitempty"`
+	After         *structpb.Struct       `protobuf:"bytes,5,opt,name=after,proto3" json:"after,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *HistoryEntry) Reset() {
+	*x = HistoryEntry{}
+	mi := &file_project_v1_project_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HistoryEntry) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HistoryEntry) ProtoMessage() {}
+
+func (x *HistoryEntry) ProtoReflect() protoreflect.Message {
+	mi := &file_project_v1_project_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HistoryEntry.ProtoReflect.Descriptor instead.
+func (*HistoryEntry) Descriptor() ([]byte, []int) {
+	return file_project_v1_project_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *HistoryEntry) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *HistoryEntry) GetAction() string {
+	if x != nil {
+		return x.Action
+	}
+	return ""
+}
+
+func (x *HistoryEntry) GetActor() string {
+	if x != nil {
+		return x.Actor
+	}
+	return ""
+}
+
+func (x *HistoryEntry) GetBefore() *structpb.Struct {
+	if x != nil {
+		return x.Before
+	}
+	return nil
+}
+
+func (x *HistoryEntry) GetAfter() *structpb.Struct {
+	if x != nil {
+		return x.After
+	}
+	return nil
+}
+
+func (x *HistoryEntry) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+// GetProjectHistoryRequest is the request message for GetProjectHistory RPC
+type GetProjectHistoryRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	// Maximum number of entries to return; defaults to 50, capped at 500
+	PageSize      int32  `protobuf:"varint,2,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	PageToken     string `protobuf:"bytes,3,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetProjectHistoryRequest) Reset() {
+	*x = GetProjectHistoryRequest{}
+	mi := &file_project_v1_project_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetProjectHistoryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetProjectHistoryRequest) ProtoMessage() {}
+
+func (x *GetProjectHistoryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_project_v1_project_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetProjectHistoryRequest.ProtoReflect.Descriptor instead.
+func (*GetProjectHistoryRequest) Descriptor() ([]byte, []int) {
+	return file_project_v1_project_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *GetProjectHistoryRequest) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *GetProjectHistoryRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *GetProjectHistoryRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+// GetProjectHistoryResponse is the response message for GetProjectHistory RPC
+type GetProjectHistoryResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Newest first
+	Entries       []*HistoryEntry `protobuf:"bytes,1,rep,name=entries,proto3" json:"entries,omitempty"`
+	NextPageToken string          `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetProjectHistoryResponse) Reset() {
+	*x = GetProjectHistoryResponse{}
+	mi := &file_project_v1_project_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetProjectHistoryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetProjectHistoryResponse) ProtoMessage() {}
+
+func (x *GetProjectHistoryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_project_v1_project_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetProjectHistoryResponse.ProtoReflect.Descriptor instead.
+func (*GetProjectHistoryResponse) Descriptor() ([]byte, []int) {
+	return file_project_v1_project_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *GetProjectHistoryResponse) GetEntries() []*HistoryEntry {
+	if x != nil {
+		return x.Entries
+	}
+	return nil
+}
+
+func (x *GetProjectHistoryResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+// RestoreProjectRequest is the request message for RestoreProject RPC
+type RestoreProjectRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RestoreProjectRequest) Reset() {
+	*x = RestoreProjectRequest{}
+	mi := &file_project_v1_project_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RestoreProjectRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RestoreProjectRequest) ProtoMessage() {}
+
+func (x *RestoreProjectRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_project_v1_project_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RestoreProjectRequest.ProtoReflect.Descriptor instead.
+func (*RestoreProjectRequest) Descriptor() ([]byte, []int) {
+	return file_project_v1_project_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *RestoreProjectRequest) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+// RestoreProjectResponse is the response message for RestoreProject RPC
+type RestoreProjectResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Project       *Project               `protobuf:"bytes,1,opt,name=project,proto3" json:"project,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RestoreProjectResponse) Reset() {
+	*x = RestoreProjectResponse{}
+	mi := &file_project_v1_project_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RestoreProjectResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RestoreProjectResponse) ProtoMessage() {}
+
+func (x *RestoreProjectResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_project_v1_project_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RestoreProjectResponse.ProtoReflect.Descriptor instead.
+func (*RestoreProjectResponse) Descriptor() ([]byte, []int) {
+	return file_project_v1_project_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *RestoreProjectResponse) GetProject() *Project {
+	if x != nil {
+		return x.Project
+	}
+	return nil
+}
+
+// ProjectMember links a student to a project
+type ProjectMember struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	ProjectId int32                  `protobuf:"varint,1,opt,name=project_id,json=projectId,proto3" json:"project_id,omitempty"`
+	StudentId int32                  `protobuf:"varint,2,opt,name=student_id,json=studentId,proto3" json:"student_id,omitempty"`
+	Role      MemberRole             `protobuf:"varint,3,opt,name=role,proto3,enum=project.v1.MemberRole" json:"role,omitempty"`
+	CreatedAt *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	// Team of the project the member is assigned to, 0 if none
+	TeamId int32 `protobuf:"varint,5,opt,name=team_id,json=teamId,proto3" json:"team_id,omitempty"`
+	// Whether the member leads their team
+	TeamLead      bool `protobuf:"varint,6,opt,name=team_lead,json=teamLead,proto3" json:"team_lead,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ProjectMember) Reset() {
+	*x = ProjectMember{}
+	mi := &file_project_v1_project_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ProjectMember) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProjectMember) ProtoMessage() {}
+
+func (x *ProjectMember) ProtoReflect() protoreflect.Message {
+	mi := &file_project_v1_project_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProjectMember.ProtoReflect.Descriptor instead.
+func (*ProjectMember) Descriptor() ([]byte, []int) {
+	return file_project_v1_project_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *ProjectMember) GetProjectId() int32 {
+	if x != nil {
+		return x.ProjectId
+	}
+	return 0
+}
+
+func (x *ProjectMember) GetStudentId() int32 {
+	if x != nil {
+		return x.StudentId
+	}
+	return 0
+}
+
+func (x *ProjectMember) GetRole() MemberRole {
+	if x != nil {
+		return x.Role
+	}
+	return MemberRole_MEMBER_ROLE_UNSPECIFIED
+}
+
+func (x *ProjectMember) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *ProjectMember) GetTeamId() int32 {
+	if x != nil {
+		return x.TeamId
+	}
+	return 0
+}
+
+func (x *ProjectMember) GetTeamLead() bool {
+	if x != nil {
+		return x.TeamLead
+	}
+	return false
+}
+
+// AddMemberRequest is the request message for AddMember RPC
+type AddMemberRequest struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	ProjectId int32                  `protobuf:"varint,1,opt,name=project_id,json=projectId,proto3" json:"project_id,omitempty"`
+	StudentId int32                  `protobuf:"varint,2,opt,name=student_id,json=studentId,proto3" json:"student_id,omitempty"`
+	Role      MemberRole             `protobuf:"varint,3,opt,name=role,proto3,enum=project.v1.MemberRole" json:"role,omitempty"`
+	// Student adding the member. Granting owner or instructor requires an
+	// owner or instructor of the project.
+	ActorId       int32 `protobuf:"varint,4,opt,name=actor_id,json=actorId,proto3" json:"actor_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AddMemberRequest) Reset() {
+	*x = AddMemberRequest{}
+	mi := &file_project_v1_project_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddMemberRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddMemberRequest) ProtoMessage() {}
+
+func (x *AddMemberRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_project_v1_project_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddMemberRequest.ProtoReflect.Descriptor instead.
+func (*AddMemberRequest) Descriptor() ([]byte, []int) {
+	return file_project_v1_project_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *AddMemberRequest) GetProjectId() int32 {
+	if x != nil {
+		return x.ProjectId
+	}
+	return 0
+}
+
+func (x *AddMemberRequest) GetStudentId() int32 {
+	if x != nil {
+		return x.StudentId
+	}
+	return 0
+}
+
+func (x *AddMemberRequest) GetRole() MemberRole {
+	if x != nil {
+		return x.Role
+	}
+	return MemberRole_MEMBER_ROLE_UNSPECIFIED
+}
+
+func (x *AddMemberRequest) GetActorId() int32 {
+	if x != nil {
+		return x.ActorId
+	}
+	return 0
+}
+
+// AddMemberResponse is the response message for AddMember RPC
+type AddMemberResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Member        *ProjectMember         `protobuf:"bytes,1,opt,name=member,proto3" json:"member,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AddMemberResponse) Reset() {
+	*x = AddMemberResponse{}
+	mi := &file_project_v1_project_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddMemberResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddMemberResponse) ProtoMessage() {}
+
+func (x *AddMemberResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_project_v1_project_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddMemberResponse.ProtoReflect.Descriptor instead.
+func (*AddMemberResponse) Descriptor() ([]byte, []int) {
+	return file_project_v1_project_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *AddMemberResponse) GetMember() *ProjectMember {
+	if x != nil {
+		return x.Member
+	}
+	return nil
+}
+
+// RemoveMemberRequest is the request message for RemoveMember RPC
+type RemoveMemberRequest struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	ProjectId int32                  `protobuf:"varint,1,opt,name=project_id,json=projectId,proto3" json:"project_id,omitempty"`
+	StudentId int32                  `protobuf:"varint,2,opt,name=student_id,json=studentId,proto3" json:"student_id,omitempty"`
+	// Student removing the member. Members may remove themselves; removing
+	// anyone else requires an owner or instructor of the project.
+	ActorId       int32 `protobuf:"varint,3,opt,name=actor_id,json=actorId,proto3" json:"actor_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RemoveMemberRequest) Reset() {
+	*x = RemoveMemberRequest{}
+	mi := &file_project_v1_project_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RemoveMemberRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoveMemberRequest) ProtoMessage() {}
+
+func (x *RemoveMemberRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_project_v1_project_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoveMemberRequest.ProtoReflect.Descriptor instead.
+func (*RemoveMemberRequest) Descriptor() ([]byte, []int) {
+	return file_project_v1_project_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *RemoveMemberRequest) GetProjectId() int32 {
+	if x != nil {
+		return x.ProjectId
+	}
+	return 0
+}
+
+func (x *RemoveMemberRequest) GetStudentId() int32 {
+	if x != nil {
+		return x.StudentId
+	}
+	return 0
+}
+
+func (x *RemoveMemberRequest) GetActorId() int32 {
+	if x != nil {
+		return x.ActorId
+	}
+	return 0
+}
+
+// RemoveMemberResponse is the response message for RemoveMember RPC
+type RemoveMemberResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RemoveMemberResponse) Reset() {
+	*x = RemoveMemberResponse{}
+	mi := &file_project_v1_project_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RemoveMemberResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoveMemberResponse) ProtoMessage() {}
+
+func (x *RemoveMemberResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_project_v1_project_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoveMemberResponse.ProtoReflect.Descriptor instead.
+func (*RemoveMemberResponse) Descriptor() ([]byte, []int) {
+	return file_project_v1_project_proto_rawDescGZIP(), []int{27}
+}
+
+// ListMembersRequest is the request message for ListMembers RPC
+type ListMembersRequest struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	ProjectId int32                  `protobuf:"varint,1,opt,name=project_id,json=projectId,proto3" json:"project_id,omitempty"`
+	// Only members of this team when set
+	TeamId        int32 `protobuf:"varint,2,opt,name=team_id,json=teamId,proto3" json:"team_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListMembersRequest) Reset() {
+	*x = ListMembersRequest{}
+	mi := &file_project_v1_project_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListMembersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListMembersRequest) ProtoMessage() {}
+
+func (x *ListMembersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_project_v1_project_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListMembersRequest.ProtoReflect.Descriptor instead.
+func (*ListMembersRequest) Descriptor() ([]byte, []int) {
+	return file_project_v1_project_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *ListMembersRequest) GetProjectId() int32 {
+	if x != nil {
+		return x.ProjectId
+	}
+	return 0
+}
+
+func (x *ListMembersRequest) GetTeamId() int32 {
+	if x != nil {
+		return x.TeamId
+	}
+	return 0
+}
+
+// ListMembersResponse is the response message for ListMembers RPC
+type ListMembersResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Members       []*ProjectMember       `protobuf:"bytes,1,rep,name=members,proto3" json:"members,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListMembersResponse) Reset() {
+	*x = ListMembersResponse{}
+	mi := &file_project_v1_project_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListMembersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListMembersResponse) ProtoMessage() {}
+
+func (x *ListMembersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_project_v1_project_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListMembersResponse.ProtoReflect.Descriptor instead.
+func (*ListMembersResponse) Descriptor() ([]byte, []int) {
+	return file_project_v1_project_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *ListMembersResponse) GetMembers() []*ProjectMember {
+	if x != nil {
+		return x.Members
+	}
+	return nil
+}
+
+// ListProjectsForStudentRequest is the request message for ListProjectsForStudent RPC
+type ListProjectsForStudentRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	StudentId     int32                  `protobuf:"varint,1,opt,name=student_id,json=studentId,proto3" json:"student_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListProjectsForStudentRequest) Reset() {
+	*x = ListProjectsForStudentRequest{}
+	mi := &file_project_v1_project_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListProjectsForStudentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListProjectsForStudentRequest) ProtoMessage() {}
+
+func (x *ListProjectsForStudentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_project_v1_project_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListProjectsForStudentRequest.ProtoReflect.Descriptor instead.
+func (*ListProjectsForStudentRequest) Descriptor() ([]byte, []int) {
+	return file_project_v1_project_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *ListProjectsForStudentRequest) GetStudentId() int32 {
+	if x != nil {
+		return x.StudentId
+	}
+	return 0
+}
+
+// StudentProject is a project together with the student's role in it
+type StudentProject struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Project       *Project               `protobuf:"bytes,1,opt,name=project,proto3" json:"project,omitempty"`
+	Role          MemberRole             `protobuf:"varint,2,opt,name=role,proto3,enum=project.v1.MemberRole" json:"role,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StudentProject) Reset() {
+	*x = StudentProject{}
+	mi := &file_project_v1_project_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StudentProject) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StudentProject) ProtoMessage() {}
+
+func (x *StudentProject) ProtoReflect() protoreflect.Message {
+	mi := &file_project_v1_project_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StudentProject.ProtoReflect.Descriptor instead.
+func (*StudentProject) Descriptor() ([]byte, []int) {
+	return file_project_v1_project_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *StudentProject) GetProject() *Project {
+	if x != nil {
+		return x.Project
+	}
+	return nil
+}
+
+func (x *StudentProject) GetRole() MemberRole {
+	if x != nil {
+		return x.Role
+	}
+	return MemberRole_MEMBER_ROLE_UNSPECIFIED
+}
+
+// ListProjectsForStudentResponse is the response message for ListProjectsForStudent RPC
+type ListProjectsForStudentResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Projects      []*StudentProject      `protobuf:"bytes,1,rep,name=projects,proto3" json:"projects,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListProjectsForStudentResponse) Reset() {
+	*x = ListProjectsForStudentResponse{}
+	mi := &file_project_v1_project_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListProjectsForStudentResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListProjectsForStudentResponse) ProtoMessage() {}
+
+func (x *ListProjectsForStudentResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_project_v1_project_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListProjectsForStudentResponse.ProtoReflect.Descriptor instead.
+func (*ListProjectsForStudentResponse) Descriptor() ([]byte, []int) {
+	return file_project_v1_project_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *ListProjectsForStudentResponse) GetProjects() []*StudentProject {
+	if x != nil {
+		return x.Projects
+	}
+	return nil
+}
+
+// Tag is a label attached to projects
+type Tag struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name  string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	// Number of projects, excluding deleted ones, carrying the tag
+	ProjectCount  int32 `protobuf:"varint,3,opt,name=project_count,json=projectCount,proto3" json:"project_count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Tag) Reset() {
+	*x = Tag{}
+	mi := &file_project_v1_project_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Tag) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Project) ProtoMessage() {}
+func (*Tag) ProtoMessage() {}
 
-func (x *Project) ProtoReflect() protoreflect.Message {
-	mi := &file_project_v1_project_proto_msgTypes[0]
+func (x *Tag) ProtoReflect() protoreflect.Message {
+	mi := &file_project_v1_project_proto_msgTypes[33]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -58,61 +2232,56 @@ func (x *Project) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Project.ProtoReflect.Descriptor instead.
-func (*Project) Descriptor() ([]byte, []int) {
-	return file_project_v1_project_proto_rawDescGZIP(), []int{0}
+// Deprecated: Use Tag.ProtoReflect.Descriptor instead.
+func (*Tag) Descriptor() ([]byte, []int) {
+	return file_project_v1_project_proto_rawDescGZIP(), []int{33}
 }
 
-func (x *Project) GetId() int32 {
+func (x *Tag) GetId() int32 {
 	if x != nil {
 		return x.Id
 	}
 	return 0
 }
 
-func (x *Project) GetName() string {
+func (x *Tag) GetName() string {
 	if x != nil {
 		return x.Name
 	}
 	return ""
 }
 
-func (x *Project) GetCreatedAt() *timestamppb.Timestamp {
-	if x != nil {
-		return x.CreatedAt
-	}
-	return nil
-}
-
-func (x *Project) GetUpdatedAt() *timestamppb.Timestamp {
+func (x *Tag) GetProjectCount() int32 {
 	if x != nil {
-		return x.UpdatedAt
+		return x.ProjectCount
 	}
-	return nil
+	return 0
 }
 
-// GetAllProjectsRequest is the request message for GetAllProjects RPC
-type GetAllProjectsRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
+// ListTagsRequest is the request message for ListTags RPC
+type ListTagsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Only return tags starting with this prefix
+	Prefix        string `protobuf:"bytes,1,opt,name=prefix,proto3" json:"prefix,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetAllProjectsRequest) Reset() {
-	*x = GetAllProjectsRequest{}
-	mi := &file_project_v1_project_proto_msgTypes[1]
+func (x *ListTagsRequest) Reset() {
+	*x = ListTagsRequest{}
+	mi := &file_project_v1_project_proto_msgTypes[34]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetAllProjectsRequest) String() string {
+func (x *ListTagsRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetAllProjectsRequest) ProtoMessage() {}
+func (*ListTagsRequest) ProtoMessage() {}
 
-func (x *GetAllProjectsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_project_v1_project_proto_msgTypes[1]
+func (x *ListTagsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_project_v1_project_proto_msgTypes[34]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -123,34 +2292,42 @@ func (x *GetAllProjectsRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetAllProjectsRequest.ProtoReflect.Descriptor instead.
-func (*GetAllProjectsRequest) Descriptor() ([]byte, []int) {
-	return file_project_v1_project_proto_rawDescGZIP(), []int{1}
+// Deprecated: Use ListTagsRequest.ProtoReflect.Descriptor instead.
+func (*ListTagsRequest) Descriptor() ([]byte, []int) {
+	return file_project_v1_project_proto_rawDescGZIP(), []int{34}
 }
 
-// GetAllProjectsResponse is the response message for GetAllProjects RPC
-type GetAllProjectsResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Projects      []*Project             `protobuf:"bytes,1,rep,name=projects,proto3" json:"projects,omitempty"`
+func (x *ListTagsRequest) GetPrefix() string {
+	if x != nil {
+		return x.Prefix
+	}
+	return ""
+}
+
+// ListTagsResponse is the response message for ListTags RPC
+type ListTagsResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Sorted by name
+	Tags          []*Tag `protobuf:"bytes,1,rep,name=tags,proto3" json:"tags,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetAllProjectsResponse) Reset() {
-	*x = GetAllProjectsResponse{}
-	mi := &file_project_v1_project_proto_msgTypes[2]
+func (x *ListTagsResponse) Reset() {
+	*x = ListTagsResponse{}
+	mi := &file_project_v1_project_proto_msgTypes[35]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetAllProjectsResponse) String() string {
+func (x *ListTagsResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetAllProjectsResponse) ProtoMessage() {}
+func (*ListTagsResponse) ProtoMessage() {}
 
-func (x *GetAllProjectsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_project_v1_project_proto_msgTypes[2]
+func (x *ListTagsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_project_v1_project_proto_msgTypes[35]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -161,41 +2338,42 @@ func (x *GetAllProjectsResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetAllProjectsResponse.ProtoReflect.Descriptor instead.
-func (*GetAllProjectsResponse) Descriptor() ([]byte, []int) {
-	return file_project_v1_project_proto_rawDescGZIP(), []int{2}
+// Deprecated: Use ListTagsResponse.ProtoReflect.Descriptor instead.
+func (*ListTagsResponse) Descriptor() ([]byte, []int) {
+	return file_project_v1_project_proto_rawDescGZIP(), []int{35}
 }
 
-func (x *GetAllProjectsResponse) GetProjects() []*Project {
+func (x *ListTagsResponse) GetTags() []*Tag {
 	if x != nil {
-		return x.Projects
+		return x.Tags
 	}
 	return nil
 }
 
-// GetProjectRequest is the request message for GetProject RPC
-type GetProjectRequest struct {
+// RenameTagRequest is the request message for RenameTag RPC
+type RenameTagRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	NewName       string                 `protobuf:"bytes,2,opt,name=new_name,json=newName,proto3" json:"new_name,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetProjectRequest) Reset() {
-	*x = GetProjectRequest{}
-	mi := &file_project_v1_project_proto_msgTypes[3]
+func (x *RenameTagRequest) Reset() {
+	*x = RenameTagRequest{}
+	mi := &file_project_v1_project_proto_msgTypes[36]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetProjectRequest) String() string {
+func (x *RenameTagRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetProjectRequest) ProtoMessage() {}
+func (*RenameTagRequest) ProtoMessage() {}
 
-func (x *GetProjectRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_project_v1_project_proto_msgTypes[3]
+func (x *RenameTagRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_project_v1_project_proto_msgTypes[36]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -206,41 +2384,48 @@ func (x *GetProjectRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetProjectRequest.ProtoReflect.Descriptor instead.
-func (*GetProjectRequest) Descriptor() ([]byte, []int) {
-	return file_project_v1_project_proto_rawDescGZIP(), []int{3}
+// Deprecated: Use RenameTagRequest.ProtoReflect.Descriptor instead.
+func (*RenameTagRequest) Descriptor() ([]byte, []int) {
+	return file_project_v1_project_proto_rawDescGZIP(), []int{36}
 }
 
-func (x *GetProjectRequest) GetId() int32 {
+func (x *RenameTagRequest) GetName() string {
 	if x != nil {
-		return x.Id
+		return x.Name
 	}
-	return 0
+	return ""
 }
 
-// GetProjectResponse is the response message for GetProject RPC
-type GetProjectResponse struct {
+func (x *RenameTagRequest) GetNewName() string {
+	if x != nil {
+		return x.NewName
+	}
+	return ""
+}
+
+// RenameTagResponse is the response message for RenameTag RPC
+type RenameTagResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Project       *Project               `protobuf:"bytes,1,opt,name=project,proto3" json:"project,omitempty"`
+	Tag           *Tag                   `protobuf:"bytes,1,opt,name=tag,proto3" json:"tag,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetProjectResponse) Reset() {
-	*x = GetProjectResponse{}
-	mi := &file_project_v1_project_proto_msgTypes[4]
+func (x *RenameTagResponse) Reset() {
+	*x = RenameTagResponse{}
+	mi := &file_project_v1_project_proto_msgTypes[37]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetProjectResponse) String() string {
+func (x *RenameTagResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetProjectResponse) ProtoMessage() {}
+func (*RenameTagResponse) ProtoMessage() {}
 
-func (x *GetProjectResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_project_v1_project_proto_msgTypes[4]
+func (x *RenameTagResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_project_v1_project_proto_msgTypes[37]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -251,41 +2436,44 @@ func (x *GetProjectResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetProjectResponse.ProtoReflect.Descriptor instead.
-func (*GetProjectResponse) Descriptor() ([]byte, []int) {
-	return file_project_v1_project_proto_rawDescGZIP(), []int{4}
+// Deprecated: Use RenameTagResponse.ProtoReflect.Descriptor instead.
+func (*RenameTagResponse) Descriptor() ([]byte, []int) {
+	return file_project_v1_project_proto_rawDescGZIP(), []int{37}
 }
 
-func (x *GetProjectResponse) GetProject() *Project {
+func (x *RenameTagResponse) GetTag() *Tag {
 	if x != nil {
-		return x.Project
+		return x.Tag
 	}
 	return nil
 }
 
-// CreateProjectRequest is the request message for CreateProject RPC
-type CreateProjectRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+// MergeTagsRequest is the request message for MergeTags RPC
+type MergeTagsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Tags to merge away. The target may be listed among them.
+	Sources []string `protobuf:"bytes,1,rep,name=sources,proto3" json:"sources,omitempty"`
+	// Tag the projects of the sources end up with; created if it does not exist
+	Target        string `protobuf:"bytes,2,opt,name=target,proto3" json:"target,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *CreateProjectRequest) Reset() {
-	*x = CreateProjectRequest{}
-	mi := &file_project_v1_project_proto_msgTypes[5]
+func (x *MergeTagsRequest) Reset() {
+	*x = MergeTagsRequest{}
+	mi := &file_project_v1_project_proto_msgTypes[38]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CreateProjectRequest) String() string {
+func (x *MergeTagsRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CreateProjectRequest) ProtoMessage() {}
+func (*MergeTagsRequest) ProtoMessage() {}
 
-func (x *CreateProjectRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_project_v1_project_proto_msgTypes[5]
+func (x *MergeTagsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_project_v1_project_proto_msgTypes[38]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -296,41 +2484,48 @@ func (x *CreateProjectRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CreateProjectRequest.ProtoReflect.Descriptor instead.
-func (*CreateProjectRequest) Descriptor() ([]byte, []int) {
-	return file_project_v1_project_proto_rawDescGZIP(), []int{5}
+// Deprecated: Use MergeTagsRequest.ProtoReflect.Descriptor instead.
+func (*MergeTagsRequest) Descriptor() ([]byte, []int) {
+	return file_project_v1_project_proto_rawDescGZIP(), []int{38}
 }
 
-func (x *CreateProjectRequest) GetName() string {
+func (x *MergeTagsRequest) GetSources() []string {
 	if x != nil {
-		return x.Name
+		return x.Sources
+	}
+	return nil
+}
+
+func (x *MergeTagsRequest) GetTarget() string {
+	if x != nil {
+		return x.Target
 	}
 	return ""
 }
 
-// CreateProjectResponse is the response message for CreateProject RPC
-type CreateProjectResponse struct {
+// MergeTagsResponse is the response message for MergeTags RPC
+type MergeTagsResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Project       *Project               `protobuf:"bytes,1,opt,name=project,proto3" json:"project,omitempty"`
+	Tag           *Tag                   `protobuf:"bytes,1,opt,name=tag,proto3" json:"tag,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *CreateProjectResponse) Reset() {
-	*x = CreateProjectResponse{}
-	mi := &file_project_v1_project_proto_msgTypes[6]
+func (x *MergeTagsResponse) Reset() {
+	*x = MergeTagsResponse{}
+	mi := &file_project_v1_project_proto_msgTypes[39]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CreateProjectResponse) String() string {
+func (x *MergeTagsResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CreateProjectResponse) ProtoMessage() {}
+func (*MergeTagsResponse) ProtoMessage() {}
 
-func (x *CreateProjectResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_project_v1_project_proto_msgTypes[6]
+func (x *MergeTagsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_project_v1_project_proto_msgTypes[39]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -341,42 +2536,40 @@ func (x *CreateProjectResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CreateProjectResponse.ProtoReflect.Descriptor instead.
-func (*CreateProjectResponse) Descriptor() ([]byte, []int) {
-	return file_project_v1_project_proto_rawDescGZIP(), []int{6}
+// Deprecated: Use MergeTagsResponse.ProtoReflect.Descriptor instead.
+func (*MergeTagsResponse) Descriptor() ([]byte, []int) {
+	return file_project_v1_project_proto_rawDescGZIP(), []int{39}
 }
 
-func (x *CreateProjectResponse) GetProject() *Project {
+func (x *MergeTagsResponse) GetTag() *Tag {
 	if x != nil {
-		return x.Project
+		return x.Tag
 	}
 	return nil
 }
 
-// UpdateProjectRequest is the request message for UpdateProject RPC
-type UpdateProjectRequest struct {
+// WatchProjectsRequest is the request message for WatchProjects RPC
+type WatchProjectsRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
-	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *UpdateProjectRequest) Reset() {
-	*x = UpdateProjectRequest{}
-	mi := &file_project_v1_project_proto_msgTypes[7]
+func (x *WatchProjectsRequest) Reset() {
+	*x = WatchProjectsRequest{}
+	mi := &file_project_v1_project_proto_msgTypes[40]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *UpdateProjectRequest) String() string {
+func (x *WatchProjectsRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UpdateProjectRequest) ProtoMessage() {}
+func (*WatchProjectsRequest) ProtoMessage() {}
 
-func (x *UpdateProjectRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_project_v1_project_proto_msgTypes[7]
+func (x *WatchProjectsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_project_v1_project_proto_msgTypes[40]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -387,48 +2580,36 @@ func (x *UpdateProjectRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UpdateProjectRequest.ProtoReflect.Descriptor instead.
-func (*UpdateProjectRequest) Descriptor() ([]byte, []int) {
-	return file_project_v1_project_proto_rawDescGZIP(), []int{7}
-}
-
-func (x *UpdateProjectRequest) GetId() int32 {
-	if x != nil {
-		return x.Id
-	}
-	return 0
-}
-
-func (x *UpdateProjectRequest) GetName() string {
-	if x != nil {
-		return x.Name
-	}
-	return ""
+// Deprecated: Use WatchProjectsRequest.ProtoReflect.Descriptor instead.
+func (*WatchProjectsRequest) Descriptor() ([]byte, []int) {
+	return file_project_v1_project_proto_rawDescGZIP(), []int{40}
 }
 
-// UpdateProjectResponse is the response message for UpdateProject RPC
-type UpdateProjectResponse struct {
+// ProjectSnapshot is a chunk of the initial project list. The last chunk has
+// complete set; events follow after it.
+type ProjectSnapshot struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Project       *Project               `protobuf:"bytes,1,opt,name=project,proto3" json:"project,omitempty"`
+	Projects      []*Project             `protobuf:"bytes,1,rep,name=projects,proto3" json:"projects,omitempty"`
+	Complete      bool                   `protobuf:"varint,2,opt,name=complete,proto3" json:"complete,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *UpdateProjectResponse) Reset() {
-	*x = UpdateProjectResponse{}
-	mi := &file_project_v1_project_proto_msgTypes[8]
+func (x *ProjectSnapshot) Reset() {
+	*x = ProjectSnapshot{}
+	mi := &file_project_v1_project_proto_msgTypes[41]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *UpdateProjectResponse) String() string {
+func (x *ProjectSnapshot) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UpdateProjectResponse) ProtoMessage() {}
+func (*ProjectSnapshot) ProtoMessage() {}
 
-func (x *UpdateProjectResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_project_v1_project_proto_msgTypes[8]
+func (x *ProjectSnapshot) ProtoReflect() protoreflect.Message {
+	mi := &file_project_v1_project_proto_msgTypes[41]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -439,41 +2620,49 @@ func (x *UpdateProjectResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UpdateProjectResponse.ProtoReflect.Descriptor instead.
-func (*UpdateProjectResponse) Descriptor() ([]byte, []int) {
-	return file_project_v1_project_proto_rawDescGZIP(), []int{8}
+// Deprecated: Use ProjectSnapshot.ProtoReflect.Descriptor instead.
+func (*ProjectSnapshot) Descriptor() ([]byte, []int) {
+	return file_project_v1_project_proto_rawDescGZIP(), []int{41}
 }
 
-func (x *UpdateProjectResponse) GetProject() *Project {
+func (x *ProjectSnapshot) GetProjects() []*Project {
 	if x != nil {
-		return x.Project
+		return x.Projects
 	}
 	return nil
 }
 
-// DeleteProjectRequest is the request message for DeleteProject RPC
-type DeleteProjectRequest struct {
+func (x *ProjectSnapshot) GetComplete() bool {
+	if x != nil {
+		return x.Complete
+	}
+	return false
+}
+
+// ProjectEvent describes a single change. For DELETED only project.id is set.
+type ProjectEvent struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Type          ProjectEventType       `protobuf:"varint,1,opt,name=type,proto3,enum=project.v1.ProjectEventType" json:"type,omitempty"`
+	Project       *Project               `protobuf:"bytes,2,opt,name=project,proto3" json:"project,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *DeleteProjectRequest) Reset() {
-	*x = DeleteProjectRequest{}
-	mi := &file_project_v1_project_proto_msgTypes[9]
+func (x *ProjectEvent) Reset() {
+	*x = ProjectEvent{}
+	mi := &file_project_v1_project_proto_msgTypes[42]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *DeleteProjectRequest) String() string {
+func (x *ProjectEvent) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DeleteProjectRequest) ProtoMessage() {}
+func (*ProjectEvent) ProtoMessage() {}
 
-func (x *DeleteProjectRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_project_v1_project_proto_msgTypes[9]
+func (x *ProjectEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_project_v1_project_proto_msgTypes[42]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -484,40 +2673,52 @@ func (x *DeleteProjectRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DeleteProjectRequest.ProtoReflect.Descriptor instead.
-func (*DeleteProjectRequest) Descriptor() ([]byte, []int) {
-	return file_project_v1_project_proto_rawDescGZIP(), []int{9}
+// Deprecated: Use ProjectEvent.ProtoReflect.Descriptor instead.
+func (*ProjectEvent) Descriptor() ([]byte, []int) {
+	return file_project_v1_project_proto_rawDescGZIP(), []int{42}
 }
 
-func (x *DeleteProjectRequest) GetId() int32 {
+func (x *ProjectEvent) GetType() ProjectEventType {
 	if x != nil {
-		return x.Id
+		return x.Type
 	}
-	return 0
+	return ProjectEventType_PROJECT_EVENT_TYPE_UNSPECIFIED
 }
 
-// DeleteProjectResponse is the response message for DeleteProject RPC
-type DeleteProjectResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
+func (x *ProjectEvent) GetProject() *Project {
+	if x != nil {
+		return x.Project
+	}
+	return nil
+}
+
+// WatchProjectsResponse is a single message on the WatchProjects stream
+type WatchProjectsResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Payload:
+	//
+	//	*WatchProjectsResponse_Snapshot
+	//	*WatchProjectsResponse_Event
+	Payload       isWatchProjectsResponse_Payload `protobuf_oneof:"payload"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *DeleteProjectResponse) Reset() {
-	*x = DeleteProjectResponse{}
-	mi := &file_project_v1_project_proto_msgTypes[10]
+func (x *WatchProjectsResponse) Reset() {
+	*x = WatchProjectsResponse{}
+	mi := &file_project_v1_project_proto_msgTypes[43]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *DeleteProjectResponse) String() string {
+func (x *WatchProjectsResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DeleteProjectResponse) ProtoMessage() {}
+func (*WatchProjectsResponse) ProtoMessage() {}
 
-func (x *DeleteProjectResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_project_v1_project_proto_msgTypes[10]
+func (x *WatchProjectsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_project_v1_project_proto_msgTypes[43]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -528,50 +2729,268 @@ func (x *DeleteProjectResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DeleteProjectResponse.ProtoReflect.Descriptor instead.
-func (*DeleteProjectResponse) Descriptor() ([]byte, []int) {
-	return file_project_v1_project_proto_rawDescGZIP(), []int{10}
+// Deprecated: Use WatchProjectsResponse.ProtoReflect.Descriptor instead.
+func (*WatchProjectsResponse) Descriptor() ([]byte, []int) {
+	return file_project_v1_project_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *WatchProjectsResponse) GetPayload() isWatchProjectsResponse_Payload {
+	if x != nil {
+		return x.Payload
+	}
+	return nil
+}
+
+func (x *WatchProjectsResponse) GetSnapshot() *ProjectSnapshot {
+	if x != nil {
+		if x, ok := x.Payload.(*WatchProjectsResponse_Snapshot); ok {
+			return x.Snapshot
+		}
+	}
+	return nil
 }
 
+func (x *WatchProjectsResponse) GetEvent() *ProjectEvent {
+	if x != nil {
+		if x, ok := x.Payload.(*WatchProjectsResponse_Event); ok {
+			return x.Event
+		}
+	}
+	return nil
+}
+
+type isWatchProjectsResponse_Payload interface {
+	isWatchProjectsResponse_Payload()
+}
+
+type WatchProjectsResponse_Snapshot struct {
+	Snapshot *ProjectSnapshot `protobuf:"bytes,1,opt,name=snapshot,proto3,oneof"`
+}
+
+type WatchProjectsResponse_Event struct {
+	Event *ProjectEvent `protobuf:"bytes,2,opt,name=event,proto3,oneof"`
+}
+
+func (*WatchProjectsResponse_Snapshot) isWatchProjectsResponse_Payload() {}
+
+func (*WatchProjectsResponse_Event) isWatchProjectsResponse_Payload() {}
+
 var File_project_v1_project_proto protoreflect.FileDescriptor
 
 const file_project_v1_project_proto_rawDesc = "" +
 	"\n" +
 	"\x18project/v1/project.proto\x12\n" +
-	"project.v1\x1a\x1fgoogle/protobuf/timestamp.proto\"\xa3\x01\n" +
+	"project.v1\x1a google/protobuf/field_mask.proto\x1a\x1cgoogle/protobuf/struct.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"\x98\x03\n" +
 	"\aProject\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\x05R\x02id\x12\x12\n" +
 	"\x04name\x18\x02 \x01(\tR\x04name\x129\n" +
 	"\n" +
 	"created_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
 	"\n" +
-	"updated_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\"\x17\n" +
+	"updated_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x12 \n" +
+	"\vdescription\x18\x05 \x01(\tR\vdescription\x121\n" +
+	"\x06status\x18\x06 \x01(\x0e2\x19.project.v1.ProjectStatusR\x06status\x129\n" +
+	"\n" +
+	"start_date\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tstartDate\x125\n" +
+	"\bdue_date\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\adueDate\x12\x18\n" +
+	"\aversion\x18\t \x01(\x03R\aversion\x12\x12\n" +
+	"\x04tags\x18\n" +
+	" \x03(\tR\x04tags\"\x17\n" +
 	"\x15GetAllProjectsRequest\"I\n" +
 	"\x16GetAllProjectsResponse\x12/\n" +
-	"\bprojects\x18\x01 \x03(\v2\x13.project.v1.ProjectR\bprojects\"#\n" +
+	"\bprojects\x18\x01 \x03(\v2\x13.project.v1.ProjectR\bprojects\"\x93\x04\n" +
+	"\x13ListProjectsRequest\x12\x1b\n" +
+	"\tpage_size\x18\x01 \x01(\x05R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x02 \x01(\tR\tpageToken\x12#\n" +
+	"\rname_contains\x18\x03 \x01(\tR\fnameContains\x12?\n" +
+	"\rcreated_after\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\fcreatedAfter\x12A\n" +
+	"\x0ecreated_before\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\rcreatedBefore\x12?\n" +
+	"\rupdated_after\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\fupdatedAfter\x12A\n" +
+	"\x0eupdated_before\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\rupdatedBefore\x12\x19\n" +
+	"\border_by\x18\b \x01(\tR\aorderBy\x121\n" +
+	"\x06status\x18\t \x01(\x0e2\x19.project.v1.ProjectStatusR\x06status\x12\x12\n" +
+	"\x04tags\x18\n" +
+	" \x03(\tR\x04tags\x121\n" +
+	"\ttag_match\x18\v \x01(\x0e2\x14.project.v1.TagMatchR\btagMatch\"o\n" +
+	"\x14ListProjectsResponse\x12/\n" +
+	"\bprojects\x18\x01 \x03(\v2\x13.project.v1.ProjectR\bprojects\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\"J\n" +
+	"\x15SearchProjectsRequest\x12\x14\n" +
+	"\x05query\x18\x01 \x01(\tR\x05query\x12\x1b\n" +
+	"\tpage_size\x18\x02 \x01(\x05R\bpageSize\"\xb4\x01\n" +
+	"\x13ProjectSearchResult\x12-\n" +
+	"\aproject\x18\x01 \x01(\v2\x13.project.v1.ProjectR\aproject\x12\x12\n" +
+	"\x04rank\x18\x02 \x01(\x01R\x04rank\x12%\n" +
+	"\x0ename_highlight\x18\x03 \x01(\tR\rnameHighlight\x123\n" +
+	"\x15description_highlight\x18\x04 \x01(\tR\x14descriptionHighlight\"S\n" +
+	"\x16SearchProjectsResponse\x129\n" +
+	"\aresults\x18\x01 \x03(\v2\x1f.project.v1.ProjectSearchResultR\aresults\"#\n" +
 	"\x11GetProjectRequest\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\x05R\x02id\"C\n" +
 	"\x12GetProjectResponse\x12-\n" +
-	"\aproject\x18\x01 \x01(\v2\x13.project.v1.ProjectR\aproject\"*\n" +
+	"\aproject\x18\x01 \x01(\v2\x13.project.v1.ProjectR\aproject\"\xd2\x01\n" +
 	"\x14CreateProjectRequest\x12\x12\n" +
-	"\x04name\x18\x01 \x01(\tR\x04name\"F\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12 \n" +
+	"\vdescription\x18\x02 \x01(\tR\vdescription\x129\n" +
+	"\n" +
+	"start_date\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\tstartDate\x125\n" +
+	"\bdue_date\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\adueDate\x12\x12\n" +
+	"\x04tags\x18\x05 \x03(\tR\x04tags\"F\n" +
 	"\x15CreateProjectResponse\x12-\n" +
-	"\aproject\x18\x01 \x01(\v2\x13.project.v1.ProjectR\aproject\":\n" +
+	"\aproject\x18\x01 \x01(\v2\x13.project.v1.ProjectR\aproject\"\xb9\x02\n" +
 	"\x14UpdateProjectRequest\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\x05R\x02id\x12\x12\n" +
-	"\x04name\x18\x02 \x01(\tR\x04name\"F\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12 \n" +
+	"\vdescription\x18\x03 \x01(\tR\vdescription\x129\n" +
+	"\n" +
+	"start_date\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\tstartDate\x125\n" +
+	"\bdue_date\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\adueDate\x12;\n" +
+	"\vupdate_mask\x18\x06 \x01(\v2\x1a.google.protobuf.FieldMaskR\n" +
+	"updateMask\x12\x18\n" +
+	"\aversion\x18\a \x01(\x03R\aversion\x12\x12\n" +
+	"\x04tags\x18\b \x03(\tR\x04tags\"F\n" +
 	"\x15UpdateProjectResponse\x12-\n" +
+	"\aproject\x18\x01 \x01(\v2\x13.project.v1.ProjectR\aproject\"]\n" +
+	"\x18TransitionProjectRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x05R\x02id\x121\n" +
+	"\x06status\x18\x02 \x01(\x0e2\x19.project.v1.ProjectStatusR\x06status\"J\n" +
+	"\x19TransitionProjectResponse\x12-\n" +
 	"\aproject\x18\x01 \x01(\v2\x13.project.v1.ProjectR\aproject\"&\n" +
 	"\x14DeleteProjectRequest\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\x05R\x02id\"\x17\n" +
-	"\x15DeleteProjectResponse2\xb8\x03\n" +
+	"\x15DeleteProjectResponse\"\xe7\x01\n" +
+	"\fHistoryEntry\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x16\n" +
+	"\x06action\x18\x02 \x01(\tR\x06action\x12\x14\n" +
+	"\x05actor\x18\x03 \x01(\tR\x05actor\x12/\n" +
+	"\x06before\x18\x04 \x01(\v2\x17.google.protobuf.StructR\x06before\x12-\n" +
+	"\x05after\x18\x05 \x01(\v2\x17.google.protobuf.StructR\x05after\x129\n" +
+	"\n" +
+	"created_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"f\n" +
+	"\x18GetProjectHistoryRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x05R\x02id\x12\x1b\n" +
+	"\tpage_size\x18\x02 \x01(\x05R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x03 \x01(\tR\tpageToken\"w\n" +
+	"\x19GetProjectHistoryResponse\x122\n" +
+	"\aentries\x18\x01 \x03(\v2\x18.project.v1.HistoryEntryR\aentries\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\"'\n" +
+	"\x15RestoreProjectRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x05R\x02id\"G\n" +
+	"\x16RestoreProjectResponse\x12-\n" +
+	"\aproject\x18\x01 \x01(\v2\x13.project.v1.ProjectR\aproject\"\xea\x01\n" +
+	"\rProjectMember\x12\x1d\n" +
+	"\n" +
+	"project_id\x18\x01 \x01(\x05R\tprojectId\x12\x1d\n" +
+	"\n" +
+	"student_id\x18\x02 \x01(\x05R\tstudentId\x12*\n" +
+	"\x04role\x18\x03 \x01(\x0e2\x16.project.v1.MemberRoleR\x04role\x129\n" +
+	"\n" +
+	"created_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x12\x17\n" +
+	"\ateam_id\x18\x05 \x01(\x05R\x06teamId\x12\x1b\n" +
+	"\tteam_lead\x18\x06 \x01(\bR\bteamLead\"\x97\x01\n" +
+	"\x10AddMemberRequest\x12\x1d\n" +
+	"\n" +
+	"project_id\x18\x01 \x01(\x05R\tprojectId\x12\x1d\n" +
+	"\n" +
+	"student_id\x18\x02 \x01(\x05R\tstudentId\x12*\n" +
+	"\x04role\x18\x03 \x01(\x0e2\x16.project.v1.MemberRoleR\x04role\x12\x19\n" +
+	"\bactor_id\x18\x04 \x01(\x05R\aactorId\"F\n" +
+	"\x11AddMemberResponse\x121\n" +
+	"\x06member\x18\x01 \x01(\v2\x19.project.v1.ProjectMemberR\x06member\"n\n" +
+	"\x13RemoveMemberRequest\x12\x1d\n" +
+	"\n" +
+	"project_id\x18\x01 \x01(\x05R\tprojectId\x12\x1d\n" +
+	"\n" +
+	"student_id\x18\x02 \x01(\x05R\tstudentId\x12\x19\n" +
+	"\bactor_id\x18\x03 \x01(\x05R\aactorId\"\x16\n" +
+	"\x14RemoveMemberResponse\"L\n" +
+	"\x12ListMembersRequest\x12\x1d\n" +
+	"\n" +
+	"project_id\x18\x01 \x01(\x05R\tprojectId\x12\x17\n" +
+	"\ateam_id\x18\x02 \x01(\x05R\x06teamId\"J\n" +
+	"\x13ListMembersResponse\x123\n" +
+	"\amembers\x18\x01 \x03(\v2\x19.project.v1.ProjectMemberR\amembers\">\n" +
+	"\x1dListProjectsForStudentRequest\x12\x1d\n" +
+	"\n" +
+	"student_id\x18\x01 \x01(\x05R\tstudentId\"k\n" +
+	"\x0eStudentProject\x12-\n" +
+	"\aproject\x18\x01 \x01(\v2\x13.project.v1.ProjectR\aproject\x12*\n" +
+	"\x04role\x18\x02 \x01(\x0e2\x16.project.v1.MemberRoleR\x04role\"X\n" +
+	"\x1eListProjectsForStudentResponse\x126\n" +
+	"\bprojects\x18\x01 \x03(\v2\x1a.project.v1.StudentProjectR\bprojects\"N\n" +
+	"\x03Tag\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x05R\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12#\n" +
+	"\rproject_count\x18\x03 \x01(\x05R\fprojectCount\")\n" +
+	"\x0fListTagsRequest\x12\x16\n" +
+	"\x06prefix\x18\x01 \x01(\tR\x06prefix\"7\n" +
+	"\x10ListTagsResponse\x12#\n" +
+	"\x04tags\x18\x01 \x03(\v2\x0f.project.v1.TagR\x04tags\"A\n" +
+	"\x10RenameTagRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x19\n" +
+	"\bnew_name\x18\x02 \x01(\tR\anewName\"6\n" +
+	"\x11RenameTagResponse\x12!\n" +
+	"\x03tag\x18\x01 \x01(\v2\x0f.project.v1.TagR\x03tag\"D\n" +
+	"\x10MergeTagsRequest\x12\x18\n" +
+	"\asources\x18\x01 \x03(\tR\asources\x12\x16\n" +
+	"\x06target\x18\x02 \x01(\tR\x06target\"6\n" +
+	"\x11MergeTagsResponse\x12!\n" +
+	"\x03tag\x18\x01 \x01(\v2\x0f.project.v1.TagR\x03tag\"\x16\n" +
+	"\x14WatchProjectsRequest\"^\n" +
+	"\x0fProjectSnapshot\x12/\n" +
+	"\bprojects\x18\x01 \x03(\v2\x13.project.v1.ProjectR\bprojects\x12\x1a\n" +
+	"\bcomplete\x18\x02 \x01(\bR\bcomplete\"o\n" +
+	"\fProjectEvent\x120\n" +
+	"\x04type\x18\x01 \x01(\x0e2\x1c.project.v1.ProjectEventTypeR\x04type\x12-\n" +
+	"\aproject\x18\x02 \x01(\v2\x13.project.v1.ProjectR\aproject\"\x8f\x01\n" +
+	"\x15WatchProjectsResponse\x129\n" +
+	"\bsnapshot\x18\x01 \x01(\v2\x1b.project.v1.ProjectSnapshotH\x00R\bsnapshot\x120\n" +
+	"\x05event\x18\x02 \x01(\v2\x18.project.v1.ProjectEventH\x00R\x05eventB\t\n" +
+	"\apayload*\x9f\x01\n" +
+	"\rProjectStatus\x12\x1e\n" +
+	"\x1aPROJECT_STATUS_UNSPECIFIED\x10\x00\x12\x18\n" +
+	"\x14PROJECT_STATUS_DRAFT\x10\x01\x12\x19\n" +
+	"\x15PROJECT_STATUS_ACTIVE\x10\x02\x12\x1c\n" +
+	"\x18PROJECT_STATUS_COMPLETED\x10\x03\x12\x1b\n" +
+	"\x17PROJECT_STATUS_ARCHIVED\x10\x04*K\n" +
+	"\bTagMatch\x12\x19\n" +
+	"\x15TAG_MATCH_UNSPECIFIED\x10\x00\x12\x11\n" +
+	"\rTAG_MATCH_ANY\x10\x01\x12\x11\n" +
+	"\rTAG_MATCH_ALL\x10\x02*\x91\x01\n" +
+	"\n" +
+	"MemberRole\x12\x1b\n" +
+	"\x17MEMBER_ROLE_UNSPECIFIED\x10\x00\x12\x15\n" +
+	"\x11MEMBER_ROLE_OWNER\x10\x01\x12\x1b\n" +
+	"\x17MEMBER_ROLE_CONTRIBUTOR\x10\x02\x12\x16\n" +
+	"\x12MEMBER_ROLE_VIEWER\x10\x03\x12\x1a\n" +
+	"\x16MEMBER_ROLE_INSTRUCTOR\x10\x04*\x96\x01\n" +
+	"\x10ProjectEventType\x12\"\n" +
+	"\x1ePROJECT_EVENT_TYPE_UNSPECIFIED\x10\x00\x12\x1e\n" +
+	"\x1aPROJECT_EVENT_TYPE_CREATED\x10\x01\x12\x1e\n" +
+	"\x1aPROJECT_EVENT_TYPE_UPDATED\x10\x02\x12\x1e\n" +
+	"\x1aPROJECT_EVENT_TYPE_DELETED\x10\x032\x92\f\n" +
 	"\x0eProjectService\x12W\n" +
-	"\x0eGetAllProjects\x12!.project.v1.GetAllProjectsRequest\x1a\".project.v1.GetAllProjectsResponse\x12K\n" +
+	"\x0eGetAllProjects\x12!.project.v1.GetAllProjectsRequest\x1a\".project.v1.GetAllProjectsResponse\x12Q\n" +
+	"\fListProjects\x12\x1f.project.v1.ListProjectsRequest\x1a .project.v1.ListProjectsResponse\x12W\n" +
+	"\x0eSearchProjects\x12!.project.v1.SearchProjectsRequest\x1a\".project.v1.SearchProjectsResponse\x12K\n" +
 	"\n" +
 	"GetProject\x12\x1d.project.v1.GetProjectRequest\x1a\x1e.project.v1.GetProjectResponse\x12T\n" +
 	"\rCreateProject\x12 .project.v1.CreateProjectRequest\x1a!.project.v1.CreateProjectResponse\x12T\n" +
-	"\rUpdateProject\x12 .project.v1.UpdateProjectRequest\x1a!.project.v1.UpdateProjectResponse\x12T\n" +
-	"\rDeleteProject\x12 .project.v1.DeleteProjectRequest\x1a!.project.v1.DeleteProjectResponseB#Z!grud/api/gen/project/v1;projectv1b\x06proto3"
+	"\rUpdateProject\x12 .project.v1.UpdateProjectRequest\x1a!.project.v1.UpdateProjectResponse\x12`\n" +
+	"\x11TransitionProject\x12$.project.v1.TransitionProjectRequest\x1a%.project.v1.TransitionProjectResponse\x12T\n" +
+	"\rDeleteProject\x12 .project.v1.DeleteProjectRequest\x1a!.project.v1.DeleteProjectResponse\x12W\n" +
+	"\x0eRestoreProject\x12!.project.v1.RestoreProjectRequest\x1a\".project.v1.RestoreProjectResponse\x12`\n" +
+	"\x11GetProjectHistory\x12$.project.v1.GetProjectHistoryRequest\x1a%.project.v1.GetProjectHistoryResponse\x12H\n" +
+	"\tAddMember\x12\x1c.project.v1.AddMemberRequest\x1a\x1d.project.v1.AddMemberResponse\x12Q\n" +
+	"\fRemoveMember\x12\x1f.project.v1.RemoveMemberRequest\x1a .project.v1.RemoveMemberResponse\x12N\n" +
+	"\vListMembers\x12\x1e.project.v1.ListMembersRequest\x1a\x1f.project.v1.ListMembersResponse\x12o\n" +
+	"\x16ListProjectsForStudent\x12).project.v1.ListProjectsForStudentRequest\x1a*.project.v1.ListProjectsForStudentResponse\x12E\n" +
+	"\bListTags\x12\x1b.project.v1.ListTagsRequest\x1a\x1c.project.v1.ListTagsResponse\x12H\n" +
+	"\tRenameTag\x12\x1c.project.v1.RenameTagRequest\x1a\x1d.project.v1.RenameTagResponse\x12H\n" +
+	"\tMergeTags\x12\x1c.project.v1.MergeTagsRequest\x1a\x1d.project.v1.MergeTagsResponse\x12V\n" +
+	"\rWatchProjects\x12 .project.v1.WatchProjectsRequest\x1a!.project.v1.WatchProjectsResponse0\x01B#Z!grud/api/gen/project/v1;projectv1b\x06proto3"
 
 var (
 	file_project_v1_project_proto_rawDescOnce sync.Once
@@ -585,43 +3004,149 @@ func file_project_v1_project_proto_rawDescGZIP() []byte {
 	return file_project_v1_project_proto_rawDescData
 }
 
-var file_project_v1_project_proto_msgTypes = make([]protoimpl.MessageInfo, 11)
+var file_project_v1_project_proto_enumTypes = make([]protoimpl.EnumInfo, 4)
+var file_project_v1_project_proto_msgTypes = make([]protoimpl.MessageInfo, 44)
 var file_project_v1_project_proto_goTypes = []any{
-	(*Project)(nil),                // 0: project.v1.Project
-	(*GetAllProjectsRequest)(nil),  // 1: project.v1.GetAllProjectsRequest
-	(*GetAllProjectsResponse)(nil), // 2: project.v1.GetAllProjectsResponse
-	(*GetProjectRequest)(nil),      // 3: project.v1.GetProjectRequest
-	(*GetProjectResponse)(nil),     // 4: project.v1.GetProjectResponse
-	(*CreateProjectRequest)(nil),   // 5: project.v1.CreateProjectRequest
-	(*CreateProjectResponse)(nil),  // 6: project.v1.CreateProjectResponse
-	(*UpdateProjectRequest)(nil),   // 7: project.v1.UpdateProjectRequest
-	(*UpdateProjectResponse)(nil),  // 8: project.v1.UpdateProjectResponse
-	(*DeleteProjectRequest)(nil),   // 9: project.v1.DeleteProjectRequest
-	(*DeleteProjectResponse)(nil),  // 10: project.v1.DeleteProjectResponse
-	(*timestamppb.Timestamp)(nil),  // 11: google.protobuf.Timestamp
+	(ProjectStatus)(0),                     // 0: project.v1.ProjectStatus
+	(TagMatch)(0),                          // 1: project.v1.TagMatch
+	(MemberRole)(0),                        // 2: project.v1.MemberRole
+	(ProjectEventType)(0),                  // 3: project.v1.ProjectEventType
+	(*Project)(nil),                        // 4: project.v1.Project
+	(*GetAllProjectsRequest)(nil),          // 5: project.v1.GetAllProjectsRequest
+	(*GetAllProjectsResponse)(nil),         // 6: project.v1.GetAllProjectsResponse
+	(*ListProjectsRequest)(nil),            // 7: project.v1.ListProjectsRequest
+	(*ListProjectsResponse)(nil),           // 8: project.v1.ListProjectsResponse
+	(*SearchProjectsRequest)(nil),          // 9: project.v1.SearchProjectsRequest
+	(*ProjectSearchResult)(nil),            // 10: project.v1.ProjectSearchResult
+	(*SearchProjectsResponse)(nil),         // 11: project.v1.SearchProjectsResponse
+	(*GetProjectRequest)(nil),              // 12: project.v1.GetProjectRequest
+	(*GetProjectResponse)(nil),             // 13: project.v1.GetProjectResponse
+	(*CreateProjectRequest)(nil),           // 14: project.v1.CreateProjectRequest
+	(*CreateProjectResponse)(nil),          // 15: project.v1.CreateProjectResponse
+	(*UpdateProjectRequest)(nil),           // 16: project.v1.UpdateProjectRequest
+	(*UpdateProjectResponse)(nil),          // 17: project.v1.UpdateProjectResponse
+	(*TransitionProjectRequest)(nil),       // 18: project.v1.TransitionProjectRequest
+	(*TransitionProjectResponse)(nil),      // 19: project.v1.TransitionProjectResponse
+	(*DeleteProjectRequest)(nil),           // 20: project.v1.DeleteProjectRequest
+	(*DeleteProjectResponse)(nil),          // 21: project.v1.DeleteProjectResponse
+	(*HistoryEntry)(nil),                   // 22: project.v1.HistoryEntry
+	(*GetProjectHistoryRequest)(nil),       // 23: project.v1.GetProjectHistoryRequest
+	(*GetProjectHistoryResponse)(nil),      // 24: project.v1.GetProjectHistoryResponse
+	(*RestoreProjectRequest)(nil),          // 25: project.v1.RestoreProjectRequest
+	(*RestoreProjectResponse)(nil),         // 26: project.v1.RestoreProjectResponse
+	(*ProjectMember)(nil),                  // 27: project.v1.ProjectMember
+	(*AddMemberRequest)(nil),               // 28: project.v1.AddMemberRequest
+	(*AddMemberResponse)(nil),              // 29: project.v1.AddMemberResponse
+	(*RemoveMemberRequest)(nil),            // 30: project.v1.RemoveMemberRequest
+	(*RemoveMemberResponse)(nil),           // 31: project.v1.RemoveMemberResponse
+	(*ListMembersRequest)(nil),             // 32: project.v1.ListMembersRequest
+	(*ListMembersResponse)(nil),            // 33: project.v1.ListMembersResponse
+	(*ListProjectsForStudentRequest)(nil),  // 34: project.v1.ListProjectsForStudentRequest
+	(*StudentProject)(nil),                 // 35: project.v1.StudentProject
+	(*ListProjectsForStudentResponse)(nil), // 36: project.v1.ListProjectsForStudentResponse
+	(*Tag)(nil),                            // 37: project.v1.Tag
+	(*ListTagsRequest)(nil),                // 38: project.v1.ListTagsRequest
+	(*ListTagsResponse)(nil),               // 39: project.v1.ListTagsResponse
+	(*RenameTagRequest)(nil),               // 40: project.v1.RenameTagRequest
+	(*RenameTagResponse)(nil),              // 41: project.v1.RenameTagResponse
+	(*MergeTagsRequest)(nil),               // 42: project.v1.MergeTagsRequest
+	(*MergeTagsResponse)(nil),              // 43: project.v1.MergeTagsResponse
+	(*WatchProjectsRequest)(nil),           // 44: project.v1.WatchProjectsRequest
+	(*ProjectSnapshot)(nil),                // 45: project.v1.ProjectSnapshot
+	(*ProjectEvent)(nil),                   // 46: project.v1.ProjectEvent
+	(*WatchProjectsResponse)(nil),          // 47: project.v1.WatchProjectsResponse
+	(*timestamppb.Timestamp)(nil),          // 48: google.protobuf.Timestamp
+	(*fieldmaskpb.FieldMask)(nil),          // 49: google.protobuf.FieldMask
+	(*structpb.Struct)(nil),                // 50: google.protobuf.Struct
 }
 var file_project_v1_project_proto_depIdxs = []int32{
-	11, // 0: project.v1.Project.created_at:type_name -> google.protobuf.Timestamp
-	11, // 1: project.v1.Project.updated_at:type_name -> google.protobuf.Timestamp
-	0,  // 2: project.v1.GetAllProjectsResponse.projects:type_name -> project.v1.Project
-	0,  // 3: project.v1.GetProjectResponse.project:type_name -> project.v1.Project
-	0,  // 4: project.v1.CreateProjectResponse.project:type_name -> project.v1.Project
-	0,  // 5: project.v1.UpdateProjectResponse.project:type_name -> project.v1.Project
-	1,  // 6: project.v1.ProjectService.GetAllProjects:input_type -> project.v1.GetAllProjectsRequest
-	3,  // 7: project.v1.ProjectService.GetProject:input_type -> project.v1.GetProjectRequest
-	5,  // 8: project.v1.ProjectService.CreateProject:input_type -> project.v1.CreateProjectRequest
-	7,  // 9: project.v1.ProjectService.UpdateProject:input_type -> project.v1.UpdateProjectRequest
-	9,  // 10: project.v1.ProjectService.DeleteProject:input_type -> project.v1.DeleteProjectRequest
-	2,  // 11: project.v1.ProjectService.GetAllProjects:output_type -> project.v1.GetAllProjectsResponse
-	4,  // 12: project.v1.ProjectService.GetProject:output_type -> project.v1.GetProjectResponse
-	6,  // 13: project.v1.ProjectService.CreateProject:output_type -> project.v1.CreateProjectResponse
-	8,  // 14: project.v1.ProjectService.UpdateProject:output_type -> project.v1.UpdateProjectResponse
-	10, // 15: project.v1.ProjectService.DeleteProject:output_type -> project.v1.DeleteProjectResponse
-	11, // [11:16] is the sub-list for method output_type
-	6,  // [6:11] is the sub-list for method input_type
-	6,  // [6:6] is the sub-list for extension type_name
-	6,  // [6:6] is the sub-list for extension extendee
-	0,  // [0:6] is the sub-list for field type_name
+	48, // 0: project.v1.Project.created_at:type_name -> google.protobuf.Timestamp
+	48, // 1: project.v1.Project.updated_at:type_name -> google.protobuf.Timestamp
+	0,  // 2: project.v1.Project.status:type_name -> project.v1.ProjectStatus
+	48, // 3: project.v1.Project.start_date:type_name -> google.protobuf.Timestamp
+	48, // 4: project.v1.Project.due_date:type_name -> google.protobuf.Timestamp
+	4,  // 5: project.v1.GetAllProjectsResponse.projects:type_name -> project.v1.Project
+	48, // 6: project.v1.ListProjectsRequest.created_after:type_name -> google.protobuf.Timestamp
+	48, // 7: project.v1.ListProjectsRequest.created_before:type_name -> google.protobuf.Timestamp
+	48, // 8: project.v1.ListProjectsRequest.updated_after:type_name -> google.protobuf.Timestamp
+	48, // 9: project.v1.ListProjectsRequest.updated_before:type_name -> google.protobuf.Timestamp
+	0,  // 10: project.v1.ListProjectsRequest.status:type_name -> project.v1.ProjectStatus
+	1,  // 11: project.v1.ListProjectsRequest.tag_match:type_name -> project.v1.TagMatch
+	4,  // 12: project.v1.ListProjectsResponse.projects:type_name -> project.v1.Project
+	4,  // 13: project.v1.ProjectSearchResult.project:type_name -> project.v1.Project
+	10, // 14: project.v1.SearchProjectsResponse.results:type_name -> project.v1.ProjectSearchResult
+	4,  // 15: project.v1.GetProjectResponse.project:type_name -> project.v1.Project
+	48, // 16: project.v1.CreateProjectRequest.start_date:type_name -> google.protobuf.Timestamp
+	48, // 17: project.v1.CreateProjectRequest.due_date:type_name -> google.protobuf.Timestamp
+	4,  // 18: project.v1.CreateProjectResponse.project:type_name -> project.v1.Project
+	48, // 19: project.v1.UpdateProjectRequest.start_date:type_name -> google.protobuf.Timestamp
+	48, // 20: project.v1.UpdateProjectRequest.due_date:type_name -> google.protobuf.Timestamp
+	49, // 21: project.v1.UpdateProjectRequest.update_mask:type_name -> google.protobuf.FieldMask
+	4,  // 22: project.v1.UpdateProjectResponse.project:type_name -> project.v1.Project
+	0,  // 23: project.v1.TransitionProjectRequest.status:type_name -> project.v1.ProjectStatus
+	4,  // 24: project.v1.TransitionProjectResponse.project:type_name -> project.v1.Project
+	50, // 25: project.v1.HistoryEntry.before:type_name -> google.protobuf.Struct
+	50, // 26: project.v1.HistoryEntry.after:type_name -> google.protobuf.Struct
+	48, // 27: project.v1.HistoryEntry.created_at:type_name -> google.protobuf.Timestamp
+	22, // 28: project.v1.GetProjectHistoryResponse.entries:type_name -> project.v1.HistoryEntry
+	4,  // 29: project.v1.RestoreProjectResponse.project:type_name -> project.v1.Project
+	2,  // 30: project.v1.ProjectMember.role:type_name -> project.v1.MemberRole
+	48, // 31: project.v1.ProjectMember.created_at:type_name -> google.protobuf.Timestamp
+	2,  // 32: project.v1.AddMemberRequest.role:type_name -> project.v1.MemberRole
+	27, // 33: project.v1.AddMemberResponse.member:type_name -> project.v1.ProjectMember
+	27, // 34: project.v1.ListMembersResponse.members:type_name -> project.v1.ProjectMember
+	4,  // 35: project.v1.StudentProject.project:type_name -> project.v1.Project
+	2,  // 36: project.v1.StudentProject.role:type_name -> project.v1.MemberRole
+	35, // 37: project.v1.ListProjectsForStudentResponse.projects:type_name -> project.v1.StudentProject
+	37, // 38: project.v1.ListTagsResponse.tags:type_name -> project.v1.Tag
+	37, // 39: project.v1.RenameTagResponse.tag:type_name -> project.v1.Tag
+	37, // 40: project.v1.MergeTagsResponse.tag:type_name -> project.v1.Tag
+	4,  // 41: project.v1.ProjectSnapshot.projects:type_name -> project.v1.Project
+	3,  // 42: project.v1.ProjectEvent.type:type_name -> project.v1.ProjectEventType
+	4,  // 43: project.v1.ProjectEvent.project:type_name -> project.v1.Project
+	45, // 44: project.v1.WatchProjectsResponse.snapshot:type_name -> project.v1.ProjectSnapshot
+	46, // 45: project.v1.WatchProjectsResponse.event:type_name -> project.v1.ProjectEvent
+	5,  // 46: project.v1.ProjectService.GetAllProjects:input_type -> project.v1.GetAllProjectsRequest
+	7,  // 47: project.v1.ProjectService.ListProjects:input_type -> project.v1.ListProjectsRequest
+	9,  // 48: project.v1.ProjectService.SearchProjects:input_type -> project.v1.SearchProjectsRequest
+	12, // 49: project.v1.ProjectService.GetProject:input_type -> project.v1.GetProjectRequest
+	14, // 50: project.v1.ProjectService.CreateProject:input_type -> project.v1.CreateProjectRequest
+	16, // 51: project.v1.ProjectService.UpdateProject:input_type -> project.v1.UpdateProjectRequest
+	18, // 52: project.v1.ProjectService.TransitionProject:input_type -> project.v1.TransitionProjectRequest
+	20, // 53: project.v1.ProjectService.DeleteProject:input_type -> project.v1.DeleteProjectRequest
+	25, // 54: project.v1.ProjectService.RestoreProject:input_type -> project.v1.RestoreProjectRequest
+	23, // 55: project.v1.ProjectService.GetProjectHistory:input_type -> project.v1.GetProjectHistoryRequest
+	28, // 56: project.v1.ProjectService.AddMember:input_type -> project.v1.AddMemberRequest
+	30, // 57: project.v1.ProjectService.RemoveMember:input_type -> project.v1.RemoveMemberRequest
+	32, // 58: project.v1.ProjectService.ListMembers:input_type -> project.v1.ListMembersRequest
+	34, // 59: project.v1.ProjectService.ListProjectsForStudent:input_type -> project.v1.ListProjectsForStudentRequest
+	38, // 60: project.v1.ProjectService.ListTags:input_type -> project.v1.ListTagsRequest
+	40, // 61: project.v1.ProjectService.RenameTag:input_type -> project.v1.RenameTagRequest
+	42, // 62: project.v1.ProjectService.MergeTags:input_type -> project.v1.MergeTagsRequest
+	44, // 63: project.v1.ProjectService.WatchProjects:input_type -> project.v1.WatchProjectsRequest
+	6,  // 64: project.v1.ProjectService.GetAllProjects:output_type -> project.v1.GetAllProjectsResponse
+	8,  // 65: project.v1.ProjectService.ListProjects:output_type -> project.v1.ListProjectsResponse
+	11, // 66: project.v1.ProjectService.SearchProjects:output_type -> project.v1.SearchProjectsResponse
+	13, // 67: project.v1.ProjectService.GetProject:output_type -> project.v1.GetProjectResponse
+	15, // 68: project.v1.ProjectService.CreateProject:output_type -> project.v1.CreateProjectResponse
+	17, // 69: project.v1.ProjectService.UpdateProject:output_type -> project.v1.UpdateProjectResponse
+	19, // 70: project.v1.ProjectService.TransitionProject:output_type -> project.v1.TransitionProjectResponse
+	21, // 71: project.v1.ProjectService.DeleteProject:output_type -> project.v1.DeleteProjectResponse
+	26, // 72: project.v1.ProjectService.RestoreProject:output_type -> project.v1.RestoreProjectResponse
+	24, // 73: project.v1.ProjectService.GetProjectHistory:output_type -> project.v1.GetProjectHistoryResponse
+	29, // 74: project.v1.ProjectService.AddMember:output_type -> project.v1.AddMemberResponse
+	31, // 75: project.v1.ProjectService.RemoveMember:output_type -> project.v1.RemoveMemberResponse
+	33, // 76: project.v1.ProjectService.ListMembers:output_type -> project.v1.ListMembersResponse
+	36, // 77: project.v1.ProjectService.ListProjectsForStudent:output_type -> project.v1.ListProjectsForStudentResponse
+	39, // 78: project.v1.ProjectService.ListTags:output_type -> project.v1.ListTagsResponse
+	41, // 79: project.v1.ProjectService.RenameTag:output_type -> project.v1.RenameTagResponse
+	43, // 80: project.v1.ProjectService.MergeTags:output_type -> project.v1.MergeTagsResponse
+	47, // 81: project.v1.ProjectService.WatchProjects:output_type -> project.v1.WatchProjectsResponse
+	64, // [64:82] is the sub-list for method output_type
+	46, // [46:64] is the sub-list for method input_type
+	46, // [46:46] is the sub-list for extension type_name
+	46, // [46:46] is the sub-list for extension extendee
+	0,  // [0:46] is the sub-list for field type_name
 }
 
 func init() { file_project_v1_project_proto_init() }
@@ -629,18 +3154,23 @@ func file_project_v1_project_proto_init() {
 	if File_project_v1_project_proto != nil {
 		return
 	}
+	file_project_v1_project_proto_msgTypes[43].OneofWrappers = []any{
+		(*WatchProjectsResponse_Snapshot)(nil),
+		(*WatchProjectsResponse_Event)(nil),
+	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_project_v1_project_proto_rawDesc), len(file_project_v1_project_proto_rawDesc)),
-			NumEnums:      0,
-			NumMessages:   11,
+			NumEnums:      4,
+			NumMessages:   44,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
 		GoTypes:           file_project_v1_project_proto_goTypes,
 		DependencyIndexes: file_project_v1_project_proto_depIdxs,
+		EnumInfos:         file_project_v1_project_proto_enumTypes,
 		MessageInfos:      file_project_v1_project_proto_msgTypes,
 	}.Build()
 	File_project_v1_project_proto = out.File
@@ -19,11 +19,24 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	ProjectService_GetAllProjects_FullMethodName = "/project.v1.ProjectService/GetAllProjects"
-	ProjectService_GetProject_FullMethodName     = "/project.v1.ProjectService/GetProject"
-	ProjectService_CreateProject_FullMethodName  = "/project.v1.ProjectService/CreateProject"
-	ProjectService_UpdateProject_FullMethodName  = "/project.v1.ProjectService/UpdateProject"
-	ProjectService_DeleteProject_FullMethodName  = "/project.v1.ProjectService/DeleteProject"
+	ProjectService_GetAllProjects_FullMethodName         = "/project.v1.ProjectService/GetAllProjects"
+	ProjectService_ListProjects_FullMethodName           = "/project.v1.ProjectService/ListProjects"
+	ProjectService_SearchProjects_FullMethodName         = "/project.v1.ProjectService/SearchProjects"
+	ProjectService_GetProject_FullMethodName             = "/project.v1.ProjectService/GetProject"
+	ProjectService_CreateProject_FullMethodName          = "/project.v1.ProjectService/CreateProject"
+	ProjectService_UpdateProject_FullMethodName          = "/project.v1.ProjectService/UpdateProject"
+	ProjectService_TransitionProject_FullMethodName      = "/project.v1.ProjectService/TransitionProject"
+	ProjectService_DeleteProject_FullMethodName          = "/project.v1.ProjectService/DeleteProject"
+	ProjectService_RestoreProject_FullMethodName         = "/project.v1.ProjectService/RestoreProject"
+	ProjectService_GetProjectHistory_FullMethodName      = "/project.v1.ProjectService/GetProjectHistory"
+	ProjectService_AddMember_FullMethodName              = "/project.v1.ProjectService/AddMember"
+	ProjectService_RemoveMember_FullMethodName           = "/project.v1.ProjectService/RemoveMember"
+	ProjectService_ListMembers_FullMethodName            = "/project.v1.ProjectService/ListMembers"
+	ProjectService_ListProjectsForStudent_FullMethodName = "/project.v1.ProjectService/ListProjectsForStudent"
+	ProjectService_ListTags_FullMethodName               = "/project.v1.ProjectService/ListTags"
+	ProjectService_RenameTag_FullMethodName              = "/project.v1.ProjectService/RenameTag"
+	ProjectService_MergeTags_FullMethodName              = "/project.v1.ProjectService/MergeTags"
+	ProjectService_WatchProjects_FullMethodName          = "/project.v1.ProjectService/WatchProjects"
 )
 
 // ProjectServiceClient is the client API for ProjectService service.
@@ -32,16 +45,52 @@ const (
 //
 // ProjectService provides operations on projects
 type ProjectServiceClient interface {
-	// GetAllProjects returns all projects
+	// GetAllProjects returns all projects.
+	// Deprecated: use ListProjects, which is paginated.
 	GetAllProjects(ctx context.Context, in *GetAllProjectsRequest, opts ...grpc.CallOption) (*GetAllProjectsResponse, error)
+	// ListProjects returns a page of projects matching the request filters (AIP-158)
+	ListProjects(ctx context.Context, in *ListProjectsRequest, opts ...grpc.CallOption) (*ListProjectsResponse, error)
+	// SearchProjects returns the projects best matching a full-text query
+	SearchProjects(ctx context.Context, in *SearchProjectsRequest, opts ...grpc.CallOption) (*SearchProjectsResponse, error)
 	// GetProject returns a single project by ID
 	GetProject(ctx context.Context, in *GetProjectRequest, opts ...grpc.CallOption) (*GetProjectResponse, error)
 	// CreateProject creates a new project
 	CreateProject(ctx context.Context, in *CreateProjectRequest, opts ...grpc.CallOption) (*CreateProjectResponse, error)
 	// UpdateProject updates an existing project
 	UpdateProject(ctx context.Context, in *UpdateProjectRequest, opts ...grpc.CallOption) (*UpdateProjectResponse, error)
-	// DeleteProject deletes a project by ID
+	// TransitionProject moves a project to a new lifecycle status. Illegal moves
+	// fail with FAILED_PRECONDITION.
+	TransitionProject(ctx context.Context, in *TransitionProjectRequest, opts ...grpc.CallOption) (*TransitionProjectResponse, error)
+	// DeleteProject soft deletes a project by ID. It can be restored until the
+	// retention period ends and it is purged.
 	DeleteProject(ctx context.Context, in *DeleteProjectRequest, opts ...grpc.CallOption) (*DeleteProjectResponse, error)
+	// RestoreProject undeletes a soft deleted project
+	RestoreProject(ctx context.Context, in *RestoreProjectRequest, opts ...grpc.CallOption) (*RestoreProjectResponse, error)
+	// GetProjectHistory returns the audit trail of a project, including deleted ones.
+	// Changes are attributed to the actor in the x-actor request metadata.
+	GetProjectHistory(ctx context.Context, in *GetProjectHistoryRequest, opts ...grpc.CallOption) (*GetProjectHistoryResponse, error)
+	// AddMember adds a student to a project with the given role. Callers
+	// granting owner or instructor who are not an owner or instructor of the
+	// project themselves are rejected with PERMISSION_DENIED.
+	AddMember(ctx context.Context, in *AddMemberRequest, opts ...grpc.CallOption) (*AddMemberResponse, error)
+	// RemoveMember removes a student from a project
+	RemoveMember(ctx context.Context, in *RemoveMemberRequest, opts ...grpc.CallOption) (*RemoveMemberResponse, error)
+	// ListMembers returns all members of a project
+	ListMembers(ctx context.Context, in *ListMembersRequest, opts ...grpc.CallOption) (*ListMembersResponse, error)
+	// ListProjectsForStudent returns all projects a student is a member of
+	ListProjectsForStudent(ctx context.Context, in *ListProjectsForStudentRequest, opts ...grpc.CallOption) (*ListProjectsForStudentResponse, error)
+	// ListTags returns all tags with the number of projects carrying them
+	ListTags(ctx context.Context, in *ListTagsRequest, opts ...grpc.CallOption) (*ListTagsResponse, error)
+	// RenameTag renames a tag on every project carrying it. Renaming to an
+	// existing tag fails with ALREADY_EXISTS; use MergeTags instead.
+	RenameTag(ctx context.Context, in *RenameTagRequest, opts ...grpc.CallOption) (*RenameTagResponse, error)
+	// MergeTags replaces the source tags with the target tag on every project
+	// and deletes the sources. Unknown sources fail with NOT_FOUND.
+	MergeTags(ctx context.Context, in *MergeTagsRequest, opts ...grpc.CallOption) (*MergeTagsResponse, error)
+	// WatchProjects streams a snapshot of all projects followed by live change events.
+	// The stream ends with RESOURCE_EXHAUSTED if the client cannot keep up and with
+	// UNAVAILABLE when the server shuts down.
+	WatchProjects(ctx context.Context, in *WatchProjectsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[WatchProjectsResponse], error)
 }
 
 type projectServiceClient struct {
@@ -62,6 +111,26 @@ func (c *projectServiceClient) GetAllProjects(ctx context.Context, in *GetAllPro
 	return out, nil
 }
 
+func (c *projectServiceClient) ListProjects(ctx context.Context, in *ListProjectsRequest, opts ...grpc.CallOption) (*ListProjectsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListProjectsResponse)
+	err := c.cc.Invoke(ctx, ProjectService_ListProjects_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *projectServiceClient) SearchProjects(ctx context.Context, in *SearchProjectsRequest, opts ...grpc.CallOption) (*SearchProjectsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SearchProjectsResponse)
+	err := c.cc.Invoke(ctx, ProjectService_SearchProjects_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *projectServiceClient) GetProject(ctx context.Context, in *GetProjectRequest, opts ...grpc.CallOption) (*GetProjectResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(GetProjectResponse)
@@ -92,6 +161,16 @@ func (c *projectServiceClient) UpdateProject(ctx context.Context, in *UpdateProj
 	return out, nil
 }
 
+func (c *projectServiceClient) TransitionProject(ctx context.Context, in *TransitionProjectRequest, opts ...grpc.CallOption) (*TransitionProjectResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TransitionProjectResponse)
+	err := c.cc.Invoke(ctx, ProjectService_TransitionProject_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *projectServiceClient) DeleteProject(ctx context.Context, in *DeleteProjectRequest, opts ...grpc.CallOption) (*DeleteProjectResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(DeleteProjectResponse)
@@ -102,22 +181,167 @@ func (c *projectServiceClient) DeleteProject(ctx context.Context, in *DeleteProj
 	return out, nil
 }
 
+func (c *projectServiceClient) RestoreProject(ctx context.Context, in *RestoreProjectRequest, opts ...grpc.CallOption) (*RestoreProjectResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RestoreProjectResponse)
+	err := c.cc.Invoke(ctx, ProjectService_RestoreProject_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *projectServiceClient) GetProjectHistory(ctx context.Context, in *GetProjectHistoryRequest, opts ...grpc.CallOption) (*GetProjectHistoryResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetProjectHistoryResponse)
+	err := c.cc.Invoke(ctx, ProjectService_GetProjectHistory_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *projectServiceClient) AddMember(ctx context.Context, in *AddMemberRequest, opts ...grpc.CallOption) (*AddMemberResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AddMemberResponse)
+	err := c.cc.Invoke(ctx, ProjectService_AddMember_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *projectServiceClient) RemoveMember(ctx context.Context, in *RemoveMemberRequest, opts ...grpc.CallOption) (*RemoveMemberResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RemoveMemberResponse)
+	err := c.cc.Invoke(ctx, ProjectService_RemoveMember_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *projectServiceClient) ListMembers(ctx context.Context, in *ListMembersRequest, opts ...grpc.CallOption) (*ListMembersResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListMembersResponse)
+	err := c.cc.Invoke(ctx, ProjectService_ListMembers_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *projectServiceClient) ListProjectsForStudent(ctx context.Context, in *ListProjectsForStudentRequest, opts ...grpc.CallOption) (*ListProjectsForStudentResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListProjectsForStudentResponse)
+	err := c.cc.Invoke(ctx, ProjectService_ListProjectsForStudent_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *projectServiceClient) ListTags(ctx context.Context, in *ListTagsRequest, opts ...grpc.CallOption) (*ListTagsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListTagsResponse)
+	err := c.cc.Invoke(ctx, ProjectService_ListTags_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *projectServiceClient) RenameTag(ctx context.Context, in *RenameTagRequest, opts ...grpc.CallOption) (*RenameTagResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RenameTagResponse)
+	err := c.cc.Invoke(ctx, ProjectService_RenameTag_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *projectServiceClient) MergeTags(ctx context.Context, in *MergeTagsRequest, opts ...grpc.CallOption) (*MergeTagsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(MergeTagsResponse)
+	err := c.cc.Invoke(ctx, ProjectService_MergeTags_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *projectServiceClient) WatchProjects(ctx context.Context, in *WatchProjectsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[WatchProjectsResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &ProjectService_ServiceDesc.Streams[0], ProjectService_WatchProjects_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[WatchProjectsRequest, WatchProjectsResponse]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ProjectService_WatchProjectsClient = grpc.ServerStreamingClient[WatchProjectsResponse]
+
 // ProjectServiceServer is the server API for ProjectService service.
 // All implementations must embed UnimplementedProjectServiceServer
 // for forward compatibility.
 //
 // ProjectService provides operations on projects
 type ProjectServiceServer interface {
-	// GetAllProjects returns all projects
+	// GetAllProjects returns all projects.
+	// Deprecated: use ListProjects, which is paginated.
 	GetAllProjects(context.Context, *GetAllProjectsRequest) (*GetAllProjectsResponse, error)
+	// ListProjects returns a page of projects matching the request filters (AIP-158)
+	ListProjects(context.Context, *ListProjectsRequest) (*ListProjectsResponse, error)
+	// SearchProjects returns the projects best matching a full-text query
+	SearchProjects(context.Context, *SearchProjectsRequest) (*SearchProjectsResponse, error)
 	// GetProject returns a single project by ID
 	GetProject(context.Context, *GetProjectRequest) (*GetProjectResponse, error)
 	// CreateProject creates a new project
 	CreateProject(context.Context, *CreateProjectRequest) (*CreateProjectResponse, error)
 	// UpdateProject updates an existing project
 	UpdateProject(context.Context, *UpdateProjectRequest) (*UpdateProjectResponse, error)
-	// DeleteProject deletes a project by ID
+	// TransitionProject moves a project to a new lifecycle status. Illegal moves
+	// fail with FAILED_PRECONDITION.
+	TransitionProject(context.Context, *TransitionProjectRequest) (*TransitionProjectResponse, error)
+	// DeleteProject soft deletes a project by ID. It can be restored until the
+	// retention period ends and it is purged.
 	DeleteProject(context.Context, *DeleteProjectRequest) (*DeleteProjectResponse, error)
+	// RestoreProject undeletes a soft deleted project
+	RestoreProject(context.Context, *RestoreProjectRequest) (*RestoreProjectResponse, error)
+	// GetProjectHistory returns the audit trail of a project, including deleted ones.
+	// Changes are attributed to the actor in the x-actor request metadata.
+	GetProjectHistory(context.Context, *GetProjectHistoryRequest) (*GetProjectHistoryResponse, error)
+	// AddMember adds a student to a project with the given role. Callers
+	// granting owner or instructor who are not an owner or instructor of the
+	// project themselves are rejected with PERMISSION_DENIED.
+	AddMember(context.Context, *AddMemberRequest) (*AddMemberResponse, error)
+	// RemoveMember removes a student from a project
+	RemoveMember(context.Context, *RemoveMemberRequest) (*RemoveMemberResponse, error)
+	// ListMembers returns all members of a project
+	ListMembers(context.Context, *ListMembersRequest) (*ListMembersResponse, error)
+	// ListProjectsForStudent returns all projects a student is a member of
+	ListProjectsForStudent(context.Context, *ListProjectsForStudentRequest) (*ListProjectsForStudentResponse, error)
+	// ListTags returns all tags with the number of projects carrying them
+	ListTags(context.Context, *ListTagsRequest) (*ListTagsResponse, error)
+	// RenameTag renames a tag on every project carrying it. Renaming to an
+	// existing tag fails with ALREADY_EXISTS; use MergeTags instead.
+	RenameTag(context.Context, *RenameTagRequest) (*RenameTagResponse, error)
+	// MergeTags replaces the source tags with the target tag on every project
+	// and deletes the sources. Unknown sources fail with NOT_FOUND.
+	MergeTags(context.Context, *MergeTagsRequest) (*MergeTagsResponse, error)
+	// WatchProjects streams a snapshot of all projects followed by live change events.
+	// The stream ends with RESOURCE_EXHAUSTED if the client cannot keep up and with
+	// UNAVAILABLE when the server shuts down.
+	WatchProjects(*WatchProjectsRequest, grpc.ServerStreamingServer[WatchProjectsResponse]) error
 	mustEmbedUnimplementedProjectServiceServer()
 }
 
@@ -131,6 +355,12 @@ type UnimplementedProjectServiceServer struct{}
 func (UnimplementedProjectServiceServer) GetAllProjects(context.Context, *GetAllProjectsRequest) (*GetAllProjectsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetAllProjects not implemented")
 }
+func (UnimplementedProjectServiceServer) ListProjects(context.Context, *ListProjectsRequest) (*ListProjectsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListProjects not implemented")
+}
+func (UnimplementedProjectServiceServer) SearchProjects(context.Context, *SearchProjectsRequest) (*SearchProjectsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SearchProjects not implemented")
+}
 func (UnimplementedProjectServiceServer) GetProject(context.Context, *GetProjectRequest) (*GetProjectResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetProject not implemented")
 }
@@ -140,9 +370,42 @@ func (UnimplementedProjectServiceServer) CreateProject(context.Context, *CreateP
 func (UnimplementedProjectServiceServer) UpdateProject(context.Context, *UpdateProjectRequest) (*UpdateProjectResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method UpdateProject not implemented")
 }
+func (UnimplementedProjectServiceServer) TransitionProject(context.Context, *TransitionProjectRequest) (*TransitionProjectResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method TransitionProject not implemented")
+}
 func (UnimplementedProjectServiceServer) DeleteProject(context.Context, *DeleteProjectRequest) (*DeleteProjectResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method DeleteProject not implemented")
 }
+func (UnimplementedProjectServiceServer) RestoreProject(context.Context, *RestoreProjectRequest) (*RestoreProjectResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RestoreProject not implemented")
+}
+func (UnimplementedProjectServiceServer) GetProjectHistory(context.Context, *GetProjectHistoryRequest) (*GetProjectHistoryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetProjectHistory not implemented")
+}
+func (UnimplementedProjectServiceServer) AddMember(context.Context, *AddMemberRequest) (*AddMemberResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddMember not implemented")
+}
+func (UnimplementedProjectServiceServer) RemoveMember(context.Context, *RemoveMemberRequest) (*RemoveMemberResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveMember not implemented")
+}
+func (UnimplementedProjectServiceServer) ListMembers(context.Context, *ListMembersRequest) (*ListMembersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListMembers not implemented")
+}
+func (UnimplementedProjectServiceServer) ListProjectsForStudent(context.Context, *ListProjectsForStudentRequest) (*ListProjectsForStudentResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListProjectsForStudent not implemented")
+}
+func (UnimplementedProjectServiceServer) ListTags(context.Context, *ListTagsRequest) (*ListTagsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListTags not implemented")
+}
+func (UnimplementedProjectServiceServer) RenameTag(context.Context, *RenameTagRequest) (*RenameTagResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RenameTag not implemented")
+}
+func (UnimplementedProjectServiceServer) MergeTags(context.Context, *MergeTagsRequest) (*MergeTagsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method MergeTags not implemented")
+}
+func (UnimplementedProjectServiceServer) WatchProjects(*WatchProjectsRequest, grpc.ServerStreamingServer[WatchProjectsResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method WatchProjects not implemented")
+}
 func (UnimplementedProjectServiceServer) mustEmbedUnimplementedProjectServiceServer() {}
 func (UnimplementedProjectServiceServer) testEmbeddedByValue()                        {}
 
@@ -182,6 +445,42 @@ func _ProjectService_GetAllProjects_Handler(srv interface{}, ctx context.Context
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ProjectService_ListProjects_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListProjectsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProjectServiceServer).ListProjects(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ProjectService_ListProjects_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProjectServiceServer).ListProjects(ctx, req.(*ListProjectsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ProjectService_SearchProjects_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SearchProjectsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProjectServiceServer).SearchProjects(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ProjectService_SearchProjects_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProjectServiceServer).SearchProjects(ctx, req.(*SearchProjectsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _ProjectService_GetProject_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(GetProjectRequest)
 	if err := dec(in); err != nil {
@@ -236,6 +535,24 @@ func _ProjectService_UpdateProject_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ProjectService_TransitionProject_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TransitionProjectRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProjectServiceServer).TransitionProject(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ProjectService_TransitionProject_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProjectServiceServer).TransitionProject(ctx, req.(*TransitionProjectRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _ProjectService_DeleteProject_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(DeleteProjectRequest)
 	if err := dec(in); err != nil {
@@ -254,6 +571,179 @@ func _ProjectService_DeleteProject_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ProjectService_RestoreProject_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RestoreProjectRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProjectServiceServer).RestoreProject(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ProjectService_RestoreProject_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProjectServiceServer).RestoreProject(ctx, req.(*RestoreProjectRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ProjectService_GetProjectHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetProjectHistoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProjectServiceServer).GetProjectHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ProjectService_GetProjectHistory_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProjectServiceServer).GetProjectHistory(ctx, req.(*GetProjectHistoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ProjectService_AddMember_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddMemberRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProjectServiceServer).AddMember(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ProjectService_AddMember_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProjectServiceServer).AddMember(ctx, req.(*AddMemberRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ProjectService_RemoveMember_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemoveMemberRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProjectServiceServer).RemoveMember(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ProjectService_RemoveMember_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProjectServiceServer).RemoveMember(ctx, req.(*RemoveMemberRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ProjectService_ListMembers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListMembersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProjectServiceServer).ListMembers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ProjectService_ListMembers_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProjectServiceServer).ListMembers(ctx, req.(*ListMembersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ProjectService_ListProjectsForStudent_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListProjectsForStudentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProjectServiceServer).ListProjectsForStudent(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ProjectService_ListProjectsForStudent_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProjectServiceServer).ListProjectsForStudent(ctx, req.(*ListProjectsForStudentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ProjectService_ListTags_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListTagsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProjectServiceServer).ListTags(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ProjectService_ListTags_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProjectServiceServer).ListTags(ctx, req.(*ListTagsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ProjectService_RenameTag_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RenameTagRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProjectServiceServer).RenameTag(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ProjectService_RenameTag_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProjectServiceServer).RenameTag(ctx, req.(*RenameTagRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ProjectService_MergeTags_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MergeTagsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProjectServiceServer).MergeTags(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ProjectService_MergeTags_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProjectServiceServer).MergeTags(ctx, req.(*MergeTagsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ProjectService_WatchProjects_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchProjectsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ProjectServiceServer).WatchProjects(m, &grpc.GenericServerStream[WatchProjectsRequest, WatchProjectsResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ProjectService_WatchProjectsServer = grpc.ServerStreamingServer[WatchProjectsResponse]
+
 // ProjectService_ServiceDesc is the grpc.ServiceDesc for ProjectService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -265,6 +755,14 @@ var ProjectService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetAllProjects",
 			Handler:    _ProjectService_GetAllProjects_Handler,
 		},
+		{
+			MethodName: "ListProjects",
+			Handler:    _ProjectService_ListProjects_Handler,
+		},
+		{
+			MethodName: "SearchProjects",
+			Handler:    _ProjectService_SearchProjects_Handler,
+		},
 		{
 			MethodName: "GetProject",
 			Handler:    _ProjectService_GetProject_Handler,
@@ -277,11 +775,57 @@ var ProjectService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "UpdateProject",
 			Handler:    _ProjectService_UpdateProject_Handler,
 		},
+		{
+			MethodName: "TransitionProject",
+			Handler:    _ProjectService_TransitionProject_Handler,
+		},
 		{
 			MethodName: "DeleteProject",
 			Handler:    _ProjectService_DeleteProject_Handler,
 		},
+		{
+			MethodName: "RestoreProject",
+			Handler:    _ProjectService_RestoreProject_Handler,
+		},
+		{
+			MethodName: "GetProjectHistory",
+			Handler:    _ProjectService_GetProjectHistory_Handler,
+		},
+		{
+			MethodName: "AddMember",
+			Handler:    _ProjectService_AddMember_Handler,
+		},
+		{
+			MethodName: "RemoveMember",
+			Handler:    _ProjectService_RemoveMember_Handler,
+		},
+		{
+			MethodName: "ListMembers",
+			Handler:    _ProjectService_ListMembers_Handler,
+		},
+		{
+			MethodName: "ListProjectsForStudent",
+			Handler:    _ProjectService_ListProjectsForStudent_Handler,
+		},
+		{
+			MethodName: "ListTags",
+			Handler:    _ProjectService_ListTags_Handler,
+		},
+		{
+			MethodName: "RenameTag",
+			Handler:    _ProjectService_RenameTag_Handler,
+		},
+		{
+			MethodName: "MergeTags",
+			Handler:    _ProjectService_MergeTags_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchProjects",
+			Handler:       _ProjectService_WatchProjects_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "project/v1/project.proto",
 }
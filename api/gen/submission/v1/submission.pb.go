@@ -0,0 +1,1123 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.10
+// 	protoc        v6.33.1
+// source: submission/v1/submission.proto
+
+package submissionv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// SubmissionStatus is where a submission is in the grading workflow
+type SubmissionStatus int32
+
+const (
+	SubmissionStatus_SUBMISSION_STATUS_UNSPECIFIED SubmissionStatus = 0
+	// Waiting for a grade
+	SubmissionStatus_SUBMISSION_STATUS_SUBMITTED SubmissionStatus = 1
+	SubmissionStatus_SUBMISSION_STATUS_GRADED    SubmissionStatus = 2
+	// Its grade was reopened and it is waiting for a new one
+	SubmissionStatus_SUBMISSION_STATUS_REOPENED SubmissionStatus = 3
+)
+
+// Enum value maps for SubmissionStatus.
+var (
+	SubmissionStatus_name = map[int32]string{
+		0: "SUBMISSION_STATUS_UNSPECIFIED",
+		1: "SUBMISSION_STATUS_SUBMITTED",
+		2: "SUBMISSION_STATUS_GRADED",
+		3: "SUBMISSION_STATUS_REOPENED",
+	}
+	SubmissionStatus_value = map[string]int32{
+		"SUBMISSION_STATUS_UNSPECIFIED": 0,
+		"SUBMISSION_STATUS_SUBMITTED":   1,
+		"SUBMISSION_STATUS_GRADED":      2,
+		"SUBMISSION_STATUS_REOPENED":    3,
+	}
+)
+
+func (x SubmissionStatus) Enum() *SubmissionStatus {
+	p := new(SubmissionStatus)
+	*p = x
+	return p
+}
+
+func (x SubmissionStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (SubmissionStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_submission_v1_submission_proto_enumTypes[0].Descriptor()
+}
+
+func (SubmissionStatus) Type() protoreflect.EnumType {
+	return &file_submission_v1_submission_proto_enumTypes[0]
+}
+
+func (x SubmissionStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use SubmissionStatus.Descriptor instead.
+func (SubmissionStatus) EnumDescriptor() ([]byte, []int) {
+	return file_submission_v1_submission_proto_rawDescGZIP(), []int{0}
+}
+
+// RubricItem scores one criterion of a grade
+type RubricItem struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Criterion     string                 `protobuf:"bytes,1,opt,name=criterion,proto3" json:"criterion,omitempty"`
+	Points        float64                `protobuf:"fixed64,2,opt,name=points,proto3" json:"points,omitempty"`
+	MaxPoints     float64                `protobuf:"fixed64,3,opt,name=max_points,json=maxPoints,proto3" json:"max_points,omitempty"`
+	Comment       string                 `protobuf:"bytes,4,opt,name=comment,proto3" json:"comment,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RubricItem) Reset() {
+	*x = RubricItem{}
+	mi := &file_submission_v1_submission_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RubricItem) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RubricItem) ProtoMessage() {}
+
+func (x *RubricItem) ProtoReflect() protoreflect.Message {
+	mi := &file_submission_v1_submission_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RubricItem.ProtoReflect.Descriptor instead.
+func (*RubricItem) Descriptor() ([]byte, []int) {
+	return file_submission_v1_submission_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *RubricItem) GetCriterion() string {
+	if x != nil {
+		return x.Criterion
+	}
+	return ""
+}
+
+func (x *RubricItem) GetPoints() float64 {
+	if x != nil {
+		return x.Points
+	}
+	return 0
+}
+
+func (x *RubricItem) GetMaxPoints() float64 {
+	if x != nil {
+		return x.MaxPoints
+	}
+	return 0
+}
+
+func (x *RubricItem) GetComment() string {
+	if x != nil {
+		return x.Comment
+	}
+	return ""
+}
+
+// Grade is an instructor's assessment of a submission. A reopened grade is
+// kept, with reopened_at set, and superseded by the next one.
+type Grade struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	// Student ID of the instructor who graded the submission
+	GraderId      int32                  `protobuf:"varint,2,opt,name=grader_id,json=graderId,proto3" json:"grader_id,omitempty"`
+	Score         float64                `protobuf:"fixed64,3,opt,name=score,proto3" json:"score,omitempty"`
+	MaxScore      float64                `protobuf:"fixed64,4,opt,name=max_score,json=maxScore,proto3" json:"max_score,omitempty"`
+	Rubric        []*RubricItem          `protobuf:"bytes,5,rep,name=rubric,proto3" json:"rubric,omitempty"`
+	Feedback      string                 `protobuf:"bytes,6,opt,name=feedback,proto3" json:"feedback,omitempty"`
+	GradedAt      *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=graded_at,json=gradedAt,proto3" json:"graded_at,omitempty"`
+	ReopenedAt    *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=reopened_at,json=reopenedAt,proto3" json:"reopened_at,omitempty"`
+	ReopenedBy    int32                  `protobuf:"varint,9,opt,name=reopened_by,json=reopenedBy,proto3" json:"reopened_by,omitempty"`
+	ReopenReason  string                 `protobuf:"bytes,10,opt,name=reopen_reason,json=reopenReason,proto3" json:"reopen_reason,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Grade) Reset() {
+	*x = Grade{}
+	mi := &file_submission_v1_submission_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Grade) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Grade) ProtoMessage() {}
+
+func (x *Grade) ProtoReflect() protoreflect.Message {
+	mi := &file_submission_v1_submission_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Grade.ProtoReflect.Descriptor instead.
+func (*Grade) Descriptor() ([]byte, []int) {
+	return file_submission_v1_submission_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Grade) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Grade) GetGraderId() int32 {
+	if x != nil {
+		return x.GraderId
+	}
+	return 0
+}
+
+func (x *Grade) GetScore() float64 {
+	if x != nil {
+		return x.Score
+	}
+	return 0
+}
+
+func (x *Grade) GetMaxScore() float64 {
+	if x != nil {
+		return x.MaxScore
+	}
+	return 0
+}
+
+func (x *Grade) GetRubric() []*RubricItem {
+	if x != nil {
+		return x.Rubric
+	}
+	return nil
+}
+
+func (x *Grade) GetFeedback() string {
+	if x != nil {
+		return x.Feedback
+	}
+	return ""
+}
+
+func (x *Grade) GetGradedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.GradedAt
+	}
+	return nil
+}
+
+func (x *Grade) GetReopenedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ReopenedAt
+	}
+	return nil
+}
+
+func (x *Grade) GetReopenedBy() int32 {
+	if x != nil {
+		return x.ReopenedBy
+	}
+	return 0
+}
+
+func (x *Grade) GetReopenReason() string {
+	if x != nil {
+		return x.ReopenReason
+	}
+	return ""
+}
+
+// Submission is one version of a student's work on a project
+type Submission struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Id        int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	ProjectId int32                  `protobuf:"varint,2,opt,name=project_id,json=projectId,proto3" json:"project_id,omitempty"`
+	StudentId int32                  `protobuf:"varint,3,opt,name=student_id,json=studentId,proto3" json:"student_id,omitempty"`
+	// Numbers the student's submissions to the project, starting at 1
+	Version int32  `protobuf:"varint,4,opt,name=version,proto3" json:"version,omitempty"`
+	Text    string `protobuf:"bytes,5,opt,name=text,proto3" json:"text,omitempty"`
+	// Attachments of the project handed in with the submission
+	AttachmentIds []int32                `protobuf:"varint,6,rep,packed,name=attachment_ids,json=attachmentIds,proto3" json:"attachment_ids,omitempty"`
+	Status        SubmissionStatus       `protobuf:"varint,7,opt,name=status,proto3,enum=submission.v1.SubmissionStatus" json:"status,omitempty"`
+	SubmittedAt   *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=submitted_at,json=submittedAt,proto3" json:"submitted_at,omitempty"`
+	// Grades given to the submission, newest first
+	Grades        []*Grade `protobuf:"bytes,9,rep,name=grades,proto3" json:"grades,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Submission) Reset() {
+	*x = Submission{}
+	mi := &file_submission_v1_submission_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Submission) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Submission) ProtoMessage() {}
+
+func (x *Submission) ProtoReflect() protoreflect.Message {
+	mi := &file_submission_v1_submission_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Submission.ProtoReflect.Descriptor instead.
+func (*Submission) Descriptor() ([]byte, []int) {
+	return file_submission_v1_submission_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *Submission) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Submission) GetProjectId() int32 {
+	if x != nil {
+		return x.ProjectId
+	}
+	return 0
+}
+
+func (x *Submission) GetStudentId() int32 {
+	if x != nil {
+		return x.StudentId
+	}
+	return 0
+}
+
+func (x *Submission) GetVersion() int32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *Submission) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
+func (x *Submission) GetAttachmentIds() []int32 {
+	if x != nil {
+		return x.AttachmentIds
+	}
+	return nil
+}
+
+func (x *Submission) GetStatus() SubmissionStatus {
+	if x != nil {
+		return x.Status
+	}
+	return SubmissionStatus_SUBMISSION_STATUS_UNSPECIFIED
+}
+
+func (x *Submission) GetSubmittedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.SubmittedAt
+	}
+	return nil
+}
+
+func (x *Submission) GetGrades() []*Grade {
+	if x != nil {
+		return x.Grades
+	}
+	return nil
+}
+
+// CreateSubmissionRequest is the request message for CreateSubmission RPC
+type CreateSubmissionRequest struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	ProjectId int32                  `protobuf:"varint,1,opt,name=project_id,json=projectId,proto3" json:"project_id,omitempty"`
+	// Submitting student, who must be an owner or contributor of the project
+	StudentId     int32   `protobuf:"varint,2,opt,name=student_id,json=studentId,proto3" json:"student_id,omitempty"`
+	Text          string  `protobuf:"bytes,3,opt,name=text,proto3" json:"text,omitempty"`
+	AttachmentIds []int32 `protobuf:"varint,4,rep,packed,name=attachment_ids,json=attachmentIds,proto3" json:"attachment_ids,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateSubmissionRequest) Reset() {
+	*x = CreateSubmissionRequest{}
+	mi := &file_submission_v1_submission_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateSubmissionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateSubmissionRequest) ProtoMessage() {}
+
+func (x *CreateSubmissionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_submission_v1_submission_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateSubmissionRequest.ProtoReflect.Descriptor instead.
+func (*CreateSubmissionRequest) Descriptor() ([]byte, []int) {
+	return file_submission_v1_submission_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *CreateSubmissionRequest) GetProjectId() int32 {
+	if x != nil {
+		return x.ProjectId
+	}
+	return 0
+}
+
+func (x *CreateSubmissionRequest) GetStudentId() int32 {
+	if x != nil {
+		return x.StudentId
+	}
+	return 0
+}
+
+func (x *CreateSubmissionRequest) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
+func (x *CreateSubmissionRequest) GetAttachmentIds() []int32 {
+	if x != nil {
+		return x.AttachmentIds
+	}
+	return nil
+}
+
+// CreateSubmissionResponse is the response message for CreateSubmission RPC
+type CreateSubmissionResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Submission    *Submission            `protobuf:"bytes,1,opt,name=submission,proto3" json:"submission,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateSubmissionResponse) Reset() {
+	*x = CreateSubmissionResponse{}
+	mi := &file_submission_v1_submission_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateSubmissionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateSubmissionResponse) ProtoMessage() {}
+
+func (x *CreateSubmissionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_submission_v1_submission_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateSubmissionResponse.ProtoReflect.Descriptor instead.
+func (*CreateSubmissionResponse) Descriptor() ([]byte, []int) {
+	return file_submission_v1_submission_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *CreateSubmissionResponse) GetSubmission() *Submission {
+	if x != nil {
+		return x.Submission
+	}
+	return nil
+}
+
+// GetSubmissionRequest is the request message for GetSubmission RPC
+type GetSubmissionRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	// Student asking, who must be the author or an instructor of the project
+	ViewerId      int32 `protobuf:"varint,2,opt,name=viewer_id,json=viewerId,proto3" json:"viewer_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetSubmissionRequest) Reset() {
+	*x = GetSubmissionRequest{}
+	mi := &file_submission_v1_submission_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetSubmissionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSubmissionRequest) ProtoMessage() {}
+
+func (x *GetSubmissionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_submission_v1_submission_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetSubmissionRequest.ProtoReflect.Descriptor instead.
+func (*GetSubmissionRequest) Descriptor() ([]byte, []int) {
+	return file_submission_v1_submission_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *GetSubmissionRequest) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *GetSubmissionRequest) GetViewerId() int32 {
+	if x != nil {
+		return x.ViewerId
+	}
+	return 0
+}
+
+// GetSubmissionResponse is the response message for GetSubmission RPC
+type GetSubmissionResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Submission    *Submission            `protobuf:"bytes,1,opt,name=submission,proto3" json:"submission,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetSubmissionResponse) Reset() {
+	*x = GetSubmissionResponse{}
+	mi := &file_submission_v1_submission_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetSubmissionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSubmissionResponse) ProtoMessage() {}
+
+func (x *GetSubmissionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_submission_v1_submission_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetSubmissionResponse.ProtoReflect.Descriptor instead.
+func (*GetSubmissionResponse) Descriptor() ([]byte, []int) {
+	return file_submission_v1_submission_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *GetSubmissionResponse) GetSubmission() *Submission {
+	if x != nil {
+		return x.Submission
+	}
+	return nil
+}
+
+// ListSubmissionsRequest filters submissions by project, student or both.
+// At least one of them is required. Viewers who are not instructors of the
+// project only see their own submissions.
+type ListSubmissionsRequest struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	ProjectId int32                  `protobuf:"varint,1,opt,name=project_id,json=projectId,proto3" json:"project_id,omitempty"`
+	StudentId int32                  `protobuf:"varint,2,opt,name=student_id,json=studentId,proto3" json:"student_id,omitempty"`
+	// Student asking
+	ViewerId      int32 `protobuf:"varint,3,opt,name=viewer_id,json=viewerId,proto3" json:"viewer_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListSubmissionsRequest) Reset() {
+	*x = ListSubmissionsRequest{}
+	mi := &file_submission_v1_submission_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListSubmissionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSubmissionsRequest) ProtoMessage() {}
+
+func (x *ListSubmissionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_submission_v1_submission_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSubmissionsRequest.ProtoReflect.Descriptor instead.
+func (*ListSubmissionsRequest) Descriptor() ([]byte, []int) {
+	return file_submission_v1_submission_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *ListSubmissionsRequest) GetProjectId() int32 {
+	if x != nil {
+		return x.ProjectId
+	}
+	return 0
+}
+
+func (x *ListSubmissionsRequest) GetStudentId() int32 {
+	if x != nil {
+		return x.StudentId
+	}
+	return 0
+}
+
+func (x *ListSubmissionsRequest) GetViewerId() int32 {
+	if x != nil {
+		return x.ViewerId
+	}
+	return 0
+}
+
+// ListSubmissionsResponse lists the matching submissions, newest first
+type ListSubmissionsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Submissions   []*Submission          `protobuf:"bytes,1,rep,name=submissions,proto3" json:"submissions,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListSubmissionsResponse) Reset() {
+	*x = ListSubmissionsResponse{}
+	mi := &file_submission_v1_submission_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListSubmissionsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSubmissionsResponse) ProtoMessage() {}
+
+func (x *ListSubmissionsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_submission_v1_submission_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSubmissionsResponse.ProtoReflect.Descriptor instead.
+func (*ListSubmissionsResponse) Descriptor() ([]byte, []int) {
+	return file_submission_v1_submission_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *ListSubmissionsResponse) GetSubmissions() []*Submission {
+	if x != nil {
+		return x.Submissions
+	}
+	return nil
+}
+
+// GradeSubmissionRequest is the request message for GradeSubmission RPC.
+// When a rubric is given, score and max_score must be the sums of its points
+// and max_points.
+type GradeSubmissionRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	// Grading student, who must be an instructor of the project
+	GraderId      int32         `protobuf:"varint,2,opt,name=grader_id,json=graderId,proto3" json:"grader_id,omitempty"`
+	Score         float64       `protobuf:"fixed64,3,opt,name=score,proto3" json:"score,omitempty"`
+	MaxScore      float64       `protobuf:"fixed64,4,opt,name=max_score,json=maxScore,proto3" json:"max_score,omitempty"`
+	Rubric        []*RubricItem `protobuf:"bytes,5,rep,name=rubric,proto3" json:"rubric,omitempty"`
+	Feedback      string        `protobuf:"bytes,6,opt,name=feedback,proto3" json:"feedback,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GradeSubmissionRequest) Reset() {
+	*x = GradeSubmissionRequest{}
+	mi := &file_submission_v1_submission_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GradeSubmissionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GradeSubmissionRequest) ProtoMessage() {}
+
+func (x *GradeSubmissionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_submission_v1_submission_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GradeSubmissionRequest.ProtoReflect.Descriptor instead.
+func (*GradeSubmissionRequest) Descriptor() ([]byte, []int) {
+	return file_submission_v1_submission_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *GradeSubmissionRequest) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *GradeSubmissionRequest) GetGraderId() int32 {
+	if x != nil {
+		return x.GraderId
+	}
+	return 0
+}
+
+func (x *GradeSubmissionRequest) GetScore() float64 {
+	if x != nil {
+		return x.Score
+	}
+	return 0
+}
+
+func (x *GradeSubmissionRequest) GetMaxScore() float64 {
+	if x != nil {
+		return x.MaxScore
+	}
+	return 0
+}
+
+func (x *GradeSubmissionRequest) GetRubric() []*RubricItem {
+	if x != nil {
+		return x.Rubric
+	}
+	return nil
+}
+
+func (x *GradeSubmissionRequest) GetFeedback() string {
+	if x != nil {
+		return x.Feedback
+	}
+	return ""
+}
+
+// GradeSubmissionResponse is the response message for GradeSubmission RPC
+type GradeSubmissionResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Submission    *Submission            `protobuf:"bytes,1,opt,name=submission,proto3" json:"submission,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GradeSubmissionResponse) Reset() {
+	*x = GradeSubmissionResponse{}
+	mi := &file_submission_v1_submission_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GradeSubmissionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GradeSubmissionResponse) ProtoMessage() {}
+
+func (x *GradeSubmissionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_submission_v1_submission_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GradeSubmissionResponse.ProtoReflect.Descriptor instead.
+func (*GradeSubmissionResponse) Descriptor() ([]byte, []int) {
+	return file_submission_v1_submission_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *GradeSubmissionResponse) GetSubmission() *Submission {
+	if x != nil {
+		return x.Submission
+	}
+	return nil
+}
+
+// ReopenGradeRequest is the request message for ReopenGrade RPC
+type ReopenGradeRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	// Reopening student, who must be an instructor of the project
+	GraderId      int32  `protobuf:"varint,2,opt,name=grader_id,json=graderId,proto3" json:"grader_id,omitempty"`
+	Reason        string `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReopenGradeRequest) Reset() {
+	*x = ReopenGradeRequest{}
+	mi := &file_submission_v1_submission_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReopenGradeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReopenGradeRequest) ProtoMessage() {}
+
+func (x *ReopenGradeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_submission_v1_submission_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReopenGradeRequest.ProtoReflect.Descriptor instead.
+func (*ReopenGradeRequest) Descriptor() ([]byte, []int) {
+	return file_submission_v1_submission_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *ReopenGradeRequest) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *ReopenGradeRequest) GetGraderId() int32 {
+	if x != nil {
+		return x.GraderId
+	}
+	return 0
+}
+
+func (x *ReopenGradeRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+// ReopenGradeResponse is the response message for ReopenGrade RPC
+type ReopenGradeResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Submission    *Submission            `protobuf:"bytes,1,opt,name=submission,proto3" json:"submission,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReopenGradeResponse) Reset() {
+	*x = ReopenGradeResponse{}
+	mi := &file_submission_v1_submission_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReopenGradeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReopenGradeResponse) ProtoMessage() {}
+
+func (x *ReopenGradeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_submission_v1_submission_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReopenGradeResponse.ProtoReflect.Descriptor instead.
+func (*ReopenGradeResponse) Descriptor() ([]byte, []int) {
+	return file_submission_v1_submission_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *ReopenGradeResponse) GetSubmission() *Submission {
+	if x != nil {
+		return x.Submission
+	}
+	return nil
+}
+
+var File_submission_v1_submission_proto protoreflect.FileDescriptor
+
+const file_submission_v1_submission_proto_rawDesc = "" +
+	"\n" +
+	"\x1esubmission/v1/submission.proto\x12\rsubmission.v1\x1a\x1fgoogle/protobuf/timestamp.proto\"{\n" +
+	"\n" +
+	"RubricItem\x12\x1c\n" +
+	"\tcriterion\x18\x01 \x01(\tR\tcriterion\x12\x16\n" +
+	"\x06points\x18\x02 \x01(\x01R\x06points\x12\x1d\n" +
+	"\n" +
+	"max_points\x18\x03 \x01(\x01R\tmaxPoints\x12\x18\n" +
+	"\acomment\x18\x04 \x01(\tR\acomment\"\xf2\x02\n" +
+	"\x05Grade\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x05R\x02id\x12\x1b\n" +
+	"\tgrader_id\x18\x02 \x01(\x05R\bgraderId\x12\x14\n" +
+	"\x05score\x18\x03 \x01(\x01R\x05score\x12\x1b\n" +
+	"\tmax_score\x18\x04 \x01(\x01R\bmaxScore\x121\n" +
+	"\x06rubric\x18\x05 \x03(\v2\x19.submission.v1.RubricItemR\x06rubric\x12\x1a\n" +
+	"\bfeedback\x18\x06 \x01(\tR\bfeedback\x127\n" +
+	"\tgraded_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\bgradedAt\x12;\n" +
+	"\vreopened_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"reopenedAt\x12\x1f\n" +
+	"\vreopened_by\x18\t \x01(\x05R\n" +
+	"reopenedBy\x12#\n" +
+	"\rreopen_reason\x18\n" +
+	" \x01(\tR\freopenReason\"\xd5\x02\n" +
+	"\n" +
+	"Submission\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x05R\x02id\x12\x1d\n" +
+	"\n" +
+	"project_id\x18\x02 \x01(\x05R\tprojectId\x12\x1d\n" +
+	"\n" +
+	"student_id\x18\x03 \x01(\x05R\tstudentId\x12\x18\n" +
+	"\aversion\x18\x04 \x01(\x05R\aversion\x12\x12\n" +
+	"\x04text\x18\x05 \x01(\tR\x04text\x12%\n" +
+	"\x0eattachment_ids\x18\x06 \x03(\x05R\rattachmentIds\x127\n" +
+	"\x06status\x18\a \x01(\x0e2\x1f.submission.v1.SubmissionStatusR\x06status\x12=\n" +
+	"\fsubmitted_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\vsubmittedAt\x12,\n" +
+	"\x06grades\x18\t \x03(\v2\x14.submission.v1.GradeR\x06grades\"\x92\x01\n" +
+	"\x17CreateSubmissionRequest\x12\x1d\n" +
+	"\n" +
+	"project_id\x18\x01 \x01(\x05R\tprojectId\x12\x1d\n" +
+	"\n" +
+	"student_id\x18\x02 \x01(\x05R\tstudentId\x12\x12\n" +
+	"\x04text\x18\x03 \x01(\tR\x04text\x12%\n" +
+	"\x0eattachment_ids\x18\x04 \x03(\x05R\rattachmentIds\"U\n" +
+	"\x18CreateSubmissionResponse\x129\n" +
+	"\n" +
+	"submission\x18\x01 \x01(\v2\x19.submission.v1.SubmissionR\n" +
+	"submission\"C\n" +
+	"\x14GetSubmissionRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x05R\x02id\x12\x1b\n" +
+	"\tviewer_id\x18\x02 \x01(\x05R\bviewerId\"R\n" +
+	"\x15GetSubmissionResponse\x129\n" +
+	"\n" +
+	"submission\x18\x01 \x01(\v2\x19.submission.v1.SubmissionR\n" +
+	"submission\"s\n" +
+	"\x16ListSubmissionsRequest\x12\x1d\n" +
+	"\n" +
+	"project_id\x18\x01 \x01(\x05R\tprojectId\x12\x1d\n" +
+	"\n" +
+	"student_id\x18\x02 \x01(\x05R\tstudentId\x12\x1b\n" +
+	"\tviewer_id\x18\x03 \x01(\x05R\bviewerId\"V\n" +
+	"\x17ListSubmissionsResponse\x12;\n" +
+	"\vsubmissions\x18\x01 \x03(\v2\x19.submission.v1.SubmissionR\vsubmissions\"\xc7\x01\n" +
+	"\x16GradeSubmissionRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x05R\x02id\x12\x1b\n" +
+	"\tgrader_id\x18\x02 \x01(\x05R\bgraderId\x12\x14\n" +
+	"\x05score\x18\x03 \x01(\x01R\x05score\x12\x1b\n" +
+	"\tmax_score\x18\x04 \x01(\x01R\bmaxScore\x121\n" +
+	"\x06rubric\x18\x05 \x03(\v2\x19.submission.v1.RubricItemR\x06rubric\x12\x1a\n" +
+	"\bfeedback\x18\x06 \x01(\tR\bfeedback\"T\n" +
+	"\x17GradeSubmissionResponse\x129\n" +
+	"\n" +
+	"submission\x18\x01 \x01(\v2\x19.submission.v1.SubmissionR\n" +
+	"submission\"Y\n" +
+	"\x12ReopenGradeRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x05R\x02id\x12\x1b\n" +
+	"\tgrader_id\x18\x02 \x01(\x05R\bgraderId\x12\x16\n" +
+	"\x06reason\x18\x03 \x01(\tR\x06reason\"P\n" +
+	"\x13ReopenGradeResponse\x129\n" +
+	"\n" +
+	"submission\x18\x01 \x01(\v2\x19.submission.v1.SubmissionR\n" +
+	"submission*\x94\x01\n" +
+	"\x10SubmissionStatus\x12!\n" +
+	"\x1dSUBMISSION_STATUS_UNSPECIFIED\x10\x00\x12\x1f\n" +
+	"\x1bSUBMISSION_STATUS_SUBMITTED\x10\x01\x12\x1c\n" +
+	"\x18SUBMISSION_STATUS_GRADED\x10\x02\x12\x1e\n" +
+	"\x1aSUBMISSION_STATUS_REOPENED\x10\x032\xee\x03\n" +
+	"\x11SubmissionService\x12c\n" +
+	"\x10CreateSubmission\x12&.submission.v1.CreateSubmissionRequest\x1a'.submission.v1.CreateSubmissionResponse\x12Z\n" +
+	"\rGetSubmission\x12#.submission.v1.GetSubmissionRequest\x1a$.submission.v1.GetSubmissionResponse\x12`\n" +
+	"\x0fListSubmissions\x12%.submission.v1.ListSubmissionsRequest\x1a&.submission.v1.ListSubmissionsResponse\x12`\n" +
+	"\x0fGradeSubmission\x12%.submission.v1.GradeSubmissionRequest\x1a&.submission.v1.GradeSubmissionResponse\x12T\n" +
+	"\vReopenGrade\x12!.submission.v1.ReopenGradeRequest\x1a\".submission.v1.ReopenGradeResponseB)Z'grud/api/gen/submission/v1;submissionv1b\x06proto3"
+
+var (
+	file_submission_v1_submission_proto_rawDescOnce sync.Once
+	file_submission_v1_submission_proto_rawDescData []byte
+)
+
+func file_submission_v1_submission_proto_rawDescGZIP() []byte {
+	file_submission_v1_submission_proto_rawDescOnce.Do(func() {
+		file_submission_v1_submission_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_submission_v1_submission_proto_rawDesc), len(file_submission_v1_submission_proto_rawDesc)))
+	})
+	return file_submission_v1_submission_proto_rawDescData
+}
+
+var file_submission_v1_submission_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_submission_v1_submission_proto_msgTypes = make([]protoimpl.MessageInfo, 13)
+var file_submission_v1_submission_proto_goTypes = []any{
+	(SubmissionStatus)(0),            // 0: submission.v1.SubmissionStatus
+	(*RubricItem)(nil),               // 1: submission.v1.RubricItem
+	(*Grade)(nil),                    // 2: submission.v1.Grade
+	(*Submission)(nil),               // 3: submission.v1.Submission
+	(*CreateSubmissionRequest)(nil),  // 4: submission.v1.CreateSubmissionRequest
+	(*CreateSubmissionResponse)(nil), // 5: submission.v1.CreateSubmissionResponse
+	(*GetSubmissionRequest)(nil),     // 6: submission.v1.GetSubmissionRequest
+	(*GetSubmissionResponse)(nil),    // 7: submission.v1.GetSubmissionResponse
+	(*ListSubmissionsRequest)(nil),   // 8: submission.v1.ListSubmissionsRequest
+	(*ListSubmissionsResponse)(nil),  // 9: submission.v1.ListSubmissionsResponse
+	(*GradeSubmissionRequest)(nil),   // 10: submission.v1.GradeSubmissionRequest
+	(*GradeSubmissionResponse)(nil),  // 11: submission.v1.GradeSubmissionResponse
+	(*ReopenGradeRequest)(nil),       // 12: submission.v1.ReopenGradeRequest
+	(*ReopenGradeResponse)(nil),      // 13: submission.v1.ReopenGradeResponse
+	(*timestamppb.Timestamp)(nil),    // 14: google.protobuf.Timestamp
+}
+var file_submission_v1_submission_proto_depIdxs = []int32{
+	1,  // 0: submission.v1.Grade.rubric:type_name -> submission.v1.RubricItem
+	14, // 1: submission.v1.Grade.graded_at:type_name -> google.protobuf.Timestamp
+	14, // 2: submission.v1.Grade.reopened_at:type_name -> google.protobuf.Timestamp
+	0,  // 3: submission.v1.Submission.status:type_name -> submission.v1.SubmissionStatus
+	14, // 4: submission.v1.Submission.submitted_at:type_name -> google.protobuf.Timestamp
+	2,  // 5: submission.v1.Submission.grades:type_name -> submission.v1.Grade
+	3,  // 6: submission.v1.CreateSubmissionResponse.submission:type_name -> submission.v1.Submission
+	3,  // 7: submission.v1.GetSubmissionResponse.submission:type_name -> submission.v1.Submission
+	3,  // 8: submission.v1.ListSubmissionsResponse.submissions:type_name -> submission.v1.Submission
+	1,  // 9: submission.v1.GradeSubmissionRequest.rubric:type_name -> submission.v1.RubricItem
+	3,  // 10: submission.v1.GradeSubmissionResponse.submission:type_name -> submission.v1.Submission
+	3,  // 11: submission.v1.ReopenGradeResponse.submission:type_name -> submission.v1.Submission
+	4,  // 12: submission.v1.SubmissionService.CreateSubmission:input_type -> submission.v1.CreateSubmissionRequest
+	6,  // 13: submission.v1.SubmissionService.GetSubmission:input_type -> submission.v1.GetSubmissionRequest
+	8,  // 14: submission.v1.SubmissionService.ListSubmissions:input_type -> submission.v1.ListSubmissionsRequest
+	10, // 15: submission.v1.SubmissionService.GradeSubmission:input_type -> submission.v1.GradeSubmissionRequest
+	12, // 16: submission.v1.SubmissionService.ReopenGrade:input_type -> submission.v1.ReopenGradeRequest
+	5,  // 17: submission.v1.SubmissionService.CreateSubmission:output_type -> submission.v1.CreateSubmissionResponse
+	7,  // 18: submission.v1.SubmissionService.GetSubmission:output_type -> submission.v1.GetSubmissionResponse
+	9,  // 19: submission.v1.SubmissionService.ListSubmissions:output_type -> submission.v1.ListSubmissionsResponse
+	11, // 20: submission.v1.SubmissionService.GradeSubmission:output_type -> submission.v1.GradeSubmissionResponse
+	13, // 21: submission.v1.SubmissionService.ReopenGrade:output_type -> submission.v1.ReopenGradeResponse
+	17, // [17:22] is the sub-list for method output_type
+	12, // [12:17] is the sub-list for method input_type
+	12, // [12:12] is the sub-list for extension type_name
+	12, // [12:12] is the sub-list for extension extendee
+	0,  // [0:12] is the sub-list for field type_name
+}
+
+func init() { file_submission_v1_submission_proto_init() }
+func file_submission_v1_submission_proto_init() {
+	if File_submission_v1_submission_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_submission_v1_submission_proto_rawDesc), len(file_submission_v1_submission_proto_rawDesc)),
+			NumEnums:      1,
+			NumMessages:   13,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_submission_v1_submission_proto_goTypes,
+		DependencyIndexes: file_submission_v1_submission_proto_depIdxs,
+		EnumInfos:         file_submission_v1_submission_proto_enumTypes,
+		MessageInfos:      file_submission_v1_submission_proto_msgTypes,
+	}.Build()
+	File_submission_v1_submission_proto = out.File
+	file_submission_v1_submission_proto_goTypes = nil
+	file_submission_v1_submission_proto_depIdxs = nil
+}
@@ -0,0 +1,297 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             v6.33.1
+// source: submission/v1/submission.proto
+
+package submissionv1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	SubmissionService_CreateSubmission_FullMethodName = "/submission.v1.SubmissionService/CreateSubmission"
+	SubmissionService_GetSubmission_FullMethodName    = "/submission.v1.SubmissionService/GetSubmission"
+	SubmissionService_ListSubmissions_FullMethodName  = "/submission.v1.SubmissionService/ListSubmissions"
+	SubmissionService_GradeSubmission_FullMethodName  = "/submission.v1.SubmissionService/GradeSubmission"
+	SubmissionService_ReopenGrade_FullMethodName      = "/submission.v1.SubmissionService/ReopenGrade"
+)
+
+// SubmissionServiceClient is the client API for SubmissionService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// SubmissionService records coursework handed in on projects and its grades
+type SubmissionServiceClient interface {
+	// CreateSubmission records a new version of the student's work. The
+	// project must be active; callers who are not owners or contributors are
+	// rejected with PERMISSION_DENIED.
+	CreateSubmission(ctx context.Context, in *CreateSubmissionRequest, opts ...grpc.CallOption) (*CreateSubmissionResponse, error)
+	// GetSubmission returns a submission with its grades to its author or an
+	// instructor of its project; anyone else gets PERMISSION_DENIED.
+	GetSubmission(ctx context.Context, in *GetSubmissionRequest, opts ...grpc.CallOption) (*GetSubmissionResponse, error)
+	ListSubmissions(ctx context.Context, in *ListSubmissionsRequest, opts ...grpc.CallOption) (*ListSubmissionsResponse, error)
+	// GradeSubmission grades a submitted or reopened submission. Graders who
+	// are not instructors of the project are rejected with PERMISSION_DENIED,
+	// and a submission that is already graded with FAILED_PRECONDITION.
+	GradeSubmission(ctx context.Context, in *GradeSubmissionRequest, opts ...grpc.CallOption) (*GradeSubmissionResponse, error)
+	// ReopenGrade reopens the grade of a graded submission so that it can be
+	// graded again. It has the same restrictions as GradeSubmission.
+	ReopenGrade(ctx context.Context, in *ReopenGradeRequest, opts ...grpc.CallOption) (*ReopenGradeResponse, error)
+}
+
+type submissionServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewSubmissionServiceClient(cc grpc.ClientConnInterface) SubmissionServiceClient {
+	return &submissionServiceClient{cc}
+}
+
+func (c *submissionServiceClient) CreateSubmission(ctx context.Context, in *CreateSubmissionRequest, opts ...grpc.CallOption) (*CreateSubmissionResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreateSubmissionResponse)
+	err := c.cc.Invoke(ctx, SubmissionService_CreateSubmission_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *submissionServiceClient) GetSubmission(ctx context.Context, in *GetSubmissionRequest, opts ...grpc.CallOption) (*GetSubmissionResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetSubmissionResponse)
+	err := c.cc.Invoke(ctx, SubmissionService_GetSubmission_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *submissionServiceClient) ListSubmissions(ctx context.Context, in *ListSubmissionsRequest, opts ...grpc.CallOption) (*ListSubmissionsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListSubmissionsResponse)
+	err := c.cc.Invoke(ctx, SubmissionService_ListSubmissions_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *submissionServiceClient) GradeSubmission(ctx context.Context, in *GradeSubmissionRequest, opts ...grpc.CallOption) (*GradeSubmissionResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GradeSubmissionResponse)
+	err := c.cc.Invoke(ctx, SubmissionService_GradeSubmission_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *submissionServiceClient) ReopenGrade(ctx context.Context, in *ReopenGradeRequest, opts ...grpc.CallOption) (*ReopenGradeResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ReopenGradeResponse)
+	err := c.cc.Invoke(ctx, SubmissionService_ReopenGrade_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// SubmissionServiceServer is the server API for SubmissionService service.
+// All implementations must embed UnimplementedSubmissionServiceServer
+// for forward compatibility.
+//
+// SubmissionService records coursework handed in on projects and its grades
+type SubmissionServiceServer interface {
+	// CreateSubmission records a new version of the student's work. The
+	// project must be active; callers who are not owners or contributors are
+	// rejected with PERMISSION_DENIED.
+	CreateSubmission(context.Context, *CreateSubmissionRequest) (*CreateSubmissionResponse, error)
+	// GetSubmission returns a submission with its grades to its author or an
+	// instructor of its project; anyone else gets PERMISSION_DENIED.
+	GetSubmission(context.Context, *GetSubmissionRequest) (*GetSubmissionResponse, error)
+	ListSubmissions(context.Context, *ListSubmissionsRequest) (*ListSubmissionsResponse, error)
+	// GradeSubmission grades a submitted or reopened submission. Graders who
+	// are not instructors of the project are rejected with PERMISSION_DENIED,
+	// and a submission that is already graded with FAILED_PRECONDITION.
+	GradeSubmission(context.Context, *GradeSubmissionRequest) (*GradeSubmissionResponse, error)
+	// ReopenGrade reopens the grade of a graded submission so that it can be
+	// graded again. It has the same restrictions as GradeSubmission.
+	ReopenGrade(context.Context, *ReopenGradeRequest) (*ReopenGradeResponse, error)
+	mustEmbedUnimplementedSubmissionServiceServer()
+}
+
+// UnimplementedSubmissionServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedSubmissionServiceServer struct{}
+
+func (UnimplementedSubmissionServiceServer) CreateSubmission(context.Context, *CreateSubmissionRequest) (*CreateSubmissionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateSubmission not implemented")
+}
+func (UnimplementedSubmissionServiceServer) GetSubmission(context.Context, *GetSubmissionRequest) (*GetSubmissionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetSubmission not implemented")
+}
+func (UnimplementedSubmissionServiceServer) ListSubmissions(context.Context, *ListSubmissionsRequest) (*ListSubmissionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListSubmissions not implemented")
+}
+func (UnimplementedSubmissionServiceServer) GradeSubmission(context.Context, *GradeSubmissionRequest) (*GradeSubmissionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GradeSubmission not implemented")
+}
+func (UnimplementedSubmissionServiceServer) ReopenGrade(context.Context, *ReopenGradeRequest) (*ReopenGradeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReopenGrade not implemented")
+}
+func (UnimplementedSubmissionServiceServer) mustEmbedUnimplementedSubmissionServiceServer() {}
+func (UnimplementedSubmissionServiceServer) testEmbeddedByValue()                           {}
+
+// UnsafeSubmissionServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to SubmissionServiceServer will
+// result in compilation errors.
+type UnsafeSubmissionServiceServer interface {
+	mustEmbedUnimplementedSubmissionServiceServer()
+}
+
+func RegisterSubmissionServiceServer(s grpc.ServiceRegistrar, srv SubmissionServiceServer) {
+	// If the following call pancis, it indicates UnimplementedSubmissionServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&SubmissionService_ServiceDesc, srv)
+}
+
+func _SubmissionService_CreateSubmission_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateSubmissionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SubmissionServiceServer).CreateSubmission(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SubmissionService_CreateSubmission_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SubmissionServiceServer).CreateSubmission(ctx, req.(*CreateSubmissionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SubmissionService_GetSubmission_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetSubmissionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SubmissionServiceServer).GetSubmission(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SubmissionService_GetSubmission_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SubmissionServiceServer).GetSubmission(ctx, req.(*GetSubmissionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SubmissionService_ListSubmissions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListSubmissionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SubmissionServiceServer).ListSubmissions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SubmissionService_ListSubmissions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SubmissionServiceServer).ListSubmissions(ctx, req.(*ListSubmissionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SubmissionService_GradeSubmission_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GradeSubmissionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SubmissionServiceServer).GradeSubmission(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SubmissionService_GradeSubmission_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SubmissionServiceServer).GradeSubmission(ctx, req.(*GradeSubmissionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SubmissionService_ReopenGrade_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReopenGradeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SubmissionServiceServer).ReopenGrade(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SubmissionService_ReopenGrade_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SubmissionServiceServer).ReopenGrade(ctx, req.(*ReopenGradeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// SubmissionService_ServiceDesc is the grpc.ServiceDesc for SubmissionService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var SubmissionService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "submission.v1.SubmissionService",
+	HandlerType: (*SubmissionServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CreateSubmission",
+			Handler:    _SubmissionService_CreateSubmission_Handler,
+		},
+		{
+			MethodName: "GetSubmission",
+			Handler:    _SubmissionService_GetSubmission_Handler,
+		},
+		{
+			MethodName: "ListSubmissions",
+			Handler:    _SubmissionService_ListSubmissions_Handler,
+		},
+		{
+			MethodName: "GradeSubmission",
+			Handler:    _SubmissionService_GradeSubmission_Handler,
+		},
+		{
+			MethodName: "ReopenGrade",
+			Handler:    _SubmissionService_ReopenGrade_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "submission/v1/submission.proto",
+}
@@ -0,0 +1,81 @@
+package history
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// ActorMetadataKey is the gRPC metadata key callers use to say on whose behalf
+// they act, so the history of both services names the same actor. It is
+// trusted as-is: project-service is only reachable by other services inside
+// the cluster, which authenticate the end user themselves.
+const ActorMetadataKey = "x-actor"
+
+// SystemActor is recorded for changes made without a known caller, such as
+// background jobs or clients that send no actor
+const SystemActor = "system"
+
+type actorKey struct{}
+
+// WithActor returns a context whose changes are attributed to actor
+func WithActor(ctx context.Context, actor string) context.Context {
+	return context.WithValue(ctx, actorKey{}, actor)
+}
+
+// ActorFromContext returns the actor set by WithActor, or SystemActor
+func ActorFromContext(ctx context.Context) string {
+	if actor, ok := ctx.Value(actorKey{}).(string); ok && actor != "" {
+		return actor
+	}
+	return SystemActor
+}
+
+// UnaryClientInterceptor forwards the actor in ctx as outgoing metadata
+func UnaryClientInterceptor() grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		ctx = metadata.AppendToOutgoingContext(ctx, ActorMetadataKey, ActorFromContext(ctx))
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}
+}
+
+// StreamClientInterceptor forwards the actor in ctx as outgoing metadata of
+// streaming calls
+func StreamClientInterceptor() grpc.StreamClientInterceptor {
+	return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		ctx = metadata.AppendToOutgoingContext(ctx, ActorMetadataKey, ActorFromContext(ctx))
+		return streamer(ctx, desc, cc, method, opts...)
+	}
+}
+
+// UnaryServerInterceptor copies the actor from incoming metadata into the context
+func UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if values := metadata.ValueFromIncomingContext(ctx, ActorMetadataKey); len(values) > 0 {
+			ctx = WithActor(ctx, values[0])
+		}
+		return handler(ctx, req)
+	}
+}
+
+// StreamServerInterceptor copies the actor from incoming metadata into the
+// context of streaming calls
+func StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if values := metadata.ValueFromIncomingContext(ss.Context(), ActorMetadataKey); len(values) > 0 {
+			ss = &actorStream{ServerStream: ss, ctx: WithActor(ss.Context(), values[0])}
+		}
+		return handler(srv, ss)
+	}
+}
+
+// actorStream is a server stream whose context carries the actor
+type actorStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *actorStream) Context() context.Context {
+	return s.ctx
+}
@@ -0,0 +1,199 @@
+// Package history holds the append-only change history shared by the
+// services: the history table, how changes are diffed and recorded, how a
+// page of an entity's history is read and who a change is attributed to.
+package history
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"time"
+
+	"grud/common/metrics"
+
+	"github.com/uptrace/bun"
+)
+
+// Action is the kind of change an Entry records
+type Action string
+
+const (
+	ActionCreate        Action = "create"
+	ActionUpdate        Action = "update"
+	ActionDelete        Action = "delete"
+	ActionRestore       Action = "restore"
+	ActionMemberAdded   Action = "member_added"
+	ActionMemberRemoved Action = "member_removed"
+)
+
+// Entry is one row of the append-only change history. Before and After hold
+// only the fields that changed, keyed by their JSON names.
+type Entry struct {
+	bun.BaseModel `bun:"table:history,alias:h"`
+
+	ID         int64                  `bun:"id,pk,autoincrement" json:"id"`
+	EntityType string                 `bun:"entity_type,notnull" json:"entityType"`
+	EntityID   int                    `bun:"entity_id,notnull" json:"entityId"`
+	Action     Action                 `bun:"action,notnull" json:"action"`
+	Actor      string                 `bun:"actor,notnull" json:"actor"`
+	Before     map[string]interface{} `bun:"before,type:jsonb,nullzero" json:"before,omitempty"`
+	After      map[string]interface{} `bun:"after,type:jsonb,nullzero" json:"after,omitempty"`
+	CreatedAt  time.Time              `bun:"created_at,notnull,default:current_timestamp" json:"createdAt"`
+}
+
+// Migration is the schema the history table needs beyond what bun creates
+// from Entry: the index pages are read by, and a trigger keeping the table
+// append-only by rejecting any attempt to rewrite it
+const Migration = `
+	CREATE INDEX IF NOT EXISTS idx_history_entity ON history (entity_type, entity_id, id);
+
+	CREATE OR REPLACE FUNCTION reject_history_change()
+	RETURNS TRIGGER AS $$
+	BEGIN
+		RAISE EXCEPTION 'history is append-only';
+	END;
+	$$ language 'plpgsql';
+
+	DROP TRIGGER IF EXISTS history_append_only ON history;
+	CREATE TRIGGER history_append_only
+		BEFORE UPDATE OR DELETE ON history
+		FOR EACH ROW
+		EXECUTE FUNCTION reject_history_change();
+`
+
+// Change describes a change to be recorded. Before and After are the entity
+// before and after the change; either may be nil.
+type Change struct {
+	EntityType string
+	EntityID   int
+	Action     Action
+	Before     interface{}
+	After      interface{}
+	// Ignore lists JSON fields left out of the diff, such as timestamps the database maintains
+	Ignore []string
+}
+
+// Record appends the change to the history table using db, which should be
+// the transaction that made the change. The actor is taken from ctx. An
+// update that changed none of the compared fields is not recorded.
+func Record(ctx context.Context, db bun.IDB, m *metrics.Metrics, c Change) error {
+	before, after, err := Diff(c.Before, c.After, c.Ignore...)
+	if err != nil {
+		return err
+	}
+	if c.Action == ActionUpdate && before == nil && after == nil {
+		return nil
+	}
+
+	entry := &Entry{
+		EntityType: c.EntityType,
+		EntityID:   c.EntityID,
+		Action:     c.Action,
+		Actor:      ActorFromContext(ctx),
+		Before:     before,
+		After:      after,
+	}
+
+	start := time.Now()
+	_, err = db.NewInsert().Model(entry).Exec(ctx)
+	m.Database.RecordQuery(ctx, "insert", "history", time.Since(start), err)
+
+	return err
+}
+
+// Recorder records and lists the history of one type of entity
+type Recorder struct {
+	EntityType string
+	// Ignore lists JSON fields left out of every diff, such as ones that
+	// change on every update
+	Ignore  []string
+	Metrics *metrics.Metrics
+}
+
+// Record appends a change of the entity with the given ID, see Record
+func (r Recorder) Record(ctx context.Context, db bun.IDB, action Action, id int, before, after interface{}) error {
+	return Record(ctx, db, r.Metrics, Change{
+		EntityType: r.EntityType,
+		EntityID:   id,
+		Action:     action,
+		Before:     before,
+		After:      after,
+		Ignore:     r.Ignore,
+	})
+}
+
+// List returns a page of the history of the entity with the given ID, see
+// List
+func (r Recorder) List(ctx context.Context, db bun.IDB, id, pageSize int, pageToken string) (*Page, error) {
+	return List(ctx, db, r.Metrics, r.EntityType, id, pageSize, pageToken)
+}
+
+// Lock loads the row of T with the given ID and locks it until tx ends, so
+// the history entry sees the state the change was applied to. It returns
+// sql.ErrNoRows if there is no such row.
+func Lock[T any](ctx context.Context, tx bun.Tx, m *metrics.Metrics, id int) (*T, error) {
+	start := time.Now()
+	model := new(T)
+	err := tx.NewSelect().Model(model).Where("id = ?", id).For("UPDATE").Scan(ctx)
+	table := tx.Dialect().Tables().Get(reflect.TypeOf(model)).Name
+	m.Database.RecordQuery(ctx, "select", table, time.Since(start), err)
+
+	if err != nil {
+		return nil, err
+	}
+	return model, nil
+}
+
+// Diff compares the JSON forms of before and after and returns the fields
+// whose values differ. A nil side yields a nil map; so does a side with no
+// differing fields.
+func Diff(before, after interface{}, ignore ...string) (map[string]interface{}, map[string]interface{}, error) {
+	b, err := toMap(before)
+	if err != nil {
+		return nil, nil, err
+	}
+	a, err := toMap(after)
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, field := range ignore {
+		delete(b, field)
+		delete(a, field)
+	}
+
+	changedBefore := make(map[string]interface{})
+	changedAfter := make(map[string]interface{})
+	for field, value := range b {
+		if other, ok := a[field]; !ok || !reflect.DeepEqual(value, other) {
+			changedBefore[field] = value
+		}
+	}
+	for field, value := range a {
+		if other, ok := b[field]; !ok || !reflect.DeepEqual(value, other) {
+			changedAfter[field] = value
+		}
+	}
+
+	if len(changedBefore) == 0 {
+		changedBefore = nil
+	}
+	if len(changedAfter) == 0 {
+		changedAfter = nil
+	}
+	return changedBefore, changedAfter, nil
+}
+
+func toMap(v interface{}) (map[string]interface{}, error) {
+	if v == nil || (reflect.ValueOf(v).Kind() == reflect.Ptr && reflect.ValueOf(v).IsNil()) {
+		return map[string]interface{}{}, nil
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	m := make(map[string]interface{})
+	if err := json.Unmarshal(data, &m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
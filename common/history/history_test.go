@@ -0,0 +1,110 @@
+package history_test
+
+import (
+	"context"
+	"testing"
+
+	"grud/common/history"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+type item struct {
+	Name    string `json:"name"`
+	Count   int    `json:"count"`
+	Touched string `json:"touched"`
+	Secret  string `json:"-"`
+}
+
+func TestDiff(t *testing.T) {
+	t.Run("Update", func(t *testing.T) {
+		before, after, err := history.Diff(
+			&item{Name: "a", Count: 1, Touched: "x", Secret: "s1"},
+			&item{Name: "b", Count: 1, Touched: "y", Secret: "s2"},
+			"touched",
+		)
+		require.NoError(t, err)
+		assert.Equal(t, map[string]interface{}{"name": "a"}, before)
+		assert.Equal(t, map[string]interface{}{"name": "b"}, after)
+	})
+
+	t.Run("Create", func(t *testing.T) {
+		before, after, err := history.Diff(nil, &item{Name: "a", Count: 2})
+		require.NoError(t, err)
+		assert.Nil(t, before)
+		assert.Equal(t, map[string]interface{}{"name": "a", "count": float64(2), "touched": ""}, after)
+	})
+
+	t.Run("DeleteWithTypedNil", func(t *testing.T) {
+		var none *item
+		before, after, err := history.Diff(&item{Name: "a"}, none)
+		require.NoError(t, err)
+		assert.Equal(t, "a", before["name"])
+		assert.Nil(t, after)
+	})
+
+	t.Run("NoChange", func(t *testing.T) {
+		before, after, err := history.Diff(&item{Name: "a"}, &item{Name: "a", Secret: "changed"})
+		require.NoError(t, err)
+		assert.Nil(t, before)
+		assert.Nil(t, after)
+	})
+}
+
+func TestActor(t *testing.T) {
+	assert.Equal(t, history.SystemActor, history.ActorFromContext(context.Background()))
+	assert.Equal(t, "jane@example.com", history.ActorFromContext(history.WithActor(context.Background(), "jane@example.com")))
+
+	interceptor := history.UnaryServerInterceptor()
+	var got string
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		got = history.ActorFromContext(ctx)
+		return nil, nil
+	}
+
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(history.ActorMetadataKey, "jane@example.com"))
+	_, err := interceptor(ctx, nil, &grpc.UnaryServerInfo{}, handler)
+	require.NoError(t, err)
+	assert.Equal(t, "jane@example.com", got)
+
+	_, err = interceptor(context.Background(), nil, &grpc.UnaryServerInfo{}, handler)
+	require.NoError(t, err)
+	assert.Equal(t, history.SystemActor, got)
+}
+
+func TestUnaryClientInterceptor(t *testing.T) {
+	interceptor := history.UnaryClientInterceptor()
+
+	var got []string
+	invoker := func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		md, _ := metadata.FromOutgoingContext(ctx)
+		got = md.Get(history.ActorMetadataKey)
+		return nil
+	}
+
+	ctx := history.WithActor(context.Background(), "jane@example.com")
+	require.NoError(t, interceptor(ctx, "/project.v1.ProjectService/UpdateProject", nil, nil, nil, invoker))
+	assert.Equal(t, []string{"jane@example.com"}, got)
+
+	require.NoError(t, interceptor(context.Background(), "/project.v1.ProjectService/UpdateProject", nil, nil, nil, invoker))
+	assert.Equal(t, []string{history.SystemActor}, got)
+}
+
+func TestStreamClientInterceptor(t *testing.T) {
+	interceptor := history.StreamClientInterceptor()
+
+	var got []string
+	streamer := func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		md, _ := metadata.FromOutgoingContext(ctx)
+		got = md.Get(history.ActorMetadataKey)
+		return nil, nil
+	}
+
+	ctx := history.WithActor(context.Background(), "jane@example.com")
+	_, err := interceptor(ctx, &grpc.StreamDesc{}, nil, "/attachment.v1.AttachmentService/UploadAttachment", streamer)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"jane@example.com"}, got)
+}
@@ -0,0 +1,80 @@
+package history
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"strconv"
+	"time"
+
+	"grud/common/metrics"
+
+	"github.com/uptrace/bun"
+)
+
+const (
+	DefaultPageSize = 50
+	MaxPageSize     = 500
+)
+
+var ErrInvalidPageToken = errors.New("invalid page token")
+
+// Page is a page of history entries, newest first
+type Page struct {
+	Entries       []Entry `json:"items"`
+	NextPageToken string  `json:"nextCursor,omitempty"`
+}
+
+// List returns a page of the history of one entity, newest first. pageSize
+// is clamped to MaxPageSize and defaults to DefaultPageSize.
+func List(ctx context.Context, db bun.IDB, m *metrics.Metrics, entityType string, entityID, pageSize int, pageToken string) (*Page, error) {
+	if pageSize <= 0 {
+		pageSize = DefaultPageSize
+	}
+	if pageSize > MaxPageSize {
+		pageSize = MaxPageSize
+	}
+
+	start := time.Now()
+	entries := make([]Entry, 0, pageSize+1)
+	query := db.NewSelect().
+		Model(&entries).
+		Where("entity_type = ?", entityType).
+		Where("entity_id = ?", entityID)
+	if pageToken != "" {
+		beforeID, err := decodePageToken(pageToken)
+		if err != nil {
+			return nil, err
+		}
+		query.Where("id < ?", beforeID)
+	}
+	err := query.OrderExpr("id DESC").Limit(pageSize + 1).Scan(ctx)
+	m.Database.RecordQuery(ctx, "select", "history", time.Since(start), err)
+
+	if err != nil {
+		return nil, err
+	}
+
+	page := &Page{Entries: entries}
+	if len(entries) > pageSize {
+		page.Entries = entries[:pageSize]
+		page.NextPageToken = encodePageToken(page.Entries[pageSize-1].ID)
+	}
+	return page, nil
+}
+
+func encodePageToken(id int64) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(strconv.FormatInt(id, 10)))
+}
+
+func decodePageToken(s string) (int64, error) {
+	data, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return 0, ErrInvalidPageToken
+	}
+	id, err := strconv.ParseInt(string(data), 10, 64)
+	if err != nil || id <= 0 {
+		return 0, ErrInvalidPageToken
+	}
+	return id, nil
+}
@@ -0,0 +1,40 @@
+package httputil
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ErrInvalidETag is returned for an If-Match header that holds no entity tag
+// produced by ETag
+var ErrInvalidETag = errors.New("malformed entity tag")
+
+// ETag returns the strong entity tag of a resource version
+func ETag(version int) string {
+	return fmt.Sprintf(`"%d"`, version)
+}
+
+// ParseIfMatch reads the version from an If-Match header holding a single
+// entity tag produced by ETag. "*" matches any version and yields 0. ok is
+// false when the header is absent.
+func ParseIfMatch(header string) (version int, ok bool, err error) {
+	header = strings.TrimSpace(header)
+	if header == "" {
+		return 0, false, nil
+	}
+	if header == "*" {
+		return 0, true, nil
+	}
+
+	unquoted, err := strconv.Unquote(header)
+	if err != nil || !strings.HasPrefix(header, `"`) {
+		return 0, true, ErrInvalidETag
+	}
+	version, err = strconv.Atoi(unquoted)
+	if err != nil || version <= 0 {
+		return 0, true, ErrInvalidETag
+	}
+	return version, true, nil
+}
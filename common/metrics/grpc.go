@@ -166,6 +166,30 @@ func (gm *GrpcMetrics) UnaryServerInterceptor() grpc.UnaryServerInterceptor {
 	}
 }
 
+// StreamServerInterceptor returns a gRPC interceptor that records golden signals
+// for streaming RPCs. Latency is the lifetime of the stream.
+func (gm *GrpcMetrics) StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if gm == nil {
+			return handler(srv, ss)
+		}
+
+		ctx := ss.Context()
+		service, method := splitMethodName(info.FullMethod)
+
+		// Track saturation
+		gm.StartRequest(ctx, service, method)
+		defer gm.EndRequest(ctx, service, method)
+
+		start := time.Now()
+		err := handler(srv, ss)
+
+		gm.RecordRequest(ctx, service, method, time.Since(start), status.Code(err))
+
+		return err
+	}
+}
+
 // splitMethodName splits "/package.Service/Method" into service and method
 func splitMethodName(fullMethod string) (string, string) {
 	fullMethod = strings.TrimPrefix(fullMethod, "/")
@@ -0,0 +1,115 @@
+package metrics
+
+import (
+	"context"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+type MailMetrics struct {
+	mailsQueued  metric.Int64Counter
+	mailsSent    metric.Int64Counter
+	mailRetries  metric.Int64Counter
+	mailsFailed  metric.Int64Counter
+	sendDuration metric.Float64Histogram
+}
+
+func NewMailMetrics(meter metric.Meter) (*MailMetrics, error) {
+	mm := &MailMetrics{}
+
+	var err error
+
+	// Mails queued for sending
+	mm.mailsQueued, err = meter.Int64Counter(
+		"mail.messages.queued",
+		metric.WithDescription("Total number of mails queued for sending"),
+		metric.WithUnit("{message}"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	// Mails handed to the mail server
+	mm.mailsSent, err = meter.Int64Counter(
+		"mail.messages.sent",
+		metric.WithDescription("Total number of mails sent"),
+		metric.WithUnit("{message}"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	// Failed attempts that will be retried
+	mm.mailRetries, err = meter.Int64Counter(
+		"mail.messages.retries",
+		metric.WithDescription("Total number of failed send attempts that will be retried"),
+		metric.WithUnit("{attempt}"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	// Mails given up on
+	mm.mailsFailed, err = meter.Int64Counter(
+		"mail.messages.failed",
+		metric.WithDescription("Total number of mails given up on after their last attempt"),
+		metric.WithUnit("{message}"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	// Send duration with custom buckets for SMTP round trips
+	// Buckets: 10ms, 50ms, 100ms, 250ms, 500ms, 1s, 2.5s, 5s, 10s, 30s
+	mm.sendDuration, err = meter.Float64Histogram(
+		"mail.send.duration",
+		metric.WithDescription("Time spent sending a mail"),
+		metric.WithUnit("s"),
+		metric.WithExplicitBucketBoundaries(
+			0.01, // 10ms
+			0.05, // 50ms
+			0.1,  // 100ms
+			0.25, // 250ms
+			0.5,  // 500ms
+			1.0,  // 1s
+			2.5,  // 2.5s
+			5.0,  // 5s
+			10.0, // 10s
+			30.0, // 30s
+		),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return mm, nil
+}
+
+func (mm *MailMetrics) RecordQueued(ctx context.Context, template string) {
+	if mm == nil || mm.mailsQueued == nil {
+		return
+	}
+	mm.mailsQueued.Add(ctx, 1, metric.WithAttributes(attribute.String("template", template)))
+}
+
+// RecordSend records one send attempt. final tells whether a failed attempt
+// was the last one the mail gets.
+func (mm *MailMetrics) RecordSend(ctx context.Context, template string, duration time.Duration, err error, final bool) {
+	if mm == nil || mm.sendDuration == nil {
+		return
+	}
+
+	attrs := metric.WithAttributes(attribute.String("template", template))
+	mm.sendDuration.Record(ctx, duration.Seconds(), attrs)
+
+	switch {
+	case err == nil:
+		mm.mailsSent.Add(ctx, 1, attrs)
+	case final:
+		mm.mailsFailed.Add(ctx, 1, attrs)
+	default:
+		mm.mailRetries.Add(ctx, 1, attrs)
+	}
+}
@@ -13,6 +13,7 @@ type Metrics struct {
 	Messaging *MessagingMetrics
 	Health    *HealthMetrics
 	Grpc      *GrpcMetrics
+	Mail      *MailMetrics
 	logger    *slog.Logger
 }
 
@@ -44,6 +45,11 @@ func New(ctx context.Context, serviceName string, logger *slog.Logger) (*Metrics
 		return nil, err
 	}
 
+	mail, err := NewMailMetrics(meter)
+	if err != nil {
+		return nil, err
+	}
+
 	logger.Info("metrics collectors initialized successfully")
 
 	return &Metrics{
@@ -52,6 +58,7 @@ func New(ctx context.Context, serviceName string, logger *slog.Logger) (*Metrics
 		Messaging: messaging,
 		Health:    health,
 		Grpc:      grpcMetrics,
+		Mail:      mail,
 		logger:    logger,
 	}, nil
 }
@@ -65,5 +72,6 @@ func NewMock() *Metrics {
 		Health:    &HealthMetrics{},
 		Runtime:   &RuntimeMetrics{},
 		Grpc:      &GrpcMetrics{},
+		Mail:      &MailMetrics{},
 	}
 }
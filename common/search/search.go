@@ -0,0 +1,64 @@
+// Package search holds the Postgres full-text search conventions shared by the
+// services: how the search_vector columns are configured, how user input
+// becomes a tsquery and how matches are ranked and highlighted.
+package search
+
+import (
+	"strings"
+	"unicode"
+)
+
+// Config is the text search configuration of every search_vector column and
+// must match the one used by the migrations. 'simple' lowercases words
+// without stemming, so prefixes of names and emails match the way people
+// type them.
+const Config = "simple"
+
+// Result limits of the search RPCs and endpoints
+const (
+	DefaultLimit = 20
+	MaxLimit     = 100
+)
+
+// Match is the condition selecting rows whose search_vector matches the
+// tsquery bound to its placeholder
+const Match = "search_vector @@ to_tsquery('" + Config + "', ?)"
+
+// Rank is the ranking expression for a search_vector column. Normalization 32
+// scales ranks into [0, 1), so results from different tables can be merged.
+const Rank = "ts_rank_cd(search_vector, to_tsquery('" + Config + "', ?), 32)"
+
+// Headline options: matched words are wrapped in <mark>. Short fields are
+// returned whole, long text is cut down to the fragments around the matches.
+const (
+	WholeField = "StartSel=<mark>, StopSel=</mark>, HighlightAll=true"
+	Fragments  = `StartSel=<mark>, StopSel=</mark>, MaxFragments=2, MinWords=5, MaxWords=20, FragmentDelimiter=" … "`
+)
+
+// Headline returns a SQL expression highlighting the matches of the tsquery
+// bound to its placeholder in the text column. The text is HTML-escaped first,
+// so the result is safe to render as HTML.
+func Headline(column, options string) string {
+	escaped := "replace(replace(replace(" + column + ", '&', '&amp;'), '<', '&lt;'), '>', '&gt;')"
+	return "ts_headline('" + Config + "', " + escaped + ", to_tsquery('" + Config + "', ?), '" + options + "')"
+}
+
+// PrefixQuery turns free text into a to_tsquery expression matching documents
+// that contain every word as a prefix: "ada love" becomes 'ada':* & 'love':*.
+// Characters other than letters, digits and the ones found in emails separate
+// words, as they do in the indexed text. It returns "" when nothing
+// searchable is left.
+func PrefixQuery(q string) string {
+	words := strings.FieldsFunc(strings.ToLower(q), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r) && !strings.ContainsRune("@._-", r)
+	})
+
+	var terms []string
+	for _, word := range words {
+		if strings.IndexFunc(word, func(r rune) bool { return unicode.IsLetter(r) || unicode.IsDigit(r) }) < 0 {
+			continue
+		}
+		terms = append(terms, "'"+word+"':*")
+	}
+	return strings.Join(terms, " & ")
+}
@@ -37,5 +37,32 @@ protoc \
     --go-grpc_opt=paths=source_relative \
     "${PROTO_DIR}/message/v1/message.proto"
 
+# Generate Go code for attachment service
+protoc \
+    --proto_path="${PROTO_DIR}" \
+    --go_out="${OUT_DIR}" \
+    --go_opt=paths=source_relative \
+    --go-grpc_out="${OUT_DIR}" \
+    --go-grpc_opt=paths=source_relative \
+    "${PROTO_DIR}/attachment/v1/attachment.proto"
+
+# Generate Go code for submission service
+protoc \
+    --proto_path="${PROTO_DIR}" \
+    --go_out="${OUT_DIR}" \
+    --go_opt=paths=source_relative \
+    --go-grpc_out="${OUT_DIR}" \
+    --go-grpc_opt=paths=source_relative \
+    "${PROTO_DIR}/submission/v1/submission.proto"
+
+# Generate Go code for team service
+protoc \
+    --proto_path="${PROTO_DIR}" \
+    --go_out="${OUT_DIR}" \
+    --go_opt=paths=source_relative \
+    --go-grpc_out="${OUT_DIR}" \
+    --go-grpc_opt=paths=source_relative \
+    "${PROTO_DIR}/team/v1/team.proto"
+
 echo -e "${GREEN}✓ Generated protobuf files${NC}"
 echo -e "${BLUE}Done!${NC}"
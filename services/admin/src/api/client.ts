@@ -1,5 +1,5 @@
 import axios from 'axios';
-import type { LoginRequest, AuthResponse, Student, Message, SendMessageRequest } from '../types';
+import type { LoginRequest, AuthResponse, Student, Message, SendMessageRequest, Page } from '../types';
 
 const API_BASE_URL = import.meta.env.VITE_API_URL || '';
 
@@ -31,10 +31,21 @@ export const authApi = {
 };
 
 export const studentApi = {
-  getAllStudents: async (): Promise<Student[]> => {
-    const response = await apiClient.get<Student[]>('/api/students');
+  listStudents: async (params: { limit?: number; cursor?: string } = {}): Promise<Page<Student>> => {
+    const response = await apiClient.get<Page<Student>>('/api/students', { params });
     return response.data;
   },
+
+  getAllStudents: async (): Promise<Student[]> => {
+    const students: Student[] = [];
+    let cursor: string | undefined;
+    do {
+      const page = await studentApi.listStudents({ limit: 200, cursor });
+      students.push(...page.items);
+      cursor = page.nextCursor;
+    } while (cursor);
+    return students;
+  },
 };
 
 export const messageApi = {
@@ -44,8 +55,16 @@ export const messageApi = {
   },
 
   getMessagesByEmail: async (email: string): Promise<Message[]> => {
-    const response = await apiClient.get<Message[]>(`/api/messages?email=${encodeURIComponent(email)}`);
-    return response.data;
+    const messages: Message[] = [];
+    let cursor: string | undefined;
+    do {
+      const params = new URLSearchParams({ email, limit: '200' });
+      if (cursor) params.set('cursor', cursor);
+      const response = await apiClient.get<Page<Message>>(`/api/messages?${params}`);
+      messages.push(...response.data.items);
+      cursor = response.data.nextCursor;
+    } while (cursor);
+    return messages;
   },
 };
 
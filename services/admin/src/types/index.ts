@@ -5,6 +5,13 @@ export interface Student {
   email: string;
   major: string;
   year: number;
+  version: number;
+}
+
+export interface Page<T> {
+  items: T[];
+  nextCursor?: string;
+  total?: number;
 }
 
 export interface LoginRequest {
@@ -17,10 +17,12 @@ func main() {
 	// Initialize application with gRPC on port 50052
 	application := app.New()
 
-	// Start dependency health checks in background
-	healthCtx, healthCancel := context.WithCancel(context.Background())
-	defer healthCancel()
-	go application.StartHealthChecks(healthCtx)
+	// Start dependency health checks, the soft delete purge job and the due date reminders in background
+	jobsCtx, jobsCancel := context.WithCancel(context.Background())
+	defer jobsCancel()
+	go application.StartHealthChecks(jobsCtx)
+	go application.StartPurgeJob(jobsCtx)
+	go application.StartReminderJob(jobsCtx)
 
 	go func() {
 		if err := application.Run(); err != nil {
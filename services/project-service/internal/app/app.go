@@ -2,25 +2,36 @@ package app
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	systemLog "log"
 	"log/slog"
 	"net"
+	"net/http"
 	"time"
 
+	"project-service/internal/attachment"
 	"project-service/internal/config"
 	"project-service/internal/db"
 	"project-service/internal/message"
 	"project-service/internal/messaging"
 	localmetrics "project-service/internal/metrics"
 	"project-service/internal/project"
+	"project-service/internal/reminder"
+	"project-service/internal/storage"
+	"project-service/internal/submission"
+	"project-service/internal/team"
 
+	"grud/common/history"
 	"grud/common/logger"
 	"grud/common/metrics"
 	"grud/common/telemetry"
 
+	attachmentpb "grud/api/gen/attachment/v1"
 	messagepb "grud/api/gen/message/v1"
 	projectpb "grud/api/gen/project/v1"
+	submissionpb "grud/api/gen/submission/v1"
+	teampb "grud/api/gen/team/v1"
 
 	"github.com/uptrace/bun"
 	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
@@ -33,7 +44,15 @@ import (
 type App struct {
 	config         *config.Config
 	grpcServer     *grpc.Server
+	projectEvents  *project.Broadcaster
+	projectService project.Service
+	attachments    attachment.Service
+	submissions    submission.Service
+	teams          team.Service
 	natsConsumer   *messaging.Consumer
+	natsProducer   *messaging.Producer
+	notifier       *project.Notifier
+	reminders      *reminder.Scheduler
 	database       *bun.DB
 	logger         *slog.Logger
 	telemetry      *telemetry.Telemetry
@@ -83,7 +102,7 @@ func New() *App {
 
 	database := db.New(cfg.Database)
 	app.database = database
-	if err := db.RunMigrations(ctx, database, (*project.Project)(nil), (*message.Message)(nil)); err != nil {
+	if err := db.RunMigrations(ctx, database, (*project.Project)(nil), (*project.ProjectMember)(nil), (*project.Tag)(nil), (*project.ProjectTag)(nil), (*message.Message)(nil), (*history.Entry)(nil), (*reminder.SentReminder)(nil), (*attachment.Attachment)(nil), (*submission.Submission)(nil), (*submission.Grade)(nil), (*message.Conversation)(nil), (*message.Participant)(nil), (*message.Read)(nil), (*message.Edit)(nil), (*team.Team)(nil)); err != nil {
 		systemLog.Fatal("failed to run migrations:", err)
 	}
 
@@ -103,28 +122,73 @@ func New() *App {
 	}
 
 	projectRepo := project.NewRepository(database, app.metrics)
-	projectService := project.NewService(projectRepo)
+	app.projectEvents = project.NewBroadcaster(cfg.Watch.BufferSize)
+	projectService := project.NewService(projectRepo, app.projectEvents)
+	app.projectService = projectService
+
+	// Every event subject is published over this one connection
+	natsProducer, err := messaging.NewProducer(cfg.NATS.URL, cfg.NATS.ReminderSubject, log)
+	if err != nil {
+		systemLog.Fatal("failed to create NATS producer:", err)
+	}
+	app.natsProducer = natsProducer
+
+	app.notifier = project.NewNotifier(app.projectEvents, projectRepo, natsProducer.WithSubject(cfg.NATS.ProjectSubject), log)
 
 	messageRepo := message.NewRepository(database, app.metrics)
-	messageService := message.NewService(messageRepo)
-	natsConsumer, err := messaging.NewConsumer(cfg.NATS.URL, cfg.NATS.Subject, messageRepo, log, app.serviceMetrics)
+	editWindow := time.Duration(cfg.Messages.EditWindowMinutes) * time.Minute
+	messageService := message.NewService(messageRepo, natsProducer.WithSubject(cfg.NATS.ReadSubject), editWindow)
+
+	subjects := messaging.Subjects{
+		Messages: cfg.NATS.Subject,
+		Edited:   cfg.NATS.EditedSubject,
+		Deleted:  cfg.NATS.DeletedSubject,
+	}
+	natsConsumer, err := messaging.NewConsumer(cfg.NATS.URL, subjects, messageRepo, messageService, natsProducer.WithSubject(cfg.NATS.CreatedSubject), log, app.serviceMetrics)
 	if err != nil {
 		systemLog.Fatal("failed to create NATS consumer:", err)
 	}
-	log.Info("NATS consumer initialized", "url", cfg.NATS.URL, "subject", cfg.NATS.Subject)
+	log.Info("NATS consumer initialized", "url", cfg.NATS.URL, "subject", cfg.NATS.Subject,
+		"edited_subject", subjects.Edited, "deleted_subject", subjects.Deleted)
 
 	app.natsConsumer = natsConsumer
 
+	offsetSpecs := cfg.Reminders.Offsets
+	if len(offsetSpecs) == 0 {
+		offsetSpecs = reminder.DefaultOffsets
+	}
+	offsets, err := reminder.ParseOffsets(offsetSpecs)
+	if err != nil {
+		systemLog.Fatal("invalid reminder config:", err)
+	}
+	reminderRepo := reminder.NewRepository(database, app.metrics)
+	app.reminders = reminder.NewScheduler(reminderRepo, natsProducer, offsets, log, app.serviceMetrics)
+
+	blobStore, err := newBlobStore(cfg.Attachments)
+	if err != nil {
+		systemLog.Fatal("failed to create attachment store:", err)
+	}
+	attachmentRepo := attachment.NewRepository(database, app.metrics)
+	app.attachments = attachment.NewService(attachmentRepo, blobStore, cfg.Attachments.MaxSizeBytes, log)
+	log.Info("attachment store initialized", "backend", cfg.Attachments.Backend)
+
+	app.submissions = submission.NewService(submission.NewRepository(database, app.metrics), natsProducer.WithSubject(cfg.NATS.GradedSubject), log)
+	app.teams = team.NewService(team.NewRepository(database, app.metrics))
+
 	// gRPC Server with OTel instrumentation and golden signals
 	var grpcOpts []grpc.ServerOption
 
 	grpcOpts = append(grpcOpts,
 		grpc.StatsHandler(otelgrpc.NewServerHandler()),
+		// Attribute changes to the caller named in the request metadata
+		grpc.ChainUnaryInterceptor(history.UnaryServerInterceptor()),
+		grpc.ChainStreamInterceptor(history.StreamServerInterceptor()),
 	)
 	// Add golden signals interceptor
 	if app.metrics.Grpc != nil {
 		grpcOpts = append(grpcOpts,
 			grpc.ChainUnaryInterceptor(app.metrics.Grpc.UnaryServerInterceptor()),
+			grpc.ChainStreamInterceptor(app.metrics.Grpc.StreamServerInterceptor()),
 		)
 	}
 
@@ -135,12 +199,24 @@ func New() *App {
 	messageGrpcHandler := message.NewGrpcServer(messageService, log)
 	messagepb.RegisterMessageServiceServer(app.grpcServer, messageGrpcHandler)
 
+	attachmentGrpcHandler := attachment.NewGrpcServer(app.attachments, log, app.serviceMetrics)
+	attachmentpb.RegisterAttachmentServiceServer(app.grpcServer, attachmentGrpcHandler)
+
+	submissionGrpcHandler := submission.NewGrpcServer(app.submissions, log, app.serviceMetrics)
+	submissionpb.RegisterSubmissionServiceServer(app.grpcServer, submissionGrpcHandler)
+
+	teamGrpcHandler := team.NewGrpcServer(app.teams, log)
+	teampb.RegisterTeamServiceServer(app.grpcServer, teamGrpcHandler)
+
 	// Register gRPC health check
 	healthServer := health.NewServer()
 	grpc_health_v1.RegisterHealthServer(app.grpcServer, healthServer)
 	healthServer.SetServingStatus("", grpc_health_v1.HealthCheckResponse_SERVING)
 	healthServer.SetServingStatus("project.v1.ProjectService", grpc_health_v1.HealthCheckResponse_SERVING)
 	healthServer.SetServingStatus("message.v1.MessageService", grpc_health_v1.HealthCheckResponse_SERVING)
+	healthServer.SetServingStatus("attachment.v1.AttachmentService", grpc_health_v1.HealthCheckResponse_SERVING)
+	healthServer.SetServingStatus("submission.v1.SubmissionService", grpc_health_v1.HealthCheckResponse_SERVING)
+	healthServer.SetServingStatus("team.v1.TeamService", grpc_health_v1.HealthCheckResponse_SERVING)
 
 	log.Info("application initialized successfully")
 
@@ -157,6 +233,13 @@ func (a *App) Run() error {
 		}
 	}()
 
+	// Publish project changes until Shutdown closes the broadcaster
+	go func() {
+		if err := a.notifier.Run(context.Background()); err != nil && !errors.Is(err, project.ErrBroadcasterClosed) {
+			a.logger.Error("project notifier error", "error", err)
+		}
+	}()
+
 	// Start gRPC server
 	lis, err := net.Listen("tcp", fmt.Sprintf(":%s", a.config.Grpc.Port))
 	if err != nil {
@@ -170,13 +253,29 @@ func (a *App) Run() error {
 func (a *App) Shutdown(ctx context.Context) error {
 	a.logger.Info("shutting down servers")
 
-	// Shutdown gRPC server
-	a.grpcServer.GracefulStop()
+	// End WatchProjects streams first, GracefulStop waits for every open stream
+	a.projectEvents.Close()
 
-	// Close NATS consumer
+	// Shutdown gRPC server, forcing it if streams are still draining when ctx expires
+	stopped := make(chan struct{})
+	go func() {
+		a.grpcServer.GracefulStop()
+		close(stopped)
+	}()
+	select {
+	case <-stopped:
+	case <-ctx.Done():
+		a.logger.Warn("gRPC graceful stop timed out, forcing stop")
+		a.grpcServer.Stop()
+	}
+
+	// Close NATS consumer and producer
 	if err := a.natsConsumer.Close(); err != nil {
 		a.logger.Error("NATS consumer close error", "error", err)
 	}
+	if err := a.natsProducer.Close(); err != nil {
+		a.logger.Error("NATS producer close error", "error", err)
+	}
 
 	// Shutdown OTel meter provider
 	if a.telemetry != nil && a.telemetry.MeterProvider != nil {
@@ -213,6 +312,142 @@ func (a *App) StartHealthChecks(ctx context.Context) {
 	}
 }
 
+// StartPurgeJob periodically hard deletes projects that were soft deleted
+// longer ago than the configured retention
+func (a *App) StartPurgeJob(ctx context.Context) {
+	retentionDays := a.config.Retention.DeletedDays
+	if retentionDays == 0 {
+		retentionDays = 30
+	}
+
+	intervalMinutes := a.config.Retention.PurgeIntervalMinutes
+	if intervalMinutes == 0 {
+		intervalMinutes = 60
+	}
+
+	retention := time.Duration(retentionDays) * 24 * time.Hour
+	ticker := time.NewTicker(time.Duration(intervalMinutes) * time.Minute)
+	defer ticker.Stop()
+
+	a.logger.Info("starting purge job",
+		"retention_days", retentionDays,
+		"interval_minutes", intervalMinutes,
+	)
+
+	a.purgeDeleted(ctx, retention)
+
+	for {
+		select {
+		case <-ticker.C:
+			a.purgeDeleted(ctx, retention)
+		case <-ctx.Done():
+			a.logger.Info("stopping purge job")
+			return
+		}
+	}
+}
+
+func (a *App) purgeDeleted(ctx context.Context, retention time.Duration) {
+	purged, err := a.projectService.PurgeDeleted(ctx, retention)
+	if err != nil {
+		a.logger.ErrorContext(ctx, "failed to purge deleted projects", "error", err)
+		return
+	}
+	if purged > 0 {
+		a.logger.InfoContext(ctx, "purged deleted projects", "count", purged)
+	}
+
+	// Purging a project leaves its attachments behind; remove them and their contents
+	removed, err := a.attachments.PurgeOrphans(ctx)
+	if err != nil {
+		a.logger.ErrorContext(ctx, "failed to purge attachments of deleted projects", "error", err)
+		return
+	}
+	if removed > 0 {
+		a.logger.InfoContext(ctx, "purged attachments of deleted projects", "count", removed)
+	}
+
+	removed, err = a.submissions.PurgeOrphans(ctx)
+	if err != nil {
+		a.logger.ErrorContext(ctx, "failed to purge submissions of deleted projects", "error", err)
+		return
+	}
+	if removed > 0 {
+		a.logger.InfoContext(ctx, "purged submissions of deleted projects", "count", removed)
+	}
+
+	removed, err = a.teams.PurgeOrphans(ctx)
+	if err != nil {
+		a.logger.ErrorContext(ctx, "failed to purge teams of deleted projects", "error", err)
+		return
+	}
+	if removed > 0 {
+		a.logger.InfoContext(ctx, "purged teams of deleted projects", "count", removed)
+	}
+}
+
+// newBlobStore creates the configured attachment store, local files by default
+func newBlobStore(cfg config.AttachmentConfig) (storage.BlobStore, error) {
+	switch cfg.Backend {
+	case "", "local":
+		dir := cfg.Dir
+		if dir == "" {
+			dir = "data/attachments"
+		}
+		return storage.NewLocalStore(dir)
+	case "s3":
+		return storage.NewS3Store(storage.S3Config{
+			Endpoint:  cfg.S3.Endpoint,
+			Region:    cfg.S3.Region,
+			Bucket:    cfg.S3.Bucket,
+			AccessKey: cfg.S3.AccessKey,
+			SecretKey: cfg.S3.SecretKey,
+			PathStyle: cfg.S3.PathStyle,
+		}, &http.Client{Timeout: 5 * time.Minute})
+	default:
+		return nil, fmt.Errorf("unknown attachment backend %q", cfg.Backend)
+	}
+}
+
+// StartReminderJob periodically publishes the due date reminders that are due.
+// Every replica runs it; a claim keeps replicas from sending the same reminder
+// together, but a reminder whose claim fails to commit after it was published
+// is sent again on the next run, so delivery is at least once.
+func (a *App) StartReminderJob(ctx context.Context) {
+	intervalSeconds := a.config.Reminders.IntervalSeconds
+	if intervalSeconds == 0 {
+		intervalSeconds = 60
+	}
+
+	ticker := time.NewTicker(time.Duration(intervalSeconds) * time.Second)
+	defer ticker.Stop()
+
+	a.logger.Info("starting reminder job", "interval_seconds", intervalSeconds)
+
+	a.sendReminders(ctx)
+
+	for {
+		select {
+		case <-ticker.C:
+			a.sendReminders(ctx)
+		case <-ctx.Done():
+			a.logger.Info("stopping reminder job")
+			return
+		}
+	}
+}
+
+func (a *App) sendReminders(ctx context.Context) {
+	sent, err := a.reminders.SendDue(ctx, time.Now())
+	if err != nil {
+		a.logger.ErrorContext(ctx, "failed to send reminders", "error", err, "sent", sent)
+		return
+	}
+	if sent > 0 {
+		a.logger.InfoContext(ctx, "sent reminders", "count", sent)
+	}
+}
+
 func (a *App) checkDependencies(ctx context.Context) {
 	// Check PostgreSQL
 	if a.database != nil {
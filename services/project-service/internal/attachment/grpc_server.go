@@ -0,0 +1,193 @@
+package attachment
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+
+	"project-service/internal/metrics"
+	"project-service/internal/project"
+
+	pb "grud/api/gen/attachment/v1"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// ChunkSize is the size of the content chunks DownloadAttachment sends,
+// well below the default 4 MiB gRPC message limit
+const ChunkSize = 64 << 10
+
+type GrpcServer struct {
+	pb.UnimplementedAttachmentServiceServer
+	service Service
+	logger  *slog.Logger
+	metrics *metrics.Metrics
+}
+
+func NewGrpcServer(service Service, logger *slog.Logger, m *metrics.Metrics) *GrpcServer {
+	return &GrpcServer{
+		service: service,
+		logger:  logger,
+		metrics: m,
+	}
+}
+
+// UploadAttachment reads the metadata from the first message and streams the
+// chunks of the following ones into the blob store
+func (s *GrpcServer) UploadAttachment(stream pb.AttachmentService_UploadAttachmentServer) error {
+	ctx := stream.Context()
+
+	first, err := stream.Recv()
+	if err == io.EOF {
+		return status.Error(codes.InvalidArgument, "upload is missing its metadata")
+	}
+	if err != nil {
+		return err
+	}
+	meta := first.GetMetadata()
+	if meta == nil {
+		return status.Error(codes.InvalidArgument, "the first upload message must carry the metadata")
+	}
+
+	s.logger.InfoContext(ctx, "gRPC: uploading attachment",
+		"project_id", meta.ProjectId, "filename", meta.Filename, "size", meta.Size)
+
+	upload := Upload{
+		ProjectID: int(meta.ProjectId),
+		Filename:  meta.Filename,
+		Size:      meta.Size,
+		SHA256:    meta.Sha256,
+	}
+	attachment, err := s.service.Upload(ctx, upload, &chunkReader{stream: stream})
+	if err != nil {
+		s.logger.ErrorContext(ctx, "gRPC: failed to upload attachment", "error", err, "project_id", meta.ProjectId)
+		return toStatusError(ctx, err)
+	}
+
+	s.metrics.RecordAttachmentUploaded(ctx, attachment.Size)
+	s.logger.InfoContext(ctx, "gRPC: attachment uploaded", "id", attachment.ID, "content_type", attachment.ContentType)
+
+	return stream.SendAndClose(&pb.UploadAttachmentResponse{Attachment: toProtoAttachment(attachment)})
+}
+
+// DownloadAttachment sends the attachment, then its contents in chunks
+func (s *GrpcServer) DownloadAttachment(req *pb.DownloadAttachmentRequest, stream pb.AttachmentService_DownloadAttachmentServer) error {
+	ctx := stream.Context()
+	s.logger.InfoContext(ctx, "gRPC: downloading attachment", "id", req.Id)
+
+	attachment, contents, err := s.service.Open(ctx, int(req.Id))
+	if err != nil {
+		s.logger.ErrorContext(ctx, "gRPC: failed to open attachment", "error", err, "id", req.Id)
+		return toStatusError(ctx, err)
+	}
+	defer contents.Close()
+
+	err = stream.Send(&pb.DownloadAttachmentResponse{
+		Payload: &pb.DownloadAttachmentResponse_Attachment{Attachment: toProtoAttachment(attachment)},
+	})
+	if err != nil {
+		return err
+	}
+
+	// Send copies the chunk into the encoded message, so buf can be reused
+	buf := make([]byte, ChunkSize)
+	for {
+		n, err := contents.Read(buf)
+		if n > 0 {
+			sendErr := stream.Send(&pb.DownloadAttachmentResponse{
+				Payload: &pb.DownloadAttachmentResponse_Chunk{Chunk: buf[:n]},
+			})
+			if sendErr != nil {
+				return sendErr
+			}
+		}
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			s.logger.ErrorContext(ctx, "gRPC: failed to read attachment", "error", err, "id", req.Id)
+			return toStatusError(ctx, err)
+		}
+	}
+}
+
+func (s *GrpcServer) ListAttachments(ctx context.Context, req *pb.ListAttachmentsRequest) (*pb.ListAttachmentsResponse, error) {
+	s.logger.InfoContext(ctx, "gRPC: listing attachments", "project_id", req.ProjectId)
+
+	attachments, err := s.service.List(ctx, int(req.ProjectId))
+	if err != nil {
+		s.logger.ErrorContext(ctx, "gRPC: failed to list attachments", "error", err, "project_id", req.ProjectId)
+		return nil, toStatusError(ctx, err)
+	}
+
+	pbAttachments := make([]*pb.Attachment, len(attachments))
+	for i, attachment := range attachments {
+		pbAttachments[i] = toProtoAttachment(attachment)
+	}
+	return &pb.ListAttachmentsResponse{Attachments: pbAttachments}, nil
+}
+
+func (s *GrpcServer) DeleteAttachment(ctx context.Context, req *pb.DeleteAttachmentRequest) (*pb.DeleteAttachmentResponse, error) {
+	s.logger.InfoContext(ctx, "gRPC: deleting attachment", "id", req.Id)
+
+	if err := s.service.Delete(ctx, int(req.Id)); err != nil {
+		s.logger.ErrorContext(ctx, "gRPC: failed to delete attachment", "error", err, "id", req.Id)
+		return nil, toStatusError(ctx, err)
+	}
+	return &pb.DeleteAttachmentResponse{}, nil
+}
+
+// chunkReader reads the contents from the chunks of an upload stream
+type chunkReader struct {
+	stream  pb.AttachmentService_UploadAttachmentServer
+	pending []byte
+}
+
+func (r *chunkReader) Read(p []byte) (int, error) {
+	for len(r.pending) == 0 {
+		msg, err := r.stream.Recv()
+		if err != nil {
+			return 0, err
+		}
+		if _, ok := msg.Payload.(*pb.UploadAttachmentRequest_Chunk); !ok {
+			return 0, status.Error(codes.InvalidArgument, "only the first upload message may carry metadata")
+		}
+		r.pending = msg.GetChunk()
+	}
+	n := copy(p, r.pending)
+	r.pending = r.pending[n:]
+	return n, nil
+}
+
+func toProtoAttachment(a *Attachment) *pb.Attachment {
+	return &pb.Attachment{
+		Id:          int32(a.ID),
+		ProjectId:   int32(a.ProjectID),
+		Filename:    a.Filename,
+		ContentType: a.ContentType,
+		Size:        a.Size,
+		Sha256:      a.SHA256,
+		CreatedAt:   timestamppb.New(a.CreatedAt),
+		UploadedBy:  a.UploadedBy,
+	}
+}
+
+func toStatusError(ctx context.Context, err error) error {
+	if ctx.Err() != nil {
+		return status.FromContextError(ctx.Err()).Err()
+	}
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+	switch {
+	case errors.Is(err, ErrAttachmentNotFound), errors.Is(err, project.ErrProjectNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, ErrInvalidInput), errors.Is(err, ErrTooLarge), errors.Is(err, ErrChecksumMismatch):
+		return status.Error(codes.InvalidArgument, err.Error())
+	default:
+		return status.Error(codes.Internal, err.Error())
+	}
+}
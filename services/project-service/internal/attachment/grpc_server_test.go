@@ -0,0 +1,320 @@
+package attachment_test
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"io/fs"
+	"log/slog"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+
+	pb "grud/api/gen/attachment/v1"
+	"grud/common/history"
+	commonmetrics "grud/common/metrics"
+	"grud/testing/testdb"
+	"project-service/internal/attachment"
+	"project-service/internal/db"
+	projectmetrics "project-service/internal/metrics"
+	"project-service/internal/project"
+	"project-service/internal/storage"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+func TestAttachmentGrpcServer_Shared(t *testing.T) {
+	pgContainer := testdb.SetupSharedPostgres(t)
+	defer pgContainer.Cleanup(t)
+
+	err := db.RunMigrations(context.Background(), pgContainer.DB,
+		(*project.Project)(nil), (*attachment.Attachment)(nil))
+	require.NoError(t, err)
+
+	dir := t.TempDir()
+	store, err := storage.NewLocalStore(dir)
+	require.NoError(t, err)
+
+	const maxSize = 1 << 20
+	repo := attachment.NewRepository(pgContainer.DB, commonmetrics.NewMock())
+	logger := slog.New(slog.NewTextHandler(os.Stderr, nil))
+	service := attachment.NewService(repo, store, maxSize, logger)
+
+	lis := bufconn.Listen(1 << 20)
+	server := grpc.NewServer(grpc.ChainStreamInterceptor(history.StreamServerInterceptor()))
+	pb.RegisterAttachmentServiceServer(server, attachment.NewGrpcServer(service, logger, projectmetrics.NewMock()))
+	go server.Serve(lis)
+	defer server.Stop()
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+	defer conn.Close()
+	client := pb.NewAttachmentServiceClient(conn)
+
+	checksum := func(data []byte) string {
+		sum := sha256.Sum256(data)
+		return hex.EncodeToString(sum[:])
+	}
+
+	// upload sends meta followed by data in chunks of chunkSize bytes
+	upload := func(ctx context.Context, meta *pb.UploadMetadata, data []byte, chunkSize int) (*pb.Attachment, error) {
+		stream, err := client.UploadAttachment(ctx)
+		require.NoError(t, err)
+		err = stream.Send(&pb.UploadAttachmentRequest{Payload: &pb.UploadAttachmentRequest_Metadata{Metadata: meta}})
+		require.NoError(t, err)
+		for len(data) > 0 {
+			n := min(chunkSize, len(data))
+			err := stream.Send(&pb.UploadAttachmentRequest{Payload: &pb.UploadAttachmentRequest_Chunk{Chunk: data[:n]}})
+			if err != nil {
+				// The server failed early; CloseAndRecv returns its status
+				break
+			}
+			data = data[n:]
+		}
+		resp, err := stream.CloseAndRecv()
+		if err != nil {
+			return nil, err
+		}
+		return resp.Attachment, nil
+	}
+
+	download := func(ctx context.Context, id int32) (*pb.Attachment, []byte, error) {
+		stream, err := client.DownloadAttachment(ctx, &pb.DownloadAttachmentRequest{Id: id})
+		require.NoError(t, err)
+		first, err := stream.Recv()
+		if err != nil {
+			return nil, nil, err
+		}
+		var contents bytes.Buffer
+		for {
+			msg, err := stream.Recv()
+			if err == io.EOF {
+				return first.GetAttachment(), contents.Bytes(), nil
+			}
+			if err != nil {
+				return nil, nil, err
+			}
+			contents.Write(msg.GetChunk())
+		}
+	}
+
+	// blobCount counts the files in the store, ignoring directories
+	blobCount := func(t *testing.T) int {
+		count := 0
+		err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+			if err == nil && !d.IsDir() {
+				count++
+			}
+			return err
+		})
+		require.NoError(t, err)
+		return count
+	}
+
+	newProject := func(t *testing.T) *project.Project {
+		p := &project.Project{Name: "Attachments"}
+		_, err := pgContainer.DB.NewInsert().Model(p).Exec(context.Background())
+		require.NoError(t, err)
+		return p
+	}
+
+	pdf := append([]byte("%PDF-1.7\n"), bytes.Repeat([]byte("0123456789"), 20000)...)
+
+	t.Run("UploadDownload", func(t *testing.T) {
+		testdb.CleanupTables(t, pgContainer.DB, "projects", "attachments")
+		p := newProject(t)
+		ctx := metadata.AppendToOutgoingContext(context.Background(), history.ActorMetadataKey, "ada@example.com")
+
+		uploaded, err := upload(ctx, &pb.UploadMetadata{
+			ProjectId: int32(p.ID),
+			Filename:  "../reports/final.pdf",
+			Size:      int64(len(pdf)),
+			Sha256:    checksum(pdf),
+		}, pdf, 7000)
+		require.NoError(t, err)
+		assert.NotZero(t, uploaded.Id)
+		assert.Equal(t, int32(p.ID), uploaded.ProjectId)
+		assert.Equal(t, "final.pdf", uploaded.Filename)
+		assert.Equal(t, "application/pdf", uploaded.ContentType)
+		assert.Equal(t, int64(len(pdf)), uploaded.Size)
+		assert.Equal(t, checksum(pdf), uploaded.Sha256)
+		assert.Equal(t, "ada@example.com", uploaded.UploadedBy)
+		assert.NotNil(t, uploaded.CreatedAt)
+
+		got, contents, err := download(ctx, uploaded.Id)
+		require.NoError(t, err)
+		assert.Equal(t, uploaded.Filename, got.Filename)
+		assert.Equal(t, pdf, contents)
+
+		// A second, text upload is listed first; the client's name for it does not matter
+		notes := []byte("meeting notes")
+		_, err = upload(ctx, &pb.UploadMetadata{
+			ProjectId: int32(p.ID), Filename: "notes.txt", Size: int64(len(notes)), Sha256: checksum(notes),
+		}, notes, 1024)
+		require.NoError(t, err)
+
+		list, err := client.ListAttachments(ctx, &pb.ListAttachmentsRequest{ProjectId: int32(p.ID)})
+		require.NoError(t, err)
+		require.Len(t, list.Attachments, 2)
+		assert.Equal(t, "notes.txt", list.Attachments[0].Filename)
+		assert.Equal(t, "text/plain; charset=utf-8", list.Attachments[0].ContentType)
+		assert.Equal(t, "final.pdf", list.Attachments[1].Filename)
+		assert.Equal(t, 2, blobCount(t))
+	})
+
+	t.Run("Rejected", func(t *testing.T) {
+		testdb.CleanupTables(t, pgContainer.DB, "projects", "attachments")
+		p := newProject(t)
+		ctx := context.Background()
+		before := blobCount(t)
+
+		tests := map[string]struct {
+			meta *pb.UploadMetadata
+			data []byte
+			code codes.Code
+		}{
+			"ChecksumMismatch": {
+				meta: &pb.UploadMetadata{ProjectId: int32(p.ID), Filename: "a.pdf", Size: int64(len(pdf)), Sha256: checksum([]byte("other"))},
+				data: pdf, code: codes.InvalidArgument,
+			},
+			"TooLarge": {
+				meta: &pb.UploadMetadata{ProjectId: int32(p.ID), Filename: "big.bin", Size: maxSize + 1, Sha256: checksum(nil)},
+				data: nil, code: codes.InvalidArgument,
+			},
+			"Shorter": {
+				meta: &pb.UploadMetadata{ProjectId: int32(p.ID), Filename: "a.pdf", Size: int64(len(pdf)) + 1, Sha256: checksum(pdf)},
+				data: pdf, code: codes.InvalidArgument,
+			},
+			"Longer": {
+				meta: &pb.UploadMetadata{ProjectId: int32(p.ID), Filename: "a.pdf", Size: int64(len(pdf)) - 1, Sha256: checksum(pdf[:len(pdf)-1])},
+				data: pdf, code: codes.InvalidArgument,
+			},
+			"Empty": {
+				meta: &pb.UploadMetadata{ProjectId: int32(p.ID), Filename: "a.pdf", Size: 0, Sha256: checksum(nil)},
+				data: nil, code: codes.InvalidArgument,
+			},
+			"BadChecksum": {
+				meta: &pb.UploadMetadata{ProjectId: int32(p.ID), Filename: "a.pdf", Size: int64(len(pdf)), Sha256: "abc"},
+				data: pdf, code: codes.InvalidArgument,
+			},
+			"NoFilename": {
+				meta: &pb.UploadMetadata{ProjectId: int32(p.ID), Filename: "../", Size: int64(len(pdf)), Sha256: checksum(pdf)},
+				data: pdf, code: codes.InvalidArgument,
+			},
+			"UnknownProject": {
+				meta: &pb.UploadMetadata{ProjectId: int32(p.ID) + 1000, Filename: "a.pdf", Size: int64(len(pdf)), Sha256: checksum(pdf)},
+				data: pdf, code: codes.NotFound,
+			},
+		}
+		for name, tt := range tests {
+			t.Run(name, func(t *testing.T) {
+				_, err := upload(ctx, tt.meta, tt.data, 32<<10)
+				assert.Equal(t, tt.code, status.Code(err), err)
+			})
+		}
+
+		// Nothing was stored by any of them
+		list, err := client.ListAttachments(ctx, &pb.ListAttachmentsRequest{ProjectId: int32(p.ID)})
+		require.NoError(t, err)
+		assert.Empty(t, list.Attachments)
+		assert.Equal(t, before, blobCount(t))
+
+		// The metadata has to come first
+		stream, err := client.UploadAttachment(ctx)
+		require.NoError(t, err)
+		require.NoError(t, stream.Send(&pb.UploadAttachmentRequest{Payload: &pb.UploadAttachmentRequest_Chunk{Chunk: pdf}}))
+		_, err = stream.CloseAndRecv()
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+
+	t.Run("DeletedProject", func(t *testing.T) {
+		testdb.CleanupTables(t, pgContainer.DB, "projects", "attachments")
+		p := newProject(t)
+		ctx := context.Background()
+		uploaded, err := upload(ctx, &pb.UploadMetadata{
+			ProjectId: int32(p.ID), Filename: "a.pdf", Size: int64(len(pdf)), Sha256: checksum(pdf),
+		}, pdf, 32<<10)
+		require.NoError(t, err)
+		_, err = pgContainer.DB.NewDelete().Model(p).WherePK().Exec(ctx)
+		require.NoError(t, err)
+
+		_, _, err = download(ctx, uploaded.Id)
+		assert.Equal(t, codes.NotFound, status.Code(err))
+
+		_, err = upload(ctx, &pb.UploadMetadata{
+			ProjectId: int32(p.ID), Filename: "a.pdf", Size: int64(len(pdf)), Sha256: checksum(pdf),
+		}, pdf, 32<<10)
+		assert.Equal(t, codes.NotFound, status.Code(err))
+
+		_, err = client.ListAttachments(ctx, &pb.ListAttachmentsRequest{ProjectId: int32(p.ID)})
+		assert.Equal(t, codes.NotFound, status.Code(err))
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		testdb.CleanupTables(t, pgContainer.DB, "projects", "attachments")
+		p := newProject(t)
+		ctx := context.Background()
+		before := blobCount(t)
+
+		uploaded, err := upload(ctx, &pb.UploadMetadata{
+			ProjectId: int32(p.ID), Filename: "a.pdf", Size: int64(len(pdf)), Sha256: checksum(pdf),
+		}, pdf, 32<<10)
+		require.NoError(t, err)
+		assert.Equal(t, before+1, blobCount(t))
+
+		_, err = client.DeleteAttachment(ctx, &pb.DeleteAttachmentRequest{Id: uploaded.Id})
+		require.NoError(t, err)
+		assert.Equal(t, before, blobCount(t))
+
+		_, _, err = download(ctx, uploaded.Id)
+		assert.Equal(t, codes.NotFound, status.Code(err))
+
+		_, err = client.DeleteAttachment(ctx, &pb.DeleteAttachmentRequest{Id: uploaded.Id})
+		assert.Equal(t, codes.NotFound, status.Code(err))
+	})
+
+	t.Run("PurgeOrphans", func(t *testing.T) {
+		testdb.CleanupTables(t, pgContainer.DB, "projects", "attachments")
+		kept, purged := newProject(t), newProject(t)
+		ctx := context.Background()
+		before := blobCount(t)
+
+		for _, p := range []*project.Project{kept, purged} {
+			_, err := upload(ctx, &pb.UploadMetadata{
+				ProjectId: int32(p.ID), Filename: "a.pdf", Size: int64(len(pdf)), Sha256: checksum(pdf),
+			}, pdf, 32<<10)
+			require.NoError(t, err)
+		}
+
+		// Soft deleted projects keep their attachments until they are purged
+		_, err := pgContainer.DB.NewDelete().Model(purged).WherePK().Exec(ctx)
+		require.NoError(t, err)
+		removed, err := service.PurgeOrphans(ctx)
+		require.NoError(t, err)
+		assert.Zero(t, removed)
+
+		_, err = pgContainer.DB.NewDelete().Model(purged).WherePK().ForceDelete().Exec(ctx)
+		require.NoError(t, err)
+		removed, err = service.PurgeOrphans(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, 1, removed)
+		assert.Equal(t, before+1, blobCount(t))
+
+		list, err := client.ListAttachments(ctx, &pb.ListAttachmentsRequest{ProjectId: int32(kept.ID)})
+		require.NoError(t, err)
+		assert.Len(t, list.Attachments, 1)
+	})
+}
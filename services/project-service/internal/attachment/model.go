@@ -0,0 +1,85 @@
+package attachment
+
+import (
+	"mime"
+	"net/http"
+	"path"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/uptrace/bun"
+)
+
+// DefaultMaxSize is the upload limit when none is configured
+const DefaultMaxSize = 25 << 20
+
+// MaxFilenameLength is the longest accepted filename, in runes
+const MaxFilenameLength = 255
+
+// Attachment is the metadata of a file attached to a project. The contents
+// live in the blob store under StorageKey.
+type Attachment struct {
+	bun.BaseModel `bun:"table:attachments,alias:att"`
+
+	ID          int       `bun:"id,pk,autoincrement" json:"id"`
+	ProjectID   int       `bun:"project_id,notnull" json:"projectId"`
+	Filename    string    `bun:"filename,notnull" json:"filename"`
+	ContentType string    `bun:"content_type,notnull" json:"contentType"`
+	Size        int64     `bun:"size,notnull" json:"size"`
+	SHA256      string    `bun:"sha256,notnull" json:"sha256"`
+	StorageKey  string    `bun:"storage_key,notnull,unique" json:"-"`
+	UploadedBy  string    `bun:"uploaded_by,notnull" json:"uploadedBy"`
+	CreatedAt   time.Time `bun:"created_at,notnull,default:current_timestamp" json:"createdAt"`
+}
+
+// Upload describes a file about to be uploaded, as declared by the client
+type Upload struct {
+	ProjectID int
+	Filename  string
+	Size      int64
+	// SHA256 is the hex encoded checksum the contents must match
+	SHA256 string
+}
+
+// CleanFilename keeps the last path element of a client supplied filename
+// and drops control characters, so it is safe to store and to send back in a
+// Content-Disposition header. It returns "" when nothing usable is left.
+func CleanFilename(name string) string {
+	name = strings.ReplaceAll(name, `\`, "/")
+	name = path.Base(strings.TrimRight(name, "/"))
+	name = strings.Map(func(r rune) rune {
+		if unicode.IsControl(r) {
+			return -1
+		}
+		return r
+	}, name)
+	name = strings.TrimSpace(name)
+	if name == "." || name == "/" || name == ".." || utf8.RuneCountInString(name) > MaxFilenameLength {
+		return ""
+	}
+	return name
+}
+
+// genericTypes are sniffed for many different formats, so the filename
+// extension names the type better
+var genericTypes = map[string]bool{
+	"application/octet-stream":  true,
+	"application/zip":           true,
+	"text/plain; charset=utf-8": true,
+}
+
+// DetectContentType sniffs the type from the first bytes of the contents,
+// falling back to the filename extension when sniffing only finds a generic
+// type. The type declared by the client is never trusted.
+func DetectContentType(head []byte, filename string) string {
+	sniffed := http.DetectContentType(head)
+	if !genericTypes[sniffed] {
+		return sniffed
+	}
+	if byExtension := mime.TypeByExtension(path.Ext(filename)); byExtension != "" {
+		return byExtension
+	}
+	return sniffed
+}
@@ -0,0 +1,44 @@
+package attachment_test
+
+import (
+	"strings"
+	"testing"
+
+	"project-service/internal/attachment"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCleanFilename(t *testing.T) {
+	tests := map[string]string{
+		"report.pdf":              "report.pdf",
+		"  notes v2.txt ":         "notes v2.txt",
+		"../../etc/passwd":        "passwd",
+		`C:\Users\ada\thesis.tex`: "thesis.tex",
+		"dir/":                    "dir",
+		"evil\r\nname.txt":        "evilname.txt",
+		"":                        "",
+		"..":                      "",
+		"/":                       "",
+		strings.Repeat("a", 256):  "",
+	}
+	for in, want := range tests {
+		assert.Equal(t, want, attachment.CleanFilename(in), in)
+	}
+}
+
+func TestDetectContentType(t *testing.T) {
+	pdf := []byte("%PDF-1.7\n%\xe2\xe3\xcf\xd3\n")
+	png := []byte("\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR")
+	zip := []byte("PK\x03\x04\x14\x00\x00\x00")
+
+	// Sniffed types win over the extension
+	assert.Equal(t, "application/pdf", attachment.DetectContentType(pdf, "report.pdf"))
+	assert.Equal(t, "image/png", attachment.DetectContentType(png, "fake.pdf"))
+
+	// Generic types are refined by the extension
+	assert.Equal(t, "application/json", attachment.DetectContentType([]byte(`{"a": 1}`), "data.json"))
+	assert.Equal(t, "application/zip", attachment.DetectContentType(zip, "archive.zip"))
+	assert.Equal(t, "text/plain; charset=utf-8", attachment.DetectContentType([]byte("hello"), "README"))
+	assert.Equal(t, "application/octet-stream", attachment.DetectContentType([]byte{0, 1, 2}, "blob.unknownext"))
+}
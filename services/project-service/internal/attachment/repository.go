@@ -0,0 +1,114 @@
+package attachment
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"grud/common/metrics"
+	"project-service/internal/project"
+
+	"github.com/uptrace/bun"
+)
+
+type Repository interface {
+	Create(ctx context.Context, attachment *Attachment) error
+	GetByID(ctx context.Context, id int) (*Attachment, error)
+	// ListByProject returns a project's attachments, newest first
+	ListByProject(ctx context.Context, projectID int) ([]*Attachment, error)
+	Delete(ctx context.Context, id int) error
+	// ProjectExists reports whether the project exists and is not deleted
+	ProjectExists(ctx context.Context, projectID int) (bool, error)
+	// Orphans returns up to limit attachments whose project was purged
+	Orphans(ctx context.Context, limit int) ([]*Attachment, error)
+}
+
+type repository struct {
+	db      *bun.DB
+	metrics *metrics.Metrics
+}
+
+func NewRepository(db *bun.DB, m *metrics.Metrics) Repository {
+	return &repository{
+		db:      db,
+		metrics: m,
+	}
+}
+
+func (r *repository) Create(ctx context.Context, attachment *Attachment) error {
+	start := time.Now()
+	_, err := r.db.NewInsert().Model(attachment).Returning("*").Exec(ctx)
+	r.metrics.Database.RecordQuery(ctx, "insert", "attachments", time.Since(start), err)
+	return err
+}
+
+func (r *repository) GetByID(ctx context.Context, id int) (*Attachment, error) {
+	start := time.Now()
+	attachment := new(Attachment)
+	err := r.db.NewSelect().Model(attachment).Where("id = ?", id).Scan(ctx)
+	r.metrics.Database.RecordQuery(ctx, "select", "attachments", time.Since(start), err)
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrAttachmentNotFound
+		}
+		return nil, err
+	}
+	return attachment, nil
+}
+
+func (r *repository) ListByProject(ctx context.Context, projectID int) ([]*Attachment, error) {
+	start := time.Now()
+	attachments := []*Attachment{}
+	err := r.db.NewSelect().
+		Model(&attachments).
+		Where("project_id = ?", projectID).
+		Order("created_at DESC", "id DESC").
+		Scan(ctx)
+	r.metrics.Database.RecordQuery(ctx, "select", "attachments", time.Since(start), err)
+
+	return attachments, err
+}
+
+func (r *repository) Delete(ctx context.Context, id int) error {
+	start := time.Now()
+	result, err := r.db.NewDelete().Model((*Attachment)(nil)).Where("id = ?", id).Exec(ctx)
+	r.metrics.Database.RecordQuery(ctx, "delete", "attachments", time.Since(start), err)
+
+	if err != nil {
+		return err
+	}
+	if rows, err := result.RowsAffected(); err == nil && rows == 0 {
+		return ErrAttachmentNotFound
+	}
+	return nil
+}
+
+func (r *repository) ProjectExists(ctx context.Context, projectID int) (bool, error) {
+	start := time.Now()
+	exists, err := r.db.NewSelect().
+		Model((*project.Project)(nil)).
+		Where("id = ?", projectID).
+		Exists(ctx)
+	r.metrics.Database.RecordQuery(ctx, "select", "projects", time.Since(start), err)
+
+	return exists, err
+}
+
+func (r *repository) Orphans(ctx context.Context, limit int) ([]*Attachment, error) {
+	start := time.Now()
+	attachments := make([]*Attachment, 0, limit)
+	err := r.db.NewSelect().
+		Model(&attachments).
+		Where("NOT EXISTS (?)", r.db.NewSelect().
+			Model((*project.Project)(nil)).
+			ColumnExpr("1").
+			Where("p.id = att.project_id").
+			WhereAllWithDeleted()).
+		Order("id").
+		Limit(limit).
+		Scan(ctx)
+	r.metrics.Database.RecordQuery(ctx, "select", "attachments", time.Since(start), err)
+
+	return attachments, err
+}
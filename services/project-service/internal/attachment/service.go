@@ -0,0 +1,255 @@
+package attachment
+
+import (
+	"bufio"
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"log/slog"
+	"strings"
+
+	"project-service/internal/project"
+	"project-service/internal/storage"
+
+	"grud/common/history"
+)
+
+var (
+	ErrAttachmentNotFound = errors.New("attachment not found")
+	ErrInvalidInput       = errors.New("invalid input")
+	ErrTooLarge           = errors.New("attachment too large")
+	ErrChecksumMismatch   = errors.New("checksum mismatch")
+)
+
+// sniffLength is how much of the contents http.DetectContentType looks at
+const sniffLength = 512
+
+// purgeBatchSize is the number of orphaned attachments removed at a time
+const purgeBatchSize = 100
+
+type Service interface {
+	// Upload stores the contents read from r as a new attachment of the
+	// project. The contents must match the declared size and checksum,
+	// otherwise nothing is stored.
+	Upload(ctx context.Context, u Upload, r io.Reader) (*Attachment, error)
+	// Open returns the attachment with a reader of its contents, which the
+	// caller closes
+	Open(ctx context.Context, id int) (*Attachment, io.ReadCloser, error)
+	// List returns a project's attachments, newest first
+	List(ctx context.Context, projectID int) ([]*Attachment, error)
+	Delete(ctx context.Context, id int) error
+	// PurgeOrphans removes the attachments of purged projects along with
+	// their contents, and returns how many were removed
+	PurgeOrphans(ctx context.Context) (int, error)
+}
+
+type service struct {
+	repo    Repository
+	store   storage.BlobStore
+	maxSize int64
+	logger  *slog.Logger
+}
+
+// NewService creates the attachment service. Uploads larger than maxSize
+// bytes are rejected; zero means DefaultMaxSize.
+func NewService(repo Repository, store storage.BlobStore, maxSize int64, logger *slog.Logger) Service {
+	if maxSize <= 0 {
+		maxSize = DefaultMaxSize
+	}
+	return &service{
+		repo:    repo,
+		store:   store,
+		maxSize: maxSize,
+		logger:  logger,
+	}
+}
+
+func (s *service) Upload(ctx context.Context, u Upload, r io.Reader) (*Attachment, error) {
+	filename := CleanFilename(u.Filename)
+	checksum := strings.ToLower(u.SHA256)
+	if u.ProjectID <= 0 || filename == "" || u.Size <= 0 {
+		return nil, ErrInvalidInput
+	}
+	if decoded, err := hex.DecodeString(checksum); err != nil || len(decoded) != sha256.Size {
+		return nil, fmt.Errorf("%w: sha256 must be 64 hex characters", ErrInvalidInput)
+	}
+	if u.Size > s.maxSize {
+		return nil, fmt.Errorf("%w: %d bytes exceeds the limit of %d", ErrTooLarge, u.Size, s.maxSize)
+	}
+
+	exists, err := s.repo.ProjectExists(ctx, u.ProjectID)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, project.ErrProjectNotFound
+	}
+
+	key, err := newStorageKey(u.ProjectID)
+	if err != nil {
+		return nil, err
+	}
+
+	br := bufio.NewReaderSize(r, sniffLength)
+	head, err := br.Peek(sniffLength)
+	if err != nil && err != io.EOF && err != bufio.ErrBufferFull {
+		return nil, err
+	}
+	contentType := DetectContentType(head, filename)
+
+	body := &uploadReader{r: br, hash: sha256.New(), remaining: u.Size}
+	// Cleanup must happen even when the upload was abandoned
+	cleanupCtx := context.WithoutCancel(ctx)
+	if err := s.store.Put(ctx, key, body, u.Size); err != nil {
+		s.discard(cleanupCtx, key)
+		if body.short {
+			return nil, fmt.Errorf("%w: contents shorter than the declared %d bytes", ErrInvalidInput, u.Size)
+		}
+		return nil, err
+	}
+
+	// Anything left after the declared size means the size was wrong
+	var extra [1]byte
+	if _, err := io.ReadFull(br, extra[:]); err != io.EOF {
+		s.discard(cleanupCtx, key)
+		if err != nil {
+			return nil, err
+		}
+		return nil, fmt.Errorf("%w: contents longer than the declared %d bytes", ErrInvalidInput, u.Size)
+	}
+
+	if sum := hex.EncodeToString(body.hash.Sum(nil)); sum != checksum {
+		s.discard(cleanupCtx, key)
+		return nil, fmt.Errorf("%w: expected %s, got %s", ErrChecksumMismatch, checksum, sum)
+	}
+
+	attachment := &Attachment{
+		ProjectID:   u.ProjectID,
+		Filename:    filename,
+		ContentType: contentType,
+		Size:        u.Size,
+		SHA256:      checksum,
+		StorageKey:  key,
+		UploadedBy:  history.ActorFromContext(ctx),
+	}
+	if err := s.repo.Create(ctx, attachment); err != nil {
+		s.discard(cleanupCtx, key)
+		return nil, err
+	}
+	return attachment, nil
+}
+
+func (s *service) Open(ctx context.Context, id int) (*Attachment, io.ReadCloser, error) {
+	attachment, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, nil, err
+	}
+	// Attachments of deleted projects are kept until the project is purged,
+	// but are no longer served
+	exists, err := s.repo.ProjectExists(ctx, attachment.ProjectID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !exists {
+		return nil, nil, ErrAttachmentNotFound
+	}
+	contents, err := s.store.Get(ctx, attachment.StorageKey)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to open contents of attachment %d: %w", id, err)
+	}
+	return attachment, contents, nil
+}
+
+func (s *service) List(ctx context.Context, projectID int) ([]*Attachment, error) {
+	exists, err := s.repo.ProjectExists(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, project.ErrProjectNotFound
+	}
+	return s.repo.ListByProject(ctx, projectID)
+}
+
+// Delete removes the contents before the row, so a failed delete can be retried
+func (s *service) Delete(ctx context.Context, id int) error {
+	attachment, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if err := s.store.Delete(ctx, attachment.StorageKey); err != nil {
+		return err
+	}
+	return s.repo.Delete(ctx, id)
+}
+
+func (s *service) PurgeOrphans(ctx context.Context) (int, error) {
+	purged := 0
+	for {
+		orphans, err := s.repo.Orphans(ctx, purgeBatchSize)
+		if err != nil {
+			return purged, err
+		}
+		for _, orphan := range orphans {
+			if err := s.store.Delete(ctx, orphan.StorageKey); err != nil {
+				return purged, err
+			}
+			if err := s.repo.Delete(ctx, orphan.ID); err != nil && !errors.Is(err, ErrAttachmentNotFound) {
+				return purged, err
+			}
+			purged++
+		}
+		if len(orphans) < purgeBatchSize {
+			return purged, nil
+		}
+	}
+}
+
+// discard removes the contents of an upload that was not stored. A failure
+// only leaves an unreferenced blob behind, so it is logged rather than returned.
+func (s *service) discard(ctx context.Context, key string) {
+	if err := s.store.Delete(ctx, key); err != nil {
+		s.logger.ErrorContext(ctx, "failed to remove contents of rejected upload", "error", err, "key", key)
+	}
+}
+
+// newStorageKey returns a fresh blob key under the project's prefix. Keys are
+// random so they do not reveal anything and are never reused.
+func newStorageKey(projectID int) (string, error) {
+	var b [16]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("projects/%d/%s", projectID, hex.EncodeToString(b[:])), nil
+}
+
+// uploadReader passes on exactly remaining bytes of an upload while hashing
+// them. short records that the input ended early.
+type uploadReader struct {
+	r         io.Reader
+	hash      hash.Hash
+	remaining int64
+	short     bool
+}
+
+func (u *uploadReader) Read(p []byte) (int, error) {
+	if u.remaining <= 0 {
+		return 0, io.EOF
+	}
+	if int64(len(p)) > u.remaining {
+		p = p[:u.remaining]
+	}
+	n, err := u.r.Read(p)
+	u.hash.Write(p[:n])
+	u.remaining -= int64(n)
+	if err == io.EOF && u.remaining > 0 {
+		u.short = true
+		return n, io.ErrUnexpectedEOF
+	}
+	return n, err
+}
@@ -8,10 +8,15 @@ import (
 )
 
 type Config struct {
-	Env      string         `mapstructure:"env"`
-	Database DatabaseConfig `mapstructure:"database"`
-	Grpc     GrpcConfig     `mapstructure:"grpc"`
-	NATS     NATSConfig     `mapstructure:"nats"`
+	Env         string           `mapstructure:"env"`
+	Database    DatabaseConfig   `mapstructure:"database"`
+	Grpc        GrpcConfig       `mapstructure:"grpc"`
+	NATS        NATSConfig       `mapstructure:"nats"`
+	Watch       WatchConfig      `mapstructure:"watch"`
+	Retention   RetentionConfig  `mapstructure:"retention"`
+	Reminders   ReminderConfig   `mapstructure:"reminders"`
+	Attachments AttachmentConfig `mapstructure:"attachments"`
+	Messages    MessageConfig    `mapstructure:"messages"`
 }
 
 type DatabaseConfig struct {
@@ -34,6 +39,84 @@ type GrpcConfig struct {
 type NATSConfig struct {
 	URL     string `mapstructure:"url"`
 	Subject string `mapstructure:"subject"`
+	// ReminderSubject is where due date reminders are published
+	ReminderSubject string `mapstructure:"reminder_subject"`
+	// ReadSubject is where message.read events are published
+	ReadSubject string `mapstructure:"read_subject"`
+	// CreatedSubject is where message.created events are published once a
+	// message is saved
+	CreatedSubject string `mapstructure:"created_subject"`
+	// ProjectSubject is where project changes are published
+	ProjectSubject string `mapstructure:"project_subject"`
+	// EditedSubject and DeletedSubject carry the edits and deletes of sent
+	// messages
+	EditedSubject  string `mapstructure:"edited_subject"`
+	DeletedSubject string `mapstructure:"deleted_subject"`
+	// GradedSubject is where submission.graded events are published
+	GradedSubject string `mapstructure:"graded_subject"`
+}
+
+// applyDefaults fills in the subjects left empty, so every user of the
+// config sees the same ones
+func (c *NATSConfig) applyDefaults() {
+	for subject, fallback := range map[*string]string{
+		&c.ReminderSubject: "project.reminder",
+		&c.ReadSubject:     "message.read",
+		&c.CreatedSubject:  "message.created",
+		&c.ProjectSubject:  "project.changed",
+		&c.EditedSubject:   "message.edited",
+		&c.DeletedSubject:  "message.deleted",
+		&c.GradedSubject:   "submission.graded",
+	} {
+		if *subject == "" {
+			*subject = fallback
+		}
+	}
+}
+
+type WatchConfig struct {
+	// BufferSize is how many events a WatchProjects stream may lag behind before it is dropped
+	BufferSize int `mapstructure:"buffer_size"`
+}
+
+// RetentionConfig controls how long soft deleted projects are kept before
+// the purge job removes them for good
+type RetentionConfig struct {
+	DeletedDays          int `mapstructure:"deleted_days"`
+	PurgeIntervalMinutes int `mapstructure:"purge_interval_minutes"`
+}
+
+// ReminderConfig controls the due date reminders. Offsets are durations
+// before the due date such as "7d", "1d" or "1h".
+type ReminderConfig struct {
+	Offsets         []string `mapstructure:"offsets"`
+	IntervalSeconds int      `mapstructure:"interval_seconds"`
+}
+
+// AttachmentConfig selects where attachment contents are stored. Backend is
+// "local", keeping files under Dir, or "s3".
+type AttachmentConfig struct {
+	Backend      string   `mapstructure:"backend"`
+	Dir          string   `mapstructure:"dir"`
+	MaxSizeBytes int64    `mapstructure:"max_size_bytes"`
+	S3           S3Config `mapstructure:"s3"`
+}
+
+// S3Config locates the bucket of the s3 attachment backend. Endpoint may
+// point at any S3-compatible server; PathStyle is needed by most of them.
+type S3Config struct {
+	Endpoint  string `mapstructure:"endpoint"`
+	Region    string `mapstructure:"region"`
+	Bucket    string `mapstructure:"bucket"`
+	AccessKey string `mapstructure:"access_key"`
+	SecretKey string `mapstructure:"secret_key"`
+	PathStyle bool   `mapstructure:"path_style"`
+}
+
+// MessageConfig controls how long after sending a message its author may
+// still edit it
+type MessageConfig struct {
+	EditWindowMinutes int `mapstructure:"edit_window_minutes"`
 }
 
 func Load() (*Config, error) {
@@ -63,12 +146,15 @@ func Load() (*Config, error) {
 	// Other config comes from the config file (ConfigMap)
 	viper.BindEnv("database.user", "DB_USER")
 	viper.BindEnv("database.password", "DB_PASSWORD")
+	viper.BindEnv("attachments.s3.access_key", "S3_ACCESS_KEY")
+	viper.BindEnv("attachments.s3.secret_key", "S3_SECRET_KEY")
 
 	// Unmarshal into struct
 	var config Config
 	if err := viper.Unmarshal(&config); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal config: %w", err)
 	}
+	config.NATS.applyDefaults()
 
 	return &config, nil
 }
@@ -6,10 +6,13 @@ import (
 	"fmt"
 	"log"
 	"log/slog"
+	"reflect"
 	"time"
 
 	"project-service/internal/config"
 
+	"grud/common/history"
+
 	"github.com/uptrace/bun"
 	"github.com/uptrace/bun/dialect/pgdialect"
 	"github.com/uptrace/bun/driver/pgdriver"
@@ -83,19 +86,47 @@ func configurePool(db *bun.DB, cfg config.DatabaseConfig) {
 	)
 }
 
-func RunMigrations(ctx context.Context, db *bun.DB, models ...interface{}) error {
-	for _, model := range models {
-		_, err := db.NewCreateTable().
-			Model(model).
-			IfNotExists().
-			Exec(ctx)
-		if err != nil {
-			return fmt.Errorf("failed to create table for model: %w", err)
-		}
-	}
+// A migration is schema that goes beyond what bun creates from the models:
+// columns added after a table was first created, triggers and indexes. It runs
+// only when every table it touches was among the models passed to
+// RunMigrations, so callers migrating part of the schema are not tripped up by
+// features they do not use.
+type migration struct {
+	name   string
+	tables []string
+	sql    string
+}
 
-	// Create trigger function for updated_at if it doesn't exist
-	_, err := db.ExecContext(ctx, `
+var migrations = []migration{
+	// Lifecycle, soft delete and version columns were added after the projects table was first created
+	{"add project columns", []string{"projects"}, `
+		ALTER TABLE projects ADD COLUMN IF NOT EXISTS description VARCHAR NOT NULL DEFAULT '';
+		ALTER TABLE projects ADD COLUMN IF NOT EXISTS status VARCHAR NOT NULL DEFAULT 'draft';
+		ALTER TABLE projects ADD COLUMN IF NOT EXISTS start_date TIMESTAMPTZ;
+		ALTER TABLE projects ADD COLUMN IF NOT EXISTS due_date TIMESTAMPTZ;
+		ALTER TABLE projects ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMPTZ;
+		ALTER TABLE projects ADD COLUMN IF NOT EXISTS version INTEGER NOT NULL DEFAULT 1;
+	`},
+	// Team assignment came after the project_members and messages tables were first created
+	{"add team columns", []string{"project_members"}, `
+		ALTER TABLE project_members ADD COLUMN IF NOT EXISTS team_id BIGINT;
+		ALTER TABLE project_members ADD COLUMN IF NOT EXISTS team_lead BOOLEAN NOT NULL DEFAULT false;
+	`},
+	{"add team columns", []string{"messages"}, `
+		ALTER TABLE messages ADD COLUMN IF NOT EXISTS team_id BIGINT;
+	`},
+	// Conversations and threads came after the messages table was first created
+	{"add thread columns", []string{"messages"}, `
+		ALTER TABLE messages ADD COLUMN IF NOT EXISTS conversation_id BIGINT;
+		ALTER TABLE messages ADD COLUMN IF NOT EXISTS parent_id BIGINT;
+		ALTER TABLE messages ADD COLUMN IF NOT EXISTS thread_root_id BIGINT;
+	`},
+	// Editing and deleting sent messages came later still
+	{"add message edit columns", []string{"messages"}, `
+		ALTER TABLE messages ADD COLUMN IF NOT EXISTS edited_at TIMESTAMPTZ;
+		ALTER TABLE messages ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMPTZ;
+	`},
+	{"create trigger", []string{"projects"}, `
 		CREATE OR REPLACE FUNCTION update_updated_at_column()
 		RETURNS TRIGGER AS $$
 		BEGIN
@@ -103,23 +134,110 @@ func RunMigrations(ctx context.Context, db *bun.DB, models ...interface{}) error
 			RETURN NEW;
 		END;
 		$$ language 'plpgsql';
-	`)
-	if err != nil {
-		return fmt.Errorf("failed to create trigger function: %w", err)
-	}
 
-	// Create trigger for projects table
-	_, err = db.ExecContext(ctx, `
 		DROP TRIGGER IF EXISTS update_projects_updated_at ON projects;
 		CREATE TRIGGER update_projects_updated_at
 			BEFORE UPDATE ON projects
 			FOR EACH ROW
 			EXECUTE FUNCTION update_updated_at_column();
-	`)
-	if err != nil {
-		return fmt.Errorf("failed to create trigger: %w", err)
+	`},
+	// The history table is append-only; see grud/common/history
+	{"create history constraints", []string{"history"}, history.Migration},
+	// Keyset pagination indexes for each ListProjects ordering, and lookups
+	// by due date for the reminder scan
+	{"create project indexes", []string{"projects"}, `
+		CREATE INDEX IF NOT EXISTS idx_projects_due_date ON projects (due_date) WHERE due_date IS NOT NULL;
+		CREATE INDEX IF NOT EXISTS idx_projects_name_id ON projects (name, id);
+		CREATE INDEX IF NOT EXISTS idx_projects_created_at_id ON projects (created_at, id);
+		CREATE INDEX IF NOT EXISTS idx_projects_updated_at_id ON projects (updated_at, id);
+		CREATE INDEX IF NOT EXISTS idx_projects_status ON projects (status);
+		CREATE INDEX IF NOT EXISTS idx_projects_deleted_at ON projects (deleted_at) WHERE deleted_at IS NOT NULL;
+	`},
+	// A student's projects and a team's members (the primary key covers
+	// lookups by project), with at most one lead per team
+	{"create member indexes", []string{"project_members"}, `
+		CREATE INDEX IF NOT EXISTS idx_project_members_student_id ON project_members (student_id);
+		CREATE INDEX IF NOT EXISTS idx_project_members_team_id ON project_members (team_id) WHERE team_id IS NOT NULL;
+		CREATE UNIQUE INDEX IF NOT EXISTS idx_project_members_team_lead ON project_members (team_id) WHERE team_lead;
+	`},
+	{"create tag indexes", []string{"project_tags"}, `
+		CREATE INDEX IF NOT EXISTS idx_project_tags_tag_id ON project_tags (tag_id);
+	`},
+	{"create reminder indexes", []string{"sent_reminders"}, `
+		CREATE INDEX IF NOT EXISTS idx_sent_reminders_due_date ON sent_reminders (due_date);
+	`},
+	{"create attachment indexes", []string{"attachments"}, `
+		CREATE INDEX IF NOT EXISTS idx_attachments_project_id ON attachments (project_id, created_at);
+	`},
+	{"create submission indexes", []string{"submissions", "grades"}, `
+		CREATE INDEX IF NOT EXISTS idx_submissions_student_id ON submissions (student_id, submitted_at);
+		CREATE INDEX IF NOT EXISTS idx_grades_submission_id ON grades (submission_id);
+	`},
+	// A team's messages, a thread's replies and a conversation's messages, and
+	// keyset pagination for ListMessages, overall and per sender
+	{"create message indexes", []string{"messages"}, `
+		CREATE INDEX IF NOT EXISTS idx_messages_team_id ON messages (team_id, created_at) WHERE team_id IS NOT NULL;
+		CREATE INDEX IF NOT EXISTS idx_messages_thread_root_id ON messages (thread_root_id, created_at) WHERE thread_root_id IS NOT NULL;
+		CREATE INDEX IF NOT EXISTS idx_messages_conversation_id ON messages (conversation_id, created_at) WHERE conversation_id IS NOT NULL;
+		CREATE INDEX IF NOT EXISTS idx_messages_email_created_at ON messages (email, created_at);
+		CREATE INDEX IF NOT EXISTS idx_messages_created_at_id ON messages (created_at, id);
+	`},
+	// The conversations of a student or project
+	{"create conversation indexes", []string{"conversations", "conversation_participants"}, `
+		CREATE INDEX IF NOT EXISTS idx_conversation_participants_email ON conversation_participants (email);
+		CREATE INDEX IF NOT EXISTS idx_conversations_project_id ON conversations (project_id, last_message_at) WHERE project_id IS NOT NULL;
+	`},
+	{"create message edit indexes", []string{"message_edits"}, `
+		CREATE INDEX IF NOT EXISTS idx_message_edits_message_id ON message_edits (message_id, edited_at);
+	`},
+	// Full-text search vectors, generated by Postgres so they always follow the
+	// text they index; see grud/common/search for how they are queried
+	{"create search columns", []string{"projects"}, `
+		ALTER TABLE projects ADD COLUMN IF NOT EXISTS search_vector tsvector GENERATED ALWAYS AS (
+			setweight(to_tsvector('simple', name), 'A') ||
+			setweight(to_tsvector('simple', description), 'B')
+		) STORED;
+		CREATE INDEX IF NOT EXISTS idx_projects_search ON projects USING GIN (search_vector);
+	`},
+	{"create search columns", []string{"messages"}, `
+		ALTER TABLE messages ADD COLUMN IF NOT EXISTS search_vector tsvector GENERATED ALWAYS AS (
+			to_tsvector('simple', message)
+		) STORED;
+		CREATE INDEX IF NOT EXISTS idx_messages_search ON messages USING GIN (search_vector);
+	`},
+}
+
+func RunMigrations(ctx context.Context, db *bun.DB, models ...interface{}) error {
+	tables := make(map[string]bool, len(models))
+	for _, model := range models {
+		_, err := db.NewCreateTable().
+			Model(model).
+			IfNotExists().
+			Exec(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to create table for model: %w", err)
+		}
+		tables[db.Table(reflect.TypeOf(model)).Name] = true
+	}
+
+	for _, m := range migrations {
+		if !hasTables(tables, m.tables) {
+			continue
+		}
+		if _, err := db.ExecContext(ctx, m.sql); err != nil {
+			return fmt.Errorf("failed to %s: %w", m.name, err)
+		}
 	}
 
 	slog.Info("database migrations completed successfully")
 	return nil
 }
+
+func hasTables(migrated map[string]bool, tables []string) bool {
+	for _, table := range tables {
+		if !migrated[table] {
+			return false
+		}
+	}
+	return true
+}
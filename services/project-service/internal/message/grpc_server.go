@@ -2,10 +2,18 @@ package message
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"log/slog"
+	"time"
+
+	"project-service/internal/project"
 
 	pb "grud/api/gen/message/v1"
 
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
@@ -23,25 +31,315 @@ func NewGrpcServer(service Service, logger *slog.Logger) *GrpcServer {
 }
 
 func (s *GrpcServer) GetMessagesByEmail(ctx context.Context, req *pb.GetMessagesByEmailRequest) (*pb.GetMessagesByEmailResponse, error) {
-	s.logger.InfoContext(ctx, "gRPC: fetching messages by email", "email", req.Email)
+	s.logger.InfoContext(ctx, "gRPC: fetching messages by email", "email", req.Email, "team_id", req.TeamId)
 
-	messages, err := s.service.GetMessagesByEmail(ctx, req.Email)
+	messages, err := s.service.GetMessagesByEmail(ctx, req.Email, int(req.TeamId))
 	if err != nil {
-		s.logger.ErrorContext(ctx, "gRPC: failed to fetch messages by email", "error", err, "email", req.Email)
+		s.logger.ErrorContext(ctx, "gRPC: failed to fetch messages by email", "error", err, "email", req.Email, "team_id", req.TeamId)
+		if errors.Is(err, ErrInvalidInput) {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
 		return nil, err
 	}
 
-	pbMessages := make([]*pb.Message, len(messages))
-	for i, msg := range messages {
-		pbMessages[i] = &pb.Message{
-			Id:        int32(msg.ID),
-			Email:     msg.Email,
-			Message:   msg.Message,
-			CreatedAt: timestamppb.New(msg.CreatedAt),
+	return &pb.GetMessagesByEmailResponse{
+		Messages: toProtoMessages(messages),
+	}, nil
+}
+
+func (s *GrpcServer) ListMessages(ctx context.Context, req *pb.ListMessagesRequest) (*pb.ListMessagesResponse, error) {
+	s.logger.InfoContext(ctx, "gRPC: listing messages", "page_size", req.PageSize, "sender", req.Sender, "recipient", req.Recipient,
+		"project_id", req.ProjectId, "team_id", req.TeamId)
+
+	opts := ListOptions{
+		PageSize:  int(req.PageSize),
+		PageToken: req.PageToken,
+		ListFilter: ListFilter{
+			Sender:       req.Sender,
+			Recipient:    req.Recipient,
+			ProjectID:    int(req.ProjectId),
+			TeamID:       int(req.TeamId),
+			TextContains: req.TextContains,
+		},
+	}
+	if req.CreatedAfter != nil {
+		opts.CreatedAfter = req.CreatedAfter.AsTime()
+	}
+	if req.CreatedBefore != nil {
+		opts.CreatedBefore = req.CreatedBefore.AsTime()
+	}
+
+	result, err := s.service.ListMessages(ctx, opts)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "gRPC: failed to list messages", "error", err)
+		return nil, toStatusError(err)
+	}
+
+	return &pb.ListMessagesResponse{
+		Messages:      toProtoMessages(result.Messages),
+		NextPageToken: result.NextPageToken,
+	}, nil
+}
+
+// ExportMessages streams the matching messages in batches. It stops as soon as
+// the client cancels, since every cursor read uses the stream context.
+func (s *GrpcServer) ExportMessages(req *pb.ExportMessagesRequest, stream pb.MessageService_ExportMessagesServer) error {
+	ctx := stream.Context()
+	s.logger.InfoContext(ctx, "gRPC: exporting messages", "email", req.Email, "viewer_email", req.ViewerEmail, "viewer_id", req.ViewerId)
+
+	filter := ExportFilter{
+		Viewer: Viewer{Email: req.ViewerEmail, StudentID: int(req.ViewerId)},
+		Email:  req.Email,
+	}
+	if req.CreatedAfter != nil {
+		filter.CreatedAfter = req.CreatedAfter.AsTime()
+	}
+	if req.CreatedBefore != nil {
+		filter.CreatedBefore = req.CreatedBefore.AsTime()
+	}
+
+	filename := fmt.Sprintf("messages-%s.ndjson", time.Now().UTC().Format("20060102T150405Z"))
+	header := metadata.Pairs("content-disposition", fmt.Sprintf(`attachment; filename="%s"`, filename))
+	if err := stream.SendHeader(header); err != nil {
+		return err
+	}
+
+	count := 0
+	err := s.service.ExportMessages(ctx, filter, func(messages []*Message) error {
+		count += len(messages)
+		return stream.Send(&pb.ExportMessagesResponse{Messages: toProtoMessages(messages)})
+	})
+	if err != nil {
+		if ctx.Err() != nil {
+			s.logger.InfoContext(ctx, "gRPC: message export cancelled", "sent", count)
+			return status.FromContextError(ctx.Err()).Err()
 		}
+		s.logger.ErrorContext(ctx, "gRPC: failed to export messages", "error", err)
+		if errors.Is(err, ErrInvalidInput) {
+			return status.Error(codes.InvalidArgument, err.Error())
+		}
+		return status.Error(codes.Internal, err.Error())
 	}
 
-	return &pb.GetMessagesByEmailResponse{
-		Messages: pbMessages,
+	s.logger.InfoContext(ctx, "gRPC: message export finished", "sent", count)
+	return nil
+}
+
+// SearchMessages returns the messages best matching the query
+func (s *GrpcServer) SearchMessages(ctx context.Context, req *pb.SearchMessagesRequest) (*pb.SearchMessagesResponse, error) {
+	s.logger.InfoContext(ctx, "gRPC: searching messages", "query", req.Query, "page_size", req.PageSize, "viewer_email", req.ViewerEmail, "viewer_id", req.ViewerId)
+
+	viewer := Viewer{Email: req.ViewerEmail, StudentID: int(req.ViewerId)}
+	results, err := s.service.SearchMessages(ctx, req.Query, int(req.PageSize), viewer)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "gRPC: failed to search messages", "error", err, "query", req.Query)
+		if errors.Is(err, ErrInvalidInput) {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	pbResults := make([]*pb.MessageSearchResult, len(results))
+	for i := range results {
+		pbResults[i] = &pb.MessageSearchResult{
+			Message:   toProtoMessage(&results[i].Message),
+			Rank:      results[i].Rank,
+			Highlight: results[i].Highlight,
+		}
+	}
+
+	return &pb.SearchMessagesResponse{
+		Results: pbResults,
 	}, nil
 }
+
+func (s *GrpcServer) CreateConversation(ctx context.Context, req *pb.CreateConversationRequest) (*pb.CreateConversationResponse, error) {
+	s.logger.InfoContext(ctx, "gRPC: creating conversation", "created_by", req.CreatedBy, "project_id", req.ProjectId)
+
+	conversation, err := s.service.CreateConversation(ctx, NewConversation{
+		CreatedBy: req.CreatedBy,
+		ProjectID: int(req.ProjectId),
+		Recipient: req.Recipient,
+		Subject:   req.Subject,
+	})
+	if err != nil {
+		s.logger.ErrorContext(ctx, "gRPC: failed to create conversation", "error", err, "created_by", req.CreatedBy)
+		return nil, toStatusError(err)
+	}
+
+	s.logger.InfoContext(ctx, "gRPC: conversation created", "id", conversation.ID)
+	return &pb.CreateConversationResponse{Conversation: toProtoConversation(conversation)}, nil
+}
+
+func (s *GrpcServer) ListConversations(ctx context.Context, req *pb.ListConversationsRequest) (*pb.ListConversationsResponse, error) {
+	s.logger.InfoContext(ctx, "gRPC: listing conversations", "email", req.Email, "project_id", req.ProjectId)
+
+	conversations, err := s.service.ListConversations(ctx, req.Email, int(req.ProjectId))
+	if err != nil {
+		s.logger.ErrorContext(ctx, "gRPC: failed to list conversations", "error", err, "email", req.Email, "project_id", req.ProjectId)
+		return nil, toStatusError(err)
+	}
+
+	pbConversations := make([]*pb.Conversation, len(conversations))
+	for i, conversation := range conversations {
+		pbConversations[i] = toProtoConversation(conversation)
+	}
+	return &pb.ListConversationsResponse{Conversations: pbConversations}, nil
+}
+
+func (s *GrpcServer) GetThread(ctx context.Context, req *pb.GetThreadRequest) (*pb.GetThreadResponse, error) {
+	s.logger.InfoContext(ctx, "gRPC: fetching thread", "message_id", req.MessageId)
+
+	viewer := Viewer{Email: req.ViewerEmail, StudentID: int(req.ViewerId)}
+	thread, err := s.service.GetThread(ctx, int(req.MessageId), viewer)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "gRPC: failed to fetch thread", "error", err, "message_id", req.MessageId)
+		return nil, toStatusError(err)
+	}
+
+	resp := &pb.GetThreadResponse{
+		Root:    toProtoMessage(thread.Root),
+		Replies: toProtoMessages(thread.Replies),
+	}
+	if thread.Conversation != nil {
+		resp.Conversation = toProtoConversation(thread.Conversation)
+	}
+	return resp, nil
+}
+
+func (s *GrpcServer) CheckPost(ctx context.Context, req *pb.CheckPostRequest) (*pb.CheckPostResponse, error) {
+	err := s.service.CheckPost(ctx, &Message{
+		Email:          req.Email,
+		StudentID:      int(req.StudentId),
+		ConversationID: int(req.ConversationId),
+		ParentID:       int(req.ReplyTo),
+	})
+	if err != nil {
+		s.logger.WarnContext(ctx, "gRPC: post refused", "error", err, "email", req.Email,
+			"conversation_id", req.ConversationId, "reply_to", req.ReplyTo)
+		return nil, toStatusError(err)
+	}
+	return &pb.CheckPostResponse{}, nil
+}
+
+func (s *GrpcServer) GetEditHistory(ctx context.Context, req *pb.GetEditHistoryRequest) (*pb.GetEditHistoryResponse, error) {
+	s.logger.InfoContext(ctx, "gRPC: fetching edit history", "message_id", req.MessageId)
+
+	viewer := Viewer{Email: req.ViewerEmail, StudentID: int(req.ViewerId)}
+	msg, edits, err := s.service.GetEditHistory(ctx, int(req.MessageId), viewer)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "gRPC: failed to fetch edit history", "error", err, "message_id", req.MessageId)
+		return nil, toStatusError(err)
+	}
+
+	resp := &pb.GetEditHistoryResponse{
+		Message: toProtoMessage(msg),
+		Edits:   make([]*pb.MessageEdit, len(edits)),
+	}
+	for i, edit := range edits {
+		resp.Edits[i] = &pb.MessageEdit{Message: edit.Message, EditedAt: timestamppb.New(edit.EditedAt)}
+	}
+	return resp, nil
+}
+
+func (s *GrpcServer) MarkRead(ctx context.Context, req *pb.MarkReadRequest) (*pb.MarkReadResponse, error) {
+	s.logger.InfoContext(ctx, "gRPC: marking messages read", "email", req.Email, "message_id", req.MessageId, "conversation_id", req.ConversationId)
+
+	r := MarkRead{
+		Email:          req.Email,
+		MessageID:      int(req.MessageId),
+		ConversationID: int(req.ConversationId),
+	}
+	if req.UpTo != nil {
+		r.UpTo = req.UpTo.AsTime()
+	}
+	event, err := s.service.MarkRead(ctx, r)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "gRPC: failed to mark messages read", "error", err, "email", req.Email)
+		return nil, toStatusError(err)
+	}
+
+	s.logger.InfoContext(ctx, "gRPC: messages marked read", "email", req.Email, "marked", event.Marked)
+	return &pb.MarkReadResponse{
+		Marked: int32(event.Marked),
+		ReadAt: timestamppb.New(event.ReadAt),
+	}, nil
+}
+
+func (s *GrpcServer) GetUnreadCounts(ctx context.Context, req *pb.GetUnreadCountsRequest) (*pb.GetUnreadCountsResponse, error) {
+	s.logger.InfoContext(ctx, "gRPC: counting unread messages", "email", req.Email)
+
+	counts, err := s.service.GetUnreadCounts(ctx, req.Email)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "gRPC: failed to count unread messages", "error", err, "email", req.Email)
+		return nil, toStatusError(err)
+	}
+
+	resp := &pb.GetUnreadCountsResponse{Conversations: make([]*pb.UnreadCount, len(counts))}
+	for i, count := range counts {
+		resp.Total += int32(count.Unread)
+		resp.Conversations[i] = &pb.UnreadCount{
+			ConversationId: int32(count.ConversationID),
+			Unread:         int32(count.Unread),
+		}
+	}
+	return resp, nil
+}
+
+func toProtoMessages(messages []*Message) []*pb.Message {
+	pbMessages := make([]*pb.Message, len(messages))
+	for i, msg := range messages {
+		pbMessages[i] = toProtoMessage(msg)
+	}
+	return pbMessages
+}
+
+func toProtoMessage(msg *Message) *pb.Message {
+	pbMessage := &pb.Message{
+		Id:             int32(msg.ID),
+		Email:          msg.Email,
+		Message:        msg.Message,
+		TeamId:         int32(msg.TeamID),
+		ConversationId: int32(msg.ConversationID),
+		ParentId:       int32(msg.ParentID),
+		ThreadRootId:   int32(msg.ThreadRootID),
+		CreatedAt:      timestamppb.New(msg.CreatedAt),
+		Edited:         msg.Edited(),
+		Deleted:        msg.Deleted(),
+	}
+	if msg.EditedAt != nil {
+		pbMessage.EditedAt = timestamppb.New(*msg.EditedAt)
+	}
+	if msg.DeletedAt != nil {
+		pbMessage.DeletedAt = timestamppb.New(*msg.DeletedAt)
+	}
+	return pbMessage
+}
+
+func toProtoConversation(c *Conversation) *pb.Conversation {
+	return &pb.Conversation{
+		Id:            int32(c.ID),
+		ProjectId:     int32(c.ProjectID),
+		Subject:       c.Subject,
+		CreatedBy:     c.CreatedBy,
+		Participants:  c.Participants,
+		CreatedAt:     timestamppb.New(c.CreatedAt),
+		LastMessageAt: timestamppb.New(c.LastMessageAt),
+	}
+}
+
+// toStatusError maps domain errors to gRPC status errors
+func toStatusError(err error) error {
+	switch {
+	case errors.Is(err, ErrMessageNotFound), errors.Is(err, ErrConversationNotFound), errors.Is(err, project.ErrProjectNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, ErrInvalidInput), errors.Is(err, ErrInvalidPageToken):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, ErrNotParticipant), errors.Is(err, ErrNotMember), errors.Is(err, ErrNotReader), errors.Is(err, ErrNotAuthor):
+		return status.Error(codes.PermissionDenied, err.Error())
+	case errors.Is(err, ErrEditWindowClosed), errors.Is(err, ErrMessageDeleted):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	default:
+		return status.Error(codes.Internal, err.Error())
+	}
+}
@@ -2,28 +2,46 @@ package message_test
 
 import (
 	"context"
+	"fmt"
+	"io"
 	"log/slog"
+	"net"
 	"os"
 	"testing"
+	"time"
 
 	pb "grud/api/gen/message/v1"
+	"grud/common/history"
 	commonmetrics "grud/common/metrics"
 	"grud/testing/testdb"
+	"project-service/internal/db"
 	"project-service/internal/message"
+	"project-service/internal/project"
+	"project-service/internal/team"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 func TestMessageGrpcServer_Shared(t *testing.T) {
 	pgContainer := testdb.SetupSharedPostgres(t)
 	defer pgContainer.Cleanup(t)
 
-	pgContainer.RunMigrations(t, (*message.Message)(nil))
+	// The service migrations also add the generated search columns
+	err := db.RunMigrations(context.Background(), pgContainer.DB,
+		(*project.Project)(nil), (*project.ProjectMember)(nil), (*project.Tag)(nil), (*project.ProjectTag)(nil), (*message.Message)(nil), (*history.Entry)(nil),
+		(*message.Conversation)(nil), (*message.Participant)(nil), (*message.Read)(nil), (*message.Edit)(nil), (*team.Team)(nil))
+	require.NoError(t, err)
 
 	mockMetrics := commonmetrics.NewMock()
 	repo := message.NewRepository(pgContainer.DB, mockMetrics)
-	service := message.NewService(repo)
+	service := message.NewService(repo, nil, 0)
 	logger := slog.New(slog.NewTextHandler(os.Stderr, nil))
 	grpcServer := message.NewGrpcServer(service, logger)
 
@@ -69,6 +87,150 @@ func TestMessageGrpcServer_Shared(t *testing.T) {
 		assert.Len(t, resp.Messages, 0)
 	})
 
+	t.Run("ExportMessages", func(t *testing.T) {
+		testdb.CleanupTables(t, pgContainer.DB, "messages")
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+
+		// More than one cursor batch, so the export arrives in several responses
+		const total = 1203
+		messages := make([]*message.Message, total)
+		for i := range messages {
+			messages[i] = &message.Message{Email: "bulk@example.com", Message: fmt.Sprintf("message %d", i)}
+		}
+		_, err := pgContainer.DB.NewInsert().Model(&messages).Exec(ctx)
+		require.NoError(t, err)
+		_, err = pgContainer.DB.NewInsert().Model(&message.Message{Email: "other@example.com", Message: "skip"}).Exec(ctx)
+		require.NoError(t, err)
+
+		lis := bufconn.Listen(1 << 20)
+		server := grpc.NewServer()
+		pb.RegisterMessageServiceServer(server, grpcServer)
+		go server.Serve(lis)
+		defer server.Stop()
+
+		conn, err := grpc.NewClient("passthrough:///bufnet",
+			grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+			grpc.WithTransportCredentials(insecure.NewCredentials()),
+		)
+		require.NoError(t, err)
+		defer conn.Close()
+		client := pb.NewMessageServiceClient(conn)
+
+		stream, err := client.ExportMessages(ctx, &pb.ExportMessagesRequest{Email: "bulk@example.com", ViewerEmail: "bulk@example.com"})
+		require.NoError(t, err)
+
+		header, err := stream.Header()
+		require.NoError(t, err)
+		require.Len(t, header.Get("content-disposition"), 1)
+		assert.Contains(t, header.Get("content-disposition")[0], `attachment; filename="messages-`)
+
+		var received []*pb.Message
+		batches := 0
+		for {
+			resp, err := stream.Recv()
+			if err == io.EOF {
+				break
+			}
+			require.NoError(t, err)
+			batches++
+			received = append(received, resp.Messages...)
+		}
+		require.Len(t, received, total)
+		assert.Greater(t, batches, 1)
+		assert.Equal(t, "message 0", received[0].Message)
+		assert.Equal(t, "message 1202", received[total-1].Message)
+
+		// Others only get what they may read, which is none of bulk's messages
+		exportAll := func(req *pb.ExportMessagesRequest) ([]*pb.Message, error) {
+			stream, err := client.ExportMessages(ctx, req)
+			require.NoError(t, err)
+			var messages []*pb.Message
+			for {
+				resp, err := stream.Recv()
+				if err == io.EOF {
+					return messages, nil
+				}
+				if err != nil {
+					return nil, err
+				}
+				messages = append(messages, resp.Messages...)
+			}
+		}
+		other, err := exportAll(&pb.ExportMessagesRequest{Email: "bulk@example.com", ViewerEmail: "other@example.com", ViewerId: 2})
+		require.NoError(t, err)
+		assert.Empty(t, other)
+		other, err = exportAll(&pb.ExportMessagesRequest{ViewerEmail: "other@example.com", ViewerId: 2})
+		require.NoError(t, err)
+		require.Len(t, other, 1)
+		assert.Equal(t, "skip", other[0].Message)
+
+		_, err = exportAll(&pb.ExportMessagesRequest{Email: "bulk@example.com"})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+		// An empty time range is rejected
+		now := time.Now()
+		stream, err = client.ExportMessages(ctx, &pb.ExportMessagesRequest{
+			ViewerEmail:   "bulk@example.com",
+			CreatedAfter:  timestamppb.New(now),
+			CreatedBefore: timestamppb.New(now.Add(-time.Hour)),
+		})
+		require.NoError(t, err)
+		_, err = stream.Recv()
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+
+	t.Run("SearchMessages", func(t *testing.T) {
+		testdb.CleanupTables(t, pgContainer.DB, "messages", "conversations", "conversation_participants")
+
+		ctx := context.Background()
+		ab, err := grpcServer.CreateConversation(ctx, &pb.CreateConversationRequest{CreatedBy: "a@example.com", Recipient: "b@example.com"})
+		require.NoError(t, err)
+		cd, err := grpcServer.CreateConversation(ctx, &pb.CreateConversationRequest{CreatedBy: "c@example.com", Recipient: "d@example.com"})
+		require.NoError(t, err)
+		messages := []*message.Message{
+			{Email: "a@example.com", Message: "The deadline for the robotics project moved to Friday"},
+			{Email: "b@example.com", Message: "Deadline? Deadline! Who set that deadline?", ConversationID: int(ab.Conversation.Id)},
+			{Email: "c@example.com", Message: "Lunch at noon"},
+			{Email: "c@example.com", Message: "Secret deadline plans", ConversationID: int(cd.Conversation.Id)},
+		}
+		for _, m := range messages {
+			_, err := pgContainer.DB.NewInsert().Model(m).Exec(ctx)
+			require.NoError(t, err)
+		}
+
+		// a sees their own message and b's in their conversation, not c's to d
+		resp, err := grpcServer.SearchMessages(ctx, &pb.SearchMessagesRequest{Query: "deadl", ViewerEmail: "a@example.com"})
+		require.NoError(t, err)
+		require.Len(t, resp.Results, 2)
+
+		// More occurrences rank higher
+		assert.Equal(t, "b@example.com", resp.Results[0].Message.Email)
+		assert.Greater(t, resp.Results[0].Rank, resp.Results[1].Rank)
+		assert.Contains(t, resp.Results[1].Highlight, "<mark>deadline</mark>")
+		assert.NotZero(t, resp.Results[1].Message.CreatedAt)
+
+		resp, err = grpcServer.SearchMessages(ctx, &pb.SearchMessagesRequest{Query: "robotics deadline", ViewerEmail: "a@example.com"})
+		require.NoError(t, err)
+		require.Len(t, resp.Results, 1)
+		assert.Equal(t, "a@example.com", resp.Results[0].Message.Email)
+
+		// Messages of conversations the viewer is not part of are not found
+		resp, err = grpcServer.SearchMessages(ctx, &pb.SearchMessagesRequest{Query: "secret", ViewerEmail: "a@example.com"})
+		require.NoError(t, err)
+		assert.Empty(t, resp.Results)
+		resp, err = grpcServer.SearchMessages(ctx, &pb.SearchMessagesRequest{Query: "deadline", ViewerEmail: "d@example.com"})
+		require.NoError(t, err)
+		require.Len(t, resp.Results, 1)
+		assert.Equal(t, "Secret deadline plans", resp.Results[0].Message.Message)
+
+		_, err = grpcServer.SearchMessages(ctx, &pb.SearchMessagesRequest{Query: "", ViewerEmail: "a@example.com"})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+		_, err = grpcServer.SearchMessages(ctx, &pb.SearchMessagesRequest{Query: "deadline"})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+
 	t.Run("GetMessagesByEmail_EmptyEmail", func(t *testing.T) {
 		testdb.CleanupTables(t, pgContainer.DB, "messages")
 
@@ -79,6 +241,352 @@ func TestMessageGrpcServer_Shared(t *testing.T) {
 		resp, err := grpcServer.GetMessagesByEmail(ctx, req)
 
 		require.Error(t, err)
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
 		assert.Nil(t, resp)
 	})
+
+	t.Run("GetMessagesByEmail_TeamFilter", func(t *testing.T) {
+		testdb.CleanupTables(t, pgContainer.DB, "messages")
+
+		ctx := context.Background()
+		messages := []*message.Message{
+			{Email: "alice@example.com", Message: "To the red team", TeamID: 7},
+			{Email: "bob@example.com", Message: "Also to the red team", TeamID: 7},
+			{Email: "alice@example.com", Message: "To the blue team", TeamID: 8},
+			{Email: "alice@example.com", Message: "To nobody in particular"},
+		}
+		_, err := pgContainer.DB.NewInsert().Model(&messages).Exec(ctx)
+		require.NoError(t, err)
+
+		resp, err := grpcServer.GetMessagesByEmail(ctx, &pb.GetMessagesByEmailRequest{TeamId: 7})
+		require.NoError(t, err)
+		require.Len(t, resp.Messages, 2)
+		for _, msg := range resp.Messages {
+			assert.Equal(t, int32(7), msg.TeamId)
+		}
+
+		resp, err = grpcServer.GetMessagesByEmail(ctx, &pb.GetMessagesByEmailRequest{Email: "alice@example.com", TeamId: 7})
+		require.NoError(t, err)
+		require.Len(t, resp.Messages, 1)
+		assert.Equal(t, "To the red team", resp.Messages[0].Message)
+	})
+
+	t.Run("ListMessages", func(t *testing.T) {
+		testdb.CleanupTables(t, pgContainer.DB, "projects", "teams", "messages", "conversations", "conversation_participants")
+		ctx := context.Background()
+
+		p := &project.Project{Name: "Coursework", Status: project.StatusActive}
+		_, err := pgContainer.DB.NewInsert().Model(p).Exec(ctx)
+		require.NoError(t, err)
+		tm := &team.Team{ProjectID: p.ID, Name: "Red"}
+		_, err = pgContainer.DB.NewInsert().Model(tm).Exec(ctx)
+		require.NoError(t, err)
+		direct, err := grpcServer.CreateConversation(ctx, &pb.CreateConversationRequest{CreatedBy: "alice@example.com", Recipient: "bob@example.com"})
+		require.NoError(t, err)
+
+		base := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+		messages := []*message.Message{
+			{Email: "alice@example.com", Message: "Draft is up", TeamID: tm.ID, CreatedAt: base},
+			{Email: "bob@example.com", Message: "Reviewed the draft", TeamID: tm.ID, CreatedAt: base.Add(time.Hour)},
+			{Email: "alice@example.com", Message: "Lunch?", ConversationID: int(direct.Conversation.Id), CreatedAt: base.Add(2 * time.Hour)},
+			{Email: "bob@example.com", Message: "Sure", ConversationID: int(direct.Conversation.Id), CreatedAt: base.Add(3 * time.Hour)},
+			{Email: "alice@example.com", Message: "100% done", CreatedAt: base.Add(4 * time.Hour)},
+		}
+		_, err = pgContainer.DB.NewInsert().Model(&messages).Exec(ctx)
+		require.NoError(t, err)
+
+		texts := func(resp *pb.ListMessagesResponse) []string {
+			var texts []string
+			for _, msg := range resp.Messages {
+				texts = append(texts, msg.Message)
+			}
+			return texts
+		}
+
+		// Pages follow each other newest first without gaps
+		var got []string
+		req := &pb.ListMessagesRequest{PageSize: 2, Sender: "alice@example.com"}
+		for {
+			resp, err := grpcServer.ListMessages(ctx, req)
+			require.NoError(t, err)
+			got = append(got, texts(resp)...)
+			if resp.NextPageToken == "" {
+				break
+			}
+			req.PageToken = resp.NextPageToken
+		}
+		assert.Equal(t, []string{"100% done", "Lunch?", "Draft is up"}, got)
+
+		resp, err := grpcServer.ListMessages(ctx, &pb.ListMessagesRequest{Recipient: "bob@example.com"})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"Lunch?"}, texts(resp))
+
+		resp, err = grpcServer.ListMessages(ctx, &pb.ListMessagesRequest{ProjectId: int32(p.ID)})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"Reviewed the draft", "Draft is up"}, texts(resp))
+
+		resp, err = grpcServer.ListMessages(ctx, &pb.ListMessagesRequest{TextContains: "DRAFT"})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"Reviewed the draft", "Draft is up"}, texts(resp))
+
+		// Wildcards are matched literally
+		resp, err = grpcServer.ListMessages(ctx, &pb.ListMessagesRequest{TextContains: "%"})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"100% done"}, texts(resp))
+
+		resp, err = grpcServer.ListMessages(ctx, &pb.ListMessagesRequest{
+			CreatedAfter:  timestamppb.New(base.Add(time.Hour)),
+			CreatedBefore: timestamppb.New(base.Add(3 * time.Hour)),
+		})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"Lunch?", "Reviewed the draft"}, texts(resp))
+
+		// A token only continues the query that produced it
+		resp, err = grpcServer.ListMessages(ctx, &pb.ListMessagesRequest{PageSize: 1})
+		require.NoError(t, err)
+		require.NotEmpty(t, resp.NextPageToken)
+		_, err = grpcServer.ListMessages(ctx, &pb.ListMessagesRequest{PageSize: 1, PageToken: resp.NextPageToken, Sender: "bob@example.com"})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+		_, err = grpcServer.ListMessages(ctx, &pb.ListMessagesRequest{PageToken: "not-a-token"})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+		_, err = grpcServer.ListMessages(ctx, &pb.ListMessagesRequest{PageSize: -1})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+
+	t.Run("Conversations", func(t *testing.T) {
+		testdb.CleanupTables(t, pgContainer.DB, "projects", "project_members", "messages", "conversations", "conversation_participants")
+		ctx := context.Background()
+
+		p := &project.Project{Name: "Coursework", Status: project.StatusActive}
+		_, err := pgContainer.DB.NewInsert().Model(p).Exec(ctx)
+		require.NoError(t, err)
+		// alice is student 1, a member of the project
+		_, err = pgContainer.DB.NewInsert().Model(&project.ProjectMember{ProjectID: p.ID, StudentID: 1, Role: project.RoleContributor}).Exec(ctx)
+		require.NoError(t, err)
+
+		direct, err := grpcServer.CreateConversation(ctx, &pb.CreateConversationRequest{
+			CreatedBy: "bob@example.com",
+			Recipient: "alice@example.com",
+			Subject:   "  Deadline ",
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "Deadline", direct.Conversation.Subject)
+		assert.Equal(t, []string{"alice@example.com", "bob@example.com"}, direct.Conversation.Participants)
+
+		projectConv, err := grpcServer.CreateConversation(ctx, &pb.CreateConversationRequest{
+			CreatedBy: "carol@example.com",
+			ProjectId: int32(p.ID),
+		})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"carol@example.com"}, projectConv.Conversation.Participants)
+
+		// Posting to a project conversation joins it, for members of the
+		// project; the direct conversation stays between its two participants
+		require.NoError(t, repo.Create(ctx, &message.Message{Email: "alice@example.com", StudentID: 1, Message: "Hi all", ConversationID: int(projectConv.Conversation.Id)}))
+		err = repo.Create(ctx, &message.Message{Email: "dave@example.com", StudentID: 4, Message: "Hi", ConversationID: int(projectConv.Conversation.Id)})
+		assert.ErrorIs(t, err, message.ErrNotMember)
+		err = repo.Create(ctx, &message.Message{Email: "carol@example.com", Message: "Hi", ConversationID: int(direct.Conversation.Id)})
+		assert.ErrorIs(t, err, message.ErrNotParticipant)
+
+		// CheckPost runs the same checks without posting
+		_, err = grpcServer.CheckPost(ctx, &pb.CheckPostRequest{Email: "alice@example.com", StudentId: 1, ConversationId: projectConv.Conversation.Id})
+		require.NoError(t, err)
+		_, err = grpcServer.CheckPost(ctx, &pb.CheckPostRequest{Email: "dave@example.com", StudentId: 4, ConversationId: projectConv.Conversation.Id})
+		assert.Equal(t, codes.PermissionDenied, status.Code(err))
+		_, err = grpcServer.CheckPost(ctx, &pb.CheckPostRequest{Email: "carol@example.com", ConversationId: direct.Conversation.Id})
+		assert.Equal(t, codes.PermissionDenied, status.Code(err))
+		_, err = grpcServer.CheckPost(ctx, &pb.CheckPostRequest{Email: "alice@example.com", ReplyTo: 999999})
+		assert.Equal(t, codes.NotFound, status.Code(err))
+		_, err = grpcServer.CheckPost(ctx, &pb.CheckPostRequest{Email: "alice@example.com"})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+		list, err := grpcServer.ListConversations(ctx, &pb.ListConversationsRequest{Email: "alice@example.com"})
+		require.NoError(t, err)
+		require.Len(t, list.Conversations, 2)
+		assert.Equal(t, projectConv.Conversation.Id, list.Conversations[0].Id)
+		assert.Equal(t, direct.Conversation.Id, list.Conversations[1].Id)
+
+		list, err = grpcServer.ListConversations(ctx, &pb.ListConversationsRequest{Email: "alice@example.com", ProjectId: int32(p.ID)})
+		require.NoError(t, err)
+		require.Len(t, list.Conversations, 1)
+		assert.Equal(t, []string{"alice@example.com", "carol@example.com"}, list.Conversations[0].Participants)
+
+		_, err = grpcServer.ListConversations(ctx, &pb.ListConversationsRequest{})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+		_, err = grpcServer.CreateConversation(ctx, &pb.CreateConversationRequest{CreatedBy: "bob@example.com", Recipient: "BOB@example.com"})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+		_, err = grpcServer.CreateConversation(ctx, &pb.CreateConversationRequest{CreatedBy: "bob@example.com", ProjectId: 999999})
+		assert.Equal(t, codes.NotFound, status.Code(err))
+	})
+
+	t.Run("GetThread", func(t *testing.T) {
+		testdb.CleanupTables(t, pgContainer.DB, "messages", "conversations", "conversation_participants")
+		ctx := context.Background()
+
+		root := &message.Message{Email: "alice@example.com", Message: "Question"}
+		require.NoError(t, repo.Create(ctx, root))
+		reply := &message.Message{Email: "bob@example.com", Message: "Answer", ParentID: root.ID}
+		require.NoError(t, repo.Create(ctx, reply))
+		nested := &message.Message{Email: "alice@example.com", Message: "Thanks", ParentID: reply.ID}
+		require.NoError(t, repo.Create(ctx, nested))
+		assert.Equal(t, root.ID, nested.ThreadRootID)
+
+		err := repo.Create(ctx, &message.Message{Email: "bob@example.com", Message: "Lost", ParentID: 999999})
+		assert.ErrorIs(t, err, message.ErrMessageNotFound)
+
+		// Any message of the thread resolves to the whole thread
+		thread, err := grpcServer.GetThread(ctx, &pb.GetThreadRequest{MessageId: int32(nested.ID), ViewerEmail: "carol@example.com"})
+		require.NoError(t, err)
+		assert.Equal(t, int32(root.ID), thread.Root.Id)
+		require.Len(t, thread.Replies, 2)
+		assert.Equal(t, int32(reply.ID), thread.Replies[0].Id)
+		assert.Equal(t, int32(reply.ID), thread.Replies[1].ParentId)
+		assert.Nil(t, thread.Conversation)
+
+		_, err = grpcServer.GetThread(ctx, &pb.GetThreadRequest{MessageId: 999999, ViewerEmail: "alice@example.com"})
+		assert.Equal(t, codes.NotFound, status.Code(err))
+		_, err = grpcServer.GetThread(ctx, &pb.GetThreadRequest{MessageId: int32(root.ID)})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+		// A thread of a direct conversation is only shown to its participants
+		direct := &message.Conversation{CreatedBy: "alice@example.com"}
+		require.NoError(t, repo.CreateConversation(ctx, direct, []string{"alice@example.com", "bob@example.com"}))
+		private := &message.Message{Email: "alice@example.com", Message: "Between us", ConversationID: direct.ID}
+		require.NoError(t, repo.Create(ctx, private))
+		thread, err = grpcServer.GetThread(ctx, &pb.GetThreadRequest{MessageId: int32(private.ID), ViewerEmail: "bob@example.com"})
+		require.NoError(t, err)
+		assert.Equal(t, direct.ID, int(thread.Conversation.Id))
+		_, err = grpcServer.GetThread(ctx, &pb.GetThreadRequest{MessageId: int32(private.ID), ViewerEmail: "carol@example.com"})
+		assert.Equal(t, codes.PermissionDenied, status.Code(err))
+	})
+
+	t.Run("EditHistory", func(t *testing.T) {
+		testdb.CleanupTables(t, pgContainer.DB, "messages", "message_edits", "conversations", "conversation_participants")
+		ctx := context.Background()
+
+		sent := &message.Message{Email: "alice@example.com", Message: "Draft"}
+		require.NoError(t, repo.Create(ctx, sent))
+		_, err := service.EditMessage(ctx, message.EditEvent{Email: "alice@example.com", MessageID: sent.ID, Message: "Final"})
+		require.NoError(t, err)
+		_, err = service.EditMessage(ctx, message.EditEvent{Email: "bob@example.com", MessageID: sent.ID, Message: "Mine"})
+		assert.ErrorIs(t, err, message.ErrNotAuthor)
+
+		resp, err := grpcServer.GetEditHistory(ctx, &pb.GetEditHistoryRequest{MessageId: int32(sent.ID), ViewerEmail: "alice@example.com"})
+		require.NoError(t, err)
+		assert.Equal(t, "Final", resp.Message.Message)
+		assert.True(t, resp.Message.Edited)
+		assert.NotNil(t, resp.Message.EditedAt)
+		require.Len(t, resp.Edits, 1)
+		assert.Equal(t, "Draft", resp.Edits[0].Message)
+
+		_, err = service.DeleteMessage(ctx, message.DeleteEvent{Email: "alice@example.com", MessageID: sent.ID})
+		require.NoError(t, err)
+		_, err = service.EditMessage(ctx, message.EditEvent{Email: "alice@example.com", MessageID: sent.ID, Message: "Back"})
+		assert.ErrorIs(t, err, message.ErrMessageDeleted)
+
+		// A tombstone keeps its history, for its author only
+		resp, err = grpcServer.GetEditHistory(ctx, &pb.GetEditHistoryRequest{MessageId: int32(sent.ID), ViewerEmail: "alice@example.com"})
+		require.NoError(t, err)
+		assert.True(t, resp.Message.Deleted)
+		assert.Empty(t, resp.Message.Message)
+		require.Len(t, resp.Edits, 1)
+		resp, err = grpcServer.GetEditHistory(ctx, &pb.GetEditHistoryRequest{MessageId: int32(sent.ID), ViewerEmail: "bob@example.com"})
+		require.NoError(t, err)
+		assert.Empty(t, resp.Edits)
+
+		_, err = grpcServer.GetEditHistory(ctx, &pb.GetEditHistoryRequest{MessageId: 999999, ViewerEmail: "alice@example.com"})
+		assert.Equal(t, codes.NotFound, status.Code(err))
+
+		// The history of a direct message is only shown to the participants
+		direct := &message.Conversation{CreatedBy: "alice@example.com"}
+		require.NoError(t, repo.CreateConversation(ctx, direct, []string{"alice@example.com", "bob@example.com"}))
+		private := &message.Message{Email: "alice@example.com", Message: "Between us", ConversationID: direct.ID}
+		require.NoError(t, repo.Create(ctx, private))
+		_, err = service.EditMessage(ctx, message.EditEvent{Email: "alice@example.com", MessageID: private.ID, Message: "Just between us"})
+		require.NoError(t, err)
+		resp, err = grpcServer.GetEditHistory(ctx, &pb.GetEditHistoryRequest{MessageId: int32(private.ID), ViewerEmail: "bob@example.com"})
+		require.NoError(t, err)
+		require.Len(t, resp.Edits, 1)
+		_, err = grpcServer.GetEditHistory(ctx, &pb.GetEditHistoryRequest{MessageId: int32(private.ID), ViewerEmail: "carol@example.com"})
+		assert.Equal(t, codes.PermissionDenied, status.Code(err))
+	})
+
+	t.Run("ReadReceipts", func(t *testing.T) {
+		testdb.CleanupTables(t, pgContainer.DB, "projects", "project_members", "messages", "conversations", "conversation_participants", "message_reads")
+		ctx := context.Background()
+
+		publisher := &recordingPublisher{}
+		readServer := message.NewGrpcServer(message.NewService(repo, publisher, 0), logger)
+
+		// alice, student 1, posts to the group conversation of her project
+		p := &project.Project{Name: "Coursework", Status: project.StatusActive}
+		_, err := pgContainer.DB.NewInsert().Model(p).Exec(ctx)
+		require.NoError(t, err)
+		_, err = pgContainer.DB.NewInsert().Model(&project.ProjectMember{ProjectID: p.ID, StudentID: 1, Role: project.RoleContributor}).Exec(ctx)
+		require.NoError(t, err)
+		studentIDs := map[string]int{"alice@example.com": 1, "bob@example.com": 2}
+
+		direct := &message.Conversation{CreatedBy: "alice@example.com"}
+		require.NoError(t, repo.CreateConversation(ctx, direct, []string{"alice@example.com", "bob@example.com"}))
+		group := &message.Conversation{ProjectID: p.ID, CreatedBy: "alice@example.com"}
+		require.NoError(t, repo.CreateConversation(ctx, group, []string{"alice@example.com", "bob@example.com"}))
+
+		post := func(email string, conversationID int) *message.Message {
+			msg := &message.Message{Email: email, StudentID: studentIDs[email], Message: "Hello", ConversationID: conversationID}
+			require.NoError(t, repo.Create(ctx, msg))
+			return msg
+		}
+		first := post("alice@example.com", direct.ID)
+		post("alice@example.com", direct.ID)
+		post("bob@example.com", direct.ID)
+		post("alice@example.com", group.ID)
+
+		counts, err := readServer.GetUnreadCounts(ctx, &pb.GetUnreadCountsRequest{Email: "bob@example.com"})
+		require.NoError(t, err)
+		assert.Equal(t, int32(3), counts.Total)
+		require.Len(t, counts.Conversations, 2)
+		assert.Equal(t, int32(2), counts.Conversations[0].Unread)
+
+		marked, err := readServer.MarkRead(ctx, &pb.MarkReadRequest{Email: "bob@example.com", MessageId: int32(first.ID)})
+		require.NoError(t, err)
+		assert.Equal(t, int32(1), marked.Marked)
+		marked, err = readServer.MarkRead(ctx, &pb.MarkReadRequest{Email: "bob@example.com", MessageId: int32(first.ID)})
+		require.NoError(t, err)
+		assert.Zero(t, marked.Marked)
+
+		_, err = readServer.MarkRead(ctx, &pb.MarkReadRequest{Email: "carol@example.com", MessageId: int32(first.ID)})
+		assert.Equal(t, codes.PermissionDenied, status.Code(err))
+		_, err = readServer.MarkRead(ctx, &pb.MarkReadRequest{Email: "bob@example.com", MessageId: 999999})
+		assert.Equal(t, codes.NotFound, status.Code(err))
+		_, err = readServer.MarkRead(ctx, &pb.MarkReadRequest{Email: "bob@example.com"})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+		marked, err = readServer.MarkRead(ctx, &pb.MarkReadRequest{
+			Email:          "bob@example.com",
+			UpTo:           timestamppb.New(time.Now().Add(time.Minute)),
+			ConversationId: int32(direct.ID),
+		})
+		require.NoError(t, err)
+		assert.Equal(t, int32(1), marked.Marked)
+
+		counts, err = readServer.GetUnreadCounts(ctx, &pb.GetUnreadCountsRequest{Email: "bob@example.com"})
+		require.NoError(t, err)
+		assert.Equal(t, int32(1), counts.Total)
+		require.Len(t, counts.Conversations, 1)
+		assert.Equal(t, int32(group.ID), counts.Conversations[0].ConversationId)
+
+		require.Len(t, publisher.events, 3)
+		assert.Equal(t, "bob@example.com", publisher.events[2].Email)
+		assert.Equal(t, direct.ID, publisher.events[2].ConversationID)
+		assert.Equal(t, 1, publisher.events[2].Marked)
+	})
+}
+
+type recordingPublisher struct {
+	events []*message.ReadEvent
+}
+
+func (p *recordingPublisher) SendMessage(ctx context.Context, value interface{}) error {
+	p.events = append(p.events, value.(*message.ReadEvent))
+	return nil
 }
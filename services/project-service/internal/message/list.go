@@ -0,0 +1,95 @@
+package message
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
+
+const (
+	DefaultPageSize = 50
+	MaxPageSize     = 500
+)
+
+// ListFilter narrows ListMessages. Zero values mean "no filter"; CreatedAfter
+// is inclusive and CreatedBefore exclusive.
+type ListFilter struct {
+	Sender string
+	// Recipient matches the messages others posted to the conversations it
+	// participates in
+	Recipient string
+	// ProjectID matches the messages posted to the project's teams and
+	// conversations
+	ProjectID     int
+	TeamID        int
+	TextContains  string
+	CreatedAfter  time.Time
+	CreatedBefore time.Time
+}
+
+// ListOptions is the caller-facing request for a page of messages
+type ListOptions struct {
+	ListFilter
+	PageSize  int
+	PageToken string
+}
+
+// ListQuery is the decoded form of ListOptions handed to the repository.
+// Messages are ordered newest first.
+type ListQuery struct {
+	ListFilter
+	After *PageToken
+	Limit int
+}
+
+// ListResult is one page of messages
+type ListResult struct {
+	Messages      []*Message
+	NextPageToken string
+}
+
+// PageToken identifies the last message of a page. Fingerprint ties the token
+// to the filters of the request that produced it.
+type PageToken struct {
+	Fingerprint string    `json:"f"`
+	ID          int       `json:"id"`
+	Time        time.Time `json:"t"`
+}
+
+// Encode returns the opaque string form of the token
+func (t *PageToken) Encode() string {
+	data, _ := json.Marshal(t)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// DecodePageToken parses a token produced by Encode
+func DecodePageToken(s string) (*PageToken, error) {
+	data, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, ErrInvalidPageToken
+	}
+	var t PageToken
+	if err := json.Unmarshal(data, &t); err != nil || t.ID <= 0 {
+		return nil, ErrInvalidPageToken
+	}
+	return &t, nil
+}
+
+// fingerprint hashes the filters, so a token cannot be replayed against a
+// different query
+func fingerprint(f ListFilter) string {
+	h := sha256.New()
+	fmt.Fprintf(h, "%s|%s|%d|%d|%s|%s|%s",
+		f.Sender, f.Recipient, f.ProjectID, f.TeamID, f.TextContains,
+		f.CreatedAfter.Format(time.RFC3339Nano), f.CreatedBefore.Format(time.RFC3339Nano))
+	return hex.EncodeToString(h.Sum(nil)[:8])
+}
+
+// escapeLike escapes the LIKE wildcards in a user supplied pattern
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(s)
+}
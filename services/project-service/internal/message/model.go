@@ -1,21 +1,260 @@
 package message
 
 import (
+	"fmt"
+	"strings"
 	"time"
+	"unicode/utf8"
 
 	"github.com/uptrace/bun"
 )
 
+// MaxSubjectLength is the longest accepted conversation subject, in characters
+const MaxSubjectLength = 200
+
 type Message struct {
 	bun.BaseModel `bun:"table:messages,alias:m"`
 
-	ID        int       `bun:"id,pk,autoincrement" json:"id"`
-	Email     string    `bun:"email,notnull" json:"email"`
-	Message   string    `bun:"message,notnull" json:"message"`
-	CreatedAt time.Time `bun:"created_at,notnull,default:current_timestamp" json:"createdAt"`
+	ID      int    `bun:"id,pk,autoincrement" json:"id"`
+	Email   string `bun:"email,notnull" json:"email"`
+	Message string `bun:"message,notnull" json:"message"`
+	// TeamID is the team the message was posted to, 0 for none
+	TeamID int `bun:"team_id,nullzero" json:"teamId,omitempty"`
+	// ConversationID is the conversation the message belongs to, 0 for none
+	ConversationID int `bun:"conversation_id,nullzero" json:"conversationId,omitempty"`
+	// ParentID is the message this one replies to, and ThreadRootID the first
+	// message of the thread; both are 0 for a message that starts a thread
+	ParentID     int       `bun:"parent_id,nullzero" json:"parentId,omitempty"`
+	ThreadRootID int       `bun:"thread_root_id,nullzero" json:"threadRootId,omitempty"`
+	CreatedAt    time.Time `bun:"created_at,notnull,default:current_timestamp" json:"createdAt"`
+	// EditedAt is when the text last changed. A deleted message stays as a
+	// tombstone without text, so its thread and replies keep their place.
+	EditedAt  *time.Time `bun:"edited_at" json:"editedAt,omitempty"`
+	DeletedAt *time.Time `bun:"deleted_at" json:"deletedAt,omitempty"`
+
+	// StudentID is the sender's student ID, which project conversations check
+	// membership by. It is not stored.
+	StudentID int `bun:"-" json:"-"`
+}
+
+// Edited reports whether the message was changed after it was sent
+func (m *Message) Edited() bool {
+	return m.EditedAt != nil
+}
+
+// Deleted reports whether the message is a tombstone
+func (m *Message) Deleted() bool {
+	return m.DeletedAt != nil
+}
+
+// Edit is a previous version of a message's text, kept when it is edited
+type Edit struct {
+	bun.BaseModel `bun:"table:message_edits,alias:me"`
+
+	ID        int    `bun:"id,pk,autoincrement"`
+	MessageID int    `bun:"message_id,notnull"`
+	Message   string `bun:"message,notnull"`
+	// EditedAt is when this text was replaced
+	EditedAt time.Time `bun:"edited_at,notnull"`
+}
+
+// SearchResult is a message matching a full-text search, with its rank and
+// the fragments around the matches highlighted as HTML
+type SearchResult struct {
+	Message `bun:",extend"`
+
+	Rank      float64 `bun:"rank,scanonly"`
+	Highlight string  `bun:"highlight,scanonly"`
+}
+
+// Conversation groups messages, either within a project or directly between
+// two students when ProjectID is 0
+type Conversation struct {
+	bun.BaseModel `bun:"table:conversations,alias:cv"`
+
+	ID            int       `bun:"id,pk,autoincrement" json:"id"`
+	ProjectID     int       `bun:"project_id,nullzero" json:"projectId,omitempty"`
+	Subject       string    `bun:"subject,notnull,default:''" json:"subject"`
+	CreatedBy     string    `bun:"created_by,notnull" json:"createdBy"`
+	CreatedAt     time.Time `bun:"created_at,notnull,default:current_timestamp" json:"createdAt"`
+	LastMessageAt time.Time `bun:"last_message_at,notnull,default:current_timestamp" json:"lastMessageAt"`
+
+	// Participants are stored in conversation_participants; the repository
+	// loads them sorted
+	Participants []string `bun:"-" json:"participants"`
+}
+
+// Participant links an email to a conversation. The participants of a
+// direct conversation are fixed when it starts; a project conversation gains
+// everyone who posts to it.
+type Participant struct {
+	bun.BaseModel `bun:"table:conversation_participants,alias:cp"`
+
+	ConversationID int    `bun:"conversation_id,pk"`
+	Email          string `bun:"email,pk"`
+}
+
+// Read records that a student read a message
+type Read struct {
+	bun.BaseModel `bun:"table:message_reads,alias:mr"`
+
+	MessageID int       `bun:"message_id,pk"`
+	Email     string    `bun:"email,pk"`
+	ReadAt    time.Time `bun:"read_at,notnull,default:current_timestamp"`
+}
+
+// MarkRead selects the messages a student read: either MessageID, or every
+// message of the student's conversations created up to UpTo, only those of
+// ConversationID if set
+type MarkRead struct {
+	Email          string
+	MessageID      int
+	UpTo           time.Time
+	ConversationID int
+}
+
+// Validate checks that exactly one of MessageID and UpTo is set
+func (r *MarkRead) Validate() error {
+	r.Email = strings.TrimSpace(r.Email)
+	switch {
+	case r.Email == "":
+		return fmt.Errorf("%w: email is required", ErrInvalidInput)
+	case r.MessageID < 0 || r.ConversationID < 0:
+		return fmt.Errorf("%w: invalid ID", ErrInvalidInput)
+	case (r.MessageID == 0) == r.UpTo.IsZero():
+		return fmt.Errorf("%w: exactly one of message and up to is required", ErrInvalidInput)
+	case r.MessageID != 0 && r.ConversationID != 0:
+		return fmt.Errorf("%w: a conversation only applies up to a time", ErrInvalidInput)
+	}
+	return nil
+}
+
+// UnreadCount is the number of unread messages in a conversation
+type UnreadCount struct {
+	ConversationID int `bun:"conversation_id"`
+	Unread         int `bun:"unread"`
+}
+
+// CreatedEvent is published on NATS once a message is saved, addressed to
+// everyone who should see it: the sender and the participants of its
+// conversation by email, the members of its team by student ID
+type CreatedEvent struct {
+	Message    *Message `json:"message"`
+	Recipients []string `json:"recipients"`
+	StudentIDs []int    `json:"studentIds,omitempty"`
+}
+
+// ReadEvent is published on NATS when a student marks messages read, so the
+// student's other sessions can update
+type ReadEvent struct {
+	Email          string     `json:"email"`
+	MessageID      int        `json:"messageId,omitempty"`
+	UpTo           *time.Time `json:"upTo,omitempty"`
+	ConversationID int        `json:"conversationId,omitempty"`
+	Marked         int        `json:"marked"`
+	ReadAt         time.Time  `json:"readAt"`
+}
+
+// Direct reports whether the conversation is between two students rather
+// than scoped to a project
+func (c *Conversation) Direct() bool {
+	return c.ProjectID == 0
+}
+
+// NewConversation starts a conversation within a project, or with a
+// recipient when ProjectID is 0
+type NewConversation struct {
+	CreatedBy string
+	ProjectID int
+	Recipient string
+	Subject   string
+}
+
+// Validate checks the conversation and trims its fields
+func (n *NewConversation) Validate() error {
+	n.CreatedBy = strings.TrimSpace(n.CreatedBy)
+	n.Recipient = strings.TrimSpace(n.Recipient)
+	n.Subject = strings.TrimSpace(n.Subject)
+	switch {
+	case n.CreatedBy == "":
+		return fmt.Errorf("%w: creator is required", ErrInvalidInput)
+	case n.ProjectID < 0:
+		return fmt.Errorf("%w: invalid project ID %d", ErrInvalidInput, n.ProjectID)
+	case (n.ProjectID == 0) == (n.Recipient == ""):
+		return fmt.Errorf("%w: exactly one of project and recipient is required", ErrInvalidInput)
+	case strings.EqualFold(n.Recipient, n.CreatedBy):
+		return fmt.Errorf("%w: a direct conversation needs another student", ErrInvalidInput)
+	case utf8.RuneCountInString(n.Subject) > MaxSubjectLength:
+		return fmt.Errorf("%w: subject is longer than %d characters", ErrInvalidInput, MaxSubjectLength)
+	}
+	return nil
+}
+
+// Thread is a message that starts a thread and every reply to it, directly or
+// indirectly, oldest first
+type Thread struct {
+	Root    *Message
+	Replies []*Message
+	// Conversation is nil if the thread is not part of one
+	Conversation *Conversation
 }
 
 type MessageEvent struct {
-	Email   string `json:"email"`
-	Message string `json:"message"`
+	Email string `json:"email"`
+	// StudentID is the sender's student ID, needed to post to a project
+	// conversation
+	StudentID int    `json:"studentId,omitempty"`
+	Message   string `json:"message"`
+	TeamID    int    `json:"teamId,omitempty"`
+	// ConversationID adds the message to a conversation and ReplyTo makes it
+	// a reply; a reply joins the conversation of the message it replies to
+	ConversationID int `json:"conversationId,omitempty"`
+	ReplyTo        int `json:"replyTo,omitempty"`
+}
+
+// EditEvent asks to replace the text of a message. Only its author may, and
+// only within the edit window after sending it, as of when the event is
+// handled.
+type EditEvent struct {
+	Email     string `json:"email"`
+	MessageID int    `json:"messageId"`
+	Message   string `json:"message"`
+}
+
+// Validate checks the edit and trims its fields.
+func (e *EditEvent) Validate() error {
+	e.Email = strings.TrimSpace(e.Email)
+	e.Message = strings.TrimSpace(e.Message)
+	switch {
+	case e.Email == "":
+		return fmt.Errorf("%w: email is required", ErrInvalidInput)
+	case e.MessageID <= 0:
+		return fmt.Errorf("%w: invalid message ID %d", ErrInvalidInput, e.MessageID)
+	case e.Message == "":
+		return fmt.Errorf("%w: message is required", ErrInvalidInput)
+	}
+	return nil
+}
+
+// DeleteEvent asks to delete a message, leaving a tombstone. Only its author
+// may, at any time.
+type DeleteEvent struct {
+	Email     string    `json:"email"`
+	MessageID int       `json:"messageId"`
+	DeletedAt time.Time `json:"deletedAt"`
+}
+
+// Validate checks the delete and trims its fields. DeletedAt defaults to now.
+func (e *DeleteEvent) Validate() error {
+	e.Email = strings.TrimSpace(e.Email)
+	switch {
+	case e.Email == "":
+		return fmt.Errorf("%w: email is required", ErrInvalidInput)
+	case e.MessageID <= 0:
+		return fmt.Errorf("%w: invalid message ID %d", ErrInvalidInput, e.MessageID)
+	}
+	if e.DeletedAt.IsZero() {
+		e.DeletedAt = time.Now().UTC()
+	}
+	return nil
 }
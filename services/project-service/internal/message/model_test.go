@@ -0,0 +1,74 @@
+package message_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"project-service/internal/message"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewConversationValidate(t *testing.T) {
+	n := message.NewConversation{CreatedBy: " alice@example.com ", Recipient: "bob@example.com", Subject: "  Deadline "}
+	require.NoError(t, n.Validate())
+	assert.Equal(t, "alice@example.com", n.CreatedBy)
+	assert.Equal(t, "Deadline", n.Subject)
+
+	n = message.NewConversation{CreatedBy: "alice@example.com", ProjectID: 1, Subject: strings.Repeat("é", message.MaxSubjectLength)}
+	require.NoError(t, n.Validate())
+
+	for name, invalid := range map[string]message.NewConversation{
+		"no creator":       {Recipient: "bob@example.com"},
+		"neither":          {CreatedBy: "alice@example.com"},
+		"both":             {CreatedBy: "alice@example.com", ProjectID: 1, Recipient: "bob@example.com"},
+		"negative project": {CreatedBy: "alice@example.com", ProjectID: -1},
+		"self":             {CreatedBy: "alice@example.com", Recipient: "Alice@Example.com"},
+		"subject too long": {CreatedBy: "alice@example.com", ProjectID: 1, Subject: strings.Repeat("a", message.MaxSubjectLength+1)},
+		"blank recipient":  {CreatedBy: "alice@example.com", Recipient: "  "},
+	} {
+		assert.ErrorIs(t, invalid.Validate(), message.ErrInvalidInput, name)
+	}
+}
+
+func TestMarkReadValidate(t *testing.T) {
+	r := message.MarkRead{Email: " bob@example.com ", MessageID: 3}
+	require.NoError(t, r.Validate())
+	assert.Equal(t, "bob@example.com", r.Email)
+
+	r = message.MarkRead{Email: "bob@example.com", UpTo: time.Now(), ConversationID: 2}
+	require.NoError(t, r.Validate())
+
+	for name, invalid := range map[string]message.MarkRead{
+		"no email":             {MessageID: 3},
+		"neither":              {Email: "bob@example.com"},
+		"both":                 {Email: "bob@example.com", MessageID: 3, UpTo: time.Now()},
+		"message conversation": {Email: "bob@example.com", MessageID: 3, ConversationID: 2},
+		"negative message":     {Email: "bob@example.com", MessageID: -1},
+	} {
+		assert.ErrorIs(t, invalid.Validate(), message.ErrInvalidInput, name)
+	}
+}
+
+func TestEditEventValidate(t *testing.T) {
+	e := message.EditEvent{Email: " alice@example.com ", MessageID: 3, Message: " Fixed typo "}
+	require.NoError(t, e.Validate())
+	assert.Equal(t, "Fixed typo", e.Message)
+	assert.Equal(t, "alice@example.com", e.Email)
+
+	for name, invalid := range map[string]message.EditEvent{
+		"no email":   {MessageID: 3, Message: "Hi"},
+		"no message": {Email: "alice@example.com", MessageID: 3, Message: "  "},
+		"no ID":      {Email: "alice@example.com", Message: "Hi"},
+	} {
+		assert.ErrorIs(t, invalid.Validate(), message.ErrInvalidInput, name)
+	}
+
+	d := message.DeleteEvent{Email: "alice@example.com", MessageID: 3}
+	require.NoError(t, d.Validate())
+	assert.False(t, d.DeletedAt.IsZero())
+	d = message.DeleteEvent{Email: "alice@example.com"}
+	assert.ErrorIs(t, d.Validate(), message.ErrInvalidInput)
+}
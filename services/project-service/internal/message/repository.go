@@ -2,18 +2,96 @@ package message
 
 import (
 	"context"
+	"database/sql"
+	"fmt"
 	"time"
 
 	"grud/common/metrics"
+	"grud/common/search"
+	"project-service/internal/project"
 
 	"github.com/uptrace/bun"
 )
 
 type Repository interface {
+	// Create inserts the message. A reply joins the thread and conversation of
+	// its parent; a message for a conversation also bumps its last activity.
+	// It returns ErrMessageNotFound if the parent does not exist,
+	// ErrConversationNotFound if the conversation does not, ErrNotParticipant
+	// if the sender is not part of a direct conversation, and ErrNotMember if
+	// they are not a member of the project of another.
 	Create(ctx context.Context, message *Message) error
-	GetByEmail(ctx context.Context, email string) ([]*Message, error)
+	// CheckPost runs the checks of Create on a message for a conversation or
+	// a thread, without saving anything
+	CheckPost(ctx context.Context, message *Message) error
+	GetByID(ctx context.Context, id int) (*Message, error)
+	// GetByEmail returns the messages of the sender, those posted to the team,
+	// or both when email and teamID are set, newest first
+	GetByEmail(ctx context.Context, email string, teamID int) ([]*Message, error)
+	// List returns up to q.Limit messages matching q, newest first, after
+	// q.After if set
+	List(ctx context.Context, q ListQuery) ([]*Message, error)
+	// Search returns up to limit messages matching the tsquery that viewer
+	// may read, best match first
+	Search(ctx context.Context, tsquery string, limit int, viewer Viewer) ([]SearchResult, error)
+	// Export calls fn with consecutive batches of the messages matching f,
+	// oldest first, read from a server-side cursor
+	Export(ctx context.Context, f ExportFilter, fn func([]*Message) error) error
+	// GetReplies returns the replies of the thread started by rootID, oldest first
+	GetReplies(ctx context.Context, rootID int) ([]*Message, error)
+	// Edit replaces the text of the message, keeping the previous one in its
+	// history. It returns ErrMessageDeleted for a tombstone.
+	Edit(ctx context.Context, id int, text string, editedAt time.Time) (*Message, error)
+	// Delete turns the message into a tombstone without text, keeping its
+	// history. It returns ErrMessageDeleted if it already is one.
+	Delete(ctx context.Context, id int, deletedAt time.Time) (*Message, error)
+	// ListEdits returns the previous texts of the message, oldest first
+	ListEdits(ctx context.Context, messageID int) ([]*Edit, error)
+
+	// CreateConversation inserts the conversation with its participants
+	CreateConversation(ctx context.Context, conversation *Conversation, participants []string) error
+	// GetConversation returns the conversation with its participants
+	GetConversation(ctx context.Context, id int) (*Conversation, error)
+	// ListConversations returns the conversations the email participates in,
+	// those of the project, or both when both are set, most recently active first
+	ListConversations(ctx context.Context, email string, projectID int) ([]*Conversation, error)
+	// ProjectExists reports whether the project exists and is not deleted
+	ProjectExists(ctx context.Context, projectID int) (bool, error)
+	// IsParticipant reports whether the email participates in the conversation
+	IsParticipant(ctx context.Context, conversationID int, email string) (bool, error)
+	// IsMember reports whether the student is a member of the project, of the
+	// team if teamID is set, or both
+	IsMember(ctx context.Context, projectID, teamID, studentID int) (bool, error)
+
+	// MarkRead records that email read the message and reports whether it
+	// was unread
+	MarkRead(ctx context.Context, messageID int, email string, readAt time.Time) (bool, error)
+	// MarkReadUpTo records that email read every message others posted up to
+	// upTo in the conversations email participates in, only conversationID
+	// if set, and returns how many were unread
+	MarkReadUpTo(ctx context.Context, email string, upTo time.Time, conversationID int, readAt time.Time) (int, error)
+	// Recipients returns who should see the message: its sender and the
+	// participants of its conversation, and the members of its team
+	Recipients(ctx context.Context, message *Message) (emails []string, studentIDs []int, err error)
+	// UnreadCounts returns the number of messages others posted that email
+	// has not read, per conversation email participates in, skipping those
+	// without any
+	UnreadCounts(ctx context.Context, email string) ([]UnreadCount, error)
+}
+
+// ExportFilter narrows Export to the messages Viewer may read. Other zero
+// values mean "no filter"; CreatedAfter is inclusive and CreatedBefore
+// exclusive.
+type ExportFilter struct {
+	Viewer        Viewer
+	Email         string
+	CreatedAfter  time.Time
+	CreatedBefore time.Time
 }
 
+// exportBatchSize is the number of rows fetched from the export cursor at a time
+const exportBatchSize = 500
+
 type repository struct {
 	db      *bun.DB
 	metrics *metrics.Metrics
@@ -27,6 +105,19 @@ func NewRepository(db *bun.DB, m *metrics.Metrics) Repository {
 }
 
 func (r *repository) Create(ctx context.Context, message *Message) error {
+	if message.ParentID != 0 || message.ConversationID != 0 {
+		return r.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+			if err := r.placeInThread(ctx, tx, message); err != nil {
+				return err
+			}
+			start := time.Now()
+			_, err := tx.NewInsert().Model(message).Returning("*").Exec(ctx)
+			r.metrics.Database.RecordQuery(ctx, "insert", "messages", time.Since(start), err)
+
+			return err
+		})
+	}
+
 	start := time.Now()
 	_, err := r.db.NewInsert().Model(message).Exec(ctx)
 
@@ -44,16 +135,627 @@ func (r *repository) Create(ctx context.Context, message *Message) error {
 	return err
 }
 
-func (r *repository) GetByEmail(ctx context.Context, email string) ([]*Message, error) {
+func (r *repository) GetByID(ctx context.Context, id int) (*Message, error) {
+	start := time.Now()
+	message := new(Message)
+	err := r.db.NewSelect().Model(message).Where("id = ?", id).Scan(ctx)
+	r.metrics.Database.RecordQuery(ctx, "select", "messages", time.Since(start), err)
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrMessageNotFound
+		}
+		return nil, err
+	}
+	return message, nil
+}
+
+func (r *repository) GetByEmail(ctx context.Context, email string, teamID int) ([]*Message, error) {
 	start := time.Now()
 	var messages []*Message
+	query := r.db.NewSelect().Model(&messages)
+	if email != "" {
+		query.Where("email = ?", email)
+	}
+	if teamID != 0 {
+		query.Where("team_id = ?", teamID)
+	}
+	err := query.Order("created_at DESC").Scan(ctx)
+
+	r.metrics.Database.RecordQuery(ctx, "select", "messages", time.Since(start), err)
+
+	return messages, err
+}
+
+func (r *repository) List(ctx context.Context, q ListQuery) ([]*Message, error) {
+	start := time.Now()
+	messages := make([]*Message, 0, q.Limit)
+
+	query := r.db.NewSelect().Model(&messages)
+	if q.Sender != "" {
+		query.Where("m.email = ?", q.Sender)
+	}
+	if q.Recipient != "" {
+		participating := r.db.NewSelect().
+			Model((*Participant)(nil)).
+			Column("cp.conversation_id").
+			Where("cp.email = ?", q.Recipient)
+		query.Where("m.conversation_id IN (?)", participating).
+			Where("m.email <> ?", q.Recipient)
+	}
+	if q.ProjectID != 0 {
+		conversations := r.db.NewSelect().
+			Model((*Conversation)(nil)).
+			Column("cv.id").
+			Where("cv.project_id = ?", q.ProjectID)
+		teams := r.db.NewSelect().
+			TableExpr("teams").
+			Column("id").
+			Where("project_id = ?", q.ProjectID)
+		query.WhereGroup(" AND ", func(sq *bun.SelectQuery) *bun.SelectQuery {
+			return sq.Where("m.conversation_id IN (?)", conversations).
+				WhereOr("m.team_id IN (?)", teams)
+		})
+	}
+	if q.TeamID != 0 {
+		query.Where("m.team_id = ?", q.TeamID)
+	}
+	if q.TextContains != "" {
+		query.Where("m.message ILIKE ?", "%"+escapeLike(q.TextContains)+"%")
+	}
+	if !q.CreatedAfter.IsZero() {
+		query.Where("m.created_at >= ?", q.CreatedAfter)
+	}
+	if !q.CreatedBefore.IsZero() {
+		query.Where("m.created_at < ?", q.CreatedBefore)
+	}
+	if q.After != nil {
+		query.Where("(m.created_at, m.id) < (?, ?)", q.After.Time, q.After.ID)
+	}
+	err := query.
+		Order("m.created_at DESC", "m.id DESC").
+		Limit(q.Limit).
+		Scan(ctx)
+
+	r.metrics.Database.RecordQuery(ctx, "select", "messages", time.Since(start), err)
+
+	if err != nil {
+		return nil, err
+	}
+	return messages, nil
+}
+
+func (r *repository) Search(ctx context.Context, tsquery string, limit int, viewer Viewer) ([]SearchResult, error) {
+	start := time.Now()
+	results := make([]SearchResult, 0, limit)
+	err := r.readableBy(r.db.NewSelect().Model(&results), viewer).
+		ColumnExpr("?TableColumns").
+		ColumnExpr(search.Rank+" AS rank", tsquery).
+		ColumnExpr(search.Headline("m.message", search.Fragments)+" AS highlight", tsquery).
+		Where(search.Match, tsquery).
+		OrderExpr("rank DESC, m.created_at DESC, m.id DESC").
+		Limit(limit).
+		Scan(ctx)
+
+	r.metrics.Database.RecordQuery(ctx, "search", "messages", time.Since(start), err)
+
+	return results, err
+}
+
+func (r *repository) Export(ctx context.Context, f ExportFilter, fn func([]*Message) error) error {
+	return r.db.RunInTx(ctx, &sql.TxOptions{ReadOnly: true}, func(ctx context.Context, tx bun.Tx) error {
+		query := r.readableBy(tx.NewSelect().Model((*Message)(nil)), f.Viewer)
+		if f.Email != "" {
+			query.Where("email = ?", f.Email)
+		}
+		if !f.CreatedAfter.IsZero() {
+			query.Where("created_at >= ?", f.CreatedAfter)
+		}
+		if !f.CreatedBefore.IsZero() {
+			query.Where("created_at < ?", f.CreatedBefore)
+		}
+		query.Order("created_at ASC", "id ASC")
+
+		// The cursor lives until the transaction ends
+		start := time.Now()
+		_, err := tx.ExecContext(ctx, "DECLARE message_export NO SCROLL CURSOR FOR "+query.String())
+		r.metrics.Database.RecordQuery(ctx, "declare", "messages", time.Since(start), err)
+		if err != nil {
+			return err
+		}
+
+		for {
+			var messages []*Message
+			start := time.Now()
+			err := tx.NewRaw("FETCH FORWARD ? FROM message_export", exportBatchSize).Scan(ctx, &messages)
+			r.metrics.Database.RecordQuery(ctx, "fetch", "messages", time.Since(start), err)
+			if err != nil {
+				return err
+			}
+
+			if len(messages) > 0 {
+				if err := fn(messages); err != nil {
+					return err
+				}
+			}
+			if len(messages) < exportBatchSize {
+				return nil
+			}
+		}
+	})
+}
+
+// readableBy narrows query to the messages viewer may read: those they sent,
+// those of conversations they participate in or whose project they are a
+// member of, and those of their teams
+func (r *repository) readableBy(query *bun.SelectQuery, viewer Viewer) *bun.SelectQuery {
+	participating := r.db.NewSelect().
+		Model((*Participant)(nil)).
+		Column("cp.conversation_id").
+		Where("cp.email = ?", viewer.Email)
+	projects := r.db.NewSelect().
+		Model((*project.ProjectMember)(nil)).
+		Column("pm.project_id").
+		Where("pm.student_id = ?", viewer.StudentID)
+	projectConversations := r.db.NewSelect().
+		Model((*Conversation)(nil)).
+		Column("cv.id").
+		Where("cv.project_id IN (?)", projects)
+	teams := r.db.NewSelect().
+		Model((*project.ProjectMember)(nil)).
+		Column("pm.team_id").
+		Where("pm.student_id = ?", viewer.StudentID).
+		Where("pm.team_id IS NOT NULL")
+	return query.WhereGroup(" AND ", func(sq *bun.SelectQuery) *bun.SelectQuery {
+		return sq.Where("m.email = ?", viewer.Email).
+			WhereOr("m.conversation_id IN (?)", participating).
+			WhereOr("m.conversation_id IN (?)", projectConversations).
+			WhereOr("m.team_id IN (?)", teams)
+	})
+}
+
+func (r *repository) GetReplies(ctx context.Context, rootID int) ([]*Message, error) {
+	start := time.Now()
+	replies := []*Message{}
 	err := r.db.NewSelect().
-		Model(&messages).
-		Where("email = ?", email).
-		Order("created_at DESC").
+		Model(&replies).
+		Where("thread_root_id = ?", rootID).
+		Order("created_at ASC", "id ASC").
 		Scan(ctx)
+	r.metrics.Database.RecordQuery(ctx, "select", "messages", time.Since(start), err)
+
+	return replies, err
+}
+
+func (r *repository) Edit(ctx context.Context, id int, text string, editedAt time.Time) (*Message, error) {
+	var message *Message
+	err := r.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		var err error
+		if message, err = r.lockForChange(ctx, tx, id); err != nil {
+			return err
+		}
+
+		start := time.Now()
+		_, err = tx.NewInsert().Model(&Edit{MessageID: id, Message: message.Message, EditedAt: editedAt}).Exec(ctx)
+		r.metrics.Database.RecordQuery(ctx, "insert", "message_edits", time.Since(start), err)
+		if err != nil {
+			return err
+		}
+
+		message.Message = text
+		message.EditedAt = &editedAt
+		start = time.Now()
+		_, err = tx.NewUpdate().Model(message).Column("message", "edited_at").WherePK().Exec(ctx)
+		r.metrics.Database.RecordQuery(ctx, "update", "messages", time.Since(start), err)
+
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return message, nil
+}
+
+func (r *repository) Delete(ctx context.Context, id int, deletedAt time.Time) (*Message, error) {
+	var message *Message
+	err := r.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		var err error
+		if message, err = r.lockForChange(ctx, tx, id); err != nil {
+			return err
+		}
+
+		message.Message = ""
+		message.DeletedAt = &deletedAt
+		start := time.Now()
+		_, err = tx.NewUpdate().Model(message).Column("message", "deleted_at").WherePK().Exec(ctx)
+		r.metrics.Database.RecordQuery(ctx, "update", "messages", time.Since(start), err)
+
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return message, nil
+}
 
+// lockForChange loads a message that is not a tombstone, locking it until
+// the transaction ends
+func (r *repository) lockForChange(ctx context.Context, tx bun.Tx, id int) (*Message, error) {
+	start := time.Now()
+	message := new(Message)
+	err := tx.NewSelect().Model(message).Where("id = ?", id).For("UPDATE").Scan(ctx)
 	r.metrics.Database.RecordQuery(ctx, "select", "messages", time.Since(start), err)
 
-	return messages, err
+	if err == sql.ErrNoRows {
+		return nil, ErrMessageNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	if message.Deleted() {
+		return nil, ErrMessageDeleted
+	}
+	return message, nil
+}
+
+func (r *repository) ListEdits(ctx context.Context, messageID int) ([]*Edit, error) {
+	start := time.Now()
+	edits := []*Edit{}
+	err := r.db.NewSelect().
+		Model(&edits).
+		Where("message_id = ?", messageID).
+		Order("edited_at ASC", "id ASC").
+		Scan(ctx)
+	r.metrics.Database.RecordQuery(ctx, "select", "message_edits", time.Since(start), err)
+
+	return edits, err
+}
+
+func (r *repository) CreateConversation(ctx context.Context, conversation *Conversation, participants []string) error {
+	return r.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		start := time.Now()
+		_, err := tx.NewInsert().Model(conversation).Returning("*").Exec(ctx)
+		r.metrics.Database.RecordQuery(ctx, "insert", "conversations", time.Since(start), err)
+
+		if err != nil {
+			return err
+		}
+
+		rows := make([]Participant, len(participants))
+		for i, email := range participants {
+			rows[i] = Participant{ConversationID: conversation.ID, Email: email}
+		}
+		start = time.Now()
+		_, err = tx.NewInsert().Model(&rows).On("CONFLICT DO NOTHING").Exec(ctx)
+		r.metrics.Database.RecordQuery(ctx, "insert", "conversation_participants", time.Since(start), err)
+
+		if err != nil {
+			return err
+		}
+		return r.attachParticipants(ctx, tx, conversation)
+	})
+}
+
+func (r *repository) GetConversation(ctx context.Context, id int) (*Conversation, error) {
+	start := time.Now()
+	conversation := new(Conversation)
+	err := r.db.NewSelect().Model(conversation).Where("id = ?", id).Scan(ctx)
+	r.metrics.Database.RecordQuery(ctx, "select", "conversations", time.Since(start), err)
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrConversationNotFound
+		}
+		return nil, err
+	}
+	return conversation, r.attachParticipants(ctx, r.db, conversation)
+}
+
+func (r *repository) ListConversations(ctx context.Context, email string, projectID int) ([]*Conversation, error) {
+	start := time.Now()
+	conversations := []*Conversation{}
+	query := r.db.NewSelect().
+		Model(&conversations).
+		Order("cv.last_message_at DESC", "cv.id DESC")
+	if email != "" {
+		query.Where("EXISTS (?)", r.db.NewSelect().
+			Model((*Participant)(nil)).
+			ColumnExpr("1").
+			Where("cp.conversation_id = cv.id AND cp.email = ?", email))
+	}
+	if projectID != 0 {
+		query.Where("cv.project_id = ?", projectID)
+	}
+	err := query.Scan(ctx)
+	r.metrics.Database.RecordQuery(ctx, "select", "conversations", time.Since(start), err)
+
+	if err != nil {
+		return nil, err
+	}
+	return conversations, r.attachParticipants(ctx, r.db, conversations...)
+}
+
+func (r *repository) ProjectExists(ctx context.Context, projectID int) (bool, error) {
+	start := time.Now()
+	exists, err := r.db.NewSelect().
+		Model((*project.Project)(nil)).
+		Where("id = ?", projectID).
+		Exists(ctx)
+	r.metrics.Database.RecordQuery(ctx, "select", "projects", time.Since(start), err)
+
+	return exists, err
+}
+
+// IsParticipant reports whether the email participates in the conversation,
+// for direct conversations that only admit their participants
+func (r *repository) IsParticipant(ctx context.Context, conversationID int, email string) (bool, error) {
+	start := time.Now()
+	exists, err := r.db.NewSelect().
+		Model((*Participant)(nil)).
+		Where("conversation_id = ? AND email = ?", conversationID, email).
+		Exists(ctx)
+	r.metrics.Database.RecordQuery(ctx, "select", "conversation_participants", time.Since(start), err)
+
+	return exists, err
+}
+
+func (r *repository) MarkRead(ctx context.Context, messageID int, email string, readAt time.Time) (bool, error) {
+	start := time.Now()
+	result, err := r.db.NewInsert().
+		Model(&Read{MessageID: messageID, Email: email, ReadAt: readAt}).
+		On("CONFLICT DO NOTHING").
+		Exec(ctx)
+	r.metrics.Database.RecordQuery(ctx, "insert", "message_reads", time.Since(start), err)
+
+	if err != nil {
+		return false, err
+	}
+	rowsAffected, err := result.RowsAffected()
+	return rowsAffected > 0, err
+}
+
+func (r *repository) MarkReadUpTo(ctx context.Context, email string, upTo time.Time, conversationID int, readAt time.Time) (int, error) {
+	start := time.Now()
+	result, err := r.db.NewRaw(`
+		INSERT INTO message_reads (message_id, email, read_at)
+		SELECT m.id, cp.email, ? FROM messages AS m
+		JOIN conversation_participants AS cp ON cp.conversation_id = m.conversation_id AND cp.email = ?
+		WHERE m.created_at <= ? AND m.email <> cp.email AND (? = 0 OR m.conversation_id = ?)
+		ON CONFLICT DO NOTHING`,
+		readAt, email, upTo, conversationID, conversationID).
+		Exec(ctx)
+	r.metrics.Database.RecordQuery(ctx, "insert", "message_reads", time.Since(start), err)
+
+	if err != nil {
+		return 0, err
+	}
+	rowsAffected, err := result.RowsAffected()
+	return int(rowsAffected), err
+}
+
+func (r *repository) UnreadCounts(ctx context.Context, email string) ([]UnreadCount, error) {
+	start := time.Now()
+	counts := []UnreadCount{}
+	err := r.db.NewSelect().
+		Model((*Message)(nil)).
+		ColumnExpr("m.conversation_id, count(*) AS unread").
+		Join("JOIN conversation_participants AS cp ON cp.conversation_id = m.conversation_id AND cp.email = ?", email).
+		Where("m.email <> cp.email").
+		Where("NOT EXISTS (SELECT 1 FROM message_reads AS mr WHERE mr.message_id = m.id AND mr.email = cp.email)").
+		Group("m.conversation_id").
+		Order("m.conversation_id").
+		Scan(ctx, &counts)
+	r.metrics.Database.RecordQuery(ctx, "select", "messages", time.Since(start), err)
+
+	if err != nil {
+		return nil, err
+	}
+	return counts, nil
+}
+
+func (r *repository) Recipients(ctx context.Context, message *Message) ([]string, []int, error) {
+	emails := []string{message.Email}
+	if message.ConversationID != 0 {
+		start := time.Now()
+		var participants []string
+		err := r.db.NewSelect().
+			Model((*Participant)(nil)).
+			Column("email").
+			Where("conversation_id = ? AND email <> ?", message.ConversationID, message.Email).
+			Order("email").
+			Scan(ctx, &participants)
+		r.metrics.Database.RecordQuery(ctx, "select", "conversation_participants", time.Since(start), err)
+
+		if err != nil {
+			return nil, nil, err
+		}
+		emails = append(emails, participants...)
+	}
+
+	var studentIDs []int
+	if message.TeamID != 0 {
+		start := time.Now()
+		err := r.db.NewSelect().
+			Model((*project.ProjectMember)(nil)).
+			Column("student_id").
+			Where("team_id = ?", message.TeamID).
+			Order("student_id").
+			Scan(ctx, &studentIDs)
+		r.metrics.Database.RecordQuery(ctx, "select", "project_members", time.Since(start), err)
+
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+	return emails, studentIDs, nil
+}
+
+// placeInThread places a message with checkPost, adds the sender to the
+// participants of a project conversation, and records the conversation's
+// activity
+func (r *repository) placeInThread(ctx context.Context, tx bun.Tx, message *Message) error {
+	conversation, err := r.checkPost(ctx, tx, message)
+	if err != nil || conversation == nil {
+		return err
+	}
+
+	if !conversation.Direct() {
+		start := time.Now()
+		_, err = tx.NewInsert().
+			Model(&Participant{ConversationID: conversation.ID, Email: message.Email}).
+			On("CONFLICT DO NOTHING").
+			Exec(ctx)
+		r.metrics.Database.RecordQuery(ctx, "insert", "conversation_participants", time.Since(start), err)
+
+		if err != nil {
+			return err
+		}
+	}
+
+	start := time.Now()
+	_, err = tx.NewUpdate().
+		Model((*Conversation)(nil)).
+		Set("last_message_at = current_timestamp").
+		Where("id = ?", conversation.ID).
+		Exec(ctx)
+	r.metrics.Database.RecordQuery(ctx, "update", "conversations", time.Since(start), err)
+
+	return err
+}
+
+// checkPost fills in the conversation and thread root of a message from its
+// parent and checks that the sender may post to the conversation: as a
+// participant of a direct one, or as a member of the project of another. It
+// returns the conversation, nil for a message outside of one.
+func (r *repository) checkPost(ctx context.Context, db bun.IDB, message *Message) (*Conversation, error) {
+	if message.ParentID != 0 {
+		start := time.Now()
+		parent := new(Message)
+		err := db.NewSelect().
+			Model(parent).
+			Column("id", "conversation_id", "thread_root_id").
+			Where("id = ?", message.ParentID).
+			Scan(ctx)
+		r.metrics.Database.RecordQuery(ctx, "select", "messages", time.Since(start), err)
+
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("%w: reply to %d", ErrMessageNotFound, message.ParentID)
+		}
+		if err != nil {
+			return nil, err
+		}
+		if message.ConversationID != 0 && message.ConversationID != parent.ConversationID {
+			return nil, fmt.Errorf("%w: message %d is not part of conversation %d", ErrInvalidInput, parent.ID, message.ConversationID)
+		}
+		message.ConversationID = parent.ConversationID
+		message.ThreadRootID = parent.ThreadRootID
+		if message.ThreadRootID == 0 {
+			message.ThreadRootID = parent.ID
+		}
+	}
+	if message.ConversationID == 0 {
+		return nil, nil
+	}
+
+	start := time.Now()
+	conversation := new(Conversation)
+	err := db.NewSelect().
+		Model(conversation).
+		Where("id = ?", message.ConversationID).
+		Scan(ctx)
+	r.metrics.Database.RecordQuery(ctx, "select", "conversations", time.Since(start), err)
+
+	if err == sql.ErrNoRows {
+		return nil, ErrConversationNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if conversation.Direct() {
+		start = time.Now()
+		participant, err := db.NewSelect().
+			Model((*Participant)(nil)).
+			Where("conversation_id = ? AND email = ?", conversation.ID, message.Email).
+			Exists(ctx)
+		r.metrics.Database.RecordQuery(ctx, "select", "conversation_participants", time.Since(start), err)
+
+		if err != nil {
+			return nil, err
+		}
+		if !participant {
+			return nil, ErrNotParticipant
+		}
+		return conversation, nil
+	}
+
+	member, err := r.isMember(ctx, db, conversation.ProjectID, 0, message.StudentID)
+	if err != nil {
+		return nil, err
+	}
+	if !member {
+		return nil, ErrNotMember
+	}
+	return conversation, nil
+}
+
+func (r *repository) CheckPost(ctx context.Context, message *Message) error {
+	_, err := r.checkPost(ctx, r.db, message)
+	return err
+}
+
+func (r *repository) IsMember(ctx context.Context, projectID, teamID, studentID int) (bool, error) {
+	return r.isMember(ctx, r.db, projectID, teamID, studentID)
+}
+
+func (r *repository) isMember(ctx context.Context, db bun.IDB, projectID, teamID, studentID int) (bool, error) {
+	if studentID == 0 {
+		return false, nil
+	}
+	start := time.Now()
+	query := db.NewSelect().
+		Model((*project.ProjectMember)(nil)).
+		Where("student_id = ?", studentID)
+	if projectID != 0 {
+		query.Where("project_id = ?", projectID)
+	}
+	if teamID != 0 {
+		query.Where("team_id = ?", teamID)
+	}
+	member, err := query.Exists(ctx)
+	r.metrics.Database.RecordQuery(ctx, "select", "project_members", time.Since(start), err)
+
+	return member, err
+}
+
+// attachParticipants fills in the sorted participants of each conversation
+func (r *repository) attachParticipants(ctx context.Context, db bun.IDB, conversations ...*Conversation) error {
+	if len(conversations) == 0 {
+		return nil
+	}
+	byID := make(map[int]*Conversation, len(conversations))
+	ids := make([]int, len(conversations))
+	for i, conversation := range conversations {
+		conversation.Participants = []string{}
+		byID[conversation.ID] = conversation
+		ids[i] = conversation.ID
+	}
+
+	start := time.Now()
+	var participants []Participant
+	err := db.NewSelect().
+		Model(&participants).
+		Where("conversation_id IN (?)", bun.In(ids)).
+		Order("email").
+		Scan(ctx)
+	r.metrics.Database.RecordQuery(ctx, "select", "conversation_participants", time.Since(start), err)
+
+	if err != nil {
+		return err
+	}
+	for _, p := range participants {
+		conversation := byID[p.ConversationID]
+		conversation.Participants = append(conversation.Participants, p.Email)
+	}
+	return nil
 }
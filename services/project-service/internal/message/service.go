@@ -3,30 +3,440 @@ package message
 import (
 	"context"
 	"errors"
+	"strings"
+	"time"
+
+	"grud/common/search"
+	"project-service/internal/project"
 )
 
 var (
-	ErrMessageNotFound = errors.New("message not found")
-	ErrInvalidInput    = errors.New("invalid input")
+	ErrMessageNotFound      = errors.New("message not found")
+	ErrInvalidInput         = errors.New("invalid input")
+	ErrConversationNotFound = errors.New("conversation not found")
+	ErrNotParticipant       = errors.New("sender is not a participant of the conversation")
+	ErrNotMember            = errors.New("sender is not a member of the project")
+	ErrNotReader            = errors.New("viewer may not read the message")
+	ErrNotAuthor            = errors.New("only the author can change the message")
+	ErrEditWindowClosed     = errors.New("message can no longer be edited")
+	ErrMessageDeleted       = errors.New("message was deleted")
+	ErrInvalidPageToken     = errors.New("invalid page token")
 )
 
+// DefaultEditWindow is how long after sending a message its author may edit it
+const DefaultEditWindow = 15 * time.Minute
+
+// Viewer is the student asking to read messages
+type Viewer struct {
+	Email     string
+	StudentID int
+}
+
 type Service interface {
-	GetMessagesByEmail(ctx context.Context, email string) ([]*Message, error)
+	// GetMessagesByEmail returns the messages matching the email, the team or
+	// both. At least one of them is required.
+	GetMessagesByEmail(ctx context.Context, email string, teamID int) ([]*Message, error)
+	// ListMessages returns a page of the messages matching the filter,
+	// newest first
+	ListMessages(ctx context.Context, opts ListOptions) (*ListResult, error)
+	// ExportMessages calls fn with consecutive batches of the matching
+	// messages the filter's viewer may read
+	ExportMessages(ctx context.Context, f ExportFilter, fn func([]*Message) error) error
+	// SearchMessages returns up to limit messages matching the full-text query
+	// that viewer may read, best match first. Every word of query must match a
+	// word prefix.
+	SearchMessages(ctx context.Context, query string, limit int, viewer Viewer) ([]SearchResult, error)
+	// CreateConversation starts a conversation within a project, whose
+	// participants are whoever posts to it, or between the creator and a
+	// recipient
+	CreateConversation(ctx context.Context, n NewConversation) (*Conversation, error)
+	// ListConversations returns the conversations the email participates in,
+	// those of the project, or both, most recently active first. At least one
+	// of them is required.
+	ListConversations(ctx context.Context, email string, projectID int) ([]*Conversation, error)
+	// GetThread returns the thread of any of its messages, if the viewer may
+	// read it. It returns ErrNotReader otherwise.
+	GetThread(ctx context.Context, messageID int, viewer Viewer) (*Thread, error)
+	// CheckPost runs the checks a message for a conversation or a thread has
+	// to pass to be saved, without saving it
+	CheckPost(ctx context.Context, message *Message) error
+	// EditMessage replaces the text of a message, for its author within the
+	// edit window. The previous text is kept in the message's history.
+	EditMessage(ctx context.Context, e EditEvent) (*Message, error)
+	// DeleteMessage turns a message of its author into a tombstone. Its text
+	// is dropped, its history kept.
+	DeleteMessage(ctx context.Context, e DeleteEvent) (*Message, error)
+	// GetEditHistory returns a message with its previous texts, oldest first,
+	// if the viewer may read its thread. The history of a tombstone is only
+	// returned to its author.
+	GetEditHistory(ctx context.Context, messageID int, viewer Viewer) (*Message, []*Edit, error)
+	// MarkRead records that a student read messages and publishes a
+	// ReadEvent. Marking messages again is a no-op.
+	MarkRead(ctx context.Context, r MarkRead) (*ReadEvent, error)
+	// GetUnreadCounts returns the unread messages per conversation of the
+	// email, skipping conversations without any
+	GetUnreadCounts(ctx context.Context, email string) ([]UnreadCount, error)
+}
+
+// Publisher sends an event to NATS. It is implemented by *messaging.Producer.
+type Publisher interface {
+	SendMessage(ctx context.Context, value interface{}) error
 }
 
 type service struct {
-	repo Repository
+	repo       Repository
+	publisher  Publisher
+	editWindow time.Duration
 }
 
-func NewService(repo Repository) Service {
+// NewService creates the message service. publisher may be nil, in which
+// case no read events are published. editWindow <= 0 means
+// DefaultEditWindow.
+func NewService(repo Repository, publisher Publisher, editWindow time.Duration) Service {
+	if editWindow <= 0 {
+		editWindow = DefaultEditWindow
+	}
 	return &service{
-		repo: repo,
+		repo:       repo,
+		publisher:  publisher,
+		editWindow: editWindow,
+	}
+}
+
+func (s *service) GetMessagesByEmail(ctx context.Context, email string, teamID int) ([]*Message, error) {
+	if teamID < 0 || (email == "" && teamID == 0) {
+		return nil, ErrInvalidInput
+	}
+	return s.repo.GetByEmail(ctx, email, teamID)
+}
+
+func (s *service) ListMessages(ctx context.Context, opts ListOptions) (*ListResult, error) {
+	if opts.PageSize < 0 || opts.ProjectID < 0 || opts.TeamID < 0 {
+		return nil, ErrInvalidInput
+	}
+	if opts.PageSize == 0 {
+		opts.PageSize = DefaultPageSize
+	}
+	if opts.PageSize > MaxPageSize {
+		opts.PageSize = MaxPageSize
+	}
+	if !opts.CreatedAfter.IsZero() && !opts.CreatedBefore.IsZero() && !opts.CreatedAfter.Before(opts.CreatedBefore) {
+		return nil, ErrInvalidInput
+	}
+	fp := fingerprint(opts.ListFilter)
+
+	q := ListQuery{
+		ListFilter: opts.ListFilter,
+		Limit:      opts.PageSize + 1, // one extra row tells us whether there is a next page
+	}
+	if opts.PageToken != "" {
+		token, err := DecodePageToken(opts.PageToken)
+		if err != nil {
+			return nil, err
+		}
+		if token.Fingerprint != fp {
+			return nil, ErrInvalidPageToken
+		}
+		q.After = token
+	}
+
+	messages, err := s.repo.List(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &ListResult{Messages: messages}
+	if len(messages) > opts.PageSize {
+		result.Messages = messages[:opts.PageSize]
+		last := result.Messages[opts.PageSize-1]
+		result.NextPageToken = (&PageToken{Fingerprint: fp, ID: last.ID, Time: last.CreatedAt}).Encode()
+	}
+	return result, nil
+}
+
+func (s *service) ExportMessages(ctx context.Context, f ExportFilter, fn func([]*Message) error) error {
+	if strings.TrimSpace(f.Viewer.Email) == "" {
+		return ErrInvalidInput
+	}
+	if !f.CreatedAfter.IsZero() && !f.CreatedBefore.IsZero() && !f.CreatedAfter.Before(f.CreatedBefore) {
+		return ErrInvalidInput
+	}
+	return s.repo.Export(ctx, f, fn)
+}
+
+func (s *service) SearchMessages(ctx context.Context, query string, limit int, viewer Viewer) ([]SearchResult, error) {
+	tsquery := search.PrefixQuery(query)
+	if tsquery == "" || limit < 0 || strings.TrimSpace(viewer.Email) == "" {
+		return nil, ErrInvalidInput
+	}
+	if limit == 0 {
+		limit = search.DefaultLimit
+	}
+	return s.repo.Search(ctx, tsquery, min(limit, search.MaxLimit), viewer)
+}
+
+func (s *service) CreateConversation(ctx context.Context, n NewConversation) (*Conversation, error) {
+	if err := n.Validate(); err != nil {
+		return nil, err
+	}
+
+	participants := []string{n.CreatedBy}
+	if n.ProjectID != 0 {
+		exists, err := s.repo.ProjectExists(ctx, n.ProjectID)
+		if err != nil {
+			return nil, err
+		}
+		if !exists {
+			return nil, project.ErrProjectNotFound
+		}
+	} else {
+		participants = append(participants, n.Recipient)
+	}
+
+	conversation := &Conversation{
+		ProjectID: n.ProjectID,
+		Subject:   n.Subject,
+		CreatedBy: n.CreatedBy,
+	}
+	if err := s.repo.CreateConversation(ctx, conversation, participants); err != nil {
+		return nil, err
+	}
+	return conversation, nil
+}
+
+func (s *service) ListConversations(ctx context.Context, email string, projectID int) ([]*Conversation, error) {
+	if projectID < 0 || (email == "" && projectID == 0) {
+		return nil, ErrInvalidInput
+	}
+	return s.repo.ListConversations(ctx, email, projectID)
+}
+
+func (s *service) GetThread(ctx context.Context, messageID int, viewer Viewer) (*Thread, error) {
+	msg, err := s.repo.GetByID(ctx, messageID)
+	if err != nil {
+		return nil, err
+	}
+	root, err := s.readableRoot(ctx, msg, viewer)
+	if err != nil {
+		return nil, err
+	}
+
+	replies, err := s.repo.GetReplies(ctx, root.ID)
+	if err != nil {
+		return nil, err
+	}
+	thread := &Thread{Root: root, Replies: replies}
+	if root.ConversationID != 0 {
+		if thread.Conversation, err = s.repo.GetConversation(ctx, root.ConversationID); err != nil {
+			return nil, err
+		}
+	}
+	return thread, nil
+}
+
+// readableRoot returns the root of the message's thread, checking that the
+// viewer may read it. Replies share the conversation or team of their root.
+func (s *service) readableRoot(ctx context.Context, msg *Message, viewer Viewer) (*Message, error) {
+	root := msg
+	if msg.ThreadRootID != 0 {
+		var err error
+		if root, err = s.repo.GetByID(ctx, msg.ThreadRootID); err != nil {
+			return nil, err
+		}
+	}
+	if err := s.checkReader(ctx, root, viewer); err != nil {
+		return nil, err
+	}
+	return root, nil
+}
+
+// checkReader returns ErrNotReader unless the viewer may read the message:
+// its author, a participant of its direct conversation, or a member of the
+// project of its other conversation or of the team it was posted to.
+// Messages outside of conversations and teams are open to all.
+func (s *service) checkReader(ctx context.Context, msg *Message, viewer Viewer) error {
+	if strings.TrimSpace(viewer.Email) == "" {
+		return ErrInvalidInput
+	}
+	if strings.EqualFold(msg.Email, viewer.Email) {
+		return nil
+	}
+
+	var allowed bool
+	switch {
+	case msg.ConversationID != 0:
+		conversation, err := s.repo.GetConversation(ctx, msg.ConversationID)
+		if err != nil {
+			return err
+		}
+		if conversation.Direct() {
+			allowed, err = s.repo.IsParticipant(ctx, conversation.ID, viewer.Email)
+		} else {
+			allowed, err = s.repo.IsMember(ctx, conversation.ProjectID, 0, viewer.StudentID)
+		}
+		if err != nil {
+			return err
+		}
+	case msg.TeamID != 0:
+		var err error
+		if allowed, err = s.repo.IsMember(ctx, 0, msg.TeamID, viewer.StudentID); err != nil {
+			return err
+		}
+	default:
+		return nil
+	}
+	if !allowed {
+		return ErrNotReader
+	}
+	return nil
+}
+
+func (s *service) CheckPost(ctx context.Context, message *Message) error {
+	if strings.TrimSpace(message.Email) == "" || (message.ConversationID == 0 && message.ParentID == 0) ||
+		message.ConversationID < 0 || message.ParentID < 0 {
+		return ErrInvalidInput
+	}
+	return s.repo.CheckPost(ctx, message)
+}
+
+func (s *service) EditMessage(ctx context.Context, e EditEvent) (*Message, error) {
+	if err := e.Validate(); err != nil {
+		return nil, err
+	}
+	msg, err := s.authored(ctx, e.MessageID, e.Email)
+	if err != nil {
+		return nil, err
+	}
+
+	// The edit is timed when it got here: a time carried by the event could
+	// be backdated past the window
+	editedAt := time.Now().UTC()
+	if editedAt.Sub(msg.CreatedAt) > s.editWindow {
+		return nil, ErrEditWindowClosed
+	}
+	if e.Message == msg.Message {
+		return msg, nil
+	}
+	return s.repo.Edit(ctx, e.MessageID, e.Message, editedAt)
+}
+
+func (s *service) DeleteMessage(ctx context.Context, e DeleteEvent) (*Message, error) {
+	if err := e.Validate(); err != nil {
+		return nil, err
+	}
+	if _, err := s.authored(ctx, e.MessageID, e.Email); err != nil {
+		return nil, err
+	}
+	return s.repo.Delete(ctx, e.MessageID, e.DeletedAt)
+}
+
+// authored returns a message that email wrote and that is not a tombstone
+func (s *service) authored(ctx context.Context, messageID int, email string) (*Message, error) {
+	msg, err := s.repo.GetByID(ctx, messageID)
+	if err != nil {
+		return nil, err
+	}
+	if !strings.EqualFold(msg.Email, email) {
+		return nil, ErrNotAuthor
+	}
+	if msg.Deleted() {
+		return nil, ErrMessageDeleted
+	}
+	return msg, nil
+}
+
+func (s *service) GetEditHistory(ctx context.Context, messageID int, viewer Viewer) (*Message, []*Edit, error) {
+	if messageID <= 0 {
+		return nil, nil, ErrInvalidInput
+	}
+	msg, err := s.repo.GetByID(ctx, messageID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if _, err := s.readableRoot(ctx, msg, viewer); err != nil {
+		return nil, nil, err
+	}
+	if msg.Deleted() && !strings.EqualFold(msg.Email, viewer.Email) {
+		return msg, nil, nil
+	}
+	edits, err := s.repo.ListEdits(ctx, messageID)
+	if err != nil {
+		return nil, nil, err
+	}
+	return msg, edits, nil
+}
+
+func (s *service) MarkRead(ctx context.Context, r MarkRead) (*ReadEvent, error) {
+	if err := r.Validate(); err != nil {
+		return nil, err
+	}
+
+	event := &ReadEvent{
+		Email:          r.Email,
+		MessageID:      r.MessageID,
+		ConversationID: r.ConversationID,
+		ReadAt:         time.Now().UTC(),
+	}
+	if r.MessageID != 0 {
+		marked, err := s.markMessageRead(ctx, r.MessageID, r.Email, event.ReadAt)
+		if err != nil {
+			return nil, err
+		}
+		if marked {
+			event.Marked = 1
+		}
+	} else {
+		marked, err := s.repo.MarkReadUpTo(ctx, r.Email, r.UpTo, r.ConversationID, event.ReadAt)
+		if err != nil {
+			return nil, err
+		}
+		event.Marked = marked
+		event.UpTo = &r.UpTo
+	}
+
+	// Sent even when nothing was marked, so a retry after a failed publish
+	// still reaches the other sessions
+	if s.publisher != nil {
+		if err := s.publisher.SendMessage(ctx, event); err != nil {
+			return nil, err
+		}
+	}
+	return event, nil
+}
+
+// markMessageRead marks a single message read. The sender's own messages are
+// never unread, and messages of a direct conversation can only be read by its
+// participants.
+func (s *service) markMessageRead(ctx context.Context, messageID int, email string, readAt time.Time) (bool, error) {
+	msg, err := s.repo.GetByID(ctx, messageID)
+	if err != nil {
+		return false, err
+	}
+	if strings.EqualFold(msg.Email, email) {
+		return false, nil
+	}
+	if msg.ConversationID != 0 {
+		conversation, err := s.repo.GetConversation(ctx, msg.ConversationID)
+		if err != nil {
+			return false, err
+		}
+		if conversation.Direct() {
+			participant, err := s.repo.IsParticipant(ctx, conversation.ID, email)
+			if err != nil {
+				return false, err
+			}
+			if !participant {
+				return false, ErrNotParticipant
+			}
+		}
 	}
+	return s.repo.MarkRead(ctx, messageID, email, readAt)
 }
 
-func (s *service) GetMessagesByEmail(ctx context.Context, email string) ([]*Message, error) {
+func (s *service) GetUnreadCounts(ctx context.Context, email string) ([]UnreadCount, error) {
+	email = strings.TrimSpace(email)
 	if email == "" {
 		return nil, ErrInvalidInput
 	}
-	return s.repo.GetByEmail(ctx, email)
+	return s.repo.UnreadCounts(ctx, email)
 }
@@ -3,7 +3,9 @@ package messaging
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"log/slog"
+	"time"
 
 	"project-service/internal/message"
 	"project-service/internal/metrics"
@@ -13,16 +15,36 @@ import (
 	"go.opentelemetry.io/otel/propagation"
 )
 
+// handleTimeout bounds the work done for one received message, including
+// announcing it
+const handleTimeout = 30 * time.Second
+
 type Consumer struct {
 	conn       *nats.Conn
-	sub        *nats.Subscription
-	subject    string
+	subs       []*nats.Subscription
+	subjects   Subjects
 	repository message.Repository
+	service    message.Service
+	publisher  message.Publisher
 	logger     *slog.Logger
 	metrics    *metrics.Metrics
 }
 
-func NewConsumer(url string, subject string, repository message.Repository, logger *slog.Logger, metrics *metrics.Metrics) (*Consumer, error) {
+// Subjects are the subjects a Consumer listens on. Edited and Deleted may be
+// empty to leave messages as they were sent.
+type Subjects struct {
+	// Messages carries new messages as message.MessageEvent
+	Messages string
+	// Edited carries message.EditEvent and Deleted message.DeleteEvent
+	Edited  string
+	Deleted string
+}
+
+// NewConsumer creates a consumer saving the messages published on the
+// subjects' Messages and applying the edits and deletes of the others
+// through service. Each saved message is announced through publisher as a
+// message.CreatedEvent; publisher may be nil.
+func NewConsumer(url string, subjects Subjects, repository message.Repository, service message.Service, publisher message.Publisher, logger *slog.Logger, metrics *metrics.Metrics) (*Consumer, error) {
 	nc, err := nats.Connect(url)
 	if err != nil {
 		return nil, err
@@ -30,60 +52,156 @@ func NewConsumer(url string, subject string, repository message.Repository, logg
 
 	return &Consumer{
 		conn:       nc,
-		subject:    subject,
+		subjects:   subjects,
 		repository: repository,
+		service:    service,
+		publisher:  publisher,
 		logger:     logger,
 		metrics:    metrics,
 	}, nil
 }
 
 func (c *Consumer) Start(ctx context.Context) error {
-	sub, err := c.conn.Subscribe(c.subject, func(msg *nats.Msg) {
-		// Extract trace context from NATS headers
-		msgCtx := otel.GetTextMapPropagator().Extract(context.Background(), propagation.HeaderCarrier(msg.Header))
+	handlers := []struct {
+		subject string
+		handle  func(context.Context, *nats.Msg)
+	}{
+		{c.subjects.Messages, c.save},
+		{c.subjects.Edited, c.edit},
+		{c.subjects.Deleted, c.delete},
+	}
+	for _, h := range handlers {
+		if h.subject == "" {
+			continue
+		}
+		handle := h.handle
+		sub, err := c.conn.Subscribe(h.subject, func(msg *nats.Msg) {
+			// Extract trace context from NATS headers
+			msgCtx := otel.GetTextMapPropagator().Extract(context.Background(), propagation.HeaderCarrier(msg.Header))
+			msgCtx, cancel := context.WithTimeout(msgCtx, handleTimeout)
+			defer cancel()
+
+			c.logger.InfoContext(msgCtx, "received message from NATS", "subject", msg.Subject)
+			handle(msgCtx, msg)
+		})
+		if err != nil {
+			return err
+		}
+		c.subs = append(c.subs, sub)
+		c.logger.Info("NATS consumer started", "subject", h.subject)
+	}
 
-		c.logger.InfoContext(msgCtx, "received message from NATS", "subject", msg.Subject)
+	<-ctx.Done()
+	return ctx.Err()
+}
+
+func (c *Consumer) save(ctx context.Context, msg *nats.Msg) {
+	var event message.MessageEvent
+	if err := json.Unmarshal(msg.Data, &event); err != nil {
+		c.logger.ErrorContext(ctx, "failed to unmarshal message", "error", err)
+		return
+	}
+
+	dbMessage := &message.Message{
+		Email:          event.Email,
+		StudentID:      event.StudentID,
+		Message:        event.Message,
+		TeamID:         event.TeamID,
+		ConversationID: event.ConversationID,
+		ParentID:       event.ReplyTo,
+	}
 
-		var event message.MessageEvent
-		if err := json.Unmarshal(msg.Data, &event); err != nil {
-			c.logger.ErrorContext(msgCtx, "failed to unmarshal message", "error", err)
+	if err := c.repository.Create(ctx, dbMessage); err != nil {
+		if rejected(err) {
+			c.logger.WarnContext(ctx, "dropping message", "error", err,
+				"email", event.Email, "conversation_id", event.ConversationID, "reply_to", event.ReplyTo)
 			return
 		}
+		c.logger.ErrorContext(ctx, "failed to save message to database", "error", err)
+		return
+	}
+
+	// Record metric
+	c.metrics.RecordMessageReceived(ctx)
+
+	c.logger.InfoContext(ctx, "message saved to database",
+		"email", event.Email,
+		"message", event.Message,
+		"id", dbMessage.ID,
+	)
 
-		dbMessage := &message.Message{
-			Email:   event.Email,
-			Message: event.Message,
+	if c.publisher != nil {
+		if err := c.announce(ctx, dbMessage); err != nil {
+			c.logger.ErrorContext(ctx, "failed to announce message", "error", err, "id", dbMessage.ID)
 		}
+	}
+}
 
-		if err := c.repository.Create(msgCtx, dbMessage); err != nil {
-			c.logger.ErrorContext(msgCtx, "failed to save message to database", "error", err)
+func (c *Consumer) edit(ctx context.Context, msg *nats.Msg) {
+	var event message.EditEvent
+	if err := json.Unmarshal(msg.Data, &event); err != nil {
+		c.logger.ErrorContext(ctx, "failed to unmarshal edit", "error", err)
+		return
+	}
+
+	if _, err := c.service.EditMessage(ctx, event); err != nil {
+		if rejected(err) {
+			c.logger.WarnContext(ctx, "dropping edit", "error", err, "email", event.Email, "id", event.MessageID)
 			return
 		}
+		c.logger.ErrorContext(ctx, "failed to edit message", "error", err, "id", event.MessageID)
+		return
+	}
+	c.logger.InfoContext(ctx, "message edited", "email", event.Email, "id", event.MessageID)
+}
 
-		// Record metric
-		c.metrics.RecordMessageReceived(msgCtx)
+func (c *Consumer) delete(ctx context.Context, msg *nats.Msg) {
+	var event message.DeleteEvent
+	if err := json.Unmarshal(msg.Data, &event); err != nil {
+		c.logger.ErrorContext(ctx, "failed to unmarshal delete", "error", err)
+		return
+	}
 
-		c.logger.InfoContext(msgCtx, "message saved to database",
-			"email", event.Email,
-			"message", event.Message,
-			"id", dbMessage.ID,
-		)
-	})
+	if _, err := c.service.DeleteMessage(ctx, event); err != nil {
+		if rejected(err) {
+			c.logger.WarnContext(ctx, "dropping delete", "error", err, "email", event.Email, "id", event.MessageID)
+			return
+		}
+		c.logger.ErrorContext(ctx, "failed to delete message", "error", err, "id", event.MessageID)
+		return
+	}
+	c.logger.InfoContext(ctx, "message deleted", "email", event.Email, "id", event.MessageID)
+}
 
+// announce publishes a CreatedEvent for the saved message
+func (c *Consumer) announce(ctx context.Context, msg *message.Message) error {
+	emails, studentIDs, err := c.repository.Recipients(ctx, msg)
 	if err != nil {
 		return err
 	}
+	return c.publisher.SendMessage(ctx, message.CreatedEvent{
+		Message:    msg,
+		Recipients: emails,
+		StudentIDs: studentIDs,
+	})
+}
 
-	c.sub = sub
-	c.logger.Info("NATS consumer started", "subject", c.subject)
-
-	<-ctx.Done()
-	return ctx.Err()
+// rejected reports whether the event can never be applied, such as a reply to
+// a message that does not exist or an edit by someone else
+func rejected(err error) bool {
+	return errors.Is(err, message.ErrMessageNotFound) ||
+		errors.Is(err, message.ErrConversationNotFound) ||
+		errors.Is(err, message.ErrNotParticipant) ||
+		errors.Is(err, message.ErrNotMember) ||
+		errors.Is(err, message.ErrInvalidInput) ||
+		errors.Is(err, message.ErrNotAuthor) ||
+		errors.Is(err, message.ErrEditWindowClosed) ||
+		errors.Is(err, message.ErrMessageDeleted)
 }
 
 func (c *Consumer) Close() error {
-	if c.sub != nil {
-		c.sub.Unsubscribe()
+	for _, sub := range c.subs {
+		sub.Unsubscribe()
 	}
 	c.conn.Close()
 	return nil
@@ -3,8 +3,10 @@ package messaging_test
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"log/slog"
 	"os"
+	"sync"
 	"testing"
 	"time"
 
@@ -27,16 +29,20 @@ func TestNATSConsumerIntegration(t *testing.T) {
 	pgContainer := testdb.SetupSharedPostgres(t)
 	defer pgContainer.Cleanup(t)
 
-	pgContainer.RunMigrations(t, (*message.Message)(nil))
+	pgContainer.RunMigrations(t, (*message.Message)(nil), (*message.Conversation)(nil), (*message.Participant)(nil), (*message.Read)(nil), (*message.Edit)(nil))
 
 	natsURL := natsContainer.URL
 	logger := slog.New(slog.NewTextHandler(os.Stderr, nil))
 	subject := "test.messages"
+	subjects := messaging.Subjects{Messages: subject, Edited: "test.messages.edited", Deleted: "test.messages.deleted"}
 	mockServiceMetrics := projectmetrics.NewMock()
 	mockRepoMetrics := commonmetrics.NewMock()
 	repo := message.NewRepository(pgContainer.DB, mockRepoMetrics)
+	announced := &recordingPublisher{}
 
-	consumer, _ := messaging.NewConsumer(natsURL, subject, repo, logger, mockServiceMetrics)
+	service := message.NewService(repo, nil, time.Minute)
+
+	consumer, _ := messaging.NewConsumer(natsURL, subjects, repo, service, announced, logger, mockServiceMetrics)
 	startConsumer(consumer)
 	defer func() { _ = consumer.Close() }()
 	time.Sleep(100 * time.Millisecond)
@@ -63,7 +69,7 @@ func TestNATSConsumerIntegration(t *testing.T) {
 		time.Sleep(200 * time.Millisecond)
 
 		// Verify message was stored
-		messages, err := repo.GetByEmail(context.Background(), "user@example.com")
+		messages, err := repo.GetByEmail(context.Background(), "user@example.com", 0)
 		require.NoError(t, err)
 		require.Len(t, messages, 1)
 		assert.Equal(t, "user@example.com", messages[0].Email)
@@ -92,11 +98,115 @@ func TestNATSConsumerIntegration(t *testing.T) {
 		// Wait for all messages to be processed
 		time.Sleep(300 * time.Millisecond)
 
-		messages, err := repo.GetByEmail(context.Background(), "user@example.com")
+		messages, err := repo.GetByEmail(context.Background(), "user@example.com", 0)
 		require.NoError(t, err)
 		assert.Len(t, messages, 5)
 	})
 
+	t.Run("Consumer_ThreadedReply", func(t *testing.T) {
+		testdb.CleanupTables(t, pgContainer.DB, "messages", "conversations", "conversation_participants")
+		ctx := context.Background()
+
+		conversation := &message.Conversation{CreatedBy: "alice@example.com", Subject: "Deadline"}
+		require.NoError(t, repo.CreateConversation(ctx, conversation, []string{"alice@example.com", "bob@example.com"}))
+
+		nc, err := nats.Connect(natsURL)
+		require.NoError(t, err)
+		defer nc.Close()
+
+		publish := func(event message.MessageEvent) {
+			data, err := json.Marshal(event)
+			require.NoError(t, err)
+			require.NoError(t, nc.Publish(subject, data))
+		}
+
+		publish(message.MessageEvent{Email: "alice@example.com", Message: "Friday?", ConversationID: conversation.ID})
+		time.Sleep(200 * time.Millisecond)
+		roots, err := repo.GetByEmail(ctx, "alice@example.com", 0)
+		require.NoError(t, err)
+		require.Len(t, roots, 1)
+
+		publish(message.MessageEvent{Email: "bob@example.com", Message: "Works for me", ReplyTo: roots[0].ID})
+		// Not a participant of the direct conversation, so dropped
+		publish(message.MessageEvent{Email: "carol@example.com", Message: "Me too", ReplyTo: roots[0].ID})
+		time.Sleep(200 * time.Millisecond)
+
+		replies, err := repo.GetReplies(ctx, roots[0].ID)
+		require.NoError(t, err)
+		require.Len(t, replies, 1)
+		assert.Equal(t, "bob@example.com", replies[0].Email)
+		assert.Equal(t, conversation.ID, replies[0].ConversationID)
+		assert.Equal(t, roots[0].ID, replies[0].ParentID)
+
+		// The reply is announced to both participants
+		events := announced.created()
+		require.NotEmpty(t, events)
+		last := events[len(events)-1]
+		assert.Equal(t, replies[0].ID, last.Message.ID)
+		assert.Equal(t, []string{"bob@example.com", "alice@example.com"}, last.Recipients)
+	})
+
+	t.Run("Consumer_EditAndDelete", func(t *testing.T) {
+		testdb.CleanupTables(t, pgContainer.DB, "messages", "message_edits")
+		ctx := context.Background()
+
+		nc, err := nats.Connect(natsURL)
+		require.NoError(t, err)
+		defer nc.Close()
+
+		publish := func(subject string, event interface{}) {
+			data, err := json.Marshal(event)
+			require.NoError(t, err)
+			require.NoError(t, nc.Publish(subject, data))
+		}
+
+		sent := &message.Message{Email: "alice@example.com", Message: "Meet at 10"}
+		require.NoError(t, repo.Create(ctx, sent))
+		old := &message.Message{Email: "alice@example.com", Message: "Meet at 9", CreatedAt: time.Now().Add(-time.Hour)}
+		require.NoError(t, repo.Create(ctx, old))
+		alice := message.Viewer{Email: "alice@example.com", StudentID: 1}
+
+		publish(subjects.Edited, message.EditEvent{Email: "alice@example.com", MessageID: sent.ID, Message: "Meet at 11"})
+		// Only the author may edit, and only within the window, whatever time
+		// the event claims
+		publish(subjects.Edited, message.EditEvent{Email: "bob@example.com", MessageID: sent.ID, Message: "Cancelled"})
+		publish(subjects.Edited, map[string]interface{}{"email": "alice@example.com", "messageId": old.ID, "message": "Too late", "editedAt": old.CreatedAt})
+		time.Sleep(200 * time.Millisecond)
+
+		unchanged, err := repo.GetByID(ctx, old.ID)
+		require.NoError(t, err)
+		assert.Equal(t, "Meet at 9", unchanged.Message)
+
+		edited, edits, err := service.GetEditHistory(ctx, sent.ID, alice)
+		require.NoError(t, err)
+		assert.Equal(t, "Meet at 11", edited.Message)
+		assert.True(t, edited.Edited())
+		require.Len(t, edits, 1)
+		assert.Equal(t, "Meet at 10", edits[0].Message)
+
+		publish(subjects.Deleted, message.DeleteEvent{Email: "bob@example.com", MessageID: sent.ID})
+		time.Sleep(200 * time.Millisecond)
+		kept, err := repo.GetByID(ctx, sent.ID)
+		require.NoError(t, err)
+		assert.False(t, kept.Deleted())
+
+		publish(subjects.Deleted, message.DeleteEvent{Email: "alice@example.com", MessageID: sent.ID})
+		time.Sleep(200 * time.Millisecond)
+
+		// The tombstone keeps its place and history without text, and only its
+		// author can still read the history
+		tombstone, edits, err := service.GetEditHistory(ctx, sent.ID, alice)
+		require.NoError(t, err)
+		assert.True(t, tombstone.Deleted())
+		assert.Empty(t, tombstone.Message)
+		require.Len(t, edits, 1)
+		assert.Equal(t, "Meet at 10", edits[0].Message)
+
+		_, edits, err = service.GetEditHistory(ctx, sent.ID, message.Viewer{Email: "bob@example.com", StudentID: 2})
+		require.NoError(t, err)
+		assert.Empty(t, edits)
+	})
+
 	t.Run("Consumer_InvalidJSON", func(t *testing.T) {
 		testdb.CleanupTables(t, pgContainer.DB, "messages")
 
@@ -111,12 +221,35 @@ func TestNATSConsumerIntegration(t *testing.T) {
 		time.Sleep(200 * time.Millisecond)
 
 		// No messages should be stored
-		messages, err := repo.GetByEmail(context.Background(), "user@example.com")
+		messages, err := repo.GetByEmail(context.Background(), "user@example.com", 0)
 		require.NoError(t, err)
 		assert.Len(t, messages, 0)
 	})
 }
 
+// recordingPublisher records created events. Like a NATS flush, it refuses
+// contexts without a deadline.
+type recordingPublisher struct {
+	mu     sync.Mutex
+	events []message.CreatedEvent
+}
+
+func (p *recordingPublisher) SendMessage(ctx context.Context, value interface{}) error {
+	if _, ok := ctx.Deadline(); !ok {
+		return errors.New("context has no deadline")
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.events = append(p.events, value.(message.CreatedEvent))
+	return nil
+}
+
+func (p *recordingPublisher) created() []message.CreatedEvent {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return append([]message.CreatedEvent(nil), p.events...)
+}
+
 func startConsumer(consumer *messaging.Consumer) {
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
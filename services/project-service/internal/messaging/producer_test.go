@@ -0,0 +1,61 @@
+package messaging_test
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"os"
+	"testing"
+	"time"
+
+	"grud/testing/testnats"
+	"project-service/internal/messaging"
+
+	"github.com/nats-io/nats.go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProducer(t *testing.T) {
+	natsContainer := testnats.SetupSharedNATS(t)
+	defer natsContainer.Cleanup(t)
+
+	logger := slog.New(slog.NewTextHandler(os.Stderr, nil))
+	producer, err := messaging.NewProducer(natsContainer.URL, "test.producer", logger)
+	require.NoError(t, err)
+	defer producer.Close()
+
+	nc, err := nats.Connect(natsContainer.URL)
+	require.NoError(t, err)
+	defer nc.Close()
+
+	t.Run("ContextWithoutDeadline", func(t *testing.T) {
+		sub, err := nc.SubscribeSync("test.producer")
+		require.NoError(t, err)
+		defer sub.Unsubscribe()
+		require.NoError(t, nc.Flush())
+
+		// Background jobs publish with contexts that never expire
+		err = producer.SendMessage(context.Background(), map[string]int{"id": 1})
+		require.NoError(t, err)
+
+		msg, err := sub.NextMsg(time.Second)
+		require.NoError(t, err)
+		var got map[string]int
+		require.NoError(t, json.Unmarshal(msg.Data, &got))
+		assert.Equal(t, 1, got["id"])
+	})
+
+	t.Run("WithSubject", func(t *testing.T) {
+		sub, err := nc.SubscribeSync("test.producer.other")
+		require.NoError(t, err)
+		defer sub.Unsubscribe()
+		require.NoError(t, nc.Flush())
+
+		err = producer.WithSubject("test.producer.other").SendMessage(context.Background(), map[string]int{"id": 2})
+		require.NoError(t, err)
+
+		_, err = sub.NextMsg(time.Second)
+		require.NoError(t, err)
+	})
+}
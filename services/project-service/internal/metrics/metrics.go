@@ -11,6 +11,13 @@ type Metrics struct {
 	projectsViewed     metric.Int64Counter
 	projectsListViewed metric.Int64Counter
 	messagesReceived   metric.Int64Counter
+	watchersActive     metric.Int64UpDownCounter
+	watchersDropped    metric.Int64Counter
+	remindersSent      metric.Int64Counter
+	attachmentsAdded   metric.Int64Counter
+	attachmentBytes    metric.Int64Counter
+	submissionsCreated metric.Int64Counter
+	submissionsGraded  metric.Int64Counter
 }
 
 func New(meter metric.Meter) (*Metrics, error) {
@@ -54,6 +61,69 @@ func New(meter metric.Meter) (*Metrics, error) {
 		return nil, err
 	}
 
+	m.watchersActive, err = meter.Int64UpDownCounter(
+		"project_service.watchers.active",
+		metric.WithDescription("Number of open WatchProjects streams"),
+		metric.WithUnit("{stream}"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	m.watchersDropped, err = meter.Int64Counter(
+		"project_service.watchers.dropped",
+		metric.WithDescription("Total number of WatchProjects streams closed for falling behind"),
+		metric.WithUnit("{stream}"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	m.remindersSent, err = meter.Int64Counter(
+		"project_service.reminders.sent",
+		metric.WithDescription("Total number of due date reminders published to NATS"),
+		metric.WithUnit("{reminder}"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	m.attachmentsAdded, err = meter.Int64Counter(
+		"project_service.attachments.uploaded",
+		metric.WithDescription("Total number of attachments uploaded"),
+		metric.WithUnit("{attachment}"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	m.attachmentBytes, err = meter.Int64Counter(
+		"project_service.attachments.uploaded_bytes",
+		metric.WithDescription("Total size of the attachments uploaded"),
+		metric.WithUnit("By"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	m.submissionsCreated, err = meter.Int64Counter(
+		"project_service.submissions.created",
+		metric.WithDescription("Total number of submissions handed in"),
+		metric.WithUnit("{submission}"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	m.submissionsGraded, err = meter.Int64Counter(
+		"project_service.submissions.graded",
+		metric.WithDescription("Total number of grades given to submissions"),
+		metric.WithUnit("{grade}"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
 	return m, nil
 }
 
@@ -81,6 +151,49 @@ func (m *Metrics) RecordMessageReceived(ctx context.Context) {
 	}
 }
 
+func (m *Metrics) RecordWatcherStarted(ctx context.Context) {
+	if m != nil && m.watchersActive != nil {
+		m.watchersActive.Add(ctx, 1)
+	}
+}
+
+func (m *Metrics) RecordWatcherStopped(ctx context.Context) {
+	if m != nil && m.watchersActive != nil {
+		m.watchersActive.Add(ctx, -1)
+	}
+}
+
+func (m *Metrics) RecordWatcherDropped(ctx context.Context) {
+	if m != nil && m.watchersDropped != nil {
+		m.watchersDropped.Add(ctx, 1)
+	}
+}
+
+func (m *Metrics) RecordReminderSent(ctx context.Context) {
+	if m != nil && m.remindersSent != nil {
+		m.remindersSent.Add(ctx, 1)
+	}
+}
+
+func (m *Metrics) RecordAttachmentUploaded(ctx context.Context, size int64) {
+	if m != nil && m.attachmentsAdded != nil {
+		m.attachmentsAdded.Add(ctx, 1)
+		m.attachmentBytes.Add(ctx, size)
+	}
+}
+
+func (m *Metrics) RecordSubmissionCreated(ctx context.Context) {
+	if m != nil && m.submissionsCreated != nil {
+		m.submissionsCreated.Add(ctx, 1)
+	}
+}
+
+func (m *Metrics) RecordSubmissionGraded(ctx context.Context) {
+	if m != nil && m.submissionsGraded != nil {
+		m.submissionsGraded.Add(ctx, 1)
+	}
+}
+
 // NewMock creates a no-op Metrics instance for testing
 // The returned Metrics will safely ignore all Record* calls
 func NewMock() *Metrics {
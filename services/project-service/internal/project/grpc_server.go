@@ -2,13 +2,19 @@ package project
 
 import (
 	"context"
+	"errors"
 	"log/slog"
+	"slices"
+	"strings"
+	"time"
 
 	pb "grud/api/gen/project/v1"
+	"grud/common/history"
 	"project-service/internal/metrics"
 
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/structpb"
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
@@ -33,7 +39,7 @@ func (s *GrpcServer) GetAllProjects(ctx context.Context, req *pb.GetAllProjectsR
 	projects, err := s.service.GetAllProjects(ctx)
 	if err != nil {
 		s.logger.ErrorContext(ctx, "gRPC: failed to fetch projects", "error", err)
-		return nil, err
+		return nil, toStatusError(err)
 	}
 
 	// Convert internal Project model to protobuf Project
@@ -55,6 +61,69 @@ func (s *GrpcServer) GetAllProjects(ctx context.Context, req *pb.GetAllProjectsR
 	}, nil
 }
 
+func (s *GrpcServer) ListProjects(ctx context.Context, req *pb.ListProjectsRequest) (*pb.ListProjectsResponse, error) {
+	s.logger.InfoContext(ctx, "gRPC: listing projects", "page_size", req.PageSize, "order_by", req.OrderBy)
+
+	opts := ListOptions{
+		PageSize:  int(req.PageSize),
+		PageToken: req.PageToken,
+		OrderBy:   req.OrderBy,
+		ListFilter: ListFilter{
+			NameContains:  req.NameContains,
+			Status:        statusFromProto(req.Status),
+			CreatedAfter:  timeFromProto(req.CreatedAfter),
+			CreatedBefore: timeFromProto(req.CreatedBefore),
+			UpdatedAfter:  timeFromProto(req.UpdatedAfter),
+			UpdatedBefore: timeFromProto(req.UpdatedBefore),
+			Tags:          req.Tags,
+			TagMatch:      tagMatchFromProto(req.TagMatch),
+		},
+	}
+
+	result, err := s.service.ListProjects(ctx, opts)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "gRPC: failed to list projects", "error", err)
+		return nil, toStatusError(err)
+	}
+
+	pbProjects := make([]*pb.Project, len(result.Projects))
+	for i := range result.Projects {
+		pbProjects[i] = toProtoProject(&result.Projects[i])
+	}
+
+	// Record metric
+	s.metrics.RecordProjectsListViewed(ctx)
+
+	return &pb.ListProjectsResponse{
+		Projects:      pbProjects,
+		NextPageToken: result.NextPageToken,
+	}, nil
+}
+
+func (s *GrpcServer) SearchProjects(ctx context.Context, req *pb.SearchProjectsRequest) (*pb.SearchProjectsResponse, error) {
+	s.logger.InfoContext(ctx, "gRPC: searching projects", "query", req.Query, "page_size", req.PageSize)
+
+	results, err := s.service.SearchProjects(ctx, req.Query, int(req.PageSize))
+	if err != nil {
+		s.logger.ErrorContext(ctx, "gRPC: failed to search projects", "error", err, "query", req.Query)
+		return nil, toStatusError(err)
+	}
+
+	pbResults := make([]*pb.ProjectSearchResult, len(results))
+	for i := range results {
+		pbResults[i] = &pb.ProjectSearchResult{
+			Project:              toProtoProject(&results[i].Project),
+			Rank:                 results[i].Rank,
+			NameHighlight:        results[i].NameHighlight,
+			DescriptionHighlight: results[i].DescriptionHighlight,
+		}
+	}
+
+	return &pb.SearchProjectsResponse{
+		Results: pbResults,
+	}, nil
+}
+
 func (s *GrpcServer) GetProject(ctx context.Context, req *pb.GetProjectRequest) (*pb.GetProjectResponse, error) {
 	if req.Id <= 0 {
 		return nil, status.Error(codes.InvalidArgument, "id must be greater than 0")
@@ -65,44 +134,42 @@ func (s *GrpcServer) GetProject(ctx context.Context, req *pb.GetProjectRequest)
 	project, err := s.service.GetProjectByID(ctx, int(req.Id))
 	if err != nil {
 		s.logger.ErrorContext(ctx, "gRPC: failed to fetch project", "error", err, "id", req.Id)
-		return nil, err
+		return nil, toStatusError(err)
 	}
 
 	// Record metric
 	s.metrics.RecordProjectViewed(ctx)
 
 	return &pb.GetProjectResponse{
-		Project: &pb.Project{
-			Id:        int32(project.ID),
-			Name:      project.Name,
-			CreatedAt: timestamppb.New(project.CreatedAt),
-			UpdatedAt: timestamppb.New(project.UpdatedAt),
-		},
+		Project: toProtoProject(project),
 	}, nil
 }
 
 func (s *GrpcServer) CreateProject(ctx context.Context, req *pb.CreateProjectRequest) (*pb.CreateProjectResponse, error) {
+	if strings.TrimSpace(req.Name) == "" {
+		return nil, status.Error(codes.InvalidArgument, "name is required")
+	}
+
 	s.logger.InfoContext(ctx, "gRPC: creating project", "name", req.Name)
 
 	project := &Project{
-		Name: req.Name,
+		Name:        req.Name,
+		Description: req.Description,
+		StartDate:   timeFromProto(req.StartDate),
+		DueDate:     timeFromProto(req.DueDate),
+		Tags:        req.Tags,
 	}
 
 	if err := s.service.CreateProject(ctx, project); err != nil {
 		s.logger.ErrorContext(ctx, "gRPC: failed to create project", "error", err)
-		return nil, err
+		return nil, toStatusError(err)
 	}
 
 	// Record metric
 	s.metrics.RecordProjectCreation(ctx)
 
 	return &pb.CreateProjectResponse{
-		Project: &pb.Project{
-			Id:        int32(project.ID),
-			Name:      project.Name,
-			CreatedAt: timestamppb.New(project.CreatedAt),
-			UpdatedAt: timestamppb.New(project.UpdatedAt),
-		},
+		Project: toProtoProject(project),
 	}, nil
 }
 
@@ -111,32 +178,88 @@ func (s *GrpcServer) UpdateProject(ctx context.Context, req *pb.UpdateProjectReq
 		return nil, status.Error(codes.InvalidArgument, "id must be greater than 0")
 	}
 
-	s.logger.InfoContext(ctx, "gRPC: updating project", "id", req.Id, "name", req.Name)
+	fields, err := updateFields(req)
+	if err != nil {
+		return nil, err
+	}
+
+	s.logger.InfoContext(ctx, "gRPC: updating project", "id", req.Id, "fields", fields)
 
 	project := &Project{
-		ID:   int(req.Id),
-		Name: req.Name,
+		ID:          int(req.Id),
+		Name:        req.Name,
+		Description: req.Description,
+		StartDate:   timeFromProto(req.StartDate),
+		DueDate:     timeFromProto(req.DueDate),
+		Version:     int(req.Version),
+		Tags:        req.Tags,
 	}
 
-	if err := s.service.UpdateProject(ctx, project); err != nil {
+	if err := s.service.UpdateProject(ctx, project, fields...); err != nil {
 		s.logger.ErrorContext(ctx, "gRPC: failed to update project", "error", err, "id", req.Id)
-		return nil, err
+		return nil, toStatusError(err)
 	}
 
-	// Fetch updated project to get all fields including timestamps
-	updatedProject, err := s.service.GetProjectByID(ctx, int(req.Id))
+	// UpdateProject reloads the row, so project carries all fields including timestamps
+	return &pb.UpdateProjectResponse{
+		Project: toProtoProject(project),
+	}, nil
+}
+
+// updateFields resolves the columns an UpdateProjectRequest writes. Without a
+// mask every populated field is written, as described in AIP-134.
+func updateFields(req *pb.UpdateProjectRequest) ([]string, error) {
+	var fields []string
+	if len(req.GetUpdateMask().GetPaths()) > 0 {
+		for _, path := range req.UpdateMask.Paths {
+			if !slices.Contains(UpdatableColumns, path) {
+				return nil, status.Errorf(codes.InvalidArgument, "field %q cannot be updated", path)
+			}
+			fields = append(fields, path)
+		}
+	} else {
+		if req.Name != "" {
+			fields = append(fields, "name")
+		}
+		if req.Description != "" {
+			fields = append(fields, "description")
+		}
+		if req.StartDate != nil {
+			fields = append(fields, "start_date")
+		}
+		if req.DueDate != nil {
+			fields = append(fields, "due_date")
+		}
+		if len(req.Tags) > 0 {
+			fields = append(fields, "tags")
+		}
+		if len(fields) == 0 {
+			return nil, status.Error(codes.InvalidArgument, "nothing to update")
+		}
+	}
+
+	if slices.Contains(fields, "name") && strings.TrimSpace(req.Name) == "" {
+		return nil, status.Error(codes.InvalidArgument, "name is required")
+	}
+	return fields, nil
+}
+
+func (s *GrpcServer) TransitionProject(ctx context.Context, req *pb.TransitionProjectRequest) (*pb.TransitionProjectResponse, error) {
+	if req.Id <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "id must be greater than 0")
+	}
+
+	to := statusFromProto(req.Status)
+	s.logger.InfoContext(ctx, "gRPC: transitioning project", "id", req.Id, "status", to)
+
+	project, err := s.service.TransitionProject(ctx, int(req.Id), to)
 	if err != nil {
-		s.logger.ErrorContext(ctx, "gRPC: failed to fetch updated project", "error", err, "id", req.Id)
-		return nil, err
+		s.logger.WarnContext(ctx, "gRPC: failed to transition project", "error", err, "id", req.Id)
+		return nil, toStatusError(err)
 	}
 
-	return &pb.UpdateProjectResponse{
-		Project: &pb.Project{
-			Id:        int32(updatedProject.ID),
-			Name:      updatedProject.Name,
-			CreatedAt: timestamppb.New(updatedProject.CreatedAt),
-			UpdatedAt: timestamppb.New(updatedProject.UpdatedAt),
-		},
+	return &pb.TransitionProjectResponse{
+		Project: toProtoProject(project),
 	}, nil
 }
 
@@ -149,8 +272,505 @@ func (s *GrpcServer) DeleteProject(ctx context.Context, req *pb.DeleteProjectReq
 
 	if err := s.service.DeleteProject(ctx, int(req.Id)); err != nil {
 		s.logger.ErrorContext(ctx, "gRPC: failed to delete project", "error", err, "id", req.Id)
-		return nil, err
+		return nil, toStatusError(err)
 	}
 
 	return &pb.DeleteProjectResponse{}, nil
 }
+
+func (s *GrpcServer) RestoreProject(ctx context.Context, req *pb.RestoreProjectRequest) (*pb.RestoreProjectResponse, error) {
+	if req.Id <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "id must be greater than 0")
+	}
+
+	s.logger.InfoContext(ctx, "gRPC: restoring project", "id", req.Id)
+
+	project, err := s.service.RestoreProject(ctx, int(req.Id))
+	if err != nil {
+		s.logger.ErrorContext(ctx, "gRPC: failed to restore project", "error", err, "id", req.Id)
+		return nil, toStatusError(err)
+	}
+
+	return &pb.RestoreProjectResponse{
+		Project: toProtoProject(project),
+	}, nil
+}
+
+func (s *GrpcServer) AddMember(ctx context.Context, req *pb.AddMemberRequest) (*pb.AddMemberResponse, error) {
+	if req.ProjectId <= 0 || req.StudentId <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "project_id and student_id must be greater than 0")
+	}
+
+	s.logger.InfoContext(ctx, "gRPC: adding project member", "project_id", req.ProjectId, "student_id", req.StudentId, "role", req.Role, "actor_id", req.ActorId)
+
+	member := &ProjectMember{
+		ProjectID: int(req.ProjectId),
+		StudentID: int(req.StudentId),
+		Role:      roleFromProto(req.Role),
+	}
+
+	if err := s.service.AddMember(ctx, member, int(req.ActorId)); err != nil {
+		s.logger.ErrorContext(ctx, "gRPC: failed to add project member", "error", err, "project_id", req.ProjectId, "student_id", req.StudentId)
+		return nil, toStatusError(err)
+	}
+
+	return &pb.AddMemberResponse{
+		Member: toProtoMember(member),
+	}, nil
+}
+
+func (s *GrpcServer) RemoveMember(ctx context.Context, req *pb.RemoveMemberRequest) (*pb.RemoveMemberResponse, error) {
+	if req.ProjectId <= 0 || req.StudentId <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "project_id and student_id must be greater than 0")
+	}
+
+	if req.ActorId <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "actor_id must be greater than 0")
+	}
+
+	s.logger.InfoContext(ctx, "gRPC: removing project member", "project_id", req.ProjectId, "student_id", req.StudentId, "actor_id", req.ActorId)
+
+	if err := s.service.RemoveMember(ctx, int(req.ProjectId), int(req.StudentId), int(req.ActorId)); err != nil {
+		s.logger.ErrorContext(ctx, "gRPC: failed to remove project member", "error", err, "project_id", req.ProjectId, "student_id", req.StudentId)
+		return nil, toStatusError(err)
+	}
+
+	return &pb.RemoveMemberResponse{}, nil
+}
+
+func (s *GrpcServer) ListMembers(ctx context.Context, req *pb.ListMembersRequest) (*pb.ListMembersResponse, error) {
+	if req.ProjectId <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "project_id must be greater than 0")
+	}
+
+	s.logger.InfoContext(ctx, "gRPC: listing project members", "project_id", req.ProjectId, "team_id", req.TeamId)
+
+	members, err := s.service.ListMembers(ctx, int(req.ProjectId), int(req.TeamId))
+	if err != nil {
+		s.logger.ErrorContext(ctx, "gRPC: failed to list project members", "error", err, "project_id", req.ProjectId)
+		return nil, toStatusError(err)
+	}
+
+	pbMembers := make([]*pb.ProjectMember, len(members))
+	for i := range members {
+		pbMembers[i] = toProtoMember(&members[i])
+	}
+
+	return &pb.ListMembersResponse{
+		Members: pbMembers,
+	}, nil
+}
+
+func (s *GrpcServer) GetProjectHistory(ctx context.Context, req *pb.GetProjectHistoryRequest) (*pb.GetProjectHistoryResponse, error) {
+	if req.Id <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "id must be greater than 0")
+	}
+	if req.PageSize < 0 {
+		return nil, status.Error(codes.InvalidArgument, "page_size must not be negative")
+	}
+
+	s.logger.InfoContext(ctx, "gRPC: getting project history", "id", req.Id)
+
+	page, err := s.service.GetProjectHistory(ctx, int(req.Id), int(req.PageSize), req.PageToken)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "gRPC: failed to get project history", "error", err, "id", req.Id)
+		return nil, toStatusError(err)
+	}
+
+	entries := make([]*pb.HistoryEntry, len(page.Entries))
+	for i := range page.Entries {
+		entry, err := toProtoHistoryEntry(&page.Entries[i])
+		if err != nil {
+			s.logger.ErrorContext(ctx, "gRPC: failed to convert history entry", "error", err, "id", page.Entries[i].ID)
+			return nil, status.Error(codes.Internal, "failed to convert history entry")
+		}
+		entries[i] = entry
+	}
+
+	return &pb.GetProjectHistoryResponse{
+		Entries:       entries,
+		NextPageToken: page.NextPageToken,
+	}, nil
+}
+
+func toProtoHistoryEntry(e *history.Entry) (*pb.HistoryEntry, error) {
+	entry := &pb.HistoryEntry{
+		Id:        e.ID,
+		Action:    string(e.Action),
+		Actor:     e.Actor,
+		CreatedAt: timestamppb.New(e.CreatedAt),
+	}
+	if e.Before != nil {
+		before, err := structpb.NewStruct(e.Before)
+		if err != nil {
+			return nil, err
+		}
+		entry.Before = before
+	}
+	if e.After != nil {
+		after, err := structpb.NewStruct(e.After)
+		if err != nil {
+			return nil, err
+		}
+		entry.After = after
+	}
+	return entry, nil
+}
+
+func (s *GrpcServer) ListProjectsForStudent(ctx context.Context, req *pb.ListProjectsForStudentRequest) (*pb.ListProjectsForStudentResponse, error) {
+	if req.StudentId <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "student_id must be greater than 0")
+	}
+
+	s.logger.InfoContext(ctx, "gRPC: listing projects for student", "student_id", req.StudentId)
+
+	memberships, err := s.service.ListProjectsForStudent(ctx, int(req.StudentId))
+	if err != nil {
+		s.logger.ErrorContext(ctx, "gRPC: failed to list projects for student", "error", err, "student_id", req.StudentId)
+		return nil, toStatusError(err)
+	}
+
+	pbProjects := make([]*pb.StudentProject, 0, len(memberships))
+	for _, m := range memberships {
+		if m.Project == nil {
+			continue
+		}
+		pbProjects = append(pbProjects, &pb.StudentProject{
+			Project: toProtoProject(m.Project),
+			Role:    roleToProto(m.Role),
+		})
+	}
+
+	return &pb.ListProjectsForStudentResponse{
+		Projects: pbProjects,
+	}, nil
+}
+
+func toProtoProject(p *Project) *pb.Project {
+	return &pb.Project{
+		Id:          int32(p.ID),
+		Name:        p.Name,
+		CreatedAt:   timestamppb.New(p.CreatedAt),
+		UpdatedAt:   timestamppb.New(p.UpdatedAt),
+		Description: p.Description,
+		Status:      statusToProto(p.Status),
+		StartDate:   timeToProto(p.StartDate),
+		DueDate:     timeToProto(p.DueDate),
+		Version:     int64(p.Version),
+		Tags:        p.Tags,
+	}
+}
+
+func (s *GrpcServer) ListTags(ctx context.Context, req *pb.ListTagsRequest) (*pb.ListTagsResponse, error) {
+	s.logger.InfoContext(ctx, "gRPC: listing tags", "prefix", req.Prefix)
+
+	tags, err := s.service.ListTags(ctx, req.Prefix)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "gRPC: failed to list tags", "error", err)
+		return nil, toStatusError(err)
+	}
+
+	pbTags := make([]*pb.Tag, len(tags))
+	for i := range tags {
+		pbTags[i] = toProtoTag(&tags[i])
+	}
+
+	return &pb.ListTagsResponse{
+		Tags: pbTags,
+	}, nil
+}
+
+func (s *GrpcServer) RenameTag(ctx context.Context, req *pb.RenameTagRequest) (*pb.RenameTagResponse, error) {
+	s.logger.InfoContext(ctx, "gRPC: renaming tag", "name", req.Name, "new_name", req.NewName)
+
+	tag, err := s.service.RenameTag(ctx, req.Name, req.NewName)
+	if err != nil {
+		s.logger.WarnContext(ctx, "gRPC: failed to rename tag", "error", err, "name", req.Name)
+		return nil, toStatusError(err)
+	}
+
+	return &pb.RenameTagResponse{
+		Tag: toProtoTag(tag),
+	}, nil
+}
+
+func (s *GrpcServer) MergeTags(ctx context.Context, req *pb.MergeTagsRequest) (*pb.MergeTagsResponse, error) {
+	s.logger.InfoContext(ctx, "gRPC: merging tags", "sources", req.Sources, "target", req.Target)
+
+	tag, err := s.service.MergeTags(ctx, req.Sources, req.Target)
+	if err != nil {
+		s.logger.WarnContext(ctx, "gRPC: failed to merge tags", "error", err, "target", req.Target)
+		return nil, toStatusError(err)
+	}
+
+	return &pb.MergeTagsResponse{
+		Tag: toProtoTag(tag),
+	}, nil
+}
+
+func toProtoTag(t *Tag) *pb.Tag {
+	return &pb.Tag{
+		Id:           int32(t.ID),
+		Name:         t.Name,
+		ProjectCount: int32(t.ProjectCount),
+	}
+}
+
+// tagMatchFromProto maps the proto enum to TagMatch; UNSPECIFIED maps to ""
+func tagMatchFromProto(m pb.TagMatch) TagMatch {
+	switch m {
+	case pb.TagMatch_TAG_MATCH_ANY:
+		return TagMatchAny
+	case pb.TagMatch_TAG_MATCH_ALL:
+		return TagMatchAll
+	}
+	return ""
+}
+
+// statusFromProto maps the proto enum to Status; UNSPECIFIED maps to ""
+func statusFromProto(st pb.ProjectStatus) Status {
+	switch st {
+	case pb.ProjectStatus_PROJECT_STATUS_DRAFT:
+		return StatusDraft
+	case pb.ProjectStatus_PROJECT_STATUS_ACTIVE:
+		return StatusActive
+	case pb.ProjectStatus_PROJECT_STATUS_COMPLETED:
+		return StatusCompleted
+	case pb.ProjectStatus_PROJECT_STATUS_ARCHIVED:
+		return StatusArchived
+	}
+	return ""
+}
+
+func statusToProto(st Status) pb.ProjectStatus {
+	switch st {
+	case StatusDraft:
+		return pb.ProjectStatus_PROJECT_STATUS_DRAFT
+	case StatusActive:
+		return pb.ProjectStatus_PROJECT_STATUS_ACTIVE
+	case StatusCompleted:
+		return pb.ProjectStatus_PROJECT_STATUS_COMPLETED
+	case StatusArchived:
+		return pb.ProjectStatus_PROJECT_STATUS_ARCHIVED
+	}
+	return pb.ProjectStatus_PROJECT_STATUS_UNSPECIFIED
+}
+
+// snapshotPageSize is the number of projects per snapshot message on WatchProjects
+const snapshotPageSize = 500
+
+// snapshotBacklog is the number of changes WatchProjects holds back while it
+// sends the snapshot before it drops the watcher as too slow
+const snapshotBacklog = 16 * DefaultWatchBufferSize
+
+func (s *GrpcServer) WatchProjects(req *pb.WatchProjectsRequest, stream pb.ProjectService_WatchProjectsServer) error {
+	ctx := stream.Context()
+	s.logger.InfoContext(ctx, "gRPC: watching projects")
+
+	// Subscribe before reading the snapshot so no change is missed. A change
+	// racing with the snapshot may therefore be seen twice.
+	sub, err := s.service.WatchProjects()
+	if err != nil {
+		s.logger.WarnContext(ctx, "gRPC: failed to start project watch", "error", err)
+		return toStatusError(err)
+	}
+	defer sub.Close()
+
+	s.metrics.RecordWatcherStarted(ctx)
+	defer s.metrics.RecordWatcherStopped(ctx)
+
+	pending, err := s.snapshot(ctx, stream, sub)
+	if err != nil {
+		return s.endWatch(ctx, err)
+	}
+	for _, e := range pending {
+		if err := stream.Send(toProtoEvent(e)); err != nil {
+			return err
+		}
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return s.endWatch(ctx, ctx.Err())
+		case <-sub.Done():
+			return s.endWatch(ctx, sub.Err())
+		case e := <-sub.Events():
+			if err := stream.Send(toProtoEvent(e)); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// snapshot sends the snapshot while it drains sub, so that a snapshot taking
+// longer than the subscription's buffer lasts does not get the watcher
+// dropped. It returns the changes made meanwhile, up to snapshotBacklog.
+func (s *GrpcServer) snapshot(ctx context.Context, stream pb.ProjectService_WatchProjectsServer, sub *Subscription) ([]Event, error) {
+	sent := make(chan error, 1)
+	go func() { sent <- s.sendSnapshot(ctx, stream) }()
+
+	// The stream is not ours until the snapshot is sent, so the snapshot
+	// always runs to the end; a failure on the way ends it early
+	var pending []Event
+	var failed error
+	events, done := sub.Events(), sub.Done()
+	for {
+		select {
+		case err := <-sent:
+			if err != nil {
+				s.logger.ErrorContext(ctx, "gRPC: failed to send project snapshot", "error", err)
+				return nil, err
+			}
+			return pending, failed
+		case <-done:
+			failed, events, done = sub.Err(), nil, nil
+		case e := <-events:
+			if len(pending) == snapshotBacklog {
+				failed, events, done = ErrWatcherTooSlow, nil, nil
+				continue
+			}
+			pending = append(pending, e)
+		}
+	}
+}
+
+// endWatch reports why a watch ended and returns its status
+func (s *GrpcServer) endWatch(ctx context.Context, err error) error {
+	switch {
+	case ctx.Err() != nil:
+		s.logger.InfoContext(ctx, "gRPC: project watcher disconnected")
+		return status.FromContextError(ctx.Err()).Err()
+	case errors.Is(err, ErrWatcherTooSlow):
+		s.metrics.RecordWatcherDropped(ctx)
+		s.logger.WarnContext(ctx, "gRPC: dropping slow project watcher")
+	}
+	return toStatusError(err)
+}
+
+func (s *GrpcServer) sendSnapshot(ctx context.Context, stream pb.ProjectService_WatchProjectsServer) error {
+	opts := ListOptions{PageSize: snapshotPageSize}
+	for {
+		page, err := s.service.ListProjects(ctx, opts)
+		if err != nil {
+			return err
+		}
+
+		snapshot := &pb.ProjectSnapshot{
+			Projects: make([]*pb.Project, len(page.Projects)),
+			Complete: page.NextPageToken == "",
+		}
+		for i := range page.Projects {
+			snapshot.Projects[i] = toProtoProject(&page.Projects[i])
+		}
+
+		if err := stream.Send(&pb.WatchProjectsResponse{
+			Payload: &pb.WatchProjectsResponse_Snapshot{Snapshot: snapshot},
+		}); err != nil {
+			return err
+		}
+
+		if snapshot.Complete {
+			return nil
+		}
+		opts.PageToken = page.NextPageToken
+	}
+}
+
+func toProtoEvent(e Event) *pb.WatchProjectsResponse {
+	event := &pb.ProjectEvent{Project: toProtoProject(&e.Project)}
+	switch e.Type {
+	case EventCreated:
+		event.Type = pb.ProjectEventType_PROJECT_EVENT_TYPE_CREATED
+	case EventUpdated, EventMemberAdded:
+		event.Type = pb.ProjectEventType_PROJECT_EVENT_TYPE_UPDATED
+	case EventDeleted:
+		event.Type = pb.ProjectEventType_PROJECT_EVENT_TYPE_DELETED
+		event.Project = &pb.Project{Id: int32(e.Project.ID)}
+	}
+	return &pb.WatchProjectsResponse{
+		Payload: &pb.WatchProjectsResponse_Event{Event: event},
+	}
+}
+
+// timeFromProto converts an optional timestamp, mapping nil to the zero time
+func timeFromProto(ts *timestamppb.Timestamp) time.Time {
+	if ts == nil {
+		return time.Time{}
+	}
+	return ts.AsTime()
+}
+
+// timeToProto converts an optional time, mapping the zero time to nil
+func timeToProto(t time.Time) *timestamppb.Timestamp {
+	if t.IsZero() {
+		return nil
+	}
+	return timestamppb.New(t)
+}
+
+func toProtoMember(m *ProjectMember) *pb.ProjectMember {
+	member := &pb.ProjectMember{
+		ProjectId: int32(m.ProjectID),
+		StudentId: int32(m.StudentID),
+		Role:      roleToProto(m.Role),
+		CreatedAt: timestamppb.New(m.CreatedAt),
+		TeamLead:  m.TeamLead,
+	}
+	if m.TeamID != nil {
+		member.TeamId = int32(*m.TeamID)
+	}
+	return member
+}
+
+func roleFromProto(role pb.MemberRole) Role {
+	switch role {
+	case pb.MemberRole_MEMBER_ROLE_OWNER:
+		return RoleOwner
+	case pb.MemberRole_MEMBER_ROLE_CONTRIBUTOR:
+		return RoleContributor
+	case pb.MemberRole_MEMBER_ROLE_VIEWER:
+		return RoleViewer
+	case pb.MemberRole_MEMBER_ROLE_INSTRUCTOR:
+		return RoleInstructor
+	}
+	return ""
+}
+
+func roleToProto(role Role) pb.MemberRole {
+	switch role {
+	case RoleOwner:
+		return pb.MemberRole_MEMBER_ROLE_OWNER
+	case RoleContributor:
+		return pb.MemberRole_MEMBER_ROLE_CONTRIBUTOR
+	case RoleViewer:
+		return pb.MemberRole_MEMBER_ROLE_VIEWER
+	case RoleInstructor:
+		return pb.MemberRole_MEMBER_ROLE_INSTRUCTOR
+	}
+	return pb.MemberRole_MEMBER_ROLE_UNSPECIFIED
+}
+
+// toStatusError maps domain errors to gRPC status errors
+func toStatusError(err error) error {
+	switch {
+	case errors.Is(err, ErrProjectNotFound), errors.Is(err, ErrMemberNotFound), errors.Is(err, ErrTagNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, ErrInvalidInput), errors.Is(err, ErrInvalidRole), errors.Is(err, ErrInvalidPageToken),
+		errors.Is(err, ErrInvalidStatus), errors.Is(err, ErrInvalidTag), errors.Is(err, history.ErrInvalidPageToken):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, ErrInvalidTransition), errors.Is(err, ErrLastManager):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	case errors.Is(err, ErrNotPermitted), errors.Is(err, ErrCannotRemove):
+		return status.Error(codes.PermissionDenied, err.Error())
+	case errors.Is(err, ErrVersionConflict):
+		return status.Error(codes.Aborted, err.Error())
+	case errors.Is(err, ErrMemberExists), errors.Is(err, ErrTagExists):
+		return status.Error(codes.AlreadyExists, err.Error())
+	case errors.Is(err, ErrWatcherTooSlow):
+		return status.Error(codes.ResourceExhausted, err.Error())
+	case errors.Is(err, ErrBroadcasterClosed):
+		return status.Error(codes.Unavailable, err.Error())
+	}
+	return err
+}
@@ -2,31 +2,49 @@ package project_test
 
 import (
 	"context"
+	"fmt"
 	"log/slog"
+	"net"
 	"os"
+	"strings"
 	"testing"
+	"time"
 
 	pb "grud/api/gen/project/v1"
+	"grud/common/history"
 	commonmetrics "grud/common/metrics"
 	"grud/testing/testdb"
+	"project-service/internal/db"
+	"project-service/internal/message"
 	projectmetrics "project-service/internal/metrics"
 	"project-service/internal/project"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 func TestProjectGrpcServer_Shared(t *testing.T) {
 	pgContainer := testdb.SetupSharedPostgres(t)
 	defer pgContainer.Cleanup(t)
 
-	pgContainer.RunMigrations(t, (*project.Project)(nil))
-	pgContainer.CreateUpdateTrigger(t, "projects")
+	// The service migrations also add the generated search columns
+	err := db.RunMigrations(context.Background(), pgContainer.DB,
+		(*project.Project)(nil), (*project.ProjectMember)(nil), (*project.Tag)(nil), (*project.ProjectTag)(nil), (*message.Message)(nil), (*history.Entry)(nil))
+	require.NoError(t, err)
 
 	mockServiceMetrics := projectmetrics.NewMock()
 	mockRepoMetrics := commonmetrics.NewMock()
 	repo := project.NewRepository(pgContainer.DB, mockRepoMetrics)
-	service := project.NewService(repo)
+	events := project.NewBroadcaster(project.DefaultWatchBufferSize)
+	service := project.NewService(repo, events)
 	logger := slog.New(slog.NewTextHandler(os.Stderr, nil))
 	grpcServer := project.NewGrpcServer(service, logger, mockServiceMetrics)
 
@@ -93,11 +111,58 @@ func TestProjectGrpcServer_Shared(t *testing.T) {
 		assert.NotZero(t, resp.Project.UpdatedAt)
 	})
 
+	t.Run("SearchProjects", func(t *testing.T) {
+		testdb.CleanupTables(t, pgContainer.DB, "projects")
+
+		ctx := context.Background()
+		projects := []*project.Project{
+			{Name: "Robotics Lab", Description: "Build a line-following robot"},
+			{Name: "Website", Description: "A site for the robotics club & friends <3"},
+			{Name: "Chess Engine", Description: "Alpha-beta search"},
+			{Name: "Robot Arm", Description: "Deleted project"},
+		}
+		for _, p := range projects {
+			_, err := pgContainer.DB.NewInsert().Model(p).Exec(ctx)
+			require.NoError(t, err)
+		}
+		_, err := grpcServer.DeleteProject(ctx, &pb.DeleteProjectRequest{Id: int32(projects[3].ID)})
+		require.NoError(t, err)
+
+		resp, err := grpcServer.SearchProjects(ctx, &pb.SearchProjectsRequest{Query: "robot"})
+		require.NoError(t, err)
+		require.Len(t, resp.Results, 2)
+
+		// A name match outranks a description match; deleted projects are not found
+		assert.Equal(t, "Robotics Lab", resp.Results[0].Project.Name)
+		assert.Equal(t, "<mark>Robotics</mark> Lab", resp.Results[0].NameHighlight)
+		assert.Contains(t, resp.Results[0].DescriptionHighlight, "<mark>robot</mark>")
+		assert.Equal(t, "Website", resp.Results[1].Project.Name)
+		assert.Greater(t, resp.Results[0].Rank, resp.Results[1].Rank)
+		assert.Less(t, resp.Results[0].Rank, 1.0)
+
+		// Highlights are HTML-escaped around the marks
+		assert.Contains(t, resp.Results[1].DescriptionHighlight, "<mark>robotics</mark> club &amp; friends")
+		assert.NotContains(t, resp.Results[1].DescriptionHighlight, "<3")
+
+		// Every word has to match
+		resp, err = grpcServer.SearchProjects(ctx, &pb.SearchProjectsRequest{Query: "ROBOT' lab!"})
+		require.NoError(t, err)
+		require.Len(t, resp.Results, 1)
+		assert.Equal(t, "Robotics Lab", resp.Results[0].Project.Name)
+
+		resp, err = grpcServer.SearchProjects(ctx, &pb.SearchProjectsRequest{Query: "robot", PageSize: 1})
+		require.NoError(t, err)
+		assert.Len(t, resp.Results, 1)
+
+		_, err = grpcServer.SearchProjects(ctx, &pb.SearchProjectsRequest{Query: " &| "})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+
 	t.Run("GetProject", func(t *testing.T) {
 		testdb.CleanupTables(t, pgContainer.DB, "projects")
 
 		ctx := context.Background()
-		p := &project.Project{Name: "Test Project"}
+		p := &project.Project{Name: "Test Project", Description: "Details", Status: project.StatusActive}
 		_, err := pgContainer.DB.NewInsert().Model(p).Exec(ctx)
 		require.NoError(t, err)
 
@@ -109,6 +174,9 @@ func TestProjectGrpcServer_Shared(t *testing.T) {
 		require.NotNil(t, resp.Project)
 		assert.Equal(t, int32(p.ID), resp.Project.Id)
 		assert.Equal(t, "Test Project", resp.Project.Name)
+		assert.Equal(t, "Details", resp.Project.Description)
+		assert.Equal(t, pb.ProjectStatus_PROJECT_STATUS_ACTIVE, resp.Project.Status)
+		assert.Equal(t, int64(1), resp.Project.Version)
 		assert.NotZero(t, resp.Project.CreatedAt)
 		assert.NotZero(t, resp.Project.UpdatedAt)
 	})
@@ -136,6 +204,42 @@ func TestProjectGrpcServer_Shared(t *testing.T) {
 		assert.NotZero(t, resp.Project.UpdatedAt)
 	})
 
+	t.Run("UpdateProject_VersionConflict", func(t *testing.T) {
+		testdb.CleanupTables(t, pgContainer.DB, "projects")
+
+		ctx := context.Background()
+		p := &project.Project{Name: "Versioned"}
+		_, err := pgContainer.DB.NewInsert().Model(p).Exec(ctx)
+		require.NoError(t, err)
+		require.Equal(t, 1, p.Version)
+
+		resp, err := grpcServer.UpdateProject(ctx, &pb.UpdateProjectRequest{Id: int32(p.ID), Name: "First", Version: 1})
+		require.NoError(t, err)
+		assert.Equal(t, int64(2), resp.Project.Version)
+
+		// A writer still holding version 1 is rejected and the row is unchanged
+		_, err = grpcServer.UpdateProject(ctx, &pb.UpdateProjectRequest{Id: int32(p.ID), Name: "Stale", Version: 1})
+		assert.Equal(t, codes.Aborted, status.Code(err))
+
+		got, err := grpcServer.GetProject(ctx, &pb.GetProjectRequest{Id: int32(p.ID)})
+		require.NoError(t, err)
+		assert.Equal(t, "First", got.Project.Name)
+		assert.Equal(t, int64(2), got.Project.Version)
+
+		// Without a version the update is unconditional
+		resp, err = grpcServer.UpdateProject(ctx, &pb.UpdateProjectRequest{Id: int32(p.ID), Name: "Forced"})
+		require.NoError(t, err)
+		assert.Equal(t, int64(3), resp.Project.Version)
+
+		// Status transitions bump the version too
+		transitioned, err := grpcServer.TransitionProject(ctx, &pb.TransitionProjectRequest{
+			Id:     int32(p.ID),
+			Status: pb.ProjectStatus_PROJECT_STATUS_ACTIVE,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, int64(4), transitioned.Project.Version)
+	})
+
 	t.Run("DeleteProject", func(t *testing.T) {
 		testdb.CleanupTables(t, pgContainer.DB, "projects")
 
@@ -155,6 +259,859 @@ func TestProjectGrpcServer_Shared(t *testing.T) {
 		count, err = pgContainer.DB.NewSelect().Model((*project.Project)(nil)).Where("id = ?", p.ID).Count(ctx)
 		require.NoError(t, err)
 		assert.Equal(t, 0, count)
+
+		// but only soft deleted
+		count, err = pgContainer.DB.NewSelect().Model((*project.Project)(nil)).WhereDeleted().Where("id = ?", p.ID).Count(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, 1, count)
+	})
+
+	t.Run("RestoreProject", func(t *testing.T) {
+		testdb.CleanupTables(t, pgContainer.DB, "projects", "project_members")
+
+		ctx := context.Background()
+		p := &project.Project{Name: "Comeback"}
+		_, err := pgContainer.DB.NewInsert().Model(p).Exec(ctx)
+		require.NoError(t, err)
+		_, err = pgContainer.DB.NewInsert().Model(&project.ProjectMember{ProjectID: p.ID, StudentID: 10, Role: project.RoleOwner}).Exec(ctx)
+		require.NoError(t, err)
+
+		// Restoring a live project is not possible
+		_, err = grpcServer.RestoreProject(ctx, &pb.RestoreProjectRequest{Id: int32(p.ID)})
+		assert.Equal(t, codes.NotFound, status.Code(err))
+
+		_, err = grpcServer.DeleteProject(ctx, &pb.DeleteProjectRequest{Id: int32(p.ID)})
+		require.NoError(t, err)
+
+		_, err = grpcServer.GetProject(ctx, &pb.GetProjectRequest{Id: int32(p.ID)})
+		assert.Equal(t, codes.NotFound, status.Code(err))
+		_, err = grpcServer.DeleteProject(ctx, &pb.DeleteProjectRequest{Id: int32(p.ID)})
+		assert.Equal(t, codes.NotFound, status.Code(err))
+		forStudent, err := grpcServer.ListProjectsForStudent(ctx, &pb.ListProjectsForStudentRequest{StudentId: 10})
+		require.NoError(t, err)
+		assert.Empty(t, forStudent.Projects)
+
+		resp, err := grpcServer.RestoreProject(ctx, &pb.RestoreProjectRequest{Id: int32(p.ID)})
+		require.NoError(t, err)
+		assert.Equal(t, "Comeback", resp.Project.Name)
+
+		// Memberships survive the round trip
+		members, err := grpcServer.ListMembers(ctx, &pb.ListMembersRequest{ProjectId: int32(p.ID)})
+		require.NoError(t, err)
+		assert.Len(t, members.Members, 1)
+
+		_, err = grpcServer.RestoreProject(ctx, &pb.RestoreProjectRequest{Id: 0})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+
+	t.Run("PurgeDeleted", func(t *testing.T) {
+		testdb.CleanupTables(t, pgContainer.DB, "projects", "project_members")
+
+		ctx := context.Background()
+		old := &project.Project{Name: "Old"}
+		recent := &project.Project{Name: "Recent"}
+		for _, p := range []*project.Project{old, recent} {
+			_, err := pgContainer.DB.NewInsert().Model(p).Exec(ctx)
+			require.NoError(t, err)
+			_, err = pgContainer.DB.NewInsert().Model(&project.ProjectMember{ProjectID: p.ID, StudentID: 10, Role: project.RoleOwner}).Exec(ctx)
+			require.NoError(t, err)
+			require.NoError(t, service.DeleteProject(ctx, p.ID))
+		}
+		_, err := pgContainer.DB.NewUpdate().
+			Model((*project.Project)(nil)).
+			Set("deleted_at = ?", time.Now().Add(-48*time.Hour)).
+			Where("id = ?", old.ID).
+			WhereDeleted().
+			Exec(ctx)
+		require.NoError(t, err)
+
+		purged, err := service.PurgeDeleted(ctx, 24*time.Hour)
+		require.NoError(t, err)
+		assert.Equal(t, 1, purged)
+
+		count, err := pgContainer.DB.NewSelect().Model((*project.ProjectMember)(nil)).Count(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, 1, count, "members of purged projects are removed")
+
+		_, err = service.RestoreProject(ctx, old.ID)
+		assert.ErrorIs(t, err, project.ErrProjectNotFound)
+		_, err = service.RestoreProject(ctx, recent.ID)
+		assert.NoError(t, err)
+	})
+
+	t.Run("GetProject_NotFound", func(t *testing.T) {
+		testdb.CleanupTables(t, pgContainer.DB, "projects")
+
+		_, err := grpcServer.GetProject(context.Background(), &pb.GetProjectRequest{Id: 999})
+		assert.Equal(t, codes.NotFound, status.Code(err))
+	})
+
+	t.Run("CreateProject_EmptyName", func(t *testing.T) {
+		_, err := grpcServer.CreateProject(context.Background(), &pb.CreateProjectRequest{Name: "  "})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+
+	t.Run("UpdateProject_NotFound", func(t *testing.T) {
+		testdb.CleanupTables(t, pgContainer.DB, "projects")
+
+		_, err := grpcServer.UpdateProject(context.Background(), &pb.UpdateProjectRequest{Id: 999, Name: "Nope"})
+		assert.Equal(t, codes.NotFound, status.Code(err))
+	})
+
+	t.Run("DeleteProject_NotFound", func(t *testing.T) {
+		testdb.CleanupTables(t, pgContainer.DB, "projects")
+
+		_, err := grpcServer.DeleteProject(context.Background(), &pb.DeleteProjectRequest{Id: 999})
+		assert.Equal(t, codes.NotFound, status.Code(err))
+	})
+
+	t.Run("CreateProject_WithDetails", func(t *testing.T) {
+		testdb.CleanupTables(t, pgContainer.DB, "projects")
+
+		start := time.Date(2026, 9, 1, 0, 0, 0, 0, time.UTC)
+		due := time.Date(2026, 12, 15, 0, 0, 0, 0, time.UTC)
+		resp, err := grpcServer.CreateProject(context.Background(), &pb.CreateProjectRequest{
+			Name:        "Capstone",
+			Description: "Final year project",
+			StartDate:   timestamppb.New(start),
+			DueDate:     timestamppb.New(due),
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, "Final year project", resp.Project.Description)
+		assert.Equal(t, pb.ProjectStatus_PROJECT_STATUS_DRAFT, resp.Project.Status)
+		assert.True(t, start.Equal(resp.Project.StartDate.AsTime()))
+		assert.True(t, due.Equal(resp.Project.DueDate.AsTime()))
+	})
+
+	t.Run("CreateProject_DueBeforeStart", func(t *testing.T) {
+		_, err := grpcServer.CreateProject(context.Background(), &pb.CreateProjectRequest{
+			Name:      "Backwards",
+			StartDate: timestamppb.New(time.Date(2026, 9, 1, 0, 0, 0, 0, time.UTC)),
+			DueDate:   timestamppb.New(time.Date(2026, 8, 1, 0, 0, 0, 0, time.UTC)),
+		})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+
+	t.Run("UpdateProject_UpdateMask", func(t *testing.T) {
+		testdb.CleanupTables(t, pgContainer.DB, "projects")
+
+		ctx := context.Background()
+		p := &project.Project{Name: "Keep Me", Description: "old"}
+		_, err := pgContainer.DB.NewInsert().Model(p).Exec(ctx)
+		require.NoError(t, err)
+
+		// Only the masked field is written even though name is empty
+		resp, err := grpcServer.UpdateProject(ctx, &pb.UpdateProjectRequest{
+			Id:          int32(p.ID),
+			Description: "new",
+			UpdateMask:  &fieldmaskpb.FieldMask{Paths: []string{"description"}},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "Keep Me", resp.Project.Name)
+		assert.Equal(t, "new", resp.Project.Description)
+
+		// Without a mask the populated fields are written
+		due := time.Date(2027, 1, 1, 0, 0, 0, 0, time.UTC)
+		resp, err = grpcServer.UpdateProject(ctx, &pb.UpdateProjectRequest{
+			Id:      int32(p.ID),
+			DueDate: timestamppb.New(due),
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "new", resp.Project.Description)
+		assert.True(t, due.Equal(resp.Project.DueDate.AsTime()))
+
+		// A masked date without a value clears it
+		resp, err = grpcServer.UpdateProject(ctx, &pb.UpdateProjectRequest{
+			Id:         int32(p.ID),
+			UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"due_date"}},
+		})
+		require.NoError(t, err)
+		assert.Nil(t, resp.Project.DueDate)
+
+		_, err = grpcServer.UpdateProject(ctx, &pb.UpdateProjectRequest{
+			Id:         int32(p.ID),
+			UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"status"}},
+		})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+
+	t.Run("TransitionProject", func(t *testing.T) {
+		testdb.CleanupTables(t, pgContainer.DB, "projects")
+
+		ctx := context.Background()
+		p := &project.Project{Name: "Lifecycle"}
+		_, err := pgContainer.DB.NewInsert().Model(p).Exec(ctx)
+		require.NoError(t, err)
+
+		for _, next := range []pb.ProjectStatus{
+			pb.ProjectStatus_PROJECT_STATUS_ACTIVE,
+			pb.ProjectStatus_PROJECT_STATUS_COMPLETED,
+			pb.ProjectStatus_PROJECT_STATUS_ARCHIVED,
+		} {
+			resp, err := grpcServer.TransitionProject(ctx, &pb.TransitionProjectRequest{Id: int32(p.ID), Status: next})
+			require.NoError(t, err)
+			assert.Equal(t, next, resp.Project.Status)
+		}
+
+		_, err = grpcServer.TransitionProject(ctx, &pb.TransitionProjectRequest{
+			Id:     int32(p.ID),
+			Status: pb.ProjectStatus_PROJECT_STATUS_ACTIVE,
+		})
+		assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+	})
+
+	t.Run("TransitionProject_InvalidArguments", func(t *testing.T) {
+		testdb.CleanupTables(t, pgContainer.DB, "projects")
+
+		ctx := context.Background()
+		p := &project.Project{Name: "Draft"}
+		_, err := pgContainer.DB.NewInsert().Model(p).Exec(ctx)
+		require.NoError(t, err)
+
+		_, err = grpcServer.TransitionProject(ctx, &pb.TransitionProjectRequest{Id: int32(p.ID)})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+		// Skipping active is not allowed
+		_, err = grpcServer.TransitionProject(ctx, &pb.TransitionProjectRequest{
+			Id:     int32(p.ID),
+			Status: pb.ProjectStatus_PROJECT_STATUS_COMPLETED,
+		})
+		assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+
+		_, err = grpcServer.TransitionProject(ctx, &pb.TransitionProjectRequest{
+			Id:     999,
+			Status: pb.ProjectStatus_PROJECT_STATUS_ACTIVE,
+		})
+		assert.Equal(t, codes.NotFound, status.Code(err))
+	})
+
+	t.Run("ListProjects_StatusFilter", func(t *testing.T) {
+		testdb.CleanupTables(t, pgContainer.DB, "projects")
+
+		ctx := context.Background()
+		projects := []*project.Project{
+			{Name: "Draft One"},
+			{Name: "Active One", Status: project.StatusActive},
+			{Name: "Active Two", Status: project.StatusActive},
+		}
+		for _, p := range projects {
+			_, err := pgContainer.DB.NewInsert().Model(p).Exec(ctx)
+			require.NoError(t, err)
+		}
+
+		resp, err := grpcServer.ListProjects(ctx, &pb.ListProjectsRequest{Status: pb.ProjectStatus_PROJECT_STATUS_ACTIVE})
+		require.NoError(t, err)
+		require.Len(t, resp.Projects, 2)
+		assert.Equal(t, "Active One", resp.Projects[0].Name)
+		assert.Equal(t, "Active Two", resp.Projects[1].Name)
+	})
+
+	t.Run("ListProjects_Pagination", func(t *testing.T) {
+		testdb.CleanupTables(t, pgContainer.DB, "projects")
+
+		ctx := context.Background()
+		for _, name := range []string{"Delta", "Alpha", "Charlie", "Bravo", "Echo"} {
+			_, err := pgContainer.DB.NewInsert().Model(&project.Project{Name: name}).Exec(ctx)
+			require.NoError(t, err)
+		}
+
+		var names []string
+		token := ""
+		for i := 0; i < 10; i++ {
+			resp, err := grpcServer.ListProjects(ctx, &pb.ListProjectsRequest{
+				PageSize:  2,
+				PageToken: token,
+				OrderBy:   "name desc",
+			})
+			require.NoError(t, err)
+			assert.LessOrEqual(t, len(resp.Projects), 2)
+
+			for _, p := range resp.Projects {
+				names = append(names, p.Name)
+			}
+			if resp.NextPageToken == "" {
+				break
+			}
+			token = resp.NextPageToken
+		}
+
+		assert.Equal(t, []string{"Echo", "Delta", "Charlie", "Bravo", "Alpha"}, names)
+	})
+
+	t.Run("ListProjects_Filters", func(t *testing.T) {
+		testdb.CleanupTables(t, pgContainer.DB, "projects")
+
+		ctx := context.Background()
+		old := &project.Project{Name: "Old Robotics", CreatedAt: time.Now().Add(-48 * time.Hour), UpdatedAt: time.Now().Add(-48 * time.Hour)}
+		recent := &project.Project{Name: "New Robotics"}
+		other := &project.Project{Name: "Chemistry"}
+		for _, p := range []*project.Project{old, recent, other} {
+			_, err := pgContainer.DB.NewInsert().Model(p).Exec(ctx)
+			require.NoError(t, err)
+		}
+
+		resp, err := grpcServer.ListProjects(ctx, &pb.ListProjectsRequest{NameContains: "robot"})
+		require.NoError(t, err)
+		assert.Len(t, resp.Projects, 2)
+		assert.Empty(t, resp.NextPageToken)
+
+		resp, err = grpcServer.ListProjects(ctx, &pb.ListProjectsRequest{
+			NameContains: "robot",
+			CreatedAfter: timestamppb.New(time.Now().Add(-time.Hour)),
+		})
+		require.NoError(t, err)
+		require.Len(t, resp.Projects, 1)
+		assert.Equal(t, "New Robotics", resp.Projects[0].Name)
+
+		resp, err = grpcServer.ListProjects(ctx, &pb.ListProjectsRequest{
+			UpdatedBefore: timestamppb.New(time.Now().Add(-time.Hour)),
+		})
+		require.NoError(t, err)
+		require.Len(t, resp.Projects, 1)
+		assert.Equal(t, "Old Robotics", resp.Projects[0].Name)
+	})
+
+	t.Run("ListProjects_InvalidArguments", func(t *testing.T) {
+		testdb.CleanupTables(t, pgContainer.DB, "projects")
+
+		ctx := context.Background()
+		for _, name := range []string{"One", "Two"} {
+			_, err := pgContainer.DB.NewInsert().Model(&project.Project{Name: name}).Exec(ctx)
+			require.NoError(t, err)
+		}
+
+		_, err := grpcServer.ListProjects(ctx, &pb.ListProjectsRequest{OrderBy: "owner"})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+		_, err = grpcServer.ListProjects(ctx, &pb.ListProjectsRequest{PageSize: -1})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+		_, err = grpcServer.ListProjects(ctx, &pb.ListProjectsRequest{PageToken: "garbage"})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+		// A token is only valid for the query that produced it
+		resp, err := grpcServer.ListProjects(ctx, &pb.ListProjectsRequest{PageSize: 1})
+		require.NoError(t, err)
+		require.NotEmpty(t, resp.NextPageToken)
+
+		_, err = grpcServer.ListProjects(ctx, &pb.ListProjectsRequest{PageSize: 1, PageToken: resp.NextPageToken, OrderBy: "name"})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+
+	t.Run("ProjectTags", func(t *testing.T) {
+		testdb.CleanupTables(t, pgContainer.DB, "projects", "tags", "project_tags")
+
+		ctx := context.Background()
+		created, err := grpcServer.CreateProject(ctx, &pb.CreateProjectRequest{
+			Name: "Compilers",
+			Tags: []string{"  CS 101 ", "fall-2026", "cs   101"},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"cs 101", "fall-2026"}, created.Project.Tags)
+		id := created.Project.Id
+
+		got, err := grpcServer.GetProject(ctx, &pb.GetProjectRequest{Id: id})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"cs 101", "fall-2026"}, got.Project.Tags)
+
+		// Without a mask non-empty tags replace the current ones and bump the version
+		updated, err := grpcServer.UpdateProject(ctx, &pb.UpdateProjectRequest{Id: id, Tags: []string{"compilers", "fall-2026"}})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"compilers", "fall-2026"}, updated.Project.Tags)
+		assert.Equal(t, got.Project.Version+1, updated.Project.Version)
+
+		// Other updates keep them
+		updated, err = grpcServer.UpdateProject(ctx, &pb.UpdateProjectRequest{Id: id, Description: "parsing"})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"compilers", "fall-2026"}, updated.Project.Tags)
+
+		// The mask clears them
+		updated, err = grpcServer.UpdateProject(ctx, &pb.UpdateProjectRequest{
+			Id:         id,
+			UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"tags"}},
+		})
+		require.NoError(t, err)
+		assert.Empty(t, updated.Project.Tags)
+
+		changes, err := grpcServer.GetProjectHistory(ctx, &pb.GetProjectHistoryRequest{Id: id})
+		require.NoError(t, err)
+		require.NotEmpty(t, changes.Entries)
+		assert.Equal(t, []interface{}{"compilers", "fall-2026"}, changes.Entries[0].Before.AsMap()["tags"])
+
+		tooMany := make([]string, project.MaxTagsPerProject+1)
+		for i := range tooMany {
+			tooMany[i] = fmt.Sprintf("tag-%d", i)
+		}
+		for _, tags := range [][]string{{" "}, {"a,b"}, {strings.Repeat("x", project.MaxTagLength+1)}, tooMany} {
+			_, err = grpcServer.CreateProject(ctx, &pb.CreateProjectRequest{Name: "Bad", Tags: tags})
+			assert.Equal(t, codes.InvalidArgument, status.Code(err))
+		}
+	})
+
+	t.Run("ListProjects_TagFilter", func(t *testing.T) {
+		testdb.CleanupTables(t, pgContainer.DB, "projects", "tags", "project_tags")
+
+		ctx := context.Background()
+		for _, req := range []*pb.CreateProjectRequest{
+			{Name: "Robots", Tags: []string{"cs101", "fall"}},
+			{Name: "Parsers", Tags: []string{"cs101", "spring"}},
+			{Name: "Cells", Tags: []string{"bio", "fall"}},
+			{Name: "Untagged"},
+		} {
+			_, err := grpcServer.CreateProject(ctx, req)
+			require.NoError(t, err)
+		}
+
+		names := func(resp *pb.ListProjectsResponse) []string {
+			var names []string
+			for _, p := range resp.Projects {
+				names = append(names, p.Name)
+			}
+			return names
+		}
+
+		resp, err := grpcServer.ListProjects(ctx, &pb.ListProjectsRequest{Tags: []string{"CS101", "bio"}})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"Robots", "Parsers", "Cells"}, names(resp))
+
+		resp, err = grpcServer.ListProjects(ctx, &pb.ListProjectsRequest{Tags: []string{"cs101", "fall"}, TagMatch: pb.TagMatch_TAG_MATCH_ALL})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"Robots"}, names(resp))
+
+		resp, err = grpcServer.ListProjects(ctx, &pb.ListProjectsRequest{Tags: []string{"fall"}, NameContains: "cell"})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"Cells"}, names(resp))
+
+		resp, err = grpcServer.ListProjects(ctx, &pb.ListProjectsRequest{Tags: []string{"unknown"}})
+		require.NoError(t, err)
+		assert.Empty(t, resp.Projects)
+
+		// Page tokens are tied to the tag filter
+		resp, err = grpcServer.ListProjects(ctx, &pb.ListProjectsRequest{PageSize: 1, Tags: []string{"cs101"}})
+		require.NoError(t, err)
+		require.NotEmpty(t, resp.NextPageToken)
+		_, err = grpcServer.ListProjects(ctx, &pb.ListProjectsRequest{PageSize: 1, PageToken: resp.NextPageToken, Tags: []string{"fall"}})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+
+	t.Run("ListRenameMergeTags", func(t *testing.T) {
+		testdb.CleanupTables(t, pgContainer.DB, "projects", "tags", "project_tags")
+
+		ctx := context.Background()
+		var ids []int32
+		for _, req := range []*pb.CreateProjectRequest{
+			{Name: "One", Tags: []string{"ml", "ai"}},
+			{Name: "Two", Tags: []string{"machine learning"}},
+			{Name: "Three", Tags: []string{"ml"}},
+		} {
+			resp, err := grpcServer.CreateProject(ctx, req)
+			require.NoError(t, err)
+			ids = append(ids, resp.Project.Id)
+		}
+		_, err := grpcServer.DeleteProject(ctx, &pb.DeleteProjectRequest{Id: ids[2]})
+		require.NoError(t, err)
+
+		list, err := grpcServer.ListTags(ctx, &pb.ListTagsRequest{})
+		require.NoError(t, err)
+		require.Len(t, list.Tags, 3)
+		assert.Equal(t, "ai", list.Tags[0].Name)
+		assert.Equal(t, "ml", list.Tags[2].Name)
+		// Deleted projects are not counted
+		assert.EqualValues(t, 1, list.Tags[2].ProjectCount)
+
+		list, err = grpcServer.ListTags(ctx, &pb.ListTagsRequest{Prefix: "M"})
+		require.NoError(t, err)
+		assert.Len(t, list.Tags, 2)
+
+		renamed, err := grpcServer.RenameTag(ctx, &pb.RenameTagRequest{Name: "ai", NewName: "Artificial Intelligence"})
+		require.NoError(t, err)
+		assert.Equal(t, "artificial intelligence", renamed.Tag.Name)
+		got, err := grpcServer.GetProject(ctx, &pb.GetProjectRequest{Id: ids[0]})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"artificial intelligence", "ml"}, got.Project.Tags)
+
+		_, err = grpcServer.RenameTag(ctx, &pb.RenameTagRequest{Name: "ml", NewName: "machine learning"})
+		assert.Equal(t, codes.AlreadyExists, status.Code(err))
+		_, err = grpcServer.RenameTag(ctx, &pb.RenameTagRequest{Name: "nope", NewName: "other"})
+		assert.Equal(t, codes.NotFound, status.Code(err))
+
+		// The target may be one of the sources
+		merged, err := grpcServer.MergeTags(ctx, &pb.MergeTagsRequest{Sources: []string{"ml", "machine learning"}, Target: "machine learning"})
+		require.NoError(t, err)
+		assert.Equal(t, "machine learning", merged.Tag.Name)
+		assert.EqualValues(t, 2, merged.Tag.ProjectCount)
+
+		_, err = grpcServer.RestoreProject(ctx, &pb.RestoreProjectRequest{Id: ids[2]})
+		require.NoError(t, err)
+		list, err = grpcServer.ListTags(ctx, &pb.ListTagsRequest{})
+		require.NoError(t, err)
+		require.Len(t, list.Tags, 2)
+		assert.Equal(t, "machine learning", list.Tags[1].Name)
+		assert.EqualValues(t, 3, list.Tags[1].ProjectCount)
+
+		_, err = grpcServer.MergeTags(ctx, &pb.MergeTagsRequest{Sources: []string{"ml"}, Target: "machine learning"})
+		assert.Equal(t, codes.NotFound, status.Code(err))
+		_, err = grpcServer.MergeTags(ctx, &pb.MergeTagsRequest{Sources: []string{"machine learning"}, Target: "machine learning"})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+
+	t.Run("AddMember", func(t *testing.T) {
+		testdb.CleanupTables(t, pgContainer.DB, "projects", "project_members")
+
+		ctx := context.Background()
+		p := &project.Project{Name: "Team Project"}
+		_, err := pgContainer.DB.NewInsert().Model(p).Exec(ctx)
+		require.NoError(t, err)
+
+		// Nobody owns the project yet, so the student may claim it
+		req := &pb.AddMemberRequest{
+			ProjectId: int32(p.ID),
+			StudentId: 42,
+			Role:      pb.MemberRole_MEMBER_ROLE_OWNER,
+			ActorId:   42,
+		}
+		resp, err := grpcServer.AddMember(ctx, req)
+
+		require.NoError(t, err)
+		require.NotNil(t, resp.Member)
+		assert.Equal(t, int32(p.ID), resp.Member.ProjectId)
+		assert.Equal(t, int32(42), resp.Member.StudentId)
+		assert.Equal(t, pb.MemberRole_MEMBER_ROLE_OWNER, resp.Member.Role)
+		assert.NotZero(t, resp.Member.CreatedAt)
+	})
+
+	t.Run("AddMember_GrantRequiresOwnerOrInstructor", func(t *testing.T) {
+		testdb.CleanupTables(t, pgContainer.DB, "projects", "project_members")
+
+		ctx := context.Background()
+		p := &project.Project{Name: "Team Project"}
+		_, err := pgContainer.DB.NewInsert().Model(p).Exec(ctx)
+		require.NoError(t, err)
+		id := int32(p.ID)
+
+		_, err = grpcServer.AddMember(ctx, &pb.AddMemberRequest{ProjectId: id, StudentId: 1, Role: pb.MemberRole_MEMBER_ROLE_OWNER, ActorId: 1})
+		require.NoError(t, err)
+		_, err = grpcServer.AddMember(ctx, &pb.AddMemberRequest{ProjectId: id, StudentId: 2, Role: pb.MemberRole_MEMBER_ROLE_CONTRIBUTOR, ActorId: 2})
+		require.NoError(t, err)
+
+		denied := []*pb.AddMemberRequest{
+			// A contributor can't make anyone, themselves included, an instructor
+			{ProjectId: id, StudentId: 3, Role: pb.MemberRole_MEMBER_ROLE_INSTRUCTOR, ActorId: 2},
+			// and an owned project can't be claimed
+			{ProjectId: id, StudentId: 4, Role: pb.MemberRole_MEMBER_ROLE_OWNER, ActorId: 4},
+			{ProjectId: id, StudentId: 5, Role: pb.MemberRole_MEMBER_ROLE_INSTRUCTOR},
+		}
+		for _, req := range denied {
+			_, err := grpcServer.AddMember(ctx, req)
+			assert.Equal(t, codes.PermissionDenied, status.Code(err), req.StudentId)
+		}
+
+		resp, err := grpcServer.AddMember(ctx, &pb.AddMemberRequest{ProjectId: id, StudentId: 3, Role: pb.MemberRole_MEMBER_ROLE_INSTRUCTOR, ActorId: 1})
+		require.NoError(t, err)
+		assert.Equal(t, pb.MemberRole_MEMBER_ROLE_INSTRUCTOR, resp.Member.Role)
+
+		// Instructors may grant instructor in turn
+		_, err = grpcServer.AddMember(ctx, &pb.AddMemberRequest{ProjectId: id, StudentId: 6, Role: pb.MemberRole_MEMBER_ROLE_INSTRUCTOR, ActorId: 3})
+		require.NoError(t, err)
+	})
+
+	t.Run("AddMember_Duplicate", func(t *testing.T) {
+		testdb.CleanupTables(t, pgContainer.DB, "projects", "project_members")
+
+		ctx := context.Background()
+		p := &project.Project{Name: "Team Project"}
+		_, err := pgContainer.DB.NewInsert().Model(p).Exec(ctx)
+		require.NoError(t, err)
+
+		req := &pb.AddMemberRequest{ProjectId: int32(p.ID), StudentId: 7, Role: pb.MemberRole_MEMBER_ROLE_VIEWER}
+		_, err = grpcServer.AddMember(ctx, req)
+		require.NoError(t, err)
+
+		_, err = grpcServer.AddMember(ctx, req)
+		require.Error(t, err)
+		assert.Equal(t, codes.AlreadyExists, status.Code(err))
+	})
+
+	t.Run("AddMember_ProjectNotFound", func(t *testing.T) {
+		testdb.CleanupTables(t, pgContainer.DB, "projects", "project_members")
+
+		ctx := context.Background()
+		req := &pb.AddMemberRequest{ProjectId: 99999, StudentId: 1, Role: pb.MemberRole_MEMBER_ROLE_CONTRIBUTOR}
+		_, err := grpcServer.AddMember(ctx, req)
+
+		require.Error(t, err)
+		assert.Equal(t, codes.NotFound, status.Code(err))
 	})
 
+	t.Run("ListMembers", func(t *testing.T) {
+		testdb.CleanupTables(t, pgContainer.DB, "projects", "project_members")
+
+		ctx := context.Background()
+		p := &project.Project{Name: "Team Project"}
+		_, err := pgContainer.DB.NewInsert().Model(p).Exec(ctx)
+		require.NoError(t, err)
+
+		members := []*project.ProjectMember{
+			{ProjectID: p.ID, StudentID: 1, Role: project.RoleOwner},
+			{ProjectID: p.ID, StudentID: 2, Role: project.RoleContributor},
+		}
+		for _, m := range members {
+			_, err := pgContainer.DB.NewInsert().Model(m).Exec(ctx)
+			require.NoError(t, err)
+		}
+
+		resp, err := grpcServer.ListMembers(ctx, &pb.ListMembersRequest{ProjectId: int32(p.ID)})
+
+		require.NoError(t, err)
+		require.Len(t, resp.Members, 2)
+		assert.Equal(t, int32(1), resp.Members[0].StudentId)
+		assert.Equal(t, pb.MemberRole_MEMBER_ROLE_OWNER, resp.Members[0].Role)
+		assert.Equal(t, int32(2), resp.Members[1].StudentId)
+		assert.Equal(t, pb.MemberRole_MEMBER_ROLE_CONTRIBUTOR, resp.Members[1].Role)
+	})
+
+	t.Run("ListMembers_TeamFilter", func(t *testing.T) {
+		testdb.CleanupTables(t, pgContainer.DB, "projects", "project_members")
+
+		ctx := context.Background()
+		p := &project.Project{Name: "Team Project"}
+		_, err := pgContainer.DB.NewInsert().Model(p).Exec(ctx)
+		require.NoError(t, err)
+
+		teamID := 42
+		members := []*project.ProjectMember{
+			{ProjectID: p.ID, StudentID: 1, Role: project.RoleOwner, TeamID: &teamID, TeamLead: true},
+			{ProjectID: p.ID, StudentID: 2, Role: project.RoleContributor},
+		}
+		_, err = pgContainer.DB.NewInsert().Model(&members).Exec(ctx)
+		require.NoError(t, err)
+
+		resp, err := grpcServer.ListMembers(ctx, &pb.ListMembersRequest{ProjectId: int32(p.ID), TeamId: int32(teamID)})
+
+		require.NoError(t, err)
+		require.Len(t, resp.Members, 1)
+		assert.Equal(t, int32(1), resp.Members[0].StudentId)
+		assert.Equal(t, int32(teamID), resp.Members[0].TeamId)
+		assert.True(t, resp.Members[0].TeamLead)
+
+		_, err = grpcServer.ListMembers(ctx, &pb.ListMembersRequest{ProjectId: int32(p.ID), TeamId: -1})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+
+	t.Run("RemoveMember", func(t *testing.T) {
+		testdb.CleanupTables(t, pgContainer.DB, "projects", "project_members")
+
+		ctx := context.Background()
+		p := &project.Project{Name: "Team Project"}
+		_, err := pgContainer.DB.NewInsert().Model(p).Exec(ctx)
+		require.NoError(t, err)
+
+		members := []*project.ProjectMember{
+			{ProjectID: p.ID, StudentID: 1, Role: project.RoleOwner},
+			{ProjectID: p.ID, StudentID: 2, Role: project.RoleInstructor},
+			{ProjectID: p.ID, StudentID: 3, Role: project.RoleContributor},
+			{ProjectID: p.ID, StudentID: 4, Role: project.RoleContributor},
+			{ProjectID: p.ID, StudentID: 5, Role: project.RoleViewer},
+		}
+		_, err = pgContainer.DB.NewInsert().Model(&members).Exec(ctx)
+		require.NoError(t, err)
+
+		remove := func(studentID, actorID int32) error {
+			_, err := grpcServer.RemoveMember(ctx, &pb.RemoveMemberRequest{ProjectId: int32(p.ID), StudentId: studentID, ActorId: actorID})
+			return err
+		}
+
+		// Members can leave on their own
+		require.NoError(t, remove(5, 5))
+		assert.Equal(t, codes.NotFound, status.Code(remove(5, 5)))
+
+		// Removing someone else takes an owner or instructor
+		assert.Equal(t, codes.PermissionDenied, status.Code(remove(4, 3)))
+		assert.Equal(t, codes.PermissionDenied, status.Code(remove(1, 99)))
+		assert.Equal(t, codes.InvalidArgument, status.Code(remove(4, 0)))
+		require.NoError(t, remove(4, 2))
+
+		// The project keeps at least one owner or instructor
+		require.NoError(t, remove(2, 1))
+		assert.Equal(t, codes.FailedPrecondition, status.Code(remove(1, 1)))
+
+		resp, err := grpcServer.ListMembers(ctx, &pb.ListMembersRequest{ProjectId: int32(p.ID)})
+		require.NoError(t, err)
+		require.Len(t, resp.Members, 2)
+		assert.Equal(t, int32(1), resp.Members[0].StudentId)
+		assert.Equal(t, int32(3), resp.Members[1].StudentId)
+	})
+
+	t.Run("ListProjectsForStudent", func(t *testing.T) {
+		testdb.CleanupTables(t, pgContainer.DB, "projects", "project_members")
+
+		ctx := context.Background()
+		projects := []*project.Project{
+			{Name: "Project One"},
+			{Name: "Project Two"},
+			{Name: "Project Three"},
+		}
+		for _, p := range projects {
+			_, err := pgContainer.DB.NewInsert().Model(p).Exec(ctx)
+			require.NoError(t, err)
+		}
+
+		members := []*project.ProjectMember{
+			{ProjectID: projects[0].ID, StudentID: 10, Role: project.RoleOwner},
+			{ProjectID: projects[2].ID, StudentID: 10, Role: project.RoleViewer},
+			{ProjectID: projects[1].ID, StudentID: 11, Role: project.RoleOwner},
+		}
+		for _, m := range members {
+			_, err := pgContainer.DB.NewInsert().Model(m).Exec(ctx)
+			require.NoError(t, err)
+		}
+
+		resp, err := grpcServer.ListProjectsForStudent(ctx, &pb.ListProjectsForStudentRequest{StudentId: 10})
+
+		require.NoError(t, err)
+		require.Len(t, resp.Projects, 2)
+		assert.Equal(t, "Project One", resp.Projects[0].Project.Name)
+		assert.Equal(t, pb.MemberRole_MEMBER_ROLE_OWNER, resp.Projects[0].Role)
+		assert.Equal(t, "Project Three", resp.Projects[1].Project.Name)
+		assert.Equal(t, pb.MemberRole_MEMBER_ROLE_VIEWER, resp.Projects[1].Role)
+	})
+	t.Run("GetProjectHistory", func(t *testing.T) {
+		testdb.CleanupTables(t, pgContainer.DB, "projects", "project_members", "history")
+
+		ctx := history.WithActor(context.Background(), "jane@example.com")
+		created, err := grpcServer.CreateProject(ctx, &pb.CreateProjectRequest{Name: "Audited"})
+		require.NoError(t, err)
+		id := created.Project.Id
+
+		_, err = grpcServer.UpdateProject(ctx, &pb.UpdateProjectRequest{
+			Id:         id,
+			Name:       "Audited v2",
+			UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"name"}},
+		})
+		require.NoError(t, err)
+		_, err = grpcServer.TransitionProject(ctx, &pb.TransitionProjectRequest{Id: id, Status: pb.ProjectStatus_PROJECT_STATUS_ACTIVE})
+		require.NoError(t, err)
+		_, err = grpcServer.AddMember(ctx, &pb.AddMemberRequest{ProjectId: id, StudentId: 10})
+		require.NoError(t, err)
+		// Changes without an actor are attributed to the system
+		_, err = grpcServer.DeleteProject(context.Background(), &pb.DeleteProjectRequest{Id: id})
+		require.NoError(t, err)
+
+		resp, err := grpcServer.GetProjectHistory(ctx, &pb.GetProjectHistoryRequest{Id: id})
+		require.NoError(t, err)
+		require.Len(t, resp.Entries, 5)
+		assert.Empty(t, resp.NextPageToken)
+
+		// Newest first
+		actions := make([]string, len(resp.Entries))
+		for i, e := range resp.Entries {
+			actions[i] = e.Action
+		}
+		assert.Equal(t, []string{"delete", "member_added", "update", "update", "create"}, actions)
+
+		assert.Equal(t, history.SystemActor, resp.Entries[0].Actor)
+		assert.Equal(t, "jane@example.com", resp.Entries[1].Actor)
+
+		rename := resp.Entries[3]
+		assert.Equal(t, map[string]interface{}{"name": "Audited"}, rename.Before.AsMap())
+		assert.Equal(t, map[string]interface{}{"name": "Audited v2"}, rename.After.AsMap())
+
+		transition := resp.Entries[2]
+		assert.Equal(t, map[string]interface{}{"status": "draft"}, transition.Before.AsMap())
+		assert.Equal(t, map[string]interface{}{"status": "active"}, transition.After.AsMap())
+
+		assert.Nil(t, resp.Entries[4].Before)
+		assert.Equal(t, "Audited", resp.Entries[4].After.AsMap()["name"])
+
+		// Paging
+		first, err := grpcServer.GetProjectHistory(ctx, &pb.GetProjectHistoryRequest{Id: id, PageSize: 3})
+		require.NoError(t, err)
+		require.Len(t, first.Entries, 3)
+		require.NotEmpty(t, first.NextPageToken)
+		second, err := grpcServer.GetProjectHistory(ctx, &pb.GetProjectHistoryRequest{Id: id, PageSize: 3, PageToken: first.NextPageToken})
+		require.NoError(t, err)
+		require.Len(t, second.Entries, 2)
+		assert.Equal(t, "create", second.Entries[1].Action)
+
+		_, err = grpcServer.GetProjectHistory(ctx, &pb.GetProjectHistoryRequest{Id: 999})
+		assert.Equal(t, codes.NotFound, status.Code(err))
+		_, err = grpcServer.GetProjectHistory(ctx, &pb.GetProjectHistoryRequest{Id: id, PageToken: "garbage"})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+
+	t.Run("WatchProjects", func(t *testing.T) {
+		testdb.CleanupTables(t, pgContainer.DB, "projects")
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+
+		existing := &project.Project{Name: "Existing"}
+		_, err := pgContainer.DB.NewInsert().Model(existing).Exec(ctx)
+		require.NoError(t, err)
+
+		// Serve over an in-memory listener with the same stats handler as the app
+		watchEvents := project.NewBroadcaster(project.DefaultWatchBufferSize)
+		watchServer := project.NewGrpcServer(project.NewService(repo, watchEvents), logger, mockServiceMetrics)
+
+		lis := bufconn.Listen(1 << 20)
+		server := grpc.NewServer(grpc.StatsHandler(otelgrpc.NewServerHandler()))
+		pb.RegisterProjectServiceServer(server, watchServer)
+		go server.Serve(lis)
+
+		conn, err := grpc.NewClient("passthrough:///bufnet",
+			grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+			grpc.WithTransportCredentials(insecure.NewCredentials()),
+		)
+		require.NoError(t, err)
+		defer conn.Close()
+
+		stream, err := pb.NewProjectServiceClient(conn).WatchProjects(ctx, &pb.WatchProjectsRequest{})
+		require.NoError(t, err)
+
+		msg, err := stream.Recv()
+		require.NoError(t, err)
+		snapshot := msg.GetSnapshot()
+		require.NotNil(t, snapshot)
+		assert.True(t, snapshot.Complete)
+		require.Len(t, snapshot.Projects, 1)
+		assert.Equal(t, "Existing", snapshot.Projects[0].Name)
+
+		created, err := watchServer.CreateProject(ctx, &pb.CreateProjectRequest{Name: "Watched"})
+		require.NoError(t, err)
+		_, err = watchServer.UpdateProject(ctx, &pb.UpdateProjectRequest{Id: created.Project.Id, Name: "Renamed"})
+		require.NoError(t, err)
+		_, err = watchServer.DeleteProject(ctx, &pb.DeleteProjectRequest{Id: created.Project.Id})
+		require.NoError(t, err)
+
+		wantTypes := []pb.ProjectEventType{
+			pb.ProjectEventType_PROJECT_EVENT_TYPE_CREATED,
+			pb.ProjectEventType_PROJECT_EVENT_TYPE_UPDATED,
+			pb.ProjectEventType_PROJECT_EVENT_TYPE_DELETED,
+		}
+		for _, want := range wantTypes {
+			msg, err := stream.Recv()
+			require.NoError(t, err)
+			event := msg.GetEvent()
+			require.NotNil(t, event)
+			assert.Equal(t, want, event.Type)
+			assert.Equal(t, created.Project.Id, event.Project.Id)
+		}
+
+		// Closing the broadcaster ends the stream so GracefulStop can return
+		watchEvents.Close()
+		_, err = stream.Recv()
+		assert.Equal(t, codes.Unavailable, status.Code(err))
+
+		stopped := make(chan struct{})
+		go func() {
+			server.GracefulStop()
+			close(stopped)
+		}()
+		select {
+		case <-stopped:
+		case <-ctx.Done():
+			t.Fatal("GracefulStop did not return")
+		}
+	})
 }
@@ -0,0 +1,168 @@
+package project
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
+
+const (
+	DefaultPageSize = 50
+	MaxPageSize     = 1000
+)
+
+// OrderField is a column ListProjects can be ordered by
+type OrderField string
+
+const (
+	OrderByID        OrderField = "id"
+	OrderByName      OrderField = "name"
+	OrderByCreatedAt OrderField = "created_at"
+	OrderByUpdatedAt OrderField = "updated_at"
+)
+
+// TagMatch says whether a project needs any or all of the tags of a ListFilter
+type TagMatch string
+
+const (
+	TagMatchAny TagMatch = "any"
+	TagMatchAll TagMatch = "all"
+)
+
+// ListFilter narrows ListProjects. Zero values mean "no filter"; time bounds
+// are inclusive on the lower end and exclusive on the upper end. An empty
+// TagMatch means TagMatchAny.
+type ListFilter struct {
+	NameContains  string
+	Status        Status
+	CreatedAfter  time.Time
+	CreatedBefore time.Time
+	UpdatedAfter  time.Time
+	UpdatedBefore time.Time
+	Tags          []string
+	TagMatch      TagMatch
+}
+
+// ListOptions is the caller-facing request for a page of projects
+type ListOptions struct {
+	ListFilter
+	PageSize  int
+	PageToken string
+	OrderBy   string
+}
+
+// ListQuery is the decoded form of ListOptions handed to the repository
+type ListQuery struct {
+	ListFilter
+	OrderBy OrderField
+	Desc    bool
+	After   *PageToken
+	Limit   int
+}
+
+// ListResult is one page of projects
+type ListResult struct {
+	Projects      []Project
+	NextPageToken string
+}
+
+// PageToken identifies the last row of a page. Fingerprint ties the token to the
+// filters and ordering of the request that produced it, as required by AIP-158.
+type PageToken struct {
+	Fingerprint string    `json:"f"`
+	ID          int       `json:"id"`
+	Name        string    `json:"n,omitempty"`
+	Time        time.Time `json:"t,omitempty"`
+}
+
+func newPageToken(fingerprint string, order OrderField, p *Project) *PageToken {
+	t := &PageToken{Fingerprint: fingerprint, ID: p.ID}
+	switch order {
+	case OrderByName:
+		t.Name = p.Name
+	case OrderByCreatedAt:
+		t.Time = p.CreatedAt
+	case OrderByUpdatedAt:
+		t.Time = p.UpdatedAt
+	}
+	return t
+}
+
+func (t *PageToken) value(order OrderField) interface{} {
+	switch order {
+	case OrderByName:
+		return t.Name
+	case OrderByCreatedAt, OrderByUpdatedAt:
+		return t.Time
+	}
+	return t.ID
+}
+
+// Encode returns the opaque string form of the token
+func (t *PageToken) Encode() string {
+	data, _ := json.Marshal(t)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// DecodePageToken parses a token produced by Encode
+func DecodePageToken(s string) (*PageToken, error) {
+	data, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, ErrInvalidPageToken
+	}
+	var t PageToken
+	if err := json.Unmarshal(data, &t); err != nil || t.ID <= 0 {
+		return nil, ErrInvalidPageToken
+	}
+	return &t, nil
+}
+
+// parseOrderBy parses an AIP-132 order_by expression limited to a single field
+func parseOrderBy(s string) (OrderField, bool, error) {
+	fields := strings.Fields(s)
+	if len(fields) == 0 {
+		return OrderByID, false, nil
+	}
+	if len(fields) > 2 {
+		return "", false, ErrInvalidInput
+	}
+
+	desc := false
+	if len(fields) == 2 {
+		switch strings.ToLower(fields[1]) {
+		case "asc":
+		case "desc":
+			desc = true
+		default:
+			return "", false, ErrInvalidInput
+		}
+	}
+
+	switch field := OrderField(fields[0]); field {
+	case OrderByID, OrderByName, OrderByCreatedAt, OrderByUpdatedAt:
+		return field, desc, nil
+	}
+	return "", false, ErrInvalidInput
+}
+
+// fingerprint hashes everything except the page size and token, so a token
+// cannot be replayed against a different query
+func fingerprint(f ListFilter, order OrderField, desc bool) string {
+	h := sha256.New()
+	fmt.Fprintf(h, "%s|%s|%s|%s|%s|%s|%s|%s|%s|%t",
+		f.NameContains, f.Status,
+		f.CreatedAfter.Format(time.RFC3339Nano), f.CreatedBefore.Format(time.RFC3339Nano),
+		f.UpdatedAfter.Format(time.RFC3339Nano), f.UpdatedBefore.Format(time.RFC3339Nano),
+		strings.Join(f.Tags, ","), f.TagMatch,
+		order, desc)
+	return hex.EncodeToString(h.Sum(nil)[:8])
+}
+
+// escapeLike escapes the LIKE wildcards in a user supplied pattern
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(s)
+}
@@ -1,7 +1,11 @@
 package project
 
 import (
+	"fmt"
+	"slices"
+	"strings"
 	"time"
+	"unicode/utf8"
 
 	"github.com/uptrace/bun"
 )
@@ -9,8 +13,147 @@ import (
 type Project struct {
 	bun.BaseModel `bun:"table:projects,alias:p"`
 
+	ID          int       `bun:"id,pk,autoincrement" json:"id"`
+	Name        string    `bun:"name,notnull" json:"name" validate:"required"`
+	Description string    `bun:"description,notnull,default:''" json:"description"`
+	Status      Status    `bun:"status,notnull,default:'draft'" json:"status"`
+	StartDate   time.Time `bun:"start_date,nullzero" json:"startDate,omitempty"`
+	DueDate     time.Time `bun:"due_date,nullzero" json:"dueDate,omitempty"`
+	CreatedAt   time.Time `bun:"created_at,notnull,default:current_timestamp" json:"createdAt"`
+	UpdatedAt   time.Time `bun:"updated_at,notnull,default:current_timestamp" json:"updatedAt"`
+	Version     int       `bun:"version,notnull,default:1" json:"version"` // incremented on every update
+	DeletedAt   time.Time `bun:"deleted_at,soft_delete,nullzero" json:"-"`
+
+	// Tags are stored in project_tags; the repository loads them sorted by name
+	Tags []string `bun:"-" json:"tags"`
+}
+
+// SearchResult is a project matching a full-text search, with its rank and
+// the name and description highlighted as HTML
+type SearchResult struct {
+	Project `bun:",extend"`
+
+	Rank                 float64 `bun:"rank,scanonly"`
+	NameHighlight        string  `bun:"name_highlight,scanonly"`
+	DescriptionHighlight string  `bun:"description_highlight,scanonly"`
+}
+
+// UpdatableColumns are the project fields UpdateProject may write. "tags" is
+// not a column; the repository replaces the project's rows in project_tags.
+var UpdatableColumns = []string{"name", "description", "start_date", "due_date", "tags"}
+
+// Tag limits; the length is counted in characters
+const (
+	MaxTagLength      = 64
+	MaxTagsPerProject = 20
+)
+
+type Tag struct {
+	bun.BaseModel `bun:"table:tags,alias:t"`
+
 	ID        int       `bun:"id,pk,autoincrement" json:"id"`
-	Name      string    `bun:"name,notnull" json:"name" validate:"required"`
+	Name      string    `bun:"name,notnull,unique" json:"name"`
 	CreatedAt time.Time `bun:"created_at,notnull,default:current_timestamp" json:"createdAt"`
-	UpdatedAt time.Time `bun:"updated_at,notnull,default:current_timestamp" json:"updatedAt"`
+
+	// ProjectCount is the number of live projects carrying the tag, filled by ListTags
+	ProjectCount int `bun:"project_count,scanonly" json:"projectCount"`
+}
+
+// ProjectTag links a tag to a project
+type ProjectTag struct {
+	bun.BaseModel `bun:"table:project_tags,alias:ptg"`
+
+	ProjectID int `bun:"project_id,pk"`
+	TagID     int `bun:"tag_id,pk"`
+}
+
+// NormalizeTag trims and lowercases a tag name and collapses inner whitespace
+func NormalizeTag(name string) (string, error) {
+	name = strings.ToLower(strings.Join(strings.Fields(name), " "))
+	if name == "" || utf8.RuneCountInString(name) > MaxTagLength || strings.Contains(name, ",") {
+		return "", fmt.Errorf("%w: %q", ErrInvalidTag, name)
+	}
+	return name, nil
+}
+
+// NormalizeTags normalizes every name with NormalizeTag and returns them
+// sorted and without duplicates. The result is never nil.
+func NormalizeTags(names []string) ([]string, error) {
+	tags := make([]string, 0, len(names))
+	for _, name := range names {
+		tag, err := NormalizeTag(name)
+		if err != nil {
+			return nil, err
+		}
+		tags = append(tags, tag)
+	}
+	slices.Sort(tags)
+	return slices.Compact(tags), nil
+}
+
+// Status is the lifecycle state of a project
+type Status string
+
+const (
+	StatusDraft     Status = "draft"
+	StatusActive    Status = "active"
+	StatusCompleted Status = "completed"
+	StatusArchived  Status = "archived"
+)
+
+// transitions lists the statuses each status may move to
+var transitions = map[Status][]Status{
+	StatusDraft:     {StatusActive, StatusArchived},
+	StatusActive:    {StatusCompleted, StatusArchived},
+	StatusCompleted: {StatusArchived},
+	StatusArchived:  {},
+}
+
+// Valid reports whether s is one of the known statuses
+func (s Status) Valid() bool {
+	_, ok := transitions[s]
+	return ok
+}
+
+// CanTransitionTo reports whether a project in status s may move to next
+func (s Status) CanTransitionTo(next Status) bool {
+	for _, allowed := range transitions[s] {
+		if allowed == next {
+			return true
+		}
+	}
+	return false
+}
+
+// Role is the role a student has within a project
+type Role string
+
+const (
+	RoleOwner       Role = "owner"
+	RoleContributor Role = "contributor"
+	RoleViewer      Role = "viewer"
+	RoleInstructor  Role = "instructor"
+)
+
+// Valid reports whether r is one of the known member roles
+func (r Role) Valid() bool {
+	switch r {
+	case RoleOwner, RoleContributor, RoleViewer, RoleInstructor:
+		return true
+	}
+	return false
+}
+
+type ProjectMember struct {
+	bun.BaseModel `bun:"table:project_members,alias:pm"`
+
+	ProjectID int       `bun:"project_id,pk" json:"projectId"`
+	StudentID int       `bun:"student_id,pk" json:"studentId"`
+	Role      Role      `bun:"role,notnull" json:"role"`
+	CreatedAt time.Time `bun:"created_at,notnull,default:current_timestamp" json:"createdAt"`
+	// TeamID is the team of the project the member is assigned to, if any
+	TeamID   *int `bun:"team_id" json:"teamId,omitempty"`
+	TeamLead bool `bun:"team_lead,notnull,default:false" json:"teamLead,omitempty"`
+
+	Project *Project `bun:"rel:belongs-to,join:project_id=id" json:"project,omitempty"`
 }
@@ -0,0 +1,57 @@
+package project_test
+
+import (
+	"strings"
+	"testing"
+
+	"project-service/internal/project"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStatusTransitions(t *testing.T) {
+	tests := []struct {
+		from, to project.Status
+		want     bool
+	}{
+		{project.StatusDraft, project.StatusActive, true},
+		{project.StatusDraft, project.StatusArchived, true},
+		{project.StatusDraft, project.StatusCompleted, false},
+		{project.StatusActive, project.StatusCompleted, true},
+		{project.StatusActive, project.StatusArchived, true},
+		{project.StatusActive, project.StatusDraft, false},
+		{project.StatusCompleted, project.StatusArchived, true},
+		{project.StatusCompleted, project.StatusActive, false},
+		{project.StatusArchived, project.StatusActive, false},
+		{project.StatusArchived, project.StatusDraft, false},
+		{project.StatusActive, project.StatusActive, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.from)+"_to_"+string(tt.to), func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.from.CanTransitionTo(tt.to))
+		})
+	}
+
+	assert.False(t, project.Status("paused").Valid())
+}
+
+func TestNormalizeTags(t *testing.T) {
+	tags, err := project.NormalizeTags([]string{" Fall  2026 ", "cs101", "CS101", "fall 2026"})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"cs101", "fall 2026"}, tags)
+
+	tags, err = project.NormalizeTags(nil)
+	assert.NoError(t, err)
+	assert.NotNil(t, tags)
+	assert.Empty(t, tags)
+
+	for _, name := range []string{"", "   ", "a,b", strings.Repeat("é", project.MaxTagLength+1)} {
+		_, err := project.NormalizeTags([]string{name})
+		assert.ErrorIs(t, err, project.ErrInvalidTag, name)
+	}
+
+	tag, err := project.NormalizeTag(strings.Repeat("é", project.MaxTagLength))
+	assert.NoError(t, err)
+	assert.Equal(t, strings.Repeat("é", project.MaxTagLength), tag)
+}
@@ -0,0 +1,105 @@
+package project
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+)
+
+// ChangeEvent is published on NATS for every project change, addressed to the
+// project's members. MemberID is the student a member_added event is about.
+type ChangeEvent struct {
+	Type       EventType `json:"type"`
+	ProjectID  int       `json:"projectId"`
+	Name       string    `json:"name,omitempty"`
+	Status     Status    `json:"status,omitempty"`
+	Version    int       `json:"version,omitempty"`
+	MemberID   int       `json:"memberId,omitempty"`
+	StudentIDs []int     `json:"studentIds"`
+	ChangedAt  time.Time `json:"changedAt"`
+}
+
+// Publisher sends an event to NATS. It is implemented by *messaging.Producer.
+type Publisher interface {
+	SendMessage(ctx context.Context, value interface{}) error
+}
+
+// publishTimeout bounds publishing one change, so a stuck NATS server cannot
+// stall the notifier
+const publishTimeout = 10 * time.Second
+
+// Notifier publishes the events of a Broadcaster on NATS. Every replica runs
+// one for the changes made through it.
+type Notifier struct {
+	events    *Broadcaster
+	repo      Repository
+	publisher Publisher
+	logger    *slog.Logger
+}
+
+func NewNotifier(events *Broadcaster, repo Repository, publisher Publisher, logger *slog.Logger) *Notifier {
+	return &Notifier{
+		events:    events,
+		repo:      repo,
+		publisher: publisher,
+		logger:    logger,
+	}
+}
+
+// Run publishes events until ctx is done or the broadcaster closes. If it
+// falls behind it subscribes again; the events it missed are not published.
+func (n *Notifier) Run(ctx context.Context) error {
+	for {
+		sub, err := n.events.Subscribe()
+		if err != nil {
+			return err
+		}
+		err = n.forward(ctx, sub)
+		sub.Close()
+		if !errors.Is(err, ErrWatcherTooSlow) {
+			return err
+		}
+		n.logger.WarnContext(ctx, "project notifier fell behind, some changes were not published")
+	}
+}
+
+func (n *Notifier) forward(ctx context.Context, sub *Subscription) error {
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-sub.Done():
+			return sub.Err()
+		case e := <-sub.Events():
+			if err := n.publish(ctx, e); err != nil {
+				n.logger.ErrorContext(ctx, "failed to publish project change", "error", err, "project_id", e.Project.ID)
+			}
+		}
+	}
+}
+
+func (n *Notifier) publish(ctx context.Context, e Event) error {
+	ctx, cancel := context.WithTimeout(ctx, publishTimeout)
+	defer cancel()
+
+	members, err := n.repo.ListMembers(ctx, e.Project.ID, 0)
+	if err != nil {
+		return err
+	}
+	studentIDs := make([]int, len(members))
+	for i, member := range members {
+		studentIDs[i] = member.StudentID
+	}
+
+	return n.publisher.SendMessage(ctx, ChangeEvent{
+		Type:       e.Type,
+		ProjectID:  e.Project.ID,
+		Name:       e.Project.Name,
+		Status:     e.Project.Status,
+		Version:    e.Project.Version,
+		MemberID:   e.StudentID,
+		StudentIDs: studentIDs,
+		ChangedAt:  time.Now().UTC(),
+	})
+}
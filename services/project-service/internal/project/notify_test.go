@@ -0,0 +1,86 @@
+package project_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"sync"
+	"testing"
+	"time"
+
+	"project-service/internal/project"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// memberRepo only answers ListMembers; project 1 has students 4 and 7
+type memberRepo struct {
+	project.Repository
+}
+
+func (memberRepo) ListMembers(ctx context.Context, projectID, teamID int) ([]project.ProjectMember, error) {
+	if projectID != 1 {
+		return nil, nil
+	}
+	return []project.ProjectMember{{ProjectID: 1, StudentID: 4}, {ProjectID: 1, StudentID: 7}}, nil
+}
+
+// changePublisher records events. Like a NATS flush, it refuses contexts
+// without a deadline.
+type changePublisher struct {
+	mu     sync.Mutex
+	events []project.ChangeEvent
+}
+
+func (p *changePublisher) SendMessage(ctx context.Context, value interface{}) error {
+	if _, ok := ctx.Deadline(); !ok {
+		return errors.New("context has no deadline")
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.events = append(p.events, value.(project.ChangeEvent))
+	return nil
+}
+
+func (p *changePublisher) published() []project.ChangeEvent {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return append([]project.ChangeEvent(nil), p.events...)
+}
+
+func TestNotifier(t *testing.T) {
+	b := project.NewBroadcaster(4)
+	publisher := &changePublisher{}
+	notifier := project.NewNotifier(b, memberRepo{}, publisher, slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+	done := make(chan error, 1)
+	go func() { done <- notifier.Run(context.Background()) }()
+	require.Eventually(t, func() bool { return b.Len() == 1 }, time.Second, 10*time.Millisecond)
+
+	b.Publish(project.Event{Type: project.EventUpdated, Project: project.Project{ID: 1, Name: "One", Status: project.StatusActive, Version: 3}})
+	require.Eventually(t, func() bool { return len(publisher.published()) == 1 }, time.Second, 10*time.Millisecond)
+
+	e := publisher.published()[0]
+	assert.Equal(t, project.EventUpdated, e.Type)
+	assert.Equal(t, 1, e.ProjectID)
+	assert.Equal(t, 3, e.Version)
+	assert.Equal(t, []int{4, 7}, e.StudentIDs)
+	assert.Zero(t, e.MemberID)
+
+	b.Publish(project.Event{Type: project.EventMemberAdded, Project: project.Project{ID: 1, Name: "One"}, StudentID: 7})
+	require.Eventually(t, func() bool { return len(publisher.published()) == 2 }, time.Second, 10*time.Millisecond)
+	e = publisher.published()[1]
+	assert.Equal(t, project.EventMemberAdded, e.Type)
+	assert.Equal(t, 7, e.MemberID)
+
+	// Closing the broadcaster at shutdown stops the notifier
+	b.Close()
+	select {
+	case err := <-done:
+		assert.ErrorIs(t, err, project.ErrBroadcasterClosed)
+	case <-time.After(time.Second):
+		t.Fatal("notifier did not stop")
+	}
+}
@@ -3,49 +3,102 @@ package project
 import (
 	"context"
 	"database/sql"
+	"errors"
+	"slices"
 	"time"
 
+	"grud/common/history"
 	"grud/common/metrics"
+	"grud/common/search"
 
 	"github.com/uptrace/bun"
+	"github.com/uptrace/bun/driver/pgdriver"
 )
 
 type Repository interface {
 	Create(ctx context.Context, project *Project) error
 	GetAll(ctx context.Context) ([]Project, error)
+	List(ctx context.Context, q ListQuery) ([]Project, error)
+	// Search returns up to limit projects matching the tsquery, best match first
+	Search(ctx context.Context, tsquery string, limit int) ([]SearchResult, error)
 	GetByID(ctx context.Context, id int) (*Project, error)
-	Update(ctx context.Context, project *Project) error
+	Update(ctx context.Context, project *Project, columns ...string) error
+	UpdateStatus(ctx context.Context, id int, from, to Status) (*Project, error)
+	// Delete soft deletes the project; its members are kept so Restore brings
+	// it back intact until Purge removes it for good
 	Delete(ctx context.Context, id int) error
+	Restore(ctx context.Context, id int) (*Project, error)
+	// Purge hard deletes projects soft deleted before the given time, with their members
+	Purge(ctx context.Context, deletedBefore time.Time) (int, error)
+
+	AddMember(ctx context.Context, member *ProjectMember) error
+	// RemoveMember fails with ErrLastManager rather than remove the project's
+	// last owner or instructor
+	RemoveMember(ctx context.Context, projectID, studentID int) error
+	// ListMembers returns the project's members, only those of the team if teamID is set
+	ListMembers(ctx context.Context, projectID, teamID int) ([]ProjectMember, error)
+	ListProjectsForStudent(ctx context.Context, studentID int) ([]ProjectMember, error)
+
+	// History returns a page of the project's change history, newest first
+	History(ctx context.Context, id int, pageSize int, pageToken string) (*history.Page, error)
+
+	// ListTags returns the tags starting with prefix, sorted by name, with their project counts
+	ListTags(ctx context.Context, prefix string) ([]Tag, error)
+	// RenameTag returns ErrTagNotFound if name does not exist and ErrTagExists if newName does
+	RenameTag(ctx context.Context, name, newName string) (*Tag, error)
+	// MergeTags moves the projects of the sources to target, creating it if
+	// needed, and deletes the sources. Every source must exist.
+	MergeTags(ctx context.Context, sources []string, target string) (*Tag, error)
 }
 
+// entityType identifies projects in the history table
+const entityType = "project"
+
+// ignoredHistoryFields change on every update and are left out of history diffs
+var ignoredHistoryFields = []string{"updatedAt", "version"}
+
 type repository struct {
 	db      *bun.DB
 	metrics *metrics.Metrics
+	history history.Recorder
 }
 
 func NewRepository(db *bun.DB, m *metrics.Metrics) Repository {
 	return &repository{
 		db:      db,
 		metrics: m,
+		history: history.Recorder{EntityType: entityType, Ignore: ignoredHistoryFields, Metrics: m},
 	}
 }
 
 func (r *repository) Create(ctx context.Context, project *Project) error {
-	start := time.Now()
-	_, err := r.db.NewInsert().Model(project).Exec(ctx)
+	return r.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		start := time.Now()
+		_, err := tx.NewInsert().Model(project).Exec(ctx)
 
-	r.metrics.Database.RecordQuery(ctx, "insert", "projects", time.Since(start), err)
+		r.metrics.Database.RecordQuery(ctx, "insert", "projects", time.Since(start), err)
 
-	if err != nil {
-		return err
-	}
+		if err != nil {
+			return err
+		}
 
-	start = time.Now()
-	err = r.db.NewSelect().Model(project).WherePK().Scan(ctx)
+		if err := r.setTags(ctx, tx, project.ID, project.Tags); err != nil {
+			return err
+		}
 
-	r.metrics.Database.RecordQuery(ctx, "select", "projects", time.Since(start), err)
+		start = time.Now()
+		err = tx.NewSelect().Model(project).WherePK().Scan(ctx)
 
-	return err
+		r.metrics.Database.RecordQuery(ctx, "select", "projects", time.Since(start), err)
+
+		if err != nil {
+			return err
+		}
+		if err := r.attachTags(ctx, tx, project); err != nil {
+			return err
+		}
+		return r.history.Record(ctx, tx, history.ActionCreate, project.ID, nil, project)
+	})
 }
 
 func (r *repository) GetAll(ctx context.Context) ([]Project, error) {
@@ -55,7 +108,100 @@ func (r *repository) GetAll(ctx context.Context) ([]Project, error) {
 
 	r.metrics.Database.RecordQuery(ctx, "select", "projects", time.Since(start), err)
 
-	return projects, err
+	if err != nil {
+		return nil, err
+	}
+	return projects, r.attachTags(ctx, r.db, pointers(projects)...)
+}
+
+// List returns up to q.Limit projects after the page token using keyset
+// pagination, ordered by the requested column with id as tie-breaker.
+func (r *repository) List(ctx context.Context, q ListQuery) ([]Project, error) {
+	start := time.Now()
+	projects := make([]Project, 0, q.Limit)
+
+	column := bun.Ident(string(q.OrderBy))
+	op, dir := bun.Safe(">"), bun.Safe("ASC")
+	if q.Desc {
+		op, dir = bun.Safe("<"), bun.Safe("DESC")
+	}
+
+	query := r.db.NewSelect().Model(&projects)
+	if q.NameContains != "" {
+		query.Where("name ILIKE ?", "%"+escapeLike(q.NameContains)+"%")
+	}
+	if q.Status != "" {
+		query.Where("status = ?", q.Status)
+	}
+	if !q.CreatedAfter.IsZero() {
+		query.Where("created_at >= ?", q.CreatedAfter)
+	}
+	if !q.CreatedBefore.IsZero() {
+		query.Where("created_at < ?", q.CreatedBefore)
+	}
+	if !q.UpdatedAfter.IsZero() {
+		query.Where("updated_at >= ?", q.UpdatedAfter)
+	}
+	if !q.UpdatedBefore.IsZero() {
+		query.Where("updated_at < ?", q.UpdatedBefore)
+	}
+	if len(q.Tags) > 0 {
+		tagged := r.db.NewSelect().
+			Model((*ProjectTag)(nil)).
+			Column("ptg.project_id").
+			Join("JOIN tags AS t ON t.id = ptg.tag_id").
+			Where("t.name IN (?)", bun.In(q.Tags))
+		if q.TagMatch == TagMatchAll {
+			// Tags are deduplicated, so a project has all of them when it matches as many rows
+			tagged.Group("ptg.project_id").Having("count(*) = ?", len(q.Tags))
+		}
+		query.Where("p.id IN (?)", tagged)
+	}
+	if q.After != nil {
+		if q.OrderBy == OrderByID {
+			query.Where("id ? ?", op, q.After.ID)
+		} else {
+			query.Where("(?, id) ? (?, ?)", column, op, q.After.value(q.OrderBy), q.After.ID)
+		}
+	}
+	query.OrderExpr("? ?", column, dir)
+	if q.OrderBy != OrderByID {
+		query.OrderExpr("id ?", dir)
+	}
+	err := query.Limit(q.Limit).Scan(ctx)
+
+	r.metrics.Database.RecordQuery(ctx, "select", "projects", time.Since(start), err)
+
+	if err != nil {
+		return nil, err
+	}
+	return projects, r.attachTags(ctx, r.db, pointers(projects)...)
+}
+
+func (r *repository) Search(ctx context.Context, tsquery string, limit int) ([]SearchResult, error) {
+	start := time.Now()
+	results := make([]SearchResult, 0, limit)
+	err := r.db.NewSelect().
+		Model(&results).
+		ColumnExpr("?TableColumns").
+		ColumnExpr(search.Rank+" AS rank", tsquery).
+		ColumnExpr(search.Headline("p.name", search.WholeField)+" AS name_highlight", tsquery).
+		ColumnExpr(search.Headline("p.description", search.Fragments)+" AS description_highlight", tsquery).
+		Where(search.Match, tsquery).
+		OrderExpr("rank DESC, p.id ASC").
+		Limit(limit).
+		Scan(ctx)
+
+	r.metrics.Database.RecordQuery(ctx, "search", "projects", time.Since(start), err)
+
+	if err != nil {
+		return nil, err
+	}
+	found := make([]*Project, len(results))
+	for i := range results {
+		found[i] = &results[i].Project
+	}
+	return results, r.attachTags(ctx, r.db, found...)
 }
 
 func (r *repository) GetByID(ctx context.Context, id int) (*Project, error) {
@@ -70,39 +216,568 @@ func (r *repository) GetByID(ctx context.Context, id int) (*Project, error) {
 		}
 		return nil, err
 	}
+	return project, r.attachTags(ctx, r.db, project)
+}
+
+// Update writes the given columns of project, or every user-editable column
+// when none are given. Status is only changed through UpdateStatus. When
+// project.Version is set the update only applies at that version, otherwise
+// ErrVersionConflict is returned; on success Version holds the new version.
+func (r *repository) Update(ctx context.Context, project *Project, columns ...string) error {
+	if len(columns) == 0 {
+		columns = UpdatableColumns
+	}
+	// Tags live in project_tags; the version still goes up when only they change
+	updateTags := slices.Contains(columns, "tags")
+	columns = slices.DeleteFunc(slices.Clone(columns), func(column string) bool { return column == "tags" })
+	columns = append(columns, "version")
+
+	return r.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		before, err := r.lockForUpdate(ctx, tx, project.ID)
+		if err != nil {
+			return err
+		}
+		// The row is locked, so the version cannot move between this check and the update
+		if project.Version != 0 && project.Version != before.Version {
+			return ErrVersionConflict
+		}
+		project.Version = before.Version + 1
+
+		start := time.Now()
+		_, err = tx.NewUpdate().
+			Model(project).
+			Column(columns...).
+			WherePK().
+			Exec(ctx)
+		r.metrics.Database.RecordQuery(ctx, "update", "projects", time.Since(start), err)
+
+		if err != nil {
+			return err
+		}
+
+		after := *before
+		if updateTags {
+			if err := r.setTags(ctx, tx, project.ID, project.Tags); err != nil {
+				return err
+			}
+			after.Tags = project.Tags
+		}
+		for _, column := range columns {
+			switch column {
+			case "name":
+				after.Name = project.Name
+			case "description":
+				after.Description = project.Description
+			case "start_date":
+				after.StartDate = project.StartDate
+			case "due_date":
+				after.DueDate = project.DueDate
+			}
+		}
+		return r.history.Record(ctx, tx, history.ActionUpdate, project.ID, before, &after)
+	})
+}
+
+// UpdateStatus moves the project from one status to another. The update only
+// applies while the row is still in status from, so concurrent transitions
+// cannot both succeed; ErrInvalidTransition is returned if it no longer is.
+func (r *repository) UpdateStatus(ctx context.Context, id int, from, to Status) (*Project, error) {
+	project := &Project{ID: id, Status: to}
+	err := r.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		start := time.Now()
+		result, err := tx.NewUpdate().
+			Model(project).
+			Set("status = ?", to).
+			Set("version = version + 1").
+			WherePK().
+			Where("status = ?", from).
+			Returning("*").
+			Exec(ctx)
+		r.metrics.Database.RecordQuery(ctx, "update", "projects", time.Since(start), err)
+
+		if err != nil {
+			return err
+		}
+		rowsAffected, err := result.RowsAffected()
+		if err != nil {
+			return err
+		}
+		if rowsAffected == 0 {
+			return ErrInvalidTransition
+		}
+		if err := r.attachTags(ctx, tx, project); err != nil {
+			return err
+		}
+
+		before := *project
+		before.Status = from
+		return r.history.Record(ctx, tx, history.ActionUpdate, id, &before, project)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return project, nil
+}
+
+func (r *repository) Delete(ctx context.Context, id int) error {
+	return r.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		before, err := r.lockForUpdate(ctx, tx, id)
+		if err != nil {
+			return err
+		}
+
+		start := time.Now()
+		_, err = tx.NewDelete().Model(&Project{ID: id}).WherePK().Exec(ctx)
+		r.metrics.Database.RecordQuery(ctx, "delete", "projects", time.Since(start), err)
+
+		if err != nil {
+			return err
+		}
+		return r.history.Record(ctx, tx, history.ActionDelete, id, before, nil)
+	})
+}
+
+// Restore clears deleted_at on a soft deleted project. A project that does not
+// exist or is not deleted yields ErrProjectNotFound.
+func (r *repository) Restore(ctx context.Context, id int) (*Project, error) {
+	project := new(Project)
+	err := r.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		start := time.Now()
+		result, err := tx.NewUpdate().
+			Model(project).
+			Set("deleted_at = NULL").
+			Where("id = ?", id).
+			WhereDeleted().
+			Returning("*").
+			Exec(ctx)
+		r.metrics.Database.RecordQuery(ctx, "update", "projects", time.Since(start), err)
+
+		if err != nil {
+			return err
+		}
+		rowsAffected, err := result.RowsAffected()
+		if err != nil {
+			return err
+		}
+		if rowsAffected == 0 {
+			return ErrProjectNotFound
+		}
+		if err := r.attachTags(ctx, tx, project); err != nil {
+			return err
+		}
+		return r.history.Record(ctx, tx, history.ActionRestore, id, nil, project)
+	})
+	if err != nil {
+		return nil, err
+	}
 	return project, nil
 }
 
-func (r *repository) Update(ctx context.Context, project *Project) error {
+func (r *repository) Purge(ctx context.Context, deletedBefore time.Time) (int, error) {
+	var purged int
+	err := r.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		expired := tx.NewSelect().
+			Model((*Project)(nil)).
+			Column("id").
+			WhereDeleted().
+			Where("deleted_at < ?", deletedBefore)
+
+		start := time.Now()
+		_, err := tx.NewDelete().
+			Model((*ProjectMember)(nil)).
+			Where("project_id IN (?)", expired).
+			Exec(ctx)
+		r.metrics.Database.RecordQuery(ctx, "purge", "project_members", time.Since(start), err)
+
+		if err != nil {
+			return err
+		}
+
+		start = time.Now()
+		_, err = tx.NewDelete().
+			Model((*ProjectTag)(nil)).
+			Where("project_id IN (?)", expired).
+			Exec(ctx)
+		r.metrics.Database.RecordQuery(ctx, "purge", "project_tags", time.Since(start), err)
+
+		if err != nil {
+			return err
+		}
+
+		start = time.Now()
+		result, err := tx.NewDelete().
+			Model((*Project)(nil)).
+			WhereDeleted().
+			Where("deleted_at < ?", deletedBefore).
+			ForceDelete().
+			Exec(ctx)
+		r.metrics.Database.RecordQuery(ctx, "purge", "projects", time.Since(start), err)
+
+		if err != nil {
+			return err
+		}
+		rowsAffected, err := result.RowsAffected()
+		purged = int(rowsAffected)
+		return err
+	})
+	return purged, err
+}
+
+func (r *repository) AddMember(ctx context.Context, member *ProjectMember) error {
+	return r.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		start := time.Now()
+		_, err := tx.NewInsert().Model(member).Returning("*").Exec(ctx)
+		r.metrics.Database.RecordQuery(ctx, "insert", "project_members", time.Since(start), err)
+
+		if isUniqueViolation(err) {
+			return ErrMemberExists
+		}
+		if err != nil {
+			return err
+		}
+		return r.history.Record(ctx, tx, history.ActionMemberAdded, member.ProjectID, nil, member)
+	})
+}
+
+func (r *repository) RemoveMember(ctx context.Context, projectID, studentID int) error {
+	return r.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		// Removals of the same project wait for each other, so two of them
+		// cannot each leave the other's manager as the last one
+		if _, err := r.lockForUpdate(ctx, tx, projectID); err != nil {
+			return err
+		}
+
+		start := time.Now()
+		member := new(ProjectMember)
+		result, err := tx.NewDelete().
+			Model(member).
+			Where("project_id = ?", projectID).
+			Where("student_id = ?", studentID).
+			Returning("*").
+			Exec(ctx)
+		r.metrics.Database.RecordQuery(ctx, "delete", "project_members", time.Since(start), err)
+
+		if err != nil {
+			return err
+		}
+		rowsAffected, err := result.RowsAffected()
+		if err != nil {
+			return err
+		}
+		if rowsAffected == 0 {
+			return ErrMemberNotFound
+		}
+
+		if member.Role == RoleOwner || member.Role == RoleInstructor {
+			start = time.Now()
+			managers, err := tx.NewSelect().
+				Model((*ProjectMember)(nil)).
+				Where("project_id = ?", projectID).
+				Where("role IN (?)", bun.In([]Role{RoleOwner, RoleInstructor})).
+				Count(ctx)
+			r.metrics.Database.RecordQuery(ctx, "select", "project_members", time.Since(start), err)
+			if err != nil {
+				return err
+			}
+			if managers == 0 {
+				return ErrLastManager
+			}
+		}
+		return r.history.Record(ctx, tx, history.ActionMemberRemoved, projectID, member, nil)
+	})
+}
+
+func (r *repository) ListMembers(ctx context.Context, projectID, teamID int) ([]ProjectMember, error) {
+	start := time.Now()
+	var members []ProjectMember
+	query := r.db.NewSelect().
+		Model(&members).
+		Where("pm.project_id = ?", projectID).
+		Order("pm.created_at ASC", "pm.student_id ASC")
+	if teamID != 0 {
+		query = query.Where("pm.team_id = ?", teamID)
+	}
+	err := query.Scan(ctx)
+	r.metrics.Database.RecordQuery(ctx, "select", "project_members", time.Since(start), err)
+
+	return members, err
+}
+
+func (r *repository) ListProjectsForStudent(ctx context.Context, studentID int) ([]ProjectMember, error) {
+	start := time.Now()
+	var members []ProjectMember
+	err := r.db.NewSelect().
+		Model(&members).
+		Relation("Project").
+		Where("pm.student_id = ?", studentID).
+		// The join only hides the project, so skip memberships of deleted projects
+		Where("project.id IS NOT NULL").
+		Order("pm.project_id ASC").
+		Scan(ctx)
+	r.metrics.Database.RecordQuery(ctx, "select", "project_members", time.Since(start), err)
+
+	if err != nil {
+		return nil, err
+	}
+	projects := make([]*Project, 0, len(members))
+	for _, m := range members {
+		if m.Project != nil {
+			projects = append(projects, m.Project)
+		}
+	}
+	return members, r.attachTags(ctx, r.db, projects...)
+}
+
+func (r *repository) History(ctx context.Context, id int, pageSize int, pageToken string) (*history.Page, error) {
+	return r.history.List(ctx, r.db, id, pageSize, pageToken)
+}
+
+// lockForUpdate loads the project and locks its row until tx ends, so the
+// history entry sees the state the change was applied to
+func (r *repository) lockForUpdate(ctx context.Context, tx bun.Tx, id int) (*Project, error) {
+	project, err := history.Lock[Project](ctx, tx, r.metrics, id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrProjectNotFound
+		}
+		return nil, err
+	}
+	return project, r.attachTags(ctx, tx, project)
+}
+
+func (r *repository) ListTags(ctx context.Context, prefix string) ([]Tag, error) {
+	start := time.Now()
+	tags := []Tag{}
+	query := r.db.NewSelect().
+		Model(&tags).
+		ColumnExpr("?TableColumns").
+		// Projects of a tag that are soft deleted are not counted
+		ColumnExpr("count(p.id) AS project_count").
+		Join("LEFT JOIN project_tags AS ptg ON ptg.tag_id = t.id").
+		Join("LEFT JOIN projects AS p ON p.id = ptg.project_id AND p.deleted_at IS NULL").
+		Group("t.id").
+		Order("t.name ASC")
+	if prefix != "" {
+		query.Where("t.name LIKE ?", escapeLike(prefix)+"%")
+	}
+	err := query.Scan(ctx)
+	r.metrics.Database.RecordQuery(ctx, "select", "tags", time.Since(start), err)
+
+	return tags, err
+}
+
+func (r *repository) RenameTag(ctx context.Context, name, newName string) (*Tag, error) {
 	start := time.Now()
 	result, err := r.db.NewUpdate().
-		Model(project).
-		Column("name").
-		WherePK().
+		Model((*Tag)(nil)).
+		Set("name = ?", newName).
+		Where("name = ?", name).
 		Exec(ctx)
-	r.metrics.Database.RecordQuery(ctx, "update", "projects", time.Since(start), err)
+	r.metrics.Database.RecordQuery(ctx, "update", "tags", time.Since(start), err)
 
+	if isUniqueViolation(err) {
+		return nil, ErrTagExists
+	}
 	if err != nil {
-		return err
+		return nil, err
 	}
 	rowsAffected, err := result.RowsAffected()
 	if err != nil {
-		return err
+		return nil, err
 	}
 	if rowsAffected == 0 {
-		return ErrProjectNotFound
+		return nil, ErrTagNotFound
 	}
-	return nil
+	return r.tagByName(ctx, r.db, newName)
 }
 
-func (r *repository) Delete(ctx context.Context, id int) error {
+func (r *repository) MergeTags(ctx context.Context, sources []string, target string) (*Tag, error) {
+	var merged *Tag
+	err := r.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		if err := r.ensureTags(ctx, tx, []string{target}); err != nil {
+			return err
+		}
+
+		start := time.Now()
+		var ids []int
+		err := tx.NewSelect().
+			Model((*Tag)(nil)).
+			Column("id").
+			Where("name IN (?)", bun.In(sources)).
+			For("UPDATE").
+			Scan(ctx, &ids)
+		r.metrics.Database.RecordQuery(ctx, "select", "tags", time.Since(start), err)
+
+		if err != nil {
+			return err
+		}
+		if len(ids) != len(sources) {
+			return ErrTagNotFound
+		}
+
+		// Projects already carrying the target keep a single link
+		start = time.Now()
+		_, err = tx.NewRaw(`
+			INSERT INTO project_tags (project_id, tag_id)
+			SELECT DISTINCT ptg.project_id, t.id
+			FROM project_tags AS ptg, tags AS t
+			WHERE ptg.tag_id IN (?) AND t.name = ?
+			ON CONFLICT DO NOTHING`, bun.In(ids), target).
+			Exec(ctx)
+		r.metrics.Database.RecordQuery(ctx, "insert", "project_tags", time.Since(start), err)
+
+		if err != nil {
+			return err
+		}
+
+		start = time.Now()
+		_, err = tx.NewDelete().
+			Model((*ProjectTag)(nil)).
+			Where("tag_id IN (?)", bun.In(ids)).
+			Exec(ctx)
+		r.metrics.Database.RecordQuery(ctx, "delete", "project_tags", time.Since(start), err)
+
+		if err != nil {
+			return err
+		}
+
+		start = time.Now()
+		_, err = tx.NewDelete().
+			Model((*Tag)(nil)).
+			Where("id IN (?)", bun.In(ids)).
+			Exec(ctx)
+		r.metrics.Database.RecordQuery(ctx, "delete", "tags", time.Since(start), err)
+
+		if err != nil {
+			return err
+		}
+
+		merged, err = r.tagByName(ctx, tx, target)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return merged, nil
+}
+
+// tagByName loads a tag with its project count
+func (r *repository) tagByName(ctx context.Context, db bun.IDB, name string) (*Tag, error) {
 	start := time.Now()
-	_, err := r.db.NewDelete().Model(&Project{ID: id}).WherePK().Exec(ctx)
-	r.metrics.Database.RecordQuery(ctx, "delete", "projects", time.Since(start), err)
+	tag := new(Tag)
+	err := db.NewSelect().
+		Model(tag).
+		ColumnExpr("?TableColumns").
+		ColumnExpr("count(p.id) AS project_count").
+		Join("LEFT JOIN project_tags AS ptg ON ptg.tag_id = t.id").
+		Join("LEFT JOIN projects AS p ON p.id = ptg.project_id AND p.deleted_at IS NULL").
+		Where("t.name = ?", name).
+		Group("t.id").
+		Scan(ctx)
+	r.metrics.Database.RecordQuery(ctx, "select", "tags", time.Since(start), err)
 
 	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrTagNotFound
+		}
+		return nil, err
+	}
+	return tag, nil
+}
+
+// ensureTags creates the tags that do not exist yet
+func (r *repository) ensureTags(ctx context.Context, tx bun.Tx, names []string) error {
+	tags := make([]Tag, len(names))
+	for i, name := range names {
+		tags[i].Name = name
+	}
+
+	start := time.Now()
+	_, err := tx.NewInsert().
+		Model(&tags).
+		On("CONFLICT (name) DO NOTHING").
+		Returning("NULL").
+		Exec(ctx)
+	r.metrics.Database.RecordQuery(ctx, "insert", "tags", time.Since(start), err)
+
+	return err
+}
+
+// setTags replaces the tags of a project with names, creating missing tags
+func (r *repository) setTags(ctx context.Context, tx bun.Tx, projectID int, names []string) error {
+	start := time.Now()
+	_, err := tx.NewDelete().
+		Model((*ProjectTag)(nil)).
+		Where("project_id = ?", projectID).
+		Exec(ctx)
+	r.metrics.Database.RecordQuery(ctx, "delete", "project_tags", time.Since(start), err)
+
+	if err != nil || len(names) == 0 {
 		return err
 	}
+	if err := r.ensureTags(ctx, tx, names); err != nil {
+		return err
+	}
+
+	start = time.Now()
+	_, err = tx.NewRaw(`
+		INSERT INTO project_tags (project_id, tag_id)
+		SELECT ?, id FROM tags WHERE name IN (?)`, projectID, bun.In(names)).
+		Exec(ctx)
+	r.metrics.Database.RecordQuery(ctx, "insert", "project_tags", time.Since(start), err)
 
+	return err
+}
+
+// attachTags loads the tags of the projects, sorted by name. Projects without
+// tags get an empty slice, so history diffs never compare null with [].
+func (r *repository) attachTags(ctx context.Context, db bun.IDB, projects ...*Project) error {
+	if len(projects) == 0 {
+		return nil
+	}
+	byID := make(map[int]*Project, len(projects))
+	ids := make([]int, len(projects))
+	for i, p := range projects {
+		p.Tags = []string{}
+		byID[p.ID] = p
+		ids[i] = p.ID
+	}
+
+	start := time.Now()
+	var rows []struct {
+		ProjectID int    `bun:"project_id"`
+		Name      string `bun:"name"`
+	}
+	err := db.NewSelect().
+		TableExpr("project_tags AS ptg").
+		Join("JOIN tags AS t ON t.id = ptg.tag_id").
+		ColumnExpr("ptg.project_id, t.name").
+		Where("ptg.project_id IN (?)", bun.In(ids)).
+		OrderExpr("t.name ASC").
+		Scan(ctx, &rows)
+	r.metrics.Database.RecordQuery(ctx, "select", "project_tags", time.Since(start), err)
+
+	if err != nil {
+		return err
+	}
+	for _, row := range rows {
+		p := byID[row.ProjectID]
+		p.Tags = append(p.Tags, row.Name)
+	}
 	return nil
 }
+
+// pointers returns pointers to the elements of projects
+func pointers(projects []Project) []*Project {
+	ptrs := make([]*Project, len(projects))
+	for i := range projects {
+		ptrs[i] = &projects[i]
+	}
+	return ptrs
+}
+
+// isUniqueViolation reports whether err is a PostgreSQL unique_violation (23505)
+func isUniqueViolation(err error) bool {
+	var pgErr pgdriver.Error
+	return errors.As(err, &pgErr) && pgErr.Field('C') == "23505"
+}
@@ -3,47 +3,468 @@ package project
 import (
 	"context"
 	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"grud/common/history"
+	"grud/common/search"
 )
 
 var (
-	ErrProjectNotFound = errors.New("project not found")
-	ErrInvalidInput    = errors.New("invalid input")
+	ErrProjectNotFound   = errors.New("project not found")
+	ErrInvalidInput      = errors.New("invalid input")
+	ErrMemberNotFound    = errors.New("member not found")
+	ErrMemberExists      = errors.New("student is already a member of the project")
+	ErrInvalidRole       = errors.New("invalid member role")
+	ErrInvalidPageToken  = errors.New("invalid page token")
+	ErrInvalidStatus     = errors.New("invalid project status")
+	ErrInvalidTransition = errors.New("illegal project status transition")
+	ErrVersionConflict   = errors.New("project was modified concurrently")
+	ErrInvalidTag        = errors.New("invalid tag")
+	ErrTagNotFound       = errors.New("tag not found")
+	ErrTagExists         = errors.New("tag already exists")
+	ErrNotPermitted      = errors.New("only owners and instructors of the project may grant that role")
+	ErrCannotRemove      = errors.New("only owners and instructors of the project may remove other members")
+	ErrLastManager       = errors.New("the last owner or instructor of a project cannot be removed")
 )
 
 type Service interface {
 	CreateProject(ctx context.Context, project *Project) error
 	GetAllProjects(ctx context.Context) ([]Project, error)
+	ListProjects(ctx context.Context, opts ListOptions) (*ListResult, error)
+	// SearchProjects returns up to limit projects matching the full-text query,
+	// best match first. Every word of query must match a word prefix.
+	SearchProjects(ctx context.Context, query string, limit int) ([]SearchResult, error)
 	GetProjectByID(ctx context.Context, id int) (*Project, error)
-	UpdateProject(ctx context.Context, project *Project) error
+	// UpdateProject writes the given UpdatableColumns of project, or all of them when none are given.
+	// A non-zero project.Version must match the stored version.
+	UpdateProject(ctx context.Context, project *Project, fields ...string) error
+	TransitionProject(ctx context.Context, id int, to Status) (*Project, error)
 	DeleteProject(ctx context.Context, id int) error
+	RestoreProject(ctx context.Context, id int) (*Project, error)
+	// PurgeDeleted hard deletes projects soft deleted more than retention ago
+	PurgeDeleted(ctx context.Context, retention time.Duration) (int, error)
+
+	// AddMember adds a member on behalf of actorID. Only owners and
+	// instructors of the project may grant the owner and instructor roles.
+	AddMember(ctx context.Context, member *ProjectMember, actorID int) error
+	// RemoveMember removes a member on behalf of actorID. Members may remove
+	// themselves; removing others takes an owner or instructor of the
+	// project. The last owner or instructor cannot be removed.
+	RemoveMember(ctx context.Context, projectID, studentID, actorID int) error
+	// ListMembers returns the project's members, only those of the team if teamID is set
+	ListMembers(ctx context.Context, projectID, teamID int) ([]ProjectMember, error)
+	ListProjectsForStudent(ctx context.Context, studentID int) ([]ProjectMember, error)
+
+	// GetProjectHistory returns a page of the project's change history, newest first.
+	// The history outlives soft deletion.
+	GetProjectHistory(ctx context.Context, id int, pageSize int, pageToken string) (*history.Page, error)
+
+	// WatchProjects subscribes to project changes made through this service
+	WatchProjects() (*Subscription, error)
+
+	ListTags(ctx context.Context, prefix string) ([]Tag, error)
+	// RenameTag and MergeTags change the tags of projects in place, without
+	// bumping their versions or recording history
+	RenameTag(ctx context.Context, name, newName string) (*Tag, error)
+	MergeTags(ctx context.Context, sources []string, target string) (*Tag, error)
 }
 
 type service struct {
-	repo Repository
+	repo   Repository
+	events *Broadcaster
 }
 
-func NewService(repo Repository) Service {
+// NewService creates the project service. Committed changes are published to
+// events, which may be nil when nobody watches.
+func NewService(repo Repository, events *Broadcaster) Service {
 	return &service{
-		repo: repo,
+		repo:   repo,
+		events: events,
 	}
 }
 
 func (s *service) CreateProject(ctx context.Context, project *Project) error {
-	return s.repo.Create(ctx, project)
+	// New projects always start as drafts
+	project.Status = StatusDraft
+	if err := validateProject(project); err != nil {
+		return err
+	}
+	if err := s.repo.Create(ctx, project); err != nil {
+		return err
+	}
+
+	s.events.Publish(Event{Type: EventCreated, Project: *project})
+	return nil
 }
 
 func (s *service) GetAllProjects(ctx context.Context) ([]Project, error) {
 	return s.repo.GetAll(ctx)
 }
 
+func (s *service) ListProjects(ctx context.Context, opts ListOptions) (*ListResult, error) {
+	if opts.PageSize < 0 {
+		return nil, ErrInvalidInput
+	}
+	if opts.PageSize == 0 {
+		opts.PageSize = DefaultPageSize
+	}
+	if opts.PageSize > MaxPageSize {
+		opts.PageSize = MaxPageSize
+	}
+	if opts.Status != "" && !opts.Status.Valid() {
+		return nil, ErrInvalidStatus
+	}
+	tags, err := NormalizeTags(opts.Tags)
+	if err != nil {
+		return nil, err
+	}
+	opts.Tags = tags
+	switch opts.TagMatch {
+	case "":
+		opts.TagMatch = TagMatchAny
+	case TagMatchAny, TagMatchAll:
+	default:
+		return nil, ErrInvalidInput
+	}
+
+	order, desc, err := parseOrderBy(opts.OrderBy)
+	if err != nil {
+		return nil, err
+	}
+	fp := fingerprint(opts.ListFilter, order, desc)
+
+	q := ListQuery{
+		ListFilter: opts.ListFilter,
+		OrderBy:    order,
+		Desc:       desc,
+		Limit:      opts.PageSize + 1, // one extra row tells us whether there is a next page
+	}
+	if opts.PageToken != "" {
+		token, err := DecodePageToken(opts.PageToken)
+		if err != nil {
+			return nil, err
+		}
+		if token.Fingerprint != fp {
+			return nil, ErrInvalidPageToken
+		}
+		q.After = token
+	}
+
+	projects, err := s.repo.List(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &ListResult{Projects: projects}
+	if len(projects) > opts.PageSize {
+		result.Projects = projects[:opts.PageSize]
+		result.NextPageToken = newPageToken(fp, order, &result.Projects[opts.PageSize-1]).Encode()
+	}
+	return result, nil
+}
+
+func (s *service) SearchProjects(ctx context.Context, query string, limit int) ([]SearchResult, error) {
+	tsquery := search.PrefixQuery(query)
+	if tsquery == "" || limit < 0 {
+		return nil, ErrInvalidInput
+	}
+	if limit == 0 {
+		limit = search.DefaultLimit
+	}
+	return s.repo.Search(ctx, tsquery, min(limit, search.MaxLimit))
+}
+
 func (s *service) GetProjectByID(ctx context.Context, id int) (*Project, error) {
+	if id <= 0 {
+		return nil, ErrInvalidInput
+	}
 	return s.repo.GetByID(ctx, id)
 }
 
-func (s *service) UpdateProject(ctx context.Context, project *Project) error {
-	return s.repo.Update(ctx, project)
+func (s *service) UpdateProject(ctx context.Context, project *Project, fields ...string) error {
+	if project.ID <= 0 {
+		return ErrInvalidInput
+	}
+	if len(fields) == 0 {
+		fields = UpdatableColumns
+	}
+
+	// Apply the requested fields onto the stored row so the result is validated as a whole
+	current, err := s.repo.GetByID(ctx, project.ID)
+	if err != nil {
+		return err
+	}
+	for _, field := range fields {
+		switch field {
+		case "name":
+			current.Name = project.Name
+		case "description":
+			current.Description = project.Description
+		case "start_date":
+			current.StartDate = project.StartDate
+		case "due_date":
+			current.DueDate = project.DueDate
+		case "tags":
+			current.Tags = project.Tags
+		default:
+			return ErrInvalidInput
+		}
+	}
+	if err := validateProject(current); err != nil {
+		return err
+	}
+
+	// The repository checks the caller's expected version, not the one just read
+	current.Version = project.Version
+	if err := s.repo.Update(ctx, current, fields...); err != nil {
+		return err
+	}
+
+	// Reload so watchers see the full row including the trigger-set updated_at
+	updated, err := s.repo.GetByID(ctx, project.ID)
+	if err != nil {
+		return err
+	}
+	*project = *updated
+
+	s.events.Publish(Event{Type: EventUpdated, Project: *updated})
+	return nil
+}
+
+func (s *service) TransitionProject(ctx context.Context, id int, to Status) (*Project, error) {
+	if id <= 0 {
+		return nil, ErrInvalidInput
+	}
+	if !to.Valid() {
+		return nil, ErrInvalidStatus
+	}
+
+	current, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if !current.Status.CanTransitionTo(to) {
+		return nil, fmt.Errorf("%w: %s -> %s", ErrInvalidTransition, current.Status, to)
+	}
+
+	updated, err := s.repo.UpdateStatus(ctx, id, current.Status, to)
+	if err != nil {
+		return nil, err
+	}
+
+	s.events.Publish(Event{Type: EventUpdated, Project: *updated})
+	return updated, nil
 }
 
 func (s *service) DeleteProject(ctx context.Context, id int) error {
-	return s.repo.Delete(ctx, id)
+	if id <= 0 {
+		return ErrInvalidInput
+	}
+	if err := s.repo.Delete(ctx, id); err != nil {
+		return err
+	}
+
+	s.events.Publish(Event{Type: EventDeleted, Project: Project{ID: id}})
+	return nil
+}
+
+func (s *service) RestoreProject(ctx context.Context, id int) (*Project, error) {
+	if id <= 0 {
+		return nil, ErrInvalidInput
+	}
+	project, err := s.repo.Restore(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	// Watchers dropped the project on delete, so it reappears as a new one
+	s.events.Publish(Event{Type: EventCreated, Project: *project})
+	return project, nil
+}
+
+func (s *service) PurgeDeleted(ctx context.Context, retention time.Duration) (int, error) {
+	if retention < 0 {
+		return 0, ErrInvalidInput
+	}
+	return s.repo.Purge(ctx, time.Now().Add(-retention))
+}
+
+// validateProject checks the invariants shared by create and update and
+// normalizes the tags
+func validateProject(p *Project) error {
+	if strings.TrimSpace(p.Name) == "" {
+		return ErrInvalidInput
+	}
+	if !p.StartDate.IsZero() && !p.DueDate.IsZero() && p.DueDate.Before(p.StartDate) {
+		return ErrInvalidInput
+	}
+	tags, err := NormalizeTags(p.Tags)
+	if err != nil {
+		return err
+	}
+	if len(tags) > MaxTagsPerProject {
+		return fmt.Errorf("%w: a project has at most %d tags", ErrInvalidTag, MaxTagsPerProject)
+	}
+	p.Tags = tags
+	return nil
+}
+
+func (s *service) WatchProjects() (*Subscription, error) {
+	if s.events == nil {
+		return nil, ErrBroadcasterClosed
+	}
+	return s.events.Subscribe()
+}
+
+func (s *service) AddMember(ctx context.Context, member *ProjectMember, actorID int) error {
+	if member.ProjectID <= 0 || member.StudentID <= 0 {
+		return ErrInvalidInput
+	}
+	if member.Role == "" {
+		member.Role = RoleContributor
+	}
+	if !member.Role.Valid() {
+		return ErrInvalidRole
+	}
+
+	project, err := s.repo.GetByID(ctx, member.ProjectID)
+	if err != nil {
+		return err
+	}
+	if member.Role == RoleOwner || member.Role == RoleInstructor {
+		if err := s.authorizeGrant(ctx, member, actorID); err != nil {
+			return err
+		}
+	}
+	if err := s.repo.AddMember(ctx, member); err != nil {
+		return err
+	}
+
+	s.events.Publish(Event{Type: EventMemberAdded, Project: *project, StudentID: member.StudentID})
+	return nil
+}
+
+// authorizeGrant checks that the actor may grant the member's owner or
+// instructor role, which takes an owner or instructor of the project. So that
+// new projects can be claimed, a student may make themselves the first owner
+// of a project that has neither.
+func (s *service) authorizeGrant(ctx context.Context, member *ProjectMember, actorID int) error {
+	members, err := s.repo.ListMembers(ctx, member.ProjectID, 0)
+	if err != nil {
+		return err
+	}
+	managed := false
+	for _, m := range members {
+		if m.Role == RoleOwner || m.Role == RoleInstructor {
+			if m.StudentID == actorID {
+				return nil
+			}
+			managed = true
+		}
+	}
+	if !managed && member.Role == RoleOwner && member.StudentID == actorID {
+		return nil
+	}
+	return ErrNotPermitted
+}
+
+func (s *service) RemoveMember(ctx context.Context, projectID, studentID, actorID int) error {
+	if projectID <= 0 || studentID <= 0 || actorID <= 0 {
+		return ErrInvalidInput
+	}
+	if actorID != studentID {
+		if err := s.authorizeRemoval(ctx, projectID, actorID); err != nil {
+			return err
+		}
+	}
+	return s.repo.RemoveMember(ctx, projectID, studentID)
+}
+
+// authorizeRemoval checks that the actor is an owner or instructor of the
+// project, who may remove other members
+func (s *service) authorizeRemoval(ctx context.Context, projectID, actorID int) error {
+	members, err := s.repo.ListMembers(ctx, projectID, 0)
+	if err != nil {
+		return err
+	}
+	for _, m := range members {
+		if m.StudentID == actorID && (m.Role == RoleOwner || m.Role == RoleInstructor) {
+			return nil
+		}
+	}
+	return ErrCannotRemove
+}
+
+func (s *service) ListMembers(ctx context.Context, projectID, teamID int) ([]ProjectMember, error) {
+	if projectID <= 0 || teamID < 0 {
+		return nil, ErrInvalidInput
+	}
+
+	if _, err := s.repo.GetByID(ctx, projectID); err != nil {
+		return nil, err
+	}
+	return s.repo.ListMembers(ctx, projectID, teamID)
+}
+
+func (s *service) ListProjectsForStudent(ctx context.Context, studentID int) ([]ProjectMember, error) {
+	if studentID <= 0 {
+		return nil, ErrInvalidInput
+	}
+	return s.repo.ListProjectsForStudent(ctx, studentID)
+}
+
+func (s *service) GetProjectHistory(ctx context.Context, id int, pageSize int, pageToken string) (*history.Page, error) {
+	if id <= 0 || pageSize < 0 {
+		return nil, ErrInvalidInput
+	}
+
+	page, err := s.repo.History(ctx, id, pageSize, pageToken)
+	if err != nil {
+		return nil, err
+	}
+
+	// Projects created before history was recorded may have none, so an
+	// empty first page is only an error when the project does not exist
+	if len(page.Entries) == 0 && pageToken == "" {
+		if _, err := s.repo.GetByID(ctx, id); err != nil {
+			return nil, err
+		}
+	}
+	return page, nil
+}
+
+func (s *service) ListTags(ctx context.Context, prefix string) ([]Tag, error) {
+	return s.repo.ListTags(ctx, strings.ToLower(strings.TrimSpace(prefix)))
+}
+
+func (s *service) RenameTag(ctx context.Context, name, newName string) (*Tag, error) {
+	from, err := NormalizeTag(name)
+	if err != nil {
+		return nil, err
+	}
+	to, err := NormalizeTag(newName)
+	if err != nil {
+		return nil, err
+	}
+	return s.repo.RenameTag(ctx, from, to)
+}
+
+func (s *service) MergeTags(ctx context.Context, sources []string, target string) (*Tag, error) {
+	into, err := NormalizeTag(target)
+	if err != nil {
+		return nil, err
+	}
+	from, err := NormalizeTags(sources)
+	if err != nil {
+		return nil, err
+	}
+	// Merging a tag into itself is a no-op, so the target may be listed as a source
+	from = slices.DeleteFunc(from, func(tag string) bool { return tag == into })
+	if len(from) == 0 {
+		return nil, ErrInvalidInput
+	}
+	return s.repo.MergeTags(ctx, from, into)
 }
@@ -0,0 +1,160 @@
+package project
+
+import (
+	"errors"
+	"sync"
+)
+
+// DefaultWatchBufferSize is the number of events a watcher may fall behind
+// before it is disconnected
+const DefaultWatchBufferSize = 256
+
+var (
+	ErrWatcherTooSlow     = errors.New("watcher fell too far behind")
+	ErrBroadcasterClosed  = errors.New("project events are shutting down")
+	ErrSubscriptionClosed = errors.New("subscription closed")
+)
+
+// EventType is the kind of change an Event describes
+type EventType string
+
+const (
+	EventCreated EventType = "created"
+	EventUpdated EventType = "updated"
+	EventDeleted EventType = "deleted"
+	// EventMemberAdded is published when a student joins a project
+	EventMemberAdded EventType = "member_added"
+)
+
+// Event is a committed change to a project
+type Event struct {
+	Type    EventType
+	Project Project
+	// StudentID is the member an EventMemberAdded is about
+	StudentID int
+}
+
+// Broadcaster fans project events out to any number of subscribers. Publish
+// never blocks: a subscriber whose buffer is full is dropped with
+// ErrWatcherTooSlow so a slow client cannot stall writes.
+type Broadcaster struct {
+	mu         sync.Mutex
+	subs       map[*Subscription]struct{}
+	bufferSize int
+	closed     bool
+}
+
+func NewBroadcaster(bufferSize int) *Broadcaster {
+	if bufferSize <= 0 {
+		bufferSize = DefaultWatchBufferSize
+	}
+	return &Broadcaster{
+		subs:       make(map[*Subscription]struct{}),
+		bufferSize: bufferSize,
+	}
+}
+
+// Subscribe registers a new subscriber. The caller must Close it when done.
+func (b *Broadcaster) Subscribe() (*Subscription, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.closed {
+		return nil, ErrBroadcasterClosed
+	}
+
+	sub := &Subscription{
+		broadcaster: b,
+		events:      make(chan Event, b.bufferSize),
+		done:        make(chan struct{}),
+	}
+	b.subs[sub] = struct{}{}
+	return sub, nil
+}
+
+// Publish delivers e to every subscriber. It is safe to call on a nil Broadcaster.
+func (b *Broadcaster) Publish(e Event) {
+	if b == nil {
+		return
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for sub := range b.subs {
+		select {
+		case sub.events <- e:
+		default:
+			delete(b.subs, sub)
+			sub.terminate(ErrWatcherTooSlow)
+		}
+	}
+}
+
+// Close terminates all subscriptions with ErrBroadcasterClosed and rejects new ones
+func (b *Broadcaster) Close() {
+	if b == nil {
+		return
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.closed = true
+	for sub := range b.subs {
+		delete(b.subs, sub)
+		sub.terminate(ErrBroadcasterClosed)
+	}
+}
+
+// Len returns the number of active subscribers
+func (b *Broadcaster) Len() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return len(b.subs)
+}
+
+// Subscription is a single watcher's view of the event stream
+type Subscription struct {
+	broadcaster *Broadcaster
+	events      chan Event
+	done        chan struct{}
+	once        sync.Once
+	err         error
+}
+
+// Events returns the buffered event channel
+func (s *Subscription) Events() <-chan Event {
+	return s.events
+}
+
+// Done is closed when the subscription ends; Err then reports why
+func (s *Subscription) Done() <-chan struct{} {
+	return s.done
+}
+
+// Err returns the reason the subscription ended, or nil while it is active
+func (s *Subscription) Err() error {
+	select {
+	case <-s.done:
+		return s.err
+	default:
+		return nil
+	}
+}
+
+// Close unsubscribes. It is safe to call more than once.
+func (s *Subscription) Close() {
+	s.broadcaster.mu.Lock()
+	delete(s.broadcaster.subs, s)
+	s.broadcaster.mu.Unlock()
+
+	s.terminate(ErrSubscriptionClosed)
+}
+
+func (s *Subscription) terminate(err error) {
+	s.once.Do(func() {
+		s.err = err
+		close(s.done)
+	})
+}
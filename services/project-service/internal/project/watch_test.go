@@ -0,0 +1,184 @@
+package project_test
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	pb "grud/api/gen/project/v1"
+	projectmetrics "project-service/internal/metrics"
+	"project-service/internal/project"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+)
+
+func TestBroadcaster(t *testing.T) {
+	t.Run("FanOut", func(t *testing.T) {
+		b := project.NewBroadcaster(4)
+
+		first, err := b.Subscribe()
+		require.NoError(t, err)
+		defer first.Close()
+		second, err := b.Subscribe()
+		require.NoError(t, err)
+		defer second.Close()
+
+		b.Publish(project.Event{Type: project.EventCreated, Project: project.Project{ID: 1, Name: "One"}})
+
+		for _, sub := range []*project.Subscription{first, second} {
+			select {
+			case e := <-sub.Events():
+				assert.Equal(t, project.EventCreated, e.Type)
+				assert.Equal(t, "One", e.Project.Name)
+			case <-time.After(time.Second):
+				t.Fatal("event not delivered")
+			}
+		}
+	})
+
+	t.Run("SlowSubscriberIsDropped", func(t *testing.T) {
+		b := project.NewBroadcaster(2)
+
+		slow, err := b.Subscribe()
+		require.NoError(t, err)
+		fast, err := b.Subscribe()
+		require.NoError(t, err)
+		defer fast.Close()
+
+		for i := 1; i <= 3; i++ {
+			b.Publish(project.Event{Type: project.EventUpdated, Project: project.Project{ID: i}})
+			<-fast.Events()
+		}
+
+		select {
+		case <-slow.Done():
+		default:
+			t.Fatal("slow subscriber was not dropped")
+		}
+		assert.ErrorIs(t, slow.Err(), project.ErrWatcherTooSlow)
+		assert.NoError(t, fast.Err())
+		assert.Equal(t, 1, b.Len())
+	})
+
+	t.Run("Close", func(t *testing.T) {
+		b := project.NewBroadcaster(1)
+
+		sub, err := b.Subscribe()
+		require.NoError(t, err)
+
+		b.Close()
+
+		<-sub.Done()
+		assert.ErrorIs(t, sub.Err(), project.ErrBroadcasterClosed)
+
+		_, err = b.Subscribe()
+		assert.ErrorIs(t, err, project.ErrBroadcasterClosed)
+
+		// Publishing after close and closing the subscription again are no-ops
+		b.Publish(project.Event{Type: project.EventDeleted})
+		sub.Close()
+		assert.ErrorIs(t, sub.Err(), project.ErrBroadcasterClosed)
+	})
+
+	t.Run("Unsubscribe", func(t *testing.T) {
+		b := project.NewBroadcaster(1)
+
+		sub, err := b.Subscribe()
+		require.NoError(t, err)
+		sub.Close()
+
+		assert.Equal(t, 0, b.Len())
+		assert.ErrorIs(t, sub.Err(), project.ErrSubscriptionClosed)
+	})
+}
+
+// snapshotService lists two pages of one project each and watches b
+type snapshotService struct {
+	project.Service
+	b *project.Broadcaster
+}
+
+func (s snapshotService) ListProjects(ctx context.Context, opts project.ListOptions) (*project.ListResult, error) {
+	if opts.PageToken == "" {
+		return &project.ListResult{Projects: []project.Project{{ID: 1, Name: "One"}}, NextPageToken: "2"}, nil
+	}
+	return &project.ListResult{Projects: []project.Project{{ID: 2, Name: "Two"}}}, nil
+}
+
+func (s snapshotService) WatchProjects() (*project.Subscription, error) {
+	return s.b.Subscribe()
+}
+
+// watchStream holds every snapshot message until release is closed, like a
+// client reading a large snapshot slowly
+type watchStream struct {
+	grpc.ServerStream
+	ctx     context.Context
+	release chan struct{}
+	sent    chan *pb.WatchProjectsResponse
+}
+
+func (s *watchStream) Context() context.Context { return s.ctx }
+
+func (s *watchStream) Send(resp *pb.WatchProjectsResponse) error {
+	if resp.GetSnapshot() != nil {
+		<-s.release
+	}
+	s.sent <- resp
+	return nil
+}
+
+func TestWatchProjects_SlowSnapshot(t *testing.T) {
+	const buffer = 4
+	b := project.NewBroadcaster(buffer)
+	server := project.NewGrpcServer(snapshotService{b: b}, slog.New(slog.NewTextHandler(io.Discard, nil)), projectmetrics.NewMock())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	stream := &watchStream{ctx: ctx, release: make(chan struct{}), sent: make(chan *pb.WatchProjectsResponse, 64)}
+	done := make(chan error, 1)
+	go func() { done <- server.WatchProjects(&pb.WatchProjectsRequest{}, stream) }()
+	require.Eventually(t, func() bool { return b.Len() == 1 }, time.Second, time.Millisecond)
+
+	// Many more changes than the subscription buffers arrive while the
+	// snapshot is held up
+	const changes = 4 * buffer
+	for i := range changes {
+		b.Publish(project.Event{Type: project.EventUpdated, Project: project.Project{ID: 100 + i}})
+		time.Sleep(time.Millisecond)
+	}
+	require.Equal(t, 1, b.Len(), "watcher was dropped during the snapshot")
+	close(stream.release)
+
+	next := func() *pb.WatchProjectsResponse {
+		select {
+		case resp := <-stream.sent:
+			return resp
+		case err := <-done:
+			t.Fatalf("watch ended: %v", err)
+		case <-time.After(time.Second):
+			t.Fatal("nothing sent")
+		}
+		return nil
+	}
+	assert.False(t, next().GetSnapshot().GetComplete())
+	assert.True(t, next().GetSnapshot().GetComplete())
+	for i := range changes {
+		assert.Equal(t, int32(100+i), next().GetEvent().GetProject().GetId())
+	}
+
+	// Live changes follow the ones held back
+	b.Publish(project.Event{Type: project.EventCreated, Project: project.Project{ID: 200}})
+	assert.Equal(t, int32(200), next().GetEvent().GetProject().GetId())
+
+	cancel()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("watch did not stop")
+	}
+}
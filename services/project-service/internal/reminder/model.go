@@ -0,0 +1,83 @@
+package reminder
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/uptrace/bun"
+)
+
+// DefaultOffsets are used when no offsets are configured
+var DefaultOffsets = []string{"7d", "1d", "1h"}
+
+// Offset is how long before a due date a reminder is sent. Name is the
+// configured spelling, e.g. "7d", and is sent in the event.
+type Offset struct {
+	Name   string
+	Before time.Duration
+}
+
+// ParseOffsets parses durations such as "7d", "36h" or "90m" and returns them
+// shortest first. Days are 24 hours.
+func ParseOffsets(specs []string) ([]Offset, error) {
+	offsets := make([]Offset, 0, len(specs))
+	for _, spec := range specs {
+		spec = strings.TrimSpace(spec)
+
+		var before time.Duration
+		if days, ok := strings.CutSuffix(spec, "d"); ok {
+			n, err := strconv.Atoi(days)
+			if err != nil {
+				return nil, fmt.Errorf("invalid reminder offset %q", spec)
+			}
+			before = time.Duration(n) * 24 * time.Hour
+		} else {
+			d, err := time.ParseDuration(spec)
+			if err != nil {
+				return nil, fmt.Errorf("invalid reminder offset %q", spec)
+			}
+			before = d
+		}
+		if before <= 0 {
+			return nil, fmt.Errorf("reminder offset %q must be positive", spec)
+		}
+		offsets = append(offsets, Offset{Name: spec, Before: before})
+	}
+
+	slices.SortFunc(offsets, func(a, b Offset) int { return cmp.Compare(a.Before, b.Before) })
+	for i := 1; i < len(offsets); i++ {
+		if offsets[i].Before == offsets[i-1].Before {
+			return nil, fmt.Errorf("reminder offsets %q and %q are the same", offsets[i-1].Name, offsets[i].Name)
+		}
+	}
+	return offsets, nil
+}
+
+// SentReminder records that the reminder for a project's due date at an
+// offset went out. The due date is part of the key, so moving the deadline
+// arms the reminders again.
+type SentReminder struct {
+	bun.BaseModel `bun:"table:sent_reminders,alias:sr"`
+
+	ProjectID     int       `bun:"project_id,pk"`
+	DueDate       time.Time `bun:"due_date,pk"`
+	OffsetSeconds int64     `bun:"offset_seconds,pk"`
+	SentAt        time.Time `bun:"sent_at,notnull,default:current_timestamp"`
+}
+
+// Event is published on NATS for every reminder
+type Event struct {
+	ProjectID int       `json:"projectId"`
+	Name      string    `json:"name"`
+	DueDate   time.Time `json:"dueDate"`
+	// Offset is the configured offset the reminder was sent for, e.g. "1d".
+	// It is the smallest offset not yet passed, so a reminder for "7d" may
+	// arrive less than 7 days before the deadline.
+	Offset     string    `json:"offset"`
+	StudentIDs []int     `json:"studentIds"`
+	SentAt     time.Time `json:"sentAt"`
+}
@@ -0,0 +1,26 @@
+package reminder_test
+
+import (
+	"testing"
+	"time"
+
+	"project-service/internal/reminder"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseOffsets(t *testing.T) {
+	offsets, err := reminder.ParseOffsets([]string{"7d", " 90m ", "1h"})
+	require.NoError(t, err)
+	assert.Equal(t, []reminder.Offset{
+		{Name: "1h", Before: time.Hour},
+		{Name: "90m", Before: 90 * time.Minute},
+		{Name: "7d", Before: 7 * 24 * time.Hour},
+	}, offsets)
+
+	for _, specs := range [][]string{{"soon"}, {"1.5d"}, {"0h"}, {"-1d"}, {"1d", "24h"}} {
+		_, err := reminder.ParseOffsets(specs)
+		assert.Error(t, err, specs)
+	}
+}
@@ -0,0 +1,126 @@
+package reminder
+
+import (
+	"context"
+	"time"
+
+	"grud/common/metrics"
+	"project-service/internal/project"
+
+	"github.com/uptrace/bun"
+)
+
+type Repository interface {
+	// Due returns up to limit projects that are still open and due in
+	// (now+after, now+offset.Before], without a reminder for offset at their
+	// current due date. Soonest due first.
+	Due(ctx context.Context, now time.Time, after time.Duration, offset Offset, limit int) ([]project.Project, error)
+	// Members returns the IDs of the students in the project
+	Members(ctx context.Context, projectID int) ([]int, error)
+	// Claim records the reminder and calls send in the same transaction, so
+	// the record is only kept if send succeeds. It returns false without
+	// calling send when the reminder is already recorded, possibly by another
+	// replica, or the project's due date or deletion changed since Due. The
+	// commit can still fail after send succeeded, in which case the reminder
+	// is sent again on a later scan: delivery is at least once.
+	Claim(ctx context.Context, reminder *SentReminder, send func(ctx context.Context) error) (bool, error)
+	// Prune deletes the records of due dates before the given time, which Due never returns again
+	Prune(ctx context.Context, before time.Time) (int, error)
+}
+
+// openStatuses are the project statuses that still get reminders
+var openStatuses = []project.Status{project.StatusDraft, project.StatusActive}
+
+type repository struct {
+	db      *bun.DB
+	metrics *metrics.Metrics
+}
+
+func NewRepository(db *bun.DB, m *metrics.Metrics) Repository {
+	return &repository{
+		db:      db,
+		metrics: m,
+	}
+}
+
+func (r *repository) Due(ctx context.Context, now time.Time, after time.Duration, offset Offset, limit int) ([]project.Project, error) {
+	start := time.Now()
+	projects := make([]project.Project, 0, limit)
+	err := r.db.NewSelect().
+		Model(&projects).
+		Where("p.due_date > ?", now.Add(after)).
+		Where("p.due_date <= ?", now.Add(offset.Before)).
+		Where("p.status IN (?)", bun.In(openStatuses)).
+		Where(`NOT EXISTS (
+			SELECT 1 FROM sent_reminders AS sr
+			WHERE sr.project_id = p.id AND sr.due_date = p.due_date AND sr.offset_seconds = ?
+		)`, int64(offset.Before/time.Second)).
+		OrderExpr("p.due_date ASC, p.id ASC").
+		Limit(limit).
+		Scan(ctx)
+	r.metrics.Database.RecordQuery(ctx, "select", "projects", time.Since(start), err)
+
+	return projects, err
+}
+
+func (r *repository) Members(ctx context.Context, projectID int) ([]int, error) {
+	start := time.Now()
+	ids := []int{}
+	err := r.db.NewSelect().
+		Model((*project.ProjectMember)(nil)).
+		Column("student_id").
+		Where("project_id = ?", projectID).
+		Order("student_id ASC").
+		Scan(ctx, &ids)
+	r.metrics.Database.RecordQuery(ctx, "select", "project_members", time.Since(start), err)
+
+	return ids, err
+}
+
+func (r *repository) Claim(ctx context.Context, reminder *SentReminder, send func(ctx context.Context) error) (bool, error) {
+	claimed := false
+	err := r.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		// Insert from the project row so a moved due date or a deleted project
+		// claims nothing. A replica claiming the same reminder blocks on the
+		// primary key until this transaction ends, then finds the conflict.
+		start := time.Now()
+		result, err := tx.NewRaw(`
+			INSERT INTO sent_reminders (project_id, due_date, offset_seconds)
+			SELECT id, due_date, ? FROM projects
+			WHERE id = ? AND due_date = ? AND deleted_at IS NULL
+			ON CONFLICT DO NOTHING`,
+			reminder.OffsetSeconds, reminder.ProjectID, reminder.DueDate).
+			Exec(ctx)
+		r.metrics.Database.RecordQuery(ctx, "insert", "sent_reminders", time.Since(start), err)
+
+		if err != nil {
+			return err
+		}
+		rowsAffected, err := result.RowsAffected()
+		if err != nil || rowsAffected == 0 {
+			return err
+		}
+
+		if err := send(ctx); err != nil {
+			return err
+		}
+		claimed = true
+		return nil
+	})
+	return claimed, err
+}
+
+func (r *repository) Prune(ctx context.Context, before time.Time) (int, error) {
+	start := time.Now()
+	result, err := r.db.NewDelete().
+		Model((*SentReminder)(nil)).
+		Where("due_date < ?", before).
+		Exec(ctx)
+	r.metrics.Database.RecordQuery(ctx, "delete", "sent_reminders", time.Since(start), err)
+
+	if err != nil {
+		return 0, err
+	}
+	rowsAffected, err := result.RowsAffected()
+	return int(rowsAffected), err
+}
@@ -0,0 +1,120 @@
+// Package reminder sends project.reminder events ahead of project due dates.
+// Every replica runs the scheduler; sent_reminders makes sure each reminder
+// is published at least once, and normally exactly once.
+package reminder
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"project-service/internal/metrics"
+)
+
+// DefaultBatchSize is how many due projects are claimed per query
+const DefaultBatchSize = 100
+
+// Publisher sends an event to NATS. It is implemented by *messaging.Producer.
+type Publisher interface {
+	SendMessage(ctx context.Context, value interface{}) error
+}
+
+type Scheduler struct {
+	repo      Repository
+	publisher Publisher
+	offsets   []Offset
+	batchSize int
+	logger    *slog.Logger
+	metrics   *metrics.Metrics
+}
+
+// NewScheduler creates a scheduler for offsets as returned by ParseOffsets
+func NewScheduler(repo Repository, publisher Publisher, offsets []Offset, logger *slog.Logger, metrics *metrics.Metrics) *Scheduler {
+	return &Scheduler{
+		repo:      repo,
+		publisher: publisher,
+		offsets:   offsets,
+		batchSize: DefaultBatchSize,
+		logger:    logger,
+		metrics:   metrics,
+	}
+}
+
+// SendDue publishes the reminders due at now and returns how many were sent.
+//
+// Offsets split the time before a due date into windows: with 1h, 1d and 7d a
+// project due in 30 hours is in the 7d window and one due in 5 hours in the
+// 1d window. A project gets one reminder per window it passes through. A
+// project that is already past a window, because it was created or its due
+// date moved late or the service was down, skips that reminder.
+func (s *Scheduler) SendDue(ctx context.Context, now time.Time) (int, error) {
+	sent := 0
+
+	// Records of passed due dates can never match again
+	if _, err := s.repo.Prune(ctx, now); err != nil {
+		return sent, err
+	}
+
+	var after time.Duration
+	for _, offset := range s.offsets {
+		for {
+			projects, err := s.repo.Due(ctx, now, after, offset, s.batchSize)
+			if err != nil {
+				return sent, err
+			}
+
+			for i := range projects {
+				ok, err := s.send(ctx, now, offset, projects[i].ID, projects[i].Name, projects[i].DueDate)
+				if err != nil {
+					return sent, err
+				}
+				if ok {
+					sent++
+				}
+			}
+
+			// Claimed and skipped projects alike drop out of the next query
+			if len(projects) < s.batchSize {
+				break
+			}
+		}
+		after = offset.Before
+	}
+	return sent, nil
+}
+
+// send claims and publishes one reminder. It returns false if the reminder
+// was claimed elsewhere first.
+func (s *Scheduler) send(ctx context.Context, now time.Time, offset Offset, projectID int, name string, dueDate time.Time) (bool, error) {
+	students, err := s.repo.Members(ctx, projectID)
+	if err != nil {
+		return false, err
+	}
+
+	event := Event{
+		ProjectID:  projectID,
+		Name:       name,
+		DueDate:    dueDate,
+		Offset:     offset.Name,
+		StudentIDs: students,
+		SentAt:     now,
+	}
+	claim := &SentReminder{
+		ProjectID:     projectID,
+		DueDate:       dueDate,
+		OffsetSeconds: int64(offset.Before / time.Second),
+	}
+
+	ok, err := s.repo.Claim(ctx, claim, func(ctx context.Context) error {
+		return s.publisher.SendMessage(ctx, event)
+	})
+	if err != nil {
+		s.logger.ErrorContext(ctx, "failed to send project reminder", "error", err, "project_id", projectID, "offset", offset.Name)
+		return false, err
+	}
+	if ok {
+		s.metrics.RecordReminderSent(ctx)
+		s.logger.InfoContext(ctx, "project reminder sent", "project_id", projectID, "offset", offset.Name, "due_date", dueDate)
+	}
+	return ok, nil
+}
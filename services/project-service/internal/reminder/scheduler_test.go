@@ -0,0 +1,178 @@
+package reminder_test
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	commonmetrics "grud/common/metrics"
+	"grud/testing/testdb"
+	"project-service/internal/db"
+	projectmetrics "project-service/internal/metrics"
+	"project-service/internal/project"
+	"project-service/internal/reminder"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// recordingPublisher keeps the events it is sent, or fails with err
+type recordingPublisher struct {
+	mu     sync.Mutex
+	events []reminder.Event
+	err    error
+}
+
+func (p *recordingPublisher) SendMessage(ctx context.Context, value interface{}) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.err != nil {
+		return p.err
+	}
+	p.events = append(p.events, value.(reminder.Event))
+	return nil
+}
+
+func TestScheduler_Shared(t *testing.T) {
+	pgContainer := testdb.SetupSharedPostgres(t)
+	defer pgContainer.Cleanup(t)
+
+	err := db.RunMigrations(context.Background(), pgContainer.DB,
+		(*project.Project)(nil), (*project.ProjectMember)(nil), (*reminder.SentReminder)(nil))
+	require.NoError(t, err)
+
+	repo := reminder.NewRepository(pgContainer.DB, commonmetrics.NewMock())
+	offsets, err := reminder.ParseOffsets(reminder.DefaultOffsets)
+	require.NoError(t, err)
+	logger := slog.New(slog.NewTextHandler(os.Stderr, nil))
+
+	newScheduler := func(publisher reminder.Publisher) *reminder.Scheduler {
+		return reminder.NewScheduler(repo, publisher, offsets, logger, projectmetrics.NewMock())
+	}
+
+	now := time.Now().UTC().Truncate(time.Second)
+	ctx := context.Background()
+
+	insert := func(t *testing.T, p *project.Project) *project.Project {
+		_, err := pgContainer.DB.NewInsert().Model(p).Exec(ctx)
+		require.NoError(t, err)
+		return p
+	}
+
+	t.Run("OncePerWindow", func(t *testing.T) {
+		testdb.CleanupTables(t, pgContainer.DB, "projects", "project_members", "sent_reminders")
+
+		p := insert(t, &project.Project{Name: "Thesis", Status: project.StatusActive, DueDate: now.Add(30 * time.Hour)})
+		for _, studentID := range []int{7, 3} {
+			_, err := pgContainer.DB.NewInsert().Model(&project.ProjectMember{ProjectID: p.ID, StudentID: studentID, Role: project.RoleContributor}).Exec(ctx)
+			require.NoError(t, err)
+		}
+		insert(t, &project.Project{Name: "Far away", Status: project.StatusActive, DueDate: now.Add(30 * 24 * time.Hour)})
+		insert(t, &project.Project{Name: "Done", Status: project.StatusCompleted, DueDate: now.Add(time.Hour)})
+		insert(t, &project.Project{Name: "Overdue", Status: project.StatusActive, DueDate: now.Add(-time.Hour)})
+
+		publisher := &recordingPublisher{}
+		scheduler := newScheduler(publisher)
+
+		sent, err := scheduler.SendDue(ctx, now)
+		require.NoError(t, err)
+		assert.Equal(t, 1, sent)
+		require.Len(t, publisher.events, 1)
+		event := publisher.events[0]
+		assert.Equal(t, p.ID, event.ProjectID)
+		assert.Equal(t, "Thesis", event.Name)
+		assert.Equal(t, "7d", event.Offset)
+		assert.Equal(t, []int{3, 7}, event.StudentIDs)
+		assert.True(t, p.DueDate.Equal(event.DueDate))
+
+		// Restarting does not resend
+		sent, err = newScheduler(publisher).SendDue(ctx, now.Add(time.Minute))
+		require.NoError(t, err)
+		assert.Zero(t, sent)
+
+		// Half an hour before the deadline the 1d window has been skipped
+		sent, err = scheduler.SendDue(ctx, p.DueDate.Add(-30*time.Minute))
+		require.NoError(t, err)
+		assert.Equal(t, 1, sent)
+		require.Len(t, publisher.events, 2)
+		assert.Equal(t, "1h", publisher.events[1].Offset)
+	})
+
+	t.Run("MovedDueDate", func(t *testing.T) {
+		testdb.CleanupTables(t, pgContainer.DB, "projects", "project_members", "sent_reminders")
+
+		p := insert(t, &project.Project{Name: "Report", DueDate: now.Add(2 * time.Hour)})
+		publisher := &recordingPublisher{}
+		scheduler := newScheduler(publisher)
+
+		sent, err := scheduler.SendDue(ctx, now)
+		require.NoError(t, err)
+		assert.Equal(t, 1, sent)
+
+		_, err = pgContainer.DB.NewUpdate().Model(p).Set("due_date = ?", now.Add(3*time.Hour)).WherePK().Exec(ctx)
+		require.NoError(t, err)
+
+		sent, err = scheduler.SendDue(ctx, now)
+		require.NoError(t, err)
+		assert.Equal(t, 1, sent)
+		assert.Equal(t, "1d", publisher.events[1].Offset)
+	})
+
+	t.Run("PublishFailure", func(t *testing.T) {
+		testdb.CleanupTables(t, pgContainer.DB, "projects", "project_members", "sent_reminders")
+
+		insert(t, &project.Project{Name: "Lab", DueDate: now.Add(10 * time.Minute)})
+		publisher := &recordingPublisher{err: errors.New("nats: connection closed")}
+
+		_, err := newScheduler(publisher).SendDue(ctx, now)
+		require.Error(t, err)
+
+		// Nothing was recorded, so the reminder goes out once NATS is back
+		publisher.err = nil
+		sent, err := newScheduler(publisher).SendDue(ctx, now)
+		require.NoError(t, err)
+		assert.Equal(t, 1, sent)
+	})
+
+	t.Run("Replicas", func(t *testing.T) {
+		testdb.CleanupTables(t, pgContainer.DB, "projects", "project_members", "sent_reminders")
+
+		for i := 0; i < 20; i++ {
+			insert(t, &project.Project{Name: "Batch", DueDate: now.Add(time.Duration(i+1) * time.Minute)})
+		}
+
+		publisher := &recordingPublisher{}
+		var wg sync.WaitGroup
+		for i := 0; i < 4; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				_, err := newScheduler(publisher).SendDue(ctx, now)
+				assert.NoError(t, err)
+			}()
+		}
+		wg.Wait()
+
+		assert.Len(t, publisher.events, 20)
+		var count int
+		count, err = pgContainer.DB.NewSelect().Model((*reminder.SentReminder)(nil)).Count(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, 20, count)
+	})
+
+	t.Run("Prune", func(t *testing.T) {
+		testdb.CleanupTables(t, pgContainer.DB, "projects", "project_members", "sent_reminders")
+
+		p := insert(t, &project.Project{Name: "Old", DueDate: now.Add(time.Minute)})
+		_, err := newScheduler(&recordingPublisher{}).SendDue(ctx, now)
+		require.NoError(t, err)
+
+		pruned, err := repo.Prune(ctx, p.DueDate.Add(time.Second))
+		require.NoError(t, err)
+		assert.Equal(t, 1, pruned)
+	})
+}
@@ -0,0 +1,106 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+// LocalStore keeps blobs as files under a root directory
+type LocalStore struct {
+	root string
+}
+
+// NewLocalStore creates a store rooted at dir, creating the directory if needed
+func NewLocalStore(dir string) (*LocalStore, error) {
+	if dir == "" {
+		return nil, errors.New("local blob store needs a directory")
+	}
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return nil, fmt.Errorf("failed to create blob directory: %w", err)
+	}
+	return &LocalStore{root: dir}, nil
+}
+
+func (s *LocalStore) path(key string) (string, error) {
+	if err := validateKey(key); err != nil {
+		return "", err
+	}
+	return filepath.Join(s.root, filepath.FromSlash(key)), nil
+}
+
+// Put writes the blob to a temporary file next to its final path and renames
+// it into place once complete, so readers never see a partial blob
+func (s *LocalStore) Put(ctx context.Context, key string, r io.Reader, size int64) error {
+	path, err := s.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".upload-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	defer tmp.Close()
+
+	// One byte more than expected is read to detect oversized input
+	written, err := io.Copy(tmp, io.LimitReader(contextReader{ctx, r}, size+1))
+	if err != nil {
+		return err
+	}
+	if written != size {
+		return fmt.Errorf("blob size mismatch: expected %d bytes, read %d", size, written)
+	}
+	if err := tmp.Sync(); err != nil {
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+func (s *LocalStore) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	path, err := s.path(key)
+	if err != nil {
+		return nil, err
+	}
+	f, err := os.Open(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, ErrNotFound
+	}
+	return f, err
+}
+
+func (s *LocalStore) Delete(ctx context.Context, key string) error {
+	path, err := s.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+// contextReader stops reading once ctx is done, so an abandoned upload does
+// not keep copying
+type contextReader struct {
+	ctx context.Context
+	r   io.Reader
+}
+
+func (r contextReader) Read(p []byte) (int, error) {
+	if err := r.ctx.Err(); err != nil {
+		return 0, err
+	}
+	return r.r.Read(p)
+}
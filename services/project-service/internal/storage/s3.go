@@ -0,0 +1,284 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+)
+
+// S3Config locates a bucket on AWS S3 or an S3-compatible server
+type S3Config struct {
+	// Endpoint is the server's base URL, such as https://s3.eu-central-1.amazonaws.com
+	// or http://localhost:9000
+	Endpoint  string
+	Region    string
+	Bucket    string
+	AccessKey string
+	SecretKey string
+	// PathStyle addresses the bucket as endpoint/bucket instead of
+	// bucket.endpoint; MinIO and most other S3-compatible servers need it
+	PathStyle bool
+}
+
+// S3Store keeps blobs as objects in an S3 bucket. Requests are signed with
+// AWS Signature Version 4; payloads are sent unsigned since blobs are
+// checksummed by their callers.
+type S3Store struct {
+	cfg    S3Config
+	base   *url.URL
+	client *http.Client
+}
+
+const (
+	unsignedPayload = "UNSIGNED-PAYLOAD"
+	// emptyPayloadHash is the SHA-256 of an empty body
+	emptyPayloadHash = "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"
+)
+
+// NewS3Store creates a store for the configured bucket. client may be nil to
+// use http.DefaultClient.
+func NewS3Store(cfg S3Config, client *http.Client) (*S3Store, error) {
+	if cfg.Endpoint == "" || cfg.Bucket == "" || cfg.Region == "" {
+		return nil, errors.New("s3 blob store needs an endpoint, region and bucket")
+	}
+	if cfg.AccessKey == "" || cfg.SecretKey == "" {
+		return nil, errors.New("s3 blob store needs an access key and secret key")
+	}
+	base, err := url.Parse(cfg.Endpoint)
+	if err != nil || base.Host == "" {
+		return nil, fmt.Errorf("invalid s3 endpoint %q", cfg.Endpoint)
+	}
+	if client == nil {
+		client = http.DefaultClient
+	}
+	return &S3Store{cfg: cfg, base: base, client: client}, nil
+}
+
+// CreateBucket creates the configured bucket. It is meant for development
+// and tests against a fresh S3-compatible server.
+func (s *S3Store) CreateBucket(ctx context.Context) error {
+	var body []byte
+	if s.cfg.Region != "us-east-1" {
+		body = []byte(`<CreateBucketConfiguration xmlns="http://s3.amazonaws.com/doc/2006-03-01/">` +
+			`<LocationConstraint>` + s.cfg.Region + `</LocationConstraint></CreateBucketConfiguration>`)
+	}
+	resp, err := s.do(ctx, http.MethodPut, "", bytes.NewReader(body), int64(len(body)))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return responseError(resp)
+	}
+	return nil
+}
+
+func (s *S3Store) Put(ctx context.Context, key string, r io.Reader, size int64) error {
+	if err := validateKey(key); err != nil {
+		return err
+	}
+	resp, err := s.do(ctx, http.MethodPut, key, r, size)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return responseError(resp)
+	}
+	return nil
+}
+
+func (s *S3Store) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	if err := validateKey(key); err != nil {
+		return nil, err
+	}
+	resp, err := s.do(ctx, http.MethodGet, key, nil, 0)
+	if err != nil {
+		return nil, err
+	}
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return resp.Body, nil
+	case http.StatusNotFound:
+		resp.Body.Close()
+		return nil, ErrNotFound
+	}
+	defer resp.Body.Close()
+	return nil, responseError(resp)
+}
+
+func (s *S3Store) Delete(ctx context.Context, key string) error {
+	if err := validateKey(key); err != nil {
+		return err
+	}
+	resp, err := s.do(ctx, http.MethodDelete, key, nil, 0)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	// S3 answers 204 whether or not the object existed
+	if resp.StatusCode != http.StatusNoContent && resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNotFound {
+		return responseError(resp)
+	}
+	return nil
+}
+
+// do sends a signed request for the object under key, or for the bucket
+// itself when key is empty
+func (s *S3Store) do(ctx context.Context, method, key string, body io.Reader, size int64) (*http.Response, error) {
+	u := *s.base
+	path := ""
+	if s.cfg.PathStyle {
+		path = "/" + s.cfg.Bucket
+	} else {
+		u.Host = s.cfg.Bucket + "." + u.Host
+	}
+	if key != "" {
+		path += "/" + key
+	}
+	if path == "" {
+		path = "/"
+	}
+	u.Path = strings.TrimSuffix(u.Path, "/") + path
+	u.RawPath = uriEncode(u.Path, false)
+
+	req, err := http.NewRequestWithContext(ctx, method, u.String(), body)
+	if err != nil {
+		return nil, err
+	}
+	payloadHash := emptyPayloadHash
+	if body != nil {
+		payloadHash = unsignedPayload
+		req.ContentLength = size
+		if size == 0 {
+			// Otherwise the body would be sent chunked, which S3 rejects
+			req.Body = http.NoBody
+		}
+	}
+	s.sign(req, payloadHash, time.Now())
+
+	return s.client.Do(req)
+}
+
+// sign adds the x-amz-date, x-amz-content-sha256 and Authorization headers of
+// AWS Signature Version 4. Every header already set on req is signed, along
+// with the host.
+func (s *S3Store) sign(req *http.Request, payloadHash string, now time.Time) {
+	amzDate := now.UTC().Format("20060102T150405Z")
+	date := amzDate[:8]
+	req.Header.Set("X-Amz-Date", amzDate)
+	req.Header.Set("X-Amz-Content-Sha256", payloadHash)
+
+	host := req.Host
+	if host == "" {
+		host = req.URL.Host
+	}
+	headers := map[string]string{"host": host}
+	for name, values := range req.Header {
+		headers[strings.ToLower(name)] = strings.TrimSpace(strings.Join(values, ","))
+	}
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var canonicalHeaders strings.Builder
+	for _, name := range names {
+		canonicalHeaders.WriteString(name + ":" + headers[name] + "\n")
+	}
+	signedHeaders := strings.Join(names, ";")
+
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		req.URL.EscapedPath(),
+		canonicalQuery(req.URL.Query()),
+		canonicalHeaders.String(),
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+
+	scope := date + "/" + s.cfg.Region + "/s3/aws4_request"
+	stringToSign := "AWS4-HMAC-SHA256\n" + amzDate + "\n" + scope + "\n" + hexSHA256(canonicalRequest)
+
+	key := hmacSHA256([]byte("AWS4"+s.cfg.SecretKey), date)
+	key = hmacSHA256(key, s.cfg.Region)
+	key = hmacSHA256(key, "s3")
+	key = hmacSHA256(key, "aws4_request")
+	signature := hex.EncodeToString(hmacSHA256(key, stringToSign))
+
+	req.Header.Set("Authorization", "AWS4-HMAC-SHA256 Credential="+s.cfg.AccessKey+"/"+scope+
+		", SignedHeaders="+signedHeaders+", Signature="+signature)
+}
+
+func canonicalQuery(query url.Values) string {
+	keys := make([]string, 0, len(query))
+	for key := range query {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var pairs []string
+	for _, key := range keys {
+		values := append([]string(nil), query[key]...)
+		sort.Strings(values)
+		for _, value := range values {
+			pairs = append(pairs, uriEncode(key, true)+"="+uriEncode(value, true))
+		}
+	}
+	return strings.Join(pairs, "&")
+}
+
+// uriEncode percent-encodes every byte except the unreserved characters, as
+// Signature Version 4 requires. Slashes are kept unless encodeSlash is set.
+func uriEncode(s string, encodeSlash bool) string {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case 'A' <= c && c <= 'Z', 'a' <= c && c <= 'z', '0' <= c && c <= '9',
+			c == '-', c == '_', c == '.', c == '~':
+			b.WriteByte(c)
+		case c == '/' && !encodeSlash:
+			b.WriteByte(c)
+		default:
+			fmt.Fprintf(&b, "%%%02X", c)
+		}
+	}
+	return b.String()
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
+
+func hexSHA256(data string) string {
+	sum := sha256.Sum256([]byte(data))
+	return hex.EncodeToString(sum[:])
+}
+
+// responseError turns an S3 error response into an error carrying its code
+func responseError(resp *http.Response) error {
+	var s3Err struct {
+		Code    string `xml:"Code"`
+		Message string `xml:"Message"`
+	}
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 64<<10))
+	if xml.Unmarshal(body, &s3Err) == nil && s3Err.Code != "" {
+		return fmt.Errorf("s3 %s: %s: %s", resp.Status, s3Err.Code, s3Err.Message)
+	}
+	return fmt.Errorf("s3 %s", resp.Status)
+}
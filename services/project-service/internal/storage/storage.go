@@ -0,0 +1,46 @@
+// Package storage keeps file contents outside Postgres. BlobStore has a local
+// filesystem implementation for development and single-node setups, and an
+// S3 one that works with AWS and S3-compatible servers such as MinIO.
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+var (
+	ErrNotFound   = errors.New("blob not found")
+	ErrInvalidKey = errors.New("invalid blob key")
+)
+
+// BlobStore stores opaque blobs under slash separated keys such as
+// "projects/12/3f9a...". Keys are chosen by the caller and never reused.
+type BlobStore interface {
+	// Put stores exactly size bytes read from r under key. It fails when r
+	// ends early; a failed Put may leave nothing or a partial blob behind,
+	// which the caller removes with Delete.
+	Put(ctx context.Context, key string, r io.Reader, size int64) error
+	// Get opens the blob stored under key, or returns ErrNotFound. The
+	// caller closes the reader.
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+	// Delete removes the blob stored under key. Deleting a missing blob is
+	// not an error.
+	Delete(ctx context.Context, key string) error
+}
+
+// validateKey rejects keys that could escape the store's root: empty,
+// absolute or with empty, "." or ".." segments
+func validateKey(key string) error {
+	if key == "" || strings.ContainsRune(key, '\\') {
+		return fmt.Errorf("%w: %q", ErrInvalidKey, key)
+	}
+	for _, segment := range strings.Split(key, "/") {
+		if segment == "" || segment == "." || segment == ".." {
+			return fmt.Errorf("%w: %q", ErrInvalidKey, key)
+		}
+	}
+	return nil
+}
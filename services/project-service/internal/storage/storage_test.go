@@ -0,0 +1,183 @@
+package storage_test
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"grud/testing/tests3"
+	"project-service/internal/storage"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testBlobStore runs the behaviour every BlobStore must share
+func testBlobStore(t *testing.T, store storage.BlobStore) {
+	ctx := context.Background()
+
+	read := func(t *testing.T, key string) string {
+		t.Helper()
+		r, err := store.Get(ctx, key)
+		require.NoError(t, err)
+		defer r.Close()
+		data, err := io.ReadAll(r)
+		require.NoError(t, err)
+		return string(data)
+	}
+
+	t.Run("PutGetDelete", func(t *testing.T) {
+		key := "projects/1/put-get-delete"
+		require.NoError(t, store.Put(ctx, key, strings.NewReader("hello"), 5))
+		assert.Equal(t, "hello", read(t, key))
+
+		require.NoError(t, store.Put(ctx, key, strings.NewReader("replaced"), 8))
+		assert.Equal(t, "replaced", read(t, key))
+
+		require.NoError(t, store.Delete(ctx, key))
+		_, err := store.Get(ctx, key)
+		assert.ErrorIs(t, err, storage.ErrNotFound)
+
+		// Deleting again is not an error
+		assert.NoError(t, store.Delete(ctx, key))
+	})
+
+	t.Run("Large", func(t *testing.T) {
+		key := "projects/1/large"
+		data := bytes.Repeat([]byte("0123456789abcdef"), 256<<10)
+		require.NoError(t, store.Put(ctx, key, bytes.NewReader(data), int64(len(data))))
+		assert.Equal(t, string(data), read(t, key))
+		require.NoError(t, store.Delete(ctx, key))
+	})
+
+	t.Run("Missing", func(t *testing.T) {
+		_, err := store.Get(ctx, "projects/1/missing")
+		assert.ErrorIs(t, err, storage.ErrNotFound)
+	})
+
+	t.Run("ShortInput", func(t *testing.T) {
+		key := "projects/1/short"
+		err := store.Put(ctx, key, strings.NewReader("abc"), 10)
+		assert.Error(t, err)
+		_, err = store.Get(ctx, key)
+		assert.ErrorIs(t, err, storage.ErrNotFound)
+	})
+
+	t.Run("InvalidKey", func(t *testing.T) {
+		for _, key := range []string{"", "/abs", "a/../b", "a//b", "..", `a\b`} {
+			err := store.Put(ctx, key, strings.NewReader("x"), 1)
+			assert.ErrorIs(t, err, storage.ErrInvalidKey, key)
+		}
+	})
+}
+
+func TestLocalStore(t *testing.T) {
+	dir := t.TempDir()
+	store, err := storage.NewLocalStore(dir)
+	require.NoError(t, err)
+
+	testBlobStore(t, store)
+
+	t.Run("NoLeftovers", func(t *testing.T) {
+		ctx := context.Background()
+		require.NoError(t, store.Put(ctx, "projects/2/kept", strings.NewReader("kept"), 4))
+		assert.Error(t, store.Put(ctx, "projects/2/too-long", strings.NewReader("too long"), 3))
+
+		entries, err := os.ReadDir(filepath.Join(dir, "projects", "2"))
+		require.NoError(t, err)
+		require.Len(t, entries, 1)
+		assert.Equal(t, "kept", entries[0].Name())
+	})
+}
+
+func TestS3Store_Shared(t *testing.T) {
+	minio := tests3.SetupSharedMinIO(t)
+	defer minio.Cleanup(t)
+
+	store, err := storage.NewS3Store(storage.S3Config{
+		Endpoint:  minio.Endpoint,
+		Region:    tests3.Region,
+		Bucket:    "attachments",
+		AccessKey: tests3.AccessKey,
+		SecretKey: tests3.SecretKey,
+		PathStyle: true,
+	}, nil)
+	require.NoError(t, err)
+	require.NoError(t, store.CreateBucket(context.Background()))
+
+	testBlobStore(t, store)
+
+	t.Run("WrongSecret", func(t *testing.T) {
+		wrong, err := storage.NewS3Store(storage.S3Config{
+			Endpoint:  minio.Endpoint,
+			Region:    tests3.Region,
+			Bucket:    "attachments",
+			AccessKey: tests3.AccessKey,
+			SecretKey: "not-the-secret",
+			PathStyle: true,
+		}, nil)
+		require.NoError(t, err)
+
+		err = wrong.Put(context.Background(), "projects/1/denied", strings.NewReader("x"), 1)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "SignatureDoesNotMatch")
+	})
+}
+
+// TestS3Store_Requests checks the requests sent to the server and how its
+// answers are mapped, without a real S3 server
+func TestS3Store_Requests(t *testing.T) {
+	var requests []*http.Request
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r)
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/bucket/projects/1/missing":
+			w.WriteHeader(http.StatusNotFound)
+		case r.Method == http.MethodGet && r.URL.Path == "/bucket/projects/1/denied":
+			w.WriteHeader(http.StatusForbidden)
+			io.WriteString(w, `<?xml version="1.0" encoding="UTF-8"?><Error><Code>AccessDenied</Code><Message>Access Denied</Message></Error>`)
+		case r.Method == http.MethodDelete:
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			io.Copy(io.Discard, r.Body)
+		}
+	}))
+	defer server.Close()
+
+	store, err := storage.NewS3Store(storage.S3Config{
+		Endpoint:  server.URL,
+		Region:    "eu-central-1",
+		Bucket:    "bucket",
+		AccessKey: "AKID",
+		SecretKey: "secret",
+		PathStyle: true,
+	}, server.Client())
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	require.NoError(t, store.Put(ctx, "projects/1/a b", strings.NewReader("hello"), 5))
+	put := requests[0]
+	assert.Equal(t, "/bucket/projects/1/a%20b", put.URL.EscapedPath())
+	assert.Equal(t, int64(5), put.ContentLength)
+	assert.Equal(t, "UNSIGNED-PAYLOAD", put.Header.Get("X-Amz-Content-Sha256"))
+	assert.Regexp(t, `^AWS4-HMAC-SHA256 Credential=AKID/\d{8}/eu-central-1/s3/aws4_request, `+
+		`SignedHeaders=host;x-amz-content-sha256;x-amz-date, Signature=[0-9a-f]{64}$`, put.Header.Get("Authorization"))
+
+	_, err = store.Get(ctx, "projects/1/missing")
+	assert.ErrorIs(t, err, storage.ErrNotFound)
+
+	_, err = store.Get(ctx, "projects/1/denied")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "AccessDenied")
+
+	assert.NoError(t, store.Delete(ctx, "projects/1/a b"))
+
+	_, err = storage.NewS3Store(storage.S3Config{Endpoint: server.URL, Region: "eu-central-1", Bucket: "bucket"}, nil)
+	assert.Error(t, err)
+}
@@ -0,0 +1,198 @@
+package submission
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+
+	"project-service/internal/metrics"
+	"project-service/internal/project"
+
+	pb "grud/api/gen/submission/v1"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type GrpcServer struct {
+	pb.UnimplementedSubmissionServiceServer
+	service Service
+	logger  *slog.Logger
+	metrics *metrics.Metrics
+}
+
+func NewGrpcServer(service Service, logger *slog.Logger, m *metrics.Metrics) *GrpcServer {
+	return &GrpcServer{
+		service: service,
+		logger:  logger,
+		metrics: m,
+	}
+}
+
+func (s *GrpcServer) CreateSubmission(ctx context.Context, req *pb.CreateSubmissionRequest) (*pb.CreateSubmissionResponse, error) {
+	s.logger.InfoContext(ctx, "gRPC: creating submission", "project_id", req.ProjectId, "student_id", req.StudentId)
+
+	attachmentIDs := make([]int, len(req.AttachmentIds))
+	for i, id := range req.AttachmentIds {
+		attachmentIDs[i] = int(id)
+	}
+	submission, err := s.service.Submit(ctx, NewSubmission{
+		ProjectID:     int(req.ProjectId),
+		StudentID:     int(req.StudentId),
+		Text:          req.Text,
+		AttachmentIDs: attachmentIDs,
+	})
+	if err != nil {
+		s.logger.ErrorContext(ctx, "gRPC: failed to create submission", "error", err, "project_id", req.ProjectId)
+		return nil, toStatusError(err)
+	}
+
+	s.metrics.RecordSubmissionCreated(ctx)
+	s.logger.InfoContext(ctx, "gRPC: submission created", "id", submission.ID, "version", submission.Version)
+
+	return &pb.CreateSubmissionResponse{Submission: toProtoSubmission(submission)}, nil
+}
+
+func (s *GrpcServer) GetSubmission(ctx context.Context, req *pb.GetSubmissionRequest) (*pb.GetSubmissionResponse, error) {
+	s.logger.InfoContext(ctx, "gRPC: fetching submission", "id", req.Id, "viewer_id", req.ViewerId)
+
+	submission, err := s.service.Get(ctx, int(req.Id), int(req.ViewerId))
+	if err != nil {
+		s.logger.ErrorContext(ctx, "gRPC: failed to fetch submission", "error", err, "id", req.Id)
+		return nil, toStatusError(err)
+	}
+	return &pb.GetSubmissionResponse{Submission: toProtoSubmission(submission)}, nil
+}
+
+func (s *GrpcServer) ListSubmissions(ctx context.Context, req *pb.ListSubmissionsRequest) (*pb.ListSubmissionsResponse, error) {
+	s.logger.InfoContext(ctx, "gRPC: listing submissions", "project_id", req.ProjectId, "student_id", req.StudentId, "viewer_id", req.ViewerId)
+
+	submissions, err := s.service.List(ctx, Filter{ProjectID: int(req.ProjectId), StudentID: int(req.StudentId)}, int(req.ViewerId))
+	if err != nil {
+		s.logger.ErrorContext(ctx, "gRPC: failed to list submissions", "error", err, "project_id", req.ProjectId)
+		return nil, toStatusError(err)
+	}
+
+	pbSubmissions := make([]*pb.Submission, len(submissions))
+	for i, submission := range submissions {
+		pbSubmissions[i] = toProtoSubmission(submission)
+	}
+	return &pb.ListSubmissionsResponse{Submissions: pbSubmissions}, nil
+}
+
+func (s *GrpcServer) GradeSubmission(ctx context.Context, req *pb.GradeSubmissionRequest) (*pb.GradeSubmissionResponse, error) {
+	s.logger.InfoContext(ctx, "gRPC: grading submission", "id", req.Id, "grader_id", req.GraderId)
+
+	rubric := make([]RubricItem, len(req.Rubric))
+	for i, item := range req.Rubric {
+		rubric[i] = RubricItem{
+			Criterion: item.Criterion,
+			Points:    item.Points,
+			MaxPoints: item.MaxPoints,
+			Comment:   item.Comment,
+		}
+	}
+	submission, err := s.service.Grade(ctx, int(req.Id), Assessment{
+		GraderID: int(req.GraderId),
+		Score:    req.Score,
+		MaxScore: req.MaxScore,
+		Rubric:   rubric,
+		Feedback: req.Feedback,
+	})
+	if err != nil {
+		s.logger.ErrorContext(ctx, "gRPC: failed to grade submission", "error", err, "id", req.Id)
+		return nil, toStatusError(err)
+	}
+
+	s.metrics.RecordSubmissionGraded(ctx)
+	return &pb.GradeSubmissionResponse{Submission: toProtoSubmission(submission)}, nil
+}
+
+func (s *GrpcServer) ReopenGrade(ctx context.Context, req *pb.ReopenGradeRequest) (*pb.ReopenGradeResponse, error) {
+	s.logger.InfoContext(ctx, "gRPC: reopening grade", "id", req.Id, "grader_id", req.GraderId)
+
+	submission, err := s.service.Reopen(ctx, int(req.Id), int(req.GraderId), req.Reason)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "gRPC: failed to reopen grade", "error", err, "id", req.Id)
+		return nil, toStatusError(err)
+	}
+	return &pb.ReopenGradeResponse{Submission: toProtoSubmission(submission)}, nil
+}
+
+func toProtoSubmission(s *Submission) *pb.Submission {
+	attachmentIDs := make([]int32, len(s.AttachmentIDs))
+	for i, id := range s.AttachmentIDs {
+		attachmentIDs[i] = int32(id)
+	}
+	grades := make([]*pb.Grade, len(s.Grades))
+	for i, grade := range s.Grades {
+		grades[i] = toProtoGrade(grade)
+	}
+	return &pb.Submission{
+		Id:            int32(s.ID),
+		ProjectId:     int32(s.ProjectID),
+		StudentId:     int32(s.StudentID),
+		Version:       int32(s.Version),
+		Text:          s.Text,
+		AttachmentIds: attachmentIDs,
+		Status:        statusToProto(s.Status),
+		SubmittedAt:   timestamppb.New(s.SubmittedAt),
+		Grades:        grades,
+	}
+}
+
+func toProtoGrade(g *Grade) *pb.Grade {
+	rubric := make([]*pb.RubricItem, len(g.Rubric))
+	for i, item := range g.Rubric {
+		rubric[i] = &pb.RubricItem{
+			Criterion: item.Criterion,
+			Points:    item.Points,
+			MaxPoints: item.MaxPoints,
+			Comment:   item.Comment,
+		}
+	}
+	grade := &pb.Grade{
+		Id:           int32(g.ID),
+		GraderId:     int32(g.GraderID),
+		Score:        g.Score,
+		MaxScore:     g.MaxScore,
+		Rubric:       rubric,
+		Feedback:     g.Feedback,
+		GradedAt:     timestamppb.New(g.GradedAt),
+		ReopenedBy:   int32(g.ReopenedBy),
+		ReopenReason: g.ReopenReason,
+	}
+	if g.ReopenedAt != nil {
+		grade.ReopenedAt = timestamppb.New(*g.ReopenedAt)
+	}
+	return grade
+}
+
+func statusToProto(s Status) pb.SubmissionStatus {
+	switch s {
+	case StatusSubmitted:
+		return pb.SubmissionStatus_SUBMISSION_STATUS_SUBMITTED
+	case StatusGraded:
+		return pb.SubmissionStatus_SUBMISSION_STATUS_GRADED
+	case StatusReopened:
+		return pb.SubmissionStatus_SUBMISSION_STATUS_REOPENED
+	}
+	return pb.SubmissionStatus_SUBMISSION_STATUS_UNSPECIFIED
+}
+
+// toStatusError maps domain errors to gRPC status errors
+func toStatusError(err error) error {
+	switch {
+	case errors.Is(err, ErrSubmissionNotFound), errors.Is(err, project.ErrProjectNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, ErrInvalidInput):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, ErrNotSubmitter), errors.Is(err, ErrNotInstructor), errors.Is(err, ErrOwnSubmission), errors.Is(err, ErrNotViewer):
+		return status.Error(codes.PermissionDenied, err.Error())
+	case errors.Is(err, ErrProjectNotActive), errors.Is(err, ErrAlreadyGraded), errors.Is(err, ErrNotGraded):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	default:
+		return status.Error(codes.Internal, err.Error())
+	}
+}
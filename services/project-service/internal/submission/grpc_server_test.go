@@ -0,0 +1,378 @@
+package submission_test
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net"
+	"os"
+	"sync"
+	"testing"
+
+	pb "grud/api/gen/submission/v1"
+	"grud/common/history"
+	commonmetrics "grud/common/metrics"
+	"grud/testing/testdb"
+	"project-service/internal/attachment"
+	"project-service/internal/db"
+	projectmetrics "project-service/internal/metrics"
+	"project-service/internal/project"
+	"project-service/internal/submission"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+type gradedPublisher struct {
+	mu     sync.Mutex
+	events []submission.GradedEvent
+}
+
+func (p *gradedPublisher) SendMessage(ctx context.Context, value interface{}) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.events = append(p.events, value.(submission.GradedEvent))
+	return nil
+}
+
+func (p *gradedPublisher) published() []submission.GradedEvent {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return append([]submission.GradedEvent(nil), p.events...)
+}
+
+func TestSubmissionGrpcServer_Shared(t *testing.T) {
+	pgContainer := testdb.SetupSharedPostgres(t)
+	defer pgContainer.Cleanup(t)
+
+	err := db.RunMigrations(context.Background(), pgContainer.DB,
+		(*project.Project)(nil), (*project.ProjectMember)(nil), (*attachment.Attachment)(nil),
+		(*submission.Submission)(nil), (*submission.Grade)(nil))
+	require.NoError(t, err)
+
+	repo := submission.NewRepository(pgContainer.DB, commonmetrics.NewMock())
+	logger := slog.New(slog.NewTextHandler(os.Stderr, nil))
+	publisher := &gradedPublisher{}
+	service := submission.NewService(repo, publisher, logger)
+
+	lis := bufconn.Listen(1 << 20)
+	server := grpc.NewServer()
+	pb.RegisterSubmissionServiceServer(server, submission.NewGrpcServer(service, logger, projectmetrics.NewMock()))
+	go server.Serve(lis)
+	defer server.Stop()
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+	defer conn.Close()
+	client := pb.NewSubmissionServiceClient(conn)
+
+	const (
+		studentID    = 10
+		classmateID  = 11
+		viewerID     = 12
+		instructorID = 20
+	)
+
+	// newProject creates an active project with a member of every role
+	newProject := func(t *testing.T) *project.Project {
+		ctx := context.Background()
+		p := &project.Project{Name: "Coursework", Status: project.StatusActive}
+		_, err := pgContainer.DB.NewInsert().Model(p).Exec(ctx)
+		require.NoError(t, err)
+		members := []*project.ProjectMember{
+			{ProjectID: p.ID, StudentID: studentID, Role: project.RoleOwner},
+			{ProjectID: p.ID, StudentID: classmateID, Role: project.RoleContributor},
+			{ProjectID: p.ID, StudentID: viewerID, Role: project.RoleViewer},
+			{ProjectID: p.ID, StudentID: instructorID, Role: project.RoleInstructor},
+		}
+		_, err = pgContainer.DB.NewInsert().Model(&members).Exec(ctx)
+		require.NoError(t, err)
+		return p
+	}
+
+	attachments := 0
+	newAttachment := func(t *testing.T, projectID int) *attachment.Attachment {
+		attachments++
+		a := &attachment.Attachment{
+			ProjectID: projectID, Filename: "report.pdf", ContentType: "application/pdf", Size: 1,
+			SHA256: "00", StorageKey: fmt.Sprintf("test/%d", attachments), UploadedBy: history.SystemActor,
+		}
+		_, err := pgContainer.DB.NewInsert().Model(a).Exec(context.Background())
+		require.NoError(t, err)
+		return a
+	}
+
+	submit := func(ctx context.Context, projectID, studentID int, text string, attachmentIDs ...int32) (*pb.Submission, error) {
+		resp, err := client.CreateSubmission(ctx, &pb.CreateSubmissionRequest{
+			ProjectId: int32(projectID), StudentId: int32(studentID), Text: text, AttachmentIds: attachmentIDs,
+		})
+		if err != nil {
+			return nil, err
+		}
+		return resp.Submission, nil
+	}
+
+	t.Run("SubmitAndVersion", func(t *testing.T) {
+		testdb.CleanupTables(t, pgContainer.DB, "projects", "project_members", "attachments", "submissions", "grades")
+		p := newProject(t)
+		ctx := context.Background()
+		report := newAttachment(t, p.ID)
+
+		first, err := submit(ctx, p.ID, studentID, "First draft", int32(report.ID))
+		require.NoError(t, err)
+		assert.Equal(t, int32(1), first.Version)
+		assert.Equal(t, pb.SubmissionStatus_SUBMISSION_STATUS_SUBMITTED, first.Status)
+		assert.Equal(t, []int32{int32(report.ID)}, first.AttachmentIds)
+		assert.Empty(t, first.Grades)
+
+		second, err := submit(ctx, p.ID, studentID, "Final version")
+		require.NoError(t, err)
+		assert.Equal(t, int32(2), second.Version)
+
+		// Versions are numbered per student
+		other, err := submit(ctx, p.ID, classmateID, "My version")
+		require.NoError(t, err)
+		assert.Equal(t, int32(1), other.Version)
+
+		list, err := client.ListSubmissions(ctx, &pb.ListSubmissionsRequest{ProjectId: int32(p.ID), StudentId: studentID, ViewerId: studentID})
+		require.NoError(t, err)
+		require.Len(t, list.Submissions, 2)
+		assert.Equal(t, second.Id, list.Submissions[0].Id)
+
+		list, err = client.ListSubmissions(ctx, &pb.ListSubmissionsRequest{ProjectId: int32(p.ID), ViewerId: instructorID})
+		require.NoError(t, err)
+		assert.Len(t, list.Submissions, 3)
+
+		got, err := client.GetSubmission(ctx, &pb.GetSubmissionRequest{Id: first.Id, ViewerId: studentID})
+		require.NoError(t, err)
+		assert.Equal(t, "First draft", got.Submission.Text)
+		_, err = client.GetSubmission(ctx, &pb.GetSubmissionRequest{Id: first.Id, ViewerId: instructorID})
+		require.NoError(t, err)
+	})
+
+	t.Run("VisibleToAuthorAndInstructors", func(t *testing.T) {
+		testdb.CleanupTables(t, pgContainer.DB, "projects", "project_members", "attachments", "submissions", "grades")
+		p := newProject(t)
+		ctx := context.Background()
+		mine, err := submit(ctx, p.ID, studentID, "Work")
+		require.NoError(t, err)
+		_, err = submit(ctx, p.ID, classmateID, "Other work")
+		require.NoError(t, err)
+
+		for _, id := range []int32{classmateID, viewerID, 99} {
+			_, err = client.GetSubmission(ctx, &pb.GetSubmissionRequest{Id: mine.Id, ViewerId: id})
+			assert.Equal(t, codes.PermissionDenied, status.Code(err), id)
+			_, err = client.ListSubmissions(ctx, &pb.ListSubmissionsRequest{ProjectId: int32(p.ID), StudentId: studentID, ViewerId: id})
+			assert.Equal(t, codes.PermissionDenied, status.Code(err), id)
+			_, err = client.ListSubmissions(ctx, &pb.ListSubmissionsRequest{StudentId: studentID, ViewerId: id})
+			assert.Equal(t, codes.PermissionDenied, status.Code(err), id)
+		}
+
+		// Without a student filter, others only see their own submissions
+		list, err := client.ListSubmissions(ctx, &pb.ListSubmissionsRequest{ProjectId: int32(p.ID), ViewerId: classmateID})
+		require.NoError(t, err)
+		require.Len(t, list.Submissions, 1)
+		assert.Equal(t, int32(classmateID), list.Submissions[0].StudentId)
+
+		_, err = client.GetSubmission(ctx, &pb.GetSubmissionRequest{Id: mine.Id})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+
+	t.Run("ConcurrentVersions", func(t *testing.T) {
+		testdb.CleanupTables(t, pgContainer.DB, "projects", "project_members", "attachments", "submissions", "grades")
+		p := newProject(t)
+		ctx := context.Background()
+
+		const n = 5
+		var wg sync.WaitGroup
+		errs := make(chan error, n)
+		for i := 0; i < n; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				_, err := submit(ctx, p.ID, studentID, "Work")
+				errs <- err
+			}()
+		}
+		wg.Wait()
+		close(errs)
+		for err := range errs {
+			require.NoError(t, err)
+		}
+
+		list, err := client.ListSubmissions(ctx, &pb.ListSubmissionsRequest{StudentId: studentID, ViewerId: studentID})
+		require.NoError(t, err)
+		versions := map[int32]bool{}
+		for _, s := range list.Submissions {
+			versions[s.Version] = true
+		}
+		assert.Equal(t, map[int32]bool{1: true, 2: true, 3: true, 4: true, 5: true}, versions)
+	})
+
+	t.Run("SubmitRejected", func(t *testing.T) {
+		testdb.CleanupTables(t, pgContainer.DB, "projects", "project_members", "attachments", "submissions", "grades")
+		p, elsewhere := newProject(t), newProject(t)
+		ctx := context.Background()
+		foreign := newAttachment(t, elsewhere.ID)
+
+		_, err := submit(ctx, p.ID, studentID, "")
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+		_, err = submit(ctx, p.ID, studentID, "Work", int32(foreign.ID))
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+		for _, id := range []int{viewerID, instructorID, 99} {
+			_, err = submit(ctx, p.ID, id, "Work")
+			assert.Equal(t, codes.PermissionDenied, status.Code(err), id)
+		}
+
+		_, err = submit(ctx, 999999, studentID, "Work")
+		assert.Equal(t, codes.NotFound, status.Code(err))
+
+		_, err = pgContainer.DB.NewUpdate().Model(p).Set("status = ?", project.StatusCompleted).WherePK().Exec(ctx)
+		require.NoError(t, err)
+		_, err = submit(ctx, p.ID, studentID, "Late work")
+		assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+
+		_, err = client.ListSubmissions(ctx, &pb.ListSubmissionsRequest{ViewerId: studentID})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+		_, err = client.GetSubmission(ctx, &pb.GetSubmissionRequest{Id: 999999, ViewerId: studentID})
+		assert.Equal(t, codes.NotFound, status.Code(err))
+	})
+
+	t.Run("GradeAndReopen", func(t *testing.T) {
+		testdb.CleanupTables(t, pgContainer.DB, "projects", "project_members", "attachments", "submissions", "grades")
+		p := newProject(t)
+		ctx := context.Background()
+		s, err := submit(ctx, p.ID, studentID, "Work")
+		require.NoError(t, err)
+		published := len(publisher.published())
+
+		rubric := []*pb.RubricItem{
+			{Criterion: "Design", Points: 8, MaxPoints: 10, Comment: "Clear"},
+			{Criterion: "Tests", Points: 6, MaxPoints: 10},
+		}
+		graded, err := client.GradeSubmission(ctx, &pb.GradeSubmissionRequest{
+			Id: s.Id, GraderId: instructorID, Score: 14, Rubric: rubric, Feedback: "Add more tests",
+		})
+		require.NoError(t, err)
+		assert.Equal(t, pb.SubmissionStatus_SUBMISSION_STATUS_GRADED, graded.Submission.Status)
+		require.Len(t, graded.Submission.Grades, 1)
+		grade := graded.Submission.Grades[0]
+		assert.Equal(t, int32(instructorID), grade.GraderId)
+		assert.Equal(t, 14.0, grade.Score)
+		assert.Equal(t, 20.0, grade.MaxScore)
+		assert.Equal(t, "Clear", grade.Rubric[0].Comment)
+		assert.Nil(t, grade.ReopenedAt)
+
+		_, err = client.GradeSubmission(ctx, &pb.GradeSubmissionRequest{Id: s.Id, GraderId: instructorID, Score: 90})
+		assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+
+		reopened, err := client.ReopenGrade(ctx, &pb.ReopenGradeRequest{Id: s.Id, GraderId: instructorID, Reason: "Regrade request"})
+		require.NoError(t, err)
+		assert.Equal(t, pb.SubmissionStatus_SUBMISSION_STATUS_REOPENED, reopened.Submission.Status)
+		require.Len(t, reopened.Submission.Grades, 1)
+		assert.NotNil(t, reopened.Submission.Grades[0].ReopenedAt)
+		assert.Equal(t, int32(instructorID), reopened.Submission.Grades[0].ReopenedBy)
+		assert.Equal(t, "Regrade request", reopened.Submission.Grades[0].ReopenReason)
+
+		_, err = client.ReopenGrade(ctx, &pb.ReopenGradeRequest{Id: s.Id, GraderId: instructorID})
+		assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+
+		regraded, err := client.GradeSubmission(ctx, &pb.GradeSubmissionRequest{Id: s.Id, GraderId: instructorID, Score: 16, MaxScore: 20})
+		require.NoError(t, err)
+		assert.Equal(t, pb.SubmissionStatus_SUBMISSION_STATUS_GRADED, regraded.Submission.Status)
+		require.Len(t, regraded.Submission.Grades, 2)
+		assert.Equal(t, 16.0, regraded.Submission.Grades[0].Score)
+		assert.Nil(t, regraded.Submission.Grades[0].ReopenedAt)
+		assert.NotNil(t, regraded.Submission.Grades[1].ReopenedAt)
+
+		// Both grades were announced to the student
+		events := publisher.published()[published:]
+		require.Len(t, events, 2)
+		assert.Equal(t, int(s.Id), events[1].SubmissionID)
+		assert.Equal(t, p.ID, events[1].ProjectID)
+		assert.Equal(t, []int{studentID}, events[1].StudentIDs)
+		assert.Equal(t, 16.0, events[1].Score)
+		assert.False(t, events[1].GradedAt.IsZero())
+	})
+
+	t.Run("GradeRestrictedToInstructors", func(t *testing.T) {
+		testdb.CleanupTables(t, pgContainer.DB, "projects", "project_members", "attachments", "submissions", "grades")
+		p, other := newProject(t), newProject(t)
+		ctx := context.Background()
+		s, err := submit(ctx, p.ID, studentID, "Work")
+		require.NoError(t, err)
+
+		// Being an instructor of another project does not count
+		_, err = pgContainer.DB.NewUpdate().Model((*project.ProjectMember)(nil)).
+			Set("role = ?", project.RoleContributor).
+			Where("project_id = ? AND student_id = ?", p.ID, instructorID).Exec(ctx)
+		require.NoError(t, err)
+		role, err := repo.MemberRole(ctx, other.ID, instructorID)
+		require.NoError(t, err)
+		require.Equal(t, project.RoleInstructor, role)
+
+		for _, id := range []int32{studentID, classmateID, viewerID, instructorID} {
+			_, err = client.GradeSubmission(ctx, &pb.GradeSubmissionRequest{Id: s.Id, GraderId: id, Score: 100})
+			assert.Equal(t, codes.PermissionDenied, status.Code(err), id)
+		}
+
+		_, err = client.GradeSubmission(ctx, &pb.GradeSubmissionRequest{Id: s.Id, GraderId: instructorID, Score: 101})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+		_, err = client.GradeSubmission(ctx, &pb.GradeSubmissionRequest{Id: 999999, GraderId: instructorID, Score: 50})
+		assert.Equal(t, codes.NotFound, status.Code(err))
+
+		// Nor may the author grade their own work after becoming an instructor
+		_, err = pgContainer.DB.NewUpdate().Model((*project.ProjectMember)(nil)).
+			Set("role = ?", project.RoleInstructor).
+			Where("project_id = ? AND student_id = ?", p.ID, studentID).Exec(ctx)
+		require.NoError(t, err)
+		_, err = client.GradeSubmission(ctx, &pb.GradeSubmissionRequest{Id: s.Id, GraderId: studentID, Score: 100})
+		assert.Equal(t, codes.PermissionDenied, status.Code(err))
+		_, err = client.ReopenGrade(ctx, &pb.ReopenGradeRequest{Id: s.Id, GraderId: studentID})
+		assert.Equal(t, codes.PermissionDenied, status.Code(err))
+
+		got, err := client.GetSubmission(ctx, &pb.GetSubmissionRequest{Id: s.Id, ViewerId: studentID})
+		require.NoError(t, err)
+		assert.Equal(t, pb.SubmissionStatus_SUBMISSION_STATUS_SUBMITTED, got.Submission.Status)
+		assert.Empty(t, got.Submission.Grades)
+	})
+
+	t.Run("PurgeOrphans", func(t *testing.T) {
+		testdb.CleanupTables(t, pgContainer.DB, "projects", "project_members", "attachments", "submissions", "grades")
+		kept, purged := newProject(t), newProject(t)
+		ctx := context.Background()
+		for _, p := range []*project.Project{kept, purged} {
+			s, err := submit(ctx, p.ID, studentID, "Work")
+			require.NoError(t, err)
+			_, err = client.GradeSubmission(ctx, &pb.GradeSubmissionRequest{Id: s.Id, GraderId: instructorID, Score: 50})
+			require.NoError(t, err)
+		}
+
+		_, err := pgContainer.DB.NewDelete().Model(purged).WherePK().ForceDelete().Exec(ctx)
+		require.NoError(t, err)
+		removed, err := service.PurgeOrphans(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, 1, removed)
+
+		grades, err := pgContainer.DB.NewSelect().Model((*submission.Grade)(nil)).Count(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, 1, grades)
+
+		list, err := client.ListSubmissions(ctx, &pb.ListSubmissionsRequest{StudentId: studentID, ViewerId: studentID})
+		require.NoError(t, err)
+		require.Len(t, list.Submissions, 1)
+		assert.Equal(t, int32(kept.ID), list.Submissions[0].ProjectId)
+	})
+}
@@ -0,0 +1,227 @@
+package submission
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/uptrace/bun"
+)
+
+const (
+	// MaxTextLength is the longest accepted submission text, in bytes
+	MaxTextLength = 100_000
+	// MaxAttachments is the most attachments one submission may reference
+	MaxAttachments = 20
+	// MaxRubricItems is the most criteria one grade may score
+	MaxRubricItems = 50
+	// MaxFeedbackLength is the longest accepted feedback or reopen reason, in bytes
+	MaxFeedbackLength = 20_000
+	// MaxCriterionLength is the longest accepted rubric criterion, in bytes
+	MaxCriterionLength = 200
+)
+
+// DefaultMaxScore is the maximum score of a grade given without one
+const DefaultMaxScore = 100
+
+// scoreTolerance absorbs rounding when rubric points are summed
+const scoreTolerance = 1e-6
+
+// Status is where a submission is in the grading workflow
+type Status string
+
+const (
+	StatusSubmitted Status = "submitted"
+	StatusGraded    Status = "graded"
+	StatusReopened  Status = "reopened"
+)
+
+// Submission is one version of a student's work on a project. Versions are
+// numbered per project and student, starting at 1.
+type Submission struct {
+	bun.BaseModel `bun:"table:submissions,alias:sub"`
+
+	ID            int       `bun:"id,pk,autoincrement" json:"id"`
+	ProjectID     int       `bun:"project_id,notnull,unique:submissions_project_student_version" json:"projectId"`
+	StudentID     int       `bun:"student_id,notnull,unique:submissions_project_student_version" json:"studentId"`
+	Version       int       `bun:"version,notnull,unique:submissions_project_student_version" json:"version"`
+	Text          string    `bun:"text,notnull" json:"text"`
+	AttachmentIDs []int     `bun:"attachment_ids,array,notnull" json:"attachmentIds"`
+	Status        Status    `bun:"status,notnull" json:"status"`
+	SubmittedAt   time.Time `bun:"submitted_at,notnull,default:current_timestamp" json:"submittedAt"`
+
+	// Grades are loaded newest first
+	Grades []*Grade `bun:"rel:has-many,join:id=submission_id" json:"grades"`
+}
+
+// Grade is an instructor's assessment of a submission. Reopening a grade
+// keeps it, with ReopenedAt set, until the next grade supersedes it.
+type Grade struct {
+	bun.BaseModel `bun:"table:grades,alias:g"`
+
+	ID           int          `bun:"id,pk,autoincrement" json:"id"`
+	SubmissionID int          `bun:"submission_id,notnull" json:"submissionId"`
+	GraderID     int          `bun:"grader_id,notnull" json:"graderId"`
+	Score        float64      `bun:"score,notnull" json:"score"`
+	MaxScore     float64      `bun:"max_score,notnull" json:"maxScore"`
+	Rubric       []RubricItem `bun:"rubric,type:jsonb,notnull" json:"rubric"`
+	Feedback     string       `bun:"feedback,notnull" json:"feedback"`
+	GradedAt     time.Time    `bun:"graded_at,notnull,default:current_timestamp" json:"gradedAt"`
+	ReopenedAt   *time.Time   `bun:"reopened_at" json:"reopenedAt,omitempty"`
+	ReopenedBy   int          `bun:"reopened_by,nullzero" json:"reopenedBy,omitempty"`
+	ReopenReason string       `bun:"reopen_reason,nullzero" json:"reopenReason,omitempty"`
+}
+
+// GradedEvent is published on NATS when a submission is graded, addressed to
+// the student who submitted it
+type GradedEvent struct {
+	SubmissionID int       `json:"submissionId"`
+	ProjectID    int       `json:"projectId"`
+	Version      int       `json:"version"`
+	Score        float64   `json:"score"`
+	MaxScore     float64   `json:"maxScore"`
+	StudentIDs   []int     `json:"studentIds"`
+	GradedAt     time.Time `json:"gradedAt"`
+}
+
+func newGradedEvent(s *Submission) GradedEvent {
+	e := GradedEvent{
+		SubmissionID: s.ID,
+		ProjectID:    s.ProjectID,
+		Version:      s.Version,
+		StudentIDs:   []int{s.StudentID},
+	}
+	if len(s.Grades) > 0 {
+		e.Score = s.Grades[0].Score
+		e.MaxScore = s.Grades[0].MaxScore
+		e.GradedAt = s.Grades[0].GradedAt
+	}
+	return e
+}
+
+// RubricItem scores one criterion of a grade
+type RubricItem struct {
+	Criterion string  `json:"criterion"`
+	Points    float64 `json:"points"`
+	MaxPoints float64 `json:"maxPoints"`
+	Comment   string  `json:"comment,omitempty"`
+}
+
+// NewSubmission is the work a student hands in
+type NewSubmission struct {
+	ProjectID     int
+	StudentID     int
+	Text          string
+	AttachmentIDs []int
+}
+
+// Assessment is the grade an instructor gives a submission. MaxScore
+// defaults to DefaultMaxScore, or to the sum of the rubric's max points.
+type Assessment struct {
+	GraderID int
+	Score    float64
+	MaxScore float64
+	Rubric   []RubricItem
+	Feedback string
+}
+
+// Filter selects submissions by project, student or both; zero matches any
+type Filter struct {
+	ProjectID int
+	StudentID int
+}
+
+// Validate checks the submission and drops duplicate attachment IDs
+func (n *NewSubmission) Validate() error {
+	if n.ProjectID <= 0 || n.StudentID <= 0 {
+		return fmt.Errorf("%w: project and student are required", ErrInvalidInput)
+	}
+	n.Text = strings.TrimSpace(n.Text)
+	if len(n.Text) > MaxTextLength {
+		return fmt.Errorf("%w: text is longer than %d bytes", ErrInvalidInput, MaxTextLength)
+	}
+
+	seen := make(map[int]bool, len(n.AttachmentIDs))
+	ids := make([]int, 0, len(n.AttachmentIDs))
+	for _, id := range n.AttachmentIDs {
+		if id <= 0 {
+			return fmt.Errorf("%w: invalid attachment ID %d", ErrInvalidInput, id)
+		}
+		if !seen[id] {
+			seen[id] = true
+			ids = append(ids, id)
+		}
+	}
+	if len(ids) > MaxAttachments {
+		return fmt.Errorf("%w: at most %d attachments may be submitted", ErrInvalidInput, MaxAttachments)
+	}
+	n.AttachmentIDs = ids
+
+	if n.Text == "" && len(n.AttachmentIDs) == 0 {
+		return fmt.Errorf("%w: a submission needs text or attachments", ErrInvalidInput)
+	}
+	return nil
+}
+
+// Validate checks the assessment and fills in its default maximum score
+func (a *Assessment) Validate() error {
+	if a.GraderID <= 0 {
+		return fmt.Errorf("%w: grader is required", ErrInvalidInput)
+	}
+	if len(a.Rubric) > MaxRubricItems {
+		return fmt.Errorf("%w: at most %d rubric items are allowed", ErrInvalidInput, MaxRubricItems)
+	}
+	a.Feedback = strings.TrimSpace(a.Feedback)
+	if len(a.Feedback) > MaxFeedbackLength {
+		return fmt.Errorf("%w: feedback is longer than %d bytes", ErrInvalidInput, MaxFeedbackLength)
+	}
+
+	var points, maxPoints float64
+	for i := range a.Rubric {
+		item := &a.Rubric[i]
+		item.Criterion = strings.TrimSpace(item.Criterion)
+		item.Comment = strings.TrimSpace(item.Comment)
+		switch {
+		case item.Criterion == "" || len(item.Criterion) > MaxCriterionLength:
+			return fmt.Errorf("%w: rubric item %d needs a criterion of at most %d bytes", ErrInvalidInput, i+1, MaxCriterionLength)
+		case !finite(item.MaxPoints) || item.MaxPoints <= 0:
+			return fmt.Errorf("%w: rubric item %q needs positive max points", ErrInvalidInput, item.Criterion)
+		case !finite(item.Points) || item.Points < 0 || item.Points > item.MaxPoints:
+			return fmt.Errorf("%w: rubric item %q must score between 0 and %g", ErrInvalidInput, item.Criterion, item.MaxPoints)
+		case len(item.Comment) > MaxFeedbackLength:
+			return fmt.Errorf("%w: rubric item %q has a comment longer than %d bytes", ErrInvalidInput, item.Criterion, MaxFeedbackLength)
+		}
+		points += item.Points
+		maxPoints += item.MaxPoints
+	}
+
+	if a.MaxScore == 0 {
+		a.MaxScore = DefaultMaxScore
+		if len(a.Rubric) > 0 {
+			a.MaxScore = maxPoints
+		}
+	}
+	if !finite(a.MaxScore) || a.MaxScore <= 0 {
+		return fmt.Errorf("%w: max score must be positive", ErrInvalidInput)
+	}
+	if !finite(a.Score) || a.Score < 0 || a.Score > a.MaxScore {
+		return fmt.Errorf("%w: score must be between 0 and %g", ErrInvalidInput, a.MaxScore)
+	}
+	if len(a.Rubric) > 0 {
+		if math.Abs(points-a.Score) > scoreTolerance {
+			return fmt.Errorf("%w: score %g does not match the rubric total of %g", ErrInvalidInput, a.Score, points)
+		}
+		if math.Abs(maxPoints-a.MaxScore) > scoreTolerance {
+			return fmt.Errorf("%w: max score %g does not match the rubric total of %g", ErrInvalidInput, a.MaxScore, maxPoints)
+		}
+	}
+	if a.Rubric == nil {
+		a.Rubric = []RubricItem{}
+	}
+	return nil
+}
+
+func finite(f float64) bool {
+	return !math.IsNaN(f) && !math.IsInf(f, 0)
+}
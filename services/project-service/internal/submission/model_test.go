@@ -0,0 +1,81 @@
+package submission_test
+
+import (
+	"math"
+	"strings"
+	"testing"
+
+	"project-service/internal/submission"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewSubmission_Validate(t *testing.T) {
+	n := submission.NewSubmission{ProjectID: 1, StudentID: 2, Text: "  done  ", AttachmentIDs: []int{3, 4, 3}}
+	require.NoError(t, n.Validate())
+	assert.Equal(t, "done", n.Text)
+	assert.Equal(t, []int{3, 4}, n.AttachmentIDs)
+
+	attachmentsOnly := submission.NewSubmission{ProjectID: 1, StudentID: 2, AttachmentIDs: []int{3}}
+	assert.NoError(t, attachmentsOnly.Validate())
+
+	tooMany := make([]int, submission.MaxAttachments+1)
+	for i := range tooMany {
+		tooMany[i] = i + 1
+	}
+	invalid := map[string]submission.NewSubmission{
+		"NoProject":     {StudentID: 2, Text: "done"},
+		"NoStudent":     {ProjectID: 1, Text: "done"},
+		"Empty":         {ProjectID: 1, StudentID: 2, Text: "   "},
+		"TextTooLong":   {ProjectID: 1, StudentID: 2, Text: strings.Repeat("a", submission.MaxTextLength+1)},
+		"BadAttachment": {ProjectID: 1, StudentID: 2, AttachmentIDs: []int{0}},
+		"TooMany":       {ProjectID: 1, StudentID: 2, AttachmentIDs: tooMany},
+	}
+	for name, n := range invalid {
+		t.Run(name, func(t *testing.T) {
+			assert.ErrorIs(t, n.Validate(), submission.ErrInvalidInput)
+		})
+	}
+}
+
+func TestAssessment_Validate(t *testing.T) {
+	t.Run("DefaultMaxScore", func(t *testing.T) {
+		a := submission.Assessment{GraderID: 1, Score: 87.5, Feedback: " Good work "}
+		require.NoError(t, a.Validate())
+		assert.Equal(t, float64(submission.DefaultMaxScore), a.MaxScore)
+		assert.Equal(t, "Good work", a.Feedback)
+		assert.NotNil(t, a.Rubric)
+	})
+
+	t.Run("RubricTotals", func(t *testing.T) {
+		a := submission.Assessment{GraderID: 1, Score: 0.3, Rubric: []submission.RubricItem{
+			{Criterion: "Design", Points: 0.1, MaxPoints: 0.5},
+			{Criterion: "Tests", Points: 0.2, MaxPoints: 0.5},
+		}}
+		require.NoError(t, a.Validate())
+		assert.Equal(t, 1.0, a.MaxScore)
+	})
+
+	rubric := func() []submission.RubricItem {
+		return []submission.RubricItem{{Criterion: "Design", Points: 8, MaxPoints: 10}}
+	}
+	invalid := map[string]submission.Assessment{
+		"NoGrader":          {Score: 50},
+		"NegativeScore":     {GraderID: 1, Score: -1},
+		"ScoreAboveMax":     {GraderID: 1, Score: 11, MaxScore: 10},
+		"NaNScore":          {GraderID: 1, Score: math.NaN()},
+		"NegativeMax":       {GraderID: 1, MaxScore: -10},
+		"ScoreNotRubricSum": {GraderID: 1, Score: 7, Rubric: rubric()},
+		"MaxNotRubricSum":   {GraderID: 1, Score: 8, MaxScore: 20, Rubric: rubric()},
+		"NoCriterion":       {GraderID: 1, Score: 8, Rubric: []submission.RubricItem{{Points: 8, MaxPoints: 10}}},
+		"PointsAboveMax":    {GraderID: 1, Score: 12, Rubric: []submission.RubricItem{{Criterion: "Design", Points: 12, MaxPoints: 10}}},
+		"ZeroMaxPoints":     {GraderID: 1, Rubric: []submission.RubricItem{{Criterion: "Design"}}},
+		"FeedbackTooLong":   {GraderID: 1, Score: 50, Feedback: strings.Repeat("a", submission.MaxFeedbackLength+1)},
+	}
+	for name, a := range invalid {
+		t.Run(name, func(t *testing.T) {
+			assert.ErrorIs(t, a.Validate(), submission.ErrInvalidInput)
+		})
+	}
+}
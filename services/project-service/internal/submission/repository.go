@@ -0,0 +1,291 @@
+package submission
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"grud/common/metrics"
+	"project-service/internal/attachment"
+	"project-service/internal/project"
+
+	"github.com/uptrace/bun"
+)
+
+type Repository interface {
+	// Create numbers the submission after the student's latest one for the
+	// project and inserts it. It returns ErrNotSubmitter if the student is no
+	// longer a member.
+	Create(ctx context.Context, submission *Submission) error
+	// GetByID returns the submission with its grades
+	GetByID(ctx context.Context, id int) (*Submission, error)
+	// List returns the matching submissions with their grades, newest first
+	List(ctx context.Context, filter Filter) ([]*Submission, error)
+	// AddGrade grades a submitted or reopened submission, returning
+	// ErrAlreadyGraded if it is graded
+	AddGrade(ctx context.Context, grade *Grade) error
+	// Reopen reopens the grade of a graded submission, returning ErrNotGraded
+	// if it is not graded
+	Reopen(ctx context.Context, submissionID, reopenedBy int, reason string) error
+	// ProjectStatus returns the status of a project that is not deleted
+	ProjectStatus(ctx context.Context, projectID int) (project.Status, error)
+	// MemberRole returns the student's role in the project, or "" if the
+	// student is not a member
+	MemberRole(ctx context.Context, projectID, studentID int) (project.Role, error)
+	// CountAttachments returns how many of the attachments belong to the project
+	CountAttachments(ctx context.Context, projectID int, ids []int) (int, error)
+	// PurgeOrphans deletes the submissions and grades of purged projects and
+	// returns how many submissions were deleted
+	PurgeOrphans(ctx context.Context) (int, error)
+}
+
+type repository struct {
+	db      *bun.DB
+	metrics *metrics.Metrics
+}
+
+func NewRepository(db *bun.DB, m *metrics.Metrics) Repository {
+	return &repository{
+		db:      db,
+		metrics: m,
+	}
+}
+
+func (r *repository) Create(ctx context.Context, submission *Submission) error {
+	return r.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		// Locking the membership serializes the student's submissions to the
+		// project, so versions are handed out without gaps or collisions
+		start := time.Now()
+		member := new(project.ProjectMember)
+		err := tx.NewSelect().
+			Model(member).
+			Where("project_id = ? AND student_id = ?", submission.ProjectID, submission.StudentID).
+			For("UPDATE").
+			Scan(ctx)
+		r.metrics.Database.RecordQuery(ctx, "select", "project_members", time.Since(start), err)
+
+		if err == sql.ErrNoRows {
+			return ErrNotSubmitter
+		}
+		if err != nil {
+			return err
+		}
+
+		start = time.Now()
+		err = tx.NewSelect().
+			Model((*Submission)(nil)).
+			ColumnExpr("COALESCE(MAX(version), 0) + 1").
+			Where("project_id = ? AND student_id = ?", submission.ProjectID, submission.StudentID).
+			Scan(ctx, &submission.Version)
+		r.metrics.Database.RecordQuery(ctx, "select", "submissions", time.Since(start), err)
+
+		if err != nil {
+			return err
+		}
+
+		start = time.Now()
+		_, err = tx.NewInsert().Model(submission).Returning("*").Exec(ctx)
+		r.metrics.Database.RecordQuery(ctx, "insert", "submissions", time.Since(start), err)
+
+		if err != nil {
+			return err
+		}
+		submission.Grades = []*Grade{}
+		return nil
+	})
+}
+
+func (r *repository) GetByID(ctx context.Context, id int) (*Submission, error) {
+	start := time.Now()
+	submission := new(Submission)
+	err := r.db.NewSelect().
+		Model(submission).
+		Relation("Grades", orderGrades).
+		Where("sub.id = ?", id).
+		Scan(ctx)
+	r.metrics.Database.RecordQuery(ctx, "select", "submissions", time.Since(start), err)
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrSubmissionNotFound
+		}
+		return nil, err
+	}
+	return submission, nil
+}
+
+func (r *repository) List(ctx context.Context, filter Filter) ([]*Submission, error) {
+	start := time.Now()
+	submissions := []*Submission{}
+	query := r.db.NewSelect().
+		Model(&submissions).
+		Relation("Grades", orderGrades).
+		Order("sub.submitted_at DESC", "sub.id DESC")
+	if filter.ProjectID != 0 {
+		query = query.Where("sub.project_id = ?", filter.ProjectID)
+	}
+	if filter.StudentID != 0 {
+		query = query.Where("sub.student_id = ?", filter.StudentID)
+	}
+	err := query.Scan(ctx)
+	r.metrics.Database.RecordQuery(ctx, "select", "submissions", time.Since(start), err)
+
+	return submissions, err
+}
+
+func (r *repository) AddGrade(ctx context.Context, grade *Grade) error {
+	return r.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		// The status check and change in one statement keeps two graders from
+		// both grading the same submission
+		start := time.Now()
+		result, err := tx.NewUpdate().
+			Model((*Submission)(nil)).
+			Set("status = ?", StatusGraded).
+			Where("id = ?", grade.SubmissionID).
+			Where("status IN (?)", bun.In([]Status{StatusSubmitted, StatusReopened})).
+			Exec(ctx)
+		r.metrics.Database.RecordQuery(ctx, "update", "submissions", time.Since(start), err)
+
+		if err != nil {
+			return err
+		}
+		if rows, err := result.RowsAffected(); err == nil && rows == 0 {
+			return ErrAlreadyGraded
+		}
+
+		start = time.Now()
+		_, err = tx.NewInsert().Model(grade).Returning("*").Exec(ctx)
+		r.metrics.Database.RecordQuery(ctx, "insert", "grades", time.Since(start), err)
+
+		return err
+	})
+}
+
+func (r *repository) Reopen(ctx context.Context, submissionID, reopenedBy int, reason string) error {
+	return r.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		start := time.Now()
+		result, err := tx.NewUpdate().
+			Model((*Submission)(nil)).
+			Set("status = ?", StatusReopened).
+			Where("id = ?", submissionID).
+			Where("status = ?", StatusGraded).
+			Exec(ctx)
+		r.metrics.Database.RecordQuery(ctx, "update", "submissions", time.Since(start), err)
+
+		if err != nil {
+			return err
+		}
+		if rows, err := result.RowsAffected(); err == nil && rows == 0 {
+			return ErrNotGraded
+		}
+
+		start = time.Now()
+		_, err = tx.NewUpdate().
+			Model((*Grade)(nil)).
+			Set("reopened_at = current_timestamp").
+			Set("reopened_by = ?", reopenedBy).
+			Set("reopen_reason = ?", bun.NullZero(reason)).
+			Where("submission_id = ?", submissionID).
+			Where("reopened_at IS NULL").
+			Exec(ctx)
+		r.metrics.Database.RecordQuery(ctx, "update", "grades", time.Since(start), err)
+
+		return err
+	})
+}
+
+func (r *repository) ProjectStatus(ctx context.Context, projectID int) (project.Status, error) {
+	start := time.Now()
+	var status project.Status
+	err := r.db.NewSelect().
+		Model((*project.Project)(nil)).
+		Column("status").
+		Where("id = ?", projectID).
+		Scan(ctx, &status)
+	r.metrics.Database.RecordQuery(ctx, "select", "projects", time.Since(start), err)
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return "", project.ErrProjectNotFound
+		}
+		return "", err
+	}
+	return status, nil
+}
+
+func (r *repository) MemberRole(ctx context.Context, projectID, studentID int) (project.Role, error) {
+	start := time.Now()
+	var role project.Role
+	err := r.db.NewSelect().
+		Model((*project.ProjectMember)(nil)).
+		Column("role").
+		Where("project_id = ? AND student_id = ?", projectID, studentID).
+		Scan(ctx, &role)
+	r.metrics.Database.RecordQuery(ctx, "select", "project_members", time.Since(start), err)
+
+	if err == sql.ErrNoRows {
+		return "", nil
+	}
+	return role, err
+}
+
+func (r *repository) CountAttachments(ctx context.Context, projectID int, ids []int) (int, error) {
+	if len(ids) == 0 {
+		return 0, nil
+	}
+
+	start := time.Now()
+	count, err := r.db.NewSelect().
+		Model((*attachment.Attachment)(nil)).
+		Where("project_id = ?", projectID).
+		Where("id IN (?)", bun.In(ids)).
+		Count(ctx)
+	r.metrics.Database.RecordQuery(ctx, "select", "attachments", time.Since(start), err)
+
+	return count, err
+}
+
+func (r *repository) PurgeOrphans(ctx context.Context) (int, error) {
+	var purged int
+	err := r.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		orphans := tx.NewSelect().
+			Model((*Submission)(nil)).
+			Column("id").
+			Where("NOT EXISTS (?)", tx.NewSelect().
+				Model((*project.Project)(nil)).
+				ColumnExpr("1").
+				Where("p.id = sub.project_id").
+				WhereAllWithDeleted())
+
+		start := time.Now()
+		_, err := tx.NewDelete().
+			Model((*Grade)(nil)).
+			Where("submission_id IN (?)", orphans).
+			Exec(ctx)
+		r.metrics.Database.RecordQuery(ctx, "purge", "grades", time.Since(start), err)
+
+		if err != nil {
+			return err
+		}
+
+		start = time.Now()
+		result, err := tx.NewDelete().
+			Model((*Submission)(nil)).
+			Where("id IN (?)", orphans).
+			Exec(ctx)
+		r.metrics.Database.RecordQuery(ctx, "purge", "submissions", time.Since(start), err)
+
+		if err != nil {
+			return err
+		}
+		rows, err := result.RowsAffected()
+		purged = int(rows)
+		return err
+	})
+	return purged, err
+}
+
+// orderGrades loads a submission's grades newest first
+func orderGrades(q *bun.SelectQuery) *bun.SelectQuery {
+	return q.Order("g.id DESC")
+}
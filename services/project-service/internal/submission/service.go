@@ -0,0 +1,240 @@
+package submission
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"project-service/internal/project"
+)
+
+var (
+	ErrSubmissionNotFound = errors.New("submission not found")
+	ErrInvalidInput       = errors.New("invalid input")
+	ErrNotSubmitter       = errors.New("only owners and contributors of the project may submit")
+	ErrNotInstructor      = errors.New("only instructors of the project may grade its submissions")
+	ErrOwnSubmission      = errors.New("students may not grade their own submissions")
+	ErrNotViewer          = errors.New("only the author and instructors of the project may see a submission")
+	ErrProjectNotActive   = errors.New("project is not accepting submissions")
+	ErrAlreadyGraded      = errors.New("submission is already graded")
+	ErrNotGraded          = errors.New("submission is not graded")
+)
+
+type Service interface {
+	// Submit records a new version of the student's work on an active
+	// project. Attachments must belong to the project.
+	Submit(ctx context.Context, n NewSubmission) (*Submission, error)
+	// Get returns the submission to its author or an instructor of its
+	// project
+	Get(ctx context.Context, id, viewerID int) (*Submission, error)
+	// List returns the submissions matching the filter, newest first. At
+	// least one of its fields must be set. Viewers who are not instructors of
+	// the filtered project only get their own submissions.
+	List(ctx context.Context, filter Filter, viewerID int) ([]*Submission, error)
+	// Grade grades a submitted or reopened submission on behalf of an
+	// instructor of its project
+	Grade(ctx context.Context, id int, a Assessment) (*Submission, error)
+	// Reopen reopens the grade of a graded submission on behalf of an
+	// instructor of its project, so that it can be graded again
+	Reopen(ctx context.Context, id, graderID int, reason string) (*Submission, error)
+	// PurgeOrphans removes the submissions of purged projects and returns
+	// how many were removed
+	PurgeOrphans(ctx context.Context) (int, error)
+}
+
+// Publisher sends an event to NATS. It is implemented by *messaging.Producer.
+type Publisher interface {
+	SendMessage(ctx context.Context, value interface{}) error
+}
+
+type service struct {
+	repo      Repository
+	publisher Publisher
+	logger    *slog.Logger
+}
+
+// NewService creates the submission service. publisher may be nil, in which
+// case no graded events are published.
+func NewService(repo Repository, publisher Publisher, logger *slog.Logger) Service {
+	return &service{
+		repo:      repo,
+		publisher: publisher,
+		logger:    logger,
+	}
+}
+
+func (s *service) Submit(ctx context.Context, n NewSubmission) (*Submission, error) {
+	if err := n.Validate(); err != nil {
+		return nil, err
+	}
+
+	status, err := s.repo.ProjectStatus(ctx, n.ProjectID)
+	if err != nil {
+		return nil, err
+	}
+	if status != project.StatusActive {
+		return nil, fmt.Errorf("%w: project is %s", ErrProjectNotActive, status)
+	}
+
+	role, err := s.repo.MemberRole(ctx, n.ProjectID, n.StudentID)
+	if err != nil {
+		return nil, err
+	}
+	if role != project.RoleOwner && role != project.RoleContributor {
+		return nil, ErrNotSubmitter
+	}
+
+	count, err := s.repo.CountAttachments(ctx, n.ProjectID, n.AttachmentIDs)
+	if err != nil {
+		return nil, err
+	}
+	if count != len(n.AttachmentIDs) {
+		return nil, fmt.Errorf("%w: attachments must belong to the project", ErrInvalidInput)
+	}
+
+	submission := &Submission{
+		ProjectID:     n.ProjectID,
+		StudentID:     n.StudentID,
+		Text:          n.Text,
+		AttachmentIDs: n.AttachmentIDs,
+		Status:        StatusSubmitted,
+	}
+	if err := s.repo.Create(ctx, submission); err != nil {
+		return nil, err
+	}
+	return submission, nil
+}
+
+func (s *service) Get(ctx context.Context, id, viewerID int) (*Submission, error) {
+	if viewerID <= 0 {
+		return nil, fmt.Errorf("%w: viewer is required", ErrInvalidInput)
+	}
+	submission, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if submission.StudentID == viewerID {
+		return submission, nil
+	}
+	role, err := s.repo.MemberRole(ctx, submission.ProjectID, viewerID)
+	if err != nil {
+		return nil, err
+	}
+	if role != project.RoleInstructor {
+		return nil, ErrNotViewer
+	}
+	return submission, nil
+}
+
+func (s *service) List(ctx context.Context, filter Filter, viewerID int) ([]*Submission, error) {
+	if filter.ProjectID < 0 || filter.StudentID < 0 || (filter.ProjectID == 0 && filter.StudentID == 0) {
+		return nil, fmt.Errorf("%w: a project or student is required", ErrInvalidInput)
+	}
+	if viewerID <= 0 {
+		return nil, fmt.Errorf("%w: viewer is required", ErrInvalidInput)
+	}
+
+	if filter.StudentID != viewerID {
+		instructor := false
+		if filter.ProjectID > 0 {
+			role, err := s.repo.MemberRole(ctx, filter.ProjectID, viewerID)
+			if err != nil {
+				return nil, err
+			}
+			instructor = role == project.RoleInstructor
+		}
+		if !instructor {
+			if filter.StudentID != 0 {
+				return nil, ErrNotViewer
+			}
+			filter.StudentID = viewerID
+		}
+	}
+	return s.repo.List(ctx, filter)
+}
+
+func (s *service) Grade(ctx context.Context, id int, a Assessment) (*Submission, error) {
+	if err := a.Validate(); err != nil {
+		return nil, err
+	}
+	submission, err := s.authorizeGrader(ctx, id, a.GraderID)
+	if err != nil {
+		return nil, err
+	}
+	if submission.Status == StatusGraded {
+		return nil, ErrAlreadyGraded
+	}
+
+	grade := &Grade{
+		SubmissionID: id,
+		GraderID:     a.GraderID,
+		Score:        a.Score,
+		MaxScore:     a.MaxScore,
+		Rubric:       a.Rubric,
+		Feedback:     a.Feedback,
+	}
+	if err := s.repo.AddGrade(ctx, grade); err != nil {
+		return nil, err
+	}
+	graded, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	// The grade is committed, so a failed publish only costs the student
+	// their notification
+	if s.publisher != nil {
+		if err := s.publisher.SendMessage(ctx, newGradedEvent(graded)); err != nil {
+			s.logger.ErrorContext(ctx, "failed to publish graded event", "error", err, "submission_id", id)
+		}
+	}
+	return graded, nil
+}
+
+func (s *service) Reopen(ctx context.Context, id, graderID int, reason string) (*Submission, error) {
+	reason = strings.TrimSpace(reason)
+	if graderID <= 0 {
+		return nil, fmt.Errorf("%w: grader is required", ErrInvalidInput)
+	}
+	if len(reason) > MaxFeedbackLength {
+		return nil, fmt.Errorf("%w: reason is longer than %d bytes", ErrInvalidInput, MaxFeedbackLength)
+	}
+	submission, err := s.authorizeGrader(ctx, id, graderID)
+	if err != nil {
+		return nil, err
+	}
+	if submission.Status != StatusGraded {
+		return nil, ErrNotGraded
+	}
+
+	if err := s.repo.Reopen(ctx, id, graderID, reason); err != nil {
+		return nil, err
+	}
+	return s.repo.GetByID(ctx, id)
+}
+
+func (s *service) PurgeOrphans(ctx context.Context) (int, error) {
+	return s.repo.PurgeOrphans(ctx)
+}
+
+// authorizeGrader returns the submission if the grader is an instructor of
+// its project other than its author
+func (s *service) authorizeGrader(ctx context.Context, id, graderID int) (*Submission, error) {
+	submission, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if submission.StudentID == graderID {
+		return nil, ErrOwnSubmission
+	}
+	role, err := s.repo.MemberRole(ctx, submission.ProjectID, graderID)
+	if err != nil {
+		return nil, err
+	}
+	if role != project.RoleInstructor {
+		return nil, ErrNotInstructor
+	}
+	return submission, nil
+}
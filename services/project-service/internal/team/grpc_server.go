@@ -0,0 +1,153 @@
+package team
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+
+	"project-service/internal/project"
+
+	pb "grud/api/gen/team/v1"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type GrpcServer struct {
+	pb.UnimplementedTeamServiceServer
+	service Service
+	logger  *slog.Logger
+}
+
+func NewGrpcServer(service Service, logger *slog.Logger) *GrpcServer {
+	return &GrpcServer{
+		service: service,
+		logger:  logger,
+	}
+}
+
+func (s *GrpcServer) CreateTeam(ctx context.Context, req *pb.CreateTeamRequest) (*pb.CreateTeamResponse, error) {
+	s.logger.InfoContext(ctx, "gRPC: creating team", "project_id", req.ProjectId, "name", req.Name)
+
+	team, err := s.service.Create(ctx, int(req.ProjectId), req.Name)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "gRPC: failed to create team", "error", err, "project_id", req.ProjectId)
+		return nil, toStatusError(err)
+	}
+
+	s.logger.InfoContext(ctx, "gRPC: team created", "id", team.ID, "project_id", team.ProjectID)
+	return &pb.CreateTeamResponse{Team: toProtoTeam(team)}, nil
+}
+
+func (s *GrpcServer) GetTeam(ctx context.Context, req *pb.GetTeamRequest) (*pb.GetTeamResponse, error) {
+	s.logger.InfoContext(ctx, "gRPC: fetching team", "project_id", req.ProjectId, "id", req.Id)
+
+	team, err := s.service.Get(ctx, int(req.ProjectId), int(req.Id))
+	if err != nil {
+		s.logger.ErrorContext(ctx, "gRPC: failed to fetch team", "error", err, "project_id", req.ProjectId, "id", req.Id)
+		return nil, toStatusError(err)
+	}
+	return &pb.GetTeamResponse{Team: toProtoTeam(team)}, nil
+}
+
+func (s *GrpcServer) ListTeams(ctx context.Context, req *pb.ListTeamsRequest) (*pb.ListTeamsResponse, error) {
+	s.logger.InfoContext(ctx, "gRPC: listing teams", "project_id", req.ProjectId)
+
+	teams, err := s.service.List(ctx, int(req.ProjectId))
+	if err != nil {
+		s.logger.ErrorContext(ctx, "gRPC: failed to list teams", "error", err, "project_id", req.ProjectId)
+		return nil, toStatusError(err)
+	}
+
+	pbTeams := make([]*pb.Team, len(teams))
+	for i, team := range teams {
+		pbTeams[i] = toProtoTeam(team)
+	}
+	return &pb.ListTeamsResponse{Teams: pbTeams}, nil
+}
+
+func (s *GrpcServer) RenameTeam(ctx context.Context, req *pb.RenameTeamRequest) (*pb.RenameTeamResponse, error) {
+	s.logger.InfoContext(ctx, "gRPC: renaming team", "project_id", req.ProjectId, "id", req.Id, "name", req.Name)
+
+	team, err := s.service.Rename(ctx, int(req.ProjectId), int(req.Id), req.Name)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "gRPC: failed to rename team", "error", err, "project_id", req.ProjectId, "id", req.Id)
+		return nil, toStatusError(err)
+	}
+	return &pb.RenameTeamResponse{Team: toProtoTeam(team)}, nil
+}
+
+func (s *GrpcServer) DeleteTeam(ctx context.Context, req *pb.DeleteTeamRequest) (*pb.DeleteTeamResponse, error) {
+	s.logger.InfoContext(ctx, "gRPC: deleting team", "project_id", req.ProjectId, "id", req.Id)
+
+	if err := s.service.Delete(ctx, int(req.ProjectId), int(req.Id)); err != nil {
+		s.logger.ErrorContext(ctx, "gRPC: failed to delete team", "error", err, "project_id", req.ProjectId, "id", req.Id)
+		return nil, toStatusError(err)
+	}
+	return &pb.DeleteTeamResponse{}, nil
+}
+
+func (s *GrpcServer) AddTeamMember(ctx context.Context, req *pb.AddTeamMemberRequest) (*pb.AddTeamMemberResponse, error) {
+	s.logger.InfoContext(ctx, "gRPC: adding team member", "project_id", req.ProjectId, "team_id", req.TeamId, "student_id", req.StudentId)
+
+	team, err := s.service.AddMember(ctx, int(req.ProjectId), int(req.TeamId), int(req.StudentId))
+	if err != nil {
+		s.logger.ErrorContext(ctx, "gRPC: failed to add team member", "error", err, "team_id", req.TeamId, "student_id", req.StudentId)
+		return nil, toStatusError(err)
+	}
+	return &pb.AddTeamMemberResponse{Team: toProtoTeam(team)}, nil
+}
+
+func (s *GrpcServer) RemoveTeamMember(ctx context.Context, req *pb.RemoveTeamMemberRequest) (*pb.RemoveTeamMemberResponse, error) {
+	s.logger.InfoContext(ctx, "gRPC: removing team member", "project_id", req.ProjectId, "team_id", req.TeamId, "student_id", req.StudentId)
+
+	team, err := s.service.RemoveMember(ctx, int(req.ProjectId), int(req.TeamId), int(req.StudentId))
+	if err != nil {
+		s.logger.ErrorContext(ctx, "gRPC: failed to remove team member", "error", err, "team_id", req.TeamId, "student_id", req.StudentId)
+		return nil, toStatusError(err)
+	}
+	return &pb.RemoveTeamMemberResponse{Team: toProtoTeam(team)}, nil
+}
+
+func (s *GrpcServer) SetTeamLead(ctx context.Context, req *pb.SetTeamLeadRequest) (*pb.SetTeamLeadResponse, error) {
+	s.logger.InfoContext(ctx, "gRPC: setting team lead", "project_id", req.ProjectId, "team_id", req.TeamId, "student_id", req.StudentId)
+
+	team, err := s.service.SetLead(ctx, int(req.ProjectId), int(req.TeamId), int(req.StudentId))
+	if err != nil {
+		s.logger.ErrorContext(ctx, "gRPC: failed to set team lead", "error", err, "team_id", req.TeamId, "student_id", req.StudentId)
+		return nil, toStatusError(err)
+	}
+	return &pb.SetTeamLeadResponse{Team: toProtoTeam(team)}, nil
+}
+
+func toProtoTeam(t *Team) *pb.Team {
+	memberIDs := make([]int32, len(t.MemberIDs))
+	for i, id := range t.MemberIDs {
+		memberIDs[i] = int32(id)
+	}
+	return &pb.Team{
+		Id:        int32(t.ID),
+		ProjectId: int32(t.ProjectID),
+		Name:      t.Name,
+		LeadId:    int32(t.LeadID),
+		MemberIds: memberIDs,
+		CreatedAt: timestamppb.New(t.CreatedAt),
+	}
+}
+
+// toStatusError maps domain errors to gRPC status errors
+func toStatusError(err error) error {
+	switch {
+	case errors.Is(err, ErrTeamNotFound), errors.Is(err, project.ErrProjectNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, ErrInvalidInput):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, ErrTeamExists):
+		return status.Error(codes.AlreadyExists, err.Error())
+	case errors.Is(err, ErrNotProjectMember), errors.Is(err, ErrNotTeamMember):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	default:
+		return status.Error(codes.Internal, err.Error())
+	}
+}
@@ -0,0 +1,239 @@
+package team_test
+
+import (
+	"context"
+	"log/slog"
+	"net"
+	"os"
+	"testing"
+	"time"
+
+	pb "grud/api/gen/team/v1"
+	commonmetrics "grud/common/metrics"
+	"grud/testing/testdb"
+	"project-service/internal/db"
+	"project-service/internal/project"
+	"project-service/internal/team"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+func TestTeamGrpcServer_Shared(t *testing.T) {
+	pgContainer := testdb.SetupSharedPostgres(t)
+	defer pgContainer.Cleanup(t)
+
+	err := db.RunMigrations(context.Background(), pgContainer.DB,
+		(*project.Project)(nil), (*project.ProjectMember)(nil), (*team.Team)(nil))
+	require.NoError(t, err)
+
+	repo := team.NewRepository(pgContainer.DB, commonmetrics.NewMock())
+	service := team.NewService(repo)
+	logger := slog.New(slog.NewTextHandler(os.Stderr, nil))
+
+	lis := bufconn.Listen(1 << 20)
+	server := grpc.NewServer()
+	pb.RegisterTeamServiceServer(server, team.NewGrpcServer(service, logger))
+	go server.Serve(lis)
+	defer server.Stop()
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+	defer conn.Close()
+	client := pb.NewTeamServiceClient(conn)
+
+	const (
+		aliceID = 10
+		bobID   = 11
+		carolID = 12
+	)
+
+	// newProject creates a project with three members, joined in ID order
+	newProject := func(t *testing.T) *project.Project {
+		ctx := context.Background()
+		p := &project.Project{Name: "Coursework", Status: project.StatusActive}
+		_, err := pgContainer.DB.NewInsert().Model(p).Exec(ctx)
+		require.NoError(t, err)
+		joined := time.Now().Add(-time.Hour)
+		members := []*project.ProjectMember{
+			{ProjectID: p.ID, StudentID: aliceID, Role: project.RoleOwner, CreatedAt: joined},
+			{ProjectID: p.ID, StudentID: bobID, Role: project.RoleContributor, CreatedAt: joined.Add(time.Minute)},
+			{ProjectID: p.ID, StudentID: carolID, Role: project.RoleContributor, CreatedAt: joined.Add(2 * time.Minute)},
+		}
+		_, err = pgContainer.DB.NewInsert().Model(&members).Exec(ctx)
+		require.NoError(t, err)
+		return p
+	}
+
+	createTeam := func(t *testing.T, projectID int, name string) *pb.Team {
+		resp, err := client.CreateTeam(context.Background(), &pb.CreateTeamRequest{ProjectId: int32(projectID), Name: name})
+		require.NoError(t, err)
+		return resp.Team
+	}
+
+	addMember := func(ctx context.Context, projectID int, teamID int32, studentID int32) (*pb.Team, error) {
+		resp, err := client.AddTeamMember(ctx, &pb.AddTeamMemberRequest{ProjectId: int32(projectID), TeamId: teamID, StudentId: studentID})
+		if err != nil {
+			return nil, err
+		}
+		return resp.Team, nil
+	}
+
+	t.Run("CreateListRename", func(t *testing.T) {
+		testdb.CleanupTables(t, pgContainer.DB, "projects", "project_members", "teams")
+		p := newProject(t)
+		ctx := context.Background()
+
+		red := createTeam(t, p.ID, "  Red   team ")
+		assert.Equal(t, "Red team", red.Name)
+		assert.Equal(t, int32(p.ID), red.ProjectId)
+		assert.Empty(t, red.MemberIds)
+		createTeam(t, p.ID, "Blue team")
+
+		_, err := client.CreateTeam(ctx, &pb.CreateTeamRequest{ProjectId: int32(p.ID), Name: "Red team"})
+		assert.Equal(t, codes.AlreadyExists, status.Code(err))
+		_, err = client.CreateTeam(ctx, &pb.CreateTeamRequest{ProjectId: int32(p.ID), Name: "  "})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+		_, err = client.CreateTeam(ctx, &pb.CreateTeamRequest{ProjectId: 999999, Name: "Green team"})
+		assert.Equal(t, codes.NotFound, status.Code(err))
+
+		list, err := client.ListTeams(ctx, &pb.ListTeamsRequest{ProjectId: int32(p.ID)})
+		require.NoError(t, err)
+		require.Len(t, list.Teams, 2)
+		assert.Equal(t, "Blue team", list.Teams[0].Name)
+		assert.Equal(t, "Red team", list.Teams[1].Name)
+
+		renamed, err := client.RenameTeam(ctx, &pb.RenameTeamRequest{ProjectId: int32(p.ID), Id: red.Id, Name: "Green team"})
+		require.NoError(t, err)
+		assert.Equal(t, "Green team", renamed.Team.Name)
+
+		_, err = client.RenameTeam(ctx, &pb.RenameTeamRequest{ProjectId: int32(p.ID), Id: red.Id, Name: "Blue team"})
+		assert.Equal(t, codes.AlreadyExists, status.Code(err))
+	})
+
+	t.Run("TeamOfAnotherProject", func(t *testing.T) {
+		testdb.CleanupTables(t, pgContainer.DB, "projects", "project_members", "teams")
+		p := newProject(t)
+		other := newProject(t)
+		ctx := context.Background()
+
+		red := createTeam(t, p.ID, "Red team")
+
+		_, err := client.GetTeam(ctx, &pb.GetTeamRequest{ProjectId: int32(other.ID), Id: red.Id})
+		assert.Equal(t, codes.NotFound, status.Code(err))
+		_, err = client.DeleteTeam(ctx, &pb.DeleteTeamRequest{ProjectId: int32(other.ID), Id: red.Id})
+		assert.Equal(t, codes.NotFound, status.Code(err))
+		_, err = addMember(ctx, other.ID, red.Id, aliceID)
+		assert.Equal(t, codes.NotFound, status.Code(err))
+	})
+
+	t.Run("MembersAndLead", func(t *testing.T) {
+		testdb.CleanupTables(t, pgContainer.DB, "projects", "project_members", "teams")
+		p := newProject(t)
+		ctx := context.Background()
+
+		red := createTeam(t, p.ID, "Red team")
+		blue := createTeam(t, p.ID, "Blue team")
+
+		_, err := addMember(ctx, p.ID, red.Id, carolID)
+		require.NoError(t, err)
+		got, err := addMember(ctx, p.ID, red.Id, aliceID)
+		require.NoError(t, err)
+		assert.Equal(t, []int32{aliceID, carolID}, got.MemberIds)
+
+		_, err = addMember(ctx, p.ID, red.Id, 999)
+		assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+
+		_, err = client.SetTeamLead(ctx, &pb.SetTeamLeadRequest{ProjectId: int32(p.ID), TeamId: red.Id, StudentId: bobID})
+		assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+
+		lead, err := client.SetTeamLead(ctx, &pb.SetTeamLeadRequest{ProjectId: int32(p.ID), TeamId: red.Id, StudentId: aliceID})
+		require.NoError(t, err)
+		assert.Equal(t, int32(aliceID), lead.Team.LeadId)
+
+		lead, err = client.SetTeamLead(ctx, &pb.SetTeamLeadRequest{ProjectId: int32(p.ID), TeamId: red.Id, StudentId: carolID})
+		require.NoError(t, err)
+		assert.Equal(t, int32(carolID), lead.Team.LeadId)
+
+		// Re-adding the lead keeps the lead; moving them to another team does not
+		got, err = addMember(ctx, p.ID, red.Id, carolID)
+		require.NoError(t, err)
+		assert.Equal(t, int32(carolID), got.LeadId)
+
+		got, err = addMember(ctx, p.ID, blue.Id, carolID)
+		require.NoError(t, err)
+		assert.Equal(t, []int32{carolID}, got.MemberIds)
+		assert.Zero(t, got.LeadId)
+
+		redNow, err := client.GetTeam(ctx, &pb.GetTeamRequest{ProjectId: int32(p.ID), Id: red.Id})
+		require.NoError(t, err)
+		assert.Equal(t, []int32{aliceID}, redNow.Team.MemberIds)
+		assert.Zero(t, redNow.Team.LeadId)
+
+		removed, err := client.RemoveTeamMember(ctx, &pb.RemoveTeamMemberRequest{ProjectId: int32(p.ID), TeamId: red.Id, StudentId: aliceID})
+		require.NoError(t, err)
+		assert.Empty(t, removed.Team.MemberIds)
+
+		_, err = client.RemoveTeamMember(ctx, &pb.RemoveTeamMemberRequest{ProjectId: int32(p.ID), TeamId: red.Id, StudentId: aliceID})
+		assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+
+		cleared, err := client.SetTeamLead(ctx, &pb.SetTeamLeadRequest{ProjectId: int32(p.ID), TeamId: blue.Id})
+		require.NoError(t, err)
+		assert.Zero(t, cleared.Team.LeadId)
+	})
+
+	t.Run("DeleteKeepsMembers", func(t *testing.T) {
+		testdb.CleanupTables(t, pgContainer.DB, "projects", "project_members", "teams")
+		p := newProject(t)
+		ctx := context.Background()
+
+		red := createTeam(t, p.ID, "Red team")
+		_, err := addMember(ctx, p.ID, red.Id, bobID)
+		require.NoError(t, err)
+		_, err = client.SetTeamLead(ctx, &pb.SetTeamLeadRequest{ProjectId: int32(p.ID), TeamId: red.Id, StudentId: bobID})
+		require.NoError(t, err)
+
+		_, err = client.DeleteTeam(ctx, &pb.DeleteTeamRequest{ProjectId: int32(p.ID), Id: red.Id})
+		require.NoError(t, err)
+
+		_, err = client.GetTeam(ctx, &pb.GetTeamRequest{ProjectId: int32(p.ID), Id: red.Id})
+		assert.Equal(t, codes.NotFound, status.Code(err))
+
+		member := new(project.ProjectMember)
+		err = pgContainer.DB.NewSelect().Model(member).
+			Where("project_id = ? AND student_id = ?", p.ID, bobID).
+			Scan(ctx)
+		require.NoError(t, err)
+		assert.Nil(t, member.TeamID)
+		assert.False(t, member.TeamLead)
+	})
+
+	t.Run("PurgeOrphans", func(t *testing.T) {
+		testdb.CleanupTables(t, pgContainer.DB, "projects", "project_members", "teams")
+		p := newProject(t)
+		kept := newProject(t)
+		ctx := context.Background()
+
+		createTeam(t, p.ID, "Red team")
+		createTeam(t, kept.ID, "Red team")
+
+		_, err := pgContainer.DB.NewDelete().Model((*project.Project)(nil)).Where("id = ?", p.ID).ForceDelete().Exec(ctx)
+		require.NoError(t, err)
+
+		purged, err := service.PurgeOrphans(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, 1, purged)
+
+		list, err := client.ListTeams(ctx, &pb.ListTeamsRequest{ProjectId: int32(kept.ID)})
+		require.NoError(t, err)
+		assert.Len(t, list.Teams, 1)
+	})
+}
@@ -0,0 +1,39 @@
+package team
+
+import (
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/uptrace/bun"
+)
+
+// MaxNameLength is the longest accepted team name, in characters
+const MaxNameLength = 100
+
+// Team is a named group of members within a project. Membership and the lead
+// are stored on the project_members rows, so that a member belongs to at most
+// one team of the project.
+type Team struct {
+	bun.BaseModel `bun:"table:teams,alias:tm"`
+
+	ID        int       `bun:"id,pk,autoincrement" json:"id"`
+	ProjectID int       `bun:"project_id,notnull,unique:teams_project_name" json:"projectId"`
+	Name      string    `bun:"name,notnull,unique:teams_project_name" json:"name"`
+	CreatedAt time.Time `bun:"created_at,notnull,default:current_timestamp" json:"createdAt"`
+
+	// LeadID and MemberIDs are filled by the repository from project_members;
+	// members are in the order they joined the project
+	LeadID    int   `bun:"-" json:"leadId,omitempty"`
+	MemberIDs []int `bun:"-" json:"memberIds"`
+}
+
+// NormalizeName trims a team name and collapses inner whitespace
+func NormalizeName(name string) (string, error) {
+	name = strings.Join(strings.Fields(name), " ")
+	if name == "" || utf8.RuneCountInString(name) > MaxNameLength {
+		return "", fmt.Errorf("%w: team name must be 1 to %d characters", ErrInvalidInput, MaxNameLength)
+	}
+	return name, nil
+}
@@ -0,0 +1,26 @@
+package team_test
+
+import (
+	"strings"
+	"testing"
+
+	"project-service/internal/team"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNormalizeName(t *testing.T) {
+	name, err := team.NormalizeName("  Red \t  Team ")
+	require.NoError(t, err)
+	assert.Equal(t, "Red Team", name)
+
+	name, err = team.NormalizeName(strings.Repeat("é", team.MaxNameLength))
+	require.NoError(t, err)
+	assert.Equal(t, team.MaxNameLength, len([]rune(name)))
+
+	for _, invalid := range []string{"", "   ", strings.Repeat("a", team.MaxNameLength+1)} {
+		_, err := team.NormalizeName(invalid)
+		assert.ErrorIs(t, err, team.ErrInvalidInput)
+	}
+}
@@ -0,0 +1,337 @@
+package team
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"grud/common/metrics"
+	"project-service/internal/project"
+
+	"github.com/uptrace/bun"
+	"github.com/uptrace/bun/driver/pgdriver"
+)
+
+type Repository interface {
+	// Create inserts the team, returning ErrTeamExists if the project has a
+	// team of that name
+	Create(ctx context.Context, team *Team) error
+	// Get returns the project's team with its members
+	Get(ctx context.Context, projectID, id int) (*Team, error)
+	// List returns the project's teams with their members, sorted by name
+	List(ctx context.Context, projectID int) ([]*Team, error)
+	// Rename renames the project's team, returning ErrTeamExists if the
+	// project has another team of that name
+	Rename(ctx context.Context, projectID, id int, name string) error
+	// Delete deletes the project's team after taking its members out of it
+	Delete(ctx context.Context, projectID, id int) error
+	// AddMember moves a project member into the team, clearing their lead of
+	// any other team. It returns ErrNotProjectMember if the student is not a
+	// member of the project.
+	AddMember(ctx context.Context, projectID, teamID, studentID int) error
+	// RemoveMember takes a member out of the team, returning ErrNotTeamMember
+	// if the student is not in it
+	RemoveMember(ctx context.Context, projectID, teamID, studentID int) error
+	// SetLead makes the team member its lead, or leaves the team without one
+	// if studentID is 0. It returns ErrNotTeamMember if the student is not in
+	// the team.
+	SetLead(ctx context.Context, projectID, teamID, studentID int) error
+	// ProjectExists reports whether the project exists and is not deleted
+	ProjectExists(ctx context.Context, projectID int) (bool, error)
+	// PurgeOrphans deletes the teams of purged projects and returns how many
+	// were deleted
+	PurgeOrphans(ctx context.Context) (int, error)
+}
+
+type repository struct {
+	db      *bun.DB
+	metrics *metrics.Metrics
+}
+
+func NewRepository(db *bun.DB, m *metrics.Metrics) Repository {
+	return &repository{
+		db:      db,
+		metrics: m,
+	}
+}
+
+func (r *repository) Create(ctx context.Context, team *Team) error {
+	start := time.Now()
+	_, err := r.db.NewInsert().Model(team).Returning("*").Exec(ctx)
+	r.metrics.Database.RecordQuery(ctx, "insert", "teams", time.Since(start), err)
+
+	if isUniqueViolation(err) {
+		return ErrTeamExists
+	}
+	if err != nil {
+		return err
+	}
+	team.MemberIDs = []int{}
+	return nil
+}
+
+func (r *repository) Get(ctx context.Context, projectID, id int) (*Team, error) {
+	start := time.Now()
+	team := new(Team)
+	err := r.db.NewSelect().
+		Model(team).
+		Where("tm.project_id = ? AND tm.id = ?", projectID, id).
+		Scan(ctx)
+	r.metrics.Database.RecordQuery(ctx, "select", "teams", time.Since(start), err)
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrTeamNotFound
+		}
+		return nil, err
+	}
+	return team, r.attachMembers(ctx, team)
+}
+
+func (r *repository) List(ctx context.Context, projectID int) ([]*Team, error) {
+	start := time.Now()
+	teams := []*Team{}
+	err := r.db.NewSelect().
+		Model(&teams).
+		Where("tm.project_id = ?", projectID).
+		Order("tm.name", "tm.id").
+		Scan(ctx)
+	r.metrics.Database.RecordQuery(ctx, "select", "teams", time.Since(start), err)
+
+	if err != nil {
+		return nil, err
+	}
+	return teams, r.attachMembers(ctx, teams...)
+}
+
+func (r *repository) Rename(ctx context.Context, projectID, id int, name string) error {
+	start := time.Now()
+	result, err := r.db.NewUpdate().
+		Model((*Team)(nil)).
+		Set("name = ?", name).
+		Where("project_id = ? AND id = ?", projectID, id).
+		Exec(ctx)
+	r.metrics.Database.RecordQuery(ctx, "update", "teams", time.Since(start), err)
+
+	if isUniqueViolation(err) {
+		return ErrTeamExists
+	}
+	if err != nil {
+		return err
+	}
+	if rows, err := result.RowsAffected(); err == nil && rows == 0 {
+		return ErrTeamNotFound
+	}
+	return nil
+}
+
+func (r *repository) Delete(ctx context.Context, projectID, id int) error {
+	return r.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		if err := r.lockTeam(ctx, tx, projectID, id); err != nil {
+			return err
+		}
+
+		start := time.Now()
+		_, err := tx.NewUpdate().
+			Model((*project.ProjectMember)(nil)).
+			Set("team_id = NULL").
+			Set("team_lead = false").
+			Where("team_id = ?", id).
+			Exec(ctx)
+		r.metrics.Database.RecordQuery(ctx, "update", "project_members", time.Since(start), err)
+
+		if err != nil {
+			return err
+		}
+
+		start = time.Now()
+		_, err = tx.NewDelete().
+			Model((*Team)(nil)).
+			Where("id = ?", id).
+			Exec(ctx)
+		r.metrics.Database.RecordQuery(ctx, "delete", "teams", time.Since(start), err)
+
+		return err
+	})
+}
+
+func (r *repository) AddMember(ctx context.Context, projectID, teamID, studentID int) error {
+	return r.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		if err := r.lockTeam(ctx, tx, projectID, teamID); err != nil {
+			return err
+		}
+
+		// SET expressions see the old row, so a member who already leads this
+		// team keeps the lead while one moving from another team loses it
+		start := time.Now()
+		result, err := tx.NewUpdate().
+			Model((*project.ProjectMember)(nil)).
+			Set("team_lead = team_lead AND team_id IS NOT DISTINCT FROM ?", teamID).
+			Set("team_id = ?", teamID).
+			Where("project_id = ? AND student_id = ?", projectID, studentID).
+			Exec(ctx)
+		r.metrics.Database.RecordQuery(ctx, "update", "project_members", time.Since(start), err)
+
+		if err != nil {
+			return err
+		}
+		if rows, err := result.RowsAffected(); err == nil && rows == 0 {
+			return ErrNotProjectMember
+		}
+		return nil
+	})
+}
+
+func (r *repository) RemoveMember(ctx context.Context, projectID, teamID, studentID int) error {
+	return r.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		if err := r.lockTeam(ctx, tx, projectID, teamID); err != nil {
+			return err
+		}
+
+		start := time.Now()
+		result, err := tx.NewUpdate().
+			Model((*project.ProjectMember)(nil)).
+			Set("team_id = NULL").
+			Set("team_lead = false").
+			Where("project_id = ? AND student_id = ? AND team_id = ?", projectID, studentID, teamID).
+			Exec(ctx)
+		r.metrics.Database.RecordQuery(ctx, "update", "project_members", time.Since(start), err)
+
+		if err != nil {
+			return err
+		}
+		if rows, err := result.RowsAffected(); err == nil && rows == 0 {
+			return ErrNotTeamMember
+		}
+		return nil
+	})
+}
+
+func (r *repository) SetLead(ctx context.Context, projectID, teamID, studentID int) error {
+	return r.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		// Locking the team serializes lead changes, which the unique index on
+		// team leads would otherwise turn into constraint violations
+		if err := r.lockTeam(ctx, tx, projectID, teamID); err != nil {
+			return err
+		}
+
+		start := time.Now()
+		_, err := tx.NewUpdate().
+			Model((*project.ProjectMember)(nil)).
+			Set("team_lead = false").
+			Where("team_id = ? AND team_lead", teamID).
+			Exec(ctx)
+		r.metrics.Database.RecordQuery(ctx, "update", "project_members", time.Since(start), err)
+
+		if err != nil || studentID == 0 {
+			return err
+		}
+
+		start = time.Now()
+		result, err := tx.NewUpdate().
+			Model((*project.ProjectMember)(nil)).
+			Set("team_lead = true").
+			Where("team_id = ? AND student_id = ?", teamID, studentID).
+			Exec(ctx)
+		r.metrics.Database.RecordQuery(ctx, "update", "project_members", time.Since(start), err)
+
+		if err != nil {
+			return err
+		}
+		if rows, err := result.RowsAffected(); err == nil && rows == 0 {
+			return ErrNotTeamMember
+		}
+		return nil
+	})
+}
+
+func (r *repository) ProjectExists(ctx context.Context, projectID int) (bool, error) {
+	start := time.Now()
+	exists, err := r.db.NewSelect().
+		Model((*project.Project)(nil)).
+		Where("id = ?", projectID).
+		Exists(ctx)
+	r.metrics.Database.RecordQuery(ctx, "select", "projects", time.Since(start), err)
+
+	return exists, err
+}
+
+func (r *repository) PurgeOrphans(ctx context.Context) (int, error) {
+	start := time.Now()
+	result, err := r.db.NewDelete().
+		Model((*Team)(nil)).
+		Where("NOT EXISTS (?)", r.db.NewSelect().
+			Model((*project.Project)(nil)).
+			ColumnExpr("1").
+			Where("p.id = tm.project_id").
+			WhereAllWithDeleted()).
+		Exec(ctx)
+	r.metrics.Database.RecordQuery(ctx, "purge", "teams", time.Since(start), err)
+
+	if err != nil {
+		return 0, err
+	}
+	rows, err := result.RowsAffected()
+	return int(rows), err
+}
+
+// lockTeam locks the project's team for the rest of the transaction
+func (r *repository) lockTeam(ctx context.Context, tx bun.Tx, projectID, id int) error {
+	start := time.Now()
+	var lockedID int
+	err := tx.NewSelect().
+		Model((*Team)(nil)).
+		Column("id").
+		Where("project_id = ? AND id = ?", projectID, id).
+		For("UPDATE").
+		Scan(ctx, &lockedID)
+	r.metrics.Database.RecordQuery(ctx, "select", "teams", time.Since(start), err)
+
+	if err == sql.ErrNoRows {
+		return ErrTeamNotFound
+	}
+	return err
+}
+
+// attachMembers fills in the members and lead of each team
+func (r *repository) attachMembers(ctx context.Context, teams ...*Team) error {
+	if len(teams) == 0 {
+		return nil
+	}
+	byID := make(map[int]*Team, len(teams))
+	ids := make([]int, len(teams))
+	for i, team := range teams {
+		team.MemberIDs = []int{}
+		byID[team.ID] = team
+		ids[i] = team.ID
+	}
+
+	start := time.Now()
+	var members []project.ProjectMember
+	err := r.db.NewSelect().
+		Model(&members).
+		Column("student_id", "team_id", "team_lead").
+		Where("team_id IN (?)", bun.In(ids)).
+		Order("created_at", "student_id").
+		Scan(ctx)
+	r.metrics.Database.RecordQuery(ctx, "select", "project_members", time.Since(start), err)
+
+	if err != nil {
+		return err
+	}
+	for _, member := range members {
+		team := byID[*member.TeamID]
+		team.MemberIDs = append(team.MemberIDs, member.StudentID)
+		if member.TeamLead {
+			team.LeadID = member.StudentID
+		}
+	}
+	return nil
+}
+
+// isUniqueViolation reports whether err is a PostgreSQL unique_violation (23505)
+func isUniqueViolation(err error) bool {
+	var pgErr pgdriver.Error
+	return errors.As(err, &pgErr) && pgErr.Field('C') == "23505"
+}
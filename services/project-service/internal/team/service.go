@@ -0,0 +1,152 @@
+package team
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"project-service/internal/project"
+)
+
+var (
+	ErrTeamNotFound     = errors.New("team not found")
+	ErrTeamExists       = errors.New("project already has a team of that name")
+	ErrInvalidInput     = errors.New("invalid input")
+	ErrNotProjectMember = errors.New("student is not a member of the project")
+	ErrNotTeamMember    = errors.New("student is not a member of the team")
+)
+
+type Service interface {
+	Create(ctx context.Context, projectID int, name string) (*Team, error)
+	Get(ctx context.Context, projectID, id int) (*Team, error)
+	// List returns the project's teams sorted by name
+	List(ctx context.Context, projectID int) ([]*Team, error)
+	Rename(ctx context.Context, projectID, id int, name string) (*Team, error)
+	// Delete deletes the team; its members stay in the project without a team
+	Delete(ctx context.Context, projectID, id int) error
+	// AddMember assigns a project member to the team, moving them out of any
+	// other team of the project
+	AddMember(ctx context.Context, projectID, teamID, studentID int) (*Team, error)
+	RemoveMember(ctx context.Context, projectID, teamID, studentID int) (*Team, error)
+	// SetLead makes a member of the team its lead; studentID 0 leaves the
+	// team without one
+	SetLead(ctx context.Context, projectID, teamID, studentID int) (*Team, error)
+	// PurgeOrphans removes the teams of purged projects and returns how many
+	// were removed
+	PurgeOrphans(ctx context.Context) (int, error)
+}
+
+type service struct {
+	repo Repository
+}
+
+func NewService(repo Repository) Service {
+	return &service{repo: repo}
+}
+
+func (s *service) Create(ctx context.Context, projectID int, name string) (*Team, error) {
+	name, err := NormalizeName(name)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.checkProject(ctx, projectID); err != nil {
+		return nil, err
+	}
+
+	team := &Team{ProjectID: projectID, Name: name}
+	if err := s.repo.Create(ctx, team); err != nil {
+		return nil, err
+	}
+	return team, nil
+}
+
+func (s *service) Get(ctx context.Context, projectID, id int) (*Team, error) {
+	if err := s.checkProject(ctx, projectID); err != nil {
+		return nil, err
+	}
+	return s.repo.Get(ctx, projectID, id)
+}
+
+func (s *service) List(ctx context.Context, projectID int) ([]*Team, error) {
+	if err := s.checkProject(ctx, projectID); err != nil {
+		return nil, err
+	}
+	return s.repo.List(ctx, projectID)
+}
+
+func (s *service) Rename(ctx context.Context, projectID, id int, name string) (*Team, error) {
+	name, err := NormalizeName(name)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.checkProject(ctx, projectID); err != nil {
+		return nil, err
+	}
+	if err := s.repo.Rename(ctx, projectID, id, name); err != nil {
+		return nil, err
+	}
+	return s.repo.Get(ctx, projectID, id)
+}
+
+func (s *service) Delete(ctx context.Context, projectID, id int) error {
+	if err := s.checkProject(ctx, projectID); err != nil {
+		return err
+	}
+	return s.repo.Delete(ctx, projectID, id)
+}
+
+func (s *service) AddMember(ctx context.Context, projectID, teamID, studentID int) (*Team, error) {
+	if studentID <= 0 {
+		return nil, fmt.Errorf("%w: student is required", ErrInvalidInput)
+	}
+	if err := s.checkProject(ctx, projectID); err != nil {
+		return nil, err
+	}
+	if err := s.repo.AddMember(ctx, projectID, teamID, studentID); err != nil {
+		return nil, err
+	}
+	return s.repo.Get(ctx, projectID, teamID)
+}
+
+func (s *service) RemoveMember(ctx context.Context, projectID, teamID, studentID int) (*Team, error) {
+	if studentID <= 0 {
+		return nil, fmt.Errorf("%w: student is required", ErrInvalidInput)
+	}
+	if err := s.checkProject(ctx, projectID); err != nil {
+		return nil, err
+	}
+	if err := s.repo.RemoveMember(ctx, projectID, teamID, studentID); err != nil {
+		return nil, err
+	}
+	return s.repo.Get(ctx, projectID, teamID)
+}
+
+func (s *service) SetLead(ctx context.Context, projectID, teamID, studentID int) (*Team, error) {
+	if studentID < 0 {
+		return nil, fmt.Errorf("%w: invalid student ID %d", ErrInvalidInput, studentID)
+	}
+	if err := s.checkProject(ctx, projectID); err != nil {
+		return nil, err
+	}
+	if err := s.repo.SetLead(ctx, projectID, teamID, studentID); err != nil {
+		return nil, err
+	}
+	return s.repo.Get(ctx, projectID, teamID)
+}
+
+func (s *service) PurgeOrphans(ctx context.Context) (int, error) {
+	return s.repo.PurgeOrphans(ctx)
+}
+
+// checkProject returns project.ErrProjectNotFound unless the project exists
+// and is not deleted
+func (s *service) checkProject(ctx context.Context, projectID int) error {
+	exists, err := s.repo.ProjectExists(ctx, projectID)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return project.ErrProjectNotFound
+	}
+	return nil
+}
@@ -14,10 +14,13 @@ import (
 func main() {
 	application := app.New()
 
-	// Start dependency health checks in background
-	healthCtx, healthCancel := context.WithCancel(context.Background())
-	defer healthCancel()
-	go application.StartHealthChecks(healthCtx)
+	// Start dependency health checks, the soft delete purge job and the mail
+	// queue in background
+	jobsCtx, jobsCancel := context.WithCancel(context.Background())
+	defer jobsCancel()
+	go application.StartHealthChecks(jobsCtx)
+	go application.StartPurgeJob(jobsCtx)
+	go application.StartMailQueue(jobsCtx)
 
 	go func() {
 		if err := application.Run(); err != nil {
@@ -12,34 +12,48 @@ import (
 	"student-service/internal/config"
 	"student-service/internal/db"
 	"student-service/internal/health"
+	"student-service/internal/mailer"
 	"student-service/internal/message"
 	"student-service/internal/messaging"
 	localmetrics "student-service/internal/metrics"
 	"student-service/internal/middleware"
+	"student-service/internal/notification"
 	"student-service/internal/projectclient"
+	"student-service/internal/search"
+	"student-service/internal/stream"
 	"student-service/internal/student"
 
+	"grud/common/history"
 	"grud/common/logger"
 	"grud/common/metrics"
 	"grud/common/telemetry"
 
 	"github.com/gin-gonic/gin"
+	"github.com/nats-io/nats.go"
 	"github.com/uptrace/bun"
 	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
 	"go.opentelemetry.io/otel"
 )
 
 type App struct {
-	config         *config.Config
-	router         *gin.Engine
-	server         *http.Server
-	logger         *slog.Logger
-	telemetry      *telemetry.Telemetry
-	metrics        *metrics.Metrics
-	serviceMetrics *localmetrics.Metrics
-	database       *bun.DB
-	natsProducer   *messaging.Producer
-	grpcClient     *projectclient.GrpcClient
+	config              *config.Config
+	router              *gin.Engine
+	server              *http.Server
+	logger              *slog.Logger
+	telemetry           *telemetry.Telemetry
+	metrics             *metrics.Metrics
+	serviceMetrics      *localmetrics.Metrics
+	database            *bun.DB
+	natsProducer        *messaging.Producer
+	streamConn          *nats.Conn
+	streamHub           *stream.Hub
+	streamRouter        *stream.Router
+	notifications       *notification.Consumer
+	mailQueue           *mailer.Queue
+	grpcClient          *projectclient.GrpcClient
+	studentService      student.Service
+	authService         *auth.Service
+	notificationService notification.Service
 }
 
 func New() *App {
@@ -88,7 +102,7 @@ func New() *App {
 
 	database := db.New(cfg.Database)
 	app.database = database
-	if err := db.RunMigrations(ctx, database, (*student.Student)(nil), (*auth.RefreshToken)(nil)); err != nil {
+	if err := db.RunMigrations(ctx, database, (*student.Student)(nil), (*auth.RefreshToken)(nil), (*auth.PasswordSetupToken)(nil), (*history.Entry)(nil), (*notification.Notification)(nil), (*notification.Preference)(nil), (*mailer.QueuedMail)(nil)); err != nil {
 		systemLog.Fatal("failed to run migrations:", err)
 	}
 
@@ -129,12 +143,31 @@ func New() *App {
 	// Auth setup
 	studentRepo := student.NewRepository(database, app.metrics)
 	authRepo := auth.NewRepository(database, app.metrics)
-	authService := auth.NewService(authRepo, studentRepo)
+	setupURL := cfg.PasswordSetup.URL
+	if setupURL == "" {
+		setupURL = "http://localhost:5173/setup-password"
+	}
+	// Without mail only the invitation is logged, with the link itself if
+	// password_setup.log_links is set
+	invites := auth.NewLogSender(log, setupURL, cfg.PasswordSetup.LogLinks)
+	authService := auth.NewService(authRepo, studentRepo, invites)
+	app.authService = authService
+	// With mail, setup links are mailed through the queue, which issues the
+	// token only when it sends the mail
+	var inviter student.Inviter = authService
+	mailTemplates, err := mailer.DefaultTemplates()
+	if err != nil {
+		systemLog.Fatalf("failed to parse mail templates: %v", err)
+	}
+	if app.mailQueue = app.newMailQueue(database); app.mailQueue != nil {
+		inviter = auth.NewMailSender(app.mailQueue, mailTemplates, setupURL, authRepo, studentRepo)
+	}
 	authHandler := auth.NewHandler(authService, log)
 	authHandler.RegisterRoutes(app.router)
 
-	// Student endpoints (auth required)
-	studentService := student.NewService(studentRepo)
+	// Student endpoints (auth required); students created here get a password setup invitation
+	studentService := student.NewService(studentRepo, authRepo, inviter, log)
+	app.studentService = studentService
 	studentHandler := student.NewHandler(studentService, log, app.serviceMetrics)
 
 	// Project client endpoints (auth required)
@@ -149,6 +182,13 @@ func New() *App {
 
 	projectHandler := projectclient.NewHandler(grpcClient, log, app.serviceMetrics)
 
+	// Search covers projects and messages only when project-service is reachable
+	var projectSearcher search.ProjectSearcher
+	if grpcClient != nil {
+		projectSearcher = grpcClient
+	}
+	searchHandler := search.NewHandler(studentService, projectSearcher, log)
+
 	// NATS producer setup
 	natsProducer, err := messaging.NewProducer(cfg.NATS.URL, cfg.NATS.Subject, log)
 	if err != nil {
@@ -164,19 +204,144 @@ func New() *App {
 	apiGroup.Use(auth.AuthMiddleware(log))
 	studentHandler.RegisterRoutes(apiGroup)
 	projectHandler.RegisterRoutes(apiGroup)
+	searchHandler.RegisterRoutes(apiGroup)
 
 	// Message handler (only if NATS is available)
 	if natsProducer != nil {
-		messageService := message.NewService(natsProducer, log)
+		// Posting to a team, conversation or thread needs project-service to
+		// check the sender
+		var directory message.Directory
+		if grpcClient != nil {
+			directory = grpcClient
+		}
+		// Edits and deletes go out on subjects of their own, over the same
+		// connection
+		edits := natsProducer.WithSubject(cfg.NATS.EditedSubject)
+		deletes := natsProducer.WithSubject(cfg.NATS.DeletedSubject)
+		messageService := message.NewService(natsProducer, edits, deletes, directory, log)
 		messageHandler := message.NewHandler(messageService, log, app.serviceMetrics)
 		messageHandler.RegisterRoutes(apiGroup)
 	}
 
+	// Real-time stream (only if NATS is available)
+	streamConn, err := nats.Connect(cfg.NATS.URL)
+	if err != nil {
+		log.Warn("failed to connect stream to NATS", "error", err)
+	} else {
+		app.streamConn = streamConn
+		app.startStream(streamConn, studentRepo)
+		streamHandler := stream.NewHandler(app.streamHub, cfg.Server.CORSOrigins, log)
+		streamHandler.RegisterRoutes(apiGroup)
+	}
+
+	// Notifications are made from the stream's events and pushed through it;
+	// without NATS students only see the ones already stored
+	var pusher notification.Pusher
+	if app.streamConn != nil {
+		pusher = stream.NewPusher(app.streamConn, cfg.Stream.SubjectPrefix)
+	}
+	var emailer notification.Emailer
+	if app.mailQueue != nil {
+		emailer = notification.NewMailer(app.mailQueue, mailTemplates, studentRepo)
+	}
+	app.notificationService = notification.NewService(notification.NewRepository(database, app.metrics), pusher, emailer, log)
+	notificationHandler := notification.NewHandler(app.notificationService, log)
+	notificationHandler.RegisterRoutes(apiGroup)
+	if app.streamConn != nil {
+		app.startNotifications(app.streamConn, app.notificationService, studentRepo)
+	}
+
 	log.Info("application initialized successfully")
 
 	return app
 }
 
+// newMailQueue creates the queue mails are sent through, or returns nil if
+// mail is not configured
+func (a *App) newMailQueue(database *bun.DB) *mailer.Queue {
+	cfg := a.config.Mail
+
+	var sender mailer.Sender
+	var err error
+	switch cfg.Driver {
+	case "":
+		a.logger.Info("mail is not configured, no mail will be sent")
+		return nil
+	case "smtp":
+		sender, err = mailer.NewSMTPSender(mailer.SMTPConfig{
+			Host:            cfg.SMTP.Host,
+			Port:            cfg.SMTP.Port,
+			Username:        cfg.SMTP.Username,
+			Password:        cfg.SMTP.Password,
+			From:            cfg.From,
+			RequireStartTLS: cfg.SMTP.RequireStartTLS,
+			Timeout:         time.Duration(cfg.SMTP.TimeoutSeconds) * time.Second,
+		})
+	case "file":
+		dir := cfg.Dir
+		if dir == "" {
+			dir = "/tmp/grud-mail"
+		}
+		sender, err = mailer.NewFileSender(dir, cfg.From)
+	default:
+		err = fmt.Errorf("unknown mail driver %q", cfg.Driver)
+	}
+	if err != nil {
+		systemLog.Fatalf("failed to initialize mail: %v", err)
+	}
+
+	q := cfg.Queue
+	a.logger.Info("mail initialized successfully", "driver", cfg.Driver)
+	return mailer.NewQueue(mailer.NewRepository(database, a.metrics), sender, mailer.QueueOptions{
+		PollInterval: time.Duration(q.PollIntervalSeconds) * time.Second,
+		MaxAttempts:  q.MaxAttempts,
+		Backoff:      time.Duration(q.BackoffSeconds) * time.Second,
+		MaxBackoff:   time.Duration(q.MaxBackoffSeconds) * time.Second,
+		Retention:    time.Duration(q.RetentionDays) * 24 * time.Hour,
+	}, a.logger, a.metrics)
+}
+
+// startStream routes the events of other services to students and starts the
+// hub their connections subscribe through
+func (a *App) startStream(conn *nats.Conn, students stream.Students) {
+	cfg := a.config.Stream
+	routes := []stream.Route{
+		{Subject: cfg.MessageSubject, Type: "message"},
+		{Subject: cfg.ReadSubject, Type: "read"},
+		{Subject: cfg.ProjectSubject, Type: "project"},
+		{Subject: cfg.ReminderSubject, Type: "reminder"},
+		{Subject: cfg.GradedSubject, Type: "grade"},
+	}
+
+	a.streamRouter = stream.NewRouter(conn, routes, students, cfg.SubjectPrefix, a.logger)
+	if err := a.streamRouter.Start(); err != nil {
+		a.logger.Warn("failed to route stream events", "error", err)
+	}
+
+	a.streamHub = stream.NewHub(conn, stream.Options{
+		SubjectPrefix: cfg.SubjectPrefix,
+		BufferSize:    cfg.BufferSize,
+		ReplaySize:    cfg.ReplaySize,
+		Heartbeat:     time.Duration(cfg.HeartbeatSeconds) * time.Second,
+		ResumeWindow:  time.Duration(cfg.ResumeWindowSeconds) * time.Second,
+	}, a.logger)
+	a.logger.Info("stream initialized successfully")
+}
+
+// startNotifications turns the events of other services into notifications
+func (a *App) startNotifications(conn *nats.Conn, service notification.Service, students notification.Students) {
+	cfg := a.config.Stream
+	a.notifications = notification.NewConsumer(conn, notification.Subjects{
+		Messages:  cfg.MessageSubject,
+		Reminders: cfg.ReminderSubject,
+		Projects:  cfg.ProjectSubject,
+		Grades:    cfg.GradedSubject,
+	}, service, students, a.logger)
+	if err := a.notifications.Start(); err != nil {
+		a.logger.Warn("failed to consume notification events", "error", err)
+	}
+}
+
 func (a *App) Run() error {
 	readTimeout := a.config.Server.ReadTimeout
 	if readTimeout == 0 {
@@ -213,11 +378,29 @@ func (a *App) Run() error {
 func (a *App) Shutdown(ctx context.Context) error {
 	a.logger.Info("shutting down server")
 
+	// Open streams would keep the server from shutting down
+	if a.streamHub != nil {
+		a.streamRouter.Close()
+		a.streamHub.Close()
+	}
+	if a.notifications != nil {
+		a.notifications.Close()
+	}
+
 	// Shutdown HTTP server
 	if err := a.server.Shutdown(ctx); err != nil {
 		return err
 	}
 
+	if a.streamConn != nil {
+		a.streamConn.Close()
+	}
+	if a.natsProducer != nil {
+		if err := a.natsProducer.Close(); err != nil {
+			a.logger.Error("NATS producer close error", "error", err)
+		}
+	}
+
 	// Shutdown OTel meter provider
 	if a.telemetry != nil && a.telemetry.MeterProvider != nil {
 		if err := telemetry.Shutdown(ctx, a.telemetry.MeterProvider, a.logger); err != nil {
@@ -253,6 +436,90 @@ func (a *App) StartHealthChecks(ctx context.Context) {
 	}
 }
 
+// StartPurgeJob periodically hard deletes students that were soft deleted
+// longer ago than the configured retention, password setup tokens that
+// expired unused and old notifications
+func (a *App) StartPurgeJob(ctx context.Context) {
+	retentionDays := a.config.Retention.DeletedDays
+	if retentionDays == 0 {
+		retentionDays = 30
+	}
+
+	notificationDays := a.config.Retention.NotificationDays
+	if notificationDays == 0 {
+		notificationDays = 90
+	}
+
+	intervalMinutes := a.config.Retention.PurgeIntervalMinutes
+	if intervalMinutes == 0 {
+		intervalMinutes = 60
+	}
+
+	retention := time.Duration(retentionDays) * 24 * time.Hour
+	notificationRetention := time.Duration(notificationDays) * 24 * time.Hour
+	ticker := time.NewTicker(time.Duration(intervalMinutes) * time.Minute)
+	defer ticker.Stop()
+
+	a.logger.Info("starting purge job",
+		"retention_days", retentionDays,
+		"notification_days", notificationDays,
+		"interval_minutes", intervalMinutes,
+	)
+
+	a.purgeDeleted(ctx, retention)
+	a.purgeNotifications(ctx, notificationRetention)
+
+	for {
+		select {
+		case <-ticker.C:
+			a.purgeDeleted(ctx, retention)
+			a.purgeNotifications(ctx, notificationRetention)
+		case <-ctx.Done():
+			a.logger.Info("stopping purge job")
+			return
+		}
+	}
+}
+
+// StartMailQueue sends queued mails until ctx is done
+func (a *App) StartMailQueue(ctx context.Context) {
+	if a.mailQueue == nil {
+		return
+	}
+	a.mailQueue.Run(ctx)
+}
+
+func (a *App) purgeDeleted(ctx context.Context, retention time.Duration) {
+	purged, err := a.studentService.PurgeDeleted(ctx, retention)
+	if err != nil {
+		a.logger.ErrorContext(ctx, "failed to purge deleted students", "error", err)
+		return
+	}
+	if purged > 0 {
+		a.logger.InfoContext(ctx, "purged deleted students", "count", purged)
+	}
+
+	expired, err := a.authService.PurgeExpiredSetupTokens(ctx)
+	if err != nil {
+		a.logger.ErrorContext(ctx, "failed to purge expired password setup tokens", "error", err)
+		return
+	}
+	if expired > 0 {
+		a.logger.InfoContext(ctx, "purged expired password setup tokens", "count", expired)
+	}
+}
+
+func (a *App) purgeNotifications(ctx context.Context, retention time.Duration) {
+	purged, err := a.notificationService.Purge(ctx, retention)
+	if err != nil {
+		a.logger.ErrorContext(ctx, "failed to purge old notifications", "error", err)
+		return
+	}
+	if purged > 0 {
+		a.logger.InfoContext(ctx, "purged old notifications", "count", purged)
+	}
+}
+
 func (a *App) checkDependencies(ctx context.Context) {
 	// Check PostgreSQL
 	if a.database != nil {
@@ -28,6 +28,7 @@ func (h *Handler) RegisterRoutes(router gin.IRouter) {
 	router.POST("/auth/login", h.Login)
 	router.POST("/auth/refresh", h.Refresh)
 	router.POST("/auth/logout", h.Logout)
+	router.POST("/auth/password/setup", h.SetupPassword)
 }
 
 func (h *Handler) Register(c *gin.Context) {
@@ -128,6 +129,39 @@ func (h *Handler) Refresh(c *gin.Context) {
 	c.JSON(http.StatusOK, resp)
 }
 
+// SetupPassword lets a student created without a password choose one with the
+// token from their invitation, and logs them in
+func (h *Handler) SetupPassword(c *gin.Context) {
+	var req SetupPasswordRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Error("failed to decode request", "error", err)
+		c.String(http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	if err := h.validator.Struct(req); err != nil {
+		h.logger.Warn("validation failed", "error", err)
+		c.String(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	resp, err := h.service.SetupPassword(c.Request.Context(), req)
+	if err != nil {
+		if errors.Is(err, ErrInvalidSetupToken) {
+			c.String(http.StatusUnauthorized, err.Error())
+			return
+		}
+		h.logger.Error("password setup failed", "error", err)
+		c.String(http.StatusInternalServerError, "internal server error")
+		return
+	}
+
+	// Set access token in cookie
+	SetAuthCookie(c.Writer, resp.AccessToken)
+
+	c.JSON(http.StatusOK, resp)
+}
+
 func (h *Handler) Logout(c *gin.Context) {
 	var req RefreshRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
@@ -9,7 +9,9 @@ import (
 	"net/http/httptest"
 	"os"
 	"testing"
+	"time"
 
+	"grud/common/history"
 	commonmetrics "grud/common/metrics"
 	"grud/testing/testdb"
 	"student-service/internal/auth"
@@ -32,13 +34,14 @@ func TestAuthService_Shared(t *testing.T) {
 	defer pgContainer.Cleanup(t)
 
 	// Run migrations for students and refresh_tokens tables
-	pgContainer.RunMigrations(t, (*student.Student)(nil), (*auth.RefreshToken)(nil))
+	pgContainer.RunMigrations(t, (*student.Student)(nil), (*auth.RefreshToken)(nil), (*auth.PasswordSetupToken)(nil), (*history.Entry)(nil))
 
 	// Create handler ONCE and reuse across all subtests
 	mockMetrics := commonmetrics.NewMock()
 	studentRepo := student.NewRepository(pgContainer.DB, mockMetrics)
 	authRepo := auth.NewRepository(pgContainer.DB, mockMetrics)
-	authService := auth.NewService(authRepo, studentRepo)
+	sender := &recordingSender{}
+	authService := auth.NewService(authRepo, studentRepo, sender)
 	logger := slog.New(slog.NewTextHandler(os.Stderr, nil))
 	authHandler := auth.NewHandler(authService, logger)
 	router := gin.New()
@@ -321,6 +324,107 @@ func TestAuthService_Shared(t *testing.T) {
 		assert.NotEmpty(t, refreshResponse.RefreshToken)
 	})
 
+	t.Run("DeletedStudent_TokensRevoked", func(t *testing.T) {
+		testdb.CleanupTables(t, pgContainer.DB, "students", "refresh_tokens")
+
+		payload := map[string]interface{}{
+			"firstName": "Gone",
+			"lastName":  "Soon",
+			"email":     "gone@example.com",
+			"password":  "password123",
+		}
+		body, _ := json.Marshal(payload)
+
+		req := httptest.NewRequest(http.MethodPost, "/auth/register", bytes.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		require.Equal(t, http.StatusCreated, w.Code)
+
+		var registered auth.AuthResponse
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&registered))
+		var stud student.Student
+		studentJSON, _ := json.Marshal(registered.Student)
+		require.NoError(t, json.Unmarshal(studentJSON, &stud))
+
+		studentService := student.NewService(studentRepo, authRepo, nil, logger)
+		require.NoError(t, studentService.DeleteStudent(context.Background(), stud.ID))
+
+		count, err := pgContainer.DB.NewSelect().Model((*auth.RefreshToken)(nil)).Where("student_id = ?", stud.ID).Count(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, 0, count)
+
+		// Neither the old refresh token nor the password work any more
+		refreshBody, _ := json.Marshal(map[string]interface{}{"refreshToken": registered.RefreshToken})
+		req = httptest.NewRequest(http.MethodPost, "/auth/refresh", bytes.NewReader(refreshBody))
+		req.Header.Set("Content-Type", "application/json")
+		w = httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+
+		loginBody, _ := json.Marshal(map[string]interface{}{"email": "gone@example.com", "password": "password123"})
+		req = httptest.NewRequest(http.MethodPost, "/auth/login", bytes.NewReader(loginBody))
+		req.Header.Set("Content-Type", "application/json")
+		w = httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+
+		// The email stays reserved while the student can still be restored
+		req = httptest.NewRequest(http.MethodPost, "/auth/register", bytes.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		w = httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusConflict, w.Code)
+	})
+
+	t.Run("SetupPassword", func(t *testing.T) {
+		testdb.CleanupTables(t, pgContainer.DB, "students", "refresh_tokens", "password_setup_tokens")
+
+		// Students created by an administrator have no password until they set one
+		studentService := student.NewService(studentRepo, authRepo, authService, logger)
+		created, err := studentService.CreateStudent(context.Background(), &student.Student{
+			FirstName: "New",
+			LastName:  "Student",
+			Email:     "invited@example.com",
+		})
+		require.NoError(t, err)
+		require.Contains(t, sender.tokens, created.Email)
+		token := sender.tokens[created.Email]
+
+		post := func(path string, payload map[string]interface{}) *httptest.ResponseRecorder {
+			body, _ := json.Marshal(payload)
+			req := httptest.NewRequest(http.MethodPost, path, bytes.NewReader(body))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+			return w
+		}
+
+		assert.Equal(t, http.StatusUnauthorized, post("/auth/login", map[string]interface{}{"email": "invited@example.com", "password": "anything"}).Code)
+		assert.Equal(t, http.StatusBadRequest, post("/auth/password/setup", map[string]interface{}{"token": token, "password": "short"}).Code)
+		assert.Equal(t, http.StatusUnauthorized, post("/auth/password/setup", map[string]interface{}{"token": "forged", "password": "password123"}).Code)
+
+		w := post("/auth/password/setup", map[string]interface{}{"token": token, "password": "password123"})
+		require.Equal(t, http.StatusOK, w.Code)
+		var response auth.AuthResponse
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&response))
+		assert.NotEmpty(t, response.AccessToken)
+
+		assert.Equal(t, http.StatusOK, post("/auth/login", map[string]interface{}{"email": "invited@example.com", "password": "password123"}).Code)
+
+		// Tokens work once
+		assert.Equal(t, http.StatusUnauthorized, post("/auth/password/setup", map[string]interface{}{"token": token, "password": "password456"}).Code)
+
+		// Tokens that expired unused are purged
+		_, err = pgContainer.DB.NewInsert().Model(&auth.PasswordSetupToken{
+			StudentID: created.ID, TokenHash: "expired", ExpiresAt: time.Now().Add(-time.Minute),
+		}).Exec(context.Background())
+		require.NoError(t, err)
+		purged, err := authService.PurgeExpiredSetupTokens(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, 1, purged)
+	})
+
 	t.Run("Refresh_InvalidToken", func(t *testing.T) {
 		testdb.CleanupTables(t, pgContainer.DB, "students", "refresh_tokens")
 
@@ -403,3 +507,16 @@ func TestAuthService_Shared(t *testing.T) {
 		assert.Equal(t, http.StatusUnauthorized, refreshW.Code, "refresh token should be invalid after logout")
 	})
 }
+
+// recordingSender captures the password setup tokens sent, by email
+type recordingSender struct {
+	tokens map[string]string
+}
+
+func (s *recordingSender) SendPasswordSetup(ctx context.Context, stud *student.Student, token string, expiresAt time.Time) error {
+	if s.tokens == nil {
+		s.tokens = make(map[string]string)
+	}
+	s.tokens[stud.Email] = token
+	return nil
+}
@@ -6,6 +6,8 @@ import (
 	"net/http"
 	"os"
 
+	"grud/common/history"
+
 	"github.com/gin-gonic/gin"
 )
 
@@ -40,6 +42,7 @@ func AuthMiddleware(logger *slog.Logger) gin.HandlerFunc {
 		// Add claims to context
 		ctx := context.WithValue(c.Request.Context(), StudentIDKey, claims.StudentID)
 		ctx = context.WithValue(ctx, EmailKey, claims.Email)
+		ctx = history.WithActor(ctx, claims.Email)
 		c.Request = c.Request.WithContext(ctx)
 
 		// Call next handler
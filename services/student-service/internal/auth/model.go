@@ -17,6 +17,18 @@ type RefreshToken struct {
 	CreatedAt time.Time `bun:"created_at,notnull,default:current_timestamp"`
 }
 
+// PasswordSetupToken lets a student created without a password, by an
+// administrator or an import, choose one. Only the SHA-256 of the token is stored.
+type PasswordSetupToken struct {
+	bun.BaseModel `bun:"table:password_setup_tokens,alias:pst"`
+
+	ID        int       `bun:"id,pk,autoincrement"`
+	StudentID int       `bun:"student_id,notnull"`
+	TokenHash string    `bun:"token_hash,unique,notnull"`
+	ExpiresAt time.Time `bun:"expires_at,notnull"`
+	CreatedAt time.Time `bun:"created_at,notnull,default:current_timestamp"`
+}
+
 // LoginRequest is the request body for login
 type LoginRequest struct {
 	Email    string `json:"email" validate:"required,email"`
@@ -38,6 +50,12 @@ type RefreshRequest struct {
 	RefreshToken string `json:"refreshToken" validate:"required"`
 }
 
+// SetupPasswordRequest is the request body for choosing the first password
+type SetupPasswordRequest struct {
+	Token    string `json:"token" validate:"required"`
+	Password string `json:"password" validate:"required,min=8"`
+}
+
 // AuthResponse is the response for successful authentication
 type AuthResponse struct {
 	AccessToken  string      `json:"accessToken"`
@@ -2,6 +2,7 @@ package auth
 
 import (
 	"context"
+	"database/sql"
 	"time"
 
 	"grud/common/metrics"
@@ -103,3 +104,68 @@ func (r *Repository) DeleteAllStudentTokens(ctx context.Context, studentID int)
 	}
 	return err
 }
+
+// ReplaceSetupToken stores a password setup token for the student,
+// invalidating any earlier one
+func (r *Repository) ReplaceSetupToken(ctx context.Context, studentID int, tokenHash string, expiresAt time.Time) error {
+	return r.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		start := time.Now()
+		_, err := tx.NewDelete().
+			Model((*PasswordSetupToken)(nil)).
+			Where("student_id = ?", studentID).
+			Exec(ctx)
+		r.metrics.Database.RecordQuery(ctx, "delete", "password_setup_tokens", time.Since(start), err)
+		if err != nil {
+			return err
+		}
+
+		start = time.Now()
+		_, err = tx.NewInsert().Model(&PasswordSetupToken{
+			StudentID: studentID,
+			TokenHash: tokenHash,
+			ExpiresAt: expiresAt,
+		}).Exec(ctx)
+		r.metrics.Database.RecordQuery(ctx, "insert", "password_setup_tokens", time.Since(start), err)
+		return err
+	})
+}
+
+// DeleteExpiredSetupTokens deletes the password setup tokens that expired
+// before the given time
+func (r *Repository) DeleteExpiredSetupTokens(ctx context.Context, before time.Time) (int, error) {
+	start := time.Now()
+	result, err := r.db.NewDelete().
+		Model((*PasswordSetupToken)(nil)).
+		Where("expires_at <= ?", before).
+		Exec(ctx)
+	r.metrics.Database.RecordQuery(ctx, "delete", "password_setup_tokens", time.Since(start), err)
+
+	if err != nil {
+		return 0, err
+	}
+	deleted, err := result.RowsAffected()
+	return int(deleted), err
+}
+
+// ConsumeSetupToken deletes an unexpired password setup token and returns the
+// student it was issued to, so that each token works once
+func (r *Repository) ConsumeSetupToken(ctx context.Context, tokenHash string) (int, error) {
+	start := time.Now()
+	token := new(PasswordSetupToken)
+	_, err := r.db.NewDelete().
+		Model(token).
+		Where("token_hash = ?", tokenHash).
+		Where("expires_at > ?", time.Now()).
+		Returning("student_id").
+		Exec(ctx)
+
+	r.metrics.Database.RecordQuery(ctx, "delete", "password_setup_tokens", time.Since(start), err)
+
+	if err != nil {
+		return 0, err
+	}
+	if token.StudentID == 0 {
+		return 0, sql.ErrNoRows
+	}
+	return token.StudentID, nil
+}
@@ -7,6 +7,8 @@ import (
 
 	"student-service/internal/student"
 
+	"grud/common/history"
+
 	"golang.org/x/crypto/bcrypt"
 )
 
@@ -19,20 +21,28 @@ var (
 type Service struct {
 	authRepo    *Repository
 	studentRepo student.Repository
+	invites     InviteSender
 }
 
-func NewService(authRepo *Repository, studentRepo student.Repository) *Service {
+// NewService creates the auth service. Password setup tokens are delivered
+// through invites, which may be nil when nothing should be sent.
+func NewService(authRepo *Repository, studentRepo student.Repository, invites InviteSender) *Service {
 	return &Service{
 		authRepo:    authRepo,
 		studentRepo: studentRepo,
+		invites:     invites,
 	}
 }
 
 // Register creates a new student account
 func (s *Service) Register(ctx context.Context, req RegisterRequest) (*AuthResponse, error) {
-	// Check if email exists
-	existingStudent, _ := s.studentRepo.GetByEmail(ctx, req.Email)
-	if existingStudent != nil {
+	// Check if email exists. Soft deleted students keep their email reserved
+	// so that they can still be restored.
+	taken, err := s.studentRepo.EmailTaken(ctx, req.Email)
+	if err != nil {
+		return nil, err
+	}
+	if taken {
 		return nil, ErrEmailExists
 	}
 
@@ -42,7 +52,8 @@ func (s *Service) Register(ctx context.Context, req RegisterRequest) (*AuthRespo
 		return nil, err
 	}
 
-	// Create student
+	// Create student; self-registration is attributed to the new student
+	ctx = history.WithActor(ctx, req.Email)
 	newStudent := &student.Student{
 		FirstName: req.FirstName,
 		LastName:  req.LastName,
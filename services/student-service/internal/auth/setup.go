@@ -0,0 +1,185 @@
+package auth
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/url"
+	"strconv"
+	"time"
+
+	"student-service/internal/mailer"
+	"student-service/internal/student"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+// PasswordSetupTTL is how long a password setup link stays valid
+const PasswordSetupTTL = 72 * time.Hour
+
+var ErrInvalidSetupToken = errors.New("invalid or expired password setup token")
+
+// InviteSender delivers a password setup token, valid until expiresAt, to a
+// student
+type InviteSender interface {
+	SendPasswordSetup(ctx context.Context, stud *student.Student, token string, expiresAt time.Time) error
+}
+
+// LogSender logs that a password setup link was issued instead of delivering
+// it, until a real delivery channel is configured. The link itself is a
+// bearer token and is only logged with logLinks, for local development.
+type LogSender struct {
+	logger   *slog.Logger
+	baseURL  string
+	logLinks bool
+}
+
+// NewLogSender creates a LogSender building links on baseURL, to which the
+// token is appended as the token query parameter
+func NewLogSender(logger *slog.Logger, baseURL string, logLinks bool) *LogSender {
+	return &LogSender{logger: logger, baseURL: baseURL, logLinks: logLinks}
+}
+
+func (s *LogSender) SendPasswordSetup(ctx context.Context, stud *student.Student, token string, expiresAt time.Time) error {
+	if s.logLinks {
+		s.logger.InfoContext(ctx, "password setup link issued", "email", stud.Email, "expires_at", expiresAt, "link", setupLink(s.baseURL, token))
+		return nil
+	}
+	s.logger.InfoContext(ctx, "password setup link issued", "email", stud.Email, "expires_at", expiresAt)
+	return nil
+}
+
+// MailSender mails password setup links through the mail queue. Only the
+// student's ID is queued; the token is issued and the link rendered when the
+// mail is sent, so the queue never holds a link. It implements
+// student.Inviter in place of Service.InvitePasswordSetup.
+type MailSender struct {
+	queue       *mailer.Queue
+	templates   *mailer.Templates
+	baseURL     string
+	authRepo    *Repository
+	studentRepo student.Repository
+}
+
+// NewMailSender creates a MailSender building links on baseURL like
+// NewLogSender, and registers it with queue to render the password setup
+// mails
+func NewMailSender(queue *mailer.Queue, templates *mailer.Templates, baseURL string, authRepo *Repository, studentRepo student.Repository) *MailSender {
+	s := &MailSender{queue: queue, templates: templates, baseURL: baseURL, authRepo: authRepo, studentRepo: studentRepo}
+	queue.Register(mailer.TemplatePasswordSetup, s)
+	return s
+}
+
+func (s *MailSender) InvitePasswordSetup(ctx context.Context, stud *student.Student) error {
+	return s.queue.SendLater(ctx, mailer.TemplatePasswordSetup, []string{stud.Email}, strconv.Itoa(stud.ID))
+}
+
+// RenderQueued issues a new setup token for the student with the ID in ref
+// and renders their mail. Students that were deleted or have set a password
+// in the meantime are not mailed.
+func (s *MailSender) RenderQueued(ctx context.Context, ref string) (*mailer.Message, error) {
+	id, err := strconv.Atoi(ref)
+	if err != nil {
+		return nil, mailer.ErrCanceled
+	}
+	stud, err := s.studentRepo.GetByID(ctx, id)
+	if errors.Is(err, student.ErrStudentNotFound) {
+		return nil, mailer.ErrCanceled
+	}
+	if err != nil {
+		return nil, err
+	}
+	if stud.Password != "" {
+		return nil, mailer.ErrCanceled
+	}
+
+	token, _, err := issueSetupToken(ctx, s.authRepo, stud.ID)
+	if err != nil {
+		return nil, err
+	}
+	return s.templates.Render(mailer.TemplatePasswordSetup, []string{stud.Email}, struct {
+		Name      string
+		Link      string
+		ExpiresIn string
+	}{
+		Name:      stud.FirstName,
+		Link:      setupLink(s.baseURL, token),
+		ExpiresIn: fmt.Sprintf("%d days", int(PasswordSetupTTL.Hours()/24)),
+	})
+}
+
+func setupLink(baseURL, token string) string {
+	u, err := url.Parse(baseURL)
+	if err != nil {
+		return baseURL + "?token=" + url.QueryEscape(token)
+	}
+	q := u.Query()
+	q.Set("token", token)
+	u.RawQuery = q.Encode()
+	return u.String()
+}
+
+// InvitePasswordSetup issues a single-use password setup token for the
+// student, replacing any earlier one, and sends it. It implements student.Inviter.
+func (s *Service) InvitePasswordSetup(ctx context.Context, stud *student.Student) error {
+	token, expiresAt, err := issueSetupToken(ctx, s.authRepo, stud.ID)
+	if err != nil {
+		return err
+	}
+	if s.invites == nil {
+		return nil
+	}
+	return s.invites.SendPasswordSetup(ctx, stud, token, expiresAt)
+}
+
+// issueSetupToken creates a password setup token for the student, replacing
+// any earlier one, and returns it with its expiry
+func issueSetupToken(ctx context.Context, authRepo *Repository, studentID int) (string, time.Time, error) {
+	token, err := GenerateRefreshToken()
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	expiresAt := time.Now().Add(PasswordSetupTTL)
+	if err := authRepo.ReplaceSetupToken(ctx, studentID, hashSetupToken(token), expiresAt); err != nil {
+		return "", time.Time{}, err
+	}
+	return token, expiresAt, nil
+}
+
+// PurgeExpiredSetupTokens deletes the password setup tokens that expired
+// unused and returns how many were deleted
+func (s *Service) PurgeExpiredSetupTokens(ctx context.Context) (int, error) {
+	return s.authRepo.DeleteExpiredSetupTokens(ctx, time.Now())
+}
+
+// SetupPassword sets the password of the student the token was issued to and
+// logs them in
+func (s *Service) SetupPassword(ctx context.Context, req SetupPasswordRequest) (*AuthResponse, error) {
+	studentID, err := s.authRepo.ConsumeSetupToken(ctx, hashSetupToken(req.Token))
+	if err != nil {
+		return nil, ErrInvalidSetupToken
+	}
+
+	stud, err := s.studentRepo.GetByID(ctx, studentID)
+	if err != nil {
+		return nil, ErrInvalidSetupToken
+	}
+
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(req.Password), bcrypt.DefaultCost)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.studentRepo.SetPassword(ctx, stud.ID, string(hashedPassword)); err != nil {
+		return nil, err
+	}
+
+	return s.generateTokenPair(ctx, stud)
+}
+
+func hashSetupToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
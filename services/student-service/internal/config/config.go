@@ -13,6 +13,10 @@ type Config struct {
 	Database       DatabaseConfig       `mapstructure:"database"`
 	ProjectService ProjectServiceConfig `mapstructure:"project_service"`
 	NATS           NATSConfig           `mapstructure:"nats"`
+	Retention      RetentionConfig      `mapstructure:"retention"`
+	PasswordSetup  PasswordSetupConfig  `mapstructure:"password_setup"`
+	Stream         StreamConfig         `mapstructure:"stream"`
+	Mail           MailConfig           `mapstructure:"mail"`
 }
 
 type ServerConfig struct {
@@ -43,6 +47,103 @@ type DatabaseConfig struct {
 type NATSConfig struct {
 	URL     string `mapstructure:"url"`
 	Subject string `mapstructure:"subject"`
+	// EditedSubject and DeletedSubject carry the edits and deletes of sent
+	// messages to project-service
+	EditedSubject  string `mapstructure:"edited_subject"`
+	DeletedSubject string `mapstructure:"deleted_subject"`
+}
+
+// applyDefaults fills in the subjects left empty, so every user of the
+// config sees the same ones
+func (c *NATSConfig) applyDefaults() {
+	for subject, fallback := range map[*string]string{
+		&c.EditedSubject:  "message.edited",
+		&c.DeletedSubject: "message.deleted",
+	} {
+		if *subject == "" {
+			*subject = fallback
+		}
+	}
+}
+
+// RetentionConfig controls how long soft deleted students and notifications
+// are kept before the purge job removes them for good
+type RetentionConfig struct {
+	DeletedDays          int `mapstructure:"deleted_days"`
+	NotificationDays     int `mapstructure:"notification_days"`
+	PurgeIntervalMinutes int `mapstructure:"purge_interval_minutes"`
+}
+
+// PasswordSetupConfig controls the links students created without a password
+// receive to choose one
+type PasswordSetupConfig struct {
+	URL string `mapstructure:"url"`
+	// LogLinks logs the full setup links when no mail driver is configured.
+	// The links log their holder in, so this is for local development only.
+	LogLinks bool `mapstructure:"log_links"`
+}
+
+// StreamConfig controls the real-time stream pushed to browsers. Events
+// published on the route subjects are relayed to per-student subjects under
+// SubjectPrefix, which every replica's connections subscribe to. The same
+// subjects feed the notifications.
+type StreamConfig struct {
+	SubjectPrefix       string `mapstructure:"subject_prefix"`
+	BufferSize          int    `mapstructure:"buffer_size"`
+	ReplaySize          int    `mapstructure:"replay_size"`
+	HeartbeatSeconds    int    `mapstructure:"heartbeat_seconds"`
+	ResumeWindowSeconds int    `mapstructure:"resume_window_seconds"`
+	MessageSubject      string `mapstructure:"message_subject"`
+	ReadSubject         string `mapstructure:"read_subject"`
+	ProjectSubject      string `mapstructure:"project_subject"`
+	ReminderSubject     string `mapstructure:"reminder_subject"`
+	GradedSubject       string `mapstructure:"graded_subject"`
+}
+
+// applyDefaults fills in the route subjects left empty, so the stream and
+// the notifications consume the same ones
+func (c *StreamConfig) applyDefaults() {
+	for subject, fallback := range map[*string]string{
+		&c.MessageSubject:  "message.created",
+		&c.ReadSubject:     "message.read",
+		&c.ProjectSubject:  "project.changed",
+		&c.ReminderSubject: "project.reminder",
+		&c.GradedSubject:   "submission.graded",
+	} {
+		if *subject == "" {
+			*subject = fallback
+		}
+	}
+}
+
+// MailConfig controls outgoing mail. Driver is smtp, or file to write mails
+// to Dir instead of sending them; without one no mail is sent and password
+// setup invitations are only logged.
+type MailConfig struct {
+	Driver string          `mapstructure:"driver"`
+	From   string          `mapstructure:"from"`
+	Dir    string          `mapstructure:"dir"`
+	SMTP   SMTPConfig      `mapstructure:"smtp"`
+	Queue  MailQueueConfig `mapstructure:"queue"`
+}
+
+type SMTPConfig struct {
+	Host            string `mapstructure:"host"`
+	Port            int    `mapstructure:"port"`
+	Username        string `mapstructure:"username"`
+	Password        string `mapstructure:"password"`
+	RequireStartTLS bool   `mapstructure:"require_starttls"`
+	TimeoutSeconds  int    `mapstructure:"timeout_seconds"`
+}
+
+// MailQueueConfig tunes the persistent send queue. Failed sends are retried
+// after BackoffSeconds, doubling up to MaxBackoffSeconds, MaxAttempts times.
+type MailQueueConfig struct {
+	PollIntervalSeconds int `mapstructure:"poll_interval_seconds"`
+	MaxAttempts         int `mapstructure:"max_attempts"`
+	BackoffSeconds      int `mapstructure:"backoff_seconds"`
+	MaxBackoffSeconds   int `mapstructure:"max_backoff_seconds"`
+	RetentionDays       int `mapstructure:"retention_days"`
 }
 
 func Load() (*Config, error) {
@@ -70,12 +171,16 @@ func Load() (*Config, error) {
 
 	viper.BindEnv("database.user", "DB_USER")
 	viper.BindEnv("database.password", "DB_PASSWORD")
+	viper.BindEnv("mail.smtp.username", "SMTP_USERNAME")
+	viper.BindEnv("mail.smtp.password", "SMTP_PASSWORD")
 
 	// Unmarshal into struct
 	var config Config
 	if err := viper.Unmarshal(&config); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal config: %w", err)
 	}
+	config.NATS.applyDefaults()
+	config.Stream.applyDefaults()
 
 	return &config, nil
 }
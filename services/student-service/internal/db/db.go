@@ -6,10 +6,13 @@ import (
 	"fmt"
 	"log"
 	"log/slog"
+	"reflect"
 	"time"
 
 	"student-service/internal/config"
 
+	"grud/common/history"
+
 	"github.com/uptrace/bun"
 	"github.com/uptrace/bun/dialect/pgdialect"
 	"github.com/uptrace/bun/driver/pgdriver"
@@ -90,7 +93,65 @@ func Close(db *bun.DB) {
 	}
 }
 
+// A migration is schema that goes beyond what bun creates from the models:
+// columns added after a table was first created, triggers and indexes. It runs
+// only when every table it touches was among the models passed to
+// RunMigrations, so callers migrating part of the schema are not tripped up by
+// features they do not use.
+type migration struct {
+	name   string
+	tables []string
+	sql    string
+}
+
+var migrations = []migration{
+	// Columns added after the students table was first created: soft delete
+	// and the optimistic concurrency version
+	{"add student columns", []string{"students"}, `
+		ALTER TABLE students ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMPTZ;
+		ALTER TABLE students ADD COLUMN IF NOT EXISTS version INTEGER NOT NULL DEFAULT 1;
+		CREATE INDEX IF NOT EXISTS idx_students_deleted_at ON students (deleted_at) WHERE deleted_at IS NOT NULL;
+	`},
+	// The history table is append-only; see grud/common/history
+	{"create history constraints", []string{"history"}, history.Migration},
+	// Indexes backing the students list: keyset pagination per sort order,
+	// equality filters and case-insensitive prefix search
+	{"create student indexes", []string{"students"}, `
+		CREATE INDEX IF NOT EXISTS idx_students_last_name_id ON students (last_name, id);
+		CREATE INDEX IF NOT EXISTS idx_students_year_id ON students (year, id);
+		CREATE INDEX IF NOT EXISTS idx_students_major ON students (major);
+		CREATE INDEX IF NOT EXISTS idx_students_email_prefix ON students (lower(email) text_pattern_ops);
+		CREATE INDEX IF NOT EXISTS idx_students_last_name_prefix ON students (lower(last_name) text_pattern_ops);
+		CREATE INDEX IF NOT EXISTS idx_students_first_name_prefix ON students (lower(first_name) text_pattern_ops);
+	`},
+	// Indexes backing the notifications list, newest first, the unread count
+	// and the purge of old notifications
+	{"create notification indexes", []string{"notifications"}, `
+		CREATE INDEX IF NOT EXISTS idx_notifications_student_id ON notifications (student_id, id);
+		CREATE INDEX IF NOT EXISTS idx_notifications_unread ON notifications (student_id) WHERE read_at IS NULL;
+		CREATE INDEX IF NOT EXISTS idx_notifications_created_at ON notifications (created_at);
+	`},
+	// Pending mails are claimed in order of their next attempt. Mails rendered
+	// at send time keep a reference instead of their body.
+	{"create mail queue indexes", []string{"mail_queue"}, `
+		ALTER TABLE mail_queue ADD COLUMN IF NOT EXISTS ref TEXT NOT NULL DEFAULT '';
+		CREATE INDEX IF NOT EXISTS idx_mail_queue_pending ON mail_queue (next_attempt_at, id) WHERE sent_at IS NULL AND failed_at IS NULL;
+	`},
+	// Full-text search vector, generated by Postgres so it always follows the
+	// columns it indexes; see grud/common/search for how it is queried. Emails
+	// are indexed whole and split at @ and dots, so domains are searchable too.
+	{"create search column", []string{"students"}, `
+		ALTER TABLE students ADD COLUMN IF NOT EXISTS search_vector tsvector GENERATED ALWAYS AS (
+			setweight(to_tsvector('simple', first_name || ' ' || last_name), 'A') ||
+			setweight(to_tsvector('simple', email || ' ' || translate(email, '@.', '  ')), 'B') ||
+			setweight(to_tsvector('simple', coalesce(major, '')), 'C')
+		) STORED;
+		CREATE INDEX IF NOT EXISTS idx_students_search ON students USING GIN (search_vector);
+	`},
+}
+
 func RunMigrations(ctx context.Context, db *bun.DB, models ...interface{}) error {
+	tables := make(map[string]bool, len(models))
 	for _, model := range models {
 		_, err := db.NewCreateTable().
 			Model(model).
@@ -99,7 +160,27 @@ func RunMigrations(ctx context.Context, db *bun.DB, models ...interface{}) error
 		if err != nil {
 			return fmt.Errorf("failed to create table for model: %w", err)
 		}
+		tables[db.Table(reflect.TypeOf(model)).Name] = true
+	}
+
+	for _, m := range migrations {
+		if !hasTables(tables, m.tables) {
+			continue
+		}
+		if _, err := db.ExecContext(ctx, m.sql); err != nil {
+			return fmt.Errorf("failed to %s: %w", m.name, err)
+		}
 	}
+
 	slog.Info("database migrations completed successfully")
 	return nil
 }
+
+func hasTables(migrated map[string]bool, tables []string) bool {
+	for _, table := range tables {
+		if !migrated[table] {
+			return false
+		}
+	}
+	return true
+}
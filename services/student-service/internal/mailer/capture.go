@@ -0,0 +1,70 @@
+package mailer
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// FileSender writes each message to an .eml file in a directory instead of
+// sending it, for local runs. Mail clients open the files as they are.
+type FileSender struct {
+	dir  string
+	from string
+	mu   sync.Mutex
+	seq  int
+}
+
+// NewFileSender creates a FileSender writing to dir, which is created if
+// missing
+func NewFileSender(dir, from string) (*FileSender, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+	return &FileSender{dir: dir, from: from}, nil
+}
+
+func (s *FileSender) Send(ctx context.Context, msg *Message) error {
+	now := time.Now()
+	data, err := msg.Encode(s.from, now)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	s.seq++
+	name := fmt.Sprintf("%s-%04d.eml", now.UTC().Format("20060102T150405.000"), s.seq)
+	s.mu.Unlock()
+
+	return os.WriteFile(filepath.Join(s.dir, name), data, 0o644)
+}
+
+// MemorySender keeps the messages it is given, for tests
+type MemorySender struct {
+	mu       sync.Mutex
+	messages []Message
+}
+
+func NewMemorySender() *MemorySender {
+	return &MemorySender{}
+}
+
+func (s *MemorySender) Send(ctx context.Context, msg *Message) error {
+	if err := msg.Validate(); err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.messages = append(s.messages, *msg)
+	return nil
+}
+
+// Messages returns the messages sent so far, oldest first
+func (s *MemorySender) Messages() []Message {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]Message(nil), s.messages...)
+}
@@ -0,0 +1,140 @@
+// Package mailer sends email. Messages are rendered from templates, put on a
+// persistent queue and handed to a Sender: SMTP in production, files or
+// memory for local runs and tests.
+package mailer
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net/mail"
+	"net/textproto"
+	"strings"
+	"time"
+)
+
+var (
+	ErrNoRecipients   = errors.New("mail has no recipients")
+	ErrInvalidAddress = errors.New("invalid mail address")
+	ErrInvalidMessage = errors.New("mail needs a single line subject and a text body")
+)
+
+// Message is a mail with a plain text body and, optionally, an HTML
+// alternative
+type Message struct {
+	To      []string
+	Subject string
+	Text    string
+	HTML    string
+	// Template names the template the message was rendered from, if any. It
+	// labels metrics and logs.
+	Template string
+}
+
+// Sender delivers a message. Implementations are safe for concurrent use.
+type Sender interface {
+	Send(ctx context.Context, msg *Message) error
+}
+
+// Validate checks that the message can be encoded
+func (m *Message) Validate() error {
+	if len(m.To) == 0 {
+		return ErrNoRecipients
+	}
+	for _, to := range m.To {
+		if _, err := mail.ParseAddress(to); err != nil {
+			return fmt.Errorf("%w %q", ErrInvalidAddress, to)
+		}
+	}
+	if strings.TrimSpace(m.Subject) == "" || strings.ContainsAny(m.Subject, "\r\n") || m.Text == "" {
+		return ErrInvalidMessage
+	}
+	return nil
+}
+
+// Encode returns the message in RFC 5322 form, sent by from at date. The
+// text and HTML bodies are quoted-printable parts of a multipart/alternative
+// body.
+func (m *Message) Encode(from string, date time.Time) ([]byte, error) {
+	if err := m.Validate(); err != nil {
+		return nil, err
+	}
+	sender, err := mail.ParseAddress(from)
+	if err != nil {
+		return nil, fmt.Errorf("%w %q", ErrInvalidAddress, from)
+	}
+
+	var buf bytes.Buffer
+	header := func(key, value string) {
+		fmt.Fprintf(&buf, "%s: %s\r\n", key, value)
+	}
+	header("From", sender.String())
+	header("To", strings.Join(m.To, ", "))
+	header("Subject", mime.QEncoding.Encode("utf-8", m.Subject))
+	header("Date", date.Format(time.RFC1123Z))
+	header("Message-ID", messageID(sender.Address))
+	header("MIME-Version", "1.0")
+
+	if m.HTML == "" {
+		header("Content-Type", `text/plain; charset="utf-8"`)
+		header("Content-Transfer-Encoding", "quoted-printable")
+		buf.WriteString("\r\n")
+		if err := writeQuotedPrintable(&buf, m.Text); err != nil {
+			return nil, err
+		}
+		return buf.Bytes(), nil
+	}
+
+	var body bytes.Buffer
+	parts := multipart.NewWriter(&body)
+	header("Content-Type", mime.FormatMediaType("multipart/alternative", map[string]string{"boundary": parts.Boundary()}))
+	buf.WriteString("\r\n")
+
+	// Clients show the last alternative they understand, so HTML goes last
+	for _, part := range []struct{ contentType, content string }{
+		{`text/plain; charset="utf-8"`, m.Text},
+		{`text/html; charset="utf-8"`, m.HTML},
+	} {
+		w, err := parts.CreatePart(textproto.MIMEHeader{
+			"Content-Type":              {part.contentType},
+			"Content-Transfer-Encoding": {"quoted-printable"},
+		})
+		if err != nil {
+			return nil, err
+		}
+		if err := writeQuotedPrintable(w, part.content); err != nil {
+			return nil, err
+		}
+	}
+	if err := parts.Close(); err != nil {
+		return nil, err
+	}
+	buf.Write(body.Bytes())
+	return buf.Bytes(), nil
+}
+
+func writeQuotedPrintable(w io.Writer, s string) error {
+	qp := quotedprintable.NewWriter(w)
+	if _, err := qp.Write([]byte(s)); err != nil {
+		return err
+	}
+	return qp.Close()
+}
+
+// messageID returns a unique Message-ID in the sender's domain
+func messageID(address string) string {
+	domain := "localhost"
+	if at := strings.LastIndexByte(address, '@'); at >= 0 {
+		domain = address[at+1:]
+	}
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+	return fmt.Sprintf("<%s@%s>", hex.EncodeToString(b), domain)
+}
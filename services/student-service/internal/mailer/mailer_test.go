@@ -0,0 +1,521 @@
+package mailer_test
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net"
+	"net/mail"
+	"net/textproto"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"student-service/internal/mailer"
+
+	commonmetrics "grud/common/metrics"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func parts(t *testing.T, data []byte) (*mail.Message, map[string]string) {
+	msg, err := mail.ReadMessage(strings.NewReader(string(data)))
+	require.NoError(t, err)
+
+	mediaType, params, err := mime.ParseMediaType(msg.Header.Get("Content-Type"))
+	require.NoError(t, err)
+	require.Equal(t, "multipart/alternative", mediaType)
+
+	bodies := make(map[string]string)
+	r := multipart.NewReader(msg.Body, params["boundary"])
+	for {
+		part, err := r.NextRawPart()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		require.NoError(t, err)
+		assert.Equal(t, "quoted-printable", part.Header.Get("Content-Transfer-Encoding"))
+		body, err := io.ReadAll(quotedprintable.NewReader(part))
+		require.NoError(t, err)
+		contentType, _, _ := mime.ParseMediaType(part.Header.Get("Content-Type"))
+		bodies[contentType] = string(body)
+	}
+	return msg, bodies
+}
+
+func TestMessage(t *testing.T) {
+	t.Run("Encode", func(t *testing.T) {
+		msg := &mailer.Message{
+			To:      []string{"Ada <ada@example.com>", "bob@example.com"},
+			Subject: "Grüße",
+			Text:    "Hello " + strings.Repeat("x", 100),
+			HTML:    "<p>Hello</p>",
+		}
+		data, err := msg.Encode("GRUD <no-reply@grud.dev>", time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC))
+		require.NoError(t, err)
+
+		encoded, bodies := parts(t, data)
+		assert.Equal(t, `"GRUD" <no-reply@grud.dev>`, encoded.Header.Get("From"))
+		assert.Equal(t, "Ada <ada@example.com>, bob@example.com", encoded.Header.Get("To"))
+		subject, err := new(mime.WordDecoder).DecodeHeader(encoded.Header.Get("Subject"))
+		require.NoError(t, err)
+		assert.Equal(t, "Grüße", subject)
+		assert.Equal(t, "Fri, 01 May 2026 12:00:00 +0000", encoded.Header.Get("Date"))
+		assert.True(t, strings.HasSuffix(encoded.Header.Get("Message-ID"), "@grud.dev>"))
+		assert.Equal(t, msg.Text, bodies["text/plain"])
+		assert.Equal(t, msg.HTML, bodies["text/html"])
+	})
+
+	t.Run("TextOnly", func(t *testing.T) {
+		msg := &mailer.Message{To: []string{"ada@example.com"}, Subject: "Hi", Text: "Hello"}
+		data, err := msg.Encode("no-reply@grud.dev", time.Now())
+		require.NoError(t, err)
+
+		encoded, err := mail.ReadMessage(strings.NewReader(string(data)))
+		require.NoError(t, err)
+		assert.Equal(t, `text/plain; charset="utf-8"`, encoded.Header.Get("Content-Type"))
+		body, err := io.ReadAll(quotedprintable.NewReader(encoded.Body))
+		require.NoError(t, err)
+		assert.Equal(t, "Hello", string(body))
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		for name, msg := range map[string]mailer.Message{
+			"NoRecipients":     {Subject: "Hi", Text: "Hello"},
+			"BadRecipient":     {To: []string{"ada@example.com\r\nBcc: eve@example.com"}, Subject: "Hi", Text: "Hello"},
+			"NoSubject":        {To: []string{"ada@example.com"}, Text: "Hello"},
+			"MultilineSubject": {To: []string{"ada@example.com"}, Subject: "Hi\r\nBcc: eve@example.com", Text: "Hello"},
+			"NoText":           {To: []string{"ada@example.com"}, Subject: "Hi"},
+		} {
+			err := msg.Validate()
+			assert.Error(t, err, name)
+			assert.True(t, mailer.Permanent(err), name)
+		}
+	})
+}
+
+func TestTemplates(t *testing.T) {
+	t.Run("Default", func(t *testing.T) {
+		templates, err := mailer.DefaultTemplates()
+		require.NoError(t, err)
+
+		msg, err := templates.Render(mailer.TemplatePasswordSetup, []string{"ada@example.com"}, map[string]string{
+			"Name":      "<Ada>",
+			"Link":      "https://grud.dev/setup?token=abc",
+			"ExpiresIn": "3 days",
+		})
+		require.NoError(t, err)
+		assert.Equal(t, mailer.TemplatePasswordSetup, msg.Template)
+		assert.Equal(t, "Choose your GRUD password", msg.Subject)
+		assert.Contains(t, msg.Text, "Hi <Ada>,")
+		assert.Contains(t, msg.Text, "https://grud.dev/setup?token=abc")
+		assert.Contains(t, msg.HTML, "Hi &lt;Ada&gt;,")
+		assert.Contains(t, msg.HTML, `href="https://grud.dev/setup?token=abc"`)
+		assert.Contains(t, msg.HTML, "</html>")
+		require.NoError(t, msg.Validate())
+
+		_, err = templates.Render("welcome", []string{"ada@example.com"}, nil)
+		assert.ErrorIs(t, err, mailer.ErrUnknownTemplate)
+
+		// Every field the template uses must be given
+		_, err = templates.Render(mailer.TemplatePasswordSetup, []string{"ada@example.com"}, map[string]string{"Name": "Ada"})
+		assert.Error(t, err)
+	})
+
+	t.Run("TextOnly", func(t *testing.T) {
+		templates, err := mailer.ParseTemplates(fstest.MapFS{
+			"hello.subject.tmpl": {Data: []byte("Hello\n  {{.}}\n")},
+			"hello.txt.tmpl":     {Data: []byte("Hello {{.}}")},
+			"other.html.tmpl":    {Data: []byte("<p>Other</p>")},
+		})
+		require.NoError(t, err)
+
+		msg, err := templates.Render("hello", []string{"ada@example.com"}, "Ada")
+		require.NoError(t, err)
+		assert.Equal(t, "Hello Ada", msg.Subject)
+		assert.Equal(t, "Hello Ada", msg.Text)
+		assert.Empty(t, msg.HTML)
+	})
+}
+
+func TestCapture(t *testing.T) {
+	msg := &mailer.Message{To: []string{"ada@example.com"}, Subject: "Hi", Text: "Hello", HTML: "<p>Hello</p>"}
+
+	t.Run("File", func(t *testing.T) {
+		dir := filepath.Join(t.TempDir(), "mail")
+		sender, err := mailer.NewFileSender(dir, "no-reply@grud.dev")
+		require.NoError(t, err)
+		require.NoError(t, sender.Send(context.Background(), msg))
+		require.NoError(t, sender.Send(context.Background(), msg))
+
+		files, err := filepath.Glob(filepath.Join(dir, "*.eml"))
+		require.NoError(t, err)
+		require.Len(t, files, 2)
+		data, err := os.ReadFile(files[0])
+		require.NoError(t, err)
+		_, bodies := parts(t, data)
+		assert.Equal(t, "Hello", bodies["text/plain"])
+
+		assert.ErrorIs(t, sender.Send(context.Background(), &mailer.Message{Subject: "Hi", Text: "Hello"}), mailer.ErrNoRecipients)
+	})
+
+	t.Run("Memory", func(t *testing.T) {
+		sender := mailer.NewMemorySender()
+		require.NoError(t, sender.Send(context.Background(), msg))
+		assert.Equal(t, []mailer.Message{*msg}, sender.Messages())
+	})
+}
+
+// smtpServer is a minimal SMTP server accepting one mail per connection
+type smtpServer struct {
+	listener net.Listener
+	// reject is the reply to RCPT TO, if set
+	reject string
+
+	mu       sync.Mutex
+	auth     string
+	from     string
+	rcpts    []string
+	data     string
+	commands []string
+}
+
+func newSMTPServer(t *testing.T) *smtpServer {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	s := &smtpServer{listener: listener}
+	t.Cleanup(func() { listener.Close() })
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go s.serve(conn)
+		}
+	}()
+	return s
+}
+
+func (s *smtpServer) port() int {
+	return s.listener.Addr().(*net.TCPAddr).Port
+}
+
+func (s *smtpServer) serve(conn net.Conn) {
+	defer conn.Close()
+	tp := textproto.NewConn(conn)
+	reply := func(line string) {
+		_ = tp.PrintfLine("%s", line)
+	}
+
+	reply("220 localhost ESMTP")
+	for {
+		line, err := tp.ReadLine()
+		if err != nil {
+			return
+		}
+		verb, arg, _ := strings.Cut(line, " ")
+		s.mu.Lock()
+		s.commands = append(s.commands, strings.ToUpper(verb))
+		s.mu.Unlock()
+
+		switch strings.ToUpper(verb) {
+		case "EHLO":
+			reply("250-localhost")
+			reply("250 AUTH PLAIN")
+		case "AUTH":
+			_, initial, _ := strings.Cut(arg, " ")
+			decoded, _ := base64.StdEncoding.DecodeString(initial)
+			s.mu.Lock()
+			s.auth = string(decoded)
+			s.mu.Unlock()
+			reply("235 2.7.0 Authentication successful")
+		case "MAIL":
+			s.mu.Lock()
+			s.from = arg
+			s.mu.Unlock()
+			reply("250 OK")
+		case "RCPT":
+			if s.reject != "" {
+				reply(s.reject)
+				continue
+			}
+			s.mu.Lock()
+			s.rcpts = append(s.rcpts, arg)
+			s.mu.Unlock()
+			reply("250 OK")
+		case "DATA":
+			reply("354 End data with <CR><LF>.<CR><LF>")
+			data, err := tp.ReadDotBytes()
+			if err != nil {
+				return
+			}
+			s.mu.Lock()
+			s.data = string(data)
+			s.mu.Unlock()
+			reply("250 OK: queued")
+		case "QUIT":
+			reply("221 Bye")
+			return
+		default:
+			reply("502 Command not implemented")
+		}
+	}
+}
+
+func TestSMTPSender(t *testing.T) {
+	msg := &mailer.Message{To: []string{"Ada <ada@example.com>", "bob@example.com"}, Subject: "Hi", Text: "Hello", HTML: "<p>Hello</p>"}
+
+	t.Run("Send", func(t *testing.T) {
+		server := newSMTPServer(t)
+		sender, err := mailer.NewSMTPSender(mailer.SMTPConfig{
+			Host:     "localhost",
+			Port:     server.port(),
+			Username: "grud",
+			Password: "secret",
+			From:     "GRUD <no-reply@grud.dev>",
+		})
+		require.NoError(t, err)
+		require.NoError(t, sender.Send(context.Background(), msg))
+
+		server.mu.Lock()
+		defer server.mu.Unlock()
+		assert.Equal(t, "\x00grud\x00secret", server.auth)
+		assert.Equal(t, "FROM:<no-reply@grud.dev>", server.from)
+		assert.Equal(t, []string{"TO:<ada@example.com>", "TO:<bob@example.com>"}, server.rcpts)
+		_, bodies := parts(t, []byte(server.data))
+		assert.Equal(t, "<p>Hello</p>", bodies["text/html"])
+		assert.Equal(t, "QUIT", server.commands[len(server.commands)-1])
+	})
+
+	t.Run("RequireStartTLS", func(t *testing.T) {
+		server := newSMTPServer(t)
+		sender, err := mailer.NewSMTPSender(mailer.SMTPConfig{
+			Host:            "localhost",
+			Port:            server.port(),
+			From:            "no-reply@grud.dev",
+			RequireStartTLS: true,
+		})
+		require.NoError(t, err)
+
+		err = sender.Send(context.Background(), msg)
+		assert.ErrorIs(t, err, mailer.ErrStartTLSUnsupported)
+		assert.False(t, mailer.Permanent(err))
+		server.mu.Lock()
+		defer server.mu.Unlock()
+		assert.NotContains(t, server.commands, "MAIL")
+	})
+
+	t.Run("Rejected", func(t *testing.T) {
+		server := newSMTPServer(t)
+		server.reject = "550 5.1.1 No such user"
+		sender, err := mailer.NewSMTPSender(mailer.SMTPConfig{Host: "localhost", Port: server.port(), From: "no-reply@grud.dev"})
+		require.NoError(t, err)
+
+		err = sender.Send(context.Background(), msg)
+		require.Error(t, err)
+		assert.True(t, mailer.Permanent(err))
+	})
+
+	t.Run("Unreachable", func(t *testing.T) {
+		server := newSMTPServer(t)
+		port := server.port()
+		server.listener.Close()
+		sender, err := mailer.NewSMTPSender(mailer.SMTPConfig{Host: "localhost", Port: port, From: "no-reply@grud.dev", Timeout: time.Second})
+		require.NoError(t, err)
+
+		err = sender.Send(context.Background(), msg)
+		require.Error(t, err)
+		assert.False(t, mailer.Permanent(err))
+	})
+
+	t.Run("Config", func(t *testing.T) {
+		_, err := mailer.NewSMTPSender(mailer.SMTPConfig{From: "no-reply@grud.dev"})
+		assert.Error(t, err)
+		_, err = mailer.NewSMTPSender(mailer.SMTPConfig{Host: "localhost", From: "not an address"})
+		assert.ErrorIs(t, err, mailer.ErrInvalidAddress)
+	})
+}
+
+// memoryQueue keeps queued mails in memory
+type memoryQueue struct {
+	mu    sync.Mutex
+	mails []mailer.QueuedMail
+}
+
+func (r *memoryQueue) Enqueue(ctx context.Context, m *mailer.QueuedMail) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	m.ID = int64(len(r.mails) + 1)
+	m.CreatedAt = time.Now()
+	m.NextAttemptAt = m.CreatedAt
+	r.mails = append(r.mails, *m)
+	return nil
+}
+
+func (r *memoryQueue) Claim(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]mailer.QueuedMail, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var claimed []mailer.QueuedMail
+	for i := range r.mails {
+		m := &r.mails[i]
+		if len(claimed) == limit || m.SentAt != nil || m.FailedAt != nil || m.NextAttemptAt.After(now) {
+			continue
+		}
+		m.Attempts++
+		m.NextAttemptAt = now.Add(lease)
+		claimed = append(claimed, *m)
+	}
+	return claimed, nil
+}
+
+func (r *memoryQueue) update(id int64, f func(m *mailer.QueuedMail)) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	f(&r.mails[id-1])
+	return nil
+}
+
+func (r *memoryQueue) MarkSent(ctx context.Context, id int64, sentAt time.Time) error {
+	return r.update(id, func(m *mailer.QueuedMail) { m.SentAt = &sentAt })
+}
+
+func (r *memoryQueue) Retry(ctx context.Context, id int64, next time.Time, lastError string) error {
+	return r.update(id, func(m *mailer.QueuedMail) { m.NextAttemptAt, m.LastError = next, lastError })
+}
+
+func (r *memoryQueue) MarkFailed(ctx context.Context, id int64, failedAt time.Time, lastError string) error {
+	return r.update(id, func(m *mailer.QueuedMail) { m.FailedAt, m.LastError = &failedAt, lastError })
+}
+
+func (r *memoryQueue) Prune(ctx context.Context, before time.Time) (int, error) {
+	return 0, nil
+}
+
+func (r *memoryQueue) get(id int64) mailer.QueuedMail {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.mails[id-1]
+}
+
+// flakySender fails the sends to the addresses in failures
+type flakySender struct {
+	mailer.MemorySender
+	failures map[string]error
+}
+
+func (s *flakySender) Send(ctx context.Context, msg *mailer.Message) error {
+	if err := s.failures[msg.To[0]]; err != nil {
+		return err
+	}
+	return s.MemorySender.Send(ctx, msg)
+}
+
+func TestQueue(t *testing.T) {
+	ctx := context.Background()
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	repo := &memoryQueue{}
+	sender := &flakySender{failures: map[string]error{
+		"down@example.com":    errors.New("connection refused"),
+		"unknown@example.com": &textproto.Error{Code: 550, Msg: "No such user"},
+	}}
+	queue := mailer.NewQueue(repo, sender, mailer.QueueOptions{
+		MaxAttempts: 3,
+		Backoff:     time.Minute,
+		MaxBackoff:  90 * time.Second,
+	}, logger, commonmetrics.NewMock())
+
+	for _, to := range []string{"ada@example.com", "down@example.com", "unknown@example.com", "bob@example.com"} {
+		require.NoError(t, queue.Send(ctx, &mailer.Message{To: []string{to}, Subject: "Hi", Text: "Hello", Template: "hello"}))
+	}
+	assert.ErrorIs(t, queue.Send(ctx, &mailer.Message{To: []string{"ada@example.com"}}), mailer.ErrInvalidMessage)
+
+	// Queued mails are sent one by one; rejected ones are given up on at once
+	sent, err := queue.SendDue(ctx, time.Now())
+	require.NoError(t, err)
+	assert.Equal(t, 2, sent)
+	assert.Len(t, sender.Messages(), 2)
+	assert.NotNil(t, repo.get(1).SentAt)
+	assert.NotNil(t, repo.get(3).FailedAt)
+	assert.Contains(t, repo.get(3).LastError, "No such user")
+
+	// Failed sends are retried after a backoff
+	down := repo.get(2)
+	assert.Nil(t, down.FailedAt)
+	assert.Equal(t, 1, down.Attempts)
+	assert.Equal(t, "connection refused", down.LastError)
+	assert.WithinDuration(t, time.Now().Add(time.Minute), down.NextAttemptAt, 5*time.Second)
+
+	sent, err = queue.SendDue(ctx, time.Now())
+	require.NoError(t, err)
+	assert.Equal(t, 0, sent)
+
+	for attempt := 2; attempt <= 3; attempt++ {
+		_, err = queue.SendDue(ctx, repo.get(2).NextAttemptAt)
+		require.NoError(t, err)
+		assert.Equal(t, attempt, repo.get(2).Attempts)
+	}
+	assert.NotNil(t, repo.get(2).FailedAt, "given up on after the last attempt")
+}
+
+// refRenderer renders queued references into a greeting, counting renders;
+// the reference "gone" is no longer wanted
+type refRenderer struct {
+	renders int
+}
+
+func (r *refRenderer) RenderQueued(ctx context.Context, ref string) (*mailer.Message, error) {
+	r.renders++
+	if ref == "gone" {
+		return nil, mailer.ErrCanceled
+	}
+	return &mailer.Message{Subject: "Hi", Text: "secret " + ref, Template: "secret"}, nil
+}
+
+func TestQueue_SendLater(t *testing.T) {
+	ctx := context.Background()
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	repo := &memoryQueue{}
+	sender := &mailer.MemorySender{}
+	queue := mailer.NewQueue(repo, sender, mailer.QueueOptions{}, logger, commonmetrics.NewMock())
+
+	assert.Error(t, queue.SendLater(ctx, "secret", []string{"ada@example.com"}, "1"), "no renderer registered")
+	renderer := &refRenderer{}
+	queue.Register("secret", renderer)
+	assert.ErrorIs(t, queue.SendLater(ctx, "secret", []string{"not an address"}, "1"), mailer.ErrInvalidAddress)
+	require.NoError(t, queue.SendLater(ctx, "secret", []string{"ada@example.com"}, "42"))
+	require.NoError(t, queue.SendLater(ctx, "secret", []string{"bob@example.com"}, "gone"))
+
+	// Only the reference is stored, the body is rendered when sent
+	stored := repo.get(1)
+	assert.Equal(t, "42", stored.Ref)
+	assert.Empty(t, stored.Text)
+	assert.Zero(t, renderer.renders)
+
+	sent, err := queue.SendDue(ctx, time.Now())
+	require.NoError(t, err)
+	assert.Equal(t, 1, sent)
+	require.Len(t, sender.Messages(), 1)
+	assert.Equal(t, []string{"ada@example.com"}, sender.Messages()[0].To)
+	assert.Equal(t, "secret 42", sender.Messages()[0].Text)
+	assert.Empty(t, repo.get(1).Text)
+	assert.NotNil(t, repo.get(2).FailedAt, "canceled mails are dropped")
+}
+
+func TestBackoff(t *testing.T) {
+	for attempt, want := range map[int]time.Duration{1: 30 * time.Second, 2: time.Minute, 3: 2 * time.Minute, 4: 4 * time.Minute, 5: 5 * time.Minute, 40: 5 * time.Minute} {
+		assert.Equal(t, want, mailer.Backoff(attempt, 30*time.Second, 5*time.Minute), fmt.Sprintf("attempt %d", attempt))
+	}
+}
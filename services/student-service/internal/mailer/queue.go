@@ -0,0 +1,252 @@
+package mailer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/mail"
+	"time"
+
+	"grud/common/metrics"
+)
+
+// Queue defaults
+const (
+	DefaultPollInterval = 10 * time.Second
+	DefaultMaxAttempts  = 8
+	DefaultBackoff      = 30 * time.Second
+	DefaultMaxBackoff   = time.Hour
+	DefaultRetention    = 7 * 24 * time.Hour
+
+	// claimLease keeps a claimed mail from other replicas while it is sent.
+	// Mails are claimed one at a time, so it only has to outlast one send.
+	claimLease = 5 * time.Minute
+)
+
+// ErrCanceled is returned by a Renderer for a mail that is no longer wanted;
+// the mail is dropped without being sent
+var ErrCanceled = errors.New("mail is no longer wanted")
+
+// Renderer renders the mails of one template that were queued with
+// SendLater, from their reference, when they are sent
+type Renderer interface {
+	RenderQueued(ctx context.Context, ref string) (*Message, error)
+}
+
+// QueueOptions tune a Queue. Zero values take the defaults.
+type QueueOptions struct {
+	PollInterval time.Duration
+	// MaxAttempts is how many sends a mail gets before it is given up on
+	MaxAttempts int
+	// Backoff is the delay before the first retry; it doubles with every
+	// further attempt up to MaxBackoff
+	Backoff    time.Duration
+	MaxBackoff time.Duration
+	// Retention is how long sent and failed mails are kept
+	Retention time.Duration
+}
+
+// Queue is a Sender that stores messages and sends them from Run, retrying
+// failed sends with exponential backoff. Every replica may run it; claims
+// make sure each mail is sent by one.
+type Queue struct {
+	repo      Repository
+	sender    Sender
+	renderers map[string]Renderer
+	opts      QueueOptions
+	logger    *slog.Logger
+	metrics   *metrics.Metrics
+}
+
+func NewQueue(repo Repository, sender Sender, opts QueueOptions, logger *slog.Logger, m *metrics.Metrics) *Queue {
+	if opts.PollInterval <= 0 {
+		opts.PollInterval = DefaultPollInterval
+	}
+	if opts.MaxAttempts <= 0 {
+		opts.MaxAttempts = DefaultMaxAttempts
+	}
+	if opts.Backoff <= 0 {
+		opts.Backoff = DefaultBackoff
+	}
+	if opts.MaxBackoff <= 0 {
+		opts.MaxBackoff = DefaultMaxBackoff
+	}
+	if opts.Retention <= 0 {
+		opts.Retention = DefaultRetention
+	}
+	return &Queue{
+		repo:      repo,
+		sender:    sender,
+		renderers: make(map[string]Renderer),
+		opts:      opts,
+		logger:    logger,
+		metrics:   m,
+	}
+}
+
+// Register makes r render the mails of template queued with SendLater. It
+// must be called before Run.
+func (q *Queue) Register(template string, r Renderer) {
+	q.renderers[template] = r
+}
+
+// Send stores the message to be sent by Run. It only fails if the message is
+// invalid or cannot be stored.
+func (q *Queue) Send(ctx context.Context, msg *Message) error {
+	if err := msg.Validate(); err != nil {
+		return err
+	}
+	err := q.repo.Enqueue(ctx, &QueuedMail{
+		Template: msg.Template,
+		To:       msg.To,
+		Subject:  msg.Subject,
+		Text:     msg.Text,
+		HTML:     msg.HTML,
+	})
+	if err != nil {
+		return err
+	}
+	q.metrics.Mail.RecordQueued(ctx, msg.Template)
+	return nil
+}
+
+// SendLater stores a reference to a mail of template that is rendered only
+// when it is sent, by the Renderer registered for template. Mails carrying
+// secrets are queued this way, so the queue never holds the secret.
+func (q *Queue) SendLater(ctx context.Context, template string, to []string, ref string) error {
+	if q.renderers[template] == nil {
+		return fmt.Errorf("no renderer registered for mail template %q", template)
+	}
+	if len(to) == 0 {
+		return ErrNoRecipients
+	}
+	for _, addr := range to {
+		if _, err := mail.ParseAddress(addr); err != nil {
+			return fmt.Errorf("%w %q", ErrInvalidAddress, addr)
+		}
+	}
+	err := q.repo.Enqueue(ctx, &QueuedMail{
+		Template: template,
+		To:       to,
+		Ref:      ref,
+	})
+	if err != nil {
+		return err
+	}
+	q.metrics.Mail.RecordQueued(ctx, template)
+	return nil
+}
+
+// Run sends due mails every poll interval until ctx is done
+func (q *Queue) Run(ctx context.Context) {
+	ticker := time.NewTicker(q.opts.PollInterval)
+	defer ticker.Stop()
+
+	q.logger.Info("starting mail queue",
+		"poll_interval", q.opts.PollInterval,
+		"max_attempts", q.opts.MaxAttempts,
+	)
+
+	for {
+		if _, err := q.SendDue(ctx, time.Now()); err != nil && ctx.Err() == nil {
+			q.logger.ErrorContext(ctx, "failed to send queued mails", "error", err)
+		}
+
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			q.logger.Info("stopping mail queue")
+			return
+		}
+	}
+}
+
+// SendDue sends the mails due at now and returns how many were sent
+func (q *Queue) SendDue(ctx context.Context, now time.Time) (int, error) {
+	if pruned, err := q.repo.Prune(ctx, now.Add(-q.opts.Retention)); err != nil {
+		return 0, err
+	} else if pruned > 0 {
+		q.logger.InfoContext(ctx, "pruned mail queue", "count", pruned)
+	}
+
+	start := time.Now()
+	sent := 0
+	for {
+		// Each mail is claimed right before it is sent, so a slow send never
+		// outlasts the lease of mails still waiting behind it. Claimed mails
+		// are not due again until their lease ends.
+		mails, err := q.repo.Claim(ctx, now.Add(time.Since(start)), claimLease, 1)
+		if err != nil || len(mails) == 0 {
+			return sent, err
+		}
+
+		ok, err := q.send(ctx, &mails[0])
+		if err != nil {
+			return sent, err
+		}
+		if ok {
+			sent++
+		}
+	}
+}
+
+// send sends one claimed mail and records the outcome. It returns whether
+// the mail was sent; failing to send is not an error, failing to record is.
+func (q *Queue) send(ctx context.Context, mail *QueuedMail) (bool, error) {
+	start := time.Now()
+	msg, err := q.render(ctx, mail)
+	if errors.Is(err, ErrCanceled) {
+		q.logger.InfoContext(ctx, "dropping mail that is no longer wanted", "mail_id", mail.ID, "template", mail.Template)
+		return false, q.repo.MarkFailed(ctx, mail.ID, time.Now(), err.Error())
+	}
+	if err == nil {
+		err = q.sender.Send(ctx, msg)
+	}
+	final := err != nil && (mail.Attempts >= q.opts.MaxAttempts || Permanent(err))
+	q.metrics.Mail.RecordSend(ctx, mail.Template, time.Since(start), err, final)
+
+	now := time.Now()
+	switch {
+	case err == nil:
+		return true, q.repo.MarkSent(ctx, mail.ID, now)
+	case final:
+		q.logger.ErrorContext(ctx, "giving up on mail", "mail_id", mail.ID, "template", mail.Template, "attempts", mail.Attempts, "error", err)
+		return false, q.repo.MarkFailed(ctx, mail.ID, now, err.Error())
+	default:
+		next := now.Add(Backoff(mail.Attempts, q.opts.Backoff, q.opts.MaxBackoff))
+		q.logger.WarnContext(ctx, "failed to send mail, retrying", "mail_id", mail.ID, "template", mail.Template, "attempts", mail.Attempts, "next_attempt_at", next, "error", err)
+		return false, q.repo.Retry(ctx, mail.ID, next, err.Error())
+	}
+}
+
+// render returns the message of a claimed mail, rendering it first if it
+// was queued with SendLater
+func (q *Queue) render(ctx context.Context, mail *QueuedMail) (*Message, error) {
+	if mail.Ref == "" {
+		return mail.Message(), nil
+	}
+	r := q.renderers[mail.Template]
+	if r == nil {
+		return nil, fmt.Errorf("no renderer registered for mail template %q", mail.Template)
+	}
+	msg, err := r.RenderQueued(ctx, mail.Ref)
+	if err != nil {
+		return nil, err
+	}
+	msg.To = mail.To
+	return msg, nil
+}
+
+// Backoff returns the delay after the given failed attempt, counted from 1:
+// base, doubled for every further attempt and capped at ceiling
+func Backoff(attempt int, base, ceiling time.Duration) time.Duration {
+	delay := base
+	for i := 1; i < attempt; i++ {
+		delay *= 2
+		if delay >= ceiling {
+			return ceiling
+		}
+	}
+	return min(delay, ceiling)
+}
@@ -0,0 +1,157 @@
+package mailer
+
+import (
+	"context"
+	"time"
+
+	"grud/common/metrics"
+
+	"github.com/uptrace/bun"
+)
+
+// QueuedMail is a message waiting in the send queue, or sent or given up on
+// recently. Attempts counts the sends tried so far. Mails queued with
+// SendLater carry only a Ref for their template's Renderer, and no subject
+// or body.
+type QueuedMail struct {
+	bun.BaseModel `bun:"table:mail_queue,alias:mq"`
+
+	ID            int64      `bun:"id,pk,autoincrement"`
+	Template      string     `bun:"template,notnull,default:''"`
+	To            []string   `bun:"recipients,array,notnull"`
+	Subject       string     `bun:"subject,notnull"`
+	Text          string     `bun:"text,notnull"`
+	HTML          string     `bun:"html,notnull,default:''"`
+	Ref           string     `bun:"ref,notnull,default:''"`
+	Attempts      int        `bun:"attempts,notnull,default:0"`
+	NextAttemptAt time.Time  `bun:"next_attempt_at,notnull,default:current_timestamp"`
+	LastError     string     `bun:"last_error,notnull,default:''"`
+	CreatedAt     time.Time  `bun:"created_at,notnull,default:current_timestamp"`
+	SentAt        *time.Time `bun:"sent_at"`
+	FailedAt      *time.Time `bun:"failed_at"`
+}
+
+// Message returns the message to send
+func (q *QueuedMail) Message() *Message {
+	return &Message{To: q.To, Subject: q.Subject, Text: q.Text, HTML: q.HTML, Template: q.Template}
+}
+
+type Repository interface {
+	// Enqueue inserts a mail and sets its ID
+	Enqueue(ctx context.Context, mail *QueuedMail) error
+	// Claim returns up to limit pending mails due at now, oldest first. Each
+	// one's attempt is counted and its next attempt is pushed back by lease,
+	// so no other replica claims it while it is being sent.
+	Claim(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]QueuedMail, error)
+	// MarkSent records that a mail was sent
+	MarkSent(ctx context.Context, id int64, sentAt time.Time) error
+	// Retry schedules another attempt of a mail that failed with lastError
+	Retry(ctx context.Context, id int64, next time.Time, lastError string) error
+	// MarkFailed gives up on a mail that failed with lastError
+	MarkFailed(ctx context.Context, id int64, failedAt time.Time, lastError string) error
+	// Prune deletes the mails sent or given up on before before and returns
+	// how many there were
+	Prune(ctx context.Context, before time.Time) (int, error)
+}
+
+type repository struct {
+	db      *bun.DB
+	metrics *metrics.Metrics
+}
+
+func NewRepository(db *bun.DB, m *metrics.Metrics) Repository {
+	return &repository{
+		db:      db,
+		metrics: m,
+	}
+}
+
+func (r *repository) Enqueue(ctx context.Context, mail *QueuedMail) error {
+	start := time.Now()
+	_, err := r.db.NewInsert().Model(mail).Returning("id, next_attempt_at, created_at").Exec(ctx)
+	r.metrics.Database.RecordQuery(ctx, "insert", "mail_queue", time.Since(start), err)
+
+	return err
+}
+
+func (r *repository) Claim(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]QueuedMail, error) {
+	start := time.Now()
+	due := r.db.NewSelect().
+		Model((*QueuedMail)(nil)).
+		Column("id").
+		Where("sent_at IS NULL").
+		Where("failed_at IS NULL").
+		Where("next_attempt_at <= ?", now).
+		OrderExpr("next_attempt_at, id").
+		Limit(limit).
+		For("UPDATE SKIP LOCKED")
+
+	var mails []QueuedMail
+	_, err := r.db.NewUpdate().
+		Model(&mails).
+		Set("attempts = attempts + 1").
+		Set("next_attempt_at = ?", now.Add(lease)).
+		Where("id IN (?)", due).
+		Returning("*").
+		Exec(ctx, &mails)
+	r.metrics.Database.RecordQuery(ctx, "update", "mail_queue", time.Since(start), err)
+
+	if err != nil {
+		return nil, err
+	}
+	return mails, nil
+}
+
+func (r *repository) MarkSent(ctx context.Context, id int64, sentAt time.Time) error {
+	start := time.Now()
+	_, err := r.db.NewUpdate().
+		Model((*QueuedMail)(nil)).
+		Set("sent_at = ?", sentAt).
+		Set("last_error = ''").
+		Where("id = ?", id).
+		Exec(ctx)
+	r.metrics.Database.RecordQuery(ctx, "update", "mail_queue", time.Since(start), err)
+
+	return err
+}
+
+func (r *repository) Retry(ctx context.Context, id int64, next time.Time, lastError string) error {
+	start := time.Now()
+	_, err := r.db.NewUpdate().
+		Model((*QueuedMail)(nil)).
+		Set("next_attempt_at = ?", next).
+		Set("last_error = ?", lastError).
+		Where("id = ?", id).
+		Exec(ctx)
+	r.metrics.Database.RecordQuery(ctx, "update", "mail_queue", time.Since(start), err)
+
+	return err
+}
+
+func (r *repository) MarkFailed(ctx context.Context, id int64, failedAt time.Time, lastError string) error {
+	start := time.Now()
+	_, err := r.db.NewUpdate().
+		Model((*QueuedMail)(nil)).
+		Set("failed_at = ?", failedAt).
+		Set("last_error = ?", lastError).
+		Where("id = ?", id).
+		Exec(ctx)
+	r.metrics.Database.RecordQuery(ctx, "update", "mail_queue", time.Since(start), err)
+
+	return err
+}
+
+func (r *repository) Prune(ctx context.Context, before time.Time) (int, error) {
+	start := time.Now()
+	res, err := r.db.NewDelete().
+		Model((*QueuedMail)(nil)).
+		Where("sent_at < ? OR failed_at < ?", before, before).
+		Exec(ctx)
+	r.metrics.Database.RecordQuery(ctx, "delete", "mail_queue", time.Since(start), err)
+
+	if err != nil {
+		return 0, err
+	}
+	pruned, err := res.RowsAffected()
+	return int(pruned), err
+}
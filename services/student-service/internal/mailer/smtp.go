@@ -0,0 +1,154 @@
+package mailer
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"net/textproto"
+	"strconv"
+	"time"
+)
+
+// DefaultSMTPTimeout bounds a whole SMTP conversation
+const DefaultSMTPTimeout = 30 * time.Second
+
+var (
+	ErrStartTLSUnsupported = errors.New("mail server does not support STARTTLS")
+	ErrAuthUnsupported     = errors.New("mail server does not support AUTH")
+)
+
+// SMTPConfig configures an SMTPSender
+type SMTPConfig struct {
+	Host string
+	Port int
+	// Username and Password authenticate with AUTH PLAIN, which is only done
+	// over TLS or to localhost. No authentication without a username.
+	Username string
+	Password string
+	// From is the sender, e.g. "GRUD <no-reply@grud.dev>"
+	From string
+	// RequireStartTLS fails sends to servers that do not offer STARTTLS.
+	// Without it STARTTLS is still used when offered.
+	RequireStartTLS bool
+	// TLSConfig overrides the TLS configuration of STARTTLS; by default the
+	// server certificate is verified against Host
+	TLSConfig *tls.Config
+	// Timeout bounds each send; it defaults to DefaultSMTPTimeout
+	Timeout time.Duration
+}
+
+// SMTPSender sends each message over a new connection to an SMTP server
+type SMTPSender struct {
+	config SMTPConfig
+	from   *mail.Address
+}
+
+func NewSMTPSender(config SMTPConfig) (*SMTPSender, error) {
+	if config.Host == "" {
+		return nil, errors.New("mail server host is required")
+	}
+	if config.Port == 0 {
+		config.Port = 587
+	}
+	if config.Timeout == 0 {
+		config.Timeout = DefaultSMTPTimeout
+	}
+	if config.TLSConfig == nil {
+		config.TLSConfig = &tls.Config{ServerName: config.Host, MinVersion: tls.VersionTLS12}
+	}
+	from, err := mail.ParseAddress(config.From)
+	if err != nil {
+		return nil, fmt.Errorf("%w %q", ErrInvalidAddress, config.From)
+	}
+	return &SMTPSender{config: config, from: from}, nil
+}
+
+func (s *SMTPSender) Send(ctx context.Context, msg *Message) error {
+	data, err := msg.Encode(s.config.From, time.Now())
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, s.config.Timeout)
+	defer cancel()
+
+	addr := net.JoinHostPort(s.config.Host, strconv.Itoa(s.config.Port))
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "tcp", addr)
+	if err != nil {
+		return err
+	}
+	deadline, _ := ctx.Deadline()
+	if err := conn.SetDeadline(deadline); err != nil {
+		conn.Close()
+		return err
+	}
+
+	c, err := smtp.NewClient(conn, s.config.Host)
+	if err != nil {
+		conn.Close()
+		return err
+	}
+	defer c.Close()
+
+	if ok, _ := c.Extension("STARTTLS"); ok {
+		if err := c.StartTLS(s.config.TLSConfig); err != nil {
+			return err
+		}
+	} else if s.config.RequireStartTLS {
+		return ErrStartTLSUnsupported
+	}
+
+	if s.config.Username != "" {
+		if ok, _ := c.Extension("AUTH"); !ok {
+			return ErrAuthUnsupported
+		}
+		if err := c.Auth(smtp.PlainAuth("", s.config.Username, s.config.Password, s.config.Host)); err != nil {
+			return err
+		}
+	}
+
+	if err := c.Mail(s.from.Address); err != nil {
+		return err
+	}
+	for _, to := range msg.To {
+		addr, _ := mail.ParseAddress(to)
+		if err := c.Rcpt(addr.Address); err != nil {
+			return err
+		}
+	}
+	w, err := c.Data()
+	if err != nil {
+		return err
+	}
+	if _, err := w.Write(data); err != nil {
+		return err
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+	return c.Quit()
+}
+
+// Permanent tells whether sending a message failed in a way retrying cannot
+// fix: the message is invalid or the server rejected it with a 5xx reply.
+// Authentication failures are not permanent, they go away once the
+// credentials are fixed.
+func Permanent(err error) bool {
+	if errors.Is(err, ErrNoRecipients) || errors.Is(err, ErrInvalidAddress) || errors.Is(err, ErrInvalidMessage) {
+		return true
+	}
+	var reply *textproto.Error
+	if !errors.As(err, &reply) || reply.Code < 500 || reply.Code >= 600 {
+		return false
+	}
+	switch reply.Code {
+	case 530, 534, 535:
+		return false
+	}
+	return true
+}
@@ -0,0 +1,86 @@
+package mailer
+
+import (
+	"bytes"
+	"embed"
+	"errors"
+	"fmt"
+	htmltemplate "html/template"
+	"io/fs"
+	"strings"
+	texttemplate "text/template"
+)
+
+// Template names
+const (
+	TemplatePasswordSetup = "password_setup"
+	TemplateNotification  = "notification"
+)
+
+var ErrUnknownTemplate = errors.New("unknown mail template")
+
+//go:embed templates/*.tmpl
+var templateFS embed.FS
+
+// Templates renders messages. A template <name> is made of
+// <name>.subject.tmpl and <name>.txt.tmpl, executed with text/template, and
+// optionally <name>.html.tmpl, executed with html/template along with the
+// header and footer of layout.html.tmpl.
+type Templates struct {
+	text *texttemplate.Template
+	html *htmltemplate.Template
+}
+
+// DefaultTemplates parses the templates built into the service
+func DefaultTemplates() (*Templates, error) {
+	sub, err := fs.Sub(templateFS, "templates")
+	if err != nil {
+		return nil, err
+	}
+	return ParseTemplates(sub)
+}
+
+// ParseTemplates parses the templates in the root of fsys
+func ParseTemplates(fsys fs.FS) (*Templates, error) {
+	text, err := texttemplate.New("").Option("missingkey=error").ParseFS(fsys, "*.subject.tmpl", "*.txt.tmpl")
+	if err != nil {
+		return nil, err
+	}
+	html, err := htmltemplate.New("").Option("missingkey=error").ParseFS(fsys, "*.html.tmpl")
+	if err != nil {
+		return nil, err
+	}
+	return &Templates{text: text, html: html}, nil
+}
+
+// Render executes the template name with data into a message to to
+func (t *Templates) Render(name string, to []string, data interface{}) (*Message, error) {
+	subject := t.text.Lookup(name + ".subject.tmpl")
+	text := t.text.Lookup(name + ".txt.tmpl")
+	if subject == nil || text == nil {
+		return nil, fmt.Errorf("%w %q", ErrUnknownTemplate, name)
+	}
+
+	msg := &Message{To: to, Template: name}
+	var buf bytes.Buffer
+	if err := subject.Execute(&buf, data); err != nil {
+		return nil, err
+	}
+	// Subjects are a single line however the template is laid out
+	msg.Subject = strings.Join(strings.Fields(buf.String()), " ")
+
+	buf.Reset()
+	if err := text.Execute(&buf, data); err != nil {
+		return nil, err
+	}
+	msg.Text = buf.String()
+
+	if html := t.html.Lookup(name + ".html.tmpl"); html != nil {
+		buf.Reset()
+		if err := html.Execute(&buf, data); err != nil {
+			return nil, err
+		}
+		msg.HTML = buf.String()
+	}
+	return msg, nil
+}
@@ -1,8 +1,12 @@
 package message
 
 import (
+	"errors"
 	"log/slog"
 	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
 
 	"student-service/internal/auth"
 	"student-service/internal/metrics"
@@ -19,9 +23,14 @@ type Handler struct {
 }
 
 func NewHandler(service *Service, logger *slog.Logger, metrics *metrics.Metrics) *Handler {
+	validate := validator.New()
+	// Validation errors name fields the way clients send them
+	validate.RegisterTagNameFunc(func(field reflect.StructField) string {
+		return strings.Split(field.Tag.Get("json"), ",")[0]
+	})
 	return &Handler{
 		service:  service,
-		validate: validator.New(),
+		validate: validate,
 		logger:   logger,
 		metrics:  metrics,
 	}
@@ -29,6 +38,8 @@ func NewHandler(service *Service, logger *slog.Logger, metrics *metrics.Metrics)
 
 func (h *Handler) RegisterRoutes(router gin.IRouter) {
 	router.POST("/messages", h.SendMessage)
+	router.PUT("/messages/:id", h.EditMessage)
+	router.DELETE("/messages/:id", h.DeleteMessage)
 }
 
 func (h *Handler) SendMessage(c *gin.Context) {
@@ -49,15 +60,33 @@ func (h *Handler) SendMessage(c *gin.Context) {
 
 	// Validate request
 	if err := h.validate.Struct(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "message is required"})
+		c.JSON(http.StatusBadRequest, gin.H{"error": validationMessage(err)})
 		return
 	}
 
-	h.logger.InfoContext(c.Request.Context(), "sending message", "email", email, "message", req.Message)
+	// Only team, conversation and thread messages need to know who the
+	// sender is
+	var studentID int
+	if req.TeamID != 0 || req.ConversationID != 0 || req.ReplyTo != 0 {
+		if studentID, ok = auth.GetStudentID(c.Request.Context()); !ok {
+			h.logger.WarnContext(c.Request.Context(), "student ID not found in context")
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+			return
+		}
+	}
+
+	h.logger.InfoContext(c.Request.Context(), "sending message", "email", email, "team_id", req.TeamID, "message", req.Message)
 
 	// Send message via service
-	if err := h.service.SendMessage(c.Request.Context(), email, req.Message); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to send message"})
+	if err := h.service.SendMessage(c.Request.Context(), email, studentID, req); err != nil {
+		switch {
+		case errors.Is(err, ErrNotTeamMember), errors.Is(err, ErrCannotPost):
+			c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+		case errors.Is(err, ErrDirectoryUnavailable):
+			c.JSON(http.StatusServiceUnavailable, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to send message"})
+		}
 		return
 	}
 
@@ -69,3 +98,92 @@ func (h *Handler) SendMessage(c *gin.Context) {
 		"message": "message sent successfully",
 	})
 }
+
+// EditMessage asks for a new text of one of the current student's messages.
+// The edit is applied asynchronously, so it is accepted rather than done.
+func (h *Handler) EditMessage(c *gin.Context) {
+	email, messageID, ok := h.ownMessage(c)
+	if !ok {
+		return
+	}
+
+	var req EditMessageRequest
+	if err := c.ShouldBindJSON(&req); err != nil || h.validate.Struct(&req) != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "message is required"})
+		return
+	}
+
+	if err := h.service.EditMessage(c.Request.Context(), email, messageID, req); err != nil {
+		h.changeFailed(c, err, "failed to edit message")
+		return
+	}
+
+	c.JSON(http.StatusAccepted, gin.H{
+		"status":  "accepted",
+		"message": "message edit sent",
+	})
+}
+
+// DeleteMessage asks for one of the current student's messages to be
+// deleted, leaving a tombstone. Like an edit, it is applied asynchronously.
+func (h *Handler) DeleteMessage(c *gin.Context) {
+	email, messageID, ok := h.ownMessage(c)
+	if !ok {
+		return
+	}
+
+	if err := h.service.DeleteMessage(c.Request.Context(), email, messageID); err != nil {
+		h.changeFailed(c, err, "failed to delete message")
+		return
+	}
+
+	c.JSON(http.StatusAccepted, gin.H{
+		"status":  "accepted",
+		"message": "message delete sent",
+	})
+}
+
+// ownMessage reads the current student's email and the message ID of the
+// path, writing the error response when either is missing
+func (h *Handler) ownMessage(c *gin.Context) (string, int, bool) {
+	email, ok := auth.GetEmail(c.Request.Context())
+	if !ok {
+		h.logger.WarnContext(c.Request.Context(), "email not found in context")
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return "", 0, false
+	}
+
+	messageID, err := strconv.Atoi(c.Param("id"))
+	if err != nil || messageID <= 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid message ID"})
+		return "", 0, false
+	}
+	return email, messageID, true
+}
+
+func (h *Handler) changeFailed(c *gin.Context, err error, message string) {
+	if errors.Is(err, ErrEditsUnavailable) {
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusInternalServerError, gin.H{"error": message})
+}
+
+// validationMessage describes the first rule a send request breaks
+func validationMessage(err error) string {
+	var invalid validator.ValidationErrors
+	if !errors.As(err, &invalid) || len(invalid) == 0 {
+		return "invalid request"
+	}
+	fe := invalid[0]
+	switch fe.Tag() {
+	case "required":
+		return fe.Field() + " is required"
+	case "required_with":
+		return "teamId and projectId must be given together"
+	case "gt":
+		return fe.Field() + " must be positive"
+	default:
+		return "invalid " + fe.Field()
+	}
+}
@@ -32,7 +32,7 @@ func setupTestWithNATSContainer(t *testing.T, natsContainer *testnats.NATSContai
 	producer, err := messaging.NewProducer(natsContainer.URL, subject, logger)
 	require.NoError(t, err)
 
-	service := message.NewService(producer, logger)
+	service := message.NewService(producer, nil, nil, nil, logger)
 	mockMetrics := metrics.NewMock()
 	handler := message.NewHandler(service, logger, mockMetrics)
 
@@ -186,3 +186,160 @@ func TestMessageHandlerWithNATSContainer(t *testing.T) {
 		assert.Equal(t, http.StatusBadRequest, w.Code)
 	})
 }
+
+// recordingProducer keeps the events it is asked to send
+type recordingProducer struct {
+	events []interface{}
+}
+
+func (p *recordingProducer) SendMessage(ctx context.Context, value interface{}) error {
+	p.events = append(p.events, value)
+	return nil
+}
+
+func (p *recordingProducer) Close() error { return nil }
+
+// directory lets only student 1 post to team 5 of project 2 and to
+// conversation 4, replying to message 9 in it
+type directory struct{}
+
+func (directory) IsTeamMember(ctx context.Context, projectID, teamID, studentID int) (bool, error) {
+	return projectID == 2 && teamID == 5 && studentID == 1, nil
+}
+
+func (directory) CanPost(ctx context.Context, email string, studentID, conversationID, replyTo int) (bool, error) {
+	return email == "test@example.com" && studentID == 1 && conversationID == 4 && (replyTo == 0 || replyTo == 9), nil
+}
+
+func TestSendTeamMessage(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	logger := slog.New(slog.NewTextHandler(os.Stderr, nil))
+
+	send := func(service *message.Service, studentID int, payload gin.H) *httptest.ResponseRecorder {
+		router := gin.New()
+		message.NewHandler(service, logger, metrics.NewMock()).RegisterRoutes(router)
+
+		body, err := json.Marshal(payload)
+		require.NoError(t, err)
+		req := httptest.NewRequest(http.MethodPost, "/messages", bytes.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		ctx := context.WithValue(req.Context(), auth.EmailKey, "test@example.com")
+		if studentID != 0 {
+			ctx = context.WithValue(ctx, auth.StudentIDKey, studentID)
+		}
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req.WithContext(ctx))
+		return w
+	}
+
+	t.Run("Member", func(t *testing.T) {
+		producer := &recordingProducer{}
+		w := send(message.NewService(producer, nil, nil, directory{}, logger), 1, gin.H{"message": "Hi team", "projectId": 2, "teamId": 5})
+
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		require.Len(t, producer.events, 1)
+		assert.Equal(t, message.MessageEvent{Email: "test@example.com", StudentID: 1, Message: "Hi team", TeamID: 5}, producer.events[0])
+	})
+
+	t.Run("NotMember", func(t *testing.T) {
+		producer := &recordingProducer{}
+		w := send(message.NewService(producer, nil, nil, directory{}, logger), 3, gin.H{"message": "Hi team", "projectId": 2, "teamId": 5})
+
+		assert.Equal(t, http.StatusForbidden, w.Code)
+		assert.Empty(t, producer.events)
+	})
+
+	t.Run("TeamWithoutProject", func(t *testing.T) {
+		producer := &recordingProducer{}
+		w := send(message.NewService(producer, nil, nil, directory{}, logger), 1, gin.H{"message": "Hi team", "teamId": 5})
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.JSONEq(t, `{"error": "teamId and projectId must be given together"}`, w.Body.String())
+		assert.Empty(t, producer.events)
+	})
+
+	t.Run("Reply", func(t *testing.T) {
+		producer := &recordingProducer{}
+		w := send(message.NewService(producer, nil, nil, directory{}, logger), 1, gin.H{"message": "Agreed", "conversationId": 4, "replyTo": 9})
+
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		require.Len(t, producer.events, 1)
+		assert.Equal(t, message.MessageEvent{Email: "test@example.com", StudentID: 1, Message: "Agreed", ConversationID: 4, ReplyTo: 9}, producer.events[0])
+
+		w = send(message.NewService(producer, nil, nil, directory{}, logger), 1, gin.H{"message": "Agreed", "replyTo": -1})
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.JSONEq(t, `{"error": "replyTo must be positive"}`, w.Body.String())
+
+		w = send(message.NewService(producer, nil, nil, directory{}, logger), 1, gin.H{"replyTo": 9})
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.JSONEq(t, `{"error": "message is required"}`, w.Body.String())
+	})
+
+	t.Run("NotParticipant", func(t *testing.T) {
+		producer := &recordingProducer{}
+		w := send(message.NewService(producer, nil, nil, directory{}, logger), 3, gin.H{"message": "Agreed", "conversationId": 4})
+		assert.Equal(t, http.StatusForbidden, w.Code)
+
+		w = send(message.NewService(producer, nil, nil, directory{}, logger), 1, gin.H{"message": "Agreed", "replyTo": 8})
+		assert.Equal(t, http.StatusForbidden, w.Code)
+		assert.Empty(t, producer.events)
+	})
+
+	t.Run("NoDirectory", func(t *testing.T) {
+		producer := &recordingProducer{}
+		w := send(message.NewService(producer, nil, nil, nil, logger), 1, gin.H{"message": "Hi team", "projectId": 2, "teamId": 5})
+		assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+
+		w = send(message.NewService(producer, nil, nil, nil, logger), 1, gin.H{"message": "Agreed", "conversationId": 4})
+		assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+		assert.Empty(t, producer.events)
+	})
+}
+
+func TestEditMessage(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	logger := slog.New(slog.NewTextHandler(os.Stderr, nil))
+
+	edits, deletes := &recordingProducer{}, &recordingProducer{}
+	router := gin.New()
+	message.NewHandler(message.NewService(&recordingProducer{}, edits, deletes, nil, logger), logger, metrics.NewMock()).RegisterRoutes(router)
+
+	do := func(email, method, target string, payload interface{}) *httptest.ResponseRecorder {
+		body, err := json.Marshal(payload)
+		require.NoError(t, err)
+		req := httptest.NewRequest(method, target, bytes.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		if email != "" {
+			req = req.WithContext(context.WithValue(req.Context(), auth.EmailKey, email))
+		}
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	w := do("test@example.com", http.MethodPut, "/messages/7", gin.H{"message": "Fixed"})
+	require.Equal(t, http.StatusAccepted, w.Code, w.Body.String())
+	require.Len(t, edits.events, 1)
+	edit := edits.events[0].(message.EditEvent)
+	assert.Equal(t, "test@example.com", edit.Email)
+	assert.Equal(t, 7, edit.MessageID)
+	assert.Equal(t, "Fixed", edit.Message)
+
+	w = do("test@example.com", http.MethodDelete, "/messages/7", nil)
+	require.Equal(t, http.StatusAccepted, w.Code, w.Body.String())
+	require.Len(t, deletes.events, 1)
+	assert.Equal(t, 7, deletes.events[0].(message.DeleteEvent).MessageID)
+
+	w = do("test@example.com", http.MethodPut, "/messages/7", gin.H{"message": ""})
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	w = do("test@example.com", http.MethodDelete, "/messages/x", nil)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	w = do("", http.MethodDelete, "/messages/7", nil)
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+
+	// Without producers for them, messages cannot be changed
+	router = gin.New()
+	message.NewHandler(message.NewService(&recordingProducer{}, nil, nil, nil, logger), logger, metrics.NewMock()).RegisterRoutes(router)
+	w = do("test@example.com", http.MethodDelete, "/messages/7", nil)
+	assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+}
@@ -1,10 +1,46 @@
 package message
 
+import "time"
+
+// SendMessageRequest is a message to send. Setting teamId posts it to that
+// team of the project, which the sender must belong to. Setting
+// conversationId adds it to that conversation and replyTo makes it a reply to
+// that message, in the conversation of the message replied to.
 type SendMessageRequest struct {
-	Message string `json:"message" validate:"required"`
+	Message        string `json:"message" validate:"required"`
+	ProjectID      int    `json:"projectId" validate:"required_with=TeamID,omitempty,gt=0"`
+	TeamID         int    `json:"teamId" validate:"required_with=ProjectID,omitempty,gt=0"`
+	ConversationID int    `json:"conversationId" validate:"omitempty,gt=0"`
+	ReplyTo        int    `json:"replyTo" validate:"omitempty,gt=0"`
 }
 
 type MessageEvent struct {
-	Email   string `json:"email"`
-	Message string `json:"message"`
+	Email          string `json:"email"`
+	StudentID      int    `json:"studentId,omitempty"`
+	Message        string `json:"message"`
+	TeamID         int    `json:"teamId,omitempty"`
+	ConversationID int    `json:"conversationId,omitempty"`
+	ReplyTo        int    `json:"replyTo,omitempty"`
+}
+
+// EditMessageRequest replaces the text of a message
+type EditMessageRequest struct {
+	Message string `json:"message" validate:"required"`
+}
+
+// EditEvent asks project-service to replace the text of a message. It is
+// applied only if Email wrote the message and project-service handles it
+// within the edit window after it was sent.
+type EditEvent struct {
+	Email     string `json:"email"`
+	MessageID int    `json:"messageId"`
+	Message   string `json:"message"`
+}
+
+// DeleteEvent asks project-service to delete a message, leaving a tombstone.
+// It is applied only if Email wrote the message.
+type DeleteEvent struct {
+	Email     string    `json:"email"`
+	MessageID int       `json:"messageId"`
+	DeletedAt time.Time `json:"deletedAt"`
 }
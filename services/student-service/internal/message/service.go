@@ -2,7 +2,16 @@ package message
 
 import (
 	"context"
+	"errors"
 	"log/slog"
+	"time"
+)
+
+var (
+	ErrNotTeamMember        = errors.New("sender is not a member of the team")
+	ErrCannotPost           = errors.New("sender cannot post to the conversation or thread")
+	ErrDirectoryUnavailable = errors.New("membership cannot be checked")
+	ErrEditsUnavailable     = errors.New("messages cannot be edited or deleted")
 )
 
 // Producer interface for messaging (NATS/Kafka)
@@ -11,25 +20,76 @@ type Producer interface {
 	Close() error
 }
 
+// Directory tells whether a student belongs to a team of a project and
+// whether they may post to a conversation or reply to a message
+type Directory interface {
+	IsTeamMember(ctx context.Context, projectID, teamID, studentID int) (bool, error)
+	CanPost(ctx context.Context, email string, studentID, conversationID, replyTo int) (bool, error)
+}
+
 type Service struct {
-	producer Producer
-	logger   *slog.Logger
+	producer  Producer
+	edits     Producer
+	deletes   Producer
+	directory Directory
+	logger    *slog.Logger
 }
 
-func NewService(producer Producer, logger *slog.Logger) *Service {
+// NewService creates the message service. Messages are sent through producer,
+// edited through edits and deleted through deletes; without edits and
+// deletes they cannot be changed. directory may be nil, in which case
+// messages can neither be posted to a team nor to a conversation or thread.
+func NewService(producer, edits, deletes Producer, directory Directory, logger *slog.Logger) *Service {
 	return &Service{
-		producer: producer,
-		logger:   logger,
+		producer:  producer,
+		edits:     edits,
+		deletes:   deletes,
+		directory: directory,
+		logger:    logger,
 	}
 }
 
-func (s *Service) SendMessage(ctx context.Context, email string, message string) error {
+// SendMessage publishes a message from email. A message for a team is only
+// published if studentID, the sender, is a member of it, and one for a
+// conversation or thread only if project-service would accept it, so that
+// it is refused here rather than dropped after the fact.
+func (s *Service) SendMessage(ctx context.Context, email string, studentID int, req SendMessageRequest) error {
+	if (req.TeamID != 0 || req.ConversationID != 0 || req.ReplyTo != 0) && s.directory == nil {
+		return ErrDirectoryUnavailable
+	}
+	if req.TeamID != 0 {
+		member, err := s.directory.IsTeamMember(ctx, req.ProjectID, req.TeamID, studentID)
+		if err != nil {
+			s.logger.ErrorContext(ctx, "failed to check team membership", "error", err, "team_id", req.TeamID)
+			return err
+		}
+		if !member {
+			return ErrNotTeamMember
+		}
+	}
+	if req.ConversationID != 0 || req.ReplyTo != 0 {
+		allowed, err := s.directory.CanPost(ctx, email, studentID, req.ConversationID, req.ReplyTo)
+		if err != nil {
+			s.logger.ErrorContext(ctx, "failed to check post", "error", err,
+				"conversation_id", req.ConversationID, "reply_to", req.ReplyTo)
+			return err
+		}
+		if !allowed {
+			return ErrCannotPost
+		}
+	}
+
 	event := MessageEvent{
-		Email:   email,
-		Message: message,
+		Email:          email,
+		StudentID:      studentID,
+		Message:        req.Message,
+		TeamID:         req.TeamID,
+		ConversationID: req.ConversationID,
+		ReplyTo:        req.ReplyTo,
 	}
 
-	s.logger.InfoContext(ctx, "sending message to NATS", "email", email)
+	s.logger.InfoContext(ctx, "sending message to NATS", "email", email, "team_id", req.TeamID,
+		"conversation_id", req.ConversationID, "reply_to", req.ReplyTo)
 
 	if err := s.producer.SendMessage(ctx, event); err != nil {
 		s.logger.ErrorContext(ctx, "failed to send message", "error", err)
@@ -38,3 +98,47 @@ func (s *Service) SendMessage(ctx context.Context, email string, message string)
 
 	return nil
 }
+
+// EditMessage publishes a new text for a message of email. Project-service
+// drops it if email is not the author or the edit window has passed.
+func (s *Service) EditMessage(ctx context.Context, email string, messageID int, req EditMessageRequest) error {
+	if s.edits == nil {
+		return ErrEditsUnavailable
+	}
+
+	event := EditEvent{
+		Email:     email,
+		MessageID: messageID,
+		Message:   req.Message,
+	}
+
+	s.logger.InfoContext(ctx, "sending message edit to NATS", "email", email, "message_id", messageID)
+
+	if err := s.edits.SendMessage(ctx, event); err != nil {
+		s.logger.ErrorContext(ctx, "failed to send message edit", "error", err)
+		return err
+	}
+	return nil
+}
+
+// DeleteMessage publishes the deletion of a message of email. Project-service
+// drops it if email is not the author.
+func (s *Service) DeleteMessage(ctx context.Context, email string, messageID int) error {
+	if s.deletes == nil {
+		return ErrEditsUnavailable
+	}
+
+	event := DeleteEvent{
+		Email:     email,
+		MessageID: messageID,
+		DeletedAt: time.Now().UTC(),
+	}
+
+	s.logger.InfoContext(ctx, "sending message delete to NATS", "email", email, "message_id", messageID)
+
+	if err := s.deletes.SendMessage(ctx, event); err != nil {
+		s.logger.ErrorContext(ctx, "failed to send message delete", "error", err)
+		return err
+	}
+	return nil
+}
@@ -31,6 +31,16 @@ func NewProducer(url string, subject string, logger *slog.Logger) (*Producer, er
 	}, nil
 }
 
+// WithSubject returns a producer that publishes to subject over the same
+// connection. Closing either producer closes the shared connection
+func (p *Producer) WithSubject(subject string) *Producer {
+	return &Producer{
+		conn:    p.conn,
+		subject: subject,
+		logger:  p.logger,
+	}
+}
+
 func (p *Producer) SendMessage(ctx context.Context, value interface{}) error {
 	valueBytes, err := json.Marshal(value)
 	if err != nil {
@@ -54,9 +64,11 @@ func (p *Producer) SendMessage(ctx context.Context, value interface{}) error {
 	return nil
 }
 
+// Close sends whatever is still buffered and closes the connection
 func (p *Producer) Close() error {
+	err := p.conn.Flush()
 	p.conn.Close()
-	return nil
+	return err
 }
 
 // HealthCheck verifies NATS connection is healthy
@@ -0,0 +1,255 @@
+package notification
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"student-service/internal/student"
+
+	"github.com/nats-io/nats.go"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+)
+
+// QueueGroup makes a single replica turn each event into notifications
+const QueueGroup = "student-service-notifications"
+
+// Subjects names the NATS subjects notifications are made from. Empty
+// subjects are not consumed.
+type Subjects struct {
+	// Messages carries message.created events
+	Messages string
+	// Reminders carries due date reminders
+	Reminders string
+	// Projects carries project changes, of which students are notified of
+	// being added to a project
+	Projects string
+	// Grades carries submission.graded events
+	Grades string
+}
+
+// Conn is the part of a NATS connection the consumer uses. It is implemented
+// by *nats.Conn.
+type Conn interface {
+	QueueSubscribe(subject, queue string, cb nats.MsgHandler) (*nats.Subscription, error)
+}
+
+// Students looks students up by email. It is implemented by
+// student.Repository.
+type Students interface {
+	GetByEmail(ctx context.Context, email string) (*student.Student, error)
+}
+
+// Consumer turns the events other services publish into notifications
+type Consumer struct {
+	conn     Conn
+	subjects Subjects
+	service  Service
+	students Students
+	logger   *slog.Logger
+	subs     []*nats.Subscription
+}
+
+func NewConsumer(conn Conn, subjects Subjects, service Service, students Students, logger *slog.Logger) *Consumer {
+	return &Consumer{
+		conn:     conn,
+		subjects: subjects,
+		service:  service,
+		students: students,
+		logger:   logger,
+	}
+}
+
+// Start subscribes to the subjects
+func (c *Consumer) Start() error {
+	handlers := []struct {
+		subject string
+		notice  func(ctx context.Context, data []byte) (Notice, error)
+	}{
+		{c.subjects.Messages, c.messageNotice},
+		{c.subjects.Reminders, reminderNotice},
+		{c.subjects.Projects, projectNotice},
+		{c.subjects.Grades, gradeNotice},
+	}
+	for _, h := range handlers {
+		if h.subject == "" {
+			continue
+		}
+		notice := h.notice
+		sub, err := c.conn.QueueSubscribe(h.subject, QueueGroup, func(msg *nats.Msg) {
+			c.handle(msg, notice)
+		})
+		if err != nil {
+			c.Close()
+			return err
+		}
+		c.subs = append(c.subs, sub)
+		c.logger.Info("consuming notification events", "subject", h.subject)
+	}
+	return nil
+}
+
+// Close stops consuming
+func (c *Consumer) Close() {
+	for _, sub := range c.subs {
+		if err := sub.Unsubscribe(); err != nil {
+			c.logger.Debug("failed to unsubscribe notification events", "subject", sub.Subject, "error", err)
+		}
+	}
+	c.subs = nil
+}
+
+func (c *Consumer) handle(msg *nats.Msg, notice func(ctx context.Context, data []byte) (Notice, error)) {
+	ctx := otel.GetTextMapPropagator().Extract(context.Background(), propagation.HeaderCarrier(msg.Header))
+
+	n, err := notice(ctx, msg.Data)
+	if err != nil {
+		c.logger.WarnContext(ctx, "dropping malformed event", "subject", msg.Subject, "error", err)
+		return
+	}
+	if len(n.StudentIDs) == 0 {
+		return
+	}
+	if _, err := c.service.Notify(ctx, n); err != nil {
+		c.logger.ErrorContext(ctx, "failed to store notifications", "subject", msg.Subject, "type", n.Type, "error", err)
+	}
+}
+
+// messageNotice notifies everyone a new message is addressed to but its
+// sender. The text is not quoted: a stored copy would outlive the message
+// being edited or deleted.
+func (c *Consumer) messageNotice(ctx context.Context, data []byte) (Notice, error) {
+	var event struct {
+		Message *struct {
+			ID             int    `json:"id"`
+			Email          string `json:"email"`
+			TeamID         int    `json:"teamId"`
+			ConversationID int    `json:"conversationId"`
+		} `json:"message"`
+		Recipients []string `json:"recipients"`
+		StudentIDs []int    `json:"studentIds"`
+	}
+	if err := json.Unmarshal(data, &event); err != nil {
+		return Notice{}, err
+	}
+	if event.Message == nil {
+		return Notice{}, errors.New("event has no message")
+	}
+	m := event.Message
+
+	studentIDs := event.StudentIDs
+	for _, email := range event.Recipients {
+		if strings.EqualFold(email, m.Email) {
+			continue
+		}
+		if id, ok := c.lookup(ctx, email); ok {
+			studentIDs = append(studentIDs, id)
+		}
+	}
+	if sender, ok := c.lookup(ctx, m.Email); ok {
+		studentIDs = without(studentIDs, sender)
+	}
+
+	n := Notice{
+		Type:       TypeMessage,
+		StudentIDs: studentIDs,
+		Title:      fmt.Sprintf("New message from %s", m.Email),
+		Data:       map[string]interface{}{"messageId": m.ID},
+	}
+	if m.ConversationID != 0 {
+		n.Data["conversationId"] = m.ConversationID
+	}
+	if m.TeamID != 0 {
+		n.Data["teamId"] = m.TeamID
+	}
+	return n, nil
+}
+
+func (c *Consumer) lookup(ctx context.Context, email string) (int, bool) {
+	s, err := c.students.GetByEmail(ctx, email)
+	if err != nil {
+		if !errors.Is(err, student.ErrStudentNotFound) {
+			c.logger.ErrorContext(ctx, "failed to look up notification recipient", "email", email, "error", err)
+		}
+		return 0, false
+	}
+	return s.ID, true
+}
+
+func reminderNotice(ctx context.Context, data []byte) (Notice, error) {
+	var event struct {
+		ProjectID  int       `json:"projectId"`
+		Name       string    `json:"name"`
+		DueDate    time.Time `json:"dueDate"`
+		StudentIDs []int     `json:"studentIds"`
+	}
+	if err := json.Unmarshal(data, &event); err != nil {
+		return Notice{}, err
+	}
+	return Notice{
+		Type:       TypeReminder,
+		StudentIDs: event.StudentIDs,
+		Title:      fmt.Sprintf("%s is due soon", event.Name),
+		Body:       fmt.Sprintf("Due %s", event.DueDate.UTC().Format("Mon, 02 Jan 2006 15:04 MST")),
+		Data:       map[string]interface{}{"projectId": event.ProjectID, "dueDate": event.DueDate},
+	}, nil
+}
+
+// projectNotice notifies a student added to a project; other changes are
+// left to the stream
+func projectNotice(ctx context.Context, data []byte) (Notice, error) {
+	var event struct {
+		Type      string `json:"type"`
+		ProjectID int    `json:"projectId"`
+		Name      string `json:"name"`
+		MemberID  int    `json:"memberId"`
+	}
+	if err := json.Unmarshal(data, &event); err != nil {
+		return Notice{}, err
+	}
+	if event.Type != "member_added" || event.MemberID <= 0 {
+		return Notice{}, nil
+	}
+	return Notice{
+		Type:       TypeProject,
+		StudentIDs: []int{event.MemberID},
+		Title:      fmt.Sprintf("You were added to %s", event.Name),
+		Data:       map[string]interface{}{"projectId": event.ProjectID},
+	}, nil
+}
+
+func gradeNotice(ctx context.Context, data []byte) (Notice, error) {
+	var event struct {
+		SubmissionID int     `json:"submissionId"`
+		ProjectID    int     `json:"projectId"`
+		Version      int     `json:"version"`
+		Score        float64 `json:"score"`
+		MaxScore     float64 `json:"maxScore"`
+		StudentIDs   []int   `json:"studentIds"`
+	}
+	if err := json.Unmarshal(data, &event); err != nil {
+		return Notice{}, err
+	}
+	return Notice{
+		Type:       TypeGrade,
+		StudentIDs: event.StudentIDs,
+		Title:      "Your submission was graded",
+		Body:       fmt.Sprintf("Version %d scored %g out of %g", event.Version, event.Score, event.MaxScore),
+		Data:       map[string]interface{}{"projectId": event.ProjectID, "submissionId": event.SubmissionID},
+	}, nil
+}
+
+func without(ids []int, id int) []int {
+	kept := ids[:0:0]
+	for _, other := range ids {
+		if other != id {
+			kept = append(kept, other)
+		}
+	}
+	return kept
+}
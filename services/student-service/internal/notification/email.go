@@ -0,0 +1,49 @@
+package notification
+
+import (
+	"context"
+
+	"student-service/internal/mailer"
+	"student-service/internal/student"
+)
+
+// EmailTypes are the types of notification that are mailed as well as
+// stored and pushed
+var EmailTypes = []Type{TypeReminder}
+
+// StudentsByID looks students up by ID. It is implemented by
+// student.Repository.
+type StudentsByID interface {
+	GetByID(ctx context.Context, id int) (*student.Student, error)
+}
+
+// Mailer mails notifications to their students
+type Mailer struct {
+	sender    mailer.Sender
+	templates *mailer.Templates
+	students  StudentsByID
+}
+
+func NewMailer(sender mailer.Sender, templates *mailer.Templates, students StudentsByID) *Mailer {
+	return &Mailer{sender: sender, templates: templates, students: students}
+}
+
+func (m *Mailer) Email(ctx context.Context, n *Notification) error {
+	s, err := m.students.GetByID(ctx, n.StudentID)
+	if err != nil {
+		return err
+	}
+	msg, err := m.templates.Render(mailer.TemplateNotification, []string{s.Email}, struct {
+		Name  string
+		Title string
+		Body  string
+	}{
+		Name:  s.FirstName,
+		Title: n.Title,
+		Body:  n.Body,
+	})
+	if err != nil {
+		return err
+	}
+	return m.sender.Send(ctx, msg)
+}
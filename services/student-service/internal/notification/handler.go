@@ -0,0 +1,165 @@
+package notification
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"student-service/internal/auth"
+
+	"github.com/gin-gonic/gin"
+)
+
+type Handler struct {
+	service Service
+	logger  *slog.Logger
+}
+
+func NewHandler(service Service, logger *slog.Logger) *Handler {
+	return &Handler{
+		service: service,
+		logger:  logger,
+	}
+}
+
+func (h *Handler) RegisterRoutes(router gin.IRouter) {
+	router.GET("/notifications", h.ListNotifications)
+	router.POST("/notifications/read", h.MarkAllRead)
+	router.POST("/notifications/:id/read", h.MarkRead)
+	router.GET("/notifications/preferences", h.GetPreferences)
+	router.PUT("/notifications/preferences", h.UpdatePreferences)
+}
+
+// ListNotifications returns a page of the current student's notifications,
+// newest first. It accepts unread=true, type, limit and cursor.
+func (h *Handler) ListNotifications(c *gin.Context) {
+	studentID, ok := h.studentID(c)
+	if !ok {
+		return
+	}
+
+	var f ListFilter
+	if unread := c.Query("unread"); unread != "" {
+		var err error
+		if f.UnreadOnly, err = strconv.ParseBool(unread); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid unread"})
+			return
+		}
+	}
+	f.Type = Type(c.Query("type"))
+
+	var limit int
+	if l := c.Query("limit"); l != "" {
+		var err error
+		if limit, err = strconv.Atoi(l); err != nil || limit <= 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid limit"})
+			return
+		}
+	}
+
+	page, err := h.service.List(c.Request.Context(), studentID, f, limit, c.Query("cursor"))
+	if err != nil {
+		h.fail(c, err, "failed to list notifications")
+		return
+	}
+
+	c.JSON(http.StatusOK, page)
+}
+
+func (h *Handler) MarkRead(c *gin.Context) {
+	studentID, ok := h.studentID(c)
+	if !ok {
+		return
+	}
+
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil || id <= 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid notification ID"})
+		return
+	}
+
+	n, err := h.service.MarkRead(c.Request.Context(), studentID, id)
+	if err != nil {
+		h.fail(c, err, "failed to mark notification read")
+		return
+	}
+
+	c.JSON(http.StatusOK, n)
+}
+
+func (h *Handler) MarkAllRead(c *gin.Context) {
+	studentID, ok := h.studentID(c)
+	if !ok {
+		return
+	}
+
+	marked, err := h.service.MarkAllRead(c.Request.Context(), studentID)
+	if err != nil {
+		h.fail(c, err, "failed to mark notifications read")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"marked": marked})
+}
+
+// GetPreferences returns whether the current student wants each type of
+// notification
+func (h *Handler) GetPreferences(c *gin.Context) {
+	studentID, ok := h.studentID(c)
+	if !ok {
+		return
+	}
+
+	prefs, err := h.service.Preferences(c.Request.Context(), studentID)
+	if err != nil {
+		h.fail(c, err, "failed to get notification preferences")
+		return
+	}
+
+	c.JSON(http.StatusOK, prefs)
+}
+
+// UpdatePreferences turns types of notification on or off. The body maps
+// types to whether they are enabled; types it leaves out are unchanged.
+func (h *Handler) UpdatePreferences(c *gin.Context) {
+	studentID, ok := h.studentID(c)
+	if !ok {
+		return
+	}
+
+	var req map[Type]bool
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request"})
+		return
+	}
+
+	prefs, err := h.service.UpdatePreferences(c.Request.Context(), studentID, req)
+	if err != nil {
+		h.fail(c, err, "failed to update notification preferences")
+		return
+	}
+
+	c.JSON(http.StatusOK, prefs)
+}
+
+func (h *Handler) studentID(c *gin.Context) (int, bool) {
+	studentID, ok := auth.GetStudentID(c.Request.Context())
+	if !ok {
+		h.logger.WarnContext(c.Request.Context(), "student ID not found in context")
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+	}
+	return studentID, ok
+}
+
+func (h *Handler) fail(c *gin.Context, err error, msg string) {
+	switch {
+	case errors.Is(err, ErrNotificationNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, ErrInvalidInput), errors.Is(err, ErrInvalidPageToken), errors.Is(err, ErrUnknownType):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		h.logger.ErrorContext(c.Request.Context(), msg, "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": msg})
+	}
+}
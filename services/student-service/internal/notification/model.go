@@ -0,0 +1,77 @@
+package notification
+
+import (
+	"time"
+
+	"github.com/uptrace/bun"
+)
+
+// Type is what a notification is about. Students turn notifications on and
+// off per type.
+type Type string
+
+const (
+	TypeMessage  Type = "message"
+	TypeReminder Type = "reminder"
+	TypeProject  Type = "project"
+	TypeGrade    Type = "grade"
+)
+
+// Types lists every notification type
+var Types = []Type{TypeMessage, TypeReminder, TypeProject, TypeGrade}
+
+func (t Type) Valid() bool {
+	switch t {
+	case TypeMessage, TypeReminder, TypeProject, TypeGrade:
+		return true
+	}
+	return false
+}
+
+// Notification tells a student that something happened. Data identifies what
+// it is about, such as the projectId or messageId.
+type Notification struct {
+	bun.BaseModel `bun:"table:notifications,alias:n"`
+
+	ID        int64                  `bun:"id,pk,autoincrement" json:"id"`
+	StudentID int                    `bun:"student_id,notnull" json:"-"`
+	Type      Type                   `bun:"type,notnull" json:"type"`
+	Title     string                 `bun:"title,notnull" json:"title"`
+	Body      string                 `bun:"body,notnull,default:''" json:"body"`
+	Data      map[string]interface{} `bun:"data,type:jsonb,nullzero" json:"data,omitempty"`
+	CreatedAt time.Time              `bun:"created_at,notnull,default:current_timestamp" json:"createdAt"`
+	ReadAt    *time.Time             `bun:"read_at" json:"readAt,omitempty"`
+}
+
+// Preference records whether a student wants notifications of a type. Types
+// without one are enabled.
+type Preference struct {
+	bun.BaseModel `bun:"table:notification_preferences,alias:np"`
+
+	StudentID int  `bun:"student_id,pk"`
+	Type      Type `bun:"type,pk"`
+	Enabled   bool `bun:"enabled,notnull"`
+}
+
+// Notice is a notification to be sent to several students
+type Notice struct {
+	Type       Type
+	StudentIDs []int
+	Title      string
+	Body       string
+	Data       map[string]interface{}
+}
+
+// ListFilter narrows List. Zero values mean "no filter".
+type ListFilter struct {
+	UnreadOnly bool
+	Type       Type
+}
+
+// Page is a page of a student's notifications, newest first, with the number
+// of notifications the student has not read
+type Page struct {
+	Items      []Notification `json:"items"`
+	NextCursor string         `json:"nextCursor,omitempty"`
+	Unread     int            `json:"unread"`
+}
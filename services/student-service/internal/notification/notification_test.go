@@ -0,0 +1,438 @@
+package notification_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"student-service/internal/auth"
+	"student-service/internal/mailer"
+	"student-service/internal/notification"
+	"student-service/internal/student"
+
+	"github.com/gin-gonic/gin"
+	"github.com/nats-io/nats.go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeBus delivers published events to the handlers subscribed to the subject
+type fakeBus struct {
+	handlers map[string]nats.MsgHandler
+}
+
+func (b *fakeBus) QueueSubscribe(subject, queue string, cb nats.MsgHandler) (*nats.Subscription, error) {
+	b.handlers[subject] = cb
+	return &nats.Subscription{Subject: subject}, nil
+}
+
+func (b *fakeBus) publish(t *testing.T, subject string, value interface{}) {
+	data, err := json.Marshal(value)
+	require.NoError(t, err)
+	b.handlers[subject](&nats.Msg{Subject: subject, Data: data})
+}
+
+// fakeStudents knows alice as student 4 and bob as student 7
+type fakeStudents struct{}
+
+func (fakeStudents) GetByEmail(ctx context.Context, email string) (*student.Student, error) {
+	switch email {
+	case "alice@example.com":
+		return &student.Student{ID: 4, Email: email}, nil
+	case "bob@example.com":
+		return &student.Student{ID: 7, Email: email}, nil
+	}
+	return nil, student.ErrStudentNotFound
+}
+
+func (fakeStudents) GetByID(ctx context.Context, id int) (*student.Student, error) {
+	switch id {
+	case 4:
+		return &student.Student{ID: id, FirstName: "Alice", Email: "alice@example.com"}, nil
+	case 7:
+		return &student.Student{ID: id, FirstName: "Bob", Email: "bob@example.com"}, nil
+	}
+	return nil, student.ErrStudentNotFound
+}
+
+type pushed struct {
+	studentID int
+	eventType string
+	n         *notification.Notification
+}
+
+type fakePusher struct {
+	pushed []pushed
+}
+
+func (p *fakePusher) Push(ctx context.Context, studentID int, eventType string, v interface{}) error {
+	p.pushed = append(p.pushed, pushed{studentID, eventType, v.(*notification.Notification)})
+	return nil
+}
+
+// memoryRepository keeps notifications and preferences in memory
+type memoryRepository struct {
+	mu            sync.Mutex
+	notifications []notification.Notification
+	prefs         map[int]map[notification.Type]bool
+}
+
+func newMemoryRepository() *memoryRepository {
+	return &memoryRepository{prefs: make(map[int]map[notification.Type]bool)}
+}
+
+func (r *memoryRepository) Create(ctx context.Context, notifications []*notification.Notification) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, n := range notifications {
+		n.ID = int64(len(r.notifications) + 1)
+		n.CreatedAt = time.Now()
+		r.notifications = append(r.notifications, *n)
+	}
+	return nil
+}
+
+func (r *memoryRepository) List(ctx context.Context, studentID int, f notification.ListFilter, beforeID int64, limit int) ([]notification.Notification, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var list []notification.Notification
+	for i := len(r.notifications) - 1; i >= 0 && len(list) < limit; i-- {
+		n := r.notifications[i]
+		if n.StudentID != studentID || (f.UnreadOnly && n.ReadAt != nil) || (f.Type != "" && n.Type != f.Type) || (beforeID > 0 && n.ID >= beforeID) {
+			continue
+		}
+		list = append(list, n)
+	}
+	return list, nil
+}
+
+func (r *memoryRepository) CountUnread(ctx context.Context, studentID int) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var count int
+	for _, n := range r.notifications {
+		if n.StudentID == studentID && n.ReadAt == nil {
+			count++
+		}
+	}
+	return count, nil
+}
+
+func (r *memoryRepository) MarkRead(ctx context.Context, studentID int, id int64, readAt time.Time) (*notification.Notification, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for i := range r.notifications {
+		n := &r.notifications[i]
+		if n.ID == id && n.StudentID == studentID {
+			if n.ReadAt == nil {
+				n.ReadAt = &readAt
+			}
+			marked := *n
+			return &marked, nil
+		}
+	}
+	return nil, notification.ErrNotificationNotFound
+}
+
+func (r *memoryRepository) MarkAllRead(ctx context.Context, studentID int, readAt time.Time) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var marked int
+	for i := range r.notifications {
+		n := &r.notifications[i]
+		if n.StudentID == studentID && n.ReadAt == nil {
+			n.ReadAt = &readAt
+			marked++
+		}
+	}
+	return marked, nil
+}
+
+func (r *memoryRepository) Preferences(ctx context.Context, studentID int) ([]notification.Preference, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var prefs []notification.Preference
+	for t, enabled := range r.prefs[studentID] {
+		prefs = append(prefs, notification.Preference{StudentID: studentID, Type: t, Enabled: enabled})
+	}
+	return prefs, nil
+}
+
+func (r *memoryRepository) SetPreferences(ctx context.Context, prefs []notification.Preference) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, p := range prefs {
+		if r.prefs[p.StudentID] == nil {
+			r.prefs[p.StudentID] = make(map[notification.Type]bool)
+		}
+		r.prefs[p.StudentID][p.Type] = p.Enabled
+	}
+	return nil
+}
+
+func (r *memoryRepository) Disabled(ctx context.Context, studentIDs []int, t notification.Type) (map[int]bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	disabled := make(map[int]bool)
+	for _, id := range studentIDs {
+		if enabled, ok := r.prefs[id][t]; ok && !enabled {
+			disabled[id] = true
+		}
+	}
+	return disabled, nil
+}
+
+func (r *memoryRepository) DeleteCreatedBefore(ctx context.Context, before time.Time) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	kept := r.notifications[:0]
+	for _, n := range r.notifications {
+		if !n.CreatedAt.Before(before) {
+			kept = append(kept, n)
+		}
+	}
+	deleted := len(r.notifications) - len(kept)
+	r.notifications = kept
+	return deleted, nil
+}
+
+func setup(t *testing.T) (*fakeBus, *fakePusher, notification.Service, *httptest.Server) {
+	gin.SetMode(gin.TestMode)
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+	pusher := &fakePusher{}
+	service := notification.NewService(newMemoryRepository(), pusher, nil, logger)
+
+	bus := &fakeBus{handlers: make(map[string]nats.MsgHandler)}
+	consumer := notification.NewConsumer(bus, notification.Subjects{
+		Messages:  "message.created",
+		Reminders: "project.reminder",
+		Projects:  "project.changed",
+		Grades:    "submission.graded",
+	}, service, fakeStudents{}, logger)
+	require.NoError(t, consumer.Start())
+	t.Cleanup(consumer.Close)
+
+	// The X-Student header stands in for the student AuthMiddleware takes from the token
+	engine := gin.New()
+	engine.Use(func(c *gin.Context) {
+		if id, err := strconv.Atoi(c.GetHeader("X-Student")); err == nil {
+			c.Request = c.Request.WithContext(context.WithValue(c.Request.Context(), auth.StudentIDKey, id))
+		}
+	})
+	notification.NewHandler(service, logger).RegisterRoutes(engine)
+	server := httptest.NewServer(engine)
+	t.Cleanup(server.Close)
+
+	return bus, pusher, service, server
+}
+
+func do(t *testing.T, server *httptest.Server, method, path string, studentID int, body string, v interface{}) int {
+	req, err := http.NewRequest(method, server.URL+path, strings.NewReader(body))
+	require.NoError(t, err)
+	if studentID != 0 {
+		req.Header.Set("X-Student", strconv.Itoa(studentID))
+	}
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	if v != nil {
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(v))
+	}
+	return resp.StatusCode
+}
+
+func TestConsumer(t *testing.T) {
+	t.Run("Message", func(t *testing.T) {
+		bus, pusher, _, _ := setup(t)
+
+		// The sender is not notified of their own message
+		bus.publish(t, "message.created", gin.H{
+			"message":    gin.H{"id": 1, "email": "alice@example.com", "message": "Meet at 10", "conversationId": 3},
+			"recipients": []string{"alice@example.com", "bob@example.com", "carol@example.com"},
+			"studentIds": []int{4, 9},
+		})
+		require.Len(t, pusher.pushed, 2)
+		assert.Equal(t, []int{7, 9}, []int{pusher.pushed[0].studentID, pusher.pushed[1].studentID})
+
+		n := pusher.pushed[0].n
+		assert.Equal(t, notification.EventType, pusher.pushed[0].eventType)
+		assert.Equal(t, notification.TypeMessage, n.Type)
+		assert.Equal(t, "New message from alice@example.com", n.Title)
+		// The text is not copied into the notification
+		assert.Empty(t, n.Body)
+		assert.Equal(t, map[string]interface{}{"messageId": 1, "conversationId": 3}, n.Data)
+	})
+
+	t.Run("ReminderAndGrade", func(t *testing.T) {
+		bus, pusher, _, _ := setup(t)
+
+		bus.publish(t, "project.reminder", gin.H{
+			"projectId":  2,
+			"name":       "Compiler",
+			"dueDate":    time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC),
+			"studentIds": []int{4},
+		})
+		bus.publish(t, "submission.graded", gin.H{
+			"submissionId": 5, "projectId": 2, "version": 2, "score": 8.5, "maxScore": 10, "studentIds": []int{4, 7},
+		})
+		require.Len(t, pusher.pushed, 3)
+		assert.Equal(t, notification.TypeReminder, pusher.pushed[0].n.Type)
+		assert.Equal(t, "Compiler is due soon", pusher.pushed[0].n.Title)
+		assert.Equal(t, "Due Fri, 01 May 2026 12:00 UTC", pusher.pushed[0].n.Body)
+		assert.Equal(t, notification.TypeGrade, pusher.pushed[1].n.Type)
+		assert.Equal(t, "Version 2 scored 8.5 out of 10", pusher.pushed[1].n.Body)
+	})
+
+	t.Run("MemberAdded", func(t *testing.T) {
+		bus, pusher, _, _ := setup(t)
+
+		// Only the member added is notified, other changes are not notifications
+		bus.publish(t, "project.changed", gin.H{"type": "updated", "projectId": 2, "studentIds": []int{4, 7}})
+		bus.publish(t, "project.changed", gin.H{"type": "member_added", "projectId": 2, "name": "Compiler", "memberId": 7, "studentIds": []int{4, 7}})
+		require.Len(t, pusher.pushed, 1)
+		assert.Equal(t, 7, pusher.pushed[0].studentID)
+		assert.Equal(t, "You were added to Compiler", pusher.pushed[0].n.Title)
+	})
+
+	t.Run("Preferences", func(t *testing.T) {
+		bus, pusher, service, _ := setup(t)
+
+		_, err := service.UpdatePreferences(context.Background(), 7, map[notification.Type]bool{notification.TypeGrade: false})
+		require.NoError(t, err)
+		bus.publish(t, "submission.graded", gin.H{"submissionId": 5, "score": 1, "maxScore": 2, "studentIds": []int{4, 7}})
+		require.Len(t, pusher.pushed, 1)
+		assert.Equal(t, 4, pusher.pushed[0].studentID)
+	})
+
+	t.Run("Email", func(t *testing.T) {
+		templates, err := mailer.DefaultTemplates()
+		require.NoError(t, err)
+		sender := mailer.NewMemorySender()
+		logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+		service := notification.NewService(newMemoryRepository(), nil, notification.NewMailer(sender, templates, fakeStudents{}), logger)
+
+		// Only reminders are mailed
+		_, err = service.Notify(context.Background(), notification.Notice{Type: notification.TypeGrade, StudentIDs: []int{4}, Title: "Graded"})
+		require.NoError(t, err)
+		_, err = service.Notify(context.Background(), notification.Notice{
+			Type: notification.TypeReminder, StudentIDs: []int{4, 7}, Title: "Compiler is due soon", Body: "Due tomorrow",
+		})
+		require.NoError(t, err)
+
+		sent := sender.Messages()
+		require.Len(t, sent, 2)
+		assert.Equal(t, []string{"alice@example.com"}, sent[0].To)
+		assert.Equal(t, "Compiler is due soon", sent[0].Subject)
+		assert.Contains(t, sent[0].Text, "Hi Alice")
+		assert.Contains(t, sent[0].HTML, "Due tomorrow")
+		assert.Equal(t, []string{"bob@example.com"}, sent[1].To)
+	})
+
+	t.Run("Malformed", func(t *testing.T) {
+		bus, pusher, _, _ := setup(t)
+
+		bus.handlers["message.created"](&nats.Msg{Subject: "message.created", Data: []byte("{")})
+		bus.publish(t, "message.created", gin.H{"recipients": []string{"bob@example.com"}})
+		assert.Empty(t, pusher.pushed)
+	})
+}
+
+func TestHandler(t *testing.T) {
+	bus, _, service, server := setup(t)
+	for i := 1; i <= 3; i++ {
+		bus.publish(t, "submission.graded", gin.H{"submissionId": i, "version": i, "score": 1, "maxScore": 2, "studentIds": []int{4}})
+	}
+	bus.publish(t, "project.reminder", gin.H{"projectId": 2, "name": "Compiler", "studentIds": []int{4}})
+
+	t.Run("List", func(t *testing.T) {
+		var page notification.Page
+		require.Equal(t, http.StatusOK, do(t, server, http.MethodGet, "/notifications?type=grade&limit=2", 4, "", &page))
+		require.Len(t, page.Items, 2)
+		assert.Equal(t, []int64{3, 2}, []int64{page.Items[0].ID, page.Items[1].ID})
+		assert.Equal(t, 4, page.Unread)
+		require.NotEmpty(t, page.NextCursor)
+
+		var next notification.Page
+		require.Equal(t, http.StatusOK, do(t, server, http.MethodGet, "/notifications?type=grade&limit=2&cursor="+page.NextCursor, 4, "", &next))
+		require.Len(t, next.Items, 1)
+		assert.Equal(t, int64(1), next.Items[0].ID)
+		assert.Empty(t, next.NextCursor)
+
+		// Other students see none of them
+		var other notification.Page
+		require.Equal(t, http.StatusOK, do(t, server, http.MethodGet, "/notifications", 7, "", &other))
+		assert.Empty(t, other.Items)
+
+		assert.Equal(t, http.StatusBadRequest, do(t, server, http.MethodGet, "/notifications?type=spam", 4, "", nil))
+		assert.Equal(t, http.StatusBadRequest, do(t, server, http.MethodGet, "/notifications?cursor=nope", 4, "", nil))
+		assert.Equal(t, http.StatusUnauthorized, do(t, server, http.MethodGet, "/notifications", 0, "", nil))
+	})
+
+	t.Run("MarkRead", func(t *testing.T) {
+		var n notification.Notification
+		require.Equal(t, http.StatusOK, do(t, server, http.MethodPost, "/notifications/2/read", 4, "", &n))
+		require.NotNil(t, n.ReadAt)
+
+		// Marking it again keeps the time it was first read
+		var again notification.Notification
+		require.Equal(t, http.StatusOK, do(t, server, http.MethodPost, "/notifications/2/read", 4, "", &again))
+		assert.True(t, n.ReadAt.Equal(*again.ReadAt))
+
+		assert.Equal(t, http.StatusNotFound, do(t, server, http.MethodPost, "/notifications/2/read", 7, "", nil))
+
+		var page notification.Page
+		require.Equal(t, http.StatusOK, do(t, server, http.MethodGet, "/notifications?unread=true", 4, "", &page))
+		assert.Len(t, page.Items, 3)
+		assert.Equal(t, 3, page.Unread)
+
+		var marked struct{ Marked int }
+		require.Equal(t, http.StatusOK, do(t, server, http.MethodPost, "/notifications/read", 4, "", &marked))
+		assert.Equal(t, 3, marked.Marked)
+	})
+
+	t.Run("Preferences", func(t *testing.T) {
+		var prefs map[notification.Type]bool
+		require.Equal(t, http.StatusOK, do(t, server, http.MethodGet, "/notifications/preferences", 4, "", &prefs))
+		assert.Len(t, prefs, len(notification.Types))
+		assert.True(t, prefs[notification.TypeMessage])
+
+		require.Equal(t, http.StatusOK, do(t, server, http.MethodPut, "/notifications/preferences", 4, `{"message":false}`, &prefs))
+		assert.False(t, prefs[notification.TypeMessage])
+		assert.True(t, prefs[notification.TypeGrade])
+
+		assert.Equal(t, http.StatusBadRequest, do(t, server, http.MethodPut, "/notifications/preferences", 4, `{"spam":true}`, nil))
+
+		// Muted types are no longer stored
+		bus.publish(t, "message.created", gin.H{"message": gin.H{"id": 9, "email": "bob@example.com", "message": "Hi"}, "studentIds": []int{4}})
+		var page notification.Page
+		require.Equal(t, http.StatusOK, do(t, server, http.MethodGet, "/notifications", 4, "", &page))
+		assert.False(t, slices.ContainsFunc(page.Items, func(n notification.Notification) bool {
+			return n.Type == notification.TypeMessage
+		}))
+	})
+	t.Run("Purge", func(t *testing.T) {
+		purged, err := service.Purge(context.Background(), time.Hour)
+		require.NoError(t, err)
+		assert.Zero(t, purged)
+
+		purged, err = service.Purge(context.Background(), 0)
+		require.NoError(t, err)
+		assert.Positive(t, purged)
+		var page notification.Page
+		require.Equal(t, http.StatusOK, do(t, server, http.MethodGet, "/notifications", 4, "", &page))
+		assert.Empty(t, page.Items)
+
+		_, err = service.Purge(context.Background(), -time.Hour)
+		assert.ErrorIs(t, err, notification.ErrInvalidInput)
+	})
+}
@@ -0,0 +1,201 @@
+package notification
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"grud/common/metrics"
+
+	"github.com/uptrace/bun"
+)
+
+type Repository interface {
+	// Create inserts the notifications and sets their IDs
+	Create(ctx context.Context, notifications []*Notification) error
+	// List returns up to limit notifications of the student matching f,
+	// newest first, only those with an ID below beforeID if it is set
+	List(ctx context.Context, studentID int, f ListFilter, beforeID int64, limit int) ([]Notification, error)
+	// CountUnread returns how many notifications the student has not read
+	CountUnread(ctx context.Context, studentID int) (int, error)
+	// MarkRead marks a notification of the student read, keeping the time it
+	// was first read. It returns ErrNotificationNotFound if the student has
+	// no such notification.
+	MarkRead(ctx context.Context, studentID int, id int64, readAt time.Time) (*Notification, error)
+	// MarkAllRead marks every unread notification of the student read and
+	// returns how many there were
+	MarkAllRead(ctx context.Context, studentID int, readAt time.Time) (int, error)
+	// Preferences returns the preferences the student set
+	Preferences(ctx context.Context, studentID int) ([]Preference, error)
+	// SetPreferences inserts or replaces the preferences
+	SetPreferences(ctx context.Context, prefs []Preference) error
+	// Disabled returns which of the students turned the type off
+	Disabled(ctx context.Context, studentIDs []int, t Type) (map[int]bool, error)
+	// DeleteCreatedBefore deletes the notifications created before the time
+	// and returns how many there were
+	DeleteCreatedBefore(ctx context.Context, before time.Time) (int, error)
+}
+
+type repository struct {
+	db      *bun.DB
+	metrics *metrics.Metrics
+}
+
+func NewRepository(db *bun.DB, m *metrics.Metrics) Repository {
+	return &repository{
+		db:      db,
+		metrics: m,
+	}
+}
+
+func (r *repository) Create(ctx context.Context, notifications []*Notification) error {
+	start := time.Now()
+	_, err := r.db.NewInsert().Model(&notifications).Returning("id, created_at").Exec(ctx)
+	r.metrics.Database.RecordQuery(ctx, "insert", "notifications", time.Since(start), err)
+
+	return err
+}
+
+func (r *repository) List(ctx context.Context, studentID int, f ListFilter, beforeID int64, limit int) ([]Notification, error) {
+	start := time.Now()
+	notifications := make([]Notification, 0, limit)
+	query := r.db.NewSelect().
+		Model(&notifications).
+		Where("student_id = ?", studentID)
+	if f.UnreadOnly {
+		query.Where("read_at IS NULL")
+	}
+	if f.Type != "" {
+		query.Where("type = ?", f.Type)
+	}
+	if beforeID > 0 {
+		query.Where("id < ?", beforeID)
+	}
+	err := query.OrderExpr("id DESC").Limit(limit).Scan(ctx)
+	r.metrics.Database.RecordQuery(ctx, "select", "notifications", time.Since(start), err)
+
+	if err != nil {
+		return nil, err
+	}
+	return notifications, nil
+}
+
+func (r *repository) CountUnread(ctx context.Context, studentID int) (int, error) {
+	start := time.Now()
+	count, err := r.db.NewSelect().
+		Model((*Notification)(nil)).
+		Where("student_id = ?", studentID).
+		Where("read_at IS NULL").
+		Count(ctx)
+	r.metrics.Database.RecordQuery(ctx, "count", "notifications", time.Since(start), err)
+
+	return count, err
+}
+
+func (r *repository) MarkRead(ctx context.Context, studentID int, id int64, readAt time.Time) (*Notification, error) {
+	start := time.Now()
+	n := new(Notification)
+	err := r.db.NewUpdate().
+		Model(n).
+		Set("read_at = COALESCE(read_at, ?)", readAt).
+		Where("id = ?", id).
+		Where("student_id = ?", studentID).
+		Returning("*").
+		Scan(ctx)
+	r.metrics.Database.RecordQuery(ctx, "update", "notifications", time.Since(start), err)
+
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrNotificationNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return n, nil
+}
+
+func (r *repository) MarkAllRead(ctx context.Context, studentID int, readAt time.Time) (int, error) {
+	start := time.Now()
+	res, err := r.db.NewUpdate().
+		Model((*Notification)(nil)).
+		Set("read_at = ?", readAt).
+		Where("student_id = ?", studentID).
+		Where("read_at IS NULL").
+		Exec(ctx)
+	r.metrics.Database.RecordQuery(ctx, "update", "notifications", time.Since(start), err)
+
+	if err != nil {
+		return 0, err
+	}
+	marked, err := res.RowsAffected()
+	return int(marked), err
+}
+
+func (r *repository) Preferences(ctx context.Context, studentID int) ([]Preference, error) {
+	start := time.Now()
+	var prefs []Preference
+	err := r.db.NewSelect().
+		Model(&prefs).
+		Where("student_id = ?", studentID).
+		Scan(ctx)
+	r.metrics.Database.RecordQuery(ctx, "select", "notification_preferences", time.Since(start), err)
+
+	return prefs, err
+}
+
+func (r *repository) SetPreferences(ctx context.Context, prefs []Preference) error {
+	if len(prefs) == 0 {
+		return nil
+	}
+
+	start := time.Now()
+	_, err := r.db.NewInsert().
+		Model(&prefs).
+		On("CONFLICT (student_id, type) DO UPDATE").
+		Set("enabled = EXCLUDED.enabled").
+		Exec(ctx)
+	r.metrics.Database.RecordQuery(ctx, "upsert", "notification_preferences", time.Since(start), err)
+
+	return err
+}
+
+func (r *repository) Disabled(ctx context.Context, studentIDs []int, t Type) (map[int]bool, error) {
+	disabled := make(map[int]bool)
+	if len(studentIDs) == 0 {
+		return disabled, nil
+	}
+
+	start := time.Now()
+	var ids []int
+	err := r.db.NewSelect().
+		Model((*Preference)(nil)).
+		Column("student_id").
+		Where("student_id IN (?)", bun.In(studentIDs)).
+		Where("type = ?", t).
+		Where("NOT enabled").
+		Scan(ctx, &ids)
+	r.metrics.Database.RecordQuery(ctx, "select", "notification_preferences", time.Since(start), err)
+
+	if err != nil {
+		return nil, err
+	}
+	for _, id := range ids {
+		disabled[id] = true
+	}
+	return disabled, nil
+}
+
+func (r *repository) DeleteCreatedBefore(ctx context.Context, before time.Time) (int, error) {
+	start := time.Now()
+	res, err := r.db.NewDelete().
+		Model((*Notification)(nil)).
+		Where("created_at < ?", before).
+		Exec(ctx)
+	r.metrics.Database.RecordQuery(ctx, "delete", "notifications", time.Since(start), err)
+
+	if err != nil {
+		return 0, err
+	}
+	deleted, err := res.RowsAffected()
+	return int(deleted), err
+}
@@ -0,0 +1,239 @@
+package notification
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"log/slog"
+	"slices"
+	"strconv"
+	"time"
+)
+
+const (
+	DefaultPageSize = 50
+	MaxPageSize     = 200
+)
+
+// EventType is the stream event type new notifications are pushed as
+const EventType = "notification"
+
+var (
+	ErrNotificationNotFound = errors.New("notification not found")
+	ErrInvalidInput         = errors.New("invalid input")
+	ErrInvalidPageToken     = errors.New("invalid page token")
+	ErrUnknownType          = errors.New("unknown notification type")
+)
+
+type Service interface {
+	// Notify stores a notification for each of the students who did not turn
+	// its type off and pushes it to their connected clients. Those of
+	// EmailTypes are mailed too.
+	Notify(ctx context.Context, n Notice) ([]*Notification, error)
+	// List returns a page of the student's notifications matching f, newest
+	// first. pageSize is clamped to MaxPageSize and defaults to
+	// DefaultPageSize.
+	List(ctx context.Context, studentID int, f ListFilter, pageSize int, pageToken string) (*Page, error)
+	// MarkRead marks one of the student's notifications read. Marking it
+	// again is a no-op.
+	MarkRead(ctx context.Context, studentID int, id int64) (*Notification, error)
+	// MarkAllRead marks all of the student's notifications read and returns
+	// how many were unread
+	MarkAllRead(ctx context.Context, studentID int) (int, error)
+	// Preferences returns whether the student wants each type of notification
+	Preferences(ctx context.Context, studentID int) (map[Type]bool, error)
+	// UpdatePreferences changes the given types and returns all preferences
+	UpdatePreferences(ctx context.Context, studentID int, prefs map[Type]bool) (map[Type]bool, error)
+	// Purge deletes the notifications created more than retention ago, read
+	// or not, and returns how many there were
+	Purge(ctx context.Context, retention time.Duration) (int, error)
+}
+
+// Pusher pushes an event to a student's connected clients. It is implemented
+// by *stream.Pusher.
+type Pusher interface {
+	Push(ctx context.Context, studentID int, eventType string, v interface{}) error
+}
+
+// Emailer mails a stored notification to its student. It is implemented by
+// *Mailer.
+type Emailer interface {
+	Email(ctx context.Context, n *Notification) error
+}
+
+type service struct {
+	repo    Repository
+	pusher  Pusher
+	emailer Emailer
+	logger  *slog.Logger
+}
+
+// NewService creates the notification service. pusher and emailer may be
+// nil, in which case notifications are not pushed or mailed.
+func NewService(repo Repository, pusher Pusher, emailer Emailer, logger *slog.Logger) Service {
+	return &service{
+		repo:    repo,
+		pusher:  pusher,
+		emailer: emailer,
+		logger:  logger,
+	}
+}
+
+func (s *service) Notify(ctx context.Context, n Notice) ([]*Notification, error) {
+	if !n.Type.Valid() {
+		return nil, ErrUnknownType
+	}
+	if n.Title == "" {
+		return nil, ErrInvalidInput
+	}
+
+	studentIDs := slices.Clone(n.StudentIDs)
+	slices.Sort(studentIDs)
+	studentIDs = slices.Compact(studentIDs)
+	disabled, err := s.repo.Disabled(ctx, studentIDs, n.Type)
+	if err != nil {
+		return nil, err
+	}
+
+	notifications := make([]*Notification, 0, len(studentIDs))
+	for _, studentID := range studentIDs {
+		if studentID <= 0 || disabled[studentID] {
+			continue
+		}
+		notifications = append(notifications, &Notification{
+			StudentID: studentID,
+			Type:      n.Type,
+			Title:     n.Title,
+			Body:      n.Body,
+			Data:      n.Data,
+		})
+	}
+	if len(notifications) == 0 {
+		return notifications, nil
+	}
+	if err := s.repo.Create(ctx, notifications); err != nil {
+		return nil, err
+	}
+
+	// Clients that miss the push see the notification on their next list
+	if s.pusher != nil {
+		for _, notification := range notifications {
+			if err := s.pusher.Push(ctx, notification.StudentID, EventType, notification); err != nil {
+				s.logger.WarnContext(ctx, "failed to push notification", "student_id", notification.StudentID, "error", err)
+			}
+		}
+	}
+	if s.emailer != nil && slices.Contains(EmailTypes, n.Type) {
+		for _, notification := range notifications {
+			if err := s.emailer.Email(ctx, notification); err != nil {
+				s.logger.WarnContext(ctx, "failed to mail notification", "student_id", notification.StudentID, "error", err)
+			}
+		}
+	}
+	return notifications, nil
+}
+
+func (s *service) List(ctx context.Context, studentID int, f ListFilter, pageSize int, pageToken string) (*Page, error) {
+	if pageSize < 0 {
+		return nil, ErrInvalidInput
+	}
+	if pageSize == 0 {
+		pageSize = DefaultPageSize
+	}
+	if pageSize > MaxPageSize {
+		pageSize = MaxPageSize
+	}
+	if f.Type != "" && !f.Type.Valid() {
+		return nil, ErrUnknownType
+	}
+
+	var beforeID int64
+	if pageToken != "" {
+		var err error
+		if beforeID, err = decodePageToken(pageToken); err != nil {
+			return nil, err
+		}
+	}
+
+	notifications, err := s.repo.List(ctx, studentID, f, beforeID, pageSize+1)
+	if err != nil {
+		return nil, err
+	}
+	unread, err := s.repo.CountUnread(ctx, studentID)
+	if err != nil {
+		return nil, err
+	}
+
+	page := &Page{Items: notifications, Unread: unread}
+	if len(notifications) > pageSize {
+		page.Items = notifications[:pageSize]
+		page.NextCursor = encodePageToken(page.Items[pageSize-1].ID)
+	}
+	return page, nil
+}
+
+func (s *service) MarkRead(ctx context.Context, studentID int, id int64) (*Notification, error) {
+	if id <= 0 {
+		return nil, ErrInvalidInput
+	}
+	return s.repo.MarkRead(ctx, studentID, id, time.Now().UTC())
+}
+
+func (s *service) MarkAllRead(ctx context.Context, studentID int) (int, error) {
+	return s.repo.MarkAllRead(ctx, studentID, time.Now().UTC())
+}
+
+func (s *service) Preferences(ctx context.Context, studentID int) (map[Type]bool, error) {
+	stored, err := s.repo.Preferences(ctx, studentID)
+	if err != nil {
+		return nil, err
+	}
+
+	prefs := make(map[Type]bool, len(Types))
+	for _, t := range Types {
+		prefs[t] = true
+	}
+	for _, p := range stored {
+		if p.Type.Valid() {
+			prefs[p.Type] = p.Enabled
+		}
+	}
+	return prefs, nil
+}
+
+func (s *service) UpdatePreferences(ctx context.Context, studentID int, prefs map[Type]bool) (map[Type]bool, error) {
+	changed := make([]Preference, 0, len(prefs))
+	for t, enabled := range prefs {
+		if !t.Valid() {
+			return nil, ErrUnknownType
+		}
+		changed = append(changed, Preference{StudentID: studentID, Type: t, Enabled: enabled})
+	}
+	if err := s.repo.SetPreferences(ctx, changed); err != nil {
+		return nil, err
+	}
+	return s.Preferences(ctx, studentID)
+}
+
+func (s *service) Purge(ctx context.Context, retention time.Duration) (int, error) {
+	if retention < 0 {
+		return 0, ErrInvalidInput
+	}
+	return s.repo.DeleteCreatedBefore(ctx, time.Now().Add(-retention))
+}
+
+func encodePageToken(id int64) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(strconv.FormatInt(id, 10)))
+}
+
+func decodePageToken(s string) (int64, error) {
+	data, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return 0, ErrInvalidPageToken
+	}
+	id, err := strconv.ParseInt(string(data), 10, 64)
+	if err != nil || id <= 0 {
+		return 0, ErrInvalidPageToken
+	}
+	return id, nil
+}
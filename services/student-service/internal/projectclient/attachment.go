@@ -0,0 +1,177 @@
+package projectclient
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// MaxAttachmentSize is the largest file accepted by UploadAttachment.
+// project-service enforces its own, configured limit as well.
+const MaxAttachmentSize = 25 << 20
+
+// multipartOverhead is the room left in the request body limit for the
+// multipart boundaries and part headers around the file
+const multipartOverhead = 64 << 10
+
+// UploadAttachment stores the "file" part of a multipart form as an
+// attachment of the project. The client's content type is ignored;
+// project-service sniffs it from the contents.
+func (h *Handler) UploadAttachment(c *gin.Context) {
+	projectID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid project ID"})
+		return
+	}
+
+	// Uploads can outlive the server's read timeout
+	_ = http.NewResponseController(c.Writer).SetReadDeadline(time.Time{})
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, MaxAttachmentSize+multipartOverhead)
+
+	header, err := c.FormFile("file")
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			c.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": fmt.Sprintf("Attachments are limited to %d bytes", MaxAttachmentSize)})
+			return
+		}
+		c.JSON(http.StatusBadRequest, gin.H{"error": "A multipart form with a file field is required"})
+		return
+	}
+	if header.Size > MaxAttachmentSize {
+		c.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": fmt.Sprintf("Attachments are limited to %d bytes", MaxAttachmentSize)})
+		return
+	}
+	if header.Size == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "The file is empty"})
+		return
+	}
+
+	file, err := header.Open()
+	if err != nil {
+		h.logger.ErrorContext(c.Request.Context(), "failed to open uploaded file", "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to upload attachment"})
+		return
+	}
+	defer file.Close()
+
+	// The checksum lets project-service verify that nothing was lost on the way
+	hash := sha256.New()
+	if _, err := io.Copy(hash, file); err != nil {
+		h.logger.ErrorContext(c.Request.Context(), "failed to read uploaded file", "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to upload attachment"})
+		return
+	}
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		h.logger.ErrorContext(c.Request.Context(), "failed to rewind uploaded file", "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to upload attachment"})
+		return
+	}
+
+	if h.grpcClient == nil {
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": "gRPC client not available"})
+		return
+	}
+
+	h.logger.InfoContext(c.Request.Context(), "uploading attachment via gRPC",
+		"project_id", projectID, "filename", header.Filename, "size", header.Size)
+	attachment, err := h.grpcClient.UploadAttachment(c.Request.Context(), projectID,
+		header.Filename, header.Size, hex.EncodeToString(hash.Sum(nil)), file)
+	if err != nil {
+		h.handleGrpcError(c, err, "Failed to upload attachment")
+		return
+	}
+
+	c.JSON(http.StatusCreated, attachment)
+}
+
+func (h *Handler) ListAttachments(c *gin.Context) {
+	projectID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid project ID"})
+		return
+	}
+
+	if h.grpcClient == nil {
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": "gRPC client not available"})
+		return
+	}
+
+	h.logger.InfoContext(c.Request.Context(), "listing attachments via gRPC", "project_id", projectID)
+	attachments, err := h.grpcClient.ListAttachments(c.Request.Context(), projectID)
+	if err != nil {
+		h.handleGrpcError(c, err, "Failed to fetch attachments")
+		return
+	}
+
+	c.JSON(http.StatusOK, attachments)
+}
+
+// DownloadAttachment streams an attachment's contents. It is always served as
+// a download, never rendered inline, whatever its content type.
+func (h *Handler) DownloadAttachment(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid attachment ID"})
+		return
+	}
+
+	if h.grpcClient == nil {
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": "gRPC client not available"})
+		return
+	}
+
+	ctx := c.Request.Context()
+	h.logger.InfoContext(ctx, "downloading attachment via gRPC", "id", id)
+	download, err := h.grpcClient.DownloadAttachment(ctx, id)
+	if err != nil {
+		h.handleGrpcError(c, err, "Failed to download attachment")
+		return
+	}
+
+	// Downloads can outlive the server's write timeout
+	_ = http.NewResponseController(c.Writer).SetWriteDeadline(time.Time{})
+
+	attachment := download.Attachment
+	c.Header("Content-Type", attachment.ContentType)
+	c.Header("Content-Length", strconv.FormatInt(attachment.Size, 10))
+	c.Header("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": attachment.Filename}))
+	c.Header("X-Content-Type-Options", "nosniff")
+	c.Header("ETag", `"`+attachment.SHA256+`"`)
+	c.Status(http.StatusOK)
+
+	// Once the body has started the status can no longer change; a failure
+	// leaves the response short of Content-Length, which clients detect
+	if _, err := io.Copy(c.Writer, download); err != nil {
+		h.logger.ErrorContext(ctx, "attachment download interrupted", "error", err, "id", id)
+	}
+}
+
+func (h *Handler) DeleteAttachment(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid attachment ID"})
+		return
+	}
+
+	if h.grpcClient == nil {
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": "gRPC client not available"})
+		return
+	}
+
+	h.logger.InfoContext(c.Request.Context(), "deleting attachment via gRPC", "id", id)
+	if err := h.grpcClient.DeleteAttachment(c.Request.Context(), id); err != nil {
+		h.handleGrpcError(c, err, "Failed to delete attachment")
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
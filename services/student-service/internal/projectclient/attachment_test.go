@@ -0,0 +1,255 @@
+package projectclient_test
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"mime/multipart"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	attachmentpb "grud/api/gen/attachment/v1"
+	"grud/common/history"
+	"student-service/internal/projectclient"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// fakeAttachmentService keeps attachments in memory and checks uploads the
+// way project-service does
+type fakeAttachmentService struct {
+	attachmentpb.UnimplementedAttachmentServiceServer
+
+	mu          sync.Mutex
+	attachments map[int32]*attachmentpb.Attachment
+	contents    map[int32][]byte
+	actors      []string
+}
+
+func (f *fakeAttachmentService) UploadAttachment(stream attachmentpb.AttachmentService_UploadAttachmentServer) error {
+	first, err := stream.Recv()
+	if err != nil {
+		return err
+	}
+	meta := first.GetMetadata()
+	var contents bytes.Buffer
+	for {
+		msg, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		contents.Write(msg.GetChunk())
+	}
+
+	sum := sha256.Sum256(contents.Bytes())
+	if int64(contents.Len()) != meta.Size || hex.EncodeToString(sum[:]) != meta.Sha256 {
+		return status.Error(codes.InvalidArgument, "checksum mismatch")
+	}
+	if meta.ProjectId != 1 {
+		return status.Error(codes.NotFound, "project not found")
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	md, _ := metadata.FromIncomingContext(stream.Context())
+	f.actors = append(f.actors, md.Get(history.ActorMetadataKey)...)
+	id := int32(len(f.attachments) + 1)
+	f.attachments[id] = &attachmentpb.Attachment{
+		Id:          id,
+		ProjectId:   meta.ProjectId,
+		Filename:    meta.Filename,
+		ContentType: "application/pdf",
+		Size:        meta.Size,
+		Sha256:      meta.Sha256,
+		CreatedAt:   timestamppb.Now(),
+	}
+	f.contents[id] = contents.Bytes()
+	return stream.SendAndClose(&attachmentpb.UploadAttachmentResponse{Attachment: f.attachments[id]})
+}
+
+func (f *fakeAttachmentService) DownloadAttachment(req *attachmentpb.DownloadAttachmentRequest, stream attachmentpb.AttachmentService_DownloadAttachmentServer) error {
+	f.mu.Lock()
+	attachment, contents := f.attachments[req.Id], f.contents[req.Id]
+	f.mu.Unlock()
+	if attachment == nil {
+		return status.Error(codes.NotFound, "attachment not found")
+	}
+
+	if err := stream.Send(&attachmentpb.DownloadAttachmentResponse{
+		Payload: &attachmentpb.DownloadAttachmentResponse_Attachment{Attachment: attachment},
+	}); err != nil {
+		return err
+	}
+	for len(contents) > 0 {
+		n := min(1000, len(contents))
+		if err := stream.Send(&attachmentpb.DownloadAttachmentResponse{
+			Payload: &attachmentpb.DownloadAttachmentResponse_Chunk{Chunk: contents[:n]},
+		}); err != nil {
+			return err
+		}
+		contents = contents[n:]
+	}
+	return nil
+}
+
+func (f *fakeAttachmentService) ListAttachments(ctx context.Context, req *attachmentpb.ListAttachmentsRequest) (*attachmentpb.ListAttachmentsResponse, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	resp := &attachmentpb.ListAttachmentsResponse{}
+	for id := int32(len(f.attachments)); id > 0; id-- {
+		if a := f.attachments[id]; a != nil && a.ProjectId == req.ProjectId {
+			resp.Attachments = append(resp.Attachments, a)
+		}
+	}
+	return resp, nil
+}
+
+func (f *fakeAttachmentService) DeleteAttachment(ctx context.Context, req *attachmentpb.DeleteAttachmentRequest) (*attachmentpb.DeleteAttachmentResponse, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.attachments[req.Id] == nil {
+		return nil, status.Error(codes.NotFound, "attachment not found")
+	}
+	f.attachments[req.Id] = nil
+	return &attachmentpb.DeleteAttachmentResponse{}, nil
+}
+
+func TestAttachments(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	fake := &fakeAttachmentService{attachments: map[int32]*attachmentpb.Attachment{}, contents: map[int32][]byte{}}
+	server := grpc.NewServer()
+	attachmentpb.RegisterAttachmentServiceServer(server, fake)
+	go server.Serve(lis)
+	defer server.Stop()
+
+	client, err := projectclient.NewGrpcClient(lis.Addr().String())
+	require.NoError(t, err)
+	defer client.Close()
+
+	router := gin.New()
+	projectclient.NewHandler(client, slog.New(slog.NewTextHandler(os.Stderr, nil)), nil).RegisterRoutes(router)
+
+	upload := func(projectID, field, filename string, contents []byte) *httptest.ResponseRecorder {
+		var body bytes.Buffer
+		mw := multipart.NewWriter(&body)
+		part, err := mw.CreateFormFile(field, filename)
+		require.NoError(t, err)
+		_, err = part.Write(contents)
+		require.NoError(t, err)
+		require.NoError(t, mw.Close())
+
+		req := httptest.NewRequest(http.MethodPost, "/projects/"+projectID+"/attachments", &body)
+		req.Header.Set("Content-Type", mw.FormDataContentType())
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	do := func(method, target string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(method, target, nil))
+		return w
+	}
+
+	report := append([]byte("%PDF-1.7\n"), bytes.Repeat([]byte("report "), 30000)...)
+
+	t.Run("UploadDownload", func(t *testing.T) {
+		w := upload("1", "file", "report.pdf", report)
+		require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+		var uploaded projectclient.Attachment
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &uploaded))
+		assert.NotZero(t, uploaded.ID)
+		assert.Equal(t, 1, uploaded.ProjectID)
+		assert.Equal(t, "report.pdf", uploaded.Filename)
+		assert.Equal(t, int64(len(report)), uploaded.Size)
+		assert.Equal(t, []string{history.SystemActor}, fake.actors)
+
+		w = do(http.MethodGet, "/attachments/"+strconv.Itoa(uploaded.ID))
+		require.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, report, w.Body.Bytes())
+		assert.Equal(t, "application/pdf", w.Header().Get("Content-Type"))
+		assert.Equal(t, "attachment; filename=report.pdf", w.Header().Get("Content-Disposition"))
+		assert.Equal(t, "nosniff", w.Header().Get("X-Content-Type-Options"))
+		assert.Equal(t, `"`+uploaded.SHA256+`"`, w.Header().Get("ETag"))
+
+		w = do(http.MethodGet, "/projects/1/attachments")
+		require.Equal(t, http.StatusOK, w.Code)
+		var list []projectclient.Attachment
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &list))
+		require.NotEmpty(t, list)
+		assert.Equal(t, uploaded.ID, list[0].ID)
+	})
+
+	t.Run("FilenameEncoding", func(t *testing.T) {
+		w := upload("1", "file", "résumé final.pdf", []byte("%PDF-1.7\n"))
+		require.Equal(t, http.StatusCreated, w.Code)
+		var uploaded projectclient.Attachment
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &uploaded))
+
+		w = do(http.MethodGet, "/attachments/"+strconv.Itoa(uploaded.ID))
+		require.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "attachment; filename*=utf-8''r%C3%A9sum%C3%A9%20final.pdf", w.Header().Get("Content-Disposition"))
+	})
+
+	t.Run("Rejected", func(t *testing.T) {
+		w := upload("1", "document", "report.pdf", report)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+
+		w = upload("1", "file", "empty.pdf", nil)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+
+		w = upload("x", "file", "report.pdf", report)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+
+		w = upload("2", "file", "report.pdf", report)
+		assert.Equal(t, http.StatusNotFound, w.Code)
+
+		w = upload("1", "file", "huge.bin", make([]byte, projectclient.MaxAttachmentSize+1))
+		assert.Equal(t, http.StatusRequestEntityTooLarge, w.Code)
+
+		req := httptest.NewRequest(http.MethodPost, "/projects/1/attachments", strings.NewReader("not a form"))
+		req.Header.Set("Content-Type", "text/plain")
+		w = httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		w := upload("1", "file", "report.pdf", report)
+		require.Equal(t, http.StatusCreated, w.Code)
+		var uploaded projectclient.Attachment
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &uploaded))
+
+		w = do(http.MethodDelete, "/attachments/"+strconv.Itoa(uploaded.ID))
+		assert.Equal(t, http.StatusNoContent, w.Code)
+
+		w = do(http.MethodGet, "/attachments/"+strconv.Itoa(uploaded.ID))
+		assert.Equal(t, http.StatusNotFound, w.Code)
+
+		w = do(http.MethodDelete, "/attachments/"+strconv.Itoa(uploaded.ID))
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+}
@@ -0,0 +1,154 @@
+package projectclient
+
+import (
+	"net/http"
+	"strconv"
+
+	"student-service/internal/auth"
+
+	"github.com/gin-gonic/gin"
+)
+
+// CreateConversation starts a conversation created by the current student,
+// either within a project or with another student
+func (h *Handler) CreateConversation(c *gin.Context) {
+	email, ok := h.currentEmail(c)
+	if !ok {
+		return
+	}
+
+	var req ConversationRequest
+	if err := c.ShouldBindJSON(&req); err != nil || h.validate.Struct(&req) != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request"})
+		return
+	}
+	if (req.ProjectID == 0) == (req.To == "") {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Exactly one of projectId and to is required"})
+		return
+	}
+
+	if h.grpcClient == nil {
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": "gRPC client not available"})
+		return
+	}
+
+	h.logger.InfoContext(c.Request.Context(), "creating conversation via gRPC", "email", email, "project_id", req.ProjectID)
+	conversation, err := h.grpcClient.CreateConversation(c.Request.Context(), email, req)
+	if err != nil {
+		h.handleGrpcError(c, err, "Failed to create conversation")
+		return
+	}
+
+	c.JSON(http.StatusCreated, conversation)
+}
+
+// ListConversations lists the current student's conversations, most recently
+// active first, only those of the project given by the projectId query
+// parameter if set
+func (h *Handler) ListConversations(c *gin.Context) {
+	email, ok := h.currentEmail(c)
+	if !ok {
+		return
+	}
+
+	var projectID int
+	if s := c.Query("projectId"); s != "" {
+		var err error
+		if projectID, err = strconv.Atoi(s); err != nil || projectID <= 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid project ID"})
+			return
+		}
+	}
+
+	if h.grpcClient == nil {
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": "gRPC client not available"})
+		return
+	}
+
+	h.logger.InfoContext(c.Request.Context(), "listing conversations via gRPC", "email", email, "project_id", projectID)
+	conversations, err := h.grpcClient.ListConversations(c.Request.Context(), email, projectID)
+	if err != nil {
+		h.handleGrpcError(c, err, "Failed to fetch conversations")
+		return
+	}
+
+	c.JSON(http.StatusOK, conversations)
+}
+
+// GetThread returns the whole thread of the message, which may be any
+// message of the thread, if the current student may read it
+func (h *Handler) GetThread(c *gin.Context) {
+	email, ok := h.currentEmail(c)
+	if !ok {
+		return
+	}
+	studentID, ok := h.currentStudent(c)
+	if !ok {
+		return
+	}
+
+	messageID, err := strconv.Atoi(c.Param("id"))
+	if err != nil || messageID <= 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid message ID"})
+		return
+	}
+
+	if h.grpcClient == nil {
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": "gRPC client not available"})
+		return
+	}
+
+	h.logger.InfoContext(c.Request.Context(), "fetching thread via gRPC", "message_id", messageID, "email", email)
+	thread, err := h.grpcClient.GetThread(c.Request.Context(), messageID, email, studentID)
+	if err != nil {
+		h.handleGrpcError(c, err, "Failed to fetch thread")
+		return
+	}
+
+	c.JSON(http.StatusOK, thread)
+}
+
+// GetEditHistory returns a message with the texts it had before its edits,
+// if the current student may read its thread
+func (h *Handler) GetEditHistory(c *gin.Context) {
+	email, ok := h.currentEmail(c)
+	if !ok {
+		return
+	}
+	studentID, ok := h.currentStudent(c)
+	if !ok {
+		return
+	}
+
+	messageID, err := strconv.Atoi(c.Param("id"))
+	if err != nil || messageID <= 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid message ID"})
+		return
+	}
+
+	if h.grpcClient == nil {
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": "gRPC client not available"})
+		return
+	}
+
+	h.logger.InfoContext(c.Request.Context(), "fetching edit history via gRPC", "message_id", messageID, "email", email)
+	history, err := h.grpcClient.GetEditHistory(c.Request.Context(), messageID, email, studentID)
+	if err != nil {
+		h.handleGrpcError(c, err, "Failed to fetch edit history")
+		return
+	}
+
+	c.JSON(http.StatusOK, history)
+}
+
+// currentEmail returns the email of the authenticated student, responding
+// with 401 if there is none
+func (h *Handler) currentEmail(c *gin.Context) (string, bool) {
+	email, ok := auth.GetEmail(c.Request.Context())
+	if !ok || email == "" {
+		h.logger.WarnContext(c.Request.Context(), "email not found in context")
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return "", false
+	}
+	return email, true
+}
@@ -0,0 +1,313 @@
+package projectclient_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strconv"
+	"testing"
+
+	messagepb "grud/api/gen/message/v1"
+	teampb "grud/api/gen/team/v1"
+	"student-service/internal/auth"
+	"student-service/internal/projectclient"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// fakeConversationService knows project 1 and a thread rooted at message 1
+// with a single reply, message 2, which was edited once and is the only
+// message listed; only alice and bob, students 1 and 2, may read the thread,
+// its history and export its messages
+type fakeConversationService struct {
+	messagepb.UnimplementedMessageServiceServer
+
+	created  []*messagepb.CreateConversationRequest
+	listed   *messagepb.ListMessagesRequest
+	exported *messagepb.ExportMessagesRequest
+}
+
+func (f *fakeConversationService) ExportMessages(req *messagepb.ExportMessagesRequest, stream messagepb.MessageService_ExportMessagesServer) error {
+	f.exported = req
+	var messages []*messagepb.Message
+	for _, m := range []*messagepb.Message{
+		{Id: 1, Email: "alice@example.com", Message: "Question", CreatedAt: timestamppb.Now()},
+		{Id: 2, Email: "bob@example.com", Message: "Answer", CreatedAt: timestamppb.Now()},
+	} {
+		readable := (req.ViewerEmail == "alice@example.com" && req.ViewerId == 1) || (req.ViewerEmail == "bob@example.com" && req.ViewerId == 2)
+		if readable && (req.Email == "" || req.Email == m.Email) {
+			messages = append(messages, m)
+		}
+	}
+	return stream.Send(&messagepb.ExportMessagesResponse{Messages: messages})
+}
+
+func (f *fakeConversationService) ListMessages(ctx context.Context, req *messagepb.ListMessagesRequest) (*messagepb.ListMessagesResponse, error) {
+	if req.PageToken == "bogus" {
+		return nil, status.Error(codes.InvalidArgument, "invalid page token")
+	}
+	f.listed = req
+	return &messagepb.ListMessagesResponse{
+		Messages:      []*messagepb.Message{{Id: 2, Email: "bob@example.com", Message: "Answer", CreatedAt: timestamppb.Now()}},
+		NextPageToken: "next",
+	}, nil
+}
+
+func (f *fakeConversationService) CreateConversation(ctx context.Context, req *messagepb.CreateConversationRequest) (*messagepb.CreateConversationResponse, error) {
+	if req.ProjectId > 1 {
+		return nil, status.Error(codes.NotFound, "project not found")
+	}
+	f.created = append(f.created, req)
+	participants := []string{req.CreatedBy}
+	if req.Recipient != "" {
+		participants = append(participants, req.Recipient)
+	}
+	return &messagepb.CreateConversationResponse{Conversation: &messagepb.Conversation{
+		Id:            int32(len(f.created)),
+		ProjectId:     req.ProjectId,
+		Subject:       req.Subject,
+		CreatedBy:     req.CreatedBy,
+		Participants:  participants,
+		CreatedAt:     timestamppb.Now(),
+		LastMessageAt: timestamppb.Now(),
+	}}, nil
+}
+
+func (f *fakeConversationService) ListConversations(ctx context.Context, req *messagepb.ListConversationsRequest) (*messagepb.ListConversationsResponse, error) {
+	resp := &messagepb.ListConversationsResponse{}
+	for i, created := range f.created {
+		if created.CreatedBy == req.Email && (req.ProjectId == 0 || created.ProjectId == req.ProjectId) {
+			resp.Conversations = append(resp.Conversations, &messagepb.Conversation{Id: int32(i + 1), ProjectId: created.ProjectId, CreatedBy: created.CreatedBy})
+		}
+	}
+	return resp, nil
+}
+
+func (f *fakeConversationService) GetThread(ctx context.Context, req *messagepb.GetThreadRequest) (*messagepb.GetThreadResponse, error) {
+	if req.MessageId != 1 && req.MessageId != 2 {
+		return nil, status.Error(codes.NotFound, "message not found")
+	}
+	if !(req.ViewerEmail == "alice@example.com" && req.ViewerId == 1) && !(req.ViewerEmail == "bob@example.com" && req.ViewerId == 2) {
+		return nil, status.Error(codes.PermissionDenied, "not a reader of the thread")
+	}
+	return &messagepb.GetThreadResponse{
+		Root:    &messagepb.Message{Id: 1, Email: "alice@example.com", Message: "Question", CreatedAt: timestamppb.Now()},
+		Replies: []*messagepb.Message{{Id: 2, Email: "bob@example.com", Message: "Answer", ParentId: 1, ThreadRootId: 1, CreatedAt: timestamppb.Now()}},
+	}, nil
+}
+
+func (f *fakeConversationService) GetEditHistory(ctx context.Context, req *messagepb.GetEditHistoryRequest) (*messagepb.GetEditHistoryResponse, error) {
+	if req.MessageId != 2 {
+		return nil, status.Error(codes.NotFound, "message not found")
+	}
+	if !(req.ViewerEmail == "alice@example.com" && req.ViewerId == 1) && !(req.ViewerEmail == "bob@example.com" && req.ViewerId == 2) {
+		return nil, status.Error(codes.PermissionDenied, "not a reader of the thread")
+	}
+	return &messagepb.GetEditHistoryResponse{
+		Message: &messagepb.Message{Id: 2, Email: "bob@example.com", Message: "Answer", Edited: true, EditedAt: timestamppb.Now(), CreatedAt: timestamppb.Now()},
+		Edits:   []*messagepb.MessageEdit{{Message: "Anwser", EditedAt: timestamppb.Now()}},
+	}, nil
+}
+
+func TestConversations(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	server := grpc.NewServer()
+	fake := &fakeConversationService{}
+	messagepb.RegisterMessageServiceServer(server, fake)
+	// Team 1 of project 1 has alice, student 1, as its only member
+	teampb.RegisterTeamServiceServer(server, &fakeTeamService{teams: []*teampb.Team{{Id: 1, ProjectId: 1, MemberIds: []int32{1}, CreatedAt: timestamppb.Now()}}})
+	go server.Serve(lis)
+	defer server.Stop()
+
+	client, err := projectclient.NewGrpcClient(lis.Addr().String())
+	require.NoError(t, err)
+	defer client.Close()
+
+	// The X-Email header stands in for the email AuthMiddleware takes from the
+	// token; alice is student 1 and bob student 2
+	students := map[string]int{"alice@example.com": 1, "bob@example.com": 2, "carol@example.com": 3}
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		if email := c.GetHeader("X-Email"); email != "" {
+			ctx := context.WithValue(c.Request.Context(), auth.EmailKey, email)
+			if id, err := strconv.Atoi(c.GetHeader("X-Student")); err == nil {
+				ctx = context.WithValue(ctx, auth.StudentIDKey, id)
+			}
+			c.Request = c.Request.WithContext(ctx)
+		}
+	})
+	projectclient.NewHandler(client, slog.New(slog.NewTextHandler(os.Stderr, nil)), nil).RegisterRoutes(router)
+
+	do := func(email, method, target string, body interface{}) *httptest.ResponseRecorder {
+		var buf bytes.Buffer
+		if body != nil {
+			require.NoError(t, json.NewEncoder(&buf).Encode(body))
+		}
+		req := httptest.NewRequest(method, target, &buf)
+		req.Header.Set("Content-Type", "application/json")
+		if email != "" {
+			req.Header.Set("X-Email", email)
+			req.Header.Set("X-Student", strconv.Itoa(students[email]))
+		}
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	const alice = "alice@example.com"
+
+	w := do(alice, http.MethodPost, "/conversations", gin.H{"to": "bob@example.com", "subject": "Deadline"})
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+	var conversation projectclient.Conversation
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &conversation))
+	assert.Equal(t, []string{alice, "bob@example.com"}, conversation.Participants)
+	assert.Equal(t, "Deadline", conversation.Subject)
+
+	w = do(alice, http.MethodPost, "/conversations", gin.H{"projectId": 1})
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+	assert.Equal(t, alice, fake.created[1].CreatedBy)
+
+	w = do(alice, http.MethodPost, "/conversations", gin.H{"projectId": 7})
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	w = do(alice, http.MethodPost, "/conversations", gin.H{"projectId": 1, "to": "bob@example.com"})
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	w = do(alice, http.MethodPost, "/conversations", gin.H{"to": "not an email"})
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	w = do("", http.MethodPost, "/conversations", gin.H{"projectId": 1})
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+
+	w = do(alice, http.MethodGet, "/conversations?projectId=1", nil)
+	require.Equal(t, http.StatusOK, w.Code)
+	var conversations []projectclient.Conversation
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &conversations))
+	require.Len(t, conversations, 1)
+	assert.Equal(t, 2, conversations[0].ID)
+
+	w = do(alice, http.MethodGet, "/conversations?projectId=x", nil)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	w = do(alice, http.MethodGet, "/messages/2/thread", nil)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	var thread projectclient.Thread
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &thread))
+	assert.Equal(t, 1, thread.Root.ID)
+	require.Len(t, thread.Replies, 1)
+	assert.Equal(t, 1, thread.Replies[0].ParentID)
+	assert.Nil(t, thread.Conversation)
+
+	w = do(alice, http.MethodGet, "/messages/9/thread", nil)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	w = do("carol@example.com", http.MethodGet, "/messages/2/thread", nil)
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	w = do("", http.MethodGet, "/messages/2/thread", nil)
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+
+	w = do(alice, http.MethodGet, "/messages/x/thread", nil)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	w = do(alice, http.MethodGet, "/messages/2/history", nil)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	var history projectclient.EditHistory
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &history))
+	assert.True(t, history.Message.Edited)
+	assert.NotNil(t, history.Message.EditedAt)
+	assert.False(t, history.Message.Deleted)
+	require.Len(t, history.Edits, 1)
+	assert.Equal(t, "Anwser", history.Edits[0].Message)
+
+	w = do(alice, http.MethodGet, "/messages/9/history", nil)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	w = do("carol@example.com", http.MethodGet, "/messages/2/history", nil)
+	assert.Equal(t, http.StatusForbidden, w.Code)
+
+	w = do(alice, http.MethodGet, "/messages?limit=20&cursor=abc&sender=bob@example.com&recipient=alice@example.com&projectId=1&teamId=1&q=ans&createdAfter=2024-03-01T00:00:00Z", nil)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	var page projectclient.MessagePage
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &page))
+	require.Len(t, page.Items, 1)
+	assert.Equal(t, "Answer", page.Items[0].Message)
+	assert.Equal(t, "next", page.NextCursor)
+	assert.Equal(t, int32(20), fake.listed.PageSize)
+	assert.Equal(t, "abc", fake.listed.PageToken)
+	assert.Equal(t, "bob@example.com", fake.listed.Sender)
+	assert.Equal(t, alice, fake.listed.Recipient)
+	assert.Equal(t, int32(1), fake.listed.ProjectId)
+	assert.Equal(t, int32(1), fake.listed.TeamId)
+	assert.Equal(t, "ans", fake.listed.TextContains)
+	assert.Equal(t, int64(1709251200), fake.listed.CreatedAfter.Seconds)
+	assert.Nil(t, fake.listed.CreatedBefore)
+
+	// ?email= still filters by sender; others' messages are only listed as
+	// far as they were sent to the caller
+	w = do(alice, http.MethodGet, "/messages?email=bob@example.com", nil)
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "bob@example.com", fake.listed.Sender)
+	assert.Equal(t, alice, fake.listed.Recipient)
+
+	w = do(alice, http.MethodGet, "/messages?sender=alice@example.com", nil)
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Empty(t, fake.listed.Recipient)
+
+	w = do(alice, http.MethodGet, "/messages?projectId=1", nil)
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, alice, fake.listed.Recipient)
+
+	// Team messages are listed for the team's members only
+	w = do(alice, http.MethodGet, "/messages?projectId=1&teamId=1", nil)
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Empty(t, fake.listed.Recipient)
+	w = do("bob@example.com", http.MethodGet, "/messages?projectId=1&teamId=1", nil)
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	w = do(alice, http.MethodGet, "/messages?teamId=1", nil)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	w = do(alice, http.MethodGet, "/messages?recipient=bob@example.com", nil)
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	w = do(alice, http.MethodGet, "/messages", nil)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	w = do(alice, http.MethodGet, "/messages?q=secret", nil)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	w = do("", http.MethodGet, "/messages?sender=bob@example.com", nil)
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+
+	w = do(alice, http.MethodGet, "/messages?sender=alice@example.com&cursor=bogus", nil)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	w = do(alice, http.MethodGet, "/messages?teamId=x", nil)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	w = do(alice, http.MethodGet, "/messages?createdBefore=yesterday", nil)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	// Exports are limited to what the caller may read, whoever ?email= names
+	w = do(alice, http.MethodGet, "/messages/export?email=bob@example.com", nil)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	assert.Equal(t, "bob@example.com", fake.exported.Email)
+	assert.Equal(t, alice, fake.exported.ViewerEmail)
+	assert.Equal(t, int32(1), fake.exported.ViewerId)
+	assert.Contains(t, w.Body.String(), "Answer")
+
+	w = do("carol@example.com", http.MethodGet, "/messages/export?email=bob@example.com", nil)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	assert.Equal(t, "carol@example.com", fake.exported.ViewerEmail)
+	assert.Equal(t, "id,email,message,createdAt\n", w.Body.String())
+
+	w = do("carol@example.com", http.MethodGet, "/messages/export", nil)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	assert.NotContains(t, w.Body.String(), "Question")
+
+	w = do("", http.MethodGet, "/messages/export?email=bob@example.com", nil)
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+}
@@ -0,0 +1,143 @@
+package projectclient
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+var messageExportContentTypes = map[string]string{
+	"csv":    "text/csv; charset=utf-8",
+	"ndjson": "application/x-ndjson",
+}
+
+// ExportMessages streams messages from the ExportMessages RPC as CSV or NDJSON
+// (?format=, default csv), filtered by ?email=&createdAfter=&createdBefore=.
+// Only messages the caller may read are exported: their own, those of their
+// conversations and those of their teams and projects. The export stops when
+// the client goes away.
+func (h *Handler) ExportMessages(c *gin.Context) {
+	email, ok := h.currentEmail(c)
+	if !ok {
+		return
+	}
+	studentID, ok := h.currentStudent(c)
+	if !ok {
+		return
+	}
+
+	format := c.DefaultQuery("format", "csv")
+	contentType, ok := messageExportContentTypes[format]
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "format must be csv or ndjson"})
+		return
+	}
+
+	opts := ExportMessagesOptions{ViewerEmail: email, ViewerID: studentID, Email: c.Query("email")}
+	for param, dst := range map[string]*time.Time{
+		"createdAfter":  &opts.CreatedAfter,
+		"createdBefore": &opts.CreatedBefore,
+	} {
+		if v := c.Query(param); v != "" {
+			t, err := time.Parse(time.RFC3339, v)
+			if err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid query parameters"})
+				return
+			}
+			*dst = t
+		}
+	}
+
+	if h.grpcClient == nil {
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": "gRPC client not available"})
+		return
+	}
+
+	ctx := c.Request.Context()
+	h.logger.InfoContext(ctx, "exporting messages via gRPC", "email", opts.Email, "viewer_email", email, "format", format)
+	export, err := h.grpcClient.ExportMessages(ctx, opts)
+	if err != nil {
+		h.handleGrpcError(c, err, "Failed to export messages")
+		return
+	}
+
+	// Read the first batch before committing to a status, so that rejected
+	// requests still get a proper error response
+	batch, err := export.Next()
+	if err != nil && err != io.EOF {
+		h.handleGrpcError(c, err, "Failed to export messages")
+		return
+	}
+
+	// Exports can outlive the server's write timeout
+	_ = http.NewResponseController(c.Writer).SetWriteDeadline(time.Time{})
+
+	c.Header("Content-Type", contentType)
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s.%s"`, export.Filename, format))
+	c.Header("X-Content-Type-Options", "nosniff")
+	c.Status(http.StatusOK)
+
+	mw, err := newMessageWriter(format, c.Writer)
+	rows := 0
+	for err == nil {
+		for i := range batch {
+			if err = mw.write(&batch[i]); err != nil {
+				break
+			}
+		}
+		if err == nil {
+			err = mw.flush()
+		}
+		if err != nil {
+			break
+		}
+		rows += len(batch)
+		c.Writer.Flush()
+		batch, err = export.Next()
+	}
+
+	// The status is already sent, so a failed export can only be cut short
+	switch {
+	case err == io.EOF:
+		h.logger.InfoContext(ctx, "message export finished", "rows", rows)
+	case ctx.Err() != nil:
+		h.logger.InfoContext(ctx, "message export cancelled by client", "rows", rows)
+	default:
+		h.logger.ErrorContext(ctx, "message export failed", "error", err, "rows", rows)
+	}
+}
+
+// messageWriter encodes exported messages; flush pushes buffered output out
+type messageWriter struct {
+	write func(*Message) error
+	flush func() error
+}
+
+// newMessageWriter returns a writer for format. CSV output starts with a header row.
+func newMessageWriter(format string, w io.Writer) (*messageWriter, error) {
+	if format == "ndjson" {
+		enc := json.NewEncoder(w)
+		return &messageWriter{
+			write: func(m *Message) error { return enc.Encode(m) },
+			flush: func() error { return nil },
+		}, nil
+	}
+
+	cw := csv.NewWriter(w)
+	mw := &messageWriter{
+		write: func(m *Message) error {
+			return cw.Write([]string{strconv.Itoa(m.ID), m.Email, m.Message, m.CreatedAt.Format(time.RFC3339Nano)})
+		},
+		flush: func() error {
+			cw.Flush()
+			return cw.Error()
+		},
+	}
+	return mw, cw.Write([]string{"id", "email", "message", "createdAt"})
+}
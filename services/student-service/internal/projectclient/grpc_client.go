@@ -3,82 +3,1049 @@ package projectclient
 import (
 	"context"
 	"fmt"
+	"io"
+	"mime"
+	"path"
+	"slices"
+	"strings"
 	"time"
 
+	attachmentpb "grud/api/gen/attachment/v1"
 	messagepb "grud/api/gen/message/v1"
 	projectpb "grud/api/gen/project/v1"
+	submissionpb "grud/api/gen/submission/v1"
+	teampb "grud/api/gen/team/v1"
+	"grud/common/history"
 
 	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 type GrpcClient struct {
-	conn          *grpc.ClientConn
-	projectClient projectpb.ProjectServiceClient
-	messageClient messagepb.MessageServiceClient
+	conn             *grpc.ClientConn
+	projectClient    projectpb.ProjectServiceClient
+	messageClient    messagepb.MessageServiceClient
+	attachmentClient attachmentpb.AttachmentServiceClient
+	submissionClient submissionpb.SubmissionServiceClient
+	teamClient       teampb.TeamServiceClient
 }
 
 func NewGrpcClient(address string) (*GrpcClient, error) {
 	conn, err := grpc.NewClient(address,
 		grpc.WithTransportCredentials(insecure.NewCredentials()),
 		grpc.WithStatsHandler(otelgrpc.NewClientHandler()),
+		// Tell project-service who the change is made for, for its history
+		grpc.WithChainUnaryInterceptor(history.UnaryClientInterceptor()),
+		grpc.WithChainStreamInterceptor(history.StreamClientInterceptor()),
 	)
 	if err != nil {
 		return nil, fmt.Errorf("failed to connect to gRPC server: %w", err)
 	}
 
 	return &GrpcClient{
-		conn:          conn,
-		projectClient: projectpb.NewProjectServiceClient(conn),
-		messageClient: messagepb.NewMessageServiceClient(conn),
+		conn:             conn,
+		projectClient:    projectpb.NewProjectServiceClient(conn),
+		messageClient:    messagepb.NewMessageServiceClient(conn),
+		attachmentClient: attachmentpb.NewAttachmentServiceClient(conn),
+		submissionClient: submissionpb.NewSubmissionServiceClient(conn),
+		teamClient:       teampb.NewTeamServiceClient(conn),
 	}, nil
 }
 
+// GetAllProjects returns every project by walking all ListProjects pages
 func (c *GrpcClient) GetAllProjects(ctx context.Context) ([]Project, error) {
+	var projects []Project
+	opts := ListProjectsOptions{PageSize: 1000}
+	for {
+		page, err := c.ListProjects(ctx, opts)
+		if err != nil {
+			return nil, err
+		}
+		projects = append(projects, page.Items...)
+		if page.NextCursor == "" {
+			return projects, nil
+		}
+		opts.PageToken = page.NextCursor
+	}
+}
+
+func (c *GrpcClient) ListProjects(ctx context.Context, opts ListProjectsOptions) (*ProjectPage, error) {
 	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
 	defer cancel()
 
-	resp, err := c.projectClient.GetAllProjects(ctx, &projectpb.GetAllProjectsRequest{})
+	resp, err := c.projectClient.ListProjects(ctx, &projectpb.ListProjectsRequest{
+		PageSize:      int32(opts.PageSize),
+		PageToken:     opts.PageToken,
+		NameContains:  opts.NameContains,
+		Status:        statusToProto(opts.Status),
+		CreatedAfter:  timeToProto(opts.CreatedAfter),
+		CreatedBefore: timeToProto(opts.CreatedBefore),
+		UpdatedAfter:  timeToProto(opts.UpdatedAfter),
+		UpdatedBefore: timeToProto(opts.UpdatedBefore),
+		OrderBy:       opts.OrderBy,
+		Tags:          opts.Tags,
+		TagMatch:      tagMatchToProto(opts.TagMatch),
+	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to call GetAllProjects: %w", err)
+		return nil, fmt.Errorf("failed to call ListProjects: %w", err)
 	}
 
-	projects := make([]Project, len(resp.Projects))
+	page := &ProjectPage{
+		Items:      make([]Project, len(resp.Projects)),
+		NextCursor: resp.NextPageToken,
+	}
 	for i, pbProj := range resp.Projects {
-		projects[i] = Project{
-			ID:        int(pbProj.Id),
-			Name:      pbProj.Name,
-			CreatedAt: pbProj.CreatedAt.AsTime(),
-			UpdatedAt: pbProj.UpdatedAt.AsTime(),
+		page.Items[i] = projectFromProto(pbProj)
+	}
+
+	return page, nil
+}
+
+// SearchProjects returns up to limit projects matching the full-text query, best match first
+func (c *GrpcClient) SearchProjects(ctx context.Context, query string, limit int) ([]ProjectSearchResult, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	resp, err := c.projectClient.SearchProjects(ctx, &projectpb.SearchProjectsRequest{
+		Query:    query,
+		PageSize: int32(limit),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to call SearchProjects: %w", err)
+	}
+
+	results := make([]ProjectSearchResult, len(resp.Results))
+	for i, r := range resp.Results {
+		results[i] = ProjectSearchResult{
+			Project: projectFromProto(r.Project),
+			Rank:    r.Rank,
+			Highlights: map[string]string{
+				"name":        r.NameHighlight,
+				"description": r.DescriptionHighlight,
+			},
+		}
+	}
+	return results, nil
+}
+
+func (c *GrpcClient) GetProject(ctx context.Context, id int) (*Project, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	resp, err := c.projectClient.GetProject(ctx, &projectpb.GetProjectRequest{
+		Id: int32(id),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to call GetProject: %w", err)
+	}
+
+	project := projectFromProto(resp.Project)
+	return &project, nil
+}
+
+func (c *GrpcClient) CreateProject(ctx context.Context, req ProjectRequest) (*Project, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	resp, err := c.projectClient.CreateProject(ctx, &projectpb.CreateProjectRequest{
+		Name:        req.Name,
+		Description: req.Description,
+		StartDate:   optionalTimeToProto(req.StartDate),
+		DueDate:     optionalTimeToProto(req.DueDate),
+		Tags:        req.Tags,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to call CreateProject: %w", err)
+	}
+
+	project := projectFromProto(resp.Project)
+	return &project, nil
+}
+
+// UpdateProject replaces all editable fields of the project with req; the tags
+// only when req.Tags is not nil. A non-zero req.Version makes the update fail
+// with codes.Aborted if the project has moved on.
+func (c *GrpcClient) UpdateProject(ctx context.Context, id int, req ProjectRequest) (*Project, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	paths := []string{"name", "description", "start_date", "due_date"}
+	if req.Tags != nil {
+		paths = append(paths, "tags")
+	}
+
+	resp, err := c.projectClient.UpdateProject(ctx, &projectpb.UpdateProjectRequest{
+		Id:          int32(id),
+		Name:        req.Name,
+		Description: req.Description,
+		StartDate:   optionalTimeToProto(req.StartDate),
+		DueDate:     optionalTimeToProto(req.DueDate),
+		Tags:        req.Tags,
+		Version:     int64(req.Version),
+		UpdateMask:  &fieldmaskpb.FieldMask{Paths: paths},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to call UpdateProject: %w", err)
+	}
+
+	project := projectFromProto(resp.Project)
+	return &project, nil
+}
+
+// ListTags returns the tags starting with prefix, sorted by name
+func (c *GrpcClient) ListTags(ctx context.Context, prefix string) ([]Tag, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	resp, err := c.projectClient.ListTags(ctx, &projectpb.ListTagsRequest{
+		Prefix: prefix,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to call ListTags: %w", err)
+	}
+
+	tags := make([]Tag, len(resp.Tags))
+	for i, t := range resp.Tags {
+		tags[i] = tagFromProto(t)
+	}
+	return tags, nil
+}
+
+func (c *GrpcClient) RenameTag(ctx context.Context, name, newName string) (*Tag, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	resp, err := c.projectClient.RenameTag(ctx, &projectpb.RenameTagRequest{
+		Name:    name,
+		NewName: newName,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to call RenameTag: %w", err)
+	}
+
+	tag := tagFromProto(resp.Tag)
+	return &tag, nil
+}
+
+func (c *GrpcClient) MergeTags(ctx context.Context, sources []string, target string) (*Tag, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	resp, err := c.projectClient.MergeTags(ctx, &projectpb.MergeTagsRequest{
+		Sources: sources,
+		Target:  target,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to call MergeTags: %w", err)
+	}
+
+	tag := tagFromProto(resp.Tag)
+	return &tag, nil
+}
+
+func (c *GrpcClient) TransitionProject(ctx context.Context, id int, status string) (*Project, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	resp, err := c.projectClient.TransitionProject(ctx, &projectpb.TransitionProjectRequest{
+		Id:     int32(id),
+		Status: statusToProto(status),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to call TransitionProject: %w", err)
+	}
+
+	project := projectFromProto(resp.Project)
+	return &project, nil
+}
+
+func (c *GrpcClient) DeleteProject(ctx context.Context, id int) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	_, err := c.projectClient.DeleteProject(ctx, &projectpb.DeleteProjectRequest{
+		Id: int32(id),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to call DeleteProject: %w", err)
+	}
+
+	return nil
+}
+
+func (c *GrpcClient) RestoreProject(ctx context.Context, id int) (*Project, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	resp, err := c.projectClient.RestoreProject(ctx, &projectpb.RestoreProjectRequest{
+		Id: int32(id),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to call RestoreProject: %w", err)
+	}
+
+	project := projectFromProto(resp.Project)
+	return &project, nil
+}
+
+func (c *GrpcClient) GetProjectHistory(ctx context.Context, id int, pageSize int, pageToken string) (*HistoryPage, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	resp, err := c.projectClient.GetProjectHistory(ctx, &projectpb.GetProjectHistoryRequest{
+		Id:        int32(id),
+		PageSize:  int32(pageSize),
+		PageToken: pageToken,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to call GetProjectHistory: %w", err)
+	}
+
+	page := &HistoryPage{
+		Items:      make([]HistoryEntry, len(resp.Entries)),
+		NextCursor: resp.NextPageToken,
+	}
+	for i, e := range resp.Entries {
+		page.Items[i] = HistoryEntry{
+			ID:        e.Id,
+			Action:    e.Action,
+			Actor:     e.Actor,
+			Before:    e.Before.AsMap(),
+			After:     e.After.AsMap(),
+			CreatedAt: e.CreatedAt.AsTime(),
+		}
+	}
+	return page, nil
+}
+
+// ListMessages returns a page of the messages matching opts, newest first
+func (c *GrpcClient) ListMessages(ctx context.Context, opts ListMessagesOptions) (*MessagePage, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	resp, err := c.messageClient.ListMessages(ctx, &messagepb.ListMessagesRequest{
+		PageSize:      int32(opts.PageSize),
+		PageToken:     opts.PageToken,
+		Sender:        opts.Sender,
+		Recipient:     opts.Recipient,
+		ProjectId:     int32(opts.ProjectID),
+		TeamId:        int32(opts.TeamID),
+		TextContains:  opts.TextContains,
+		CreatedAfter:  timeToProto(opts.CreatedAfter),
+		CreatedBefore: timeToProto(opts.CreatedBefore),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to call ListMessages: %w", err)
+	}
+
+	return &MessagePage{
+		Items:      messagesFromProto(resp.Messages),
+		NextCursor: resp.NextPageToken,
+	}, nil
+}
+
+// CreateConversation starts a conversation created by email, either within the
+// project or with the recipient
+func (c *GrpcClient) CreateConversation(ctx context.Context, email string, req ConversationRequest) (*Conversation, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	resp, err := c.messageClient.CreateConversation(ctx, &messagepb.CreateConversationRequest{
+		CreatedBy: email,
+		ProjectId: int32(req.ProjectID),
+		Recipient: req.To,
+		Subject:   req.Subject,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to call CreateConversation: %w", err)
+	}
+
+	conversation := conversationFromProto(resp.Conversation)
+	return &conversation, nil
+}
+
+// ListConversations returns the conversations email participates in, limited
+// to the project's if projectID is set, most recently active first
+func (c *GrpcClient) ListConversations(ctx context.Context, email string, projectID int) ([]Conversation, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	resp, err := c.messageClient.ListConversations(ctx, &messagepb.ListConversationsRequest{
+		Email:     email,
+		ProjectId: int32(projectID),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to call ListConversations: %w", err)
+	}
+
+	conversations := make([]Conversation, len(resp.Conversations))
+	for i, conversation := range resp.Conversations {
+		conversations[i] = conversationFromProto(conversation)
+	}
+	return conversations, nil
+}
+
+// GetThread returns the whole thread the message belongs to, as read by the
+// given student
+func (c *GrpcClient) GetThread(ctx context.Context, messageID int, viewerEmail string, viewerID int) (*Thread, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	resp, err := c.messageClient.GetThread(ctx, &messagepb.GetThreadRequest{
+		MessageId:   int32(messageID),
+		ViewerEmail: viewerEmail,
+		ViewerId:    int32(viewerID),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to call GetThread: %w", err)
+	}
+
+	thread := &Thread{
+		Root:    messageFromProto(resp.Root),
+		Replies: messagesFromProto(resp.Replies),
+	}
+	if resp.Conversation != nil {
+		conversation := conversationFromProto(resp.Conversation)
+		thread.Conversation = &conversation
+	}
+	return thread, nil
+}
+
+// GetEditHistory returns the message with its previous texts, as read by the
+// given student
+func (c *GrpcClient) GetEditHistory(ctx context.Context, messageID int, viewerEmail string, viewerID int) (*EditHistory, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	resp, err := c.messageClient.GetEditHistory(ctx, &messagepb.GetEditHistoryRequest{
+		MessageId:   int32(messageID),
+		ViewerEmail: viewerEmail,
+		ViewerId:    int32(viewerID),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to call GetEditHistory: %w", err)
+	}
+
+	history := &EditHistory{
+		Message: messageFromProto(resp.Message),
+		Edits:   make([]MessageEdit, len(resp.Edits)),
+	}
+	for i, edit := range resp.Edits {
+		history.Edits[i] = MessageEdit{Message: edit.Message, EditedAt: edit.EditedAt.AsTime()}
+	}
+	return history, nil
+}
+
+// MarkRead marks the message read by email
+func (c *GrpcClient) MarkRead(ctx context.Context, email string, messageID int) (*ReadReceipt, error) {
+	return c.markRead(ctx, &messagepb.MarkReadRequest{Email: email, MessageId: int32(messageID)})
+}
+
+// MarkReadUpTo marks read by email every message of its conversations created
+// up to upTo, only those of the conversation if conversationID is set
+func (c *GrpcClient) MarkReadUpTo(ctx context.Context, email string, upTo time.Time, conversationID int) (*ReadReceipt, error) {
+	return c.markRead(ctx, &messagepb.MarkReadRequest{
+		Email:          email,
+		UpTo:           timestamppb.New(upTo),
+		ConversationId: int32(conversationID),
+	})
+}
+
+func (c *GrpcClient) markRead(ctx context.Context, req *messagepb.MarkReadRequest) (*ReadReceipt, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	resp, err := c.messageClient.MarkRead(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to call MarkRead: %w", err)
+	}
+
+	return &ReadReceipt{Marked: int(resp.Marked), ReadAt: resp.ReadAt.AsTime()}, nil
+}
+
+// GetUnreadCounts counts the unread messages of email's conversations
+func (c *GrpcClient) GetUnreadCounts(ctx context.Context, email string) (*UnreadCounts, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	resp, err := c.messageClient.GetUnreadCounts(ctx, &messagepb.GetUnreadCountsRequest{Email: email})
+	if err != nil {
+		return nil, fmt.Errorf("failed to call GetUnreadCounts: %w", err)
+	}
+
+	counts := &UnreadCounts{
+		Total:         int(resp.Total),
+		Conversations: make([]UnreadCount, len(resp.Conversations)),
+	}
+	for i, count := range resp.Conversations {
+		counts.Conversations[i] = UnreadCount{ConversationID: int(count.ConversationId), Unread: int(count.Unread)}
+	}
+	return counts, nil
+}
+
+// SearchMessages returns up to limit messages matching the full-text query
+// that the viewer may read, best match first
+func (c *GrpcClient) SearchMessages(ctx context.Context, query string, limit int, viewerEmail string, viewerID int) ([]MessageSearchResult, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	resp, err := c.messageClient.SearchMessages(ctx, &messagepb.SearchMessagesRequest{
+		Query:       query,
+		PageSize:    int32(limit),
+		ViewerEmail: viewerEmail,
+		ViewerId:    int32(viewerID),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to call SearchMessages: %w", err)
+	}
+
+	results := make([]MessageSearchResult, len(resp.Results))
+	for i, r := range resp.Results {
+		results[i] = MessageSearchResult{
+			Message:    messageFromProto(r.Message),
+			Rank:       r.Rank,
+			Highlights: map[string]string{"message": r.Highlight},
+		}
+	}
+	return results, nil
+}
+
+// MessageExport is an open ExportMessages stream
+type MessageExport struct {
+	// Filename is the file name suggested by project-service, without extension
+	Filename string
+	stream   messagepb.MessageService_ExportMessagesClient
+}
+
+// Next returns the next batch of messages, or io.EOF after the last one
+func (e *MessageExport) Next() ([]Message, error) {
+	resp, err := e.stream.Recv()
+	if err != nil {
+		return nil, err
+	}
+	return messagesFromProto(resp.Messages), nil
+}
+
+// ExportMessages opens a message export. It has no timeout of its own; the
+// export ends when ctx is cancelled.
+func (c *GrpcClient) ExportMessages(ctx context.Context, opts ExportMessagesOptions) (*MessageExport, error) {
+	stream, err := c.messageClient.ExportMessages(ctx, &messagepb.ExportMessagesRequest{
+		ViewerEmail:   opts.ViewerEmail,
+		ViewerId:      int32(opts.ViewerID),
+		Email:         opts.Email,
+		CreatedAfter:  timeToProto(opts.CreatedAfter),
+		CreatedBefore: timeToProto(opts.CreatedBefore),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to call ExportMessages: %w", err)
+	}
+
+	header, err := stream.Header()
+	if err != nil {
+		return nil, fmt.Errorf("failed to call ExportMessages: %w", err)
+	}
+	export := &MessageExport{Filename: "messages", stream: stream}
+	if values := header.Get("content-disposition"); len(values) > 0 {
+		if _, params, err := mime.ParseMediaType(values[0]); err == nil && params["filename"] != "" {
+			export.Filename = strings.TrimSuffix(params["filename"], path.Ext(params["filename"]))
+		}
+	}
+	return export, nil
+}
+
+// AddMember adds a student to a project on behalf of actorID, who must be an
+// owner or instructor of the project to grant either role
+func (c *GrpcClient) AddMember(ctx context.Context, projectID, studentID, actorID int, role string) (*Member, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	resp, err := c.projectClient.AddMember(ctx, &projectpb.AddMemberRequest{
+		ProjectId: int32(projectID),
+		StudentId: int32(studentID),
+		Role:      roleToProto(role),
+		ActorId:   int32(actorID),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to call AddMember: %w", err)
+	}
+
+	member := memberFromProto(resp.Member)
+	return &member, nil
+}
+
+// RemoveMember removes a student from a project on behalf of actorID, who must
+// be the student or an owner or instructor of the project
+func (c *GrpcClient) RemoveMember(ctx context.Context, projectID, studentID, actorID int) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	_, err := c.projectClient.RemoveMember(ctx, &projectpb.RemoveMemberRequest{
+		ProjectId: int32(projectID),
+		StudentId: int32(studentID),
+		ActorId:   int32(actorID),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to call RemoveMember: %w", err)
+	}
+
+	return nil
+}
+
+// ListMembers returns the project's members, only those of the team if teamID is set
+func (c *GrpcClient) ListMembers(ctx context.Context, projectID, teamID int) ([]Member, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	resp, err := c.projectClient.ListMembers(ctx, &projectpb.ListMembersRequest{
+		ProjectId: int32(projectID),
+		TeamId:    int32(teamID),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to call ListMembers: %w", err)
+	}
+
+	members := make([]Member, len(resp.Members))
+	for i, pbMember := range resp.Members {
+		members[i] = memberFromProto(pbMember)
+	}
+
+	return members, nil
+}
+
+func (c *GrpcClient) ListProjectsForStudent(ctx context.Context, studentID int) ([]StudentProject, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	resp, err := c.projectClient.ListProjectsForStudent(ctx, &projectpb.ListProjectsForStudentRequest{
+		StudentId: int32(studentID),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to call ListProjectsForStudent: %w", err)
+	}
+
+	projects := make([]StudentProject, len(resp.Projects))
+	for i, pbProj := range resp.Projects {
+		projects[i] = StudentProject{
+			Project: projectFromProto(pbProj.Project),
+			Role:    roleFromProto(pbProj.Role),
 		}
 	}
 
 	return projects, nil
 }
 
-func (c *GrpcClient) GetMessagesByEmail(ctx context.Context, email string) ([]Message, error) {
+// attachmentChunkSize is the size of the chunks uploads are sent in
+const attachmentChunkSize = 64 << 10
+
+// UploadAttachment streams size bytes read from r to project-service, which
+// stores them only if they match the size and the hex encoded SHA-256
+// checksum. It has no timeout of its own; the upload ends when ctx is
+// cancelled.
+func (c *GrpcClient) UploadAttachment(ctx context.Context, projectID int, filename string, size int64, checksum string, r io.Reader) (*Attachment, error) {
+	stream, err := c.attachmentClient.UploadAttachment(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to call UploadAttachment: %w", err)
+	}
+
+	err = stream.Send(&attachmentpb.UploadAttachmentRequest{
+		Payload: &attachmentpb.UploadAttachmentRequest_Metadata{Metadata: &attachmentpb.UploadMetadata{
+			ProjectId: int32(projectID),
+			Filename:  filename,
+			Size:      size,
+			Sha256:    checksum,
+		}},
+	})
+	buf := make([]byte, attachmentChunkSize)
+	for err == nil {
+		n, readErr := r.Read(buf)
+		if n > 0 {
+			err = stream.Send(&attachmentpb.UploadAttachmentRequest{
+				Payload: &attachmentpb.UploadAttachmentRequest_Chunk{Chunk: buf[:n]},
+			})
+		}
+		if readErr == io.EOF {
+			break
+		}
+		if readErr != nil {
+			return nil, fmt.Errorf("failed to read attachment: %w", readErr)
+		}
+	}
+	// Send fails with io.EOF once the server has answered; CloseAndRecv
+	// returns that answer
+	if err != nil && err != io.EOF {
+		return nil, fmt.Errorf("failed to call UploadAttachment: %w", err)
+	}
+
+	resp, err := stream.CloseAndRecv()
+	if err != nil {
+		return nil, fmt.Errorf("failed to call UploadAttachment: %w", err)
+	}
+	attachment := attachmentFromProto(resp.Attachment)
+	return &attachment, nil
+}
+
+// AttachmentDownload is an open DownloadAttachment stream. Reading it yields
+// the contents of Attachment.
+type AttachmentDownload struct {
+	Attachment Attachment
+	stream     attachmentpb.AttachmentService_DownloadAttachmentClient
+	pending    []byte
+}
+
+func (d *AttachmentDownload) Read(p []byte) (int, error) {
+	for len(d.pending) == 0 {
+		resp, err := d.stream.Recv()
+		if err != nil {
+			return 0, err
+		}
+		d.pending = resp.GetChunk()
+	}
+	n := copy(p, d.pending)
+	d.pending = d.pending[n:]
+	return n, nil
+}
+
+// DownloadAttachment opens an attachment for reading. It has no timeout of
+// its own; the download ends when ctx is cancelled.
+func (c *GrpcClient) DownloadAttachment(ctx context.Context, id int) (*AttachmentDownload, error) {
+	stream, err := c.attachmentClient.DownloadAttachment(ctx, &attachmentpb.DownloadAttachmentRequest{
+		Id: int32(id),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to call DownloadAttachment: %w", err)
+	}
+
+	// The first message carries the attachment, or the error
+	first, err := stream.Recv()
+	if err != nil {
+		return nil, fmt.Errorf("failed to call DownloadAttachment: %w", err)
+	}
+	if first.GetAttachment() == nil {
+		return nil, fmt.Errorf("failed to call DownloadAttachment: the first message carries no attachment")
+	}
+	return &AttachmentDownload{
+		Attachment: attachmentFromProto(first.GetAttachment()),
+		stream:     stream,
+	}, nil
+}
+
+func (c *GrpcClient) ListAttachments(ctx context.Context, projectID int) ([]Attachment, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	resp, err := c.attachmentClient.ListAttachments(ctx, &attachmentpb.ListAttachmentsRequest{
+		ProjectId: int32(projectID),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to call ListAttachments: %w", err)
+	}
+
+	attachments := make([]Attachment, len(resp.Attachments))
+	for i, pbAttachment := range resp.Attachments {
+		attachments[i] = attachmentFromProto(pbAttachment)
+	}
+	return attachments, nil
+}
+
+func (c *GrpcClient) DeleteAttachment(ctx context.Context, id int) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	_, err := c.attachmentClient.DeleteAttachment(ctx, &attachmentpb.DeleteAttachmentRequest{
+		Id: int32(id),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to call DeleteAttachment: %w", err)
+	}
+
+	return nil
+}
+
+// CreateSubmission hands in a new version of the student's work on the project
+func (c *GrpcClient) CreateSubmission(ctx context.Context, projectID, studentID int, req SubmissionRequest) (*Submission, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	resp, err := c.submissionClient.CreateSubmission(ctx, &submissionpb.CreateSubmissionRequest{
+		ProjectId:     int32(projectID),
+		StudentId:     int32(studentID),
+		Text:          req.Text,
+		AttachmentIds: idsToProto(req.AttachmentIDs),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to call CreateSubmission: %w", err)
+	}
+
+	submission := submissionFromProto(resp.Submission)
+	return &submission, nil
+}
+
+// GetSubmission returns a submission to viewerID, who must be its author or
+// an instructor of its project
+func (c *GrpcClient) GetSubmission(ctx context.Context, id, viewerID int) (*Submission, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	resp, err := c.submissionClient.GetSubmission(ctx, &submissionpb.GetSubmissionRequest{
+		Id:       int32(id),
+		ViewerId: int32(viewerID),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to call GetSubmission: %w", err)
+	}
+
+	submission := submissionFromProto(resp.Submission)
+	return &submission, nil
+}
+
+// ListSubmissions returns the submissions to a project, by a student or
+// both, newest first. Zero IDs match any. Unless viewerID is an instructor
+// of the project, only their own submissions are returned.
+func (c *GrpcClient) ListSubmissions(ctx context.Context, projectID, studentID, viewerID int) ([]Submission, error) {
 	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
 	defer cancel()
 
-	resp, err := c.messageClient.GetMessagesByEmail(ctx, &messagepb.GetMessagesByEmailRequest{
-		Email: email,
+	resp, err := c.submissionClient.ListSubmissions(ctx, &submissionpb.ListSubmissionsRequest{
+		ProjectId: int32(projectID),
+		StudentId: int32(studentID),
+		ViewerId:  int32(viewerID),
 	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to call GetMessagesByEmail: %w", err)
+		return nil, fmt.Errorf("failed to call ListSubmissions: %w", err)
+	}
+
+	submissions := make([]Submission, len(resp.Submissions))
+	for i, pbSubmission := range resp.Submissions {
+		submissions[i] = submissionFromProto(pbSubmission)
 	}
+	return submissions, nil
+}
 
-	messages := make([]Message, len(resp.Messages))
-	for i, pbMsg := range resp.Messages {
-		messages[i] = Message{
-			ID:        int(pbMsg.Id),
-			Email:     pbMsg.Email,
-			Message:   pbMsg.Message,
-			CreatedAt: pbMsg.CreatedAt.AsTime(),
+// GradeSubmission grades a submission on behalf of graderID, who must be an
+// instructor of its project
+func (c *GrpcClient) GradeSubmission(ctx context.Context, id, graderID int, req GradeRequest) (*Submission, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	rubric := make([]*submissionpb.RubricItem, len(req.Rubric))
+	for i, item := range req.Rubric {
+		rubric[i] = &submissionpb.RubricItem{
+			Criterion: item.Criterion,
+			Points:    item.Points,
+			MaxPoints: item.MaxPoints,
+			Comment:   item.Comment,
 		}
 	}
+	resp, err := c.submissionClient.GradeSubmission(ctx, &submissionpb.GradeSubmissionRequest{
+		Id:       int32(id),
+		GraderId: int32(graderID),
+		Score:    req.Score,
+		MaxScore: req.MaxScore,
+		Rubric:   rubric,
+		Feedback: req.Feedback,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to call GradeSubmission: %w", err)
+	}
+
+	submission := submissionFromProto(resp.Submission)
+	return &submission, nil
+}
+
+// ReopenGrade reopens a submission's grade on behalf of graderID, who must
+// be an instructor of its project
+func (c *GrpcClient) ReopenGrade(ctx context.Context, id, graderID int, reason string) (*Submission, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	resp, err := c.submissionClient.ReopenGrade(ctx, &submissionpb.ReopenGradeRequest{
+		Id:       int32(id),
+		GraderId: int32(graderID),
+		Reason:   reason,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to call ReopenGrade: %w", err)
+	}
+
+	submission := submissionFromProto(resp.Submission)
+	return &submission, nil
+}
+
+func (c *GrpcClient) CreateTeam(ctx context.Context, projectID int, name string) (*Team, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	resp, err := c.teamClient.CreateTeam(ctx, &teampb.CreateTeamRequest{
+		ProjectId: int32(projectID),
+		Name:      name,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to call CreateTeam: %w", err)
+	}
+
+	team := teamFromProto(resp.Team)
+	return &team, nil
+}
+
+func (c *GrpcClient) GetTeam(ctx context.Context, projectID, id int) (*Team, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	resp, err := c.teamClient.GetTeam(ctx, &teampb.GetTeamRequest{
+		ProjectId: int32(projectID),
+		Id:        int32(id),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to call GetTeam: %w", err)
+	}
+
+	team := teamFromProto(resp.Team)
+	return &team, nil
+}
+
+// ListTeams returns the project's teams sorted by name
+func (c *GrpcClient) ListTeams(ctx context.Context, projectID int) ([]Team, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	resp, err := c.teamClient.ListTeams(ctx, &teampb.ListTeamsRequest{
+		ProjectId: int32(projectID),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to call ListTeams: %w", err)
+	}
+
+	teams := make([]Team, len(resp.Teams))
+	for i, pbTeam := range resp.Teams {
+		teams[i] = teamFromProto(pbTeam)
+	}
+	return teams, nil
+}
+
+func (c *GrpcClient) RenameTeam(ctx context.Context, projectID, id int, name string) (*Team, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	resp, err := c.teamClient.RenameTeam(ctx, &teampb.RenameTeamRequest{
+		ProjectId: int32(projectID),
+		Id:        int32(id),
+		Name:      name,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to call RenameTeam: %w", err)
+	}
+
+	team := teamFromProto(resp.Team)
+	return &team, nil
+}
+
+// DeleteTeam deletes the team; its members stay in the project without a team
+func (c *GrpcClient) DeleteTeam(ctx context.Context, projectID, id int) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	_, err := c.teamClient.DeleteTeam(ctx, &teampb.DeleteTeamRequest{
+		ProjectId: int32(projectID),
+		Id:        int32(id),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to call DeleteTeam: %w", err)
+	}
+	return nil
+}
+
+// AddTeamMember moves a project member into the team
+func (c *GrpcClient) AddTeamMember(ctx context.Context, projectID, teamID, studentID int) (*Team, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	resp, err := c.teamClient.AddTeamMember(ctx, &teampb.AddTeamMemberRequest{
+		ProjectId: int32(projectID),
+		TeamId:    int32(teamID),
+		StudentId: int32(studentID),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to call AddTeamMember: %w", err)
+	}
+
+	team := teamFromProto(resp.Team)
+	return &team, nil
+}
+
+func (c *GrpcClient) RemoveTeamMember(ctx context.Context, projectID, teamID, studentID int) (*Team, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	resp, err := c.teamClient.RemoveTeamMember(ctx, &teampb.RemoveTeamMemberRequest{
+		ProjectId: int32(projectID),
+		TeamId:    int32(teamID),
+		StudentId: int32(studentID),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to call RemoveTeamMember: %w", err)
+	}
+
+	team := teamFromProto(resp.Team)
+	return &team, nil
+}
+
+// SetTeamLead makes a member of the team its lead; studentID 0 clears the lead
+func (c *GrpcClient) SetTeamLead(ctx context.Context, projectID, teamID, studentID int) (*Team, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	resp, err := c.teamClient.SetTeamLead(ctx, &teampb.SetTeamLeadRequest{
+		ProjectId: int32(projectID),
+		TeamId:    int32(teamID),
+		StudentId: int32(studentID),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to call SetTeamLead: %w", err)
+	}
+
+	team := teamFromProto(resp.Team)
+	return &team, nil
+}
 
-	return messages, nil
+// IsTeamMember reports whether the student belongs to the project's team. A
+// team that does not exist has no members.
+func (c *GrpcClient) IsTeamMember(ctx context.Context, projectID, teamID, studentID int) (bool, error) {
+	team, err := c.GetTeam(ctx, projectID, teamID)
+	if status.Code(err) == codes.NotFound {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return slices.Contains(team.MemberIDs, studentID), nil
+}
+
+// CanPost reports whether the student may post to the conversation or reply
+// to the message. A conversation or message that does not exist takes no
+// posts.
+func (c *GrpcClient) CanPost(ctx context.Context, email string, studentID, conversationID, replyTo int) (bool, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	_, err := c.messageClient.CheckPost(ctx, &messagepb.CheckPostRequest{
+		Email:          email,
+		StudentId:      int32(studentID),
+		ConversationId: int32(conversationID),
+		ReplyTo:        int32(replyTo),
+	})
+	switch status.Code(err) {
+	case codes.OK:
+		return true, nil
+	case codes.NotFound, codes.PermissionDenied, codes.InvalidArgument:
+		return false, nil
+	default:
+		return false, fmt.Errorf("failed to call CheckPost: %w", err)
+	}
 }
 
 func (c *GrpcClient) Close() error {
@@ -95,6 +1062,278 @@ func (c *GrpcClient) HealthCheck(ctx context.Context) error {
 	ctx, cancel := context.WithTimeout(ctx, 2*time.Second)
 	defer cancel()
 
-	_, err := c.projectClient.GetAllProjects(ctx, &projectpb.GetAllProjectsRequest{})
+	_, err := c.projectClient.ListProjects(ctx, &projectpb.ListProjectsRequest{PageSize: 1})
 	return err
 }
+
+func projectFromProto(p *projectpb.Project) Project {
+	return Project{
+		ID:          int(p.Id),
+		Name:        p.Name,
+		Description: p.Description,
+		Status:      statusFromProto(p.Status),
+		StartDate:   optionalTimeFromProto(p.StartDate),
+		DueDate:     optionalTimeFromProto(p.DueDate),
+		CreatedAt:   p.CreatedAt.AsTime(),
+		UpdatedAt:   p.UpdatedAt.AsTime(),
+		Version:     int(p.Version),
+		Tags:        nonNil(p.Tags),
+	}
+}
+
+func tagFromProto(t *projectpb.Tag) Tag {
+	return Tag{
+		ID:           int(t.Id),
+		Name:         t.Name,
+		ProjectCount: int(t.ProjectCount),
+	}
+}
+
+func tagMatchToProto(match string) projectpb.TagMatch {
+	switch match {
+	case "any":
+		return projectpb.TagMatch_TAG_MATCH_ANY
+	case "all":
+		return projectpb.TagMatch_TAG_MATCH_ALL
+	}
+	return projectpb.TagMatch_TAG_MATCH_UNSPECIFIED
+}
+
+// nonNil turns a nil slice into an empty one, so it is encoded as [] rather than null
+func nonNil(s []string) []string {
+	if s == nil {
+		return []string{}
+	}
+	return s
+}
+
+func statusToProto(status string) projectpb.ProjectStatus {
+	switch status {
+	case "draft":
+		return projectpb.ProjectStatus_PROJECT_STATUS_DRAFT
+	case "active":
+		return projectpb.ProjectStatus_PROJECT_STATUS_ACTIVE
+	case "completed":
+		return projectpb.ProjectStatus_PROJECT_STATUS_COMPLETED
+	case "archived":
+		return projectpb.ProjectStatus_PROJECT_STATUS_ARCHIVED
+	}
+	return projectpb.ProjectStatus_PROJECT_STATUS_UNSPECIFIED
+}
+
+func statusFromProto(status projectpb.ProjectStatus) string {
+	switch status {
+	case projectpb.ProjectStatus_PROJECT_STATUS_DRAFT:
+		return "draft"
+	case projectpb.ProjectStatus_PROJECT_STATUS_ACTIVE:
+		return "active"
+	case projectpb.ProjectStatus_PROJECT_STATUS_COMPLETED:
+		return "completed"
+	case projectpb.ProjectStatus_PROJECT_STATUS_ARCHIVED:
+		return "archived"
+	}
+	return ""
+}
+
+func timeToProto(t time.Time) *timestamppb.Timestamp {
+	if t.IsZero() {
+		return nil
+	}
+	return timestamppb.New(t)
+}
+
+func optionalTimeToProto(t *time.Time) *timestamppb.Timestamp {
+	if t == nil {
+		return nil
+	}
+	return timestamppb.New(*t)
+}
+
+func optionalTimeFromProto(ts *timestamppb.Timestamp) *time.Time {
+	if ts == nil {
+		return nil
+	}
+	t := ts.AsTime()
+	return &t
+}
+
+func memberFromProto(m *projectpb.ProjectMember) Member {
+	return Member{
+		ProjectID: int(m.ProjectId),
+		StudentID: int(m.StudentId),
+		Role:      roleFromProto(m.Role),
+		TeamID:    int(m.TeamId),
+		TeamLead:  m.TeamLead,
+		CreatedAt: m.CreatedAt.AsTime(),
+	}
+}
+
+func roleToProto(role string) projectpb.MemberRole {
+	switch role {
+	case "owner":
+		return projectpb.MemberRole_MEMBER_ROLE_OWNER
+	case "contributor":
+		return projectpb.MemberRole_MEMBER_ROLE_CONTRIBUTOR
+	case "viewer":
+		return projectpb.MemberRole_MEMBER_ROLE_VIEWER
+	case "instructor":
+		return projectpb.MemberRole_MEMBER_ROLE_INSTRUCTOR
+	}
+	return projectpb.MemberRole_MEMBER_ROLE_UNSPECIFIED
+}
+
+func roleFromProto(role projectpb.MemberRole) string {
+	switch role {
+	case projectpb.MemberRole_MEMBER_ROLE_OWNER:
+		return "owner"
+	case projectpb.MemberRole_MEMBER_ROLE_CONTRIBUTOR:
+		return "contributor"
+	case projectpb.MemberRole_MEMBER_ROLE_VIEWER:
+		return "viewer"
+	case projectpb.MemberRole_MEMBER_ROLE_INSTRUCTOR:
+		return "instructor"
+	}
+	return ""
+}
+
+func messagesFromProto(pbMessages []*messagepb.Message) []Message {
+	messages := make([]Message, len(pbMessages))
+	for i, pbMsg := range pbMessages {
+		messages[i] = messageFromProto(pbMsg)
+	}
+	return messages
+}
+
+func messageFromProto(m *messagepb.Message) Message {
+	msg := Message{
+		ID:             int(m.Id),
+		Email:          m.Email,
+		Message:        m.Message,
+		TeamID:         int(m.TeamId),
+		ConversationID: int(m.ConversationId),
+		ParentID:       int(m.ParentId),
+		ThreadRootID:   int(m.ThreadRootId),
+		CreatedAt:      m.CreatedAt.AsTime(),
+		Edited:         m.Edited,
+		Deleted:        m.Deleted,
+	}
+	if m.EditedAt != nil {
+		editedAt := m.EditedAt.AsTime()
+		msg.EditedAt = &editedAt
+	}
+	if m.DeletedAt != nil {
+		deletedAt := m.DeletedAt.AsTime()
+		msg.DeletedAt = &deletedAt
+	}
+	return msg
+}
+
+func conversationFromProto(c *messagepb.Conversation) Conversation {
+	participants := c.Participants
+	if participants == nil {
+		participants = []string{}
+	}
+	return Conversation{
+		ID:            int(c.Id),
+		ProjectID:     int(c.ProjectId),
+		Subject:       c.Subject,
+		CreatedBy:     c.CreatedBy,
+		Participants:  participants,
+		CreatedAt:     c.CreatedAt.AsTime(),
+		LastMessageAt: c.LastMessageAt.AsTime(),
+	}
+}
+
+func teamFromProto(t *teampb.Team) Team {
+	memberIDs := make([]int, len(t.MemberIds))
+	for i, id := range t.MemberIds {
+		memberIDs[i] = int(id)
+	}
+	return Team{
+		ID:        int(t.Id),
+		ProjectID: int(t.ProjectId),
+		Name:      t.Name,
+		LeadID:    int(t.LeadId),
+		MemberIDs: memberIDs,
+		CreatedAt: t.CreatedAt.AsTime(),
+	}
+}
+
+func attachmentFromProto(a *attachmentpb.Attachment) Attachment {
+	return Attachment{
+		ID:          int(a.Id),
+		ProjectID:   int(a.ProjectId),
+		Filename:    a.Filename,
+		ContentType: a.ContentType,
+		Size:        a.Size,
+		SHA256:      a.Sha256,
+		UploadedBy:  a.UploadedBy,
+		CreatedAt:   a.CreatedAt.AsTime(),
+	}
+}
+
+func submissionFromProto(s *submissionpb.Submission) Submission {
+	attachmentIDs := make([]int, len(s.AttachmentIds))
+	for i, id := range s.AttachmentIds {
+		attachmentIDs[i] = int(id)
+	}
+	grades := make([]Grade, len(s.Grades))
+	for i, g := range s.Grades {
+		grades[i] = gradeFromProto(g)
+	}
+	return Submission{
+		ID:            int(s.Id),
+		ProjectID:     int(s.ProjectId),
+		StudentID:     int(s.StudentId),
+		Version:       int(s.Version),
+		Text:          s.Text,
+		AttachmentIDs: attachmentIDs,
+		Status:        submissionStatusFromProto(s.Status),
+		SubmittedAt:   s.SubmittedAt.AsTime(),
+		Grades:        grades,
+	}
+}
+
+func gradeFromProto(g *submissionpb.Grade) Grade {
+	rubric := make([]RubricItem, len(g.Rubric))
+	for i, item := range g.Rubric {
+		rubric[i] = RubricItem{
+			Criterion: item.Criterion,
+			Points:    item.Points,
+			MaxPoints: item.MaxPoints,
+			Comment:   item.Comment,
+		}
+	}
+	return Grade{
+		ID:           int(g.Id),
+		GraderID:     int(g.GraderId),
+		Score:        g.Score,
+		MaxScore:     g.MaxScore,
+		Rubric:       rubric,
+		Feedback:     g.Feedback,
+		GradedAt:     g.GradedAt.AsTime(),
+		ReopenedAt:   optionalTimeFromProto(g.ReopenedAt),
+		ReopenedBy:   int(g.ReopenedBy),
+		ReopenReason: g.ReopenReason,
+	}
+}
+
+func submissionStatusFromProto(status submissionpb.SubmissionStatus) string {
+	switch status {
+	case submissionpb.SubmissionStatus_SUBMISSION_STATUS_SUBMITTED:
+		return "submitted"
+	case submissionpb.SubmissionStatus_SUBMISSION_STATUS_GRADED:
+		return "graded"
+	case submissionpb.SubmissionStatus_SUBMISSION_STATUS_REOPENED:
+		return "reopened"
+	}
+	return ""
+}
+
+func idsToProto(ids []int) []int32 {
+	pbIDs := make([]int32, len(ids))
+	for i, id := range ids {
+		pbIDs[i] = int32(id)
+	}
+	return pbIDs
+}
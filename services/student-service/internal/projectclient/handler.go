@@ -1,16 +1,28 @@
 package projectclient
 
 import (
+	"errors"
+	"fmt"
 	"log/slog"
 	"net/http"
+	"strconv"
+	"strings"
+	"time"
 
+	projectpb "grud/api/gen/project/v1"
+	"grud/common/httputil"
+	"student-service/internal/auth"
 	"student-service/internal/metrics"
 
 	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 type Handler struct {
 	grpcClient *GrpcClient
+	validate   *validator.Validate
 	logger     *slog.Logger
 	metrics    *metrics.Metrics
 }
@@ -18,6 +30,7 @@ type Handler struct {
 func NewHandler(grpcClient *GrpcClient, logger *slog.Logger, metrics *metrics.Metrics) *Handler {
 	return &Handler{
 		grpcClient: grpcClient,
+		validate:   validator.New(),
 		logger:     logger,
 		metrics:    metrics,
 	}
@@ -25,48 +38,650 @@ func NewHandler(grpcClient *GrpcClient, logger *slog.Logger, metrics *metrics.Me
 
 func (h *Handler) RegisterRoutes(router gin.IRouter) {
 	router.GET("/projects", h.GetAllProjects)
+	router.GET("/projects/:id", h.GetProject)
+	router.POST("/projects", h.CreateProject)
+	router.PUT("/projects/:id", h.UpdateProject)
+	router.DELETE("/projects/:id", h.DeleteProject)
+	router.POST("/projects/:id/transition", h.TransitionProject)
+	router.POST("/projects/:id/restore", h.RestoreProject)
+	router.GET("/projects/:id/history", h.GetProjectHistory)
+	router.GET("/projects/:id/members", h.ListMembers)
+	router.POST("/projects/:id/members", h.AddMember)
+	router.DELETE("/projects/:id/members/:studentId", h.RemoveMember)
+	router.GET("/projects/:id/teams", h.ListTeams)
+	router.POST("/projects/:id/teams", h.CreateTeam)
+	router.GET("/projects/:id/teams/:teamId", h.GetTeam)
+	router.PUT("/projects/:id/teams/:teamId", h.RenameTeam)
+	router.DELETE("/projects/:id/teams/:teamId", h.DeleteTeam)
+	router.PUT("/projects/:id/teams/:teamId/members/:studentId", h.AddTeamMember)
+	router.DELETE("/projects/:id/teams/:teamId/members/:studentId", h.RemoveTeamMember)
+	router.PUT("/projects/:id/teams/:teamId/lead", h.SetTeamLead)
+	router.GET("/projects/:id/attachments", h.ListAttachments)
+	router.POST("/projects/:id/attachments", h.UploadAttachment)
+	router.GET("/attachments/:id", h.DownloadAttachment)
+	router.DELETE("/attachments/:id", h.DeleteAttachment)
+	router.GET("/projects/:id/submissions", h.ListSubmissions)
+	router.POST("/projects/:id/submissions", h.CreateSubmission)
+	router.GET("/submissions/:id", h.GetSubmission)
+	router.POST("/submissions/:id/grade", h.GradeSubmission)
+	router.POST("/submissions/:id/reopen", h.ReopenGrade)
+	router.GET("/me/projects", h.GetMyProjects)
+	router.GET("/me/submissions", h.GetMySubmissions)
 	router.GET("/messages", h.GetMessages)
+	router.GET("/messages/export", h.ExportMessages)
+	router.GET("/messages/:id/thread", h.GetThread)
+	router.GET("/messages/:id/history", h.GetEditHistory)
+	router.GET("/messages/unread", h.GetUnreadCounts)
+	router.POST("/messages/read", h.MarkAllRead)
+	router.POST("/messages/:id/read", h.MarkRead)
+	router.GET("/conversations", h.ListConversations)
+	router.POST("/conversations", h.CreateConversation)
+	router.GET("/tags", h.ListTags)
+	router.POST("/tags/rename", h.RenameTag)
+	router.POST("/tags/merge", h.MergeTags)
 }
 
 func (h *Handler) GetAllProjects(c *gin.Context) {
+	opts, err := listProjectsOptionsFromQuery(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid query parameters"})
+		return
+	}
+
 	if h.grpcClient == nil {
 		c.JSON(http.StatusServiceUnavailable, gin.H{"error": "gRPC client not available"})
 		return
 	}
 
-	h.logger.InfoContext(c.Request.Context(), "fetching all projects from project-service via gRPC")
-	projects, err := h.grpcClient.GetAllProjects(c.Request.Context())
+	h.logger.InfoContext(c.Request.Context(), "listing projects from project-service via gRPC", "limit", opts.PageSize, "order_by", opts.OrderBy)
+	page, err := h.grpcClient.ListProjects(c.Request.Context(), opts)
 	if err != nil {
-		h.logger.ErrorContext(c.Request.Context(), "failed to fetch projects via gRPC", "error", err)
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch projects"})
+		h.handleGrpcError(c, err, "Failed to fetch projects")
 		return
 	}
 
 	// Record metric
 	h.metrics.RecordProjectsListViewedByStudent(c.Request.Context())
 
-	c.JSON(http.StatusOK, projects)
+	c.JSON(http.StatusOK, page)
+}
+
+// projectSortFields maps the REST sort names to ListProjects order_by fields
+var projectSortFields = map[string]string{
+	"id":        "id",
+	"name":      "name",
+	"createdAt": "created_at",
+	"updatedAt": "updated_at",
+}
+
+// listProjectsOptionsFromQuery reads ?limit=&cursor=&sort=&q=&status=&tags=&tagMatch=&createdAfter=&createdBefore=&updatedAfter=&updatedBefore=
+func listProjectsOptionsFromQuery(c *gin.Context) (ListProjectsOptions, error) {
+	opts := ListProjectsOptions{
+		PageToken:    c.Query("cursor"),
+		NameContains: c.Query("q"),
+		Status:       c.Query("status"),
+		TagMatch:     c.Query("tagMatch"),
+	}
+
+	// Tags cannot contain commas, so ?tags=a,b and ?tags=a&tags=b mean the same
+	for _, v := range c.QueryArray("tags") {
+		for _, tag := range strings.Split(v, ",") {
+			if tag = strings.TrimSpace(tag); tag != "" {
+				opts.Tags = append(opts.Tags, tag)
+			}
+		}
+	}
+	if opts.TagMatch != "" && opts.TagMatch != "any" && opts.TagMatch != "all" {
+		return opts, fmt.Errorf("unknown tag match %q", opts.TagMatch)
+	}
+
+	if v := c.Query("limit"); v != "" {
+		limit, err := strconv.Atoi(v)
+		if err != nil {
+			return opts, err
+		}
+		opts.PageSize = limit
+	}
+
+	if opts.Status != "" && statusToProto(opts.Status) == projectpb.ProjectStatus_PROJECT_STATUS_UNSPECIFIED {
+		return opts, fmt.Errorf("unknown status %q", opts.Status)
+	}
+
+	if v := c.Query("sort"); v != "" {
+		field, ok := projectSortFields[strings.TrimPrefix(v, "-")]
+		if !ok {
+			return opts, fmt.Errorf("unknown sort field %q", v)
+		}
+		opts.OrderBy = field
+		if strings.HasPrefix(v, "-") {
+			opts.OrderBy += " desc"
+		}
+	}
+
+	for param, dst := range map[string]*time.Time{
+		"createdAfter":  &opts.CreatedAfter,
+		"createdBefore": &opts.CreatedBefore,
+		"updatedAfter":  &opts.UpdatedAfter,
+		"updatedBefore": &opts.UpdatedBefore,
+	} {
+		if v := c.Query(param); v != "" {
+			t, err := time.Parse(time.RFC3339, v)
+			if err != nil {
+				return opts, err
+			}
+			*dst = t
+		}
+	}
+
+	return opts, nil
 }
 
+func (h *Handler) GetProject(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid project ID"})
+		return
+	}
+
+	if h.grpcClient == nil {
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": "gRPC client not available"})
+		return
+	}
+
+	h.logger.InfoContext(c.Request.Context(), "fetching project via gRPC", "id", id)
+	project, err := h.grpcClient.GetProject(c.Request.Context(), id)
+	if err != nil {
+		h.handleGrpcError(c, err, "Failed to fetch project")
+		return
+	}
+
+	c.Header("ETag", httputil.ETag(project.Version))
+	c.JSON(http.StatusOK, project)
+}
+
+func (h *Handler) CreateProject(c *gin.Context) {
+	var req ProjectRequest
+	if err := c.ShouldBindJSON(&req); err != nil || h.validate.Struct(&req) != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request"})
+		return
+	}
+
+	if h.grpcClient == nil {
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": "gRPC client not available"})
+		return
+	}
+
+	h.logger.InfoContext(c.Request.Context(), "creating project via gRPC", "name", req.Name)
+	project, err := h.grpcClient.CreateProject(c.Request.Context(), req)
+	if err != nil {
+		h.handleGrpcError(c, err, "Failed to create project")
+		return
+	}
+
+	c.JSON(http.StatusCreated, project)
+}
+
+func (h *Handler) UpdateProject(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid project ID"})
+		return
+	}
+
+	var req ProjectRequest
+	if err := c.ShouldBindJSON(&req); err != nil || h.validate.Struct(&req) != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request"})
+		return
+	}
+
+	// If-Match is optional here; without it the update is unconditional
+	req.Version, _, err = httputil.ParseIfMatch(c.GetHeader("If-Match"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid If-Match header"})
+		return
+	}
+
+	if h.grpcClient == nil {
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": "gRPC client not available"})
+		return
+	}
+
+	h.logger.InfoContext(c.Request.Context(), "updating project via gRPC", "id", id, "name", req.Name)
+	project, err := h.grpcClient.UpdateProject(c.Request.Context(), id, req)
+	if err != nil {
+		h.handleGrpcError(c, err, "Failed to update project")
+		return
+	}
+
+	c.Header("ETag", httputil.ETag(project.Version))
+	c.JSON(http.StatusOK, project)
+}
+
+func (h *Handler) TransitionProject(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid project ID"})
+		return
+	}
+
+	var req TransitionRequest
+	if err := c.ShouldBindJSON(&req); err != nil || h.validate.Struct(&req) != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request"})
+		return
+	}
+
+	if h.grpcClient == nil {
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": "gRPC client not available"})
+		return
+	}
+
+	h.logger.InfoContext(c.Request.Context(), "transitioning project via gRPC", "id", id, "status", req.Status)
+	project, err := h.grpcClient.TransitionProject(c.Request.Context(), id, req.Status)
+	if err != nil {
+		h.handleGrpcError(c, err, "Failed to transition project")
+		return
+	}
+
+	c.JSON(http.StatusOK, project)
+}
+
+func (h *Handler) DeleteProject(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid project ID"})
+		return
+	}
+
+	if h.grpcClient == nil {
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": "gRPC client not available"})
+		return
+	}
+
+	h.logger.InfoContext(c.Request.Context(), "deleting project via gRPC", "id", id)
+	if err := h.grpcClient.DeleteProject(c.Request.Context(), id); err != nil {
+		h.handleGrpcError(c, err, "Failed to delete project")
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func (h *Handler) RestoreProject(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid project ID"})
+		return
+	}
+
+	if h.grpcClient == nil {
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": "gRPC client not available"})
+		return
+	}
+
+	h.logger.InfoContext(c.Request.Context(), "restoring project via gRPC", "id", id)
+	project, err := h.grpcClient.RestoreProject(c.Request.Context(), id)
+	if err != nil {
+		h.handleGrpcError(c, err, "Failed to restore project")
+		return
+	}
+
+	c.JSON(http.StatusOK, project)
+}
+
+func (h *Handler) GetProjectHistory(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid project ID"})
+		return
+	}
+
+	limit := 0
+	if v := c.Query("limit"); v != "" {
+		if limit, err = strconv.Atoi(v); err != nil || limit < 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid query parameters"})
+			return
+		}
+	}
+
+	if h.grpcClient == nil {
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": "gRPC client not available"})
+		return
+	}
+
+	h.logger.InfoContext(c.Request.Context(), "fetching project history via gRPC", "id", id)
+	page, err := h.grpcClient.GetProjectHistory(c.Request.Context(), id, limit, c.Query("cursor"))
+	if err != nil {
+		h.handleGrpcError(c, err, "Failed to fetch project history")
+		return
+	}
+
+	c.JSON(http.StatusOK, page)
+}
+
+// GetMessages lists a page of the messages matching
+// ?limit=&cursor=&sender=&recipient=&projectId=&teamId=&q=&createdAfter=&createdBefore=,
+// newest first. ?email= is kept as an alias of ?sender=. At least one of
+// sender, recipient, projectId and teamId is required. Students list their
+// own messages, the messages of teams they belong to, and otherwise only
+// what was sent to them: recipient can only be the caller, and is made the
+// caller unless sender or teamId allow more.
 func (h *Handler) GetMessages(c *gin.Context) {
-	email := c.Query("email")
-	if email == "" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Email parameter is required"})
+	email, ok := h.currentEmail(c)
+	if !ok {
+		return
+	}
+
+	opts, err := listMessagesOptionsFromQuery(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid query parameters"})
+		return
+	}
+	if opts.Sender == "" && opts.Recipient == "" && opts.ProjectID == 0 && opts.TeamID == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "sender, recipient, projectId or teamId is required"})
+		return
+	}
+	if opts.TeamID != 0 && opts.ProjectID == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "teamId needs projectId"})
+		return
+	}
+	if opts.Recipient != "" && !strings.EqualFold(opts.Recipient, email) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Only messages sent to you can be listed by recipient"})
+		return
+	}
+
+	if h.grpcClient == nil {
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": "gRPC client not available"})
+		return
+	}
+
+	if opts.TeamID != 0 {
+		studentID, ok := h.currentStudent(c)
+		if !ok {
+			return
+		}
+		member, err := h.grpcClient.IsTeamMember(c.Request.Context(), opts.ProjectID, opts.TeamID, studentID)
+		if err != nil {
+			h.handleGrpcError(c, err, "Failed to check team membership")
+			return
+		}
+		if !member {
+			c.JSON(http.StatusForbidden, gin.H{"error": "Only members can list the messages of a team"})
+			return
+		}
+	} else if !strings.EqualFold(opts.Sender, email) {
+		opts.Recipient = email
+	}
+
+	h.logger.InfoContext(c.Request.Context(), "listing messages from project-service via gRPC",
+		"sender", opts.Sender, "recipient", opts.Recipient, "project_id", opts.ProjectID, "team_id", opts.TeamID, "limit", opts.PageSize)
+	page, err := h.grpcClient.ListMessages(c.Request.Context(), opts)
+	if err != nil {
+		h.handleGrpcError(c, err, "Failed to fetch messages")
+		return
+	}
+
+	c.JSON(http.StatusOK, page)
+}
+
+func listMessagesOptionsFromQuery(c *gin.Context) (ListMessagesOptions, error) {
+	opts := ListMessagesOptions{
+		PageToken:    c.Query("cursor"),
+		Sender:       c.Query("sender"),
+		Recipient:    c.Query("recipient"),
+		TextContains: c.Query("q"),
+	}
+	if opts.Sender == "" {
+		opts.Sender = c.Query("email")
+	}
+
+	for param, dst := range map[string]*int{
+		"limit":     &opts.PageSize,
+		"projectId": &opts.ProjectID,
+		"teamId":    &opts.TeamID,
+	} {
+		if v := c.Query(param); v != "" {
+			n, err := strconv.Atoi(v)
+			if err != nil || n < 0 {
+				return opts, fmt.Errorf("invalid %s %q", param, v)
+			}
+			*dst = n
+		}
+	}
+
+	for param, dst := range map[string]*time.Time{
+		"createdAfter":  &opts.CreatedAfter,
+		"createdBefore": &opts.CreatedBefore,
+	} {
+		if v := c.Query(param); v != "" {
+			t, err := time.Parse(time.RFC3339, v)
+			if err != nil {
+				return opts, err
+			}
+			*dst = t
+		}
+	}
+
+	return opts, nil
+}
+
+// ListMembers lists a project's members, only those of the team given by
+// the teamId query parameter if set
+func (h *Handler) ListMembers(c *gin.Context) {
+	projectID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid project ID"})
 		return
 	}
 
+	var teamID int
+	if s := c.Query("teamId"); s != "" {
+		if teamID, err = strconv.Atoi(s); err != nil || teamID <= 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid team ID"})
+			return
+		}
+	}
+
 	if h.grpcClient == nil {
 		c.JSON(http.StatusServiceUnavailable, gin.H{"error": "gRPC client not available"})
 		return
 	}
 
-	h.logger.InfoContext(c.Request.Context(), "fetching messages from project-service via gRPC", "email", email)
-	messages, err := h.grpcClient.GetMessagesByEmail(c.Request.Context(), email)
+	h.logger.InfoContext(c.Request.Context(), "fetching project members via gRPC", "project_id", projectID, "team_id", teamID)
+	members, err := h.grpcClient.ListMembers(c.Request.Context(), projectID, teamID)
 	if err != nil {
-		h.logger.ErrorContext(c.Request.Context(), "failed to fetch messages via gRPC", "error", err, "email", email)
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch messages"})
+		h.handleGrpcError(c, err, "Failed to fetch project members")
 		return
 	}
 
-	c.JSON(http.StatusOK, messages)
+	c.JSON(http.StatusOK, members)
+}
+
+func (h *Handler) AddMember(c *gin.Context) {
+	projectID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid project ID"})
+		return
+	}
+
+	actorID, ok := h.currentStudent(c)
+	if !ok {
+		return
+	}
+
+	var req AddMemberRequest
+	if err := c.ShouldBindJSON(&req); err != nil || h.validate.Struct(&req) != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request"})
+		return
+	}
+
+	if h.grpcClient == nil {
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": "gRPC client not available"})
+		return
+	}
+
+	h.logger.InfoContext(c.Request.Context(), "adding project member via gRPC", "project_id", projectID, "student_id", req.StudentID, "role", req.Role, "actor_id", actorID)
+	member, err := h.grpcClient.AddMember(c.Request.Context(), projectID, req.StudentID, actorID, req.Role)
+	if err != nil {
+		h.handleGrpcError(c, err, "Failed to add project member")
+		return
+	}
+
+	c.JSON(http.StatusCreated, member)
+}
+
+func (h *Handler) RemoveMember(c *gin.Context) {
+	projectID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid project ID"})
+		return
+	}
+
+	studentID, err := strconv.Atoi(c.Param("studentId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid student ID"})
+		return
+	}
+
+	actorID, ok := h.currentStudent(c)
+	if !ok {
+		return
+	}
+
+	if h.grpcClient == nil {
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": "gRPC client not available"})
+		return
+	}
+
+	h.logger.InfoContext(c.Request.Context(), "removing project member via gRPC", "project_id", projectID, "student_id", studentID, "actor_id", actorID)
+	if err := h.grpcClient.RemoveMember(c.Request.Context(), projectID, studentID, actorID); err != nil {
+		h.handleGrpcError(c, err, "Failed to remove project member")
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func (h *Handler) GetMyProjects(c *gin.Context) {
+	studentID, ok := auth.GetStudentID(c.Request.Context())
+	if !ok {
+		h.logger.WarnContext(c.Request.Context(), "student ID not found in context")
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	if h.grpcClient == nil {
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": "gRPC client not available"})
+		return
+	}
+
+	h.logger.InfoContext(c.Request.Context(), "fetching projects for current student via gRPC", "student_id", studentID)
+	projects, err := h.grpcClient.ListProjectsForStudent(c.Request.Context(), studentID)
+	if err != nil {
+		h.handleGrpcError(c, err, "Failed to fetch projects")
+		return
+	}
+
+	c.JSON(http.StatusOK, projects)
+}
+
+func (h *Handler) ListTags(c *gin.Context) {
+	if h.grpcClient == nil {
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": "gRPC client not available"})
+		return
+	}
+
+	prefix := c.Query("prefix")
+	h.logger.InfoContext(c.Request.Context(), "listing tags via gRPC", "prefix", prefix)
+	tags, err := h.grpcClient.ListTags(c.Request.Context(), prefix)
+	if err != nil {
+		h.handleGrpcError(c, err, "Failed to fetch tags")
+		return
+	}
+
+	c.JSON(http.StatusOK, tags)
+}
+
+func (h *Handler) RenameTag(c *gin.Context) {
+	var req RenameTagRequest
+	if err := c.ShouldBindJSON(&req); err != nil || h.validate.Struct(&req) != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request"})
+		return
+	}
+
+	if h.grpcClient == nil {
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": "gRPC client not available"})
+		return
+	}
+
+	h.logger.InfoContext(c.Request.Context(), "renaming tag via gRPC", "from", req.From, "to", req.To)
+	tag, err := h.grpcClient.RenameTag(c.Request.Context(), req.From, req.To)
+	if err != nil {
+		h.handleGrpcError(c, err, "Failed to rename tag")
+		return
+	}
+
+	c.JSON(http.StatusOK, tag)
+}
+
+func (h *Handler) MergeTags(c *gin.Context) {
+	var req MergeTagsRequest
+	if err := c.ShouldBindJSON(&req); err != nil || h.validate.Struct(&req) != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request"})
+		return
+	}
+
+	if h.grpcClient == nil {
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": "gRPC client not available"})
+		return
+	}
+
+	h.logger.InfoContext(c.Request.Context(), "merging tags via gRPC", "sources", req.Sources, "target", req.Target)
+	tag, err := h.grpcClient.MergeTags(c.Request.Context(), req.Sources, req.Target)
+	if err != nil {
+		h.handleGrpcError(c, err, "Failed to merge tags")
+		return
+	}
+
+	c.JSON(http.StatusOK, tag)
+}
+
+// handleGrpcError translates a gRPC status returned by project-service into an HTTP response
+func (h *Handler) handleGrpcError(c *gin.Context, err error, message string) {
+	code := HTTPStatusFromError(err)
+	if code == http.StatusInternalServerError {
+		h.logger.ErrorContext(c.Request.Context(), "gRPC call failed", "error", err)
+		c.JSON(code, gin.H{"error": message})
+		return
+	}
+
+	h.logger.InfoContext(c.Request.Context(), "gRPC call rejected", "error", err)
+	c.JSON(code, gin.H{"error": statusMessage(err)})
+}
+
+// statusMessage returns the message of the gRPC status wrapped in err, without the client-side wrapping
+func statusMessage(err error) string {
+	var se interface{ GRPCStatus() *status.Status }
+	if errors.As(err, &se) {
+		return se.GRPCStatus().Message()
+	}
+	return err.Error()
+}
+
+// HTTPStatusFromError maps the gRPC status code carried by err to an HTTP status code
+func HTTPStatusFromError(err error) int {
+	switch status.Code(err) {
+	case codes.NotFound:
+		return http.StatusNotFound
+	case codes.InvalidArgument:
+		return http.StatusBadRequest
+	case codes.PermissionDenied:
+		return http.StatusForbidden
+	case codes.AlreadyExists, codes.FailedPrecondition:
+		return http.StatusConflict
+	case codes.Aborted:
+		return http.StatusPreconditionFailed
+	case codes.Unavailable:
+		return http.StatusServiceUnavailable
+	}
+	return http.StatusInternalServerError
 }
@@ -3,8 +3,11 @@ package projectclient_test
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"strconv"
+	"strings"
 	"testing"
 	"time"
 
@@ -13,12 +16,16 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 // Mock gRPC Client
 type mockGrpcClient struct {
 	messages []projectclient.Message
 	projects []projectclient.Project
+	members  []projectclient.Member
+	tags     []projectclient.Tag
 	err      error
 }
 
@@ -29,11 +36,156 @@ func (m *mockGrpcClient) GetAllProjects(ctx context.Context) ([]projectclient.Pr
 	return m.projects, nil
 }
 
-func (m *mockGrpcClient) GetMessagesByEmail(ctx context.Context, email string) ([]projectclient.Message, error) {
+func (m *mockGrpcClient) ListProjects(ctx context.Context, opts projectclient.ListProjectsOptions) (*projectclient.ProjectPage, error) {
 	if m.err != nil {
 		return nil, m.err
 	}
-	return m.messages, nil
+
+	start := 0
+	if opts.PageToken != "" {
+		start, _ = strconv.Atoi(opts.PageToken)
+	}
+	end := len(m.projects)
+	if opts.PageSize > 0 && start+opts.PageSize < end {
+		end = start + opts.PageSize
+	}
+
+	page := &projectclient.ProjectPage{Items: m.projects[start:end]}
+	if end < len(m.projects) {
+		page.NextCursor = strconv.Itoa(end)
+	}
+	return page, nil
+}
+
+func (m *mockGrpcClient) ListMessages(ctx context.Context, opts projectclient.ListMessagesOptions) (*projectclient.MessagePage, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	page := &projectclient.MessagePage{Items: []projectclient.Message{}}
+	for _, msg := range m.messages {
+		if opts.Sender == "" || msg.Email == opts.Sender {
+			page.Items = append(page.Items, msg)
+		}
+	}
+	return page, nil
+}
+
+func (m *mockGrpcClient) GetProject(ctx context.Context, id int) (*projectclient.Project, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	for i := range m.projects {
+		if m.projects[i].ID == id {
+			return &m.projects[i], nil
+		}
+	}
+	return nil, status.Error(codes.NotFound, "project not found")
+}
+
+func (m *mockGrpcClient) CreateProject(ctx context.Context, req projectclient.ProjectRequest) (*projectclient.Project, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	project := projectclient.Project{
+		ID:          len(m.projects) + 1,
+		Name:        req.Name,
+		Description: req.Description,
+		Status:      "draft",
+		StartDate:   req.StartDate,
+		DueDate:     req.DueDate,
+		CreatedAt:   time.Now(),
+		UpdatedAt:   time.Now(),
+	}
+	m.projects = append(m.projects, project)
+	return &project, nil
+}
+
+func (m *mockGrpcClient) UpdateProject(ctx context.Context, id int, req projectclient.ProjectRequest) (*projectclient.Project, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	for i := range m.projects {
+		if m.projects[i].ID == id {
+			m.projects[i].Name = req.Name
+			m.projects[i].Description = req.Description
+			m.projects[i].StartDate = req.StartDate
+			m.projects[i].DueDate = req.DueDate
+			m.projects[i].UpdatedAt = time.Now()
+			return &m.projects[i], nil
+		}
+	}
+	return nil, status.Error(codes.NotFound, "project not found")
+}
+
+func (m *mockGrpcClient) TransitionProject(ctx context.Context, id int, newStatus string) (*projectclient.Project, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	for i := range m.projects {
+		if m.projects[i].ID == id {
+			m.projects[i].Status = newStatus
+			return &m.projects[i], nil
+		}
+	}
+	return nil, status.Error(codes.NotFound, "project not found")
+}
+
+func (m *mockGrpcClient) RestoreProject(ctx context.Context, id int) (*projectclient.Project, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	return nil, status.Error(codes.NotFound, "project not found")
+}
+
+func (m *mockGrpcClient) DeleteProject(ctx context.Context, id int) error {
+	if m.err != nil {
+		return m.err
+	}
+	for i := range m.projects {
+		if m.projects[i].ID == id {
+			m.projects = append(m.projects[:i], m.projects[i+1:]...)
+			return nil
+		}
+	}
+	return status.Error(codes.NotFound, "project not found")
+}
+
+func (m *mockGrpcClient) ListMembers(ctx context.Context, projectID, teamID int) ([]projectclient.Member, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	return m.members, nil
+}
+
+func (m *mockGrpcClient) ListTags(ctx context.Context, prefix string) ([]projectclient.Tag, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	var tags []projectclient.Tag
+	for _, tag := range m.tags {
+		if strings.HasPrefix(tag.Name, prefix) {
+			tags = append(tags, tag)
+		}
+	}
+	return tags, nil
+}
+
+func (m *mockGrpcClient) RenameTag(ctx context.Context, name, newName string) (*projectclient.Tag, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	for i := range m.tags {
+		if m.tags[i].Name == newName {
+			return nil, status.Error(codes.AlreadyExists, "tag already exists")
+		}
+	}
+	for i := range m.tags {
+		if m.tags[i].Name == name {
+			m.tags[i].Name = newName
+			return &m.tags[i], nil
+		}
+	}
+	return nil, status.Error(codes.NotFound, "tag not found")
 }
 
 func (m *mockGrpcClient) Close() error {
@@ -43,13 +195,38 @@ func (m *mockGrpcClient) Close() error {
 // Ensure mockGrpcClient implements the interface
 var _ interface {
 	GetAllProjects(ctx context.Context) ([]projectclient.Project, error)
-	GetMessagesByEmail(ctx context.Context, email string) ([]projectclient.Message, error)
+	ListProjects(ctx context.Context, opts projectclient.ListProjectsOptions) (*projectclient.ProjectPage, error)
+	ListMessages(ctx context.Context, opts projectclient.ListMessagesOptions) (*projectclient.MessagePage, error)
+	GetProject(ctx context.Context, id int) (*projectclient.Project, error)
+	CreateProject(ctx context.Context, req projectclient.ProjectRequest) (*projectclient.Project, error)
+	UpdateProject(ctx context.Context, id int, req projectclient.ProjectRequest) (*projectclient.Project, error)
+	TransitionProject(ctx context.Context, id int, status string) (*projectclient.Project, error)
+	DeleteProject(ctx context.Context, id int) error
+	RestoreProject(ctx context.Context, id int) (*projectclient.Project, error)
+	ListMembers(ctx context.Context, projectID, teamID int) ([]projectclient.Member, error)
+	ListTags(ctx context.Context, prefix string) ([]projectclient.Tag, error)
+	RenameTag(ctx context.Context, name, newName string) (*projectclient.Tag, error)
 	Close() error
 } = (*mockGrpcClient)(nil)
 
 func TestGetMessages(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 
+	newRouter := func(mockClient *mockGrpcClient) *gin.Engine {
+		router := gin.New()
+		router.GET("/messages", func(c *gin.Context) {
+			opts := projectclient.ListMessagesOptions{Sender: c.Query("email"), PageToken: c.Query("cursor")}
+			page, err := mockClient.ListMessages(c.Request.Context(), opts)
+			if err != nil {
+				c.JSON(projectclient.HTTPStatusFromError(err), gin.H{"error": "Failed to fetch messages"})
+				return
+			}
+
+			c.JSON(http.StatusOK, page)
+		})
+		return router
+	}
+
 	t.Run("GetMessages_Success", func(t *testing.T) {
 		// Mock data
 		mockMessages := []projectclient.Message{
@@ -65,70 +242,43 @@ func TestGetMessages(t *testing.T) {
 				Message:   "Second message",
 				CreatedAt: time.Now(),
 			},
+			{
+				ID:        3,
+				Email:     "other@example.com",
+				Message:   "Other message",
+				CreatedAt: time.Now(),
+			},
 		}
 
 		mockClient := &mockGrpcClient{
 			messages: mockMessages,
 		}
 
-		router := gin.New()
-
-		// Create a test handler function that mimics the behavior
-		router.GET("/messages", func(c *gin.Context) {
-			email := c.Query("email")
-			if email == "" {
-				c.JSON(http.StatusBadRequest, gin.H{"error": "Email parameter is required"})
-				return
-			}
-
-			messages, err := mockClient.GetMessagesByEmail(c.Request.Context(), email)
-			if err != nil {
-				c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch messages"})
-				return
-			}
-
-			c.JSON(http.StatusOK, messages)
-		})
-
 		req := httptest.NewRequest(http.MethodGet, "/messages?email=test@example.com", nil)
 		w := httptest.NewRecorder()
 
-		router.ServeHTTP(w, req)
+		newRouter(mockClient).ServeHTTP(w, req)
 
 		assert.Equal(t, http.StatusOK, w.Code)
 
-		var response []projectclient.Message
+		var response projectclient.MessagePage
 		err := json.NewDecoder(w.Body).Decode(&response)
 		require.NoError(t, err)
-		assert.Len(t, response, 2)
-		assert.Equal(t, "test@example.com", response[0].Email)
-		assert.Equal(t, "First message", response[0].Message)
+		assert.Len(t, response.Items, 2)
+		assert.Equal(t, "test@example.com", response.Items[0].Email)
+		assert.Equal(t, "First message", response.Items[0].Message)
+		assert.Empty(t, response.NextCursor)
 	})
 
-	t.Run("GetMessages_MissingEmail", func(t *testing.T) {
-		mockClient := &mockGrpcClient{}
-
-		router := gin.New()
-		router.GET("/messages", func(c *gin.Context) {
-			email := c.Query("email")
-			if email == "" {
-				c.JSON(http.StatusBadRequest, gin.H{"error": "Email parameter is required"})
-				return
-			}
-
-			messages, err := mockClient.GetMessagesByEmail(c.Request.Context(), email)
-			if err != nil {
-				c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch messages"})
-				return
-			}
-
-			c.JSON(http.StatusOK, messages)
-		})
+	t.Run("GetMessages_InvalidPageToken", func(t *testing.T) {
+		mockClient := &mockGrpcClient{
+			err: status.Error(codes.InvalidArgument, "invalid page token"),
+		}
 
-		req := httptest.NewRequest(http.MethodGet, "/messages", nil)
+		req := httptest.NewRequest(http.MethodGet, "/messages?cursor=bogus", nil)
 		w := httptest.NewRecorder()
 
-		router.ServeHTTP(w, req)
+		newRouter(mockClient).ServeHTTP(w, req)
 
 		assert.Equal(t, http.StatusBadRequest, w.Code)
 	})
@@ -138,40 +288,47 @@ func TestGetMessages(t *testing.T) {
 			messages: []projectclient.Message{},
 		}
 
-		router := gin.New()
-		router.GET("/messages", func(c *gin.Context) {
-			email := c.Query("email")
-			if email == "" {
-				c.JSON(http.StatusBadRequest, gin.H{"error": "Email parameter is required"})
-				return
-			}
-
-			messages, err := mockClient.GetMessagesByEmail(c.Request.Context(), email)
-			if err != nil {
-				c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch messages"})
-				return
-			}
-
-			c.JSON(http.StatusOK, messages)
-		})
-
 		req := httptest.NewRequest(http.MethodGet, "/messages?email=nonexistent@example.com", nil)
 		w := httptest.NewRecorder()
 
-		router.ServeHTTP(w, req)
+		newRouter(mockClient).ServeHTTP(w, req)
 
 		assert.Equal(t, http.StatusOK, w.Code)
 
-		var response []projectclient.Message
+		var response projectclient.MessagePage
 		err := json.NewDecoder(w.Body).Decode(&response)
 		require.NoError(t, err)
-		assert.Len(t, response, 0)
+		assert.Len(t, response.Items, 0)
 	})
 }
 
 func TestGetAllProjects(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 
+	newRouter := func(mockClient *mockGrpcClient) *gin.Engine {
+		router := gin.New()
+		router.GET("/projects", func(c *gin.Context) {
+			opts := projectclient.ListProjectsOptions{PageToken: c.Query("cursor")}
+			if v := c.Query("limit"); v != "" {
+				limit, err := strconv.Atoi(v)
+				if err != nil {
+					c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid query parameters"})
+					return
+				}
+				opts.PageSize = limit
+			}
+
+			page, err := mockClient.ListProjects(c.Request.Context(), opts)
+			if err != nil {
+				c.JSON(projectclient.HTTPStatusFromError(err), gin.H{"error": "Failed to fetch projects"})
+				return
+			}
+
+			c.JSON(http.StatusOK, page)
+		})
+		return router
+	}
+
 	t.Run("GetAllProjects_Success", func(t *testing.T) {
 		// Mock data
 		mockProjects := []projectclient.Project{
@@ -193,60 +350,500 @@ func TestGetAllProjects(t *testing.T) {
 			projects: mockProjects,
 		}
 
+		req := httptest.NewRequest(http.MethodGet, "/projects", nil)
+		w := httptest.NewRecorder()
+
+		newRouter(mockClient).ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		var response projectclient.ProjectPage
+		err := json.NewDecoder(w.Body).Decode(&response)
+		require.NoError(t, err)
+		assert.Len(t, response.Items, 2)
+		assert.Equal(t, "Project One", response.Items[0].Name)
+		assert.Equal(t, "Project Two", response.Items[1].Name)
+		assert.Empty(t, response.NextCursor)
+	})
+
+	t.Run("GetAllProjects_Paginated", func(t *testing.T) {
+		mockClient := &mockGrpcClient{
+			projects: []projectclient.Project{{ID: 1, Name: "One"}, {ID: 2, Name: "Two"}, {ID: 3, Name: "Three"}},
+		}
+
+		req := httptest.NewRequest(http.MethodGet, "/projects?limit=2", nil)
+		w := httptest.NewRecorder()
+		newRouter(mockClient).ServeHTTP(w, req)
+
+		require.Equal(t, http.StatusOK, w.Code)
+		var first projectclient.ProjectPage
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&first))
+		assert.Len(t, first.Items, 2)
+		require.NotEmpty(t, first.NextCursor)
+
+		req = httptest.NewRequest(http.MethodGet, "/projects?limit=2&cursor="+first.NextCursor, nil)
+		w = httptest.NewRecorder()
+		newRouter(mockClient).ServeHTTP(w, req)
+
+		require.Equal(t, http.StatusOK, w.Code)
+		var second projectclient.ProjectPage
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&second))
+		require.Len(t, second.Items, 1)
+		assert.Equal(t, "Three", second.Items[0].Name)
+		assert.Empty(t, second.NextCursor)
+	})
+
+	t.Run("GetAllProjects_EmptyResult", func(t *testing.T) {
+		mockClient := &mockGrpcClient{
+			projects: []projectclient.Project{},
+		}
+
+		req := httptest.NewRequest(http.MethodGet, "/projects", nil)
+		w := httptest.NewRecorder()
+
+		newRouter(mockClient).ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		var response projectclient.ProjectPage
+		err := json.NewDecoder(w.Body).Decode(&response)
+		require.NoError(t, err)
+		assert.Len(t, response.Items, 0)
+	})
+
+	t.Run("GetAllProjects_InvalidPageToken", func(t *testing.T) {
+		mockClient := &mockGrpcClient{
+			err: status.Error(codes.InvalidArgument, "invalid page token"),
+		}
+
+		req := httptest.NewRequest(http.MethodGet, "/projects?cursor=bogus", nil)
+		w := httptest.NewRecorder()
+
+		newRouter(mockClient).ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
+
+func TestProjectCRUD(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	newRouter := func(mockClient *mockGrpcClient) *gin.Engine {
 		router := gin.New()
+		router.GET("/projects/:id", func(c *gin.Context) {
+			id, err := strconv.Atoi(c.Param("id"))
+			if err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid project ID"})
+				return
+			}
 
-		// Create a test handler function that mimics the behavior
-		router.GET("/projects", func(c *gin.Context) {
-			projects, err := mockClient.GetAllProjects(c.Request.Context())
+			project, err := mockClient.GetProject(c.Request.Context(), id)
 			if err != nil {
-				c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch projects"})
+				c.JSON(projectclient.HTTPStatusFromError(err), gin.H{"error": "Failed to fetch project"})
 				return
 			}
 
-			c.JSON(http.StatusOK, projects)
+			c.JSON(http.StatusOK, project)
 		})
+		router.POST("/projects", func(c *gin.Context) {
+			var req projectclient.ProjectRequest
+			if err := c.ShouldBindJSON(&req); err != nil || req.Name == "" {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request"})
+				return
+			}
 
-		req := httptest.NewRequest(http.MethodGet, "/projects", nil)
+			project, err := mockClient.CreateProject(c.Request.Context(), req)
+			if err != nil {
+				c.JSON(projectclient.HTTPStatusFromError(err), gin.H{"error": "Failed to create project"})
+				return
+			}
+
+			c.JSON(http.StatusCreated, project)
+		})
+		router.PUT("/projects/:id", func(c *gin.Context) {
+			id, err := strconv.Atoi(c.Param("id"))
+			if err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid project ID"})
+				return
+			}
+
+			var req projectclient.ProjectRequest
+			if err := c.ShouldBindJSON(&req); err != nil || req.Name == "" {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request"})
+				return
+			}
+
+			project, err := mockClient.UpdateProject(c.Request.Context(), id, req)
+			if err != nil {
+				c.JSON(projectclient.HTTPStatusFromError(err), gin.H{"error": "Failed to update project"})
+				return
+			}
+
+			c.JSON(http.StatusOK, project)
+		})
+		router.DELETE("/projects/:id", func(c *gin.Context) {
+			id, err := strconv.Atoi(c.Param("id"))
+			if err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid project ID"})
+				return
+			}
+
+			if err := mockClient.DeleteProject(c.Request.Context(), id); err != nil {
+				c.JSON(projectclient.HTTPStatusFromError(err), gin.H{"error": "Failed to delete project"})
+				return
+			}
+
+			c.Status(http.StatusNoContent)
+		})
+		return router
+	}
+
+	t.Run("GetProject_Success", func(t *testing.T) {
+		mockClient := &mockGrpcClient{
+			projects: []projectclient.Project{{ID: 1, Name: "Project One"}},
+		}
+
+		req := httptest.NewRequest(http.MethodGet, "/projects/1", nil)
 		w := httptest.NewRecorder()
 
-		router.ServeHTTP(w, req)
+		newRouter(mockClient).ServeHTTP(w, req)
 
 		assert.Equal(t, http.StatusOK, w.Code)
 
-		var response []projectclient.Project
+		var response projectclient.Project
 		err := json.NewDecoder(w.Body).Decode(&response)
 		require.NoError(t, err)
-		assert.Len(t, response, 2)
-		assert.Equal(t, "Project One", response[0].Name)
-		assert.Equal(t, "Project Two", response[1].Name)
+		assert.Equal(t, "Project One", response.Name)
 	})
 
-	t.Run("GetAllProjects_EmptyResult", func(t *testing.T) {
+	t.Run("GetProject_NotFound", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/projects/99", nil)
+		w := httptest.NewRecorder()
+
+		newRouter(&mockGrpcClient{}).ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	t.Run("CreateProject_Success", func(t *testing.T) {
+		mockClient := &mockGrpcClient{}
+
+		req := httptest.NewRequest(http.MethodPost, "/projects", strings.NewReader(`{"name":"New Project","description":"About","dueDate":"2026-12-15T00:00:00Z"}`))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+
+		newRouter(mockClient).ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusCreated, w.Code)
+		assert.Len(t, mockClient.projects, 1)
+		assert.Equal(t, "New Project", mockClient.projects[0].Name)
+
+		var response projectclient.Project
+		err := json.NewDecoder(w.Body).Decode(&response)
+		require.NoError(t, err)
+		assert.Equal(t, "About", response.Description)
+		assert.Equal(t, "draft", response.Status)
+		require.NotNil(t, response.DueDate)
+		assert.Nil(t, response.StartDate)
+	})
+
+	t.Run("CreateProject_InvalidArgument", func(t *testing.T) {
 		mockClient := &mockGrpcClient{
-			projects: []projectclient.Project{},
+			err: status.Error(codes.InvalidArgument, "name is required"),
 		}
 
+		req := httptest.NewRequest(http.MethodPost, "/projects", strings.NewReader(`{"name":"x"}`))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+
+		newRouter(mockClient).ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("UpdateProject_Success", func(t *testing.T) {
+		mockClient := &mockGrpcClient{
+			projects: []projectclient.Project{{ID: 1, Name: "Old Name"}},
+		}
+
+		req := httptest.NewRequest(http.MethodPut, "/projects/1", strings.NewReader(`{"name":"New Name"}`))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+
+		newRouter(mockClient).ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		var response projectclient.Project
+		err := json.NewDecoder(w.Body).Decode(&response)
+		require.NoError(t, err)
+		assert.Equal(t, "New Name", response.Name)
+	})
+
+	t.Run("UpdateProject_NotFound", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPut, "/projects/99", strings.NewReader(`{"name":"New Name"}`))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+
+		newRouter(&mockGrpcClient{}).ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	t.Run("DeleteProject_Success", func(t *testing.T) {
+		mockClient := &mockGrpcClient{
+			projects: []projectclient.Project{{ID: 1, Name: "To Delete"}},
+		}
+
+		req := httptest.NewRequest(http.MethodDelete, "/projects/1", nil)
+		w := httptest.NewRecorder()
+
+		newRouter(mockClient).ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusNoContent, w.Code)
+		assert.Len(t, mockClient.projects, 0)
+	})
+
+	t.Run("DeleteProject_NotFound", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodDelete, "/projects/99", nil)
+		w := httptest.NewRecorder()
+
+		newRouter(&mockGrpcClient{}).ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+}
+
+func TestTransitionProject(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	newRouter := func(mockClient *mockGrpcClient) *gin.Engine {
 		router := gin.New()
-		router.GET("/projects", func(c *gin.Context) {
-			projects, err := mockClient.GetAllProjects(c.Request.Context())
+		router.POST("/projects/:id/transition", func(c *gin.Context) {
+			id, err := strconv.Atoi(c.Param("id"))
 			if err != nil {
-				c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch projects"})
+				c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid project ID"})
+				return
+			}
+
+			var req projectclient.TransitionRequest
+			if err := c.ShouldBindJSON(&req); err != nil || req.Status == "" {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request"})
 				return
 			}
 
-			c.JSON(http.StatusOK, projects)
+			project, err := mockClient.TransitionProject(c.Request.Context(), id, req.Status)
+			if err != nil {
+				c.JSON(projectclient.HTTPStatusFromError(err), gin.H{"error": "Failed to transition project"})
+				return
+			}
+
+			c.JSON(http.StatusOK, project)
 		})
+		return router
+	}
 
-		req := httptest.NewRequest(http.MethodGet, "/projects", nil)
+	t.Run("TransitionProject_Success", func(t *testing.T) {
+		mockClient := &mockGrpcClient{
+			projects: []projectclient.Project{{ID: 1, Name: "Project", Status: "draft"}},
+		}
+
+		req := httptest.NewRequest(http.MethodPost, "/projects/1/transition", strings.NewReader(`{"status":"active"}`))
+		req.Header.Set("Content-Type", "application/json")
 		w := httptest.NewRecorder()
 
-		router.ServeHTTP(w, req)
+		newRouter(mockClient).ServeHTTP(w, req)
 
 		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "active", mockClient.projects[0].Status)
+	})
+
+	t.Run("TransitionProject_Illegal", func(t *testing.T) {
+		mockClient := &mockGrpcClient{
+			err: status.Error(codes.FailedPrecondition, "illegal project status transition: archived -> active"),
+		}
+
+		req := httptest.NewRequest(http.MethodPost, "/projects/1/transition", strings.NewReader(`{"status":"active"}`))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+
+		newRouter(mockClient).ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusConflict, w.Code)
+	})
+}
+
+func TestTags(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	newRouter := func(mockClient *mockGrpcClient) *gin.Engine {
+		router := gin.New()
+		router.GET("/tags", func(c *gin.Context) {
+			tags, err := mockClient.ListTags(c.Request.Context(), c.Query("prefix"))
+			if err != nil {
+				c.JSON(projectclient.HTTPStatusFromError(err), gin.H{"error": "Failed to fetch tags"})
+				return
+			}
+			c.JSON(http.StatusOK, tags)
+		})
+		router.POST("/tags/rename", func(c *gin.Context) {
+			var req projectclient.RenameTagRequest
+			if err := c.ShouldBindJSON(&req); err != nil || req.From == "" || req.To == "" {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request"})
+				return
+			}
 
-		var response []projectclient.Project
+			tag, err := mockClient.RenameTag(c.Request.Context(), req.From, req.To)
+			if err != nil {
+				c.JSON(projectclient.HTTPStatusFromError(err), gin.H{"error": "Failed to rename tag"})
+				return
+			}
+			c.JSON(http.StatusOK, tag)
+		})
+		return router
+	}
+
+	newMock := func() *mockGrpcClient {
+		return &mockGrpcClient{tags: []projectclient.Tag{
+			{ID: 1, Name: "cs101", ProjectCount: 2},
+			{ID: 2, Name: "cs102", ProjectCount: 1},
+			{ID: 3, Name: "fall", ProjectCount: 3},
+		}}
+	}
+
+	rename := func(mockClient *mockGrpcClient, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/tags/rename", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		newRouter(mockClient).ServeHTTP(w, req)
+		return w
+	}
+
+	t.Run("ListTags_Prefix", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/tags?prefix=cs", nil)
+		w := httptest.NewRecorder()
+		newRouter(newMock()).ServeHTTP(w, req)
+
+		require.Equal(t, http.StatusOK, w.Code)
+		var tags []projectclient.Tag
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&tags))
+		require.Len(t, tags, 2)
+		assert.Equal(t, "cs101", tags[0].Name)
+		assert.Equal(t, 2, tags[0].ProjectCount)
+	})
+
+	t.Run("RenameTag_Success", func(t *testing.T) {
+		mockClient := newMock()
+		w := rename(mockClient, `{"from":"fall","to":"fall-2026"}`)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "fall-2026", mockClient.tags[2].Name)
+	})
+
+	t.Run("RenameTag_Conflict", func(t *testing.T) {
+		w := rename(newMock(), `{"from":"cs101","to":"fall"}`)
+		assert.Equal(t, http.StatusConflict, w.Code)
+	})
+
+	t.Run("RenameTag_NotFound", func(t *testing.T) {
+		w := rename(newMock(), `{"from":"spring","to":"spring-2027"}`)
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	t.Run("RenameTag_InvalidRequest", func(t *testing.T) {
+		w := rename(newMock(), `{"from":"fall"}`)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
+
+func TestListMembers(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	newRouter := func(mockClient *mockGrpcClient) *gin.Engine {
+		router := gin.New()
+		router.GET("/projects/:id/members", func(c *gin.Context) {
+			projectID, err := strconv.Atoi(c.Param("id"))
+			if err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid project ID"})
+				return
+			}
+
+			members, err := mockClient.ListMembers(c.Request.Context(), projectID, 0)
+			if err != nil {
+				c.JSON(projectclient.HTTPStatusFromError(err), gin.H{"error": "Failed to fetch project members"})
+				return
+			}
+
+			c.JSON(http.StatusOK, members)
+		})
+		return router
+	}
+
+	t.Run("ListMembers_Success", func(t *testing.T) {
+		mockClient := &mockGrpcClient{
+			members: []projectclient.Member{
+				{ProjectID: 1, StudentID: 10, Role: "owner", CreatedAt: time.Now()},
+				{ProjectID: 1, StudentID: 11, Role: "viewer", CreatedAt: time.Now()},
+			},
+		}
+
+		req := httptest.NewRequest(http.MethodGet, "/projects/1/members", nil)
+		w := httptest.NewRecorder()
+
+		newRouter(mockClient).ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		var response []projectclient.Member
 		err := json.NewDecoder(w.Body).Decode(&response)
 		require.NoError(t, err)
-		assert.Len(t, response, 0)
+		assert.Len(t, response, 2)
+		assert.Equal(t, 10, response[0].StudentID)
+		assert.Equal(t, "owner", response[0].Role)
+	})
+
+	t.Run("ListMembers_ProjectNotFound", func(t *testing.T) {
+		mockClient := &mockGrpcClient{
+			err: status.Error(codes.NotFound, "project not found"),
+		}
+
+		req := httptest.NewRequest(http.MethodGet, "/projects/99/members", nil)
+		w := httptest.NewRecorder()
+
+		newRouter(mockClient).ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
 	})
+
+	t.Run("ListMembers_InvalidProjectID", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/projects/abc/members", nil)
+		w := httptest.NewRecorder()
+
+		newRouter(&mockGrpcClient{}).ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
+
+func TestHTTPStatusFromError(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want int
+	}{
+		{"NotFound", status.Error(codes.NotFound, "not found"), http.StatusNotFound},
+		{"InvalidArgument", status.Error(codes.InvalidArgument, "bad"), http.StatusBadRequest},
+		{"PermissionDenied", status.Error(codes.PermissionDenied, "denied"), http.StatusForbidden},
+		{"AlreadyExists", status.Error(codes.AlreadyExists, "exists"), http.StatusConflict},
+		{"FailedPrecondition", status.Error(codes.FailedPrecondition, "illegal"), http.StatusConflict},
+		{"Aborted", status.Error(codes.Aborted, "modified"), http.StatusPreconditionFailed},
+		{"Wrapped", fmt.Errorf("failed to call AddMember: %w", status.Error(codes.NotFound, "not found")), http.StatusNotFound},
+		{"Internal", status.Error(codes.Internal, "boom"), http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, projectclient.HTTPStatusFromError(tt.err))
+		})
+	}
 }
@@ -3,15 +3,316 @@ package projectclient
 import "time"
 
 type Project struct {
-	ID        int       `json:"id"`
-	Name      string    `json:"name"`
-	CreatedAt time.Time `json:"createdAt"`
-	UpdatedAt time.Time `json:"updatedAt"`
+	ID          int        `json:"id"`
+	Name        string     `json:"name"`
+	Description string     `json:"description"`
+	Status      string     `json:"status"`
+	StartDate   *time.Time `json:"startDate,omitempty"`
+	DueDate     *time.Time `json:"dueDate,omitempty"`
+	CreatedAt   time.Time  `json:"createdAt"`
+	UpdatedAt   time.Time  `json:"updatedAt"`
+	Version     int        `json:"version"`
+	Tags        []string   `json:"tags"`
+}
+
+// ListProjectsOptions mirrors ListProjectsRequest. Zero values mean "not set".
+type ListProjectsOptions struct {
+	PageSize      int
+	PageToken     string
+	NameContains  string
+	Status        string
+	CreatedAfter  time.Time
+	CreatedBefore time.Time
+	UpdatedAfter  time.Time
+	UpdatedBefore time.Time
+	OrderBy       string
+	Tags          []string
+	// TagMatch is "any" or "all"; empty means "any"
+	TagMatch string
+}
+
+// ProjectPage is one page of projects, shaped like the students list envelope
+type ProjectPage struct {
+	Items      []Project `json:"items"`
+	NextCursor string    `json:"nextCursor,omitempty"`
+}
+
+// HistoryEntry is one recorded change to a project or its members
+type HistoryEntry struct {
+	ID        int64                  `json:"id"`
+	Action    string                 `json:"action"`
+	Actor     string                 `json:"actor"`
+	Before    map[string]interface{} `json:"before,omitempty"`
+	After     map[string]interface{} `json:"after,omitempty"`
+	CreatedAt time.Time              `json:"createdAt"`
+}
+
+// HistoryPage is one page of a project's history, newest first
+type HistoryPage struct {
+	Items      []HistoryEntry `json:"items"`
+	NextCursor string         `json:"nextCursor,omitempty"`
+}
+
+// ListMessagesOptions mirrors ListMessagesRequest. Zero values mean "not set".
+type ListMessagesOptions struct {
+	PageSize      int
+	PageToken     string
+	Sender        string
+	Recipient     string
+	ProjectID     int
+	TeamID        int
+	TextContains  string
+	CreatedAfter  time.Time
+	CreatedBefore time.Time
+}
+
+// MessagePage is one page of messages, newest first
+type MessagePage struct {
+	Items      []Message `json:"items"`
+	NextCursor string    `json:"nextCursor,omitempty"`
+}
+
+// ExportMessagesOptions mirrors ExportMessagesRequest. Zero values mean "not set".
+type ExportMessagesOptions struct {
+	ViewerEmail   string
+	ViewerID      int
+	Email         string
+	CreatedAfter  time.Time
+	CreatedBefore time.Time
+}
+
+// ProjectSearchResult is a project matching a full-text search. Highlights
+// holds its name and description as HTML with the matches wrapped in <mark>.
+type ProjectSearchResult struct {
+	Project    Project
+	Rank       float64
+	Highlights map[string]string
+}
+
+// MessageSearchResult is a message matching a full-text search. Highlights
+// holds the fragments of the message around the matches as HTML.
+type MessageSearchResult struct {
+	Message    Message
+	Rank       float64
+	Highlights map[string]string
 }
 
 type Message struct {
-	ID        int       `json:"id"`
-	Email     string    `json:"email"`
-	Message   string    `json:"message"`
+	ID             int       `json:"id"`
+	Email          string    `json:"email"`
+	Message        string    `json:"message"`
+	TeamID         int       `json:"teamId,omitempty"`
+	ConversationID int       `json:"conversationId,omitempty"`
+	ParentID       int       `json:"parentId,omitempty"`
+	ThreadRootID   int       `json:"threadRootId,omitempty"`
+	CreatedAt      time.Time `json:"createdAt"`
+	// Edited is set once the text changed after sending; a deleted message is
+	// a tombstone without text
+	Edited    bool       `json:"edited"`
+	EditedAt  *time.Time `json:"editedAt,omitempty"`
+	Deleted   bool       `json:"deleted"`
+	DeletedAt *time.Time `json:"deletedAt,omitempty"`
+}
+
+// Conversation groups messages either within a project, joined by whoever
+// posts to it, or between two students
+type Conversation struct {
+	ID int `json:"id"`
+	// ProjectID is 0 for a direct conversation
+	ProjectID     int       `json:"projectId,omitempty"`
+	Subject       string    `json:"subject"`
+	CreatedBy     string    `json:"createdBy"`
+	Participants  []string  `json:"participants"`
+	CreatedAt     time.Time `json:"createdAt"`
+	LastMessageAt time.Time `json:"lastMessageAt"`
+}
+
+// Thread is a message and every reply to it, oldest first
+type Thread struct {
+	Root         Message       `json:"root"`
+	Replies      []Message     `json:"replies"`
+	Conversation *Conversation `json:"conversation,omitempty"`
+}
+
+// EditHistory is a message with the texts it had before its edits, oldest
+// first
+type EditHistory struct {
+	Message Message       `json:"message"`
+	Edits   []MessageEdit `json:"edits"`
+}
+
+// MessageEdit is a previous text of a message and when it was replaced
+type MessageEdit struct {
+	Message  string    `json:"message"`
+	EditedAt time.Time `json:"editedAt"`
+}
+
+// UnreadCounts is the number of messages others posted to a student's
+// conversations that the student has not read
+type UnreadCounts struct {
+	Total         int           `json:"total"`
+	Conversations []UnreadCount `json:"conversations"`
+}
+
+type UnreadCount struct {
+	ConversationID int `json:"conversationId"`
+	Unread         int `json:"unread"`
+}
+
+// ReadReceipt reports how many messages a MarkRead call found unread
+type ReadReceipt struct {
+	Marked int       `json:"marked"`
+	ReadAt time.Time `json:"readAt"`
+}
+
+// MarkReadRequest marks read every message of the student's conversations
+// created up to UpTo, which defaults to now, only those of ConversationID if set
+type MarkReadRequest struct {
+	UpTo           *time.Time `json:"upTo"`
+	ConversationID int        `json:"conversationId" validate:"gte=0"`
+}
+
+// ConversationRequest starts a conversation in a project or with the student
+// whose email is given in To
+type ConversationRequest struct {
+	ProjectID int    `json:"projectId" validate:"gte=0"`
+	To        string `json:"to" validate:"omitempty,email"`
+	Subject   string `json:"subject" validate:"max=200"`
+}
+
+type Member struct {
+	ProjectID int       `json:"projectId"`
+	StudentID int       `json:"studentId"`
+	Role      string    `json:"role"`
+	TeamID    int       `json:"teamId,omitempty"`
+	TeamLead  bool      `json:"teamLead,omitempty"`
 	CreatedAt time.Time `json:"createdAt"`
 }
+
+// Team is a named group of members within a project
+type Team struct {
+	ID        int    `json:"id"`
+	ProjectID int    `json:"projectId"`
+	Name      string `json:"name"`
+	// LeadID is 0 when the team has no lead
+	LeadID    int       `json:"leadId,omitempty"`
+	MemberIDs []int     `json:"memberIds"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+type StudentProject struct {
+	Project
+	Role string `json:"role"`
+}
+
+type ProjectRequest struct {
+	Name        string     `json:"name" validate:"required,min=1,max=255"`
+	Description string     `json:"description" validate:"max=10000"`
+	StartDate   *time.Time `json:"startDate"`
+	DueDate     *time.Time `json:"dueDate"`
+	// Tags replace the project's tags; on update, omitting them keeps the current ones
+	Tags []string `json:"tags" validate:"max=20,dive,min=1,max=64"`
+
+	// Version is the expected current version, taken from If-Match; zero updates unconditionally
+	Version int `json:"-"`
+}
+
+type Tag struct {
+	ID           int    `json:"id"`
+	Name         string `json:"name"`
+	ProjectCount int    `json:"projectCount"`
+}
+
+type RenameTagRequest struct {
+	From string `json:"from" validate:"required,max=64"`
+	To   string `json:"to" validate:"required,max=64"`
+}
+
+type MergeTagsRequest struct {
+	Sources []string `json:"sources" validate:"required,min=1,dive,required,max=64"`
+	Target  string   `json:"target" validate:"required,max=64"`
+}
+
+// Attachment is a file attached to a project
+type Attachment struct {
+	ID          int       `json:"id"`
+	ProjectID   int       `json:"projectId"`
+	Filename    string    `json:"filename"`
+	ContentType string    `json:"contentType"`
+	Size        int64     `json:"size"`
+	SHA256      string    `json:"sha256"`
+	UploadedBy  string    `json:"uploadedBy"`
+	CreatedAt   time.Time `json:"createdAt"`
+}
+
+// Submission is one version of a student's work on a project
+type Submission struct {
+	ID            int       `json:"id"`
+	ProjectID     int       `json:"projectId"`
+	StudentID     int       `json:"studentId"`
+	Version       int       `json:"version"`
+	Text          string    `json:"text"`
+	AttachmentIDs []int     `json:"attachmentIds"`
+	Status        string    `json:"status"`
+	SubmittedAt   time.Time `json:"submittedAt"`
+	// Grades are newest first; a reopened grade has ReopenedAt set
+	Grades []Grade `json:"grades"`
+}
+
+// Grade is an instructor's assessment of a submission
+type Grade struct {
+	ID           int          `json:"id"`
+	GraderID     int          `json:"graderId"`
+	Score        float64      `json:"score"`
+	MaxScore     float64      `json:"maxScore"`
+	Rubric       []RubricItem `json:"rubric"`
+	Feedback     string       `json:"feedback"`
+	GradedAt     time.Time    `json:"gradedAt"`
+	ReopenedAt   *time.Time   `json:"reopenedAt,omitempty"`
+	ReopenedBy   int          `json:"reopenedBy,omitempty"`
+	ReopenReason string       `json:"reopenReason,omitempty"`
+}
+
+// RubricItem scores one criterion of a grade
+type RubricItem struct {
+	Criterion string  `json:"criterion" validate:"required,max=200"`
+	Points    float64 `json:"points" validate:"gte=0"`
+	MaxPoints float64 `json:"maxPoints" validate:"gt=0"`
+	Comment   string  `json:"comment,omitempty"`
+}
+
+type SubmissionRequest struct {
+	Text          string `json:"text"`
+	AttachmentIDs []int  `json:"attachmentIds" validate:"max=20,dive,gt=0"`
+}
+
+// GradeRequest grades a submission. With a rubric, score and maxScore must
+// be the totals of its points; maxScore defaults to the rubric total or 100.
+type GradeRequest struct {
+	Score    float64      `json:"score" validate:"gte=0"`
+	MaxScore float64      `json:"maxScore" validate:"gte=0"`
+	Rubric   []RubricItem `json:"rubric" validate:"max=50,dive"`
+	Feedback string       `json:"feedback"`
+}
+
+type ReopenRequest struct {
+	Reason string `json:"reason"`
+}
+
+type TransitionRequest struct {
+	Status string `json:"status" validate:"required,oneof=draft active completed archived"`
+}
+
+type TeamRequest struct {
+	Name string `json:"name" validate:"required,max=100"`
+}
+
+// TeamLeadRequest names the new team lead; studentId 0 clears it
+type TeamLeadRequest struct {
+	StudentID int `json:"studentId" validate:"gte=0"`
+}
+
+type AddMemberRequest struct {
+	StudentID int    `json:"studentId" validate:"required,gt=0"`
+	Role      string `json:"role" validate:"omitempty,oneof=owner contributor viewer instructor"`
+}
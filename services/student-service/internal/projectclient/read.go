@@ -0,0 +1,94 @@
+package projectclient
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GetUnreadCounts counts the current student's unread messages per
+// conversation
+func (h *Handler) GetUnreadCounts(c *gin.Context) {
+	email, ok := h.currentEmail(c)
+	if !ok {
+		return
+	}
+
+	if h.grpcClient == nil {
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": "gRPC client not available"})
+		return
+	}
+
+	counts, err := h.grpcClient.GetUnreadCounts(c.Request.Context(), email)
+	if err != nil {
+		h.handleGrpcError(c, err, "Failed to count unread messages")
+		return
+	}
+
+	c.JSON(http.StatusOK, counts)
+}
+
+// MarkRead marks a message read by the current student
+func (h *Handler) MarkRead(c *gin.Context) {
+	email, ok := h.currentEmail(c)
+	if !ok {
+		return
+	}
+
+	messageID, err := strconv.Atoi(c.Param("id"))
+	if err != nil || messageID <= 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid message ID"})
+		return
+	}
+
+	if h.grpcClient == nil {
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": "gRPC client not available"})
+		return
+	}
+
+	h.logger.InfoContext(c.Request.Context(), "marking message read via gRPC", "email", email, "message_id", messageID)
+	receipt, err := h.grpcClient.MarkRead(c.Request.Context(), email, messageID)
+	if err != nil {
+		h.handleGrpcError(c, err, "Failed to mark message read")
+		return
+	}
+
+	c.JSON(http.StatusOK, receipt)
+}
+
+// MarkAllRead marks read every message of the current student's
+// conversations up to a time, now unless the body says otherwise
+func (h *Handler) MarkAllRead(c *gin.Context) {
+	email, ok := h.currentEmail(c)
+	if !ok {
+		return
+	}
+
+	var req MarkReadRequest
+	if err := c.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) || h.validate.Struct(&req) != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request"})
+		return
+	}
+	upTo := time.Now()
+	if req.UpTo != nil {
+		upTo = *req.UpTo
+	}
+
+	if h.grpcClient == nil {
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": "gRPC client not available"})
+		return
+	}
+
+	h.logger.InfoContext(c.Request.Context(), "marking messages read via gRPC", "email", email, "up_to", upTo, "conversation_id", req.ConversationID)
+	receipt, err := h.grpcClient.MarkReadUpTo(c.Request.Context(), email, upTo, req.ConversationID)
+	if err != nil {
+		h.handleGrpcError(c, err, "Failed to mark messages read")
+		return
+	}
+
+	c.JSON(http.StatusOK, receipt)
+}
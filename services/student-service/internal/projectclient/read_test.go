@@ -0,0 +1,131 @@
+package projectclient_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+
+	messagepb "grud/api/gen/message/v1"
+	"student-service/internal/auth"
+	"student-service/internal/projectclient"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// fakeReadService records MarkRead calls; message 1 is the only one that exists
+type fakeReadService struct {
+	messagepb.UnimplementedMessageServiceServer
+
+	marked []*messagepb.MarkReadRequest
+}
+
+func (f *fakeReadService) MarkRead(ctx context.Context, req *messagepb.MarkReadRequest) (*messagepb.MarkReadResponse, error) {
+	if req.MessageId > 1 {
+		return nil, status.Error(codes.NotFound, "message not found")
+	}
+	f.marked = append(f.marked, req)
+	return &messagepb.MarkReadResponse{Marked: 1, ReadAt: timestamppb.Now()}, nil
+}
+
+func (f *fakeReadService) GetUnreadCounts(ctx context.Context, req *messagepb.GetUnreadCountsRequest) (*messagepb.GetUnreadCountsResponse, error) {
+	return &messagepb.GetUnreadCountsResponse{
+		Total:         3,
+		Conversations: []*messagepb.UnreadCount{{ConversationId: 2, Unread: 1}, {ConversationId: 5, Unread: 2}},
+	}, nil
+}
+
+func TestReadReceipts(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	server := grpc.NewServer()
+	fake := &fakeReadService{}
+	messagepb.RegisterMessageServiceServer(server, fake)
+	go server.Serve(lis)
+	defer server.Stop()
+
+	client, err := projectclient.NewGrpcClient(lis.Addr().String())
+	require.NoError(t, err)
+	defer client.Close()
+
+	// The X-Email header stands in for the email AuthMiddleware takes from the token
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		if email := c.GetHeader("X-Email"); email != "" {
+			c.Request = c.Request.WithContext(context.WithValue(c.Request.Context(), auth.EmailKey, email))
+		}
+	})
+	projectclient.NewHandler(client, slog.New(slog.NewTextHandler(os.Stderr, nil)), nil).RegisterRoutes(router)
+
+	do := func(email, method, target string, body interface{}) *httptest.ResponseRecorder {
+		var buf bytes.Buffer
+		if body != nil {
+			require.NoError(t, json.NewEncoder(&buf).Encode(body))
+		}
+		req := httptest.NewRequest(method, target, &buf)
+		req.Header.Set("Content-Type", "application/json")
+		if email != "" {
+			req.Header.Set("X-Email", email)
+		}
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	const bob = "bob@example.com"
+
+	w := do(bob, http.MethodGet, "/messages/unread", nil)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	var counts projectclient.UnreadCounts
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &counts))
+	assert.Equal(t, 3, counts.Total)
+	assert.Equal(t, []projectclient.UnreadCount{{ConversationID: 2, Unread: 1}, {ConversationID: 5, Unread: 2}}, counts.Conversations)
+
+	w = do(bob, http.MethodPost, "/messages/1/read", nil)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	var receipt projectclient.ReadReceipt
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &receipt))
+	assert.Equal(t, 1, receipt.Marked)
+	assert.Equal(t, bob, fake.marked[0].Email)
+	assert.Equal(t, int32(1), fake.marked[0].MessageId)
+
+	w = do(bob, http.MethodPost, "/messages/9/read", nil)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	w = do(bob, http.MethodPost, "/messages/x/read", nil)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	// Without a body everything up to now is marked read
+	before := time.Now()
+	w = do(bob, http.MethodPost, "/messages/read", nil)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	require.Len(t, fake.marked, 2)
+	assert.False(t, fake.marked[1].UpTo.AsTime().Before(before.Truncate(time.Microsecond)))
+	assert.Zero(t, fake.marked[1].ConversationId)
+
+	upTo := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	w = do(bob, http.MethodPost, "/messages/read", gin.H{"upTo": upTo, "conversationId": 5})
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	require.Len(t, fake.marked, 3)
+	assert.True(t, upTo.Equal(fake.marked[2].UpTo.AsTime()))
+	assert.Equal(t, int32(5), fake.marked[2].ConversationId)
+
+	w = do(bob, http.MethodPost, "/messages/read", gin.H{"conversationId": -1})
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	w = do("", http.MethodGet, "/messages/unread", nil)
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+}
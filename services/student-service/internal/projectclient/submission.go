@@ -0,0 +1,217 @@
+package projectclient
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+
+	"student-service/internal/auth"
+
+	"github.com/gin-gonic/gin"
+)
+
+// CreateSubmission hands in the work of the current student, who must be an
+// owner or contributor of the project
+func (h *Handler) CreateSubmission(c *gin.Context) {
+	projectID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid project ID"})
+		return
+	}
+
+	studentID, ok := h.currentStudent(c)
+	if !ok {
+		return
+	}
+
+	var req SubmissionRequest
+	if err := c.ShouldBindJSON(&req); err != nil || h.validate.Struct(&req) != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request"})
+		return
+	}
+
+	if h.grpcClient == nil {
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": "gRPC client not available"})
+		return
+	}
+
+	h.logger.InfoContext(c.Request.Context(), "creating submission via gRPC", "project_id", projectID, "student_id", studentID)
+	submission, err := h.grpcClient.CreateSubmission(c.Request.Context(), projectID, studentID, req)
+	if err != nil {
+		h.handleGrpcError(c, err, "Failed to create submission")
+		return
+	}
+
+	c.JSON(http.StatusCreated, submission)
+}
+
+// ListSubmissions lists a project's submissions, optionally only those of
+// the student given by the studentId query parameter. Students who are not
+// instructors of the project only see their own.
+func (h *Handler) ListSubmissions(c *gin.Context) {
+	projectID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid project ID"})
+		return
+	}
+
+	viewerID, ok := h.currentStudent(c)
+	if !ok {
+		return
+	}
+
+	var studentID int
+	if s := c.Query("studentId"); s != "" {
+		if studentID, err = strconv.Atoi(s); err != nil || studentID <= 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid student ID"})
+			return
+		}
+	}
+
+	if h.grpcClient == nil {
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": "gRPC client not available"})
+		return
+	}
+
+	h.logger.InfoContext(c.Request.Context(), "listing submissions via gRPC", "project_id", projectID, "student_id", studentID)
+	submissions, err := h.grpcClient.ListSubmissions(c.Request.Context(), projectID, studentID, viewerID)
+	if err != nil {
+		h.handleGrpcError(c, err, "Failed to fetch submissions")
+		return
+	}
+
+	c.JSON(http.StatusOK, submissions)
+}
+
+// GetMySubmissions lists the current student's submissions across projects
+func (h *Handler) GetMySubmissions(c *gin.Context) {
+	studentID, ok := h.currentStudent(c)
+	if !ok {
+		return
+	}
+
+	if h.grpcClient == nil {
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": "gRPC client not available"})
+		return
+	}
+
+	h.logger.InfoContext(c.Request.Context(), "fetching submissions for current student via gRPC", "student_id", studentID)
+	submissions, err := h.grpcClient.ListSubmissions(c.Request.Context(), 0, studentID, studentID)
+	if err != nil {
+		h.handleGrpcError(c, err, "Failed to fetch submissions")
+		return
+	}
+
+	c.JSON(http.StatusOK, submissions)
+}
+
+// GetSubmission returns a submission to its author or an instructor of its
+// project
+func (h *Handler) GetSubmission(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid submission ID"})
+		return
+	}
+
+	viewerID, ok := h.currentStudent(c)
+	if !ok {
+		return
+	}
+
+	if h.grpcClient == nil {
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": "gRPC client not available"})
+		return
+	}
+
+	h.logger.InfoContext(c.Request.Context(), "fetching submission via gRPC", "id", id, "viewer_id", viewerID)
+	submission, err := h.grpcClient.GetSubmission(c.Request.Context(), id, viewerID)
+	if err != nil {
+		h.handleGrpcError(c, err, "Failed to fetch submission")
+		return
+	}
+
+	c.JSON(http.StatusOK, submission)
+}
+
+// GradeSubmission grades a submission on behalf of the current student, who
+// must be an instructor of its project
+func (h *Handler) GradeSubmission(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid submission ID"})
+		return
+	}
+
+	graderID, ok := h.currentStudent(c)
+	if !ok {
+		return
+	}
+
+	var req GradeRequest
+	if err := c.ShouldBindJSON(&req); err != nil || h.validate.Struct(&req) != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request"})
+		return
+	}
+
+	if h.grpcClient == nil {
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": "gRPC client not available"})
+		return
+	}
+
+	h.logger.InfoContext(c.Request.Context(), "grading submission via gRPC", "id", id, "grader_id", graderID)
+	submission, err := h.grpcClient.GradeSubmission(c.Request.Context(), id, graderID, req)
+	if err != nil {
+		h.handleGrpcError(c, err, "Failed to grade submission")
+		return
+	}
+
+	c.JSON(http.StatusOK, submission)
+}
+
+// ReopenGrade reopens a submission's grade on behalf of the current student,
+// who must be an instructor of its project. The reason is optional.
+func (h *Handler) ReopenGrade(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid submission ID"})
+		return
+	}
+
+	graderID, ok := h.currentStudent(c)
+	if !ok {
+		return
+	}
+
+	var req ReopenRequest
+	if err := c.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request"})
+		return
+	}
+
+	if h.grpcClient == nil {
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": "gRPC client not available"})
+		return
+	}
+
+	h.logger.InfoContext(c.Request.Context(), "reopening grade via gRPC", "id", id, "grader_id", graderID)
+	submission, err := h.grpcClient.ReopenGrade(c.Request.Context(), id, graderID, req.Reason)
+	if err != nil {
+		h.handleGrpcError(c, err, "Failed to reopen grade")
+		return
+	}
+
+	c.JSON(http.StatusOK, submission)
+}
+
+// currentStudent returns the ID of the authenticated student, responding
+// with 401 if there is none
+func (h *Handler) currentStudent(c *gin.Context) (int, bool) {
+	studentID, ok := auth.GetStudentID(c.Request.Context())
+	if !ok {
+		h.logger.WarnContext(c.Request.Context(), "student ID not found in context")
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+	}
+	return studentID, ok
+}
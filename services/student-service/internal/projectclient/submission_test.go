@@ -0,0 +1,311 @@
+package projectclient_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strconv"
+	"sync"
+	"testing"
+
+	submissionpb "grud/api/gen/submission/v1"
+	"student-service/internal/auth"
+	"student-service/internal/projectclient"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// fakeSubmissionService keeps submissions in memory. Student 1 may submit to
+// project 1 and student 2 is its instructor. Student 3 is a classmate.
+type fakeSubmissionService struct {
+	submissionpb.UnimplementedSubmissionServiceServer
+
+	mu          sync.Mutex
+	submissions []*submissionpb.Submission
+	lastGrade   *submissionpb.GradeSubmissionRequest
+}
+
+const (
+	fakeStudentID    = 1
+	fakeInstructorID = 2
+	fakeClassmateID  = 3
+)
+
+func (f *fakeSubmissionService) CreateSubmission(ctx context.Context, req *submissionpb.CreateSubmissionRequest) (*submissionpb.CreateSubmissionResponse, error) {
+	if req.ProjectId != 1 {
+		return nil, status.Error(codes.NotFound, "project not found")
+	}
+	if req.StudentId != fakeStudentID {
+		return nil, status.Error(codes.PermissionDenied, "only owners and contributors of the project may submit")
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	s := &submissionpb.Submission{
+		Id:            int32(len(f.submissions) + 1),
+		ProjectId:     req.ProjectId,
+		StudentId:     req.StudentId,
+		Version:       int32(len(f.submissions) + 1),
+		Text:          req.Text,
+		AttachmentIds: req.AttachmentIds,
+		Status:        submissionpb.SubmissionStatus_SUBMISSION_STATUS_SUBMITTED,
+		SubmittedAt:   timestamppb.Now(),
+	}
+	f.submissions = append(f.submissions, s)
+	return &submissionpb.CreateSubmissionResponse{Submission: s}, nil
+}
+
+func (f *fakeSubmissionService) GetSubmission(ctx context.Context, req *submissionpb.GetSubmissionRequest) (*submissionpb.GetSubmissionResponse, error) {
+	s, err := f.get(req.Id)
+	if err != nil {
+		return nil, err
+	}
+	if req.ViewerId != s.StudentId && req.ViewerId != fakeInstructorID {
+		return nil, status.Error(codes.PermissionDenied, "only the author and instructors of the project may see a submission")
+	}
+	return &submissionpb.GetSubmissionResponse{Submission: s}, nil
+}
+
+func (f *fakeSubmissionService) ListSubmissions(ctx context.Context, req *submissionpb.ListSubmissionsRequest) (*submissionpb.ListSubmissionsResponse, error) {
+	if req.ViewerId != fakeInstructorID {
+		if req.StudentId != 0 && req.StudentId != req.ViewerId {
+			return nil, status.Error(codes.PermissionDenied, "only the author and instructors of the project may see a submission")
+		}
+		req.StudentId = req.ViewerId
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	resp := &submissionpb.ListSubmissionsResponse{}
+	for i := len(f.submissions) - 1; i >= 0; i-- {
+		s := f.submissions[i]
+		if (req.ProjectId == 0 || s.ProjectId == req.ProjectId) && (req.StudentId == 0 || s.StudentId == req.StudentId) {
+			resp.Submissions = append(resp.Submissions, s)
+		}
+	}
+	return resp, nil
+}
+
+func (f *fakeSubmissionService) GradeSubmission(ctx context.Context, req *submissionpb.GradeSubmissionRequest) (*submissionpb.GradeSubmissionResponse, error) {
+	s, err := f.get(req.Id)
+	if err != nil {
+		return nil, err
+	}
+	if req.GraderId != fakeInstructorID {
+		return nil, status.Error(codes.PermissionDenied, "only instructors of the project may grade its submissions")
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if s.Status == submissionpb.SubmissionStatus_SUBMISSION_STATUS_GRADED {
+		return nil, status.Error(codes.FailedPrecondition, "submission is already graded")
+	}
+	f.lastGrade = req
+	s.Status = submissionpb.SubmissionStatus_SUBMISSION_STATUS_GRADED
+	s.Grades = append([]*submissionpb.Grade{{
+		Id:       int32(len(s.Grades) + 1),
+		GraderId: req.GraderId,
+		Score:    req.Score,
+		MaxScore: req.MaxScore,
+		Rubric:   req.Rubric,
+		Feedback: req.Feedback,
+		GradedAt: timestamppb.Now(),
+	}}, s.Grades...)
+	return &submissionpb.GradeSubmissionResponse{Submission: s}, nil
+}
+
+func (f *fakeSubmissionService) ReopenGrade(ctx context.Context, req *submissionpb.ReopenGradeRequest) (*submissionpb.ReopenGradeResponse, error) {
+	s, err := f.get(req.Id)
+	if err != nil {
+		return nil, err
+	}
+	if req.GraderId != fakeInstructorID {
+		return nil, status.Error(codes.PermissionDenied, "only instructors of the project may grade its submissions")
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if s.Status != submissionpb.SubmissionStatus_SUBMISSION_STATUS_GRADED {
+		return nil, status.Error(codes.FailedPrecondition, "submission is not graded")
+	}
+	s.Status = submissionpb.SubmissionStatus_SUBMISSION_STATUS_REOPENED
+	s.Grades[0].ReopenedAt = timestamppb.Now()
+	s.Grades[0].ReopenedBy = req.GraderId
+	s.Grades[0].ReopenReason = req.Reason
+	return &submissionpb.ReopenGradeResponse{Submission: s}, nil
+}
+
+func (f *fakeSubmissionService) get(id int32) (*submissionpb.Submission, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if id <= 0 || int(id) > len(f.submissions) {
+		return nil, status.Error(codes.NotFound, "submission not found")
+	}
+	return f.submissions[id-1], nil
+}
+
+func TestSubmissions(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	fake := &fakeSubmissionService{}
+	server := grpc.NewServer()
+	submissionpb.RegisterSubmissionServiceServer(server, fake)
+	go server.Serve(lis)
+	defer server.Stop()
+
+	client, err := projectclient.NewGrpcClient(lis.Addr().String())
+	require.NoError(t, err)
+	defer client.Close()
+
+	// The X-Student header stands in for the student AuthMiddleware takes from the token
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		if id, err := strconv.Atoi(c.GetHeader("X-Student")); err == nil {
+			c.Request = c.Request.WithContext(context.WithValue(c.Request.Context(), auth.StudentIDKey, id))
+		}
+	})
+	projectclient.NewHandler(client, slog.New(slog.NewTextHandler(os.Stderr, nil)), nil).RegisterRoutes(router)
+
+	do := func(studentID int, method, target string, body interface{}) *httptest.ResponseRecorder {
+		var buf bytes.Buffer
+		if body != nil {
+			require.NoError(t, json.NewEncoder(&buf).Encode(body))
+		}
+		req := httptest.NewRequest(method, target, &buf)
+		req.Header.Set("Content-Type", "application/json")
+		if studentID != 0 {
+			req.Header.Set("X-Student", strconv.Itoa(studentID))
+		}
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	decode := func(t *testing.T, w *httptest.ResponseRecorder) projectclient.Submission {
+		var s projectclient.Submission
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &s))
+		return s
+	}
+
+	t.Run("SubmitGradeReopen", func(t *testing.T) {
+		w := do(fakeStudentID, http.MethodPost, "/projects/1/submissions",
+			gin.H{"text": "My report", "attachmentIds": []int{4, 5}})
+		require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+		submitted := decode(t, w)
+		assert.Equal(t, fakeStudentID, submitted.StudentID)
+		assert.Equal(t, []int{4, 5}, submitted.AttachmentIDs)
+		assert.Equal(t, "submitted", submitted.Status)
+		assert.Empty(t, submitted.Grades)
+
+		target := "/submissions/" + strconv.Itoa(submitted.ID)
+		w = do(fakeInstructorID, http.MethodPost, target+"/grade", gin.H{
+			"score": 14, "maxScore": 20, "feedback": "Solid",
+			"rubric": []gin.H{{"criterion": "Design", "points": 8, "maxPoints": 10}, {"criterion": "Tests", "points": 6, "maxPoints": 10}},
+		})
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		graded := decode(t, w)
+		assert.Equal(t, "graded", graded.Status)
+		require.Len(t, graded.Grades, 1)
+		assert.Equal(t, fakeInstructorID, graded.Grades[0].GraderID)
+		assert.Equal(t, 14.0, graded.Grades[0].Score)
+		assert.Equal(t, "Design", graded.Grades[0].Rubric[0].Criterion)
+		assert.Nil(t, graded.Grades[0].ReopenedAt)
+		assert.True(t, proto.Equal(&submissionpb.RubricItem{Criterion: "Tests", Points: 6, MaxPoints: 10}, fake.lastGrade.Rubric[1]))
+
+		w = do(fakeInstructorID, http.MethodPost, target+"/grade", gin.H{"score": 15})
+		assert.Equal(t, http.StatusConflict, w.Code)
+
+		// The reason is optional
+		w = do(fakeInstructorID, http.MethodPost, target+"/reopen", nil)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		reopened := decode(t, w)
+		assert.Equal(t, "reopened", reopened.Status)
+		require.NotNil(t, reopened.Grades[0].ReopenedAt)
+		assert.Equal(t, fakeInstructorID, reopened.Grades[0].ReopenedBy)
+
+		w = do(fakeStudentID, http.MethodGet, target, nil)
+		require.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "reopened", decode(t, w).Status)
+
+		w = do(fakeStudentID, http.MethodGet, "/me/submissions", nil)
+		require.Equal(t, http.StatusOK, w.Code)
+		var mine []projectclient.Submission
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &mine))
+		require.NotEmpty(t, mine)
+		assert.Equal(t, submitted.ID, mine[0].ID)
+
+		w = do(fakeInstructorID, http.MethodGet, "/projects/1/submissions?studentId=1", nil)
+		require.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("Forbidden", func(t *testing.T) {
+		w := do(fakeInstructorID, http.MethodPost, "/projects/1/submissions", gin.H{"text": "Not mine to submit"})
+		assert.Equal(t, http.StatusForbidden, w.Code)
+
+		w = do(fakeStudentID, http.MethodPost, "/projects/1/submissions", gin.H{"text": "Work"})
+		require.Equal(t, http.StatusCreated, w.Code)
+		target := "/submissions/" + strconv.Itoa(decode(t, w).ID)
+
+		w = do(fakeStudentID, http.MethodPost, target+"/grade", gin.H{"score": 100})
+		assert.Equal(t, http.StatusForbidden, w.Code)
+		assert.Contains(t, w.Body.String(), "only instructors")
+
+		w = do(fakeStudentID, http.MethodPost, target+"/reopen", gin.H{"reason": "Please"})
+		assert.Equal(t, http.StatusForbidden, w.Code)
+
+		w = do(0, http.MethodPost, target+"/grade", gin.H{"score": 100})
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+
+		// Grades and feedback are only shown to the author and instructors
+		w = do(fakeClassmateID, http.MethodGet, target, nil)
+		assert.Equal(t, http.StatusForbidden, w.Code)
+
+		w = do(fakeClassmateID, http.MethodGet, "/projects/1/submissions?studentId=1", nil)
+		assert.Equal(t, http.StatusForbidden, w.Code)
+
+		w = do(fakeClassmateID, http.MethodGet, "/projects/1/submissions", nil)
+		require.Equal(t, http.StatusOK, w.Code)
+		assert.JSONEq(t, "[]", w.Body.String())
+
+		w = do(0, http.MethodGet, target, nil)
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+	})
+
+	t.Run("Rejected", func(t *testing.T) {
+		w := do(fakeStudentID, http.MethodPost, "/projects/x/submissions", gin.H{"text": "Work"})
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+
+		w = do(fakeStudentID, http.MethodPost, "/projects/1/submissions", gin.H{"attachmentIds": []int{0}})
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+
+		w = do(fakeStudentID, http.MethodPost, "/projects/2/submissions", gin.H{"text": "Work"})
+		assert.Equal(t, http.StatusNotFound, w.Code)
+
+		w = do(fakeInstructorID, http.MethodPost, "/submissions/1/grade", gin.H{"score": -1})
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+
+		w = do(fakeInstructorID, http.MethodPost, "/submissions/1/grade",
+			gin.H{"score": 5, "rubric": []gin.H{{"criterion": "", "points": 5, "maxPoints": 10}}})
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+
+		w = do(fakeStudentID, http.MethodGet, "/projects/1/submissions?studentId=abc", nil)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+
+		w = do(fakeStudentID, http.MethodGet, "/submissions/999", nil)
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+}
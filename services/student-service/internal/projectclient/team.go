@@ -0,0 +1,228 @@
+package projectclient
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+func (h *Handler) ListTeams(c *gin.Context) {
+	projectID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid project ID"})
+		return
+	}
+
+	if h.grpcClient == nil {
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": "gRPC client not available"})
+		return
+	}
+
+	h.logger.InfoContext(c.Request.Context(), "listing teams via gRPC", "project_id", projectID)
+	teams, err := h.grpcClient.ListTeams(c.Request.Context(), projectID)
+	if err != nil {
+		h.handleGrpcError(c, err, "Failed to fetch teams")
+		return
+	}
+
+	c.JSON(http.StatusOK, teams)
+}
+
+func (h *Handler) CreateTeam(c *gin.Context) {
+	projectID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid project ID"})
+		return
+	}
+
+	var req TeamRequest
+	if err := c.ShouldBindJSON(&req); err != nil || h.validate.Struct(&req) != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request"})
+		return
+	}
+
+	if h.grpcClient == nil {
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": "gRPC client not available"})
+		return
+	}
+
+	h.logger.InfoContext(c.Request.Context(), "creating team via gRPC", "project_id", projectID, "name", req.Name)
+	team, err := h.grpcClient.CreateTeam(c.Request.Context(), projectID, req.Name)
+	if err != nil {
+		h.handleGrpcError(c, err, "Failed to create team")
+		return
+	}
+
+	c.JSON(http.StatusCreated, team)
+}
+
+func (h *Handler) GetTeam(c *gin.Context) {
+	projectID, teamID, ok := teamParams(c)
+	if !ok {
+		return
+	}
+
+	if h.grpcClient == nil {
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": "gRPC client not available"})
+		return
+	}
+
+	h.logger.InfoContext(c.Request.Context(), "fetching team via gRPC", "project_id", projectID, "team_id", teamID)
+	team, err := h.grpcClient.GetTeam(c.Request.Context(), projectID, teamID)
+	if err != nil {
+		h.handleGrpcError(c, err, "Failed to fetch team")
+		return
+	}
+
+	c.JSON(http.StatusOK, team)
+}
+
+func (h *Handler) RenameTeam(c *gin.Context) {
+	projectID, teamID, ok := teamParams(c)
+	if !ok {
+		return
+	}
+
+	var req TeamRequest
+	if err := c.ShouldBindJSON(&req); err != nil || h.validate.Struct(&req) != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request"})
+		return
+	}
+
+	if h.grpcClient == nil {
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": "gRPC client not available"})
+		return
+	}
+
+	h.logger.InfoContext(c.Request.Context(), "renaming team via gRPC", "project_id", projectID, "team_id", teamID, "name", req.Name)
+	team, err := h.grpcClient.RenameTeam(c.Request.Context(), projectID, teamID, req.Name)
+	if err != nil {
+		h.handleGrpcError(c, err, "Failed to rename team")
+		return
+	}
+
+	c.JSON(http.StatusOK, team)
+}
+
+// DeleteTeam deletes a team; its members stay in the project without a team
+func (h *Handler) DeleteTeam(c *gin.Context) {
+	projectID, teamID, ok := teamParams(c)
+	if !ok {
+		return
+	}
+
+	if h.grpcClient == nil {
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": "gRPC client not available"})
+		return
+	}
+
+	h.logger.InfoContext(c.Request.Context(), "deleting team via gRPC", "project_id", projectID, "team_id", teamID)
+	if err := h.grpcClient.DeleteTeam(c.Request.Context(), projectID, teamID); err != nil {
+		h.handleGrpcError(c, err, "Failed to delete team")
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// AddTeamMember assigns a project member to the team, moving them out of
+// any other team of the project
+func (h *Handler) AddTeamMember(c *gin.Context) {
+	projectID, teamID, ok := teamParams(c)
+	if !ok {
+		return
+	}
+
+	studentID, err := strconv.Atoi(c.Param("studentId"))
+	if err != nil || studentID <= 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid student ID"})
+		return
+	}
+
+	if h.grpcClient == nil {
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": "gRPC client not available"})
+		return
+	}
+
+	h.logger.InfoContext(c.Request.Context(), "adding team member via gRPC", "project_id", projectID, "team_id", teamID, "student_id", studentID)
+	team, err := h.grpcClient.AddTeamMember(c.Request.Context(), projectID, teamID, studentID)
+	if err != nil {
+		h.handleGrpcError(c, err, "Failed to add team member")
+		return
+	}
+
+	c.JSON(http.StatusOK, team)
+}
+
+func (h *Handler) RemoveTeamMember(c *gin.Context) {
+	projectID, teamID, ok := teamParams(c)
+	if !ok {
+		return
+	}
+
+	studentID, err := strconv.Atoi(c.Param("studentId"))
+	if err != nil || studentID <= 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid student ID"})
+		return
+	}
+
+	if h.grpcClient == nil {
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": "gRPC client not available"})
+		return
+	}
+
+	h.logger.InfoContext(c.Request.Context(), "removing team member via gRPC", "project_id", projectID, "team_id", teamID, "student_id", studentID)
+	team, err := h.grpcClient.RemoveTeamMember(c.Request.Context(), projectID, teamID, studentID)
+	if err != nil {
+		h.handleGrpcError(c, err, "Failed to remove team member")
+		return
+	}
+
+	c.JSON(http.StatusOK, team)
+}
+
+// SetTeamLead replaces the team's lead, who must be a member of the team.
+// A studentId of 0 leaves the team without a lead.
+func (h *Handler) SetTeamLead(c *gin.Context) {
+	projectID, teamID, ok := teamParams(c)
+	if !ok {
+		return
+	}
+
+	var req TeamLeadRequest
+	if err := c.ShouldBindJSON(&req); err != nil || h.validate.Struct(&req) != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request"})
+		return
+	}
+
+	if h.grpcClient == nil {
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": "gRPC client not available"})
+		return
+	}
+
+	h.logger.InfoContext(c.Request.Context(), "setting team lead via gRPC", "project_id", projectID, "team_id", teamID, "student_id", req.StudentID)
+	team, err := h.grpcClient.SetTeamLead(c.Request.Context(), projectID, teamID, req.StudentID)
+	if err != nil {
+		h.handleGrpcError(c, err, "Failed to set team lead")
+		return
+	}
+
+	c.JSON(http.StatusOK, team)
+}
+
+// teamParams reads the project and team IDs from the path, responding with
+// 400 if either is invalid
+func teamParams(c *gin.Context) (projectID, teamID int, ok bool) {
+	projectID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid project ID"})
+		return 0, 0, false
+	}
+	teamID, err = strconv.Atoi(c.Param("teamId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid team ID"})
+		return 0, 0, false
+	}
+	return projectID, teamID, true
+}
@@ -0,0 +1,182 @@
+package projectclient_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"slices"
+	"sync"
+	"testing"
+
+	teampb "grud/api/gen/team/v1"
+	"student-service/internal/projectclient"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// fakeTeamService keeps the teams of project 1 in memory; students 1 to 3
+// are its members
+type fakeTeamService struct {
+	teampb.UnimplementedTeamServiceServer
+
+	mu    sync.Mutex
+	teams []*teampb.Team
+}
+
+func (f *fakeTeamService) CreateTeam(ctx context.Context, req *teampb.CreateTeamRequest) (*teampb.CreateTeamResponse, error) {
+	if req.ProjectId != 1 {
+		return nil, status.Error(codes.NotFound, "project not found")
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for _, team := range f.teams {
+		if team.Name == req.Name {
+			return nil, status.Error(codes.AlreadyExists, "project already has a team of that name")
+		}
+	}
+	team := &teampb.Team{Id: int32(len(f.teams) + 1), ProjectId: 1, Name: req.Name, CreatedAt: timestamppb.Now()}
+	f.teams = append(f.teams, team)
+	return &teampb.CreateTeamResponse{Team: team}, nil
+}
+
+func (f *fakeTeamService) GetTeam(ctx context.Context, req *teampb.GetTeamRequest) (*teampb.GetTeamResponse, error) {
+	team, err := f.get(req.ProjectId, req.Id)
+	if err != nil {
+		return nil, err
+	}
+	return &teampb.GetTeamResponse{Team: team}, nil
+}
+
+func (f *fakeTeamService) ListTeams(ctx context.Context, req *teampb.ListTeamsRequest) (*teampb.ListTeamsResponse, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return &teampb.ListTeamsResponse{Teams: f.teams}, nil
+}
+
+func (f *fakeTeamService) AddTeamMember(ctx context.Context, req *teampb.AddTeamMemberRequest) (*teampb.AddTeamMemberResponse, error) {
+	team, err := f.get(req.ProjectId, req.TeamId)
+	if err != nil {
+		return nil, err
+	}
+	if req.StudentId > 3 {
+		return nil, status.Error(codes.FailedPrecondition, "student is not a member of the project")
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if !slices.Contains(team.MemberIds, req.StudentId) {
+		team.MemberIds = append(team.MemberIds, req.StudentId)
+	}
+	return &teampb.AddTeamMemberResponse{Team: team}, nil
+}
+
+func (f *fakeTeamService) SetTeamLead(ctx context.Context, req *teampb.SetTeamLeadRequest) (*teampb.SetTeamLeadResponse, error) {
+	team, err := f.get(req.ProjectId, req.TeamId)
+	if err != nil {
+		return nil, err
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if req.StudentId != 0 && !slices.Contains(team.MemberIds, req.StudentId) {
+		return nil, status.Error(codes.FailedPrecondition, "student is not a member of the team")
+	}
+	team.LeadId = req.StudentId
+	return &teampb.SetTeamLeadResponse{Team: team}, nil
+}
+
+func (f *fakeTeamService) get(projectID, id int32) (*teampb.Team, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if projectID != 1 || id <= 0 || int(id) > len(f.teams) {
+		return nil, status.Error(codes.NotFound, "team not found")
+	}
+	return f.teams[id-1], nil
+}
+
+func TestTeams(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	server := grpc.NewServer()
+	teampb.RegisterTeamServiceServer(server, &fakeTeamService{})
+	go server.Serve(lis)
+	defer server.Stop()
+
+	client, err := projectclient.NewGrpcClient(lis.Addr().String())
+	require.NoError(t, err)
+	defer client.Close()
+
+	router := gin.New()
+	projectclient.NewHandler(client, slog.New(slog.NewTextHandler(os.Stderr, nil)), nil).RegisterRoutes(router)
+
+	do := func(method, target string, body interface{}) *httptest.ResponseRecorder {
+		var buf bytes.Buffer
+		if body != nil {
+			require.NoError(t, json.NewEncoder(&buf).Encode(body))
+		}
+		req := httptest.NewRequest(method, target, &buf)
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	decode := func(t *testing.T, w *httptest.ResponseRecorder) projectclient.Team {
+		var team projectclient.Team
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &team))
+		return team
+	}
+
+	w := do(http.MethodPost, "/projects/1/teams", gin.H{"name": "Red team"})
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+	red := decode(t, w)
+	assert.Equal(t, "Red team", red.Name)
+	assert.Equal(t, []int{}, red.MemberIDs)
+
+	w = do(http.MethodPost, "/projects/1/teams", gin.H{"name": "Red team"})
+	assert.Equal(t, http.StatusConflict, w.Code)
+
+	w = do(http.MethodPut, "/projects/1/teams/1/members/2", nil)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	assert.Equal(t, []int{2}, decode(t, w).MemberIDs)
+
+	w = do(http.MethodPut, "/projects/1/teams/1/members/9", nil)
+	assert.Equal(t, http.StatusConflict, w.Code)
+
+	w = do(http.MethodPut, "/projects/1/teams/1/lead", gin.H{"studentId": 2})
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	assert.Equal(t, 2, decode(t, w).LeadID)
+
+	w = do(http.MethodPut, "/projects/1/teams/1/lead", gin.H{"studentId": 3})
+	assert.Equal(t, http.StatusConflict, w.Code)
+
+	w = do(http.MethodGet, "/projects/1/teams", nil)
+	require.Equal(t, http.StatusOK, w.Code)
+	var teams []projectclient.Team
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &teams))
+	require.Len(t, teams, 1)
+	assert.Equal(t, 2, teams[0].LeadID)
+
+	w = do(http.MethodGet, "/projects/2/teams/1", nil)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+
+	w = do(http.MethodPost, "/projects/1/teams", gin.H{"name": ""})
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	w = do(http.MethodGet, "/projects/1/teams/x", nil)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	w = do(http.MethodGet, "/projects/1/members?teamId=0", nil)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
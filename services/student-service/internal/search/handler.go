@@ -0,0 +1,221 @@
+// Package search serves the global search: it runs a full-text query against
+// students, projects and messages at once and merges the results by rank.
+package search
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+
+	fulltext "grud/common/search"
+	"student-service/internal/auth"
+	"student-service/internal/projectclient"
+	"student-service/internal/student"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Result types, in the order results of equal rank are listed
+const (
+	TypeStudent = "student"
+	TypeProject = "project"
+	TypeMessage = "message"
+)
+
+// ProjectSearcher searches project-service. It is implemented by
+// *projectclient.GrpcClient.
+type ProjectSearcher interface {
+	SearchProjects(ctx context.Context, query string, limit int) ([]projectclient.ProjectSearchResult, error)
+	SearchMessages(ctx context.Context, query string, limit int, viewerEmail string, viewerID int) ([]projectclient.MessageSearchResult, error)
+}
+
+// Hit is one search result. Exactly one of Student, Project and Message is
+// set, as named by Type. Highlights maps field names to their text as HTML
+// with the matches wrapped in <mark>.
+type Hit struct {
+	Type       string                 `json:"type"`
+	Rank       float64                `json:"rank"`
+	Highlights map[string]string      `json:"highlights"`
+	Student    *student.Student       `json:"student,omitempty"`
+	Project    *projectclient.Project `json:"project,omitempty"`
+	Message    *projectclient.Message `json:"message,omitempty"`
+}
+
+// Response is the body of GET /search
+type Response struct {
+	Query   string `json:"query"`
+	Results []Hit  `json:"results"`
+	// Unavailable lists the result types that could not be searched; the
+	// response lacks their results
+	Unavailable []string `json:"unavailable,omitempty"`
+}
+
+type Handler struct {
+	students student.Service
+	projects ProjectSearcher
+	logger   *slog.Logger
+}
+
+// NewHandler creates the search handler. projects may be nil when
+// project-service is not reachable; projects and messages are then reported
+// as unavailable.
+func NewHandler(students student.Service, projects ProjectSearcher, logger *slog.Logger) *Handler {
+	return &Handler{
+		students: students,
+		projects: projects,
+		logger:   logger,
+	}
+}
+
+func (h *Handler) RegisterRoutes(router gin.IRouter) {
+	router.GET("/search", h.Search)
+}
+
+// source searches one result type
+type source struct {
+	typ    string
+	search func(ctx context.Context, query string, limit int) ([]Hit, error)
+}
+
+// Search handles GET /search?q=&limit=. Every word of q must match the start
+// of a word; at most limit results (default 20, max 100) are returned, best
+// match first. Only messages the caller may read are searched.
+func (h *Handler) Search(c *gin.Context) {
+	email, ok := auth.GetEmail(c.Request.Context())
+	if !ok || email == "" {
+		h.logger.WarnContext(c.Request.Context(), "email not found in context")
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+	studentID, _ := auth.GetStudentID(c.Request.Context())
+
+	query := strings.TrimSpace(c.Query("q"))
+	if fulltext.PrefixQuery(query) == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "q must contain a word to search for"})
+		return
+	}
+
+	limit := fulltext.DefaultLimit
+	if v := c.Query("limit"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "limit must be a positive number"})
+			return
+		}
+		limit = min(n, fulltext.MaxLimit)
+	}
+
+	sources := []source{{TypeStudent, h.searchStudents}}
+	if h.projects != nil {
+		searchMessages := func(ctx context.Context, query string, limit int) ([]Hit, error) {
+			return h.searchMessages(ctx, query, limit, email, studentID)
+		}
+		sources = append(sources, source{TypeProject, h.searchProjects}, source{TypeMessage, searchMessages})
+	}
+
+	// Search all sources at once; each fills its own slot so the merge is deterministic
+	ctx := c.Request.Context()
+	found := make([][]Hit, len(sources))
+	errs := make([]error, len(sources))
+	var wg sync.WaitGroup
+	for i, src := range sources {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			found[i], errs[i] = src.search(ctx, query, limit)
+		}()
+	}
+	wg.Wait()
+
+	resp := Response{Query: query, Results: []Hit{}}
+	if h.projects == nil {
+		resp.Unavailable = []string{TypeProject, TypeMessage}
+	}
+	failed := 0
+	for i, src := range sources {
+		if errs[i] != nil {
+			h.logger.ErrorContext(ctx, "search failed", "type", src.typ, "error", errs[i])
+			resp.Unavailable = append(resp.Unavailable, src.typ)
+			failed++
+			continue
+		}
+		resp.Results = append(resp.Results, found[i]...)
+	}
+	if failed == len(sources) {
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": "Search is unavailable"})
+		return
+	}
+
+	// Ranks are normalized to [0, 1) by every source, so they compare across types
+	slices.SortStableFunc(resp.Results, func(a, b Hit) int {
+		switch {
+		case a.Rank > b.Rank:
+			return -1
+		case a.Rank < b.Rank:
+			return 1
+		}
+		return 0
+	})
+	resp.Results = resp.Results[:min(len(resp.Results), limit)]
+
+	c.JSON(http.StatusOK, resp)
+}
+
+func (h *Handler) searchStudents(ctx context.Context, query string, limit int) ([]Hit, error) {
+	results, err := h.students.SearchStudents(ctx, query, limit)
+	if err != nil {
+		return nil, err
+	}
+	hits := make([]Hit, len(results))
+	for i := range results {
+		hits[i] = Hit{
+			Type: TypeStudent,
+			Rank: results[i].Rank,
+			Highlights: map[string]string{
+				"name":  results[i].NameHighlight,
+				"email": results[i].EmailHighlight,
+				"major": results[i].MajorHighlight,
+			},
+			Student: &results[i].Student,
+		}
+	}
+	return hits, nil
+}
+
+func (h *Handler) searchProjects(ctx context.Context, query string, limit int) ([]Hit, error) {
+	results, err := h.projects.SearchProjects(ctx, query, limit)
+	if err != nil {
+		return nil, err
+	}
+	hits := make([]Hit, len(results))
+	for i := range results {
+		hits[i] = Hit{
+			Type:       TypeProject,
+			Rank:       results[i].Rank,
+			Highlights: results[i].Highlights,
+			Project:    &results[i].Project,
+		}
+	}
+	return hits, nil
+}
+
+func (h *Handler) searchMessages(ctx context.Context, query string, limit int, viewerEmail string, viewerID int) ([]Hit, error) {
+	results, err := h.projects.SearchMessages(ctx, query, limit, viewerEmail, viewerID)
+	if err != nil {
+		return nil, err
+	}
+	hits := make([]Hit, len(results))
+	for i := range results {
+		hits[i] = Hit{
+			Type:       TypeMessage,
+			Rank:       results[i].Rank,
+			Highlights: results[i].Highlights,
+			Message:    &results[i].Message,
+		}
+	}
+	return hits, nil
+}
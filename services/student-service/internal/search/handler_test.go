@@ -0,0 +1,191 @@
+package search_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"grud/common/history"
+	commonmetrics "grud/common/metrics"
+	"grud/testing/testdb"
+	"student-service/internal/auth"
+	"student-service/internal/db"
+	"student-service/internal/projectclient"
+	"student-service/internal/search"
+	"student-service/internal/student"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeProjects answers SearchProjects and SearchMessages with canned results.
+// Only viewer may read the messages.
+type fakeProjects struct {
+	projects []projectclient.ProjectSearchResult
+	messages []projectclient.MessageSearchResult
+	viewer   string
+	err      error
+}
+
+func (f *fakeProjects) SearchProjects(ctx context.Context, query string, limit int) ([]projectclient.ProjectSearchResult, error) {
+	return f.projects, f.err
+}
+
+func (f *fakeProjects) SearchMessages(ctx context.Context, query string, limit int, viewerEmail string, viewerID int) ([]projectclient.MessageSearchResult, error) {
+	if viewerEmail != f.viewer {
+		return nil, f.err
+	}
+	return f.messages, f.err
+}
+
+func TestSearch_Shared(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	pgContainer := testdb.SetupSharedPostgres(t)
+	defer pgContainer.Cleanup(t)
+
+	// The service migrations also add the generated search column
+	err := db.RunMigrations(context.Background(), pgContainer.DB,
+		(*student.Student)(nil), (*auth.RefreshToken)(nil), (*auth.PasswordSetupToken)(nil), (*history.Entry)(nil))
+	require.NoError(t, err)
+
+	repo := student.NewRepository(pgContainer.DB, commonmetrics.NewMock())
+	logger := slog.New(slog.NewTextHandler(os.Stderr, nil))
+	students := student.NewService(repo, nil, nil, logger)
+
+	projects := &fakeProjects{
+		projects: []projectclient.ProjectSearchResult{{
+			Project:    projectclient.Project{ID: 7, Name: "Ada's compiler"},
+			Rank:       0.5,
+			Highlights: map[string]string{"name": "<mark>Ada</mark>'s compiler", "description": ""},
+		}},
+		messages: []projectclient.MessageSearchResult{{
+			Message:    projectclient.Message{ID: 3, Email: "x@example.com", Message: "ask ada"},
+			Rank:       0.01,
+			Highlights: map[string]string{"message": "ask <mark>ada</mark>"},
+		}},
+		viewer: "ada@example.com",
+	}
+
+	newRouter := func(projects search.ProjectSearcher) *gin.Engine {
+		router := gin.New()
+		search.NewHandler(students, projects, logger).RegisterRoutes(router)
+		return router
+	}
+
+	// getAs searches as the student with the email; ada is student 1
+	getAs := func(email string, router *gin.Engine, query string) (*httptest.ResponseRecorder, search.Response) {
+		req := httptest.NewRequest(http.MethodGet, "/search"+query, nil)
+		if email != "" {
+			ctx := context.WithValue(req.Context(), auth.EmailKey, email)
+			req = req.WithContext(context.WithValue(ctx, auth.StudentIDKey, 1))
+		}
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		var resp search.Response
+		if w.Code == http.StatusOK {
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+		}
+		return w, resp
+	}
+	get := func(router *gin.Engine, query string) (*httptest.ResponseRecorder, search.Response) {
+		return getAs("ada@example.com", router, query)
+	}
+
+	testdb.CleanupTables(t, pgContainer.DB, "students")
+	for _, s := range []*student.Student{
+		{FirstName: "Ada", LastName: "Lovelace", Email: "ada@example.com", Password: "hash", Major: "Mathematics", Year: 2},
+		{FirstName: "Grace", LastName: "Hopper", Email: "grace@navy.mil", Password: "hash", Major: "Mathematics", Year: 3},
+		{FirstName: "Alan", LastName: "Turing", Email: "alan@example.com", Password: "hash", Major: "Computer Science", Year: 1},
+	} {
+		_, err := pgContainer.DB.NewInsert().Model(s).Exec(context.Background())
+		require.NoError(t, err)
+	}
+
+	t.Run("MergesByRank", func(t *testing.T) {
+		w, resp := get(newRouter(projects), "?q=ada")
+		require.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "ada", resp.Query)
+		assert.Empty(t, resp.Unavailable)
+		require.Len(t, resp.Results, 3)
+
+		types := []string{resp.Results[0].Type, resp.Results[1].Type, resp.Results[2].Type}
+		assert.Contains(t, types, search.TypeStudent)
+		assert.Equal(t, search.TypeMessage, resp.Results[2].Type)
+		for i := 1; i < len(resp.Results); i++ {
+			assert.GreaterOrEqual(t, resp.Results[i-1].Rank, resp.Results[i].Rank)
+		}
+
+		for _, hit := range resp.Results {
+			if hit.Type == search.TypeStudent {
+				require.NotNil(t, hit.Student)
+				assert.Equal(t, "Ada", hit.Student.FirstName)
+				assert.Equal(t, "<mark>Ada</mark> Lovelace", hit.Highlights["name"])
+				assert.Equal(t, "<mark>ada@example.com</mark>", hit.Highlights["email"])
+				assert.Equal(t, "Mathematics", hit.Highlights["major"])
+			}
+		}
+		assert.NotContains(t, w.Body.String(), "hash")
+	})
+
+	t.Run("StudentFields", func(t *testing.T) {
+		router := newRouter(&fakeProjects{})
+
+		_, resp := get(router, "?q=math")
+		assert.Len(t, resp.Results, 2)
+
+		// Domains are searchable, and every word has to match
+		_, resp = get(router, "?q=navy")
+		require.Len(t, resp.Results, 1)
+		assert.Equal(t, "Grace", resp.Results[0].Student.FirstName)
+
+		_, resp = get(router, "?q=math+ada")
+		require.Len(t, resp.Results, 1)
+		assert.Equal(t, "Ada", resp.Results[0].Student.FirstName)
+
+		_, resp = get(router, "?q=turing&limit=1")
+		assert.Len(t, resp.Results, 1)
+
+		_, resp = get(router, "?q=nobody")
+		assert.Empty(t, resp.Results)
+	})
+
+	t.Run("ProjectServiceDown", func(t *testing.T) {
+		w, resp := get(newRouter(&fakeProjects{err: errors.New("connection refused")}), "?q=ada")
+		require.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, []string{search.TypeProject, search.TypeMessage}, resp.Unavailable)
+		require.Len(t, resp.Results, 1)
+		assert.Equal(t, search.TypeStudent, resp.Results[0].Type)
+
+		w, resp = get(newRouter(nil), "?q=ada")
+		require.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, []string{search.TypeProject, search.TypeMessage}, resp.Unavailable)
+		assert.Len(t, resp.Results, 1)
+	})
+
+	t.Run("OnlyReadableMessages", func(t *testing.T) {
+		w, resp := getAs("alan@example.com", newRouter(projects), "?q=ada")
+		require.Equal(t, http.StatusOK, w.Code)
+		for _, hit := range resp.Results {
+			assert.NotEqual(t, search.TypeMessage, hit.Type)
+		}
+		assert.NotContains(t, w.Body.String(), "ask ada")
+
+		w, _ = getAs("", newRouter(projects), "?q=ada")
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+	})
+
+	t.Run("BadRequest", func(t *testing.T) {
+		router := newRouter(projects)
+		for _, query := range []string{"", "?q=", "?q=%26%7C!", "?q=ada&limit=0", "?q=ada&limit=x"} {
+			w, _ := get(router, query)
+			assert.Equal(t, http.StatusBadRequest, w.Code, query)
+		}
+	})
+}
@@ -0,0 +1,234 @@
+package stream
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	"student-service/internal/auth"
+
+	"github.com/gin-gonic/gin"
+	"golang.org/x/net/websocket"
+)
+
+// writeWait bounds a single write to a connection
+const writeWait = 10 * time.Second
+
+var errOriginNotAllowed = errors.New("origin not allowed")
+
+type Handler struct {
+	hub     *Hub
+	origins []string
+	logger  *slog.Logger
+}
+
+// NewHandler creates the stream handler. WebSocket upgrades are accepted from
+// the service's own host and the allowed origins, from anywhere when there
+// are none.
+func NewHandler(hub *Hub, allowedOrigins []string, logger *slog.Logger) *Handler {
+	return &Handler{
+		hub:     hub,
+		origins: allowedOrigins,
+		logger:  logger,
+	}
+}
+
+func (h *Handler) RegisterRoutes(router gin.IRouter) {
+	router.GET("/stream", h.Stream)
+}
+
+// Stream pushes the current student's events, over a WebSocket when the
+// request asks for an upgrade and as server-sent events otherwise. A client
+// resumes with the Last-Event-ID header or the lastEventId query parameter.
+func (h *Handler) Stream(c *gin.Context) {
+	studentID, ok := auth.GetStudentID(c.Request.Context())
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	lastEventID := c.GetHeader("Last-Event-ID")
+	if lastEventID == "" {
+		lastEventID = c.Query("lastEventId")
+	}
+
+	if strings.EqualFold(c.GetHeader("Upgrade"), "websocket") {
+		h.serveWebSocket(c, studentID, lastEventID)
+		return
+	}
+	h.serveSSE(c, studentID, lastEventID)
+}
+
+func (h *Handler) serveSSE(c *gin.Context, studentID int, lastEventID string) {
+	client, err := h.hub.Subscribe(studentID, lastEventID)
+	if err != nil {
+		h.logger.ErrorContext(c.Request.Context(), "failed to open stream", "student_id", studentID, "error", err)
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": "Stream not available"})
+		return
+	}
+	defer client.Close()
+
+	w := c.Writer
+	rc := http.NewResponseController(w)
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+
+	// The server's write timeout would cut the stream, so every write gets
+	// its own deadline instead
+	send := func(frame []byte) error {
+		rc.SetWriteDeadline(time.Now().Add(writeWait))
+		if _, err := w.Write(frame); err != nil {
+			return err
+		}
+		return rc.Flush()
+	}
+
+	if err := send([]byte(": connected\n\n")); err != nil {
+		return
+	}
+	for _, e := range client.Replay() {
+		if err := send(sseFrame(e)); err != nil {
+			return
+		}
+	}
+
+	heartbeat := time.NewTicker(h.hub.Heartbeat())
+	defer heartbeat.Stop()
+
+	for {
+		var err error
+		select {
+		case <-c.Request.Context().Done():
+			return
+		case <-client.Done():
+			h.logger.InfoContext(c.Request.Context(), "stream closed", "student_id", studentID, "reason", client.Err())
+			return
+		case e := <-client.Events():
+			err = send(sseFrame(e))
+		case <-heartbeat.C:
+			err = send([]byte(": heartbeat\n\n"))
+		}
+		if err != nil {
+			return
+		}
+	}
+}
+
+// sseFrame formats an event for an EventSource; its type is the event name
+func sseFrame(e Event) []byte {
+	var b bytes.Buffer
+	if e.ID != "" {
+		fmt.Fprintf(&b, "id: %s\n", e.ID)
+	}
+	fmt.Fprintf(&b, "event: %s\n", e.Type)
+	data := e.Data
+	if len(data) == 0 {
+		data = []byte("{}")
+	}
+	for _, line := range bytes.Split(data, []byte("\n")) {
+		fmt.Fprintf(&b, "data: %s\n", line)
+	}
+	b.WriteString("\n")
+	return b.Bytes()
+}
+
+func (h *Handler) serveWebSocket(c *gin.Context, studentID int, lastEventID string) {
+	server := websocket.Server{
+		Handshake: h.handshake,
+		Handler: func(ws *websocket.Conn) {
+			h.pushWebSocket(c, ws, studentID, lastEventID)
+		},
+	}
+	server.ServeHTTP(c.Writer, c.Request)
+}
+
+// handshake rejects cross-site upgrades, which browsers send with the
+// student's cookie
+func (h *Handler) handshake(config *websocket.Config, req *http.Request) error {
+	origin, err := websocket.Origin(config, req)
+	if err != nil {
+		return err
+	}
+	if origin != nil && !h.allowOrigin(origin, req) {
+		return errOriginNotAllowed
+	}
+	config.Origin = origin
+	return nil
+}
+
+func (h *Handler) allowOrigin(origin *url.URL, req *http.Request) bool {
+	if len(h.origins) == 0 || strings.EqualFold(origin.Host, req.Host) {
+		return true
+	}
+	return slices.Contains(h.origins, origin.Scheme+"://"+origin.Host)
+}
+
+func (h *Handler) pushWebSocket(c *gin.Context, ws *websocket.Conn, studentID int, lastEventID string) {
+	defer ws.Close()
+	ctx := c.Request.Context()
+
+	client, err := h.hub.Subscribe(studentID, lastEventID)
+	if err != nil {
+		h.logger.ErrorContext(ctx, "failed to open stream", "student_id", studentID, "error", err)
+		return
+	}
+	defer client.Close()
+
+	// Clients only ever close, so reading just watches for that
+	ws.SetReadDeadline(time.Time{})
+	closed := make(chan struct{})
+	go func() {
+		defer close(closed)
+		var discard []byte
+		for {
+			if err := websocket.Message.Receive(ws, &discard); err != nil {
+				if !errors.Is(err, io.EOF) {
+					h.logger.DebugContext(ctx, "stream connection read failed", "student_id", studentID, "error", err)
+				}
+				return
+			}
+		}
+	}()
+
+	send := func(e Event) error {
+		ws.SetWriteDeadline(time.Now().Add(writeWait))
+		return websocket.JSON.Send(ws, e)
+	}
+
+	for _, e := range client.Replay() {
+		if err := send(e); err != nil {
+			return
+		}
+	}
+
+	heartbeat := time.NewTicker(h.hub.Heartbeat())
+	defer heartbeat.Stop()
+
+	for {
+		var err error
+		select {
+		case <-closed:
+			return
+		case <-client.Done():
+			h.logger.InfoContext(ctx, "stream closed", "student_id", studentID, "reason", client.Err())
+			return
+		case e := <-client.Events():
+			err = send(e)
+		case <-heartbeat.C:
+			err = send(Event{Type: TypeHeartbeat})
+		}
+		if err != nil {
+			return
+		}
+	}
+}
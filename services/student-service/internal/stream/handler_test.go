@@ -0,0 +1,317 @@
+package stream_test
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"student-service/internal/auth"
+	"student-service/internal/stream"
+	"student-service/internal/student"
+
+	"github.com/gin-gonic/gin"
+	"github.com/nats-io/nats.go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/websocket"
+)
+
+// fakeBus delivers published messages to the handlers subscribed to the
+// exact subject, queue groups included
+type fakeBus struct {
+	mu       sync.Mutex
+	handlers map[string][]nats.MsgHandler
+}
+
+func newFakeBus() *fakeBus {
+	return &fakeBus{handlers: make(map[string][]nats.MsgHandler)}
+}
+
+func (b *fakeBus) Subscribe(subject string, cb nats.MsgHandler) (*nats.Subscription, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.handlers[subject] = append(b.handlers[subject], cb)
+	return &nats.Subscription{Subject: subject}, nil
+}
+
+func (b *fakeBus) QueueSubscribe(subject, queue string, cb nats.MsgHandler) (*nats.Subscription, error) {
+	return b.Subscribe(subject, cb)
+}
+
+func (b *fakeBus) PublishMsg(msg *nats.Msg) error {
+	b.mu.Lock()
+	handlers := append([]nats.MsgHandler(nil), b.handlers[msg.Subject]...)
+	b.mu.Unlock()
+	for _, cb := range handlers {
+		cb(msg)
+	}
+	return nil
+}
+
+func (b *fakeBus) publish(t *testing.T, subject string, value interface{}) {
+	data, err := json.Marshal(value)
+	require.NoError(t, err)
+	require.NoError(t, b.PublishMsg(&nats.Msg{Subject: subject, Data: data}))
+}
+
+// fakeStudents knows alice as student 4 and bob as student 7
+type fakeStudents struct{}
+
+func (fakeStudents) GetByEmail(ctx context.Context, email string) (*student.Student, error) {
+	switch email {
+	case "alice@example.com":
+		return &student.Student{ID: 4, Email: email}, nil
+	case "bob@example.com":
+		return &student.Student{ID: 7, Email: email}, nil
+	}
+	return nil, student.ErrStudentNotFound
+}
+
+var routes = []stream.Route{
+	{Subject: "message.created", Type: "message"},
+	{Subject: "message.read", Type: "read"},
+	{Subject: "project.changed", Type: "project"},
+}
+
+func newStreamServer(t *testing.T, opts stream.Options, origins []string) (*fakeBus, *stream.Hub, *httptest.Server) {
+	gin.SetMode(gin.TestMode)
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+	bus := newFakeBus()
+	router := stream.NewRouter(bus, routes, fakeStudents{}, "", logger)
+	require.NoError(t, router.Start())
+	hub := stream.NewHub(bus, opts, logger)
+
+	// The X-Student header stands in for the student AuthMiddleware takes from the token
+	engine := gin.New()
+	engine.Use(func(c *gin.Context) {
+		if id, err := strconv.Atoi(c.GetHeader("X-Student")); err == nil {
+			c.Request = c.Request.WithContext(context.WithValue(c.Request.Context(), auth.StudentIDKey, id))
+		}
+	})
+	stream.NewHandler(hub, origins, logger).RegisterRoutes(engine)
+
+	server := httptest.NewServer(engine)
+	t.Cleanup(func() {
+		hub.Close()
+		server.Close()
+	})
+	return bus, hub, server
+}
+
+// sseReader reads the frames of an event stream
+type sseReader struct {
+	t    *testing.T
+	body io.ReadCloser
+	r    *bufio.Reader
+}
+
+func openSSE(t *testing.T, server *httptest.Server, studentID int, lastEventID string) *sseReader {
+	req, err := http.NewRequest(http.MethodGet, server.URL+"/stream", nil)
+	require.NoError(t, err)
+	req.Header.Set("X-Student", strconv.Itoa(studentID))
+	if lastEventID != "" {
+		req.Header.Set("Last-Event-ID", lastEventID)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "text/event-stream", resp.Header.Get("Content-Type"))
+
+	s := &sseReader{t: t, body: resp.Body, r: bufio.NewReader(resp.Body)}
+	t.Cleanup(func() { s.body.Close() })
+	// The connected comment is written once the stream is subscribed
+	assert.Equal(t, []string{": connected"}, s.frame())
+	return s
+}
+
+// frame returns the lines of the next frame
+func (s *sseReader) frame() []string {
+	var lines []string
+	for {
+		line, err := s.r.ReadString('\n')
+		require.NoError(s.t, err)
+		line = strings.TrimSuffix(line, "\n")
+		if line == "" {
+			return lines
+		}
+		lines = append(lines, line)
+	}
+}
+
+// event returns the next event frame as id, type and data
+func (s *sseReader) event() (string, string, string) {
+	for {
+		lines := s.frame()
+		if len(lines) == 1 && strings.HasPrefix(lines[0], ":") {
+			continue
+		}
+		var id, typ, data string
+		for _, line := range lines {
+			name, value, _ := strings.Cut(line, ": ")
+			switch name {
+			case "id":
+				id = value
+			case "event":
+				typ = value
+			case "data":
+				data = value
+			}
+		}
+		return id, typ, data
+	}
+}
+
+func TestStream(t *testing.T) {
+	t.Run("ServerSentEvents", func(t *testing.T) {
+		bus, _, server := newStreamServer(t, stream.Options{Heartbeat: 50 * time.Millisecond}, nil)
+		alice := openSSE(t, server, 4, "")
+
+		// Addressed to alice both by ID and email, delivered once
+		bus.publish(t, "message.created", gin.H{
+			"message":    gin.H{"id": 1, "message": "Hi"},
+			"recipients": []string{"bob@example.com", "alice@example.com"},
+			"studentIds": []int{4},
+		})
+		id, typ, data := alice.event()
+		assert.NotEmpty(t, id)
+		assert.Equal(t, "message", typ)
+		assert.JSONEq(t, `{"id":1,"message":"Hi"}`, data)
+
+		bus.publish(t, "project.changed", gin.H{"type": "updated", "projectId": 2, "studentIds": []int{9}})
+		bus.publish(t, "message.read", gin.H{"email": "alice@example.com", "messageId": 1})
+		_, typ, data = alice.event()
+		assert.Equal(t, "read", typ)
+		assert.JSONEq(t, `{"email":"alice@example.com","messageId":1}`, data)
+
+		// Idle streams get heartbeats
+		assert.Equal(t, []string{": heartbeat"}, alice.frame())
+	})
+
+	t.Run("Resume", func(t *testing.T) {
+		bus, _, server := newStreamServer(t, stream.Options{ResumeWindow: time.Minute}, nil)
+		first := openSSE(t, server, 7, "")
+		bus.publish(t, "project.changed", gin.H{"projectId": 1, "studentIds": []int{7}})
+		lastID, _, _ := first.event()
+		first.body.Close()
+
+		// What arrives while disconnected is replayed on reconnect
+		bus.publish(t, "project.changed", gin.H{"projectId": 2, "studentIds": []int{7}})
+		resumed := openSSE(t, server, 7, lastID)
+		_, typ, data := resumed.event()
+		assert.Equal(t, "project", typ)
+		assert.JSONEq(t, `{"projectId":2,"studentIds":[7]}`, data)
+
+		// An unknown event ID asks the client to start over
+		reset := openSSE(t, server, 7, "unknown-1")
+		id, typ, _ := reset.event()
+		assert.Empty(t, id)
+		assert.Equal(t, stream.TypeReset, typ)
+	})
+
+	t.Run("ResumeWindowExpired", func(t *testing.T) {
+		bus, hub, _ := newStreamServer(t, stream.Options{ResumeWindow: 20 * time.Millisecond}, nil)
+		client, err := hub.Subscribe(4, "")
+		require.NoError(t, err)
+		bus.publish(t, "message.read", gin.H{"email": "alice@example.com"})
+		e := <-client.Events()
+		client.Close()
+		assert.ErrorIs(t, client.Err(), stream.ErrClientClosed)
+
+		require.Eventually(t, func() bool {
+			client, err := hub.Subscribe(4, e.ID)
+			require.NoError(t, err)
+			defer client.Close()
+			replay := client.Replay()
+			return len(replay) == 1 && replay[0].Type == stream.TypeReset
+		}, time.Second, 30*time.Millisecond)
+	})
+
+	t.Run("SlowClient", func(t *testing.T) {
+		bus, hub, _ := newStreamServer(t, stream.Options{BufferSize: 2}, nil)
+		client, err := hub.Subscribe(4, "")
+		require.NoError(t, err)
+		for i := 0; i < 3; i++ {
+			bus.publish(t, "project.changed", gin.H{"projectId": i, "studentIds": []int{4}})
+		}
+		select {
+		case <-client.Done():
+			assert.ErrorIs(t, client.Err(), stream.ErrClientTooSlow)
+		case <-time.After(time.Second):
+			t.Fatal("slow client was not dropped")
+		}
+	})
+
+	t.Run("WebSocket", func(t *testing.T) {
+		bus, _, server := newStreamServer(t, stream.Options{Heartbeat: 50 * time.Millisecond}, []string{"http://localhost:5173"})
+		url := "ws" + strings.TrimPrefix(server.URL, "http") + "/stream"
+
+		config, err := websocket.NewConfig(url, "http://localhost:5173")
+		require.NoError(t, err)
+		config.Header.Set("X-Student", "7")
+		ws, err := websocket.DialConfig(config)
+		require.NoError(t, err)
+		defer ws.Close()
+
+		// The first heartbeat proves the connection is subscribed
+		var e stream.Event
+		require.NoError(t, websocket.JSON.Receive(ws, &e))
+		assert.Equal(t, stream.TypeHeartbeat, e.Type)
+
+		bus.publish(t, "message.created", gin.H{"message": gin.H{"id": 3}, "recipients": []string{"bob@example.com"}})
+		for e.Type == stream.TypeHeartbeat {
+			require.NoError(t, websocket.JSON.Receive(ws, &e))
+		}
+		assert.NotEmpty(t, e.ID)
+		assert.Equal(t, "message", e.Type)
+		assert.JSONEq(t, `{"id":3}`, string(e.Data))
+
+		// Browsers on other sites would send the student's cookie along
+		config, err = websocket.NewConfig(url, "http://evil.example")
+		require.NoError(t, err)
+		config.Header.Set("X-Student", "7")
+		_, err = websocket.DialConfig(config)
+		assert.Error(t, err)
+	})
+
+	t.Run("Unauthorized", func(t *testing.T) {
+		_, _, server := newStreamServer(t, stream.Options{}, nil)
+		resp, err := http.Get(server.URL + "/stream")
+		require.NoError(t, err)
+		resp.Body.Close()
+		assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+	})
+
+	t.Run("Push", func(t *testing.T) {
+		bus, _, server := newStreamServer(t, stream.Options{}, nil)
+		alice := openSSE(t, server, 4, "")
+
+		pusher := stream.NewPusher(bus, "")
+		require.NoError(t, pusher.Push(context.Background(), 4, "notification", gin.H{"id": 3, "title": "Hi"}))
+		_, typ, data := alice.event()
+		assert.Equal(t, "notification", typ)
+		assert.JSONEq(t, `{"id":3,"title":"Hi"}`, data)
+	})
+
+	t.Run("Close", func(t *testing.T) {
+		_, hub, server := newStreamServer(t, stream.Options{}, nil)
+		alice := openSSE(t, server, 4, "")
+
+		// Shutting down ends open streams
+		hub.Close()
+		_, err := io.ReadAll(alice.r)
+		assert.NoError(t, err)
+
+		_, err = hub.Subscribe(4, "")
+		assert.ErrorIs(t, err, stream.ErrHubClosed)
+	})
+}
@@ -0,0 +1,315 @@
+package stream
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/nats-io/nats.go"
+)
+
+// Event types pushed besides the ones routed from NATS
+const (
+	// TypeReset tells a resuming client that events were lost and it should
+	// refetch what it shows
+	TypeReset = "reset"
+	// TypeHeartbeat keeps idle connections open through proxies
+	TypeHeartbeat = "heartbeat"
+)
+
+const (
+	DefaultSubjectPrefix = "stream.student"
+	DefaultBufferSize    = 64
+	DefaultReplaySize    = 100
+	DefaultHeartbeat     = 25 * time.Second
+	DefaultResumeWindow  = 2 * time.Minute
+)
+
+var (
+	ErrHubClosed     = errors.New("stream is shutting down")
+	ErrClientTooSlow = errors.New("stream client fell too far behind")
+	ErrClientClosed  = errors.New("stream client closed")
+)
+
+// Event is pushed to a student's connections. ID is what a reconnecting
+// client sends back as its last event ID to resume.
+type Event struct {
+	ID   string          `json:"id,omitempty"`
+	Type string          `json:"type"`
+	Data json.RawMessage `json:"data,omitempty"`
+}
+
+// Conn is the part of a NATS connection the stream uses. It is implemented
+// by *nats.Conn.
+type Conn interface {
+	Subscribe(subject string, cb nats.MsgHandler) (*nats.Subscription, error)
+	QueueSubscribe(subject, queue string, cb nats.MsgHandler) (*nats.Subscription, error)
+	PublishMsg(msg *nats.Msg) error
+}
+
+// Options tune the hub; zero values take the defaults
+type Options struct {
+	SubjectPrefix string
+	// BufferSize is how many events a connection may fall behind before it
+	// is dropped
+	BufferSize int
+	// ReplaySize is how many recent events of a student are kept to resume from
+	ReplaySize int
+	Heartbeat  time.Duration
+	// ResumeWindow is how long a student's events keep being recorded after
+	// their last connection closed, so a reconnect misses nothing
+	ResumeWindow time.Duration
+}
+
+func (o Options) withDefaults() Options {
+	if o.SubjectPrefix == "" {
+		o.SubjectPrefix = DefaultSubjectPrefix
+	}
+	if o.BufferSize <= 0 {
+		o.BufferSize = DefaultBufferSize
+	}
+	if o.ReplaySize <= 0 {
+		o.ReplaySize = DefaultReplaySize
+	}
+	if o.Heartbeat <= 0 {
+		o.Heartbeat = DefaultHeartbeat
+	}
+	if o.ResumeWindow <= 0 {
+		o.ResumeWindow = DefaultResumeWindow
+	}
+	return o
+}
+
+// StudentSubject is the NATS subject a student's events are published on
+func StudentSubject(prefix string, studentID int) string {
+	return fmt.Sprintf("%s.%d", prefix, studentID)
+}
+
+// Hub fans the events of each connected student's NATS subject out to their
+// connections on this replica
+type Hub struct {
+	conn   Conn
+	opts   Options
+	logger *slog.Logger
+
+	mu       sync.Mutex
+	students map[int]*feed
+	closed   bool
+}
+
+// feed is a student's subscription with the events it delivered recently
+type feed struct {
+	sub     *nats.Subscription
+	epoch   string
+	seq     int
+	history []Event
+	clients map[*Client]struct{}
+	expiry  *time.Timer
+}
+
+func NewHub(conn Conn, opts Options, logger *slog.Logger) *Hub {
+	return &Hub{
+		conn:     conn,
+		opts:     opts.withDefaults(),
+		logger:   logger,
+		students: make(map[int]*feed),
+	}
+}
+
+// Heartbeat is how often idle connections are sent a heartbeat
+func (h *Hub) Heartbeat() time.Duration {
+	return h.opts.Heartbeat
+}
+
+// Subscribe connects a client to a student's events. With a lastEventID the
+// client first replays the events after it, or a reset event when they are
+// no longer known.
+func (h *Hub) Subscribe(studentID int, lastEventID string) (*Client, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.closed {
+		return nil, ErrHubClosed
+	}
+
+	f := h.students[studentID]
+	if f == nil {
+		f = &feed{
+			epoch:   strconv.FormatInt(time.Now().UnixNano(), 36),
+			clients: make(map[*Client]struct{}),
+		}
+		sub, err := h.conn.Subscribe(StudentSubject(h.opts.SubjectPrefix, studentID), func(msg *nats.Msg) {
+			h.deliver(studentID, f, msg.Data)
+		})
+		if err != nil {
+			return nil, err
+		}
+		f.sub = sub
+		h.students[studentID] = f
+	}
+	if f.expiry != nil {
+		f.expiry.Stop()
+		f.expiry = nil
+	}
+
+	client := &Client{
+		hub:       h,
+		studentID: studentID,
+		events:    make(chan Event, h.opts.BufferSize),
+		done:      make(chan struct{}),
+	}
+	if lastEventID != "" {
+		client.replay = f.since(lastEventID)
+	}
+	f.clients[client] = struct{}{}
+	return client, nil
+}
+
+// Close disconnects every client and stops listening to NATS
+func (h *Hub) Close() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.closed = true
+	for studentID, f := range h.students {
+		for client := range f.clients {
+			client.terminate(ErrHubClosed)
+		}
+		h.drop(studentID, f)
+	}
+}
+
+func (h *Hub) deliver(studentID int, f *feed, data []byte) {
+	var payload struct {
+		Type string          `json:"type"`
+		Data json.RawMessage `json:"data"`
+	}
+	if err := json.Unmarshal(data, &payload); err != nil || payload.Type == "" {
+		h.logger.Warn("dropping malformed stream event", "student_id", studentID, "error", err)
+		return
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	// A feed that expired can still receive what NATS had in flight
+	if h.students[studentID] != f {
+		return
+	}
+
+	f.seq++
+	e := Event{ID: f.epoch + "-" + strconv.Itoa(f.seq), Type: payload.Type, Data: payload.Data}
+	if len(f.history) < h.opts.ReplaySize {
+		f.history = append(f.history, e)
+	} else {
+		copy(f.history, f.history[1:])
+		f.history[len(f.history)-1] = e
+	}
+
+	for client := range f.clients {
+		select {
+		case client.events <- e:
+		default:
+			h.logger.Warn("dropping slow stream client", "student_id", studentID)
+			delete(f.clients, client)
+			client.terminate(ErrClientTooSlow)
+		}
+	}
+	h.linger(studentID, f)
+}
+
+// release removes a client that closed
+func (h *Hub) release(client *Client) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	f := h.students[client.studentID]
+	if f == nil {
+		return
+	}
+	delete(f.clients, client)
+	h.linger(client.studentID, f)
+}
+
+// linger keeps an unwatched feed for the resume window, then drops it
+func (h *Hub) linger(studentID int, f *feed) {
+	if len(f.clients) > 0 || f.expiry != nil {
+		return
+	}
+	f.expiry = time.AfterFunc(h.opts.ResumeWindow, func() {
+		h.mu.Lock()
+		defer h.mu.Unlock()
+		if h.students[studentID] == f && len(f.clients) == 0 {
+			h.drop(studentID, f)
+		}
+	})
+}
+
+func (h *Hub) drop(studentID int, f *feed) {
+	if f.expiry != nil {
+		f.expiry.Stop()
+	}
+	if err := f.sub.Unsubscribe(); err != nil {
+		h.logger.Debug("failed to unsubscribe from student stream", "student_id", studentID, "error", err)
+	}
+	delete(h.students, studentID)
+}
+
+// since returns the events after lastEventID, or a reset event when it is
+// no longer in the history
+func (f *feed) since(lastEventID string) []Event {
+	for i, e := range f.history {
+		if e.ID == lastEventID {
+			return append([]Event(nil), f.history[i+1:]...)
+		}
+	}
+	return []Event{{Type: TypeReset}}
+}
+
+// Client is one connection's view of a student's events
+type Client struct {
+	hub       *Hub
+	studentID int
+	replay    []Event
+	events    chan Event
+	done      chan struct{}
+	once      sync.Once
+	err       error
+}
+
+// Replay returns the events to send before the live ones
+func (c *Client) Replay() []Event {
+	return c.replay
+}
+
+// Events delivers the student's events as they arrive
+func (c *Client) Events() <-chan Event {
+	return c.events
+}
+
+// Done is closed when the client was disconnected; Err says why
+func (c *Client) Done() <-chan struct{} {
+	return c.done
+}
+
+func (c *Client) Err() error {
+	<-c.done
+	return c.err
+}
+
+// Close disconnects the client
+func (c *Client) Close() {
+	c.hub.release(c)
+	c.terminate(ErrClientClosed)
+}
+
+func (c *Client) terminate(err error) {
+	c.once.Do(func() {
+		c.err = err
+		close(c.done)
+	})
+}
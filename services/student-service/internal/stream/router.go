@@ -0,0 +1,166 @@
+package stream
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"slices"
+
+	"student-service/internal/student"
+
+	"github.com/nats-io/nats.go"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+)
+
+// QueueGroup makes a single replica route each event
+const QueueGroup = "student-service-stream"
+
+// Route relays the events published on a NATS subject to the students they
+// concern, as events of the given type
+type Route struct {
+	Subject string
+	Type    string
+}
+
+// Students looks students up by email. It is implemented by
+// student.Repository.
+type Students interface {
+	GetByEmail(ctx context.Context, email string) (*student.Student, error)
+}
+
+// Router addresses the events other services publish to the per-student
+// subjects the hubs listen on. An event names its students by ID
+// (studentIds), by email (email, recipients) or both; one carrying a message,
+// as message.created does, is pushed as that message.
+type Router struct {
+	conn     Conn
+	routes   []Route
+	students Students
+	prefix   string
+	logger   *slog.Logger
+	subs     []*nats.Subscription
+}
+
+func NewRouter(conn Conn, routes []Route, students Students, prefix string, logger *slog.Logger) *Router {
+	if prefix == "" {
+		prefix = DefaultSubjectPrefix
+	}
+	return &Router{
+		conn:     conn,
+		routes:   routes,
+		students: students,
+		prefix:   prefix,
+		logger:   logger,
+	}
+}
+
+// Start subscribes to the routes' subjects
+func (r *Router) Start() error {
+	for _, route := range r.routes {
+		sub, err := r.conn.QueueSubscribe(route.Subject, QueueGroup, func(msg *nats.Msg) {
+			r.route(route, msg)
+		})
+		if err != nil {
+			r.Close()
+			return err
+		}
+		r.subs = append(r.subs, sub)
+		r.logger.Info("routing stream events", "subject", route.Subject, "type", route.Type)
+	}
+	return nil
+}
+
+// Close stops routing
+func (r *Router) Close() {
+	for _, sub := range r.subs {
+		if err := sub.Unsubscribe(); err != nil {
+			r.logger.Debug("failed to unsubscribe stream route", "subject", sub.Subject, "error", err)
+		}
+	}
+	r.subs = nil
+}
+
+func (r *Router) route(route Route, msg *nats.Msg) {
+	ctx := otel.GetTextMapPropagator().Extract(context.Background(), propagation.HeaderCarrier(msg.Header))
+
+	var addressed struct {
+		Email      string          `json:"email"`
+		Recipients []string        `json:"recipients"`
+		StudentIDs []int           `json:"studentIds"`
+		Message    json.RawMessage `json:"message"`
+	}
+	if err := json.Unmarshal(msg.Data, &addressed); err != nil {
+		r.logger.WarnContext(ctx, "dropping malformed event", "subject", msg.Subject, "error", err)
+		return
+	}
+
+	studentIDs := slices.Clone(addressed.StudentIDs)
+	emails := addressed.Recipients
+	if addressed.Email != "" {
+		emails = append(emails, addressed.Email)
+	}
+	for _, email := range emails {
+		s, err := r.students.GetByEmail(ctx, email)
+		if errors.Is(err, student.ErrStudentNotFound) {
+			continue
+		}
+		if err != nil {
+			r.logger.ErrorContext(ctx, "failed to look up stream recipient", "email", email, "error", err)
+			continue
+		}
+		studentIDs = append(studentIDs, s.ID)
+	}
+	slices.Sort(studentIDs)
+	studentIDs = slices.Compact(studentIDs)
+
+	data := json.RawMessage(msg.Data)
+	if len(addressed.Message) > 0 {
+		data = addressed.Message
+	}
+	payload, err := json.Marshal(Event{Type: route.Type, Data: data})
+	if err != nil {
+		r.logger.WarnContext(ctx, "dropping malformed event", "subject", msg.Subject, "error", err)
+		return
+	}
+
+	for _, studentID := range studentIDs {
+		if err := publish(ctx, r.conn, StudentSubject(r.prefix, studentID), payload); err != nil {
+			r.logger.ErrorContext(ctx, "failed to publish stream event", "student_id", studentID, "error", err)
+		}
+	}
+}
+
+// Pusher pushes events this service produces itself to students' streams
+type Pusher struct {
+	conn   Conn
+	prefix string
+}
+
+func NewPusher(conn Conn, prefix string) *Pusher {
+	if prefix == "" {
+		prefix = DefaultSubjectPrefix
+	}
+	return &Pusher{conn: conn, prefix: prefix}
+}
+
+// Push sends v as an event of the given type to the student's connections
+func (p *Pusher) Push(ctx context.Context, studentID int, eventType string, v interface{}) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	payload, err := json.Marshal(Event{Type: eventType, Data: data})
+	if err != nil {
+		return err
+	}
+	return publish(ctx, p.conn, StudentSubject(p.prefix, studentID), payload)
+}
+
+func publish(ctx context.Context, conn Conn, subject string, payload []byte) error {
+	msg := nats.NewMsg(subject)
+	msg.Data = payload
+	otel.GetTextMapPropagator().Inject(ctx, propagation.HeaderCarrier(msg.Header))
+	return conn.PublishMsg(msg)
+}
@@ -0,0 +1,151 @@
+package student
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"student-service/internal/xlsx"
+
+	"github.com/gin-gonic/gin"
+)
+
+// exportFlushRows is how many rows are written between flushes to the client
+const exportFlushRows = 500
+
+// exportColumns are the header of CSV and XLSX exports, in row order
+var exportColumns = []string{"id", "firstName", "lastName", "email", "major", "year"}
+
+// rowWriter encodes exported students in one of the export formats
+type rowWriter interface {
+	Write(s *Student) error
+	Flush() error
+	Close() error
+}
+
+// ExportStudents streams every student matching the list filters as CSV,
+// NDJSON or XLSX, selected by ?format= (default csv)
+func (h *Handler) ExportStudents(c *gin.Context) {
+	opts, err := listOptionsFromQuery(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid query parameters"})
+		return
+	}
+
+	format := c.DefaultQuery("format", "csv")
+	contentType, ok := exportContentTypes[format]
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "format must be csv, ndjson or xlsx"})
+		return
+	}
+	if _, _, err := parseSort(opts.Sort); err != nil {
+		h.handleServiceError(c, err)
+		return
+	}
+
+	// Exports can outlive the server's write timeout
+	_ = http.NewResponseController(c.Writer).SetWriteDeadline(time.Time{})
+
+	filename := fmt.Sprintf("students-%s.%s", time.Now().UTC().Format("20060102T150405Z"), format)
+	c.Header("Content-Type", contentType)
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, filename))
+	c.Header("X-Content-Type-Options", "nosniff")
+	c.Status(http.StatusOK)
+
+	w, err := newRowWriter(format, c.Writer)
+	if err != nil {
+		h.logger.ErrorContext(c.Request.Context(), "failed to start student export", "error", err)
+		return
+	}
+
+	h.logger.InfoContext(c.Request.Context(), "exporting students", "format", format)
+	rows := 0
+	err = h.service.ExportStudents(c.Request.Context(), opts, func(s *Student) error {
+		if err := w.Write(s); err != nil {
+			return err
+		}
+		rows++
+		if rows%exportFlushRows == 0 {
+			if err := w.Flush(); err != nil {
+				return err
+			}
+			c.Writer.Flush()
+		}
+		return nil
+	})
+	if err == nil {
+		err = w.Close()
+	}
+
+	// The status is already sent, so a failed export can only be cut short
+	switch {
+	case err != nil && c.Request.Context().Err() != nil:
+		h.logger.InfoContext(c.Request.Context(), "student export cancelled by client", "rows", rows)
+	case err != nil:
+		h.logger.ErrorContext(c.Request.Context(), "student export failed", "error", err, "rows", rows)
+	default:
+		h.logger.InfoContext(c.Request.Context(), "student export finished", "rows", rows)
+	}
+}
+
+var exportContentTypes = map[string]string{
+	"csv":    "text/csv; charset=utf-8",
+	"ndjson": ImportFormatNDJSON,
+	"xlsx":   xlsx.ContentType,
+}
+
+func newRowWriter(format string, w io.Writer) (rowWriter, error) {
+	switch format {
+	case "csv":
+		cw := &csvRowWriter{w: csv.NewWriter(w)}
+		return cw, cw.w.Write(exportColumns)
+	case "ndjson":
+		return &ndjsonRowWriter{enc: json.NewEncoder(w)}, nil
+	case "xlsx":
+		xw, err := xlsx.NewWriter(w, "Students")
+		if err != nil {
+			return nil, err
+		}
+		header := make([]interface{}, len(exportColumns))
+		for i, column := range exportColumns {
+			header[i] = column
+		}
+		return &xlsxRowWriter{w: xw}, xw.WriteRow(header...)
+	}
+	return nil, errors.New("unsupported export format " + format)
+}
+
+type csvRowWriter struct{ w *csv.Writer }
+
+func (cw *csvRowWriter) Write(s *Student) error {
+	return cw.w.Write([]string{
+		strconv.Itoa(s.ID), s.FirstName, s.LastName, s.Email, s.Major, strconv.Itoa(s.Year),
+	})
+}
+
+func (cw *csvRowWriter) Flush() error {
+	cw.w.Flush()
+	return cw.w.Error()
+}
+
+func (cw *csvRowWriter) Close() error { return cw.Flush() }
+
+type ndjsonRowWriter struct{ enc *json.Encoder }
+
+func (nw *ndjsonRowWriter) Write(s *Student) error { return nw.enc.Encode(s) }
+func (nw *ndjsonRowWriter) Flush() error           { return nil }
+func (nw *ndjsonRowWriter) Close() error           { return nil }
+
+type xlsxRowWriter struct{ w *xlsx.Writer }
+
+func (xw *xlsxRowWriter) Write(s *Student) error {
+	return xw.w.WriteRow(s.ID, s.FirstName, s.LastName, s.Email, s.Major, s.Year)
+}
+
+func (xw *xlsxRowWriter) Flush() error { return xw.w.Flush() }
+func (xw *xlsxRowWriter) Close() error { return xw.w.Close() }
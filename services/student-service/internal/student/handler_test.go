@@ -4,12 +4,17 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"log/slog"
 	"net/http"
 	"net/http/httptest"
 	"os"
+	"strconv"
+	"strings"
 	"testing"
+	"time"
 
+	"grud/common/history"
 	commonmetrics "grud/common/metrics"
 	"grud/testing/testdb"
 	"student-service/internal/metrics"
@@ -26,14 +31,16 @@ func TestStudentService_Shared(t *testing.T) {
 	pgContainer := testdb.SetupSharedPostgres(t)
 	defer pgContainer.Cleanup(t)
 
-	pgContainer.RunMigrations(t, (*student.Student)(nil))
+	pgContainer.RunMigrations(t, (*student.Student)(nil), (*history.Entry)(nil))
 
 	// Create handler ONCE and reuse across all subtests
 	mockServiceMetrics := metrics.NewMock()
 	mockRepoMetrics := commonmetrics.NewMock()
 	repo := student.NewRepository(pgContainer.DB, mockRepoMetrics)
-	service := student.NewService(repo)
+	revoker := &recordingRevoker{}
+	inviter := &recordingInviter{}
 	logger := slog.New(slog.NewTextHandler(os.Stderr, nil))
+	service := student.NewService(repo, revoker, inviter, logger)
 	handler := student.NewHandler(service, logger, mockServiceMetrics)
 	router := gin.New()
 	handler.RegisterRoutes(router)
@@ -63,6 +70,183 @@ func TestStudentService_Shared(t *testing.T) {
 		err := json.NewDecoder(w.Body).Decode(&response)
 		require.NoError(t, err)
 		assert.NotZero(t, response.ID)
+
+		// No password is set; the student is invited to choose one
+		stored, err := repo.GetByID(context.Background(), response.ID)
+		require.NoError(t, err)
+		assert.Empty(t, stored.Password)
+		assert.Contains(t, inviter.invited, "john.doe@example.com")
+	})
+
+	t.Run("CreateStudent_InviteFails", func(t *testing.T) {
+		testdb.CleanupTables(t, pgContainer.DB, "students")
+		inviter.err = errors.New("mail server down")
+		defer func() { inviter.err = nil }()
+
+		create := func() *httptest.ResponseRecorder {
+			body, _ := json.Marshal(map[string]interface{}{"firstName": "Jane", "lastName": "Doe", "email": "jane.doe@example.com"})
+			req := httptest.NewRequest(http.MethodPost, "/students", bytes.NewReader(body))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+			return w
+		}
+
+		// The student is created all the same, and a retry is a conflict
+		assert.Equal(t, http.StatusCreated, create().Code)
+		assert.Equal(t, http.StatusConflict, create().Code)
+	})
+
+	t.Run("ImportStudents_CSV", func(t *testing.T) {
+		testdb.CleanupTables(t, pgContainer.DB, "students")
+		inviter.invited = nil
+
+		ctx := context.Background()
+		existing := &student.Student{FirstName: "Old", LastName: "Timer", Email: "taken@example.com"}
+		_, err := pgContainer.DB.NewInsert().Model(existing).Exec(ctx)
+		require.NoError(t, err)
+
+		csv := "firstName,lastName,email,major,year\n" +
+			"Ada,Lovelace,ada@example.com,Math,2\n" +
+			"Alan,Turing,taken@example.com,CS,3\n" +
+			",Nameless,nameless@example.com,,1\n" +
+			"Grace,Hopper,grace@example.com,CS,eleven\n" +
+			"Ada,Again,ada@example.com,Math,2\n" +
+			"Edsger,Dijkstra,edsger@example.com,CS,4\n"
+
+		req := httptest.NewRequest(http.MethodPost, "/students/import", strings.NewReader(csv))
+		req.Header.Set("Content-Type", "text/csv")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		require.Equal(t, http.StatusOK, w.Code)
+
+		var report student.ImportReport
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&report))
+		assert.False(t, report.DryRun)
+		assert.Equal(t, 6, report.Total)
+		assert.Equal(t, 2, report.Created)
+		assert.Equal(t, 2, report.Duplicates)
+		assert.Equal(t, 2, report.Invalid)
+
+		statuses := make([]student.ImportStatus, len(report.Rows))
+		for i, row := range report.Rows {
+			statuses[i] = row.Status
+		}
+		assert.Equal(t, []student.ImportStatus{
+			student.ImportCreated, student.ImportDuplicate, student.ImportInvalid,
+			student.ImportInvalid, student.ImportDuplicate, student.ImportCreated,
+		}, statuses)
+		assert.Equal(t, 2, report.Rows[0].Line)
+		assert.NotZero(t, report.Rows[0].ID)
+		assert.Equal(t, []string{"firstName: failed required"}, report.Rows[2].Errors)
+
+		count, err := pgContainer.DB.NewSelect().Model((*student.Student)(nil)).Count(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, 3, count)
+		assert.ElementsMatch(t, []string{"ada@example.com", "edsger@example.com"}, inviter.invited)
+	})
+
+	t.Run("ImportStudents_NDJSONDryRun", func(t *testing.T) {
+		testdb.CleanupTables(t, pgContainer.DB, "students")
+
+		ndjson := `{"firstName":"Ada","lastName":"Lovelace","email":"ada@example.com","year":2}` + "\n" +
+			"\n" +
+			`{"firstName":"Bad","lastName":"Email","email":"nope"}` + "\n" +
+			`{"firstName":` + "\n"
+
+		req := httptest.NewRequest(http.MethodPost, "/students/import?dryRun=true", strings.NewReader(ndjson))
+		req.Header.Set("Content-Type", "application/x-ndjson")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		require.Equal(t, http.StatusOK, w.Code)
+
+		var report student.ImportReport
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&report))
+		assert.True(t, report.DryRun)
+		assert.Equal(t, 3, report.Total)
+		assert.Equal(t, 0, report.Created)
+		assert.Equal(t, 1, report.Valid)
+		assert.Equal(t, 2, report.Invalid)
+		assert.Equal(t, student.ImportValid, report.Rows[0].Status)
+		assert.Equal(t, 3, report.Rows[1].Line)
+		assert.Equal(t, []string{"email: failed email"}, report.Rows[1].Errors)
+
+		count, err := pgContainer.DB.NewSelect().Model((*student.Student)(nil)).Count(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, 0, count)
+	})
+
+	t.Run("ImportStudents_BadInput", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/students/import", strings.NewReader("{}"))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusUnsupportedMediaType, w.Code)
+
+		req = httptest.NewRequest(http.MethodPost, "/students/import", strings.NewReader("name,email\nx,y\n"))
+		req.Header.Set("Content-Type", "text/csv")
+		w = httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("ExportStudents", func(t *testing.T) {
+		testdb.CleanupTables(t, pgContainer.DB, "students")
+
+		ctx := context.Background()
+		for i := 0; i < 1205; i++ {
+			major := "Math"
+			if i%2 == 0 {
+				major = "Physics"
+			}
+			s := &student.Student{
+				FirstName: "First" + strconv.Itoa(i),
+				LastName:  "Last",
+				Email:     "export" + strconv.Itoa(i) + "@example.com",
+				Password:  "hash",
+				Major:     major,
+				Year:      1,
+			}
+			_, err := pgContainer.DB.NewInsert().Model(s).Exec(ctx)
+			require.NoError(t, err)
+		}
+
+		export := func(query string) *httptest.ResponseRecorder {
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/students/export"+query, nil))
+			return w
+		}
+
+		w := export("?major=Physics&sort=-id")
+		require.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "text/csv; charset=utf-8", w.Header().Get("Content-Type"))
+		assert.Regexp(t, `^attachment; filename="students-\d{8}T\d{6}Z\.csv"$`, w.Header().Get("Content-Disposition"))
+
+		lines := strings.Split(strings.TrimSpace(w.Body.String()), "\n")
+		require.Len(t, lines, 604)
+		assert.Equal(t, "id,firstName,lastName,email,major,year", lines[0])
+		assert.Equal(t, "1205,First1204,Last,export1204@example.com,Physics,1", lines[1])
+		assert.NotContains(t, w.Body.String(), "hash")
+
+		w = export("?format=ndjson&q=export12")
+		require.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "application/x-ndjson", w.Header().Get("Content-Type"))
+		dec := json.NewDecoder(w.Body)
+		count := 0
+		for dec.More() {
+			var s student.Student
+			require.NoError(t, dec.Decode(&s))
+			count++
+		}
+		assert.Equal(t, 16, count) // export12, export120-129 and export1200-1204
+
+		w = export("?format=xlsx")
+		require.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet", w.Header().Get("Content-Type"))
+		assert.Equal(t, "PK", w.Body.String()[:2])
+
+		assert.Equal(t, http.StatusBadRequest, export("?format=pdf").Code)
+		assert.Equal(t, http.StatusBadRequest, export("?sort=email").Code)
 	})
 
 	t.Run("GetStudent", func(t *testing.T) {
@@ -127,11 +311,14 @@ func TestStudentService_Shared(t *testing.T) {
 
 		assert.Equal(t, http.StatusOK, w.Code)
 
-		var response []student.Student
-		err := json.NewDecoder(w.Body).Decode(&response)
+		var page student.ListResult
+		err := json.NewDecoder(w.Body).Decode(&page)
 		require.NoError(t, err)
 
+		response := page.Items
 		assert.Len(t, response, 2)
+		assert.Empty(t, page.NextCursor)
+		assert.Nil(t, page.Total)
 
 		// Verify first student
 		assert.Equal(t, "Student", response[0].FirstName)
@@ -150,6 +337,102 @@ func TestStudentService_Shared(t *testing.T) {
 		assert.NotZero(t, response[1].ID)
 	})
 
+	t.Run("ListStudents_Pagination", func(t *testing.T) {
+		testdb.CleanupTables(t, pgContainer.DB, "students")
+
+		ctx := context.Background()
+		students := []*student.Student{
+			{FirstName: "Ann", LastName: "Baker", Email: "ann@example.com", Major: "Physics", Year: 1},
+			{FirstName: "Bob", LastName: "Adams", Email: "bob@example.com", Major: "Physics", Year: 2},
+			{FirstName: "Cid", LastName: "Adams", Email: "cid@example.com", Major: "Biology", Year: 2},
+			{FirstName: "Dan", LastName: "Clark", Email: "dan@example.com", Major: "Physics", Year: 3},
+		}
+		for _, s := range students {
+			_, err := pgContainer.DB.NewInsert().Model(s).Exec(ctx)
+			require.NoError(t, err)
+		}
+
+		// Walk every page sorted by last name and collect the emails
+		var emails []string
+		cursor := ""
+		for i := 0; i < 10; i++ {
+			url := "/students?limit=3&sort=lastName&includeTotal=true"
+			if cursor != "" {
+				url += "&cursor=" + cursor
+			}
+			req := httptest.NewRequest(http.MethodGet, url, nil)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+			require.Equal(t, http.StatusOK, w.Code)
+
+			var page student.ListResult
+			require.NoError(t, json.NewDecoder(w.Body).Decode(&page))
+			require.NotNil(t, page.Total)
+			assert.Equal(t, 4, *page.Total)
+
+			for _, s := range page.Items {
+				emails = append(emails, s.Email)
+			}
+			if page.NextCursor == "" {
+				break
+			}
+			cursor = page.NextCursor
+		}
+
+		assert.Equal(t, []string{"bob@example.com", "cid@example.com", "ann@example.com", "dan@example.com"}, emails)
+	})
+
+	t.Run("ListStudents_Filters", func(t *testing.T) {
+		testdb.CleanupTables(t, pgContainer.DB, "students")
+
+		ctx := context.Background()
+		students := []*student.Student{
+			{FirstName: "Ann", LastName: "Baker", Email: "ann@example.com", Major: "Physics", Year: 1},
+			{FirstName: "Bob", LastName: "Adams", Email: "bob@example.com", Major: "Physics", Year: 2},
+			{FirstName: "Cid", LastName: "Annis", Email: "cid@example.com", Major: "Biology", Year: 2},
+		}
+		for _, s := range students {
+			_, err := pgContainer.DB.NewInsert().Model(s).Exec(ctx)
+			require.NoError(t, err)
+		}
+
+		tests := []struct {
+			query string
+			want  []string
+		}{
+			{"major=Physics", []string{"ann@example.com", "bob@example.com"}},
+			{"year=2&sort=-id", []string{"cid@example.com", "bob@example.com"}},
+			{"q=an", []string{"ann@example.com", "cid@example.com"}},
+			{"q=BOB", []string{"bob@example.com"}},
+			{"q=%25", nil},
+		}
+
+		for _, tt := range tests {
+			req := httptest.NewRequest(http.MethodGet, "/students?"+tt.query, nil)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+			require.Equal(t, http.StatusOK, w.Code, tt.query)
+
+			var page student.ListResult
+			require.NoError(t, json.NewDecoder(w.Body).Decode(&page))
+
+			var emails []string
+			for _, s := range page.Items {
+				emails = append(emails, s.Email)
+			}
+			assert.Equal(t, tt.want, emails, tt.query)
+		}
+	})
+
+	t.Run("ListStudents_InvalidParams", func(t *testing.T) {
+		for _, query := range []string{"limit=abc", "limit=1000", "sort=email", "cursor=not-a-cursor", "year=x"} {
+			req := httptest.NewRequest(http.MethodGet, "/students?"+query, nil)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+			assert.Equal(t, http.StatusBadRequest, w.Code, query)
+		}
+	})
+
 	t.Run("GetStudentNotFound", func(t *testing.T) {
 		testdb.CleanupTables(t, pgContainer.DB, "students")
 
@@ -188,11 +471,13 @@ func TestStudentService_Shared(t *testing.T) {
 
 		req := httptest.NewRequest(http.MethodPut, "/students/1", bytes.NewReader(body))
 		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("If-Match", `"1"`)
 		w := httptest.NewRecorder()
 
 		router.ServeHTTP(w, req)
 
 		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, `"2"`, w.Header().Get("ETag"))
 
 		var response student.Student
 		err = json.NewDecoder(w.Body).Decode(&response)
@@ -201,6 +486,163 @@ func TestStudentService_Shared(t *testing.T) {
 		assert.Equal(t, "updated@example.com", response.Email)
 		assert.Equal(t, "Computer Science", response.Major)
 		assert.Equal(t, 4, response.Year)
+		assert.Equal(t, 2, response.Version)
+	})
+
+	t.Run("UpdateStudent_Preconditions", func(t *testing.T) {
+		testdb.CleanupTables(t, pgContainer.DB, "students")
+
+		ctx := context.Background()
+		testStudent := &student.Student{FirstName: "Con", LastName: "Current", Email: "concurrent@example.com"}
+		_, err := pgContainer.DB.NewInsert().Model(testStudent).Exec(ctx)
+		require.NoError(t, err)
+		path := "/students/" + strconv.Itoa(testStudent.ID)
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+		require.Equal(t, http.StatusOK, w.Code)
+		tag := w.Header().Get("ETag")
+		assert.Equal(t, `"1"`, tag)
+
+		// Conditional GET
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		req.Header.Set("If-None-Match", tag)
+		w = httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusNotModified, w.Code)
+
+		put := func(ifMatch, major string) *httptest.ResponseRecorder {
+			body, _ := json.Marshal(map[string]interface{}{
+				"firstName": "Con",
+				"lastName":  "Current",
+				"email":     "concurrent@example.com",
+				"major":     major,
+			})
+			req := httptest.NewRequest(http.MethodPut, path, bytes.NewReader(body))
+			req.Header.Set("Content-Type", "application/json")
+			if ifMatch != "" {
+				req.Header.Set("If-Match", ifMatch)
+			}
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+			return w
+		}
+
+		assert.Equal(t, http.StatusPreconditionRequired, put("", "Physics").Code)
+		assert.Equal(t, http.StatusBadRequest, put("1", "Physics").Code)
+
+		// Two editors start from the same version; the second one loses
+		first := put(tag, "Physics")
+		require.Equal(t, http.StatusOK, first.Code)
+		assert.Equal(t, `"2"`, first.Header().Get("ETag"))
+		assert.Equal(t, http.StatusPreconditionFailed, put(tag, "Chemistry").Code)
+
+		stored, err := repo.GetByID(ctx, testStudent.ID)
+		require.NoError(t, err)
+		assert.Equal(t, "Physics", stored.Major)
+		assert.Equal(t, 2, stored.Version)
+
+		// "*" matches whatever version is current
+		assert.Equal(t, http.StatusOK, put("*", "Chemistry").Code)
+	})
+
+	t.Run("UpdateStudent_KeepsPassword", func(t *testing.T) {
+		testdb.CleanupTables(t, pgContainer.DB, "students")
+
+		ctx := context.Background()
+		testStudent := &student.Student{FirstName: "Pass", LastName: "Word", Email: "password@example.com", Password: "hash"}
+		_, err := pgContainer.DB.NewInsert().Model(testStudent).Exec(ctx)
+		require.NoError(t, err)
+
+		body, _ := json.Marshal(map[string]interface{}{
+			"firstName": "Pass",
+			"lastName":  "Word",
+			"email":     "password@example.com",
+			"major":     "Physics",
+		})
+		req := httptest.NewRequest(http.MethodPut, "/students/"+strconv.Itoa(testStudent.ID), bytes.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("If-Match", `"1"`)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		require.Equal(t, http.StatusOK, w.Code)
+
+		stored, err := repo.GetByID(ctx, testStudent.ID)
+		require.NoError(t, err)
+		assert.Equal(t, "Physics", stored.Major)
+		assert.Equal(t, "hash", stored.Password)
+	})
+
+	t.Run("PatchStudent", func(t *testing.T) {
+		testdb.CleanupTables(t, pgContainer.DB, "students")
+
+		ctx := context.Background()
+		testStudent := &student.Student{
+			FirstName: "Pat",
+			LastName:  "Ch",
+			Email:     "patch@example.com",
+			Password:  "hash",
+			Major:     "Math",
+			Year:      2,
+		}
+		_, err := pgContainer.DB.NewInsert().Model(testStudent).Exec(ctx)
+		require.NoError(t, err)
+		path := "/students/" + strconv.Itoa(testStudent.ID)
+
+		patch := func(body, ifMatch string) *httptest.ResponseRecorder {
+			req := httptest.NewRequest(http.MethodPatch, path, bytes.NewReader([]byte(body)))
+			req.Header.Set("Content-Type", student.MergePatchContentType)
+			if ifMatch != "" {
+				req.Header.Set("If-Match", ifMatch)
+			}
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+			return w
+		}
+
+		w := patch(`{"year": 3, "major": null}`, "")
+		require.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, `"2"`, w.Header().Get("ETag"))
+
+		var response student.Student
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&response))
+		assert.Equal(t, "Pat", response.FirstName)
+		assert.Equal(t, 3, response.Year)
+		assert.Empty(t, response.Major)
+
+		stored, err := repo.GetByID(ctx, testStudent.ID)
+		require.NoError(t, err)
+		assert.Equal(t, 3, stored.Year)
+		assert.Empty(t, stored.Major)
+		assert.Equal(t, "patch@example.com", stored.Email)
+		assert.Equal(t, "hash", stored.Password)
+
+		// A patch that changes nothing does not bump the version
+		w = patch(`{"year": 3}`, "")
+		require.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, `"2"`, w.Header().Get("ETag"))
+
+		// Protected fields, invalid results and stale versions are rejected
+		assert.Equal(t, http.StatusBadRequest, patch(`{"password": "secret"}`, "").Code)
+		assert.Equal(t, http.StatusBadRequest, patch(`{"id": 42}`, "").Code)
+		assert.Equal(t, http.StatusBadRequest, patch(`{"firstName": null}`, "").Code)
+		assert.Equal(t, http.StatusBadRequest, patch(`{"email": "not-an-email"}`, "").Code)
+		assert.Equal(t, http.StatusBadRequest, patch(`{"year": "three"}`, "").Code)
+		assert.Equal(t, http.StatusBadRequest, patch(`[]`, "").Code)
+		assert.Equal(t, http.StatusPreconditionFailed, patch(`{"year": 4}`, `"1"`).Code)
+		assert.Equal(t, http.StatusOK, patch(`{"year": 4}`, `"2"`).Code)
+
+		req := httptest.NewRequest(http.MethodPatch, path, bytes.NewReader([]byte(`{"year": 5}`)))
+		req.Header.Set("Content-Type", "text/plain")
+		w = httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusUnsupportedMediaType, w.Code)
+
+		req = httptest.NewRequest(http.MethodPatch, "/students/99999", bytes.NewReader([]byte(`{"year": 5}`)))
+		req.Header.Set("Content-Type", student.MergePatchContentType)
+		w = httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusNotFound, w.Code)
 	})
 
 	t.Run("UpdateStudentNotFound", func(t *testing.T) {
@@ -217,6 +659,7 @@ func TestStudentService_Shared(t *testing.T) {
 
 		req := httptest.NewRequest(http.MethodPut, "/students/99999", bytes.NewReader(body))
 		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("If-Match", `"1"`)
 		w := httptest.NewRecorder()
 
 		router.ServeHTTP(w, req)
@@ -265,6 +708,191 @@ func TestStudentService_Shared(t *testing.T) {
 		assert.Equal(t, http.StatusNotFound, w.Code)
 	})
 
+	t.Run("DeleteStudent_SoftDeleteAndRestore", func(t *testing.T) {
+		testdb.CleanupTables(t, pgContainer.DB, "students")
+		revoker.revoked = nil
+
+		ctx := context.Background()
+		testStudent := &student.Student{
+			FirstName: "Soft",
+			LastName:  "Delete",
+			Email:     "soft.delete@example.com",
+		}
+		_, err := pgContainer.DB.NewInsert().Model(testStudent).Exec(ctx)
+		require.NoError(t, err)
+		path := "/students/" + strconv.Itoa(testStudent.ID)
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodDelete, path, nil))
+		require.Equal(t, http.StatusNoContent, w.Code)
+		assert.Equal(t, []int{testStudent.ID}, revoker.revoked)
+
+		// The row is kept but hidden from reads and deletes
+		count, err := pgContainer.DB.NewSelect().Model((*student.Student)(nil)).WhereDeleted().Count(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, 1, count)
+
+		w = httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+		assert.Equal(t, http.StatusNotFound, w.Code)
+
+		w = httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodDelete, path, nil))
+		assert.Equal(t, http.StatusNotFound, w.Code)
+
+		w = httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/students?includeTotal=true", nil))
+		var page student.ListResult
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&page))
+		assert.Empty(t, page.Items)
+		assert.Equal(t, 0, *page.Total)
+
+		taken, err := repo.EmailTaken(ctx, testStudent.Email)
+		require.NoError(t, err)
+		assert.True(t, taken, "a deleted student's email stays reserved")
+
+		// Restore brings it back
+		w = httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodPost, path+"/restore", nil))
+		require.Equal(t, http.StatusOK, w.Code)
+		var restored student.Student
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&restored))
+		assert.Equal(t, testStudent.Email, restored.Email)
+
+		w = httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("RestoreStudent_NotDeleted", func(t *testing.T) {
+		testdb.CleanupTables(t, pgContainer.DB, "students")
+
+		ctx := context.Background()
+		testStudent := &student.Student{FirstName: "Still", LastName: "Here", Email: "still.here@example.com"}
+		_, err := pgContainer.DB.NewInsert().Model(testStudent).Exec(ctx)
+		require.NoError(t, err)
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/students/"+strconv.Itoa(testStudent.ID)+"/restore", nil))
+		assert.Equal(t, http.StatusNotFound, w.Code)
+
+		w = httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/students/99999/restore", nil))
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	t.Run("PurgeDeleted", func(t *testing.T) {
+		testdb.CleanupTables(t, pgContainer.DB, "students")
+
+		ctx := context.Background()
+		old := &student.Student{FirstName: "Old", LastName: "Deleted", Email: "old@example.com"}
+		recent := &student.Student{FirstName: "Recent", LastName: "Deleted", Email: "recent@example.com"}
+		active := &student.Student{FirstName: "Active", LastName: "Student", Email: "active@example.com"}
+		for _, s := range []*student.Student{old, recent, active} {
+			_, err := pgContainer.DB.NewInsert().Model(s).Exec(ctx)
+			require.NoError(t, err)
+		}
+		require.NoError(t, service.DeleteStudent(ctx, old.ID))
+		require.NoError(t, service.DeleteStudent(ctx, recent.ID))
+		_, err := pgContainer.DB.NewUpdate().
+			Model((*student.Student)(nil)).
+			Set("deleted_at = ?", time.Now().Add(-48*time.Hour)).
+			Where("id = ?", old.ID).
+			WhereDeleted().
+			Exec(ctx)
+		require.NoError(t, err)
+
+		purged, err := service.PurgeDeleted(ctx, 24*time.Hour)
+		require.NoError(t, err)
+		assert.Equal(t, 1, purged)
+
+		remaining, err := pgContainer.DB.NewSelect().Model((*student.Student)(nil)).WhereAllWithDeleted().Count(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, 2, remaining)
+
+		_, err = service.RestoreStudent(ctx, old.ID)
+		assert.ErrorIs(t, err, student.ErrStudentNotFound)
+		_, err = service.RestoreStudent(ctx, recent.ID)
+		assert.NoError(t, err)
+	})
+
+	t.Run("GetStudentHistory", func(t *testing.T) {
+		testdb.CleanupTables(t, pgContainer.DB, "students", "history")
+
+		ctx := history.WithActor(context.Background(), "admin@example.com")
+		created, err := service.CreateStudent(ctx, &student.Student{
+			FirstName: "Audit",
+			LastName:  "Trail",
+			Email:     "audit@example.com",
+			Major:     "Physics",
+			Year:      1,
+		})
+		require.NoError(t, err)
+		path := "/students/" + strconv.Itoa(created.ID)
+
+		// Change the major over HTTP; without the auth middleware the actor is the system
+		body, _ := json.Marshal(map[string]interface{}{
+			"firstName": "Audit",
+			"lastName":  "Trail",
+			"email":     "audit@example.com",
+			"major":     "Mathematics",
+			"year":      1,
+		})
+		req := httptest.NewRequest(http.MethodPut, path, bytes.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("If-Match", `"1"`)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		require.Equal(t, http.StatusOK, w.Code)
+
+		require.NoError(t, service.DeleteStudent(ctx, created.ID))
+
+		// History stays readable after the student is deleted
+		w = httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path+"/history", nil))
+		require.Equal(t, http.StatusOK, w.Code)
+
+		var page history.Page
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&page))
+		require.Len(t, page.Entries, 3)
+		assert.Empty(t, page.NextPageToken)
+
+		deleted, updated, createdEntry := page.Entries[0], page.Entries[1], page.Entries[2]
+		assert.Equal(t, history.ActionDelete, deleted.Action)
+		assert.Equal(t, "admin@example.com", deleted.Actor)
+		assert.Equal(t, "Mathematics", deleted.Before["major"])
+
+		assert.Equal(t, history.ActionUpdate, updated.Action)
+		assert.Equal(t, history.SystemActor, updated.Actor)
+		assert.Equal(t, map[string]interface{}{"major": "Physics"}, updated.Before)
+		assert.Equal(t, map[string]interface{}{"major": "Mathematics"}, updated.After)
+
+		assert.Equal(t, history.ActionCreate, createdEntry.Action)
+		assert.Nil(t, createdEntry.Before)
+		assert.NotContains(t, createdEntry.After, "password")
+
+		// Paging
+		w = httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path+"/history?limit=2", nil))
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&page))
+		require.Len(t, page.Entries, 2)
+		require.NotEmpty(t, page.NextPageToken)
+
+		w = httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path+"/history?limit=2&cursor="+page.NextPageToken, nil))
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&page))
+		require.Len(t, page.Entries, 1)
+		assert.Equal(t, history.ActionCreate, page.Entries[0].Action)
+
+		w = httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/students/99999/history", nil))
+		assert.Equal(t, http.StatusNotFound, w.Code)
+
+		w = httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path+"/history?cursor=garbage", nil))
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
 	t.Run("InvalidJSON", func(t *testing.T) {
 		testdb.CleanupTables(t, pgContainer.DB, "students")
 
@@ -288,3 +916,28 @@ func TestStudentService_Shared(t *testing.T) {
 		assert.Equal(t, http.StatusBadRequest, w.Code)
 	})
 }
+
+// recordingRevoker captures the students whose tokens were revoked
+type recordingRevoker struct {
+	revoked []int
+}
+
+func (r *recordingRevoker) DeleteAllStudentTokens(ctx context.Context, studentID int) error {
+	r.revoked = append(r.revoked, studentID)
+	return nil
+}
+
+// recordingInviter captures the emails of the students invited to set a
+// password, failing with err if it is set
+type recordingInviter struct {
+	invited []string
+	err     error
+}
+
+func (r *recordingInviter) InvitePasswordSetup(ctx context.Context, s *student.Student) error {
+	if r.err != nil {
+		return r.err
+	}
+	r.invited = append(r.invited, s.Email)
+	return nil
+}
@@ -2,15 +2,18 @@ package student
 
 import (
 	"errors"
+	"io"
 	"log/slog"
 	"net/http"
 	"strconv"
 
 	"student-service/internal/metrics"
 
+	"grud/common/history"
+	"grud/common/httputil"
+
 	"github.com/gin-gonic/gin"
 	"github.com/go-playground/validator/v10"
-	"golang.org/x/crypto/bcrypt"
 )
 
 type Handler struct {
@@ -31,10 +34,15 @@ func NewHandler(service Service, logger *slog.Logger, metrics *metrics.Metrics)
 
 func (h *Handler) RegisterRoutes(router gin.IRouter) {
 	router.POST("/students", h.CreateStudent)
-	router.GET("/students", h.GetAllStudents)
+	router.POST("/students/import", h.ImportStudents)
+	router.GET("/students", h.ListStudents)
+	router.GET("/students/export", h.ExportStudents)
 	router.GET("/students/:id", h.GetStudent)
 	router.PUT("/students/:id", h.UpdateStudent)
+	router.PATCH("/students/:id", h.PatchStudent)
 	router.DELETE("/students/:id", h.DeleteStudent)
+	router.POST("/students/:id/restore", h.RestoreStudent)
+	router.GET("/students/:id/history", h.GetStudentHistory)
 }
 
 func (h *Handler) CreateStudent(c *gin.Context) {
@@ -44,18 +52,7 @@ func (h *Handler) CreateStudent(c *gin.Context) {
 		return
 	}
 
-	// Set default password for students created via API
-	// In production, students should be created via /auth/register
-	if student.Password == "" {
-		hashedPassword, err := bcrypt.GenerateFromPassword([]byte("DefaultPassword123!"), bcrypt.DefaultCost)
-		if err != nil {
-			h.logger.ErrorContext(c.Request.Context(), "failed to hash password", "error", err)
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "Internal server error"})
-			return
-		}
-		student.Password = string(hashedPassword)
-	}
-
+	// The student is sent a password setup invitation instead of a password
 	h.logger.InfoContext(c.Request.Context(), "creating student", "email", student.Email)
 	createdStudent, err := h.service.CreateStudent(c.Request.Context(), &student)
 	if err != nil {
@@ -66,13 +63,102 @@ func (h *Handler) CreateStudent(c *gin.Context) {
 	// Record metric
 	h.metrics.RecordStudentRegistration(c.Request.Context())
 
+	c.Header("ETag", httputil.ETag(createdStudent.Version))
 	c.JSON(http.StatusCreated, createdStudent)
 }
 
-func (h *Handler) GetAllStudents(c *gin.Context) {
-	h.logger.InfoContext(c.Request.Context(), "fetching all students")
+// ImportStudents creates students from a CSV or NDJSON body. Every line is
+// validated like a single create and reported on; ?dryRun=true reports
+// without writing.
+func (h *Handler) ImportStudents(c *gin.Context) {
+	dryRun := false
+	if v := c.Query("dryRun"); v != "" {
+		var err error
+		if dryRun, err = strconv.ParseBool(v); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid query parameters"})
+			return
+		}
+	}
+
+	format := c.ContentType()
+	if format == "application/ndjson" {
+		format = ImportFormatNDJSON
+	}
+	if format != ImportFormatCSV && format != ImportFormatNDJSON {
+		c.JSON(http.StatusUnsupportedMediaType, gin.H{"error": "Content-Type must be " + ImportFormatCSV + " or " + ImportFormatNDJSON})
+		return
+	}
+
+	report := &ImportReport{DryRun: dryRun, Rows: []ImportRowResult{}}
+	var students []*Student
+	var rows []int // index into report.Rows of each entry of students
 
-	students, err := h.service.GetAllStudents(c.Request.Context())
+	err := readImport(format, c.Request.Body, func(line int, student *Student, err error) error {
+		if len(report.Rows) == MaxImportRows {
+			return errTooManyRows
+		}
+
+		result := ImportRowResult{Line: line}
+		if student != nil {
+			result.Email = student.Email
+		}
+		if err == nil {
+			err = h.validate.Struct(student)
+		}
+		if err != nil {
+			result.Status = ImportInvalid
+			result.Errors = fieldErrors(err)
+			report.Invalid++
+		} else {
+			students = append(students, student)
+			rows = append(rows, len(report.Rows))
+		}
+		report.Rows = append(report.Rows, result)
+		return nil
+	})
+	if err != nil {
+		h.logger.InfoContext(c.Request.Context(), "rejected student import", "error", err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	h.logger.InfoContext(c.Request.Context(), "importing students", "rows", len(report.Rows), "valid", len(students), "dryRun", dryRun)
+	skipped, err := h.service.ImportStudents(c.Request.Context(), students, dryRun)
+	if err != nil {
+		h.handleServiceError(c, err)
+		return
+	}
+
+	for i, student := range students {
+		result := &report.Rows[rows[i]]
+		switch {
+		case skipped[i]:
+			result.Status = ImportDuplicate
+			report.Duplicates++
+		case dryRun:
+			result.Status = ImportValid
+			report.Valid++
+		default:
+			result.Status = ImportCreated
+			result.ID = student.ID
+			report.Created++
+			h.metrics.RecordStudentRegistration(c.Request.Context())
+		}
+	}
+	report.Total = len(report.Rows)
+
+	c.JSON(http.StatusOK, report)
+}
+
+func (h *Handler) ListStudents(c *gin.Context) {
+	opts, err := listOptionsFromQuery(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid query parameters"})
+		return
+	}
+
+	h.logger.InfoContext(c.Request.Context(), "listing students", "limit", opts.Limit, "sort", opts.Sort)
+	result, err := h.service.ListStudents(c.Request.Context(), opts)
 	if err != nil {
 		h.handleServiceError(c, err)
 		return
@@ -81,7 +167,43 @@ func (h *Handler) GetAllStudents(c *gin.Context) {
 	// Record metric
 	h.metrics.RecordStudentsListViewed(c.Request.Context())
 
-	c.JSON(http.StatusOK, students)
+	c.JSON(http.StatusOK, result)
+}
+
+// listOptionsFromQuery reads ?limit=&cursor=&sort=&major=&year=&q=&includeTotal= into ListOptions
+func listOptionsFromQuery(c *gin.Context) (ListOptions, error) {
+	opts := ListOptions{
+		Cursor: c.Query("cursor"),
+		Sort:   c.Query("sort"),
+		ListFilter: ListFilter{
+			Major:  c.Query("major"),
+			Prefix: c.Query("q"),
+		},
+	}
+
+	if v := c.Query("limit"); v != "" {
+		limit, err := strconv.Atoi(v)
+		if err != nil {
+			return opts, err
+		}
+		opts.Limit = limit
+	}
+	if v := c.Query("year"); v != "" {
+		year, err := strconv.Atoi(v)
+		if err != nil {
+			return opts, err
+		}
+		opts.Year = &year
+	}
+	if v := c.Query("includeTotal"); v != "" {
+		includeTotal, err := strconv.ParseBool(v)
+		if err != nil {
+			return opts, err
+		}
+		opts.IncludeTotal = includeTotal
+	}
+
+	return opts, nil
 }
 
 func (h *Handler) GetStudent(c *gin.Context) {
@@ -101,18 +223,37 @@ func (h *Handler) GetStudent(c *gin.Context) {
 	// Record metric
 	h.metrics.RecordStudentViewed(c.Request.Context())
 
+	tag := httputil.ETag(student.Version)
+	c.Header("ETag", tag)
+	if c.GetHeader("If-None-Match") == tag {
+		c.Status(http.StatusNotModified)
+		return
+	}
 	c.JSON(http.StatusOK, student)
 }
 
+// UpdateStudent replaces a student. The request must carry the student's
+// current ETag in If-Match so that concurrent edits are not lost.
 func (h *Handler) UpdateStudent(c *gin.Context) {
 	id, _ := strconv.Atoi(c.Param("id"))
 
+	version, ok, err := httputil.ParseIfMatch(c.GetHeader("If-Match"))
+	if !ok {
+		c.JSON(http.StatusPreconditionRequired, gin.H{"error": "If-Match header is required"})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid If-Match header"})
+		return
+	}
+
 	var student Student
 	if err := c.ShouldBindJSON(&student); err != nil || h.validate.Struct(&student) != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request"})
 		return
 	}
 	student.ID = id
+	student.Version = version
 
 	h.logger.InfoContext(c.Request.Context(), "updating student", "email", student.Email)
 	if err := h.service.UpdateStudent(c.Request.Context(), &student); err != nil {
@@ -120,6 +261,67 @@ func (h *Handler) UpdateStudent(c *gin.Context) {
 		return
 	}
 
+	c.Header("ETag", httputil.ETag(student.Version))
+	c.JSON(http.StatusOK, student)
+}
+
+// PatchStudent applies an RFC 7396 merge patch and writes only the changed
+// columns. If-Match is optional: a patch only touches the fields it names, so
+// clients do not have to fetch the student first.
+func (h *Handler) PatchStudent(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid student ID"})
+		return
+	}
+
+	version, _, err := httputil.ParseIfMatch(c.GetHeader("If-Match"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid If-Match header"})
+		return
+	}
+
+	if ct := c.ContentType(); ct != MergePatchContentType && ct != "application/json" {
+		c.JSON(http.StatusUnsupportedMediaType, gin.H{"error": "Content-Type must be " + MergePatchContentType})
+		return
+	}
+	patch, err := io.ReadAll(c.Request.Body)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request"})
+		return
+	}
+
+	current, err := h.service.GetStudentByID(c.Request.Context(), id)
+	if err != nil {
+		h.handleServiceError(c, err)
+		return
+	}
+	if version != 0 && version != current.Version {
+		h.handleServiceError(c, ErrVersionConflict)
+		return
+	}
+
+	student, columns, err := applyPatch(current, patch)
+	if err != nil {
+		h.handleServiceError(c, err)
+		return
+	}
+	if h.validate.Struct(student) != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request"})
+		return
+	}
+
+	// An empty or no-op patch leaves the student, and its version, untouched
+	if len(columns) > 0 {
+		h.logger.InfoContext(c.Request.Context(), "patching student", "id", id, "columns", columns)
+		student.Version = current.Version
+		if err := h.service.UpdateStudent(c.Request.Context(), student, columns...); err != nil {
+			h.handleServiceError(c, err)
+			return
+		}
+	}
+
+	c.Header("ETag", httputil.ETag(student.Version))
 	c.JSON(http.StatusOK, student)
 }
 
@@ -139,12 +341,69 @@ func (h *Handler) DeleteStudent(c *gin.Context) {
 	c.Status(http.StatusNoContent)
 }
 
+func (h *Handler) RestoreStudent(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid student ID"})
+		return
+	}
+
+	h.logger.InfoContext(c.Request.Context(), "restoring student", "id", id)
+	student, err := h.service.RestoreStudent(c.Request.Context(), id)
+	if err != nil {
+		h.handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, student)
+}
+
+func (h *Handler) GetStudentHistory(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid student ID"})
+		return
+	}
+
+	limit := 0
+	if v := c.Query("limit"); v != "" {
+		if limit, err = strconv.Atoi(v); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid query parameters"})
+			return
+		}
+	}
+
+	h.logger.InfoContext(c.Request.Context(), "fetching student history", "id", id)
+	page, err := h.service.GetStudentHistory(c.Request.Context(), id, limit, c.Query("cursor"))
+	if err != nil {
+		h.handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, page)
+}
+
 func (h *Handler) handleServiceError(c *gin.Context, err error) {
 	if errors.Is(err, ErrStudentNotFound) {
 		h.logger.Info("student not found")
 		c.JSON(http.StatusNotFound, gin.H{"error": "Student not found"})
 		return
 	}
+	if errors.Is(err, ErrInvalidCursor) || errors.Is(err, history.ErrInvalidPageToken) {
+		h.logger.Info("invalid cursor")
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid cursor"})
+		return
+	}
+	if errors.Is(err, ErrVersionConflict) {
+		h.logger.Info("version conflict")
+		c.JSON(http.StatusPreconditionFailed, gin.H{"error": "Student was modified, reload and try again"})
+		return
+	}
+	if errors.Is(err, ErrEmailTaken) {
+		h.logger.Info("email taken")
+		c.JSON(http.StatusConflict, gin.H{"error": "Email already taken"})
+		return
+	}
 	if errors.Is(err, ErrInvalidInput) {
 		h.logger.Info("invalid input")
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
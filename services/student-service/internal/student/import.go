@@ -0,0 +1,206 @@
+package student
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// MaxImportRows caps the number of rows a single import may contain
+const MaxImportRows = 20000
+
+// Import formats, selected by the request Content-Type
+const (
+	ImportFormatCSV    = "text/csv"
+	ImportFormatNDJSON = "application/x-ndjson"
+)
+
+// ImportStatus is the outcome of one import row
+type ImportStatus string
+
+const (
+	ImportCreated   ImportStatus = "created"
+	ImportValid     ImportStatus = "valid" // would be created; dry runs only
+	ImportDuplicate ImportStatus = "duplicate_email"
+	ImportInvalid   ImportStatus = "invalid"
+)
+
+// ImportRowResult reports what happened to one line of the input
+type ImportRowResult struct {
+	Line   int          `json:"line"`
+	Email  string       `json:"email,omitempty"`
+	Status ImportStatus `json:"status"`
+	ID     int          `json:"id,omitempty"`
+	Errors []string     `json:"errors,omitempty"`
+}
+
+// ImportReport is the response of an import
+type ImportReport struct {
+	DryRun     bool              `json:"dryRun"`
+	Total      int               `json:"total"`
+	Created    int               `json:"created"`
+	Valid      int               `json:"valid,omitempty"`
+	Duplicates int               `json:"duplicates"`
+	Invalid    int               `json:"invalid"`
+	Rows       []ImportRowResult `json:"rows"`
+}
+
+var errTooManyRows = fmt.Errorf("import is limited to %d rows", MaxImportRows)
+
+// importColumns maps accepted CSV header names, lowercased, to the field they fill
+var importColumns = map[string]string{
+	"firstname":  "firstName",
+	"first_name": "firstName",
+	"lastname":   "lastName",
+	"last_name":  "lastName",
+	"email":      "email",
+	"major":      "major",
+	"year":       "year",
+}
+
+// readImport streams the students in body, calling fn once per line with the
+// decoded student or the reason the line could not be decoded. Errors that
+// make the rest of the input unreadable, and errors returned by fn, stop the
+// import.
+func readImport(format string, body io.Reader, fn func(line int, student *Student, err error) error) error {
+	switch format {
+	case ImportFormatCSV:
+		return readCSV(body, fn)
+	case ImportFormatNDJSON:
+		return readNDJSON(body, fn)
+	}
+	return fmt.Errorf("unsupported import format %q", format)
+}
+
+func readCSV(body io.Reader, fn func(int, *Student, error) error) error {
+	r := csv.NewReader(body)
+	r.FieldsPerRecord = -1
+	r.TrimLeadingSpace = true
+
+	header, err := r.Read()
+	if err != nil {
+		return fmt.Errorf("failed to read CSV header: %w", err)
+	}
+	fields := make([]string, len(header))
+	for i, name := range header {
+		field, ok := importColumns[strings.ToLower(strings.TrimSpace(name))]
+		if !ok {
+			return fmt.Errorf("unknown CSV column %q", name)
+		}
+		fields[i] = field
+	}
+
+	for {
+		record, err := r.Read()
+		if err == io.EOF {
+			return nil
+		}
+		var parseErr *csv.ParseError
+		if errors.As(err, &parseErr) {
+			if err := fn(parseErr.Line, nil, err); err != nil {
+				return err
+			}
+			continue
+		}
+		if err != nil {
+			return err
+		}
+
+		line, _ := r.FieldPos(0)
+		if len(record) != len(fields) {
+			err = fmt.Errorf("expected %d fields, got %d", len(fields), len(record))
+			if err := fn(line, nil, err); err != nil {
+				return err
+			}
+			continue
+		}
+
+		student, err := studentFromRecord(fields, record)
+		if err := fn(line, student, err); err != nil {
+			return err
+		}
+	}
+}
+
+func studentFromRecord(fields, record []string) (*Student, error) {
+	student := &Student{}
+	for i, value := range record {
+		value = strings.TrimSpace(value)
+		switch fields[i] {
+		case "firstName":
+			student.FirstName = value
+		case "lastName":
+			student.LastName = value
+		case "email":
+			student.Email = value
+		case "major":
+			student.Major = value
+		case "year":
+			if value == "" {
+				continue
+			}
+			year, err := strconv.Atoi(value)
+			if err != nil {
+				return student, fmt.Errorf("year: %q is not a number", value)
+			}
+			student.Year = year
+		}
+	}
+	return student, nil
+}
+
+func readNDJSON(body io.Reader, fn func(int, *Student, error) error) error {
+	scanner := bufio.NewScanner(body)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+
+	line := 0
+	for scanner.Scan() {
+		line++
+		data := bytes.TrimSpace(scanner.Bytes())
+		if len(data) == 0 {
+			continue
+		}
+
+		student := &Student{}
+		err := json.Unmarshal(data, student)
+		// Only the fields of a new student are taken from the input
+		student.ID, student.Version, student.Password = 0, 0, ""
+		if err := fn(line, student, err); err != nil {
+			return err
+		}
+	}
+	return scanner.Err()
+}
+
+// fieldErrors turns a decoding or validation error into messages naming the
+// offending JSON fields
+func fieldErrors(err error) []string {
+	var invalid validator.ValidationErrors
+	if !errors.As(err, &invalid) {
+		return []string{err.Error()}
+	}
+
+	messages := make([]string, len(invalid))
+	for i, fe := range invalid {
+		messages[i] = fmt.Sprintf("%s: failed %s", lowerFirst(fe.Field()), fe.Tag())
+	}
+	return messages
+}
+
+func lowerFirst(s string) string {
+	if s == "" {
+		return s
+	}
+	r := []rune(s)
+	r[0] = unicode.ToLower(r[0])
+	return string(r)
+}
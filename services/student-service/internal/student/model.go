@@ -1,6 +1,10 @@
 package student
 
-import "github.com/uptrace/bun"
+import (
+	"time"
+
+	"github.com/uptrace/bun"
+)
 
 type Student struct {
 	bun.BaseModel `bun:"table:students,alias:s"`
@@ -12,4 +16,26 @@ type Student struct {
 	Password  string `bun:"password,notnull" json:"-"` // Never expose password in JSON
 	Major     string `bun:"major" json:"major"`
 	Year      int    `bun:"year" json:"year" validate:"min=0,max=10"`
+
+	// Version increments on every update and is served as the ETag
+	Version int `bun:"version,notnull,default:1" json:"version"`
+
+	// DeletedAt is set when the student is soft deleted; bun excludes such rows
+	// from queries unless WhereDeleted or WhereAllWithDeleted is used
+	DeletedAt time.Time `bun:"deleted_at,soft_delete,nullzero" json:"-"`
 }
+
+// SearchResult is a student matching a full-text search, with its rank and
+// the matching fields highlighted as HTML
+type SearchResult struct {
+	Student `bun:",extend"`
+
+	Rank           float64 `bun:"rank,scanonly"`
+	NameHighlight  string  `bun:"name_highlight,scanonly"`
+	EmailHighlight string  `bun:"email_highlight,scanonly"`
+	MajorHighlight string  `bun:"major_highlight,scanonly"`
+}
+
+// UpdatableColumns are the student columns UpdateStudent may write. The
+// password is only changed through the auth flows.
+var UpdatableColumns = []string{"first_name", "last_name", "email", "major", "year"}
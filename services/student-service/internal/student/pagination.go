@@ -0,0 +1,131 @@
+package student
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"strings"
+)
+
+const (
+	DefaultPageSize = 50
+	MaxPageSize     = 200
+)
+
+// SortField is a column the students list can be ordered by
+type SortField string
+
+const (
+	SortByID       SortField = "id"
+	SortByLastName SortField = "lastName"
+	SortByYear     SortField = "year"
+)
+
+func (f SortField) column() string {
+	switch f {
+	case SortByLastName:
+		return "last_name"
+	case SortByYear:
+		return "year"
+	}
+	return "id"
+}
+
+// ListFilter narrows the students list. Zero values mean "no filter".
+type ListFilter struct {
+	Major  string
+	Year   *int
+	Prefix string // matched case-insensitively against the start of email, first name and last name
+}
+
+// ListOptions is the caller-facing request for a page of students
+type ListOptions struct {
+	ListFilter
+	Limit        int
+	Cursor       string
+	Sort         string // "id", "lastName" or "year", prefixed with "-" for descending order
+	IncludeTotal bool
+}
+
+// ListQuery is the decoded form of ListOptions handed to the repository
+type ListQuery struct {
+	ListFilter
+	Sort  SortField
+	Desc  bool
+	After *Cursor
+	Limit int
+}
+
+// ListResult is one page of students
+type ListResult struct {
+	Items      []Student `json:"items"`
+	NextCursor string    `json:"nextCursor,omitempty"`
+	Total      *int      `json:"total,omitempty"`
+}
+
+// Cursor identifies the last row of a page. It carries the sort key so keyset
+// pagination stays stable when rows share the same sort value.
+type Cursor struct {
+	Sort     SortField `json:"s"`
+	Desc     bool      `json:"d,omitempty"`
+	ID       int       `json:"id"`
+	LastName string    `json:"ln,omitempty"`
+	Year     int       `json:"y,omitempty"`
+}
+
+func newCursor(sort SortField, desc bool, s *Student) *Cursor {
+	c := &Cursor{Sort: sort, Desc: desc, ID: s.ID}
+	switch sort {
+	case SortByLastName:
+		c.LastName = s.LastName
+	case SortByYear:
+		c.Year = s.Year
+	}
+	return c
+}
+
+func (c *Cursor) value() interface{} {
+	switch c.Sort {
+	case SortByLastName:
+		return c.LastName
+	case SortByYear:
+		return c.Year
+	}
+	return c.ID
+}
+
+// Encode returns the opaque string form of the cursor
+func (c *Cursor) Encode() string {
+	data, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// DecodeCursor parses a cursor produced by Encode
+func DecodeCursor(s string) (*Cursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+	var c Cursor
+	if err := json.Unmarshal(data, &c); err != nil || c.ID <= 0 {
+		return nil, ErrInvalidCursor
+	}
+	return &c, nil
+}
+
+// parseSort splits a sort expression such as "-lastName" into field and direction
+func parseSort(s string) (SortField, bool, error) {
+	desc := strings.HasPrefix(s, "-")
+	field := SortField(strings.TrimPrefix(s, "-"))
+	switch field {
+	case "":
+		return SortByID, desc, nil
+	case SortByID, SortByLastName, SortByYear:
+		return field, desc, nil
+	}
+	return "", false, ErrInvalidInput
+}
+
+// escapeLike escapes the LIKE wildcards in a user supplied prefix
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(s)
+}
@@ -0,0 +1,99 @@
+package student
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// MergePatchContentType is the media type of an RFC 7396 JSON merge patch
+const MergePatchContentType = "application/merge-patch+json"
+
+// patchableFields are the JSON names of the fields a merge patch may change.
+// Anything else, notably id, password and version, is rejected.
+var patchableFields = map[string]bool{
+	"firstName": true,
+	"lastName":  true,
+	"email":     true,
+	"major":     true,
+	"year":      true,
+}
+
+// applyPatch applies the merge patch to a copy of student and returns the
+// result together with the columns whose value changed. Fields the patch sets
+// to null are reset to their zero value; validation is left to the caller.
+func applyPatch(student *Student, patch []byte) (*Student, []string, error) {
+	var ops map[string]interface{}
+	if err := json.Unmarshal(patch, &ops); err != nil || ops == nil {
+		return nil, nil, fmt.Errorf("%w: patch must be a JSON object", ErrInvalidInput)
+	}
+	for field := range ops {
+		if !patchableFields[field] {
+			return nil, nil, fmt.Errorf("%w: field %q cannot be patched", ErrInvalidInput, field)
+		}
+	}
+
+	current, err := json.Marshal(student)
+	if err != nil {
+		return nil, nil, err
+	}
+	var doc map[string]interface{}
+	if err := json.Unmarshal(current, &doc); err != nil {
+		return nil, nil, err
+	}
+
+	merged, err := json.Marshal(mergePatch(doc, ops))
+	if err != nil {
+		return nil, nil, err
+	}
+	// Decode into a fresh value so fields removed by the patch end up zero
+	patched := &Student{}
+	if err := json.Unmarshal(merged, patched); err != nil {
+		return nil, nil, fmt.Errorf("%w: %v", ErrInvalidInput, err)
+	}
+	patched.ID = student.ID
+	patched.Version = student.Version
+
+	return patched, changedColumns(student, patched), nil
+}
+
+// mergePatch implements the MergePatch algorithm of RFC 7396 section 2
+func mergePatch(target, patch interface{}) interface{} {
+	ops, ok := patch.(map[string]interface{})
+	if !ok {
+		return patch
+	}
+
+	doc, ok := target.(map[string]interface{})
+	if !ok {
+		doc = map[string]interface{}{}
+	}
+	for name, value := range ops {
+		if value == nil {
+			delete(doc, name)
+			continue
+		}
+		doc[name] = mergePatch(doc[name], value)
+	}
+	return doc
+}
+
+// changedColumns lists the UpdatableColumns that differ between before and after
+func changedColumns(before, after *Student) []string {
+	var columns []string
+	if before.FirstName != after.FirstName {
+		columns = append(columns, "first_name")
+	}
+	if before.LastName != after.LastName {
+		columns = append(columns, "last_name")
+	}
+	if before.Email != after.Email {
+		columns = append(columns, "email")
+	}
+	if before.Major != after.Major {
+		columns = append(columns, "major")
+	}
+	if before.Year != after.Year {
+		columns = append(columns, "year")
+	}
+	return columns
+}
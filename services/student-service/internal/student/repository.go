@@ -3,56 +3,245 @@ package student
 import (
 	"context"
 	"database/sql"
+	"errors"
+	"strings"
 	"time"
 
+	"grud/common/history"
 	"grud/common/metrics"
+	"grud/common/search"
 
 	"github.com/uptrace/bun"
+	"github.com/uptrace/bun/driver/pgdriver"
 )
 
 type Repository interface {
+	// Create returns ErrEmailTaken if a student, possibly soft deleted, has the email
 	Create(ctx context.Context, student *Student) (*Student, error)
-	GetAll(ctx context.Context) ([]Student, error)
+	List(ctx context.Context, q ListQuery) ([]Student, error)
+	Count(ctx context.Context, f ListFilter) (int, error)
+	// Export calls fn for every student matching q, in q's order, reading them
+	// from a server-side cursor in batches. q.After and q.Limit are ignored.
+	Export(ctx context.Context, q ListQuery, fn func(*Student) error) error
+	// Search returns up to limit students matching the tsquery, best match first
+	Search(ctx context.Context, tsquery string, limit int) ([]SearchResult, error)
 	GetByID(ctx context.Context, id int) (*Student, error)
 	GetByEmail(ctx context.Context, email string) (*Student, error)
-	Update(ctx context.Context, student *Student) error
+	// EmailTaken reports whether any student, including soft deleted ones, uses email
+	EmailTaken(ctx context.Context, email string) (bool, error)
+	// TakenEmails returns the subset of emails used by any student, including soft deleted ones
+	TakenEmails(ctx context.Context, emails []string) (map[string]bool, error)
+	// CreateBatch inserts students in one transaction, batchSize rows per
+	// statement. It inserts none and returns ErrEmailTaken if any email is taken.
+	CreateBatch(ctx context.Context, students []*Student, batchSize int) error
+	// SetPassword replaces the student's password hash
+	SetPassword(ctx context.Context, id int, password string) error
+	// Update writes the given columns of student, or all UpdatableColumns when
+	// none are given, if it is still at student.Version, or unconditionally when
+	// Version is 0, and sets Version to the new version
+	Update(ctx context.Context, student *Student, columns ...string) error
+	// Delete soft deletes the student; Restore undoes it until Purge removes the row
 	Delete(ctx context.Context, id int) error
+	Restore(ctx context.Context, id int) (*Student, error)
+	// Purge hard deletes students soft deleted before the given time
+	Purge(ctx context.Context, deletedBefore time.Time) (int, error)
+	// History returns a page of the student's change history, newest first
+	History(ctx context.Context, id int, pageSize int, pageToken string) (*history.Page, error)
 }
 
+// entityType identifies students in the history table
+const entityType = "student"
+
+// ignoredHistoryFields change on every update and are left out of history diffs
+var ignoredHistoryFields = []string{"version"}
+
 type repository struct {
 	db      *bun.DB
 	metrics *metrics.Metrics
+	history history.Recorder
 }
 
 func NewRepository(db *bun.DB, m *metrics.Metrics) Repository {
 	return &repository{
 		db:      db,
 		metrics: m,
+		history: history.Recorder{EntityType: entityType, Ignore: ignoredHistoryFields, Metrics: m},
 	}
 }
 
 func (r *repository) Create(ctx context.Context, student *Student) (*Student, error) {
-	start := time.Now()
-	_, err := r.db.NewInsert().Model(student).Returning("*").Exec(ctx)
+	err := r.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		start := time.Now()
+		_, err := tx.NewInsert().Model(student).Returning("*").Exec(ctx)
 
-	r.metrics.Database.RecordQuery(ctx, "insert", "students", time.Since(start), err)
+		r.metrics.Database.RecordQuery(ctx, "insert", "students", time.Since(start), err)
 
+		if isUniqueViolation(err) {
+			return ErrEmailTaken
+		}
+		if err != nil {
+			return err
+		}
+		return r.history.Record(ctx, tx, history.ActionCreate, student.ID, nil, student)
+	})
 	if err != nil {
 		return nil, err
 	}
 	return student, nil
 }
 
-func (r *repository) GetAll(ctx context.Context) ([]Student, error) {
+func (r *repository) CreateBatch(ctx context.Context, students []*Student, batchSize int) error {
+	return r.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		for len(students) > 0 {
+			batch := students[:min(batchSize, len(students))]
+			students = students[len(batch):]
+
+			start := time.Now()
+			_, err := tx.NewInsert().Model(&batch).Returning("*").Exec(ctx)
+
+			r.metrics.Database.RecordQuery(ctx, "insert", "students", time.Since(start), err)
+
+			if isUniqueViolation(err) {
+				return ErrEmailTaken
+			}
+			if err != nil {
+				return err
+			}
+			for _, student := range batch {
+				if err := r.history.Record(ctx, tx, history.ActionCreate, student.ID, nil, student); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	})
+}
+
+// List returns up to q.Limit students after the cursor using keyset pagination,
+// ordered by the sort column with id as tie-breaker.
+func (r *repository) List(ctx context.Context, q ListQuery) ([]Student, error) {
 	start := time.Now()
-	var students []Student
-	err := r.db.NewSelect().Model(&students).Scan(ctx)
+	students := make([]Student, 0, q.Limit)
+
+	column := bun.Ident(q.Sort.column())
+	op, dir := bun.Safe(">"), bun.Safe("ASC")
+	if q.Desc {
+		op, dir = bun.Safe("<"), bun.Safe("DESC")
+	}
+
+	query := r.db.NewSelect().Model(&students)
+	applyFilter(query, q.ListFilter)
+	if q.After != nil {
+		if q.Sort == SortByID {
+			query.Where("id ? ?", op, q.After.ID)
+		} else {
+			query.Where("(?, id) ? (?, ?)", column, op, q.After.value(), q.After.ID)
+		}
+	}
+	query.OrderExpr("? ?", column, dir)
+	if q.Sort != SortByID {
+		query.OrderExpr("id ?", dir)
+	}
+	err := query.Limit(q.Limit).Scan(ctx)
 
 	r.metrics.Database.RecordQuery(ctx, "select", "students", time.Since(start), err)
 
 	return students, err
 }
 
+func (r *repository) Search(ctx context.Context, tsquery string, limit int) ([]SearchResult, error) {
+	start := time.Now()
+	results := make([]SearchResult, 0, limit)
+	err := r.db.NewSelect().
+		Model(&results).
+		ExcludeColumn("password").
+		ColumnExpr(search.Rank+" AS rank", tsquery).
+		ColumnExpr(search.Headline("s.first_name || ' ' || s.last_name", search.WholeField)+" AS name_highlight", tsquery).
+		ColumnExpr(search.Headline("s.email", search.WholeField)+" AS email_highlight", tsquery).
+		ColumnExpr(search.Headline("coalesce(s.major, '')", search.WholeField)+" AS major_highlight", tsquery).
+		Where(search.Match, tsquery).
+		OrderExpr("rank DESC, s.id ASC").
+		Limit(limit).
+		Scan(ctx)
+
+	r.metrics.Database.RecordQuery(ctx, "search", "students", time.Since(start), err)
+
+	return results, err
+}
+
+func (r *repository) Count(ctx context.Context, f ListFilter) (int, error) {
+	start := time.Now()
+	query := r.db.NewSelect().Model((*Student)(nil))
+	applyFilter(query, f)
+	count, err := query.Count(ctx)
+
+	r.metrics.Database.RecordQuery(ctx, "count", "students", time.Since(start), err)
+
+	return count, err
+}
+
+// exportBatchSize is the number of rows fetched from the export cursor at a time
+const exportBatchSize = 500
+
+func (r *repository) Export(ctx context.Context, q ListQuery, fn func(*Student) error) error {
+	return r.db.RunInTx(ctx, &sql.TxOptions{ReadOnly: true}, func(ctx context.Context, tx bun.Tx) error {
+		dir := bun.Safe("ASC")
+		if q.Desc {
+			dir = bun.Safe("DESC")
+		}
+		query := tx.NewSelect().Model((*Student)(nil)).ExcludeColumn("password")
+		applyFilter(query, q.ListFilter)
+		query.OrderExpr("? ?", bun.Ident(q.Sort.column()), dir)
+		if q.Sort != SortByID {
+			query.OrderExpr("id ?", dir)
+		}
+
+		// The cursor lives until the transaction ends
+		start := time.Now()
+		_, err := tx.ExecContext(ctx, "DECLARE student_export NO SCROLL CURSOR FOR "+query.String())
+		r.metrics.Database.RecordQuery(ctx, "declare", "students", time.Since(start), err)
+		if err != nil {
+			return err
+		}
+
+		for {
+			var students []Student
+			start := time.Now()
+			err := tx.NewRaw("FETCH FORWARD ? FROM student_export", exportBatchSize).Scan(ctx, &students)
+			r.metrics.Database.RecordQuery(ctx, "fetch", "students", time.Since(start), err)
+			if err != nil {
+				return err
+			}
+
+			for i := range students {
+				if err := fn(&students[i]); err != nil {
+					return err
+				}
+			}
+			if len(students) < exportBatchSize {
+				return nil
+			}
+		}
+	})
+}
+
+func applyFilter(query *bun.SelectQuery, f ListFilter) {
+	if f.Major != "" {
+		query.Where("major = ?", f.Major)
+	}
+	if f.Year != nil {
+		query.Where("year = ?", *f.Year)
+	}
+	if f.Prefix != "" {
+		pattern := escapeLike(strings.ToLower(f.Prefix)) + "%"
+		query.WhereGroup(" AND ", func(q *bun.SelectQuery) *bun.SelectQuery {
+			return q.Where("lower(email) LIKE ?", pattern).
+				WhereOr("lower(last_name) LIKE ?", pattern).
+				WhereOr("lower(first_name) LIKE ?", pattern)
+		})
+	}
+}
+
 func (r *repository) GetByID(ctx context.Context, id int) (*Student, error) {
 	start := time.Now()
 	student := new(Student)
@@ -69,40 +258,176 @@ func (r *repository) GetByID(ctx context.Context, id int) (*Student, error) {
 	return student, nil
 }
 
-func (r *repository) Update(ctx context.Context, student *Student) error {
+func (r *repository) Update(ctx context.Context, student *Student, columns ...string) error {
+	if len(columns) == 0 {
+		columns = UpdatableColumns
+	}
+	columns = append(columns[:len(columns):len(columns)], "version")
+
+	return r.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		before, err := r.lockForUpdate(ctx, tx, student.ID)
+		if err != nil {
+			return err
+		}
+		// The row is locked, so the version cannot move between this check and the update
+		if student.Version != 0 && student.Version != before.Version {
+			return ErrVersionConflict
+		}
+		student.Version = before.Version + 1
+
+		start := time.Now()
+		_, err = tx.NewUpdate().Model(student).Column(columns...).WherePK().Exec(ctx)
+
+		r.metrics.Database.RecordQuery(ctx, "update", "students", time.Since(start), err)
+
+		if err != nil {
+			return err
+		}
+
+		after := *before
+		for _, column := range columns {
+			switch column {
+			case "first_name":
+				after.FirstName = student.FirstName
+			case "last_name":
+				after.LastName = student.LastName
+			case "email":
+				after.Email = student.Email
+			case "major":
+				after.Major = student.Major
+			case "year":
+				after.Year = student.Year
+			}
+		}
+		return r.history.Record(ctx, tx, history.ActionUpdate, student.ID, before, &after)
+	})
+}
+
+func (r *repository) Delete(ctx context.Context, id int) error {
+	return r.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		before, err := r.lockForUpdate(ctx, tx, id)
+		if err != nil {
+			return err
+		}
+
+		start := time.Now()
+		_, err = tx.NewDelete().Model(&Student{ID: id}).WherePK().Exec(ctx)
+
+		r.metrics.Database.RecordQuery(ctx, "delete", "students", time.Since(start), err)
+
+		if err != nil {
+			return err
+		}
+		return r.history.Record(ctx, tx, history.ActionDelete, id, before, nil)
+	})
+}
+
+// Restore clears deleted_at on a soft deleted student. A student that does not
+// exist or is not deleted yields ErrStudentNotFound.
+func (r *repository) Restore(ctx context.Context, id int) (*Student, error) {
+	student := new(Student)
+	err := r.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		start := time.Now()
+		result, err := tx.NewUpdate().
+			Model(student).
+			Set("deleted_at = NULL").
+			Where("id = ?", id).
+			WhereDeleted().
+			Returning("*").
+			Exec(ctx)
+
+		r.metrics.Database.RecordQuery(ctx, "update", "students", time.Since(start), err)
+
+		if err != nil {
+			return err
+		}
+		rowsAffected, err := result.RowsAffected()
+		if err != nil {
+			return err
+		}
+		if rowsAffected == 0 {
+			return ErrStudentNotFound
+		}
+		return r.history.Record(ctx, tx, history.ActionRestore, id, nil, student)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return student, nil
+}
+
+func (r *repository) Purge(ctx context.Context, deletedBefore time.Time) (int, error) {
 	start := time.Now()
-	result, err := r.db.NewUpdate().Model(student).WherePK().Exec(ctx)
+	result, err := r.db.NewDelete().
+		Model((*Student)(nil)).
+		WhereDeleted().
+		Where("deleted_at < ?", deletedBefore).
+		ForceDelete().
+		Exec(ctx)
 
-	r.metrics.Database.RecordQuery(ctx, "update", "students", time.Since(start), err)
+	r.metrics.Database.RecordQuery(ctx, "purge", "students", time.Since(start), err)
 
 	if err != nil {
-		return err
+		return 0, err
 	}
 	rowsAffected, err := result.RowsAffected()
+	return int(rowsAffected), err
+}
+
+func (r *repository) EmailTaken(ctx context.Context, email string) (bool, error) {
+	start := time.Now()
+	exists, err := r.db.NewSelect().
+		Model((*Student)(nil)).
+		WhereAllWithDeleted().
+		Where("email = ?", email).
+		Exists(ctx)
+
+	r.metrics.Database.RecordQuery(ctx, "select", "students", time.Since(start), err)
+
+	return exists, err
+}
+
+func (r *repository) TakenEmails(ctx context.Context, emails []string) (map[string]bool, error) {
+	taken := make(map[string]bool)
+	if len(emails) == 0 {
+		return taken, nil
+	}
+
+	start := time.Now()
+	var found []string
+	err := r.db.NewSelect().
+		Model((*Student)(nil)).
+		Column("email").
+		WhereAllWithDeleted().
+		Where("email IN (?)", bun.In(emails)).
+		Scan(ctx, &found)
+
+	r.metrics.Database.RecordQuery(ctx, "select", "students", time.Since(start), err)
+
 	if err != nil {
-		return err
+		return nil, err
 	}
-	if rowsAffected == 0 {
-		return ErrStudentNotFound
+	for _, email := range found {
+		taken[email] = true
 	}
-	return nil
+	return taken, nil
 }
 
-func (r *repository) Delete(ctx context.Context, id int) error {
+// SetPassword is not recorded in the history, which never contains passwords
+func (r *repository) SetPassword(ctx context.Context, id int, password string) error {
 	start := time.Now()
-	student := &Student{ID: id}
-	result, err := r.db.NewDelete().Model(student).WherePK().Exec(ctx)
+	result, err := r.db.NewUpdate().
+		Model((*Student)(nil)).
+		Set("password = ?", password).
+		Where("id = ?", id).
+		Exec(ctx)
 
-	r.metrics.Database.RecordQuery(ctx, "delete", "students", time.Since(start), err)
+	r.metrics.Database.RecordQuery(ctx, "update", "students", time.Since(start), err)
 
 	if err != nil {
 		return err
 	}
-	rowsAffected, err := result.RowsAffected()
-	if err != nil {
-		return err
-	}
-	if rowsAffected == 0 {
+	if rows, err := result.RowsAffected(); err == nil && rows == 0 {
 		return ErrStudentNotFound
 	}
 	return nil
@@ -126,3 +451,27 @@ func (r *repository) GetByEmail(ctx context.Context, email string) (*Student, er
 	}
 	return student, nil
 }
+
+func (r *repository) History(ctx context.Context, id int, pageSize int, pageToken string) (*history.Page, error) {
+	return r.history.List(ctx, r.db, id, pageSize, pageToken)
+}
+
+// lockForUpdate loads the student and locks its row until tx ends, so the
+// history entry sees the state the change was applied to
+func (r *repository) lockForUpdate(ctx context.Context, tx bun.Tx, id int) (*Student, error) {
+	student, err := history.Lock[Student](ctx, tx, r.metrics, id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrStudentNotFound
+		}
+		return nil, err
+	}
+	return student, nil
+}
+
+// isUniqueViolation reports whether err is a PostgreSQL unique_violation
+// (23505); on students that is the email
+func isUniqueViolation(err error) bool {
+	var pgErr pgdriver.Error
+	return errors.As(err, &pgErr) && pgErr.Field('C') == "23505"
+}
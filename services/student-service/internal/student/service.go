@@ -3,37 +3,240 @@ package student
 import (
 	"context"
 	"errors"
+	"log/slog"
+	"slices"
+	"time"
+
+	"grud/common/history"
+	"grud/common/search"
 )
 
 var (
 	ErrStudentNotFound = errors.New("student not found")
 	ErrInvalidInput    = errors.New("invalid input")
+	ErrInvalidCursor   = errors.New("invalid cursor")
+	ErrVersionConflict = errors.New("student was modified concurrently")
+	ErrEmailTaken      = errors.New("email is already taken")
 )
 
 type Service interface {
 	CreateStudent(ctx context.Context, student *Student) (*Student, error)
-	GetAllStudents(ctx context.Context) ([]Student, error)
+	ListStudents(ctx context.Context, opts ListOptions) (*ListResult, error)
+	// ExportStudents calls fn for every student matching the filters and sort of
+	// opts, without loading them all at once. Paging options are ignored.
+	ExportStudents(ctx context.Context, opts ListOptions, fn func(*Student) error) error
+	// SearchStudents returns up to limit students matching the full-text query,
+	// best match first. Every word of query must match a word prefix.
+	SearchStudents(ctx context.Context, query string, limit int) ([]SearchResult, error)
 	GetStudentByID(ctx context.Context, id int) (*Student, error)
-	UpdateStudent(ctx context.Context, student *Student) error
+	// UpdateStudent writes the given UpdatableColumns of student, or all of them when none are given
+	UpdateStudent(ctx context.Context, student *Student, columns ...string) error
 	DeleteStudent(ctx context.Context, id int) error
+	RestoreStudent(ctx context.Context, id int) (*Student, error)
+	// GetStudentHistory returns a page of the student's change history, newest
+	// first. The history outlives soft deletion.
+	GetStudentHistory(ctx context.Context, id int, limit int, cursor string) (*history.Page, error)
+	// PurgeDeleted hard deletes students soft deleted more than retention ago
+	PurgeDeleted(ctx context.Context, retention time.Duration) (int, error)
+	// ImportStudents creates students in batches within one transaction and
+	// reports, per student, whether it was skipped because its email is taken
+	// or repeated. With dryRun nothing is written.
+	ImportStudents(ctx context.Context, students []*Student, dryRun bool) ([]bool, error)
+}
+
+// TokenRevoker invalidates a student's sessions. It is implemented by
+// auth.Repository; the interface lives here because auth imports student.
+type TokenRevoker interface {
+	DeleteAllStudentTokens(ctx context.Context, studentID int) error
+}
+
+// Inviter starts the password setup flow for a student created without a
+// password. It is implemented by auth.Service.
+type Inviter interface {
+	InvitePasswordSetup(ctx context.Context, student *Student) error
 }
 
+// ImportBatchSize is the number of students inserted per statement by ImportStudents
+const ImportBatchSize = 500
+
+// importAttempts bounds how often ImportStudents sorts out the taken emails
+// again after a concurrent create took one of them
+const importAttempts = 3
+
 type service struct {
-	repo Repository
+	repo    Repository
+	tokens  TokenRevoker
+	invites Inviter
+	logger  *slog.Logger
 }
 
-func NewService(repo Repository) Service {
+// NewService creates the student service. Deleting a student revokes their
+// refresh tokens through tokens, and students created here are sent a
+// password setup invitation through invites; either may be nil.
+func NewService(repo Repository, tokens TokenRevoker, invites Inviter, logger *slog.Logger) Service {
 	return &service{
-		repo: repo,
+		repo:    repo,
+		tokens:  tokens,
+		invites: invites,
+		logger:  logger,
 	}
 }
 
+// CreateStudent creates a student without a password; the student chooses one
+// through the password setup invitation. The student is created even if the
+// invitation fails, which is only logged.
 func (s *service) CreateStudent(ctx context.Context, student *Student) (*Student, error) {
-	return s.repo.Create(ctx, student)
+	student.Password = ""
+	created, err := s.repo.Create(ctx, student)
+	if err != nil {
+		return nil, err
+	}
+	s.invite(ctx, created)
+	return created, nil
+}
+
+func (s *service) ImportStudents(ctx context.Context, students []*Student, dryRun bool) ([]bool, error) {
+	for attempt := 1; ; attempt++ {
+		skipped, fresh, err := s.sortImport(ctx, students)
+		if err != nil {
+			return nil, err
+		}
+		if dryRun || len(fresh) == 0 {
+			return skipped, nil
+		}
+
+		err = s.repo.CreateBatch(ctx, fresh, ImportBatchSize)
+		if errors.Is(err, ErrEmailTaken) && attempt < importAttempts {
+			// The transaction was rolled back, so forget the IDs it handed out
+			for _, student := range fresh {
+				student.ID, student.Version = 0, 0
+			}
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		for _, student := range fresh {
+			s.invite(ctx, student)
+		}
+		return skipped, nil
+	}
+}
+
+// sortImport reports which students to skip because their email is taken or
+// repeated, and returns the others
+func (s *service) sortImport(ctx context.Context, students []*Student) ([]bool, []*Student, error) {
+	skipped := make([]bool, len(students))
+	fresh := make([]*Student, 0, len(students))
+	seen := make(map[string]bool, len(students))
+
+	for i := 0; i < len(students); i += ImportBatchSize {
+		batch := students[i:min(i+ImportBatchSize, len(students))]
+		emails := make([]string, len(batch))
+		for j, student := range batch {
+			emails[j] = student.Email
+		}
+
+		taken, err := s.repo.TakenEmails(ctx, emails)
+		if err != nil {
+			return nil, nil, err
+		}
+		for j, student := range batch {
+			// Later rows repeating an email from an earlier row are duplicates too
+			if taken[student.Email] || seen[student.Email] {
+				skipped[i+j] = true
+				continue
+			}
+			seen[student.Email] = true
+			student.Password = ""
+			fresh = append(fresh, student)
+		}
+	}
+	return skipped, fresh, nil
+}
+
+// invite sends the student a password setup invitation. The student is
+// already committed, so a failure is logged rather than failing the request,
+// which a retry would only turn into a taken email.
+func (s *service) invite(ctx context.Context, student *Student) {
+	if s.invites == nil {
+		return
+	}
+	if err := s.invites.InvitePasswordSetup(ctx, student); err != nil {
+		s.logger.ErrorContext(ctx, "failed to send password setup invitation", "error", err, "student_id", student.ID)
+	}
+}
+
+func (s *service) ListStudents(ctx context.Context, opts ListOptions) (*ListResult, error) {
+	if opts.Limit < 0 || opts.Limit > MaxPageSize {
+		return nil, ErrInvalidInput
+	}
+	if opts.Limit == 0 {
+		opts.Limit = DefaultPageSize
+	}
+
+	sort, desc, err := parseSort(opts.Sort)
+	if err != nil {
+		return nil, err
+	}
+
+	q := ListQuery{
+		ListFilter: opts.ListFilter,
+		Sort:       sort,
+		Desc:       desc,
+		Limit:      opts.Limit + 1, // one extra row tells us whether there is a next page
+	}
+	if opts.Cursor != "" {
+		cursor, err := DecodeCursor(opts.Cursor)
+		if err != nil {
+			return nil, err
+		}
+		// A cursor is only meaningful for the ordering it was issued for
+		if cursor.Sort != sort || cursor.Desc != desc {
+			return nil, ErrInvalidCursor
+		}
+		q.After = cursor
+	}
+
+	students, err := s.repo.List(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &ListResult{Items: students}
+	if len(students) > opts.Limit {
+		result.Items = students[:opts.Limit]
+		result.NextCursor = newCursor(sort, desc, &result.Items[opts.Limit-1]).Encode()
+	}
+
+	if opts.IncludeTotal {
+		total, err := s.repo.Count(ctx, opts.ListFilter)
+		if err != nil {
+			return nil, err
+		}
+		result.Total = &total
+	}
+
+	return result, nil
+}
+
+func (s *service) ExportStudents(ctx context.Context, opts ListOptions, fn func(*Student) error) error {
+	sort, desc, err := parseSort(opts.Sort)
+	if err != nil {
+		return err
+	}
+	return s.repo.Export(ctx, ListQuery{ListFilter: opts.ListFilter, Sort: sort, Desc: desc}, fn)
 }
 
-func (s *service) GetAllStudents(ctx context.Context) ([]Student, error) {
-	return s.repo.GetAll(ctx)
+func (s *service) SearchStudents(ctx context.Context, query string, limit int) ([]SearchResult, error) {
+	tsquery := search.PrefixQuery(query)
+	if tsquery == "" || limit < 0 {
+		return nil, ErrInvalidInput
+	}
+	if limit == 0 {
+		limit = search.DefaultLimit
+	}
+	return s.repo.Search(ctx, tsquery, min(limit, search.MaxLimit))
 }
 
 func (s *service) GetStudentByID(ctx context.Context, id int) (*Student, error) {
@@ -43,16 +246,64 @@ func (s *service) GetStudentByID(ctx context.Context, id int) (*Student, error)
 	return s.repo.GetByID(ctx, id)
 }
 
-func (s *service) UpdateStudent(ctx context.Context, student *Student) error {
+func (s *service) UpdateStudent(ctx context.Context, student *Student, columns ...string) error {
 	if student.ID <= 0 {
 		return ErrInvalidInput
 	}
-	return s.repo.Update(ctx, student)
+	for _, column := range columns {
+		if !slices.Contains(UpdatableColumns, column) {
+			return ErrInvalidInput
+		}
+	}
+	return s.repo.Update(ctx, student, columns...)
 }
 
 func (s *service) DeleteStudent(ctx context.Context, id int) error {
 	if id <= 0 {
 		return ErrInvalidInput
 	}
-	return s.repo.Delete(ctx, id)
+	if err := s.repo.Delete(ctx, id); err != nil {
+		return err
+	}
+
+	// A deleted student can no longer refresh, but revoke eagerly so the
+	// tokens are gone if the student is later restored
+	if s.tokens != nil {
+		return s.tokens.DeleteAllStudentTokens(ctx, id)
+	}
+	return nil
+}
+
+func (s *service) RestoreStudent(ctx context.Context, id int) (*Student, error) {
+	if id <= 0 {
+		return nil, ErrInvalidInput
+	}
+	return s.repo.Restore(ctx, id)
+}
+
+func (s *service) GetStudentHistory(ctx context.Context, id int, limit int, cursor string) (*history.Page, error) {
+	if id <= 0 || limit < 0 || limit > history.MaxPageSize {
+		return nil, ErrInvalidInput
+	}
+
+	page, err := s.repo.History(ctx, id, limit, cursor)
+	if err != nil {
+		return nil, err
+	}
+
+	// Students created before history was recorded may have none, so an
+	// empty first page is only an error when the student does not exist
+	if len(page.Entries) == 0 && cursor == "" {
+		if _, err := s.repo.GetByID(ctx, id); err != nil {
+			return nil, err
+		}
+	}
+	return page, nil
+}
+
+func (s *service) PurgeDeleted(ctx context.Context, retention time.Duration) (int, error) {
+	if retention < 0 {
+		return 0, ErrInvalidInput
+	}
+	return s.repo.Purge(ctx, time.Now().Add(-retention))
 }
@@ -0,0 +1,161 @@
+// Package xlsx writes single-sheet Office Open XML workbooks row by row, so
+// that large exports never have to be held in memory.
+package xlsx
+
+import (
+	"archive/zip"
+	"bufio"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ContentType is the media type of the workbooks written by Writer
+const ContentType = "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+
+// The parts every workbook needs besides the sheet itself. Cells use inline
+// strings, so there is no shared string table to build up front.
+var staticParts = []struct{ name, body string }{
+	{"[Content_Types].xml", `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types"><Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/><Default Extension="xml" ContentType="application/xml"/><Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/><Override PartName="/xl/worksheets/sheet1.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/><Override PartName="/xl/styles.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.styles+xml"/></Types>`},
+	{"_rels/.rels", `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships"><Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/></Relationships>`},
+	{"xl/_rels/workbook.xml.rels", `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships"><Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet1.xml"/><Relationship Id="rId2" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/styles" Target="styles.xml"/></Relationships>`},
+	// Style 1 formats date cells as ISO timestamps
+	{"xl/styles.xml", `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<styleSheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><numFmts count="1"><numFmt numFmtId="164" formatCode="yyyy-mm-dd hh:mm:ss"/></numFmts><fonts count="1"><font/></fonts><fills count="1"><fill/></fills><borders count="1"><border/></borders><cellStyleXfs count="1"><xf/></cellStyleXfs><cellXfs count="2"><xf/><xf numFmtId="164" applyNumberFormat="1"/></cellXfs></styleSheet>`},
+}
+
+const sheetHeader = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData>`
+
+const sheetFooter = `</sheetData></worksheet>`
+
+// excelEpoch is day zero of the 1900 date system, adjusted for its fictitious 29 February 1900
+var excelEpoch = time.Date(1899, 12, 30, 0, 0, 0, 0, time.UTC)
+
+// Writer streams one worksheet into a workbook
+type Writer struct {
+	zip   *zip.Writer
+	sheet *bufio.Writer
+	rows  int
+	err   error
+}
+
+// NewWriter starts a workbook with a single sheet named sheetName on w
+func NewWriter(w io.Writer, sheetName string) (*Writer, error) {
+	zw := zip.NewWriter(w)
+	for _, part := range staticParts {
+		if err := writePart(zw, part.name, part.body); err != nil {
+			return nil, err
+		}
+	}
+
+	workbook := `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships"><sheets><sheet name="` + escape(sheetName) + `" sheetId="1" r:id="rId1"/></sheets></workbook>`
+	if err := writePart(zw, "xl/workbook.xml", workbook); err != nil {
+		return nil, err
+	}
+
+	// The sheet is the last part, so it can stay open while rows are added
+	sheet, err := zw.Create("xl/worksheets/sheet1.xml")
+	if err != nil {
+		return nil, err
+	}
+	bw := bufio.NewWriter(sheet)
+	if _, err := bw.WriteString(sheetHeader); err != nil {
+		return nil, err
+	}
+	return &Writer{zip: zw, sheet: bw}, nil
+}
+
+// WriteRow appends a row. Strings become text cells, integers and floats
+// number cells and time.Time date cells; a nil value leaves the cell empty.
+func (w *Writer) WriteRow(values ...interface{}) error {
+	if w.err != nil {
+		return w.err
+	}
+	w.rows++
+
+	fmt.Fprintf(w.sheet, `<row r="%d">`, w.rows)
+	for i, value := range values {
+		ref := columnName(i) + strconv.Itoa(w.rows)
+		switch v := value.(type) {
+		case nil:
+		case string:
+			fmt.Fprintf(w.sheet, `<c r="%s" t="inlineStr"><is><t xml:space="preserve">%s</t></is></c>`, ref, escape(v))
+		case int:
+			fmt.Fprintf(w.sheet, `<c r="%s"><v>%d</v></c>`, ref, v)
+		case int64:
+			fmt.Fprintf(w.sheet, `<c r="%s"><v>%d</v></c>`, ref, v)
+		case float64:
+			fmt.Fprintf(w.sheet, `<c r="%s"><v>%s</v></c>`, ref, strconv.FormatFloat(v, 'f', -1, 64))
+		case time.Time:
+			if v.IsZero() {
+				continue
+			}
+			days := v.UTC().Sub(excelEpoch).Hours() / 24
+			fmt.Fprintf(w.sheet, `<c r="%s" s="1"><v>%s</v></c>`, ref, strconv.FormatFloat(days, 'f', -1, 64))
+		default:
+			w.err = fmt.Errorf("xlsx: unsupported cell type %T", value)
+			return w.err
+		}
+	}
+	_, w.err = w.sheet.WriteString(`</row>`)
+	return w.err
+}
+
+// Flush writes buffered rows through to the underlying writer
+func (w *Writer) Flush() error {
+	if w.err != nil {
+		return w.err
+	}
+	if w.err = w.sheet.Flush(); w.err != nil {
+		return w.err
+	}
+	w.err = w.zip.Flush()
+	return w.err
+}
+
+// Close finishes the sheet and the workbook. It does not close the underlying writer.
+func (w *Writer) Close() error {
+	if w.err != nil {
+		return w.err
+	}
+	if _, err := w.sheet.WriteString(sheetFooter); err != nil {
+		return err
+	}
+	if err := w.sheet.Flush(); err != nil {
+		return err
+	}
+	return w.zip.Close()
+}
+
+func writePart(zw *zip.Writer, name, body string) error {
+	part, err := zw.Create(name)
+	if err != nil {
+		return err
+	}
+	_, err = io.WriteString(part, body)
+	return err
+}
+
+// columnName returns the spreadsheet column letters of the zero based index i
+func columnName(i int) string {
+	name := ""
+	for i++; i > 0; i = (i - 1) / 26 {
+		name = string(rune('A'+(i-1)%26)) + name
+	}
+	return name
+}
+
+// escape escapes s for use in XML character data and attribute values
+func escape(s string) string {
+	var b strings.Builder
+	_ = xml.EscapeText(&b, []byte(s))
+	return b.String()
+}
@@ -0,0 +1,57 @@
+package xlsx_test
+
+import (
+	"archive/zip"
+	"bytes"
+	"io"
+	"testing"
+	"time"
+
+	"student-service/internal/xlsx"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriter(t *testing.T) {
+	var buf bytes.Buffer
+	w, err := xlsx.NewWriter(&buf, "Students & co")
+	require.NoError(t, err)
+
+	require.NoError(t, w.WriteRow("id", "name", "createdAt"))
+	require.NoError(t, w.WriteRow(1, "Ada <Lovelace>", time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)))
+	require.NoError(t, w.WriteRow(2, nil, time.Time{}))
+	require.NoError(t, w.Flush())
+	require.NoError(t, w.Close())
+
+	zr, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	require.NoError(t, err)
+
+	parts := make(map[string]string)
+	for _, f := range zr.File {
+		rc, err := f.Open()
+		require.NoError(t, err)
+		data, err := io.ReadAll(rc)
+		require.NoError(t, err)
+		rc.Close()
+		parts[f.Name] = string(data)
+	}
+
+	require.Contains(t, parts, "[Content_Types].xml")
+	assert.Contains(t, parts["xl/workbook.xml"], `name="Students &amp; co"`)
+
+	sheet := parts["xl/worksheets/sheet1.xml"]
+	assert.Contains(t, sheet, `<c r="B1" t="inlineStr"><is><t xml:space="preserve">name</t></is></c>`)
+	assert.Contains(t, sheet, `<c r="A2"><v>1</v></c>`)
+	assert.Contains(t, sheet, `Ada &lt;Lovelace&gt;`)
+	assert.Contains(t, sheet, `<c r="C2" s="1"><v>45292.5</v></c>`)
+	assert.Contains(t, sheet, `<row r="3"><c r="A3"><v>2</v></c></row>`)
+	assert.Contains(t, sheet, `</sheetData></worksheet>`)
+}
+
+func TestWriter_UnsupportedType(t *testing.T) {
+	w, err := xlsx.NewWriter(io.Discard, "Sheet1")
+	require.NoError(t, err)
+	assert.Error(t, w.WriteRow(struct{}{}))
+	assert.Error(t, w.Close())
+}
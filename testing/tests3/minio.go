@@ -0,0 +1,95 @@
+package tests3
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+// Credentials of the MinIO root user; the container accepts S3 requests
+// signed with them
+const (
+	AccessKey = "minioadmin"
+	SecretKey = "minioadmin"
+	Region    = "us-east-1"
+)
+
+var (
+	sharedContainer *MinIOContainer
+	sharedOnce      sync.Once
+)
+
+// MinIOContainer is an S3-compatible server. Endpoint is its base URL; buckets
+// are addressed path-style.
+type MinIOContainer struct {
+	Container testcontainers.Container
+	Endpoint  string
+}
+
+// SetupSharedMinIO creates a single MinIO container shared across all tests.
+// It starts without buckets; tests create the ones they use.
+//
+// IMPORTANT: Tests using shared container CANNOT run in parallel!
+//
+// Usage:
+//
+//	func TestMyStore(t *testing.T) {
+//	    minio := tests3.SetupSharedMinIO(t)
+//	    defer minio.Cleanup(t)  // ← Only call once at top level
+//
+//	    t.Run("Test1", func(t *testing.T) {
+//	        // ... test using minio.Endpoint
+//	    })
+//	}
+func SetupSharedMinIO(t *testing.T) *MinIOContainer {
+	t.Helper()
+
+	sharedOnce.Do(func() {
+		ctx := context.Background()
+
+		req := testcontainers.ContainerRequest{
+			Image:        "minio/minio:latest",
+			Cmd:          []string{"server", "/data"},
+			ExposedPorts: []string{"9000/tcp"},
+			Env: map[string]string{
+				"MINIO_ROOT_USER":     AccessKey,
+				"MINIO_ROOT_PASSWORD": SecretKey,
+			},
+			WaitingFor: wait.ForHTTP("/minio/health/ready").WithPort("9000/tcp"),
+		}
+
+		minioContainer, err := testcontainers.GenericContainer(ctx, testcontainers.GenericContainerRequest{
+			ContainerRequest: req,
+			Started:          true,
+		})
+		require.NoError(t, err)
+
+		host, err := minioContainer.Host(ctx)
+		require.NoError(t, err)
+
+		port, err := minioContainer.MappedPort(ctx, "9000")
+		require.NoError(t, err)
+
+		sharedContainer = &MinIOContainer{
+			Container: minioContainer,
+			Endpoint:  "http://" + host + ":" + port.Port(),
+		}
+	})
+
+	return sharedContainer
+}
+
+func (mc *MinIOContainer) Cleanup(t *testing.T) {
+	t.Helper()
+	ctx := context.Background()
+
+	if mc.Container != nil {
+		if err := mc.Container.Terminate(ctx); err != nil {
+			t.Logf("failed to terminate container: %s", err)
+		}
+	}
+}